@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +20,14 @@ type BackupStorage struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	S3 *S3 `json:"s3,omitempty"`
+	// GCS defines the configuration to store backups in Google Cloud Storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty"`
+	// AzureBlob defines the configuration to store backups in Azure Blob Storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AzureBlob *AzureBlob `json:"azureBlob,omitempty"`
 	// PersistentVolumeClaim is a Kubernetes PVC specification.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -39,9 +50,167 @@ func (b *BackupStorage) Validate() error {
 	if storageTypes != 1 {
 		return errors.New("exactly one storage type should be provided")
 	}
+	if b.S3 != nil {
+		if err := b.S3.Validate(); err != nil {
+			return fmt.Errorf("invalid S3: %v", err)
+		}
+	}
 	return nil
 }
 
+// IsObjectStorage determines whether the backups are stored in an object storage, either S3, GCS or Azure Blob Storage.
+func (b *BackupStorage) IsObjectStorage() bool {
+	return b.S3 != nil || b.GCS != nil || b.AzureBlob != nil
+}
+
+// CompressAlgorithm defines the algorithm used to compress a Backup.
+type CompressAlgorithm string
+
+const (
+	// CompressGzip compresses the Backup with gzip, or pigz when available.
+	CompressGzip CompressAlgorithm = "gzip"
+	// CompressZstd compresses the Backup with zstd.
+	CompressZstd CompressAlgorithm = "zstd"
+	// CompressBzip2 compresses the Backup with bzip2, or pbzip2 when available.
+	CompressBzip2 CompressAlgorithm = "bzip2"
+)
+
+// MaxLevel returns the maximum compression level supported by the algorithm.
+func (c CompressAlgorithm) MaxLevel() int32 {
+	if c == CompressZstd {
+		return 19
+	}
+	return 9
+}
+
+// BackupCompression defines the compression stage of a Backup.
+type BackupCompression struct {
+	// Algorithm defines the algorithm used to compress the Backup. It defaults to gzip.
+	// The zstd and bzip2 binaries must be available in the MariaDB image when using the corresponding algorithm.
+	// +optional
+	// +kubebuilder:default=gzip
+	// +kubebuilder:validation:Enum=gzip;zstd;bzip2
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Algorithm CompressAlgorithm `json:"algorithm,omitempty"`
+	// Level defines the compression level, from 1 (fastest) to 9 (best compression). Up to 19 levels are supported by zstd.
+	// +optional
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=19
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Level int32 `json:"level,omitempty"`
+	// Threads defines the number of threads used to compress the backup. Multiple threads require pigz or pbzip2 to be available
+	// in the MariaDB image when using gzip or bzip2 respectively, otherwise a single thread will be used.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Threads int32 `json:"threads,omitempty"`
+	// Resouces describes the compute resource requirements of the compression stage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+func (c *BackupCompression) Validate() error {
+	algorithm := c.Algorithm
+	if algorithm == "" {
+		algorithm = CompressGzip
+	}
+	if c.Level > algorithm.MaxLevel() {
+		return fmt.Errorf("level %d exceeds the maximum level %d supported by %s", c.Level, algorithm.MaxLevel(), algorithm)
+	}
+	return nil
+}
+
+// BackupHook is an action executed by the Backup Job. Either SQL or Exec must be provided.
+type BackupHook struct {
+	// SQL is a statement executed against the MariaDB, using the same connection as the dump.
+	// Session scoped state, such as locks or session variables, is not kept after the statement is executed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SQL *string `json:"sql,omitempty"`
+	// Exec is a command executed in the container that takes the dump, which uses the MariaDB image.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Exec *corev1.ExecAction `json:"exec,omitempty"`
+}
+
+func (h *BackupHook) Validate() error {
+	if (h.SQL == nil) == (h.Exec == nil) {
+		return errors.New("either sql or exec must be provided")
+	}
+	if h.SQL != nil && strings.TrimSpace(*h.SQL) == "" {
+		return errors.New("sql must not be empty")
+	}
+	if h.Exec != nil && len(h.Exec.Command) == 0 {
+		return errors.New("exec command must not be empty")
+	}
+	return nil
+}
+
+// BackupHooks are actions executed by the Backup Job around the dump.
+type BackupHooks struct {
+	// BeforeBackup hooks are executed in order before taking the dump. The Backup Job fails if any of them fails, without taking the dump.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BeforeBackup []BackupHook `json:"beforeBackup,omitempty"`
+	// AfterBackup hooks are executed in order once the dump has been taken, before uploading it. They are executed even if the dump
+	// or the BeforeBackup hooks fail, so they can revert the changes made by the BeforeBackup hooks. The Backup Job fails if any of them fails.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AfterBackup []BackupHook `json:"afterBackup,omitempty"`
+}
+
+func (h *BackupHooks) Validate() error {
+	for i, hook := range h.BeforeBackup {
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("invalid beforeBackup[%d]: %v", i, err)
+		}
+	}
+	for i, hook := range h.AfterBackup {
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("invalid afterBackup[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// BackupTieringRule moves the backups older than a given age to a colder storage class of the object storage.
+type BackupTieringRule struct {
+	// MinAge is the minimum age of the backups to be moved to the storage class.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MinAge metav1.Duration `json:"minAge"`
+	// StorageClass is the storage class of the object storage where the backups are moved to,
+	// for example 'GLACIER' in S3, 'ARCHIVE' in GCS or 'Archive' in Azure Blob Storage.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StorageClass string `json:"storageClass"`
+	// RestoreLatency is the expected time to restore the backups from the storage class, which is reported in the Backup status.
+	// It defaults to the documented latency of the well-known storage classes.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RestoreLatency *metav1.Duration `json:"restoreLatency,omitempty"`
+}
+
+// storageClassRestoreLatencies are the documented restore latencies of the storage classes that are not immediately accessible.
+// The names are case sensitive, as the 'ARCHIVE' storage class of GCS is accessible within milliseconds, unlike the 'Archive' tier of Azure.
+// See: https://aws.amazon.com/s3/storage-classes/ and
+// https://learn.microsoft.com/en-us/azure/storage/blobs/archive-rehydrate-overview.
+var storageClassRestoreLatencies = map[string]time.Duration{
+	"GLACIER":      5 * time.Hour,
+	"DEEP_ARCHIVE": 12 * time.Hour,
+	"Archive":      15 * time.Hour,
+}
+
+// RestoreLatencyOrDefault returns the expected time to restore the backups from the storage class.
+func (r *BackupTieringRule) RestoreLatencyOrDefault() time.Duration {
+	if r.RestoreLatency != nil {
+		return r.RestoreLatency.Duration
+	}
+	return storageClassRestoreLatencies[r.StorageClass]
+}
+
 // BackupSpec defines the desired state of Backup
 type BackupSpec struct {
 	// MariaDBRef is a reference to a MariaDB object.
@@ -56,6 +225,25 @@ type BackupSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Args []string `json:"args,omitempty"`
+	// Databases to be backed up. Each database is dumped into its own file, in a separate transaction, allowing to restore them selectively.
+	// The per-database files are not consistent with each other, and they cannot be used for point-in-time recovery.
+	// All databases are dumped into a single file when not provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Databases []string `json:"databases,omitempty"`
+	// Compression defines the compression stage of the Backup. The dump will be compressed with the specified algorithm.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Compression *BackupCompression `json:"compression,omitempty" webhook:"inmutable"`
+	// Streaming uploads the dump to the object storage while it is being taken, without writing it to a local volume first.
+	// This allows taking backups larger than the ephemeral storage available to the Job. Only supported by object storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Streaming bool `json:"streaming,omitempty" webhook:"inmutable"`
+	// Hooks are actions executed by the Backup Job before and after taking the dump, such as SQL statements or commands.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Hooks *BackupHooks `json:"hooks,omitempty"`
 	// Schedule defines when the Backup will be taken.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -65,6 +253,16 @@ type BackupSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	MaxRetention metav1.Duration `json:"maxRetention,omitempty" webhook:"inmutableinit"`
+	// MaxBackups defines the maximum number of backups to be kept in the storage. When exceeded, the oldest backups will be cleaned up by the Backup Job,
+	// in addition to the ones exceeding the MaxRetention.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+	// Tiering defines rules to move the backups to colder storage classes as they age. Only supported by object storages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tiering []BackupTieringRule `json:"tiering,omitempty"`
 	// LogLevel to be used n the Backup Job. It defaults to 'info'.
 	// +optional
 	// +kubebuilder:default=info
@@ -74,6 +272,34 @@ type BackupSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
 	BackoffLimit int32 `json:"backoffLimit,omitempty"`
+	// TTLSecondsAfterFinished defines the number of seconds after which a finished Backup Job is deleted, along with its Pods.
+	// The TTL is set by the operator once the result of the Job has been recorded in the Backup status.
+	// The Job is not recreated once the Backup is complete. It must be at least 60 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// ActiveDeadlineSeconds defines the maximum duration in seconds of the Backup Job. Once it is exceeded, the Pods are terminated and the Job is marked as failed.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// PodFailurePolicy defines how the failures of the Backup Pods are handled, allowing to fail fast or to retry depending on the exit code
+	// or the Pod conditions. It requires the RestartPolicy to be Never. When not provided and the RestartPolicy is Never, Pod disruptions,
+	// such as node evictions or preemptions, are retried without counting towards the BackoffLimit.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PodFailurePolicy *batchv1.PodFailurePolicy `json:"podFailurePolicy,omitempty" webhook:"inmutable"`
+	// SuccessfulJobsHistoryLimit defines the number of successful Jobs to be kept when the Backup is scheduled. It defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit defines the number of failed Jobs to be kept when the Backup is scheduled. It defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
 	// RestartPolicy to be added to the Backup Pod.
 	// +optional
 	// +kubebuilder:default=OnFailure
@@ -104,6 +330,76 @@ type BackupStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Retention reports the backups cleaned up by the retention policy in the last Backup Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Retention *BackupRetentionStatus `json:"retention,omitempty"`
+	// Artifacts are the backups available in the storage after the last Backup Job, from the most recent to the oldest one.
+	// Only the most recent ones are reported when there are many backups in the storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Artifacts []BackupArtifact `json:"artifacts,omitempty"`
+}
+
+// BackupArtifactType is the type of a backup artifact.
+type BackupArtifactType string
+
+const (
+	// BackupArtifactTypeLogical is a logical backup taken by mariadb-dump.
+	BackupArtifactTypeLogical BackupArtifactType = "Logical"
+)
+
+// BackupArtifact is a backup available in the storage, which can be used as restore point.
+type BackupArtifact struct {
+	// Name is the name of the backup file.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Database is the database contained in the backup file. It is only reported for per-database backups.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Database string `json:"database,omitempty"`
+	// Size is the size of the backup file in bytes.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Size int64 `json:"size,omitempty"`
+	// Timestamp is the time when the backup was taken.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Timestamp metav1.Time `json:"timestamp"`
+	// Type is the type of the backup.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Type BackupArtifactType `json:"type"`
+	// StorageClass is the storage class of the backup in the object storage.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StorageClass string `json:"storageClass,omitempty"`
+	// Tiered indicates whether the backup has been moved to a colder storage class by a tiering rule.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Tiered bool `json:"tiered,omitempty"`
+	// RestoreLatency is the expected time to restore the backup from its storage class, only reported for tiered backups.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RestoreLatency *metav1.Duration `json:"restoreLatency,omitempty"`
+}
+
+// BackupRetentionStatus reports the outcome of the retention policy.
+type BackupRetentionStatus struct {
+	// LastPruneTime is the completion time of the last Backup Job that applied the retention policy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastPruneTime *metav1.Time `json:"lastPruneTime,omitempty"`
+	// PrunedBackups is the number of backups deleted in the last Backup Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PrunedBackups int32 `json:"prunedBackups,omitempty"`
+	// RetainedBackups is the number of backups kept in the storage after the last Backup Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RetainedBackups int32 `json:"retainedBackups,omitempty"`
+	// PrunedFiles are the names of the backups deleted in the last Backup Job. It may be truncated when many backups are deleted at once.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PrunedFiles []string `json:"prunedFiles,omitempty"`
 }
 
 func (b *BackupStatus) SetCondition(condition metav1.Condition) {
@@ -135,6 +431,41 @@ func (b *Backup) IsComplete() bool {
 	return meta.IsStatusConditionTrue(b.Status.Conditions, ConditionTypeComplete)
 }
 
+func (b *Backup) IsSuccessful() bool {
+	condition := meta.FindStatusCondition(b.Status.Conditions, ConditionTypeComplete)
+	if condition == nil {
+		return false
+	}
+	return condition.Status == metav1.ConditionTrue && condition.Reason != ConditionReasonJobFailed
+}
+
+// IsFailed returns whether the last Job, or the last scheduled Job in case of CronJobs, has failed.
+func (b *Backup) IsFailed() bool {
+	condition := meta.FindStatusCondition(b.Status.Conditions, ConditionTypeComplete)
+	if condition == nil {
+		return false
+	}
+	return (condition.Reason == ConditionReasonJobFailed || condition.Reason == ConditionReasonCronJobFailed) &&
+		strings.HasPrefix(condition.Message, "Failed")
+}
+
+// RestorePointBefore returns the most recent artifact taken before or at the given time, if any.
+// The second return value indicates whether it can be determined, which is not the case when the artifacts
+// have not been reported yet or when only the most recent ones have been reported.
+func (b *Backup) RestorePointBefore(t time.Time) (*BackupArtifact, bool) {
+	artifacts := b.Status.Artifacts
+	retention := b.Status.Retention
+	if len(artifacts) == 0 || retention == nil {
+		return nil, false
+	}
+	for i := range artifacts {
+		if !artifacts[i].Timestamp.Time.After(t) {
+			return &artifacts[i], true
+		}
+	}
+	return nil, len(artifacts) >= int(retention.RetainedBackups)
+}
+
 func (b *Backup) Validate() error {
 	if b.Spec.Schedule != nil {
 		if err := b.Spec.Schedule.Validate(); err != nil {
@@ -144,6 +475,83 @@ func (b *Backup) Validate() error {
 	if err := b.Spec.Storage.Validate(); err != nil {
 		return fmt.Errorf("invalid Storage: %v", err)
 	}
+	if b.Spec.Compression != nil {
+		if err := b.Spec.Compression.Validate(); err != nil {
+			return fmt.Errorf("invalid Compression: %v", err)
+		}
+	}
+	if b.Spec.Streaming && !b.Spec.Storage.IsObjectStorage() {
+		return errors.New("invalid Streaming: only supported by object storages")
+	}
+	if b.Spec.Hooks != nil {
+		if err := b.Spec.Hooks.Validate(); err != nil {
+			return fmt.Errorf("invalid Hooks: %v", err)
+		}
+	}
+	if err := b.validateDatabases(); err != nil {
+		return fmt.Errorf("invalid Databases: %v", err)
+	}
+	if err := b.validateTiering(); err != nil {
+		return fmt.Errorf("invalid Tiering: %v", err)
+	}
+	return nil
+}
+
+var backupDatabaseRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func (b *Backup) validateDatabases() error {
+	if len(b.Spec.Databases) == 0 {
+		return nil
+	}
+	if b.Spec.Streaming {
+		return errors.New("not supported with Streaming")
+	}
+	databases := make(map[string]struct{})
+	for _, db := range b.Spec.Databases {
+		if !backupDatabaseRegex.MatchString(db) {
+			return fmt.Errorf("invalid database '%s': only alphanumeric characters, '_' and '-' are allowed", db)
+		}
+		if _, ok := databases[db]; ok {
+			return fmt.Errorf("duplicated database '%s'", db)
+		}
+		databases[db] = struct{}{}
+	}
+	return nil
+}
+
+func (b *Backup) validateTiering() error {
+	if len(b.Spec.Tiering) == 0 {
+		return nil
+	}
+	if !b.Spec.Storage.IsObjectStorage() {
+		return errors.New("only supported by object storages")
+	}
+	storageClasses := make(map[string]struct{})
+	for _, rule := range b.Spec.Tiering {
+		if rule.MinAge.Duration <= 0 {
+			return fmt.Errorf("min age of storage class '%s' must be greater than zero", rule.StorageClass)
+		}
+		if b.Spec.MaxRetention.Duration > 0 && rule.MinAge.Duration >= b.Spec.MaxRetention.Duration {
+			return fmt.Errorf("min age of storage class '%s' must be lower than maxRetention", rule.StorageClass)
+		}
+		if strings.Contains(rule.StorageClass, "=") {
+			return fmt.Errorf("invalid storage class '%s'", rule.StorageClass)
+		}
+		if _, ok := storageClasses[rule.StorageClass]; ok {
+			return fmt.Errorf("duplicated storage class '%s'", rule.StorageClass)
+		}
+		storageClasses[rule.StorageClass] = struct{}{}
+	}
+	return nil
+}
+
+// TieringRule returns the tiering rule of a storage class, if any.
+func (b *Backup) TieringRule(storageClass string) *BackupTieringRule {
+	for i, rule := range b.Spec.Tiering {
+		if strings.EqualFold(rule.StorageClass, storageClass) {
+			return &b.Spec.Tiering[i]
+		}
+	}
 	return nil
 }
 
@@ -157,7 +565,7 @@ func (b *Backup) SetDefaults() {
 }
 
 func (b *Backup) Volume() (*corev1.VolumeSource, error) {
-	if b.Spec.Storage.S3 != nil {
+	if b.Spec.Storage.IsObjectStorage() {
 		return &corev1.VolumeSource{
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		}, nil
@@ -131,4 +131,92 @@ var _ = Describe("Backup types", func() {
 			),
 		)
 	})
+
+	Context("When listing restore points", func() {
+		artifact := func(timestamp string) BackupArtifact {
+			t, err := time.Parse(time.RFC3339, timestamp)
+			Expect(err).ToNot(HaveOccurred())
+			return BackupArtifact{
+				Name:      "backup." + timestamp + ".sql",
+				Timestamp: metav1.NewTime(t),
+				Type:      BackupArtifactTypeLogical,
+			}
+		}
+		artifacts := []BackupArtifact{
+			artifact("2023-12-22T20:00:00Z"),
+			artifact("2023-12-22T18:00:00Z"),
+			artifact("2023-12-22T15:00:00Z"),
+		}
+
+		DescribeTable(
+			"Should return the restore point before a time",
+			func(status BackupStatus, target string, wantArtifact *BackupArtifact, wantKnown bool) {
+				backup := &Backup{
+					ObjectMeta: objMeta,
+					Status:     status,
+				}
+				t, err := time.Parse(time.RFC3339, target)
+				Expect(err).ToNot(HaveOccurred())
+
+				artifact, known := backup.RestorePointBefore(t)
+				Expect(known).To(Equal(wantKnown))
+				Expect(artifact).To(Equal(wantArtifact))
+			},
+			Entry(
+				"No artifacts",
+				BackupStatus{},
+				"2023-12-22T19:00:00Z",
+				nil,
+				false,
+			),
+			Entry(
+				"Artifact before",
+				BackupStatus{
+					Retention: &BackupRetentionStatus{
+						RetainedBackups: 3,
+					},
+					Artifacts: artifacts,
+				},
+				"2023-12-22T19:00:00Z",
+				&artifacts[1],
+				true,
+			),
+			Entry(
+				"Artifact at",
+				BackupStatus{
+					Retention: &BackupRetentionStatus{
+						RetainedBackups: 3,
+					},
+					Artifacts: artifacts,
+				},
+				"2023-12-22T15:00:00Z",
+				&artifacts[2],
+				true,
+			),
+			Entry(
+				"No artifact before",
+				BackupStatus{
+					Retention: &BackupRetentionStatus{
+						RetainedBackups: 3,
+					},
+					Artifacts: artifacts,
+				},
+				"2023-12-22T13:00:00Z",
+				nil,
+				true,
+			),
+			Entry(
+				"Truncated artifacts",
+				BackupStatus{
+					Retention: &BackupRetentionStatus{
+						RetainedBackups: 50,
+					},
+					Artifacts: artifacts,
+				},
+				"2023-12-22T13:00:00Z",
+				nil,
+				false,
+			),
+		)
+	})
 })
@@ -13,6 +13,9 @@ import (
 func (r *Backup) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(&backupPolicyWebhook{
+			client: mgr.GetClient(),
+		}).
 		Complete()
 }
 
@@ -28,10 +31,15 @@ func (r *Backup) ValidateCreate() (admission.Warnings, error) {
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *Backup) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
-	if err := inmutableWebhook.ValidateUpdate(r, old.(*Backup)); err != nil {
+	oldBackup := old.(*Backup)
+	if err := inmutableWebhook.ValidateUpdate(r, oldBackup); err != nil {
 		return nil, err
 	}
-	return r.validate()
+	warnings, err := r.validate()
+	if err != nil {
+		return nil, err
+	}
+	return newWarnings(warnings, oldBackup.warnings()), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -47,5 +55,18 @@ func (r *Backup) validate() (admission.Warnings, error) {
 			fmt.Sprintf("invalid Backup: %v", err),
 		)
 	}
-	return nil, nil
+	if err := validatePodFailurePolicy(r.Spec.PodFailurePolicy, r.Spec.RestartPolicy); err != nil {
+		return nil, err
+	}
+	return r.warnings(), nil
+}
+
+// warnings returns the configurations that are allowed but risky, to be reported at apply time without blocking the request.
+func (r *Backup) warnings() admission.Warnings {
+	var warnings admission.Warnings
+	if r.Spec.Schedule == nil {
+		warnings = append(warnings,
+			"'spec.schedule' is not set: the Backup will be taken only once, set a schedule to take backups periodically")
+	}
+	return warnings
 }
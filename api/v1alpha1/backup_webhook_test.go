@@ -5,10 +5,12 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -151,6 +153,464 @@ var _ = Describe("Backup webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Invalid compression level",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-compression",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						Compression: &BackupCompression{
+							Algorithm: CompressGzip,
+							Level:     19,
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								"cpu": resource.MustParse("100m"),
+							},
+						},
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Pod failure policy without restart policy Never",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-pod-failure-policy",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+						PodFailurePolicy: &batchv1.PodFailurePolicy{
+							Rules: []batchv1.PodFailurePolicyRule{
+								{
+									Action: batchv1.PodFailurePolicyActionFailJob,
+									OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+										Operator: batchv1.PodFailurePolicyOnExitCodesOpIn,
+										Values:   []int32{1},
+									},
+								},
+							},
+						},
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Tiering without object storage",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-tiering-storage",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										"storage": resource.MustParse("100Mi"),
+									},
+								},
+								AccessModes: []corev1.PersistentVolumeAccessMode{
+									corev1.ReadWriteOnce,
+								},
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						MaxRetention: metav1.Duration{Duration: 30 * 24 * time.Hour},
+						Tiering: []BackupTieringRule{
+							{
+								MinAge:       metav1.Duration{Duration: 7 * 24 * time.Hour},
+								StorageClass: "GLACIER",
+							},
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Tiering exceeding max retention",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-tiering-age",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						MaxRetention: metav1.Duration{Duration: 30 * 24 * time.Hour},
+						Tiering: []BackupTieringRule{
+							{
+								MinAge:       metav1.Duration{Duration: 30 * 24 * time.Hour},
+								StorageClass: "GLACIER",
+							},
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid tiering",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-valid-tiering",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						MaxRetention: metav1.Duration{Duration: 30 * 24 * time.Hour},
+						Tiering: []BackupTieringRule{
+							{
+								MinAge:       metav1.Duration{Duration: 7 * 24 * time.Hour},
+								StorageClass: "GLACIER",
+							},
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Streaming without object storage",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-streaming",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										"storage": resource.MustParse("100Mi"),
+									},
+								},
+								AccessModes: []corev1.PersistentVolumeAccessMode{
+									corev1.ReadWriteOnce,
+								},
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Streaming:     true,
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid streaming",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-valid-streaming",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Streaming:     true,
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid databases",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-valid-databases",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Databases:     []string{"db1", "db_2"},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Duplicated databases",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-duplicated-databases",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Databases:     []string{"db1", "db1"},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid database",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-database",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Databases:     []string{"db1", "db.2"},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Databases with streaming",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-databases-streaming",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Databases:     []string{"db1"},
+						Streaming:     true,
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid hooks",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-valid-hooks",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Hooks: &BackupHooks{
+							BeforeBackup: []BackupHook{
+								{
+									SQL: ptr.To("SET GLOBAL wsrep_desync = ON"),
+								},
+							},
+							AfterBackup: []BackupHook{
+								{
+									SQL: ptr.To("SET GLOBAL wsrep_desync = OFF"),
+								},
+								{
+									Exec: &corev1.ExecAction{
+										Command: []string{"curl", "-X", "POST", "http://notifier/backup"},
+									},
+								},
+							},
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Hook with SQL and exec",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-invalid-hook",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Hooks: &BackupHooks{
+							BeforeBackup: []BackupHook{
+								{
+									SQL: ptr.To("FLUSH TABLES"),
+									Exec: &corev1.ExecAction{
+										Command: []string{"echo", "backup"},
+									},
+								},
+							},
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Hook with empty exec",
+				&Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "backup-empty-hook",
+						Namespace: testNamespace,
+					},
+					Spec: BackupSpec{
+						Storage: BackupStorage{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Hooks: &BackupHooks{
+							AfterBackup: []BackupHook{
+								{
+									Exec: &corev1.ExecAction{},
+								},
+							},
+						},
+						BackoffLimit:  10,
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+					},
+				},
+				true,
+			),
 			Entry(
 				"Valid",
 				&Backup{
@@ -298,4 +758,30 @@ var _ = Describe("Backup webhook", func() {
 			),
 		)
 	})
+
+	Context("When applying risky configurations", func() {
+		It("Should warn about Backups without schedule", func() {
+			backup := &Backup{
+				Spec: BackupSpec{
+					Storage: BackupStorage{
+						S3: &S3{
+							Bucket:   "test",
+							Endpoint: "test",
+						},
+					},
+				},
+			}
+			warnings, err := backup.ValidateCreate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+
+			scheduled := backup.DeepCopy()
+			scheduled.Spec.Schedule = &Schedule{
+				Cron: "*/1 * * * *",
+			}
+			warnings, err = scheduled.ValidateCreate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
 })
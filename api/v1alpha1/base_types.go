@@ -7,8 +7,11 @@ import (
 
 	"github.com/mariadb-operator/mariadb-operator/pkg/webhook"
 	cron "github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
@@ -71,6 +74,28 @@ type SecretTemplate struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	DatabaseKey *string `json:"databaseKey,omitempty"`
+	// OptionsFileKey is the Secret key where a MariaDB client option file is rendered, containing a [client] group
+	// with the user, password, host, port and database. It can be mounted and used via the --defaults-extra-file flag.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OptionsFileKey *string `json:"optionsFileKey,omitempty"`
+	// Files are additional keys rendered in the Secret using Go templates, to match the layout expected by applications.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Files []SecretTemplateFile `json:"files,omitempty"`
+}
+
+// SecretTemplateFile defines an additional Secret key rendered from a Go template. The template has access to
+// the same fields as the DSN Format: Username, Password, Host, Port, Database and Params.
+type SecretTemplateFile struct {
+	// Key to be used in the Secret.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Key string `json:"key"`
+	// Format is the Go template used to render the content of the key.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Format string `json:"format"`
 }
 
 // ContainerTemplate defines a template to configure Container objects.
@@ -178,6 +203,33 @@ type VolumeClaimTemplate struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// HealthCheckType defines how the health of a Connection is checked.
+type HealthCheckType string
+
+const (
+	// HealthCheckTypeTCP checks that a TCP connection can be established with the server.
+	HealthCheckTypeTCP HealthCheckType = "TCP"
+	// HealthCheckTypePing checks that the server can be authenticated against and pinged. This is the default HealthCheckType.
+	HealthCheckTypePing HealthCheckType = "Ping"
+	// HealthCheckTypeSelect checks that the server is able to execute a 'SELECT 1' statement.
+	HealthCheckTypeSelect HealthCheckType = "Select"
+	// HealthCheckTypeReplicationLag checks that the replication lag of the server is below MaxReplicationLag.
+	// Servers that are not replicating from a primary are considered healthy.
+	HealthCheckTypeReplicationLag HealthCheckType = "ReplicationLag"
+	// HealthCheckTypeQuery checks that the server is able to execute a custom Query within a read-only transaction.
+	HealthCheckTypeQuery HealthCheckType = "Query"
+)
+
+// Validate returns an error if the HealthCheckType is not valid.
+func (h HealthCheckType) Validate() error {
+	switch h {
+	case HealthCheckTypeTCP, HealthCheckTypePing, HealthCheckTypeSelect, HealthCheckTypeReplicationLag, HealthCheckTypeQuery:
+		return nil
+	default:
+		return fmt.Errorf("invalid HealthCheckType: %v", h)
+	}
+}
+
 // HealthCheck defines intervals for performing health checks.
 type HealthCheck struct {
 	// Interval used to perform health checks.
@@ -188,6 +240,51 @@ type HealthCheck struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+	// Type of health check to be performed. It defaults to Ping.
+	// +optional
+	// +kubebuilder:validation:Enum=TCP;Ping;Select;ReplicationLag;Query
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Type HealthCheckType `json:"type,omitempty"`
+	// Timeout is the maximum duration of the health check. Slower health checks are considered unhealthy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxReplicationLag is the maximum replication lag allowed by the ReplicationLag health check.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxReplicationLag *metav1.Duration `json:"maxReplicationLag,omitempty"`
+	// Query to be executed by the Query health check. It is executed within a read-only transaction.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Query *string `json:"query,omitempty"`
+}
+
+// TypeOrDefault returns the health check type, defaulting to Ping.
+func (h *HealthCheck) TypeOrDefault() HealthCheckType {
+	if h == nil || h.Type == "" {
+		return HealthCheckTypePing
+	}
+	return h.Type
+}
+
+// Validate returns an error if the HealthCheck is not valid.
+func (h *HealthCheck) Validate() error {
+	if h.Type != "" {
+		if err := h.Type.Validate(); err != nil {
+			return err
+		}
+	}
+	switch h.TypeOrDefault() {
+	case HealthCheckTypeReplicationLag:
+		if h.MaxReplicationLag == nil {
+			return errors.New("MaxReplicationLag must be set when using the ReplicationLag health check")
+		}
+	case HealthCheckTypeQuery:
+		if h.Query == nil || *h.Query == "" {
+			return errors.New("Query must be set when using the Query health check")
+		}
+	}
+	return nil
 }
 
 // ConnectionTemplate defines a template to customize Connection objects.
@@ -224,6 +321,11 @@ type SQLTemplate struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+	// Timeout is the timeout applied to every SQL statement executed to reconcile the object.
+	// It defaults to the operator's --sql-timeout flag.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 type TLS struct {
@@ -235,6 +337,63 @@ type TLS struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	CASecretKeyRef *corev1.SecretKeySelector `json:"caSecretKeyRef,omitempty"`
+	// InsecureSkipVerify disables the verification of the S3 server certificate. It should only be used for testing purposes.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// SSEType defines the type of server-side encryption.
+type SSEType string
+
+const (
+	// SSETypeS3 uses keys managed by S3 (SSE-S3).
+	SSETypeS3 SSEType = "S3"
+	// SSETypeKMS uses keys managed by a KMS (SSE-KMS).
+	SSETypeKMS SSEType = "KMS"
+	// SSETypeCustomer uses keys provided by the customer (SSE-C).
+	SSETypeCustomer SSEType = "Customer"
+)
+
+// SSE defines the server-side encryption configuration for S3.
+type SSE struct {
+	// Type is the server-side encryption type. It can be S3 (SSE-S3), KMS (SSE-KMS) or Customer (SSE-C).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=S3;KMS;Customer
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Type SSEType `json:"type"`
+	// KMSKeyID is the identifier of the KMS key used to encrypt the backups. It is only used with the KMS type.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KMSKeyID *string `json:"kmsKeyId,omitempty"`
+	// CustomerKeySecretKeyRef is a reference to a Secret key containing a 32 byte key used to encrypt the backups.
+	// It is required when using the Customer type.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CustomerKeySecretKeyRef *corev1.SecretKeySelector `json:"customerKeySecretKeyRef,omitempty"`
+}
+
+func (s *SSE) Validate() error {
+	switch s.Type {
+	case SSETypeS3:
+		if s.KMSKeyID != nil || s.CustomerKeySecretKeyRef != nil {
+			return errors.New("'kmsKeyId' and 'customerKeySecretKeyRef' are not supported with S3 type")
+		}
+	case SSETypeKMS:
+		if s.CustomerKeySecretKeyRef != nil {
+			return errors.New("'customerKeySecretKeyRef' is not supported with KMS type")
+		}
+	case SSETypeCustomer:
+		if s.CustomerKeySecretKeyRef == nil {
+			return errors.New("'customerKeySecretKeyRef' must be provided with Customer type")
+		}
+		if s.KMSKeyID != nil {
+			return errors.New("'kmsKeyId' is not supported with Customer type")
+		}
+	default:
+		return fmt.Errorf("unsupported SSE type: %v", s.Type)
+	}
+	return nil
 }
 
 type S3 struct {
@@ -242,6 +401,10 @@ type S3 struct {
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Bucket string `json:"bucket" webhook:"inmutable"`
+	// Prefix is the path within the bucket where the backups are stored, i.e. "mariadb/production".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Prefix string `json:"prefix,omitempty" webhook:"inmutable"`
 	// Endpoint is the S3 API endpoint without scheme.
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -262,15 +425,90 @@ type S3 struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SessionTokenSecretKeyRef *corev1.SecretKeySelector `json:"sessionTokenSecretKeyRef,omitempty"`
+	// PathStyle forces path-style addressing (https://endpoint/bucket) instead of virtual-hosted-style (https://bucket.endpoint).
+	// It is usually required by on-premise S3 compatible storages, such as Minio or Ceph RGW.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	PathStyle bool `json:"pathStyle,omitempty" webhook:"inmutable"`
 	// TLS provides the configuration required to establish TLS connections with S3.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	TLS *TLS `json:"tls,omitempty"`
+	// SSE defines the server-side encryption configuration used to store backups in S3.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SSE *SSE `json:"sse,omitempty"`
+}
+
+func (s *S3) Validate() error {
+	if s.SSE != nil {
+		if err := s.SSE.Validate(); err != nil {
+			return fmt.Errorf("invalid SSE: %v", err)
+		}
+	}
+	return nil
+}
+
+// GCS defines the configuration to store backups in Google Cloud Storage.
+type GCS struct {
+	// Bucket is the name of the bucket to store backups.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Bucket string `json:"bucket" webhook:"inmutable"`
+	// Prefix is the path within the bucket where the backups are stored, i.e. "mariadb/production".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Prefix string `json:"prefix,omitempty" webhook:"inmutable"`
+	// CredentialsSecretKeyRef is a reference to a Secret key containing a Google service account key in JSON format.
+	// When not provided, the credentials are obtained from the environment, for instance, via Workload Identity.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CredentialsSecretKeyRef *corev1.SecretKeySelector `json:"credentialsSecretKeyRef,omitempty"`
+	// ServiceAccountName is the name of the Kubernetes ServiceAccount used by the Job Pods. When using Workload Identity,
+	// it must be bound to a Google service account with access to the bucket.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+}
+
+// AzureBlob defines the configuration to store backups in Azure Blob Storage.
+type AzureBlob struct {
+	// Container is the name of the Blob Storage container to store backups.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Container string `json:"container" webhook:"inmutable"`
+	// Prefix is the path within the container where the backups are stored, i.e. "mariadb/production".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Prefix string `json:"prefix,omitempty" webhook:"inmutable"`
+	// StorageAccount is the name of the Azure storage account.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StorageAccount string `json:"storageAccount" webhook:"inmutable"`
+	// Endpoint is the Blob service endpoint. It defaults to https://<storageAccount>.blob.core.windows.net.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Endpoint string `json:"endpoint,omitempty" webhook:"inmutable"`
+	// AccountKeySecretKeyRef is a reference to a Secret key containing the storage account key.
+	// When not provided, the credentials are obtained via Azure Workload Identity.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AccountKeySecretKeyRef *corev1.SecretKeySelector `json:"accountKeySecretKeyRef,omitempty"`
+	// ServiceAccountName is the name of the Kubernetes ServiceAccount used by the Job Pods. When using Azure Workload Identity,
+	// it must be annotated with the client ID of a managed identity with access to the container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+}
+
+// IsWorkloadIdentity indicates whether the credentials are obtained via Azure Workload Identity.
+func (a *AzureBlob) IsWorkloadIdentity() bool {
+	return a.AccountKeySecretKeyRef == nil
 }
 
 // RestoreSource defines a source for restoring a MariaDB.
 type RestoreSource struct {
-	// BackupRef is a reference to a Backup object. It has priority over S3 and Volume.
+	// BackupRef is a reference to a Backup object. It has priority over S3, GCS, AzureBlob and Volume.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	BackupRef *corev1.LocalObjectReference `json:"backupRef,omitempty" webhook:"inmutableinit"`
@@ -278,21 +516,43 @@ type RestoreSource struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	S3 *S3 `json:"s3,omitempty" webhook:"inmutableinit"`
+	// GCS defines the configuration to restore backups from Google Cloud Storage. It has priority over Volume.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCS *GCS `json:"gcs,omitempty" webhook:"inmutableinit"`
+	// AzureBlob defines the configuration to restore backups from Azure Blob Storage. It has priority over Volume.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AzureBlob *AzureBlob `json:"azureBlob,omitempty" webhook:"inmutableinit"`
 	// Volume is a Kubernetes Volume object that contains a backup.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Volume *corev1.VolumeSource `json:"volume,omitempty" webhook:"inmutableinit"`
 	// TargetRecoveryTime is a RFC3339 (1970-01-01T00:00:00Z) date and time that defines the point in time recovery objective.
-	// It is used to determine the closest restoration source in time.
+	// The latest backup taken before or at this time is restored. By default, the latest available backup is restored.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	TargetRecoveryTime *metav1.Time `json:"targetRecoveryTime,omitempty" webhook:"inmutable"`
 }
 
 func (r *RestoreSource) Validate() error {
-	if r.BackupRef == nil && r.S3 == nil && r.Volume == nil {
+	if r.BackupRef == nil && r.S3 == nil && r.GCS == nil && r.AzureBlob == nil && r.Volume == nil {
 		return errors.New("unable to determine restore source")
 	}
+	objectStorages := 0
+	for _, isSet := range []bool{r.S3 != nil, r.GCS != nil, r.AzureBlob != nil} {
+		if isSet {
+			objectStorages++
+		}
+	}
+	if objectStorages > 1 {
+		return errors.New("only one of S3, GCS and AzureBlob can be provided")
+	}
+	if r.S3 != nil {
+		if err := r.S3.Validate(); err != nil {
+			return fmt.Errorf("invalid S3: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -301,7 +561,7 @@ func (r *RestoreSource) IsDefaulted() bool {
 }
 
 func (r *RestoreSource) SetDefaults() {
-	if r.S3 != nil {
+	if r.S3 != nil || r.GCS != nil || r.AzureBlob != nil {
 		r.Volume = &corev1.VolumeSource{
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		}
@@ -315,6 +575,8 @@ func (r *RestoreSource) SetDefaultsWithBackup(backup *Backup) error {
 	}
 	r.Volume = volume
 	r.S3 = backup.Spec.Storage.S3
+	r.GCS = backup.Spec.Storage.GCS
+	r.AzureBlob = backup.Spec.Storage.AzureBlob
 	return nil
 }
 
@@ -342,3 +604,93 @@ func (s *Schedule) Validate() error {
 	_, err := cronParser.Parse(s.Cron)
 	return err
 }
+
+// validatePodFailurePolicy validates that a Pod failure policy is compatible with the restart policy of the Job,
+// as Kubernetes only allows Pod failure policies in Jobs whose Pods are never restarted.
+func validatePodFailurePolicy(policy *batchv1.PodFailurePolicy, restartPolicy corev1.RestartPolicy) error {
+	if policy != nil && restartPolicy != corev1.RestartPolicyNever {
+		return field.Invalid(
+			field.NewPath("spec").Child("podFailurePolicy"),
+			policy,
+			"'spec.podFailurePolicy' requires 'spec.restartPolicy' to be 'Never'",
+		)
+	}
+	return nil
+}
+
+// newWarnings returns the warnings that were not reported for the previous version of an object,
+// so updates only warn about the risks they introduce.
+func newWarnings(warnings, oldWarnings admission.Warnings) admission.Warnings {
+	old := make(map[string]struct{}, len(oldWarnings))
+	for _, w := range oldWarnings {
+		old[w] = struct{}{}
+	}
+	var result admission.Warnings
+	for _, w := range warnings {
+		if _, ok := old[w]; !ok {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// MaintenanceWindow defines a recurring time window in which disruptive operations are allowed.
+type MaintenanceWindow struct {
+	// Cron is a cron expression that defines the start of the window.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Cron string `json:"cron"`
+	// Duration of the window.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+}
+
+func (w *MaintenanceWindow) Validate() error {
+	if _, err := cronParser.Parse(w.Cron); err != nil {
+		return err
+	}
+	if w.Duration.Duration <= 0 {
+		return errors.New("duration must be greater than zero")
+	}
+	return nil
+}
+
+// IsActive indicates whether the window is active at the given time.
+func (w *MaintenanceWindow) IsActive(now time.Time) bool {
+	schedule, err := cronParser.Parse(w.Cron)
+	if err != nil {
+		return false
+	}
+	start := schedule.Next(now.Add(-w.Duration.Duration))
+	return !start.After(now)
+}
+
+// NextStart returns the next start of the window after the given time.
+func (w *MaintenanceWindow) NextStart(now time.Time) (time.Time, error) {
+	schedule, err := cronParser.Parse(w.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(now), nil
+}
+
+// PasswordRotation defines the periodic rotation of a password by the operator.
+type PasswordRotation struct {
+	// Interval at which the password is rotated.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Interval metav1.Duration `json:"interval"`
+}
+
+func (p *PasswordRotation) Validate() error {
+	if p.Interval.Duration <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	return nil
+}
+
+// IsDue indicates whether a password last rotated at the given time has to be rotated.
+func (p *PasswordRotation) IsDue(lastRotated time.Time, now time.Time) bool {
+	return !now.Before(lastRotated.Add(p.Interval.Duration))
+}
@@ -1,9 +1,13 @@
 package v1alpha1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("Base types", func() {
@@ -222,4 +226,126 @@ var _ = Describe("Base types", func() {
 			),
 		)
 	})
+
+	Context("When checking a MaintenanceWindow", func() {
+		DescribeTable(
+			"Should determine if it is active",
+			func(w *MaintenanceWindow, now time.Time, wantActive bool) {
+				Expect(w.IsActive(now)).To(Equal(wantActive))
+			},
+			Entry(
+				"Before start",
+				&MaintenanceWindow{
+					Cron:     "0 3 * * *",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				},
+				time.Date(2023, 12, 19, 2, 59, 0, 0, time.Local),
+				false,
+			),
+			Entry(
+				"Within window",
+				&MaintenanceWindow{
+					Cron:     "0 3 * * *",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				},
+				time.Date(2023, 12, 19, 4, 0, 0, 0, time.Local),
+				true,
+			),
+			Entry(
+				"After end",
+				&MaintenanceWindow{
+					Cron:     "0 3 * * *",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				},
+				time.Date(2023, 12, 19, 5, 1, 0, 0, time.Local),
+				false,
+			),
+			Entry(
+				"Invalid cron",
+				&MaintenanceWindow{
+					Cron:     "foo",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				},
+				time.Date(2023, 12, 19, 4, 0, 0, 0, time.Local),
+				false,
+			),
+		)
+	})
+
+	Context("When validating a HealthCheck", func() {
+		DescribeTable(
+			"Should validate",
+			func(h *HealthCheck, wantType HealthCheckType, wantErr bool) {
+				Expect(h.TypeOrDefault()).To(Equal(wantType))
+				err := h.Validate()
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Empty",
+				&HealthCheck{},
+				HealthCheckTypePing,
+				false,
+			),
+			Entry(
+				"TCP",
+				&HealthCheck{
+					Type: HealthCheckTypeTCP,
+				},
+				HealthCheckTypeTCP,
+				false,
+			),
+			Entry(
+				"Invalid type",
+				&HealthCheck{
+					Type: HealthCheckType("foo"),
+				},
+				HealthCheckType("foo"),
+				true,
+			),
+			Entry(
+				"ReplicationLag without max lag",
+				&HealthCheck{
+					Type: HealthCheckTypeReplicationLag,
+				},
+				HealthCheckTypeReplicationLag,
+				true,
+			),
+			Entry(
+				"ReplicationLag",
+				&HealthCheck{
+					Type:              HealthCheckTypeReplicationLag,
+					MaxReplicationLag: &metav1.Duration{Duration: 30 * time.Second},
+				},
+				HealthCheckTypeReplicationLag,
+				false,
+			),
+			Entry(
+				"Query without query",
+				&HealthCheck{
+					Type:  HealthCheckTypeQuery,
+					Query: ptr.To(""),
+				},
+				HealthCheckTypeQuery,
+				true,
+			),
+			Entry(
+				"Query",
+				&HealthCheck{
+					Type:  HealthCheckTypeQuery,
+					Query: ptr.To("SELECT 1 FROM dual;"),
+				},
+				HealthCheckTypeQuery,
+				false,
+			),
+		)
+
+		It("Should default the type of a nil HealthCheck", func() {
+			var h *HealthCheck
+			Expect(h.TypeOrDefault()).To(Equal(HealthCheckTypePing))
+		})
+	})
 })
@@ -10,10 +10,33 @@ const (
 	// ConditionTypeGaleraConfigured indicates that the cluster has been successfully configured.
 	ConditionTypeGaleraConfigured string = "GaleraConfigured"
 	ConditionTypeComplete         string = "Complete"
+	// ConditionTypeWriteFrozen indicates that the MariaDB writes have been frozen by a Restore.
+	ConditionTypeWriteFrozen string = "WriteFrozen"
+	// ConditionTypeRestartPending indicates that there are Pods pending to be restarted to apply the latest changes.
+	ConditionTypeRestartPending string = "RestartPending"
+	// ConditionTypePodFailed indicates that a Pod is failing to become ready, the message contains the root cause from the error log.
+	ConditionTypePodFailed string = "PodFailed"
+	// ConditionTypeQuotaExceeded indicates that the size of a Database has exceeded its quota.
+	ConditionTypeQuotaExceeded string = "QuotaExceeded"
+	// ConditionTypeDataSeeded indicates that the seed data has been loaded into MariaDB.
+	ConditionTypeDataSeeded string = "DataSeeded"
+	// ConditionTypeActionsRateLimited indicates that the disruptive actions of the operator are blocked, as the rate limit has been hit.
+	ConditionTypeActionsRateLimited string = "ActionsRateLimited"
+	// ConditionTypePasswordExpiring indicates that the password of a User is about to expire, or it has already expired.
+	ConditionTypePasswordExpiring string = "PasswordExpiring"
+	// ConditionTypeReconciling indicates that the MariaDB is progressing towards the desired state, for example during a restore,
+	// a primary switchover or a rollout. It is only present when true, following the kstatus conventions used by GitOps tools.
+	ConditionTypeReconciling string = "Reconciling"
+	// ConditionTypeStalled indicates that the MariaDB is not able to progress without intervention, for example after a reconciliation
+	// error or when a Pod keeps failing. It is only present when true, following the kstatus conventions used by GitOps tools.
+	ConditionTypeStalled string = "Stalled"
 
 	ConditionReasonStatefulSetNotReady  string = "StatefulSetNotReady"
 	ConditionReasonStatefulSetReady     string = "StatefulSetReady"
+	ConditionReasonDeploymentNotReady   string = "DeploymentNotReady"
+	ConditionReasonDeploymentReady      string = "DeploymentReady"
 	ConditionReasonRestoreBackup        string = "RestoreBackup"
+	ConditionReasonSeedData             string = "SeedData"
 	ConditionReasonConfigureReplication string = "ConfigureReplication"
 	ConditionReasonSwitchPrimary        string = "SwitchPrimary"
 	ConditionReasonGaleraReady          string = "GaleraReady"
@@ -22,6 +45,16 @@ const (
 
 	ConditionReasonRestoreNotComplete string = "RestoreNotComplete"
 	ConditionReasonRestoreComplete    string = "RestoreComplete"
+	ConditionReasonWriteFreeze        string = "WriteFreeze"
+
+	ConditionReasonMaintenanceWindow string = "MaintenanceWindow"
+	ConditionReasonOnDeleteStrategy  string = "OnDeleteStrategy"
+
+	ConditionReasonErrorLog string = "ErrorLog"
+
+	ConditionReasonRateLimitExceeded string = "RateLimitExceeded"
+
+	ConditionReasonWaitingForDependencies string = "WaitingForDependencies"
 
 	ConditionReasonJobComplete  string = "JobComplete"
 	ConditionReasonJobSuspended string = "JobSuspended"
@@ -35,7 +68,28 @@ const (
 
 	ConditionReasonConnectionFailed string = "ConnectionFailed"
 
-	ConditionReasonCreated string = "Created"
-	ConditionReasonHealthy string = "Healthy"
-	ConditionReasonFailed  string = "Failed"
+	ConditionReasonFleetMembersNotReady string = "FleetMembersNotReady"
+	ConditionReasonFleetRollingOut      string = "FleetRollingOut"
+
+	ConditionReasonRehearsalProvisioning string = "RehearsalProvisioning"
+	ConditionReasonRehearsalSucceeded    string = "RehearsalSucceeded"
+	ConditionReasonRehearsalFailed       string = "RehearsalFailed"
+
+	ConditionReasonProvisioning string = "Provisioning"
+
+	ConditionReasonHibernated string = "Hibernated"
+
+	ConditionReasonStatefulSetRollingOut string = "StatefulSetRollingOut"
+
+	ConditionReasonQuotaExceeded    string = "QuotaExceeded"
+	ConditionReasonQuotaNotExceeded string = "QuotaNotExceeded"
+
+	ConditionReasonPasswordExpiring    string = "PasswordExpiring"
+	ConditionReasonPasswordExpired     string = "PasswordExpired"
+	ConditionReasonPasswordNotExpiring string = "PasswordNotExpiring"
+
+	ConditionReasonCreated     string = "Created"
+	ConditionReasonHealthy     string = "Healthy"
+	ConditionReasonFailed      string = "Failed"
+	ConditionReasonUnsupported string = "Unsupported"
 )
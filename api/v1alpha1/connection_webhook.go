@@ -45,7 +45,10 @@ func (r *Connection) validate() (admission.Warnings, error) {
 	if err := r.validateHealthCheck(); err != nil {
 		return nil, err
 	}
-	return nil, r.validateCustomDSNFormat()
+	if err := r.validateCustomDSNFormat(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateSecretTemplateFiles()
 }
 
 func (r *Connection) validateHealthCheck() error {
@@ -72,6 +75,13 @@ func (r *Connection) validateHealthCheck() error {
 			)
 		}
 	}
+	if err := r.Spec.HealthCheck.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("healthCheck"),
+			r.Spec.HealthCheck,
+			err.Error(),
+		)
+	}
 	return nil
 }
 
@@ -91,3 +101,42 @@ func (r *Connection) validateCustomDSNFormat() error {
 
 	return nil
 }
+
+func (r *Connection) validateSecretTemplateFiles() error {
+	if r.Spec.SecretTemplate == nil {
+		return nil
+	}
+	keys := make(map[string]struct{})
+	for _, k := range []*string{
+		r.Spec.SecretTemplate.Key,
+		r.Spec.SecretTemplate.UsernameKey,
+		r.Spec.SecretTemplate.PasswordKey,
+		r.Spec.SecretTemplate.HostKey,
+		r.Spec.SecretTemplate.PortKey,
+		r.Spec.SecretTemplate.DatabaseKey,
+		r.Spec.SecretTemplate.OptionsFileKey,
+	} {
+		if k != nil {
+			keys[*k] = struct{}{}
+		}
+	}
+	for i, f := range r.Spec.SecretTemplate.Files {
+		path := field.NewPath("spec").Child("secretTemplate").Child("files").Index(i)
+		if f.Key == "" {
+			return field.Invalid(path.Child("key"), f.Key, "key must not be empty")
+		}
+		if _, ok := keys[f.Key]; ok {
+			return field.Invalid(path.Child("key"), f.Key, fmt.Sprintf("duplicated key: '%s'", f.Key))
+		}
+		keys[f.Key] = struct{}{}
+
+		if _, err := template.New("").Parse(f.Format); err != nil {
+			return field.Invalid(
+				path.Child("format"),
+				f.Format,
+				fmt.Sprintf("invalid format template: '%s'", err),
+			)
+		}
+	}
+	return nil
+}
@@ -124,6 +124,29 @@ var _ = Describe("Connection webhook", func() {
 				},
 				false,
 			),
+			Entry(
+				"Updating HealthCheck type",
+				func(conn *Connection) {
+					conn.Spec.HealthCheck.Type = HealthCheckTypeSelect
+					conn.Spec.HealthCheck.Timeout = &metav1.Duration{Duration: 3 * time.Second}
+				},
+				false,
+			),
+			Entry(
+				"Updating HealthCheck to ReplicationLag without MaxReplicationLag",
+				func(conn *Connection) {
+					conn.Spec.HealthCheck.Type = HealthCheckTypeReplicationLag
+				},
+				true,
+			),
+			Entry(
+				"Updating HealthCheck to Query",
+				func(conn *Connection) {
+					conn.Spec.HealthCheck.Type = HealthCheckTypeQuery
+					conn.Spec.HealthCheck.Query = func() *string { q := "SELECT COUNT(*) FROM app.users"; return &q }()
+				},
+				false,
+			),
 		)
 	})
 })
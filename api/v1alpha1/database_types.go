@@ -1,7 +1,12 @@
 package v1alpha1
 
 import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,6 +35,75 @@ type DatabaseSpec struct {
 	// +kubebuilder:validation:MaxLength=80
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Name string `json:"name,omitempty" webhook:"inmutable"`
+	// InitSQL is the baseline schema applied exactly once after the Database has been created.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	InitSQL *DatabaseInitSQL `json:"initSql,omitempty" webhook:"inmutable"`
+	// Quota defines the maximum size of the Database. The size is periodically monitored by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Quota *DatabaseQuota `json:"quota,omitempty"`
+}
+
+// DatabaseQuotaEnforcement defines the action taken when the Database quota is exceeded.
+type DatabaseQuotaEnforcement string
+
+const (
+	// DatabaseQuotaEnforcementNone only reports the quota as exceeded via conditions and events.
+	DatabaseQuotaEnforcementNone DatabaseQuotaEnforcement = "None"
+	// DatabaseQuotaEnforcementRevokeInsert revokes the INSERT privilege on the Database from all the accounts that have it,
+	// and grants it back when the size is below the quota again. Only database-level grants are revoked: the accounts that have
+	// INSERT via a global or a table-level grant are reported via a warning event.
+	DatabaseQuotaEnforcementRevokeInsert DatabaseQuotaEnforcement = "RevokeInsert"
+)
+
+// DatabaseQuota defines the maximum size of a Database.
+type DatabaseQuota struct {
+	// Size is the maximum size of the data and indexes of the Database, as reported by information_schema.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Size resource.Quantity `json:"size"`
+	// Enforcement defines the action taken when the quota is exceeded. RevokeInsert only revokes database-level grants,
+	// the accounts that have INSERT via a global or a table-level grant are able to keep writing.
+	// +optional
+	// +kubebuilder:default=None
+	// +kubebuilder:validation:Enum=None;RevokeInsert
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Enforcement DatabaseQuotaEnforcement `json:"enforcement,omitempty"`
+}
+
+// Validate returns an error if the DatabaseQuota is not valid.
+func (d *DatabaseQuota) Validate() error {
+	if d.Size.Sign() <= 0 {
+		return errors.New("size must be greater than zero")
+	}
+	switch d.Enforcement {
+	case "", DatabaseQuotaEnforcementNone, DatabaseQuotaEnforcementRevokeInsert:
+		return nil
+	default:
+		return fmt.Errorf("unsupported enforcement: %s", d.Enforcement)
+	}
+}
+
+// DatabaseInitSQL defines the source of the SQL statements applied when the Database is created.
+// Only one of its fields may be specified.
+type DatabaseInitSQL struct {
+	// ConfigMapKeyRef is a reference to a ConfigMap key containing SQL statements.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef is a reference to a Secret key containing SQL statements.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// Validate returns an error if the DatabaseInitSQL is not valid.
+func (d *DatabaseInitSQL) Validate() error {
+	if (d.ConfigMapKeyRef == nil) == (d.SecretKeyRef == nil) {
+		return errors.New("exactly one of 'configMapKeyRef' or 'secretKeyRef' must be specified")
+	}
+	return nil
 }
 
 // DatabaseStatus defines the observed state of Database
@@ -38,6 +112,18 @@ type DatabaseStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// InitSQLApplied indicates that the InitSQL statements have been applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	InitSQLApplied bool `json:"initSqlApplied,omitempty"`
+	// Size is the last observed size of the data and indexes of the Database. It is only reported when a quota is defined.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Size *resource.Quantity `json:"size,omitempty"`
+	// QuotaRevokedAccounts are the accounts whose INSERT privilege has been revoked because the quota was exceeded.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	QuotaRevokedAccounts []string `json:"quotaRevokedAccounts,omitempty"`
 }
 
 func (d *DatabaseStatus) SetCondition(condition metav1.Condition) {
@@ -56,6 +142,8 @@ func (d *DatabaseStatus) SetCondition(condition metav1.Condition) {
 // +kubebuilder:printcolumn:name="CharSet",type="string",JSONPath=".spec.characterSet"
 // +kubebuilder:printcolumn:name="Collate",type="string",JSONPath=".spec.collate"
 // +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.size"
+// +kubebuilder:printcolumn:name="Quota",type="string",JSONPath=".spec.quota.size"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Database,v1alpha1}}
@@ -84,6 +172,21 @@ func (d *Database) IsReady() bool {
 	return meta.IsStatusConditionTrue(d.Status.Conditions, ConditionTypeReady)
 }
 
+// IsQuotaExceeded indicates whether the size of the Database has exceeded its quota.
+func (d *Database) IsQuotaExceeded() bool {
+	return meta.IsStatusConditionTrue(d.Status.Conditions, ConditionTypeQuotaExceeded)
+}
+
+// IsQuotaRevokedAccount indicates whether the INSERT privilege has been revoked from an account due to the quota.
+func (d *Database) IsQuotaRevokedAccount(accountName string) bool {
+	for _, a := range d.Status.QuotaRevokedAccounts {
+		if a == accountName {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Database) MariaDBRef() *MariaDBRef {
 	return &d.Spec.MariaDBRef
 }
@@ -96,6 +199,10 @@ func (d *Database) RetryInterval() *metav1.Duration {
 	return d.Spec.RetryInterval
 }
 
+func (d *Database) SqlTimeout() *metav1.Duration {
+	return d.Spec.Timeout
+}
+
 // +kubebuilder:object:root=true
 
 // DatabaseList contains a list of Database
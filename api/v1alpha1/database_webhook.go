@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -20,7 +21,10 @@ var _ webhook.Validator = &Database{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Database) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	if err := r.validateInitSQL(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateQuota()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -28,10 +32,38 @@ func (r *Database) ValidateUpdate(old runtime.Object) (admission.Warnings, error
 	if err := inmutableWebhook.ValidateUpdate(r, old.(*Database)); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return nil, r.validateQuota()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Database) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
+
+func (r *Database) validateInitSQL() error {
+	if r.Spec.InitSQL == nil {
+		return nil
+	}
+	if err := r.Spec.InitSQL.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("initSql"),
+			r.Spec.InitSQL,
+			err.Error(),
+		)
+	}
+	return nil
+}
+
+func (r *Database) validateQuota() error {
+	if r.Spec.Quota == nil {
+		return nil
+	}
+	if err := r.Spec.Quota.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("quota"),
+			r.Spec.Quota,
+			err.Error(),
+		)
+	}
+	return nil
+}
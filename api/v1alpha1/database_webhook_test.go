@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -72,6 +73,25 @@ var _ = Describe("Database webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Updating Quota",
+				func(db *Database) {
+					db.Spec.Quota = &DatabaseQuota{
+						Size:        resource.MustParse("1Gi"),
+						Enforcement: DatabaseQuotaEnforcementRevokeInsert,
+					}
+				},
+				false,
+			),
+			Entry(
+				"Updating Quota with invalid size",
+				func(db *Database) {
+					db.Spec.Quota = &DatabaseQuota{
+						Size: resource.MustParse("0"),
+					}
+				},
+				true,
+			),
 		)
 	})
 })
@@ -5,6 +5,14 @@ const (
 	ReasonReplicationConfiguring = "ReplicationConfiguring"
 	// ReasonReplicationConfigured indicates that replication has been configured.
 	ReasonReplicationConfigured = "ReplicationConfigured"
+	// ReasonReplicationPrimaryFence indicates that the old primary is being fenced during an automatic failover.
+	ReasonReplicationPrimaryFence = "PrimaryFence"
+	// ReasonReplicationPrimaryFenceErr indicates that the old primary could not be fenced during an automatic failover.
+	ReasonReplicationPrimaryFenceErr = "PrimaryFenceErr"
+	// ReasonReplicationPrimaryDrain indicates that the queries running in the primary are being drained.
+	ReasonReplicationPrimaryDrain = "PrimaryDrain"
+	// ReasonReplicationPrimaryKill indicates that the queries still running in the primary after draining have been killed.
+	ReasonReplicationPrimaryKill = "PrimaryKill"
 	// ReasonReplicationPrimaryLock indicates that primary tables have a read lock.
 	ReasonReplicationPrimaryLock = "PrimaryLock"
 	// ReasonReplicationPrimaryReadonly indicates that primary is being changed to readonly mode.
@@ -19,6 +27,22 @@ const (
 	ReasonReplicationReplicaConn = "ReplicaConn"
 	// ReasonReplicationPrimaryToReplica indicates that current primary is being unlocked to become a replica.
 	ReasonReplicationPrimaryToReplica = "PrimaryToReplica"
+	// ReasonReplicationExternalConnect indicates that the primary is connecting to the external primary.
+	ReasonReplicationExternalConnect = "ExternalConnect"
+	// ReasonReplicationExternalPromote indicates that the primary stops replicating from the external primary and accepts writes.
+	ReasonReplicationExternalPromote = "ExternalPromote"
+	// ReasonReplicationChannelConfigure indicates that a replication channel is being configured.
+	ReasonReplicationChannelConfigure = "ReplicationChannelConfigure"
+	// ReasonReplicationGtidSettings indicates that the GTID settings have been applied to a Pod.
+	ReasonReplicationGtidSettings = "GtidSettings"
+	// ReasonReplicationTLS indicates that the replication connection of a Pod has been changed to match the TLS settings.
+	ReasonReplicationTLS = "ReplicationTLS"
+	// ReasonReplicaProvisioning indicates that a new replica is being provisioned before starting replication.
+	ReasonReplicaProvisioning = "ReplicaProvisioning"
+	// ReasonReplicaProvisioned indicates that a new replica has been provisioned and it has started replicating.
+	ReasonReplicaProvisioned = "ReplicaProvisioned"
+	// ReasonReplicaProvisioningFailed indicates that a new replica could not be provisioned.
+	ReasonReplicaProvisioningFailed = "ReplicaProvisioningFailed"
 
 	// ReasonGaleraClusterHealthy indicates that the cluster is healthy,
 	ReasonGaleraClusterHealthy = "GaleraClusterHealthy"
@@ -34,15 +58,98 @@ const (
 	ReasonGaleraPodRecovered = "GaleraPodRecovered"
 	// ReasonGaleraPodSyncTimeout indicates that the Pod has timed out reaching the Sync state.
 	ReasonGaleraPodSyncTimeout = "GaleraPodSyncTimeout"
+	// ReasonGaleraRecoveryPlan indicates that a plan to recover the cluster has been computed.
+	ReasonGaleraRecoveryPlan = "GaleraRecoveryPlan"
+	// ReasonGaleraRecoveryPendingApproval indicates that the recovery plan is waiting to be approved.
+	ReasonGaleraRecoveryPendingApproval = "GaleraRecoveryPendingApproval"
+	// ReasonGaleraConfigDrift indicates that the wsrep settings are not consistent across the Galera nodes.
+	ReasonGaleraConfigDrift = "GaleraConfigDrift"
+	// ReasonGaleraConfigConsistent indicates that the wsrep settings are consistent again across the Galera nodes.
+	ReasonGaleraConfigConsistent = "GaleraConfigConsistent"
+	// ReasonGaleraPodStateChanged indicates that the Pod has reported a Galera node state change via wsrep_notify_cmd.
+	ReasonGaleraPodStateChanged = "GaleraPodStateChanged"
+	// ReasonGaleraArbitratorReady indicates that the Galera arbitrator is running.
+	ReasonGaleraArbitratorReady = "GaleraArbitratorReady"
+	// ReasonGaleraArbitratorNotReady indicates that the Galera arbitrator is not running.
+	ReasonGaleraArbitratorNotReady = "GaleraArbitratorNotReady"
+	// ReasonGaleraPodStateTransfer indicates the state transfer (IST or SST) used by the Pod to join the Galera cluster.
+	ReasonGaleraPodStateTransfer = "GaleraPodStateTransfer"
+
+	// ReasonErrantGtidDetected indicates that a replica has executed transactions that have not been executed by the primary.
+	ReasonErrantGtidDetected = "ErrantGtidDetected"
+	// ReasonGtidGapDetected indicates that a replica is missing replication domains of the primary.
+	ReasonGtidGapDetected = "GtidGapDetected"
 
 	// ReasonPrimarySwitching indicates that primary is being switched.
 	ReasonPrimarySwitching = "PrimarySwitching"
 	// ReasonPrimarySwitched indicates that primary has been switched.
 	ReasonPrimarySwitched = "PrimarySwitched"
 
+	// ReasonMariaDBCrashed indicates that the MariaDB container has crashed and its diagnostics have been captured.
+	ReasonMariaDBCrashed = "MariaDBCrashed"
+	// ReasonMariaDBNotReady indicates that the MariaDB container is not ready, the root cause has been read from the error log.
+	ReasonMariaDBNotReady = "MariaDBNotReady"
+	// ReasonActionsRateLimited indicates that a disruptive action has been blocked by the rate limit.
+	ReasonActionsRateLimited = "ActionsRateLimited"
+	// ReasonMariaDBScaled indicates that the MariaDB replicas have been scaled according to the scheduled scaling.
+	ReasonMariaDBScaled = "MariaDBScaled"
+	// ReasonMariaDBUpgraded indicates that all the MariaDB Pods have been upgraded to a new image.
+	ReasonMariaDBUpgraded = "MariaDBUpgraded"
+	// ReasonMariaDBHibernated indicates that the MariaDB has been scaled down to zero Pods.
+	ReasonMariaDBHibernated = "MariaDBHibernated"
+	// ReasonHibernationBackupFailed indicates that the Backup taken before hibernating has failed and it is going to be retried.
+	ReasonHibernationBackupFailed = "HibernationBackupFailed"
+	// ReasonMariaDBResumed indicates that the MariaDB has been scaled back after being hibernated.
+	ReasonMariaDBResumed = "MariaDBResumed"
+	// ReasonRollingRestartStarted indicates that a rolling restart of the Pods has been started.
+	ReasonRollingRestartStarted = "RollingRestartStarted"
+	// ReasonRollingRestartPod indicates that a Pod is being restarted as part of a rolling restart.
+	ReasonRollingRestartPod = "RollingRestartPod"
+	// ReasonRollingRestartCompleted indicates that all the Pods have been restarted.
+	ReasonRollingRestartCompleted = "RollingRestartCompleted"
+	// ReasonTLSCertReloaded indicates that the Pods have reloaded a renewed server certificate without restarting.
+	ReasonTLSCertReloaded = "TLSCertReloaded"
+	// ReasonTLSCertRollout indicates that the Pods are rolled out to load a renewed server certificate.
+	ReasonTLSCertRollout = "TLSCertRollout"
+	// ReasonUpgradeBlocked indicates that a new MariaDB image is not rolled out because critical pre-flight checks failed.
+	ReasonUpgradeBlocked = "UpgradeBlocked"
+
+	// ReasonBackupFailed indicates that a Backup has failed.
+	ReasonBackupFailed = "BackupFailed"
+	// ReasonBackupsPruned indicates that old backups have been deleted by the retention policy.
+	ReasonBackupsPruned = "BackupsPruned"
+	// ReasonSeedDataFailed indicates that the seed data could not be loaded into MariaDB.
+	ReasonSeedDataFailed = "SeedDataFailed"
+
 	// ReasonWebhookUpdateFailed indicates that the webhook configuration update failed.
 	ReasonWebhookUpdateFailed = "WebhookUpdateFailed"
 
 	// ReasonCRDNotFound indicates that a third party CRD is not present in the cluster.
 	ReasonCRDNotFound = "CRDNotFound"
+
+	// ReasonSpecChanged indicates that the key fields of the MariaDB spec have been changed.
+	ReasonSpecChanged = "SpecChanged"
+
+	// ReasonDriftReverted indicates that out-of-band modifications to a generated resource have been reverted.
+	ReasonDriftReverted = "DriftReverted"
+
+	// ReasonDatabaseQuotaExceeded indicates that the size of a Database has exceeded its quota.
+	ReasonDatabaseQuotaExceeded = "DatabaseQuotaExceeded"
+	// ReasonDatabaseQuotaRecovered indicates that the size of a Database is below its quota again.
+	ReasonDatabaseQuotaRecovered = "DatabaseQuotaRecovered"
+	// ReasonDatabaseQuotaNotEnforced indicates that the INSERT privilege of some accounts cannot be revoked when a Database exceeds its quota.
+	ReasonDatabaseQuotaNotEnforced = "DatabaseQuotaNotEnforced"
+
+	// ReasonUserPasswordExpiring indicates that the password of a User is about to expire.
+	ReasonUserPasswordExpiring = "UserPasswordExpiring"
+	// ReasonUserPasswordExpired indicates that the password of a User has expired.
+	ReasonUserPasswordExpired = "UserPasswordExpired"
+	// ReasonUserRequireDrift indicates that the TLS requirement of an account did not match the User and it has been altered.
+	ReasonUserRequireDrift = "UserRequireDrift"
+	// ReasonUserAuthPluginDrift indicates that the authentication plugin of an account did not match the User and it has been altered.
+	ReasonUserAuthPluginDrift = "UserAuthPluginDrift"
+	// ReasonUserPasswordHashDrift indicates that the password hash of an account did not match the User and it has been altered.
+	ReasonUserPasswordHashDrift = "UserPasswordHashDrift"
+	// ReasonPasswordRotated indicates that a password has been rotated by the operator.
+	ReasonPasswordRotated = "PasswordRotated"
 )
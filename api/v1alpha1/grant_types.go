@@ -32,11 +32,16 @@ type GrantSpec struct {
 	// +kubebuilder:default=*
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Table string `json:"table,omitempty" webhook:"inmutable"`
-	// Username to use in the Grant.
-	// +kubebuilder:validation:Required
+	// Username to use in the Grant. Either Username or Role must be specified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username,omitempty" webhook:"inmutable"`
+	// Role to use in the Grant, so the privileges are granted to a role instead of to a User. It references a Role object in the same namespace.
+	// Either Username or Role must be specified.
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	Username string `json:"username" webhook:"inmutable"`
-	// Host to use in the Grant.
+	Role string `json:"role,omitempty" webhook:"inmutable"`
+	// Host to use in the Grant. It cannot be specified along with Role.
 	// +optional
 	// +kubebuilder:MaxLength=255
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -71,6 +76,7 @@ func (g *GrantStatus) SetCondition(condition metav1.Condition) {
 // +kubebuilder:printcolumn:name="Database",type="string",JSONPath=".spec.database"
 // +kubebuilder:printcolumn:name="Table",type="string",JSONPath=".spec.table"
 // +kubebuilder:printcolumn:name="Username",type="string",JSONPath=".spec.username"
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=".spec.role"
 // +kubebuilder:printcolumn:name="GrantOpt",type="string",JSONPath=".spec.grantOption"
 // +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
@@ -105,6 +111,12 @@ func (g *Grant) RetryInterval() *metav1.Duration {
 	return g.Spec.RetryInterval
 }
 
+func (g *Grant) SqlTimeout() *metav1.Duration {
+	return g.Spec.Timeout
+}
+
+// AccountName returns the account of the User. Grants to a Role are granted to the name of the Role in MariaDB,
+// which is resolved from the Role object.
 func (g *Grant) AccountName() string {
 	return fmt.Sprintf("'%s'@'%s'", g.Spec.Username, g.HostnameOrDefault())
 }
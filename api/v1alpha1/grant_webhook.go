@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -20,7 +21,7 @@ var _ webhook.Validator = &Grant{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Grant) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validateGrantee()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -28,10 +29,28 @@ func (r *Grant) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	if err := inmutableWebhook.ValidateUpdate(r, old.(*Grant)); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return nil, r.validateGrantee()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *Grant) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
+
+func (r *Grant) validateGrantee() error {
+	if (r.Spec.Username == "") == (r.Spec.Role == "") {
+		return field.Invalid(
+			field.NewPath("spec").Child("username"),
+			r.Spec.Username,
+			"exactly one of 'username' or 'role' must be specified",
+		)
+	}
+	if r.Spec.Role != "" && r.Spec.Host != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("host"),
+			r.Spec.Host,
+			"'host' cannot be specified along with 'role'",
+		)
+	}
+	return nil
+}
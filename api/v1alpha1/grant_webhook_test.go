@@ -6,10 +6,101 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Grant webhook", func() {
+	Context("When creating a Grant", func() {
+		meta := metav1.ObjectMeta{
+			Name:      "grant-create-webhook",
+			Namespace: testNamespace,
+		}
+		spec := GrantSpec{
+			MariaDBRef: MariaDBRef{
+				ObjectReference: corev1.ObjectReference{
+					Name: "mariadb-webhook",
+				},
+				WaitForIt: true,
+			},
+			Privileges: []string{
+				"SELECT",
+			},
+			Database: "foo",
+			Table:    "foo",
+		}
+		DescribeTable(
+			"Should validate",
+			func(grant *Grant, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, grant)
+				err := k8sClient.Create(testCtx, grant)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Username",
+				&Grant{
+					ObjectMeta: meta,
+					Spec: func() GrantSpec {
+						s := *spec.DeepCopy()
+						s.Username = "foo"
+						return s
+					}(),
+				},
+				false,
+			),
+			Entry(
+				"Role",
+				&Grant{
+					ObjectMeta: meta,
+					Spec: func() GrantSpec {
+						s := *spec.DeepCopy()
+						s.Role = "foo"
+						return s
+					}(),
+				},
+				false,
+			),
+			Entry(
+				"Username and Role",
+				&Grant{
+					ObjectMeta: meta,
+					Spec: func() GrantSpec {
+						s := *spec.DeepCopy()
+						s.Username = "foo"
+						s.Role = "foo"
+						return s
+					}(),
+				},
+				true,
+			),
+			Entry(
+				"No Username nor Role",
+				&Grant{
+					ObjectMeta: meta,
+					Spec:       *spec.DeepCopy(),
+				},
+				true,
+			),
+			Entry(
+				"Role with Host",
+				&Grant{
+					ObjectMeta: meta,
+					Spec: func() GrantSpec {
+						s := *spec.DeepCopy()
+						s.Role = "foo"
+						s.Host = ptr.To("%")
+						return s
+					}(),
+				},
+				true,
+			),
+		)
+	})
+
 	Context("When updating a Grant", Ordered, func() {
 		key := types.NamespacedName{
 			Name:      "grant-mariadb-webhook",
@@ -94,6 +185,13 @@ var _ = Describe("Grant webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Updating Role",
+				func(grant *Grant) {
+					grant.Spec.Role = "bar"
+				},
+				true,
+			),
 			Entry(
 				"Updating GrantOption",
 				func(grant *Grant) {
@@ -0,0 +1,141 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceOperation defines the maintenance operation to be performed on the tables.
+type MaintenanceOperation string
+
+const (
+	// MaintenanceOperationCheck checks the tables for errors.
+	MaintenanceOperationCheck MaintenanceOperation = "Check"
+	// MaintenanceOperationAnalyze analyzes the tables to refresh the index statistics.
+	MaintenanceOperationAnalyze MaintenanceOperation = "Analyze"
+	// MaintenanceOperationOptimize optimizes the tables to reclaim unused space and defragment the data.
+	MaintenanceOperationOptimize MaintenanceOperation = "Optimize"
+)
+
+// Validate returns an error if the MaintenanceOperation is not valid.
+func (m MaintenanceOperation) Validate() error {
+	switch m {
+	case MaintenanceOperationCheck, MaintenanceOperationAnalyze, MaintenanceOperationOptimize:
+		return nil
+	default:
+		return fmt.Errorf("invalid MaintenanceOperation: %v", m)
+	}
+}
+
+// MaintenanceJobSpec defines the desired state of MaintenanceJob
+type MaintenanceJobSpec struct {
+	// MariaDBRef is a reference to a MariaDB object.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
+	// Operation is the maintenance operation to be performed on the tables.
+	// +optional
+	// +kubebuilder:default=Analyze
+	// +kubebuilder:validation:Enum=Check;Analyze;Optimize
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Operation MaintenanceOperation `json:"operation,omitempty"`
+	// Databases to be maintained. If not provided, all the databases will be maintained.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Databases []string `json:"databases,omitempty"`
+	// Concurrency is the number of databases that are maintained in parallel in each Pod.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Concurrency int32 `json:"concurrency,omitempty"`
+	// Schedule defines the start of the maintenance window. If not provided, the maintenance will be executed only once.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Schedule *Schedule `json:"schedule,omitempty"`
+	// MaxDuration defines the duration of the maintenance window. The maintenance will be stopped once it is exceeded.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+	// BackoffLimit defines the maximum number of attempts to successfully perform the maintenance.
+	// +optional
+	// +kubebuilder:default=5
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	BackoffLimit int32 `json:"backoffLimit,omitempty"`
+	// RestartPolicy to be added to the MaintenanceJob Pod.
+	// +optional
+	// +kubebuilder:default=OnFailure
+	// +kubebuilder:validation:Enum=Always;OnFailure;Never
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RestartPolicy corev1.RestartPolicy `json:"restartPolicy,omitempty" webhook:"inmutable"`
+	// Resouces describes the compute resource requirements.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty" webhook:"inmutable"`
+	// Affinity to be used in the MaintenanceJob Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// NodeSelector to be used in the MaintenanceJob Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations to be used in the MaintenanceJob Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// MaintenanceJobStatus defines the observed state of MaintenanceJob
+type MaintenanceJobStatus struct {
+	// Conditions for the MaintenanceJob object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (s *MaintenanceJobStatus) SetCondition(condition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=mmdb
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Complete",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].message"
+// +kubebuilder:printcolumn:name="Operation",type="string",JSONPath=".spec.operation"
+// +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{MaintenanceJob,v1alpha1},{CronJob,v1},{Job,v1}}
+
+// MaintenanceJob is the Schema for the maintenancejobs API
+type MaintenanceJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceJobSpec   `json:"spec,omitempty"`
+	Status MaintenanceJobStatus `json:"status,omitempty"`
+}
+
+func (m *MaintenanceJob) IsComplete() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeComplete)
+}
+
+//+kubebuilder:object:root=true
+
+// MaintenanceJobList contains a list of MaintenanceJob
+type MaintenanceJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceJob{}, &MaintenanceJobList{})
+}
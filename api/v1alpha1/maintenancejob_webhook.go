@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (r *MaintenanceJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint
+//+kubebuilder:webhook:path=/validate-mariadb-mmontes-io-v1alpha1-maintenancejob,mutating=false,failurePolicy=fail,sideEffects=None,groups=mariadb.mmontes.io,resources=maintenancejobs,verbs=create;update,versions=v1alpha1,name=vmaintenancejob.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MaintenanceJob{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (m *MaintenanceJob) ValidateCreate() (admission.Warnings, error) {
+	return m.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (m *MaintenanceJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := inmutableWebhook.ValidateUpdate(m, old.(*MaintenanceJob)); err != nil {
+		return nil, err
+	}
+	return m.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (m *MaintenanceJob) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (m *MaintenanceJob) validate() (admission.Warnings, error) {
+	if err := m.validateOperation(); err != nil {
+		return nil, err
+	}
+	if err := m.validateDatabases(); err != nil {
+		return nil, err
+	}
+	if err := m.validateSchedule(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (m *MaintenanceJob) validateOperation() error {
+	if m.Spec.Operation == "" {
+		return nil
+	}
+	if err := m.Spec.Operation.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("operation"),
+			m.Spec.Operation,
+			err.Error(),
+		)
+	}
+	return nil
+}
+
+func (m *MaintenanceJob) validateDatabases() error {
+	seen := make(map[string]struct{}, len(m.Spec.Databases))
+	for i, db := range m.Spec.Databases {
+		if _, ok := seen[db]; ok {
+			return field.Invalid(
+				field.NewPath("spec").Child("databases").Index(i),
+				db,
+				"duplicated database",
+			)
+		}
+		seen[db] = struct{}{}
+	}
+	return nil
+}
+
+func (m *MaintenanceJob) validateSchedule() error {
+	if m.Spec.Schedule == nil {
+		return nil
+	}
+	if err := m.Spec.Schedule.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("schedule"),
+			m.Spec.Schedule,
+			fmt.Sprintf("invalid schedule: %v", err),
+		)
+	}
+	return nil
+}
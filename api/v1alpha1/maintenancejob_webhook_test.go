@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("MaintenanceJob webhook", func() {
+	Context("When creating a MaintenanceJob", func() {
+		objMeta := metav1.ObjectMeta{
+			Name:      "maintenancejob-create-webhook",
+			Namespace: testNamespace,
+		}
+		mariaDBRef := MariaDBRef{
+			ObjectReference: corev1.ObjectReference{
+				Name: "foo",
+			},
+		}
+		DescribeTable(
+			"Should validate",
+			func(m *MaintenanceJob, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, m)
+				err := k8sClient.Create(testCtx, m)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Invalid operation",
+				&MaintenanceJob{
+					ObjectMeta: objMeta,
+					Spec: MaintenanceJobSpec{
+						MariaDBRef: mariaDBRef,
+						Operation:  "foo",
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated databases",
+				&MaintenanceJob{
+					ObjectMeta: objMeta,
+					Spec: MaintenanceJobSpec{
+						MariaDBRef: mariaDBRef,
+						Databases:  []string{"foo", "bar", "foo"},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid schedule",
+				&MaintenanceJob{
+					ObjectMeta: objMeta,
+					Spec: MaintenanceJobSpec{
+						MariaDBRef: mariaDBRef,
+						Schedule: &Schedule{
+							Cron: "foo",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid",
+				&MaintenanceJob{
+					ObjectMeta: objMeta,
+					Spec: MaintenanceJobSpec{
+						MariaDBRef:  mariaDBRef,
+						Operation:   MaintenanceOperationOptimize,
+						Databases:   []string{"foo", "bar"},
+						Concurrency: 2,
+						Schedule: &Schedule{
+							Cron: "0 3 * * 0",
+						},
+						MaxDuration: &metav1.Duration{Duration: time.Hour},
+					},
+				},
+				false,
+			),
+		)
+	})
+})
@@ -1,7 +1,9 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -131,6 +133,152 @@ type GaleraAgent struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	GracefulShutdownTimeout *metav1.Duration `json:"gracefulShutdownTimeout,omitempty"`
+	// WsrepNotify configures a wsrep_notify_cmd that publishes the node state changes in the Pod annotations.
+	// More info: https://mariadb.com/kb/en/galera-cluster-system-variables/#wsrep_notify_cmd.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WsrepNotify *WsrepNotify `json:"wsrepNotify,omitempty"`
+}
+
+// GaleraArbitrator is a Galera arbitrator (garbd), a member of the cluster that takes part in the quorum without storing data.
+// It provides an extra vote for clusters with an even number of nodes, i.e. 2-node clusters or clusters spread across two availability zones.
+// More info: https://galeracluster.com/library/documentation/arbitrator.html.
+type GaleraArbitrator struct {
+	// ContainerTemplate defines a template to configure the arbitrator Container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ContainerTemplate `json:",inline"`
+	// Enabled is a flag to deploy the arbitrator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Image name containing the garbd binary. The supported format is `<image>:<tag>`. It defaults to the MariaDB image.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy is the image pull policy. One of `Always`, `Never` or `IfNotPresent`. If not defined, it defaults to `IfNotPresent`.
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"}
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// NodeSelector to be used in the arbitrator Pod, i.e. to schedule it in a different availability zone than the MariaDB Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity to be used in the arbitrator Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Tolerations to be used in the arbitrator Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// GaleraArbitratorStatus is the state of the Galera arbitrator.
+type GaleraArbitratorStatus struct {
+	// Ready indicates whether the arbitrator Pod is running.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Ready bool `json:"ready,omitempty"`
+	// ClusterSize is the number of members of the Galera cluster, including the arbitrator, as reported by the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ClusterSize int `json:"clusterSize,omitempty"`
+}
+
+// GaleraGCache is the write-set cache (gcache) kept by each node to serve Incremental State Transfers (IST) to the joining nodes.
+// A gcache able to hold the write sets generated while a node is down allows it to rejoin via IST instead of a full SST.
+// More info: https://galeracluster.com/library/documentation/state-transfer.html.
+type GaleraGCache struct {
+	// Size of the gcache ring buffer (gcache.size).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Recover indicates whether the gcache is recovered on startup (gcache.recover), so the node can serve IST right after restarting.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Recover *bool `json:"recover,omitempty"`
+}
+
+// ProviderOptions returns the gcache settings as Galera provider options.
+func (g *GaleraGCache) ProviderOptions() map[string]string {
+	opts := make(map[string]string)
+	if g.Size != nil {
+		opts["gcache.size"] = strconv.FormatInt(g.Size.Value(), 10)
+	}
+	if g.Recover != nil {
+		value := "no"
+		if *g.Recover {
+			value = "yes"
+		}
+		opts["gcache.recover"] = value
+	}
+	return opts
+}
+
+// Validate returns an error if the gcache is not valid.
+func (g *GaleraGCache) Validate() error {
+	if g.Size != nil && g.Size.Sign() <= 0 {
+		return errors.New("gcache size must be greater than zero")
+	}
+	return nil
+}
+
+// GaleraSegment assigns the Galera nodes to segments based on the topology of the Node where they are scheduled,
+// so geo-distributed clusters replicate across data centers once per segment instead of once per node.
+// More info: https://galeracluster.com/library/documentation/galera-parameters.html#gmcast-segment.
+type GaleraSegment struct {
+	// Enabled is a flag to set gmcast.segment based on the Node topology.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// TopologyKey is the Node label used to derive the segment. It defaults to 'topology.kubernetes.io/zone'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// Segments maps the values of the topology label to a segment number between 0 and 255.
+	// The Nodes with a value not listed here belong to the segment 0.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Segments map[string]int32 `json:"segments,omitempty"`
+}
+
+// TopologyKeyOrDefault returns the Node label used to derive the segment.
+func (s *GaleraSegment) TopologyKeyOrDefault() string {
+	if s.TopologyKey != "" {
+		return s.TopologyKey
+	}
+	return corev1.LabelTopologyZone
+}
+
+// SegmentForTopology returns the segment of a Node given the value of its topology label.
+func (s *GaleraSegment) SegmentForTopology(value string) int32 {
+	return s.Segments[value]
+}
+
+// Validate returns an error if the segments are not valid.
+func (s *GaleraSegment) Validate() error {
+	for topology, segment := range s.Segments {
+		if segment < 0 || segment > 255 {
+			return fmt.Errorf("segment for '%s' must be between 0 and 255", topology)
+		}
+	}
+	return nil
+}
+
+// WsrepNotify configures a wsrep_notify_cmd that publishes the Galera node state changes (i.e. Synced, Donor, Joined)
+// in the Pod annotations. These state changes are turned into Events and status updates by the operator.
+type WsrepNotify struct {
+	// Enabled is a flag to enable the wsrep_notify_cmd integration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsWsrepNotifyEnabled indicates whether the wsrep_notify_cmd integration is enabled.
+func (r *GaleraAgent) IsWsrepNotifyEnabled() bool {
+	return r.WsrepNotify != nil && r.WsrepNotify.Enabled
 }
 
 // FillWithDefaults fills the current GaleraAgent object with DefaultReplicationSpec.
@@ -181,6 +329,16 @@ type GaleraRecovery struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	PodSyncTimeout *metav1.Duration `json:"podSyncTimeout,omitempty"`
+	// DryRun computes the recovery plan and publishes it in the status and as an Event, without bootstrapping the cluster.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	DryRun bool `json:"dryRun,omitempty"`
+	// RequireApproval waits for the recovery plan to be approved before bootstrapping the cluster.
+	// The plan is approved by setting the 'mariadb.mmontes.io/galera-recovery-approved' annotation in the MariaDB
+	// to the name of the Pod chosen to bootstrap the cluster.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	RequireApproval bool `json:"requireApproval,omitempty"`
 }
 
 func (g *GaleraRecovery) FillWithDefaults() {
@@ -231,6 +389,12 @@ type GaleraSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ReplicaThreads *int `json:"replicaThreads,omitempty"`
+	// ProviderOptions are Galera provider options merged into wsrep_provider_options, i.e. gcache.size, evs timeouts or flow control.
+	// The options not specified keep the value defined by the generated configuration or the Galera defaults.
+	// More info: https://galeracluster.com/library/documentation/galera-parameters.html.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ProviderOptions map[string]string `json:"providerOptions,omitempty"`
 	// GaleraAgent is a sidecar agent that co-operates with mariadb-operator.
 	// More info: https://github.com/mariadb-operator/agent.
 	// +optional
@@ -251,6 +415,35 @@ type GaleraSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	VolumeClaimTemplate *VolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+	// Arbitrator is a Galera arbitrator (garbd) deployed alongside the cluster as an extra quorum vote.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Arbitrator *GaleraArbitrator `json:"arbitrator,omitempty"`
+	// Segment assigns the Galera nodes to segments (gmcast.segment) based on the topology of the Node where they are scheduled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Segment *GaleraSegment `json:"segment,omitempty"`
+	// GCache is the write-set cache used to serve Incremental State Transfers (IST) to the joining nodes.
+	// More info: https://galeracluster.com/library/documentation/state-transfer.html.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GCache *GaleraGCache `json:"gcache,omitempty"`
+}
+
+// ValidateProviderOptions returns an error if the provider options cannot be formatted as wsrep_provider_options.
+func (g GaleraSpec) ValidateProviderOptions() error {
+	for k, v := range g.ProviderOptions {
+		if strings.TrimSpace(k) == "" {
+			return errors.New("provider option keys must not be empty")
+		}
+		if strings.ContainsAny(k, ";= ") {
+			return fmt.Errorf("invalid provider option key '%s'", k)
+		}
+		if strings.TrimSpace(v) == "" || strings.Contains(v, ";") {
+			return fmt.Errorf("invalid value for provider option '%s'", k)
+		}
+	}
+	return nil
 }
 
 // FillWithDefaults fills the current GaleraSpec object with DefaultGaleraSpec.
@@ -349,6 +542,38 @@ type GaleraRecoveryBootstrap struct {
 	Pod  *string      `json:"pod,omitempty"`
 }
 
+// GaleraRecoveryPlanPod is the Galera sequence found in a Pod during the recovery process.
+type GaleraRecoveryPlanPod struct {
+	// Pod is the name of the Pod.
+	Pod string `json:"pod"`
+	// UUID is the Galera cluster UUID found in the Pod.
+	// +optional
+	UUID string `json:"uuid,omitempty"`
+	// Seqno is the highest sequence number found in the Pod, either in the Galera state file or by the sequence recovery.
+	Seqno int `json:"seqno"`
+	// SafeToBootstrap indicates whether the Pod is marked as safe to bootstrap in the Galera state file.
+	// +optional
+	SafeToBootstrap bool `json:"safeToBootstrap,omitempty"`
+}
+
+// GaleraRecoveryPlan is the plan computed by the operator to recover the Galera cluster.
+type GaleraRecoveryPlan struct {
+	// Pods are the sequences found in each Pod.
+	// +optional
+	Pods []GaleraRecoveryPlanPod `json:"pods,omitempty"`
+	// BootstrapPod is the Pod chosen to bootstrap the cluster.
+	BootstrapPod string `json:"bootstrapPod"`
+	// Actions are the actions to be taken by the operator to recover the cluster.
+	// +optional
+	Actions []string `json:"actions,omitempty"`
+	// DryRun indicates that the plan will not be executed, as the recovery is in dry run mode.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// PendingApproval indicates that the plan is waiting to be approved before being executed.
+	// +optional
+	PendingApproval bool `json:"pendingApproval,omitempty"`
+}
+
 // GaleraRecoveryStatus is the current state of the Galera recovery process.
 type GaleraRecoveryStatus struct {
 	// State is a per Pod representation of the Galera state file (grastate.dat).
@@ -357,6 +582,19 @@ type GaleraRecoveryStatus struct {
 	Recovered map[string]*agentgalera.Bootstrap `json:"recovered,omitempty"`
 	// Bootstrap indicates when and in which Pod the cluster bootstrap process has been performed.
 	Bootstrap *GaleraRecoveryBootstrap `json:"bootstrap,omitempty"`
+	// Plan is the plan computed by the operator to recover the cluster.
+	Plan *GaleraRecoveryPlan `json:"plan,omitempty"`
+}
+
+// GaleraConfigDrift is a wsrep setting that has different values across the Galera nodes.
+type GaleraConfigDrift struct {
+	// Variable is the name of the setting. Provider options are prefixed by 'wsrep_provider_options.'.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Variable string `json:"variable"`
+	// Values are the values of the setting, indexed by Pod name.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Values map[string]string `json:"values,omitempty"`
 }
 
 // HasGaleraReadyCondition indicates whether the MariaDB object has a GaleraReady status condition.
@@ -376,3 +614,24 @@ func (m *MariaDB) HasGaleraNotReadyCondition() bool {
 func (m *MariaDB) HasGaleraConfiguredCondition() bool {
 	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeGaleraConfigured)
 }
+
+// IsGaleraArbitratorEnabled indicates whether the Galera arbitrator should be deployed.
+func (m *MariaDB) IsGaleraArbitratorEnabled() bool {
+	galera := m.Galera()
+	return galera.Enabled && galera.Arbitrator != nil && galera.Arbitrator.Enabled
+}
+
+// IsGaleraSegmentEnabled indicates whether the Galera segments are derived from the Node topology.
+func (m *MariaDB) IsGaleraSegmentEnabled() bool {
+	galera := m.Galera()
+	return galera.Enabled && galera.Segment != nil && galera.Segment.Enabled
+}
+
+// GaleraClusterSize returns the expected number of members of the Galera cluster, including the arbitrator when it is ready.
+func (m *MariaDB) GaleraClusterSize() int {
+	size := int(m.ScaledReplicas())
+	if m.IsGaleraArbitratorEnabled() && m.Status.GaleraArbitrator != nil && m.Status.GaleraArbitrator.Ready {
+		size++
+	}
+	return size
+}
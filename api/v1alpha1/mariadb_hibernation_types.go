@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Hibernate defines the hibernation of a MariaDB, which is scaled down to zero Pods while preserving its PVCs, Secrets and status.
+type Hibernate struct {
+	// Enabled scales the MariaDB down to zero Pods. Once disabled, the MariaDB is scaled back to 'spec.replicas'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// FinalBackup defines a Backup to be taken before scaling down. The MariaDB is not hibernated until the Backup has completed successfully.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FinalBackup *HibernateBackup `json:"finalBackup,omitempty"`
+}
+
+// Validate determines whether a Hibernate is valid.
+func (h *Hibernate) Validate() error {
+	if h.FinalBackup == nil {
+		return nil
+	}
+	if err := h.FinalBackup.Storage.Validate(); err != nil {
+		return fmt.Errorf("invalid finalBackup storage: %v", err)
+	}
+	if h.FinalBackup.Compression != nil {
+		if err := h.FinalBackup.Compression.Validate(); err != nil {
+			return fmt.Errorf("invalid finalBackup compression: %v", err)
+		}
+	}
+	return nil
+}
+
+// HibernateBackup defines the Backup taken before hibernating.
+type HibernateBackup struct {
+	// Storage to be used in the Backup.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Storage BackupStorage `json:"storage"`
+	// Compression defines the compression stage of the Backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Compression *BackupCompression `json:"compression,omitempty"`
+}
+
+// HibernationStatus is the state of the hibernation.
+type HibernationStatus struct {
+	// FinalBackup is the name of the Backup taken before hibernating.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FinalBackup *string `json:"finalBackup,omitempty"`
+	// HibernatedAt is the time when the MariaDB was scaled down to zero Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	HibernatedAt *metav1.Time `json:"hibernatedAt,omitempty"`
+}
+
+// IsHibernateEnabled indicates whether the hibernation has been requested.
+func (m *MariaDB) IsHibernateEnabled() bool {
+	return m.Spec.Hibernate != nil && m.Spec.Hibernate.Enabled
+}
+
+// IsHibernated indicates whether the MariaDB has been scaled down to zero Pods.
+func (m *MariaDB) IsHibernated() bool {
+	return m.Status.Hibernation != nil && m.Status.Hibernation.HibernatedAt != nil
+}
+
+// HibernationBackupKey defines the key for the Backup taken before hibernating at the given time.
+func (m *MariaDB) HibernationBackupKey(now time.Time) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-hibernation-%d", m.Name, now.Unix()),
+		Namespace: m.Namespace,
+	}
+}
@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultHistoryLimit int32 = 10
+
+// HistoryEntryType defines the type of an entry of the MariaDB history.
+type HistoryEntryType string
+
+const (
+	// HistoryEntryTypeSpecChange indicates that the key fields of the MariaDB spec have been changed.
+	HistoryEntryTypeSpecChange HistoryEntryType = "SpecChange"
+	// HistoryEntryTypeAction indicates that the operator has performed an action on the MariaDB, i.e. a primary failover.
+	HistoryEntryTypeAction HistoryEntryType = "Action"
+)
+
+// FieldChange defines the previous and the new values of a field.
+type FieldChange struct {
+	// Field is the path of the field within the spec.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Field string `json:"field"`
+	// Old is the previous value of the field.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Old string `json:"old,omitempty"`
+	// New is the current value of the field.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	New string `json:"new,omitempty"`
+}
+
+// HistoryEntry is an audit record of a spec change or an action performed by the operator.
+type HistoryEntry struct {
+	// Time when the change or the action was observed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Time metav1.Time `json:"time"`
+	// Type of the entry.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Type HistoryEntryType `json:"type"`
+	// Actor that performed the change. For spec changes, it is the field manager that last updated the spec,
+	// for actions, it is the operator component that performed them.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Actor string `json:"actor,omitempty"`
+	// Reason of the entry.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable description of the entry.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+	// Changes contains the key fields that have been changed, only set in SpecChange entries.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// HistoryLimit returns the maximum number of history entries to be kept in the status.
+func (m *MariaDB) HistoryLimit() int32 {
+	if m.Spec.HistoryLimit != nil {
+		return *m.Spec.HistoryLimit
+	}
+	return defaultHistoryLimit
+}
+
+// AddHistoryEntry appends an entry to the history, removing the oldest entries when the limit is exceeded.
+func (s *MariaDBStatus) AddHistoryEntry(entry HistoryEntry, limit int32) {
+	if limit <= 0 {
+		s.History = nil
+		return
+	}
+	s.History = append(s.History, entry)
+	if overflow := len(s.History) - int(limit); overflow > 0 {
+		s.History = s.History[overflow:]
+	}
+}
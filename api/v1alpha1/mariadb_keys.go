@@ -12,7 +12,7 @@ import (
 func (m *MariaDB) RootPasswordSecretKeyRef() corev1.SecretKeySelector {
 	return corev1.SecretKeySelector{
 		LocalObjectReference: corev1.LocalObjectReference{
-			Name: fmt.Sprintf("%s-root", m.Name),
+			Name: m.generatedName(NamingRoot),
 		},
 		Key: "password",
 	}
@@ -22,7 +22,7 @@ func (m *MariaDB) RootPasswordSecretKeyRef() corev1.SecretKeySelector {
 func (m *MariaDB) PasswordSecretKeyRef() corev1.SecretKeySelector {
 	return corev1.SecretKeySelector{
 		LocalObjectReference: corev1.LocalObjectReference{
-			Name: fmt.Sprintf("%s-password", m.Name),
+			Name: m.generatedName(NamingPassword),
 		},
 		Key: "password",
 	}
@@ -32,16 +32,48 @@ func (m *MariaDB) PasswordSecretKeyRef() corev1.SecretKeySelector {
 func (m *MariaDB) MyCnfConfigMapKeyRef() corev1.ConfigMapKeySelector {
 	return corev1.ConfigMapKeySelector{
 		LocalObjectReference: corev1.LocalObjectReference{
-			Name: fmt.Sprintf("%s-config", m.Name),
+			Name: m.generatedName(NamingConfig),
 		},
 		Key: "my.cnf",
 	}
 }
 
+// ServiceKey defines the key for the default Service
+func (m *MariaDB) ServiceKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingService),
+		Namespace: m.Namespace,
+	}
+}
+
+// ConnectionKey defines the key for the default Connection
+func (m *MariaDB) ConnectionKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingConnection),
+		Namespace: m.Namespace,
+	}
+}
+
 // RestoreKey defines the key for the Restore resource used to bootstrap.
 func (m *MariaDB) RestoreKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-restore", m.Name),
+		Name:      m.generatedName(NamingRestore),
+		Namespace: m.Namespace,
+	}
+}
+
+// SeedDataJobKey defines the key for the Job that loads the seed data.
+func (m *MariaDB) SeedDataJobKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingSeedData),
+		Namespace: m.Namespace,
+	}
+}
+
+// ProvisioningJobKey defines the key for the Job that provisions the replica with the given Pod index.
+func (m *MariaDB) ProvisioningJobKey(podIndex int) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%d", m.generatedName(NamingProvisioning), podIndex),
 		Namespace: m.Namespace,
 	}
 }
@@ -49,7 +81,7 @@ func (m *MariaDB) RestoreKey() types.NamespacedName {
 // InternalServiceKey defines the key for the internal headless Service
 func (m *MariaDB) InternalServiceKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-internal", m.Name),
+		Name:      m.generatedName(NamingInternal),
 		Namespace: m.Namespace,
 	}
 }
@@ -57,7 +89,7 @@ func (m *MariaDB) InternalServiceKey() types.NamespacedName {
 // PrimaryServiceKey defines the key for the primary Service
 func (m *MariaDB) PrimaryServiceKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-primary", m.Name),
+		Name:      m.generatedName(NamingPrimary),
 		Namespace: m.Namespace,
 	}
 }
@@ -65,7 +97,7 @@ func (m *MariaDB) PrimaryServiceKey() types.NamespacedName {
 // PrimaryConnectioneKey defines the key for the primary Connection
 func (m *MariaDB) PrimaryConnectioneKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-primary", m.Name),
+		Name:      m.generatedName(NamingPrimaryConnection),
 		Namespace: m.Namespace,
 	}
 }
@@ -73,7 +105,15 @@ func (m *MariaDB) PrimaryConnectioneKey() types.NamespacedName {
 // SecondaryServiceKey defines the key for the secondary Service
 func (m *MariaDB) SecondaryServiceKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-secondary", m.Name),
+		Name:      m.generatedName(NamingSecondary),
+		Namespace: m.Namespace,
+	}
+}
+
+// NamedSecondaryServiceKey defines the key for an additional secondary Service
+func (m *MariaDB) NamedSecondaryServiceKey(name string) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.namingOrDefault().apply(fmt.Sprintf("%s-%s", m.Name, name)),
 		Namespace: m.Namespace,
 	}
 }
@@ -81,7 +121,7 @@ func (m *MariaDB) SecondaryServiceKey() types.NamespacedName {
 // SecondaryConnectioneKey defines the key for the secondary Connection
 func (m *MariaDB) SecondaryConnectioneKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-secondary", m.Name),
+		Name:      m.generatedName(NamingSecondaryConnection),
 		Namespace: m.Namespace,
 	}
 }
@@ -89,7 +129,47 @@ func (m *MariaDB) SecondaryConnectioneKey() types.NamespacedName {
 // MetricsKey defines the key for the metrics related resources
 func (m *MariaDB) MetricsKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      fmt.Sprintf("%s-metrics", m.Name),
+		Name:      m.generatedName(NamingMetrics),
+		Namespace: m.Namespace,
+	}
+}
+
+// WsrepNotifyKey defines the key for the wsrep_notify_cmd ConfigMap
+func (m *MariaDB) WsrepNotifyKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingWsrepNotify),
+		Namespace: m.Namespace,
+	}
+}
+
+// GaleraArbitratorKey defines the key for the Galera arbitrator Deployment
+func (m *MariaDB) GaleraArbitratorKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingGaleraArbitrator),
+		Namespace: m.Namespace,
+	}
+}
+
+// TLSKey defines the key for the server certificate Secret, and the cert-manager Certificate that issues it
+func (m *MariaDB) TLSKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingTLS),
+		Namespace: m.Namespace,
+	}
+}
+
+// TLSCAKey defines the key for the Secret containing the CA that issues the server certificate when it is managed by the operator
+func (m *MariaDB) TLSCAKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.generatedName(NamingTLSCA),
+		Namespace: m.Namespace,
+	}
+}
+
+// CrashDiagnosticsKey defines the key for the ConfigMap containing the diagnostics of a crashed container
+func (m *MariaDB) CrashDiagnosticsKey(podName string, restartCount int32) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-crash-%d", podName, restartCount),
 		Namespace: m.Namespace,
 	}
 }
@@ -98,7 +178,27 @@ func (m *MariaDB) MetricsKey() types.NamespacedName {
 func (m *MariaDB) MetricsPasswordSecretKeyRef() corev1.SecretKeySelector {
 	return corev1.SecretKeySelector{
 		LocalObjectReference: corev1.LocalObjectReference{
-			Name: fmt.Sprintf("%s-metrics-password", m.Name),
+			Name: m.generatedName(NamingMetricsPassword),
+		},
+		Key: "password",
+	}
+}
+
+// OperatorAccountPasswordSecretKeyRef defines the key selector for the operator account password Secret.
+func (m *MariaDB) OperatorAccountPasswordSecretKeyRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: m.generatedName(NamingOperatorPassword),
+		},
+		Key: "password",
+	}
+}
+
+// ProbeAccountPasswordSecretKeyRef defines the key selector for the probe account password Secret.
+func (m *MariaDB) ProbeAccountPasswordSecretKeyRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: m.generatedName(NamingProbePassword),
 		},
 		Key: "password",
 	}
@@ -108,8 +208,38 @@ func (m *MariaDB) MetricsPasswordSecretKeyRef() corev1.SecretKeySelector {
 func (m *MariaDB) MetricsConfigSecretKeyRef() corev1.SecretKeySelector {
 	return corev1.SecretKeySelector{
 		LocalObjectReference: corev1.LocalObjectReference{
-			Name: fmt.Sprintf("%s-metrics-config", m.Name),
+			Name: m.generatedName(NamingMetricsConfig),
 		},
 		Key: "exporter.cnf",
 	}
 }
+
+// namingDefaultSuffixes are the suffixes of the resources whose default name does not match their NamingResource.
+var namingDefaultSuffixes = map[NamingResource]string{
+	NamingService:             "",
+	NamingConnection:          "",
+	NamingPrimaryConnection:   string(NamingPrimary),
+	NamingSecondaryConnection: string(NamingSecondary),
+}
+
+func (m *MariaDB) generatedName(resource NamingResource) string {
+	name := fmt.Sprintf("%s-%s", m.Name, resource)
+	if suffix, ok := namingDefaultSuffixes[resource]; ok {
+		name = m.Name
+		if suffix != "" {
+			name = fmt.Sprintf("%s-%s", m.Name, suffix)
+		}
+	}
+	naming := m.namingOrDefault()
+	if override, ok := naming.Overrides[resource]; ok {
+		return override
+	}
+	return naming.apply(name)
+}
+
+func (m *MariaDB) namingOrDefault() *Naming {
+	if m.Spec.Naming != nil {
+		return m.Spec.Naming
+	}
+	return &Naming{}
+}
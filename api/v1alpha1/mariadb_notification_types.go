@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NotificationEvent defines an event that can be notified.
+type NotificationEvent string
+
+const (
+	// NotificationEventFailover is sent when the operator performs an automatic primary failover.
+	NotificationEventFailover NotificationEvent = "Failover"
+	// NotificationEventBackupFailed is sent when a Backup fails.
+	NotificationEventBackupFailed NotificationEvent = "BackupFailed"
+	// NotificationEventRecoveryStarted is sent when the operator starts recovering a Galera cluster.
+	NotificationEventRecoveryStarted NotificationEvent = "RecoveryStarted"
+	// NotificationEventUpgradeCompleted is sent when all the Pods have been upgraded to a new image.
+	NotificationEventUpgradeCompleted NotificationEvent = "UpgradeCompleted"
+	// NotificationEventPasswordExpiring is sent when the password of a User is about to expire or it has expired.
+	NotificationEventPasswordExpiring NotificationEvent = "PasswordExpiring"
+)
+
+// Validate returns an error if the NotificationEvent is not valid.
+func (n NotificationEvent) Validate() error {
+	switch n {
+	case NotificationEventFailover, NotificationEventBackupFailed, NotificationEventRecoveryStarted,
+		NotificationEventUpgradeCompleted, NotificationEventPasswordExpiring:
+		return nil
+	default:
+		return fmt.Errorf("invalid NotificationEvent: %v", n)
+	}
+}
+
+// NotificationTargetType defines the type of the notification target.
+type NotificationTargetType string
+
+const (
+	// NotificationTargetTypeWebhook sends the notification as a JSON object to a generic HTTP endpoint.
+	NotificationTargetTypeWebhook NotificationTargetType = "Webhook"
+	// NotificationTargetTypeSlack sends the notification to a Slack incoming webhook.
+	NotificationTargetTypeSlack NotificationTargetType = "Slack"
+	// NotificationTargetTypePagerDuty sends the notification to the PagerDuty Events API v2.
+	NotificationTargetTypePagerDuty NotificationTargetType = "PagerDuty"
+)
+
+// Validate returns an error if the NotificationTargetType is not valid.
+func (n NotificationTargetType) Validate() error {
+	switch n {
+	case NotificationTargetTypeWebhook, NotificationTargetTypeSlack, NotificationTargetTypePagerDuty:
+		return nil
+	default:
+		return fmt.Errorf("invalid NotificationTargetType: %v", n)
+	}
+}
+
+// NotificationTarget defines where the notifications are sent.
+type NotificationTarget struct {
+	// Name of the target.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Type of the target.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Webhook;Slack;PagerDuty
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Type NotificationTargetType `json:"type"`
+	// URLSecretKeyRef is a reference to a Secret key containing the URL of the Webhook and Slack targets.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	URLSecretKeyRef *corev1.SecretKeySelector `json:"urlSecretKeyRef,omitempty"`
+	// RoutingKeySecretKeyRef is a reference to a Secret key containing the integration key of the PagerDuty target.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RoutingKeySecretKeyRef *corev1.SecretKeySelector `json:"routingKeySecretKeyRef,omitempty"`
+	// Events to be notified. If not provided, all the events will be notified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Events []NotificationEvent `json:"events,omitempty"`
+}
+
+// Validate returns an error if the NotificationTarget is not valid.
+func (n *NotificationTarget) Validate() error {
+	if err := n.Type.Validate(); err != nil {
+		return err
+	}
+	switch n.Type {
+	case NotificationTargetTypeWebhook, NotificationTargetTypeSlack:
+		if n.URLSecretKeyRef == nil {
+			return fmt.Errorf("urlSecretKeyRef must be set in %s targets", n.Type)
+		}
+	case NotificationTargetTypePagerDuty:
+		if n.RoutingKeySecretKeyRef == nil {
+			return errors.New("routingKeySecretKeyRef must be set in PagerDuty targets")
+		}
+	}
+	for _, e := range n.Events {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Notifications defines the targets where the key events of the MariaDB are notified.
+type Notifications struct {
+	// Targets where the notifications are sent.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Targets []NotificationTarget `json:"targets,omitempty"`
+}
+
+// Validate returns an error if the Notifications are not valid.
+func (n *Notifications) Validate() error {
+	names := make(map[string]struct{}, len(n.Targets))
+	for i, t := range n.Targets {
+		if _, ok := names[t.Name]; ok {
+			return fmt.Errorf("duplicated target name '%s'", t.Name)
+		}
+		names[t.Name] = struct{}{}
+
+		if err := n.Targets[i].Validate(); err != nil {
+			return fmt.Errorf("invalid target '%s': %v", t.Name, err)
+		}
+	}
+	return nil
+}
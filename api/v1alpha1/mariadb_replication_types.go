@@ -1,7 +1,9 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +46,43 @@ func (w WaitPoint) MariaDBFormat() (string, error) {
 	}
 }
 
+// BinlogFormat defines the format of the binary log.
+// More info: https://mariadb.com/kb/en/binary-log-formats/.
+type BinlogFormat string
+
+const (
+	// BinlogFormatRow indicates that the binary log records the changes made to the individual rows.
+	BinlogFormatRow BinlogFormat = "Row"
+	// BinlogFormatMixed indicates that the binary log records statements, switching to rows for the statements that are not safe to replicate.
+	BinlogFormatMixed BinlogFormat = "Mixed"
+	// BinlogFormatStatement indicates that the binary log records the SQL statements.
+	BinlogFormatStatement BinlogFormat = "Statement"
+)
+
+// Validate returns an error if the BinlogFormat is not valid.
+func (b BinlogFormat) Validate() error {
+	switch b {
+	case BinlogFormatRow, BinlogFormatMixed, BinlogFormatStatement:
+		return nil
+	default:
+		return fmt.Errorf("invalid BinlogFormat: %v", b)
+	}
+}
+
+// MariaDBFormat formats the BinlogFormat so it can be used in MariaDB config files.
+func (b BinlogFormat) MariaDBFormat() (string, error) {
+	switch b {
+	case BinlogFormatRow:
+		return "ROW", nil
+	case BinlogFormatMixed:
+		return "MIXED", nil
+	case BinlogFormatStatement:
+		return "STATEMENT", nil
+	default:
+		return "", fmt.Errorf("invalid BinlogFormat: %v", b)
+	}
+}
+
 // Gtid indicates which Global Transaction ID should be used when connecting a replica to the master.
 // See: https://mariadb.com/kb/en/gtid/#using-current_pos-vs-slave_pos.
 type Gtid string
@@ -78,6 +117,157 @@ func (g Gtid) MariaDBFormat() (string, error) {
 	}
 }
 
+// ParallelMode defines how the replica applies events in parallel.
+// More info: https://mariadb.com/kb/en/parallel-replication/#configuring-parallel-replication.
+type ParallelMode string
+
+const (
+	// ParallelModeNone disables parallel apply of events in the replica.
+	ParallelModeNone ParallelMode = "None"
+	// ParallelModeMinimal only parallelizes the commit steps of transactions.
+	ParallelModeMinimal ParallelMode = "Minimal"
+	// ParallelModeConservative limits parallelism to transactions that group-committed together on the primary.
+	ParallelModeConservative ParallelMode = "Conservative"
+	// ParallelModeOptimistic applies transactions in parallel and rolls back and retries them when a conflict is detected.
+	ParallelModeOptimistic ParallelMode = "Optimistic"
+	// ParallelModeAggressive is like Optimistic, but it also parallelizes transactions that had a conflict on the primary.
+	ParallelModeAggressive ParallelMode = "Aggressive"
+)
+
+// Validate returns an error if the ParallelMode is not valid.
+func (p ParallelMode) Validate() error {
+	switch p {
+	case ParallelModeNone, ParallelModeMinimal, ParallelModeConservative, ParallelModeOptimistic, ParallelModeAggressive:
+		return nil
+	default:
+		return fmt.Errorf("invalid ParallelMode: %v", p)
+	}
+}
+
+// MariaDBFormat formats the ParallelMode so it can be used in MariaDB config files.
+func (p ParallelMode) MariaDBFormat() (string, error) {
+	switch p {
+	case ParallelModeNone:
+		return "none", nil
+	case ParallelModeMinimal:
+		return "minimal", nil
+	case ParallelModeConservative:
+		return "conservative", nil
+	case ParallelModeOptimistic:
+		return "optimistic", nil
+	case ParallelModeAggressive:
+		return "aggressive", nil
+	default:
+		return "", fmt.Errorf("invalid ParallelMode: %v", p)
+	}
+}
+
+// KillPolicy defines what to do with the queries still running in the primary once the draining grace period has elapsed.
+type KillPolicy string
+
+const (
+	// KillPolicyNone does not kill any query, the switchover waits for them to finish. This is the default KillPolicy.
+	KillPolicyNone KillPolicy = "None"
+	// KillPolicyQuery kills the running queries, keeping the client connections open.
+	KillPolicyQuery KillPolicy = "Query"
+	// KillPolicyConnection kills the connections with running queries.
+	KillPolicyConnection KillPolicy = "Connection"
+)
+
+// Validate returns an error if the KillPolicy is not valid.
+func (k KillPolicy) Validate() error {
+	switch k {
+	case KillPolicyNone, KillPolicyQuery, KillPolicyConnection:
+		return nil
+	default:
+		return fmt.Errorf("invalid KillPolicy: %v", k)
+	}
+}
+
+// ConnectionDraining defines how the queries running in the primary are drained before it is locked during a switchover.
+type ConnectionDraining struct {
+	// GracePeriod is the time to wait for the running queries to finish before applying the KillPolicy. It defaults to 30s.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// KillPolicy defines what to do with the queries still running once the GracePeriod has elapsed. It defaults to None.
+	// +optional
+	// +kubebuilder:validation:Enum=None;Query;Connection
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KillPolicy KillPolicy `json:"killPolicy,omitempty"`
+	// ExemptUsers are the users whose queries are never killed, for example the ones running batch jobs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ExemptUsers []string `json:"exemptUsers,omitempty"`
+}
+
+// GracePeriodOrDefault returns the draining grace period, falling back to the default.
+func (c *ConnectionDraining) GracePeriodOrDefault() time.Duration {
+	if c.GracePeriod != nil {
+		return c.GracePeriod.Duration
+	}
+	return 30 * time.Second
+}
+
+// KillPolicyOrDefault returns the kill policy, falling back to the default.
+func (c *ConnectionDraining) KillPolicyOrDefault() KillPolicy {
+	if c.KillPolicy != "" {
+		return c.KillPolicy
+	}
+	return KillPolicyNone
+}
+
+// IsExempt indicates whether the queries of a user must not be killed.
+func (c *ConnectionDraining) IsExempt(user string) bool {
+	for _, u := range c.ExemptUsers {
+		if u == user {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if the ConnectionDraining is not valid.
+func (c *ConnectionDraining) Validate() error {
+	if c.GracePeriod != nil && c.GracePeriod.Duration < 0 {
+		return errors.New("GracePeriod must not be negative")
+	}
+	if c.KillPolicy != "" {
+		if err := c.KillPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fencing defines how the old primary is fenced during an automatic failover, before a replica is promoted.
+type Fencing struct {
+	// Timeout is the time to wait for the old primary to be fenced via SQL, which is not possible when it is unreachable. It defaults to 5s.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// DeletePod deletes the old primary Pod after fencing it, which guarantees that its connections are closed even when it is unreachable.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	DeletePod bool `json:"deletePod,omitempty"`
+}
+
+// TimeoutOrDefault returns the fencing timeout, falling back to the default.
+func (f *Fencing) TimeoutOrDefault() time.Duration {
+	if f.Timeout != nil {
+		return f.Timeout.Duration
+	}
+	return 5 * time.Second
+}
+
+// Validate returns an error if the Fencing is not valid.
+func (f *Fencing) Validate() error {
+	if f.Timeout != nil && f.Timeout.Duration <= 0 {
+		return errors.New("Timeout must be greater than zero")
+	}
+	return nil
+}
+
 // PrimaryReplication is the replication configuration for the primary node.
 type PrimaryReplication struct {
 	// PodIndex is the StatefulSet index of the primary node. The user may change this field to perform a manual switchover.
@@ -88,6 +278,55 @@ type PrimaryReplication struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	AutomaticFailover *bool `json:"automaticFailover,omitempty"`
+	// FailoverDelay is the time that the primary Pod must remain not ready before an automatic failover is performed.
+	// It prevents failing over on transient failures. By default, the failover is performed as soon as the primary Pod is not ready.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FailoverDelay *metav1.Duration `json:"failoverDelay,omitempty"`
+	// FailoverCooldown is the minimum time between a primary switch and the next automatic failover.
+	// It prevents the primary from flapping between Pods. By default, there is no cooldown.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FailoverCooldown *metav1.Duration `json:"failoverCooldown,omitempty"`
+	// FailoverRetryInterval is the time to wait before retrying an automatic failover when there are no healthy replicas available.
+	// By default, the failover is retried with exponential backoff.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FailoverRetryInterval *metav1.Duration `json:"failoverRetryInterval,omitempty"`
+	// ConnectionDraining defines how the queries running in the current primary are drained before switching the primary.
+	// By default, the primary is locked straight away, waiting for the running queries to finish.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ConnectionDraining *ConnectionDraining `json:"connectionDraining,omitempty"`
+	// Fencing enables fencing the old primary during an automatic failover: it is set to read_only and its connections are killed
+	// before promoting a replica, preventing writes in the old primary when it comes back.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Fencing *Fencing `json:"fencing,omitempty"`
+}
+
+// Validate returns an error if the PrimaryReplication is not valid.
+func (r *PrimaryReplication) Validate() error {
+	if r.FailoverDelay != nil && r.FailoverDelay.Duration < 0 {
+		return errors.New("FailoverDelay must not be negative")
+	}
+	if r.FailoverCooldown != nil && r.FailoverCooldown.Duration < 0 {
+		return errors.New("FailoverCooldown must not be negative")
+	}
+	if r.FailoverRetryInterval != nil && r.FailoverRetryInterval.Duration < 0 {
+		return errors.New("FailoverRetryInterval must not be negative")
+	}
+	if r.ConnectionDraining != nil {
+		if err := r.ConnectionDraining.Validate(); err != nil {
+			return fmt.Errorf("invalid ConnectionDraining: %v", err)
+		}
+	}
+	if r.Fencing != nil {
+		if err := r.Fencing.Validate(); err != nil {
+			return fmt.Errorf("invalid Fencing: %v", err)
+		}
+	}
+	return nil
 }
 
 // FillWithDefaults fills the current PrimaryReplication object with DefaultReplicationSpec.
@@ -103,6 +342,65 @@ func (r *PrimaryReplication) FillWithDefaults() {
 	}
 }
 
+// ReplicaProvisioningSource defines where the data of a new replica is taken from.
+type ReplicaProvisioningSource string
+
+const (
+	// ReplicaProvisioningSourceBackup restores the most recent backup taken by a Backup.
+	// This is the default ReplicaProvisioningSource.
+	ReplicaProvisioningSourceBackup ReplicaProvisioningSource = "Backup"
+	// ReplicaProvisioningSourcePrimary takes a logical dump of the primary and loads it into the replica.
+	ReplicaProvisioningSourcePrimary ReplicaProvisioningSource = "Primary"
+)
+
+// Validate returns an error if the ReplicaProvisioningSource is not valid.
+func (s ReplicaProvisioningSource) Validate() error {
+	switch s {
+	case ReplicaProvisioningSourceBackup, ReplicaProvisioningSourcePrimary:
+		return nil
+	default:
+		return fmt.Errorf("invalid ReplicaProvisioningSource: %v", s)
+	}
+}
+
+// ReplicaProvisioning defines how new replicas are seeded before starting replication,
+// so they don't need to replicate the full binary log history of the primary.
+type ReplicaProvisioning struct {
+	// Source is where the data of the new replicas is taken from. It defaults to Backup.
+	// +optional
+	// +kubebuilder:validation:Enum=Backup;Primary
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Source ReplicaProvisioningSource `json:"source,omitempty"`
+	// BackupRef is a reference to the Backup restored in the new replicas when using the Backup source.
+	// If not provided, the Backup of the MariaDB with the most recent backup is used.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BackupRef *corev1.LocalObjectReference `json:"backupRef,omitempty"`
+	// Resources describes the compute resource requirements of the provisioning Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SourceOrDefault returns the Source, defaulting to Backup.
+func (p *ReplicaProvisioning) SourceOrDefault() ReplicaProvisioningSource {
+	if p.Source == "" {
+		return ReplicaProvisioningSourceBackup
+	}
+	return p.Source
+}
+
+// Validate returns an error if the ReplicaProvisioning is not valid.
+func (p *ReplicaProvisioning) Validate() error {
+	if err := p.SourceOrDefault().Validate(); err != nil {
+		return err
+	}
+	if p.BackupRef != nil && p.SourceOrDefault() != ReplicaProvisioningSourceBackup {
+		return fmt.Errorf("backupRef is only supported with the '%s' source", ReplicaProvisioningSourceBackup)
+	}
+	return nil
+}
+
 // ReplicaReplication is the replication configuration for the replica nodes.
 type ReplicaReplication struct {
 	// WaitPoint defines whether the transaction should wait for ACK before committing to the storage engine.
@@ -134,6 +432,39 @@ type ReplicaReplication struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SyncTimeout *metav1.Duration `json:"syncTimeout,omitempty"`
+	// ParallelThreads is the number of threads used by the replica to apply events in parallel. 0 disables parallel replication.
+	// More info: https://mariadb.com/kb/en/replication-and-binary-log-system-variables/#slave_parallel_threads.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	ParallelThreads *int32 `json:"parallelThreads,omitempty"`
+	// ParallelMode defines how the replica applies events in parallel. It requires ParallelThreads to be greater than 0.
+	// More info: https://mariadb.com/kb/en/replication-and-binary-log-system-variables/#slave_parallel_mode.
+	// +optional
+	// +kubebuilder:validation:Enum=None;Minimal;Conservative;Optimistic;Aggressive
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ParallelMode *ParallelMode `json:"parallelMode,omitempty"`
+	// ParallelMaxQueued is the maximum amount of memory in bytes that each parallel thread can use for queueing events.
+	// More info: https://mariadb.com/kb/en/replication-and-binary-log-system-variables/#slave_parallel_max_queued.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	ParallelMaxQueued *int64 `json:"parallelMaxQueued,omitempty"`
+	// Provisioning defines how the replicas added when scaling up are seeded before starting replication.
+	// If not provided, new replicas start with an empty data directory and replicate the full binary log history of the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Provisioning *ReplicaProvisioning `json:"provisioning,omitempty"`
+	// MaxLag is the maximum replication lag allowed for a replica to be served by the secondary Services.
+	// Replicas lagging behind or with stopped replication are moved to the not ready addresses of the secondary Endpoints.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxLag *metav1.Duration `json:"maxLag,omitempty"`
+}
+
+// IsParallelReplicationEnabled indicates whether the replica applies events in parallel.
+func (r *ReplicaReplication) IsParallelReplicationEnabled() bool {
+	return r.ParallelThreads != nil && *r.ParallelThreads > 0
 }
 
 // FillWithDefaults fills the current ReplicaReplication object with DefaultReplicationSpec.
@@ -173,6 +504,339 @@ func (r *ReplicaReplication) Validate() error {
 			return fmt.Errorf("invalid GTID: %v", err)
 		}
 	}
+	if r.Provisioning != nil {
+		if err := r.Provisioning.Validate(); err != nil {
+			return fmt.Errorf("invalid Provisioning: %v", err)
+		}
+	}
+	if r.MaxLag != nil && r.MaxLag.Duration <= 0 {
+		return errors.New("MaxLag must be greater than 0")
+	}
+	if r.ParallelMode != nil {
+		if err := r.ParallelMode.Validate(); err != nil {
+			return fmt.Errorf("invalid ParallelMode: %v", err)
+		}
+		if *r.ParallelMode != ParallelModeNone && !r.IsParallelReplicationEnabled() {
+			return fmt.Errorf("ParallelMode '%s' requires ParallelThreads to be greater than 0", *r.ParallelMode)
+		}
+		if (*r.ParallelMode == ParallelModeOptimistic || *r.ParallelMode == ParallelModeAggressive) && r.Gtid == nil {
+			return fmt.Errorf("ParallelMode '%s' requires replicas to connect using GTID", *r.ParallelMode)
+		}
+	}
+	return nil
+}
+
+// SemiSyncReplication defines the semi-synchronous replication settings of the primary.
+// MariaDB always waits for the ACK of a single replica, the number of ACKs cannot be configured.
+// More info: https://mariadb.com/kb/en/semisynchronous-replication/.
+type SemiSyncReplication struct {
+	// Timeout is how long the primary waits for a replica ACK before falling back to asynchronous replication.
+	// It defaults to the replica connectionTimeout.
+	// More info: https://mariadb.com/kb/en/semisynchronous-replication/#rpl_semi_sync_master_timeout.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// WaitNoSlave defines whether the primary keeps waiting for ACKs when no replicas are connected, until the timeout is reached.
+	// When disabled, the primary falls back to asynchronous replication as soon as there are no replicas connected.
+	// More info: https://mariadb.com/kb/en/semisynchronous-replication/#rpl_semi_sync_master_wait_no_slave.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	WaitNoSlave *bool `json:"waitNoSlave,omitempty"`
+}
+
+// Validate returns an error if the SemiSyncReplication is not valid.
+func (s *SemiSyncReplication) Validate() error {
+	if s.Timeout != nil && s.Timeout.Duration < 0 {
+		return fmt.Errorf("timeout must not be negative, got %v", s.Timeout.Duration)
+	}
+	return nil
+}
+
+// SemiSyncStatus reports the effective semi-synchronous replication settings and state of the primary.
+type SemiSyncStatus struct {
+	// Enabled indicates whether semi-synchronous replication is enabled in the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Enabled bool `json:"enabled,omitempty"`
+	// Active indicates whether the primary is currently replicating semi-synchronously.
+	// It is false when the primary has fallen back to asynchronous replication after reaching the timeout.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Active bool `json:"active,omitempty"`
+	// Timeout is the effective 'rpl_semi_sync_master_timeout'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// WaitPoint is the effective 'rpl_semi_sync_master_wait_point'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	WaitPoint string `json:"waitPoint,omitempty"`
+	// WaitNoSlave is the effective 'rpl_semi_sync_master_wait_no_slave'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	WaitNoSlave bool `json:"waitNoSlave,omitempty"`
+	// Clients is the number of semi-synchronous replicas connected to the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Clients int `json:"clients,omitempty"`
+}
+
+// SwitchoverStatus is the state of an ongoing primary switchover.
+type SwitchoverStatus struct {
+	// FromIndex is the Pod index of the primary being demoted.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FromIndex int `json:"fromIndex"`
+	// ToIndex is the Pod index of the replica being promoted.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ToIndex int `json:"toIndex"`
+	// Phase is the switchover phase currently being performed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Phase string `json:"phase,omitempty"`
+	// StartTime is when the switchover started.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime metav1.Time `json:"startTime"`
+	// DrainStartTime is when the connections of the primary started being drained.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DrainStartTime *metav1.Time `json:"drainStartTime,omitempty"`
+}
+
+// ExternalReplicationTLS defines the TLS options used to connect to an external primary.
+type ExternalReplicationTLS struct {
+	// Enabled is a flag to enable TLS in the connection to the external primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled"`
+	// CASecretKeyRef is a reference to a Secret key containing a CA bundle in PEM format used to verify the external primary certificate.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CASecretKeyRef *corev1.SecretKeySelector `json:"caSecretKeyRef,omitempty"`
+	// VerifyServerCert enables the verification of the external primary certificate hostname.
+	// More info: https://mariadb.com/kb/en/change-master-to/#master_ssl_verify_server_cert.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	VerifyServerCert bool `json:"verifyServerCert,omitempty"`
+}
+
+// ExternalReplication defines a primary running outside of the cluster to replicate from.
+// The primary Pod replicates from the external primary and the rest of the replicas replicate from the primary Pod.
+type ExternalReplication struct {
+	// Host is the hostname of the external primary.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Host string `json:"host"`
+	// Port is the port of the external primary. It defaults to 3306.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Port int32 `json:"port,omitempty"`
+	// Username is the user used to replicate from the external primary. It requires the REPLICATION SLAVE privilege.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username"`
+	// PasswordSecretKeyRef is a reference to the Secret key containing the password of the replication user.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef"`
+	// TLS defines the TLS options used to connect to the external primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TLS *ExternalReplicationTLS `json:"tls,omitempty"`
+	// GtidDomainId is the 'gtid_domain_id' of the transactions executed in the cluster, for example the users and databases created by the operator.
+	// It must not be used by the external primary, as this domain is ignored when replicating from it. It defaults to 1.
+	// More info: https://mariadb.com/kb/en/gtid/#gtid_domain_id.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	GtidDomainId *uint32 `json:"gtidDomainId,omitempty"`
+}
+
+// PortOrDefault returns the port of the external primary, falling back to the default.
+func (e *ExternalReplication) PortOrDefault() int32 {
+	if e.Port != 0 {
+		return e.Port
+	}
+	return 3306
+}
+
+// GtidDomainIdOrDefault returns the GTID domain of the transactions executed in the cluster, falling back to the default.
+func (e *ExternalReplication) GtidDomainIdOrDefault() uint32 {
+	if e.GtidDomainId != nil {
+		return *e.GtidDomainId
+	}
+	return 1
+}
+
+// IsTLSEnabled indicates whether TLS is used to connect to the external primary.
+func (e *ExternalReplication) IsTLSEnabled() bool {
+	return e.TLS != nil && e.TLS.Enabled
+}
+
+// Validate returns an error if the ExternalReplication is not valid.
+func (e *ExternalReplication) Validate() error {
+	if e.Host == "" {
+		return errors.New("host must be set")
+	}
+	if e.Port < 0 || e.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", e.Port)
+	}
+	if e.Username == "" {
+		return errors.New("username must be set")
+	}
+	if e.PasswordSecretKeyRef.Name == "" || e.PasswordSecretKeyRef.Key == "" {
+		return errors.New("passwordSecretKeyRef must be set")
+	}
+	if e.TLS != nil && !e.TLS.Enabled && (e.TLS.CASecretKeyRef != nil || e.TLS.VerifyServerCert) {
+		return errors.New("TLS options require TLS to be enabled")
+	}
+	return nil
+}
+
+// ReservedReplicationChannel is the name of the replication connection used by the operator to replicate from the primary,
+// which cannot be used by the replication channels.
+const ReservedReplicationChannel = "mariadb-operator"
+
+var replicationChannelNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ReplicationChannel is a named replication connection to an upstream server.
+type ReplicationChannel struct {
+	// Name of the channel, used as replication connection name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Host is the hostname of the upstream server.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Host string `json:"host"`
+	// Port is the port of the upstream server. It defaults to 3306.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Port int32 `json:"port,omitempty"`
+	// Username is the user used to replicate from the upstream server. It requires the REPLICATION SLAVE privilege.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username"`
+	// PasswordSecretKeyRef is a reference to the Secret key containing the password of the replication user.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef"`
+	// Gtid indicates which Global Transaction ID should be used when connecting to the upstream server. It defaults to CurrentPos.
+	// See: https://mariadb.com/kb/en/gtid/#using-current_pos-vs-slave_pos.
+	// +optional
+	// +kubebuilder:validation:Enum=CurrentPos;SlavePos
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Gtid *Gtid `json:"gtid,omitempty"`
+	// TLS defines the TLS options used to connect to the upstream server.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TLS *ExternalReplicationTLS `json:"tls,omitempty"`
+}
+
+// PortOrDefault returns the port of the upstream server, falling back to the default.
+func (c *ReplicationChannel) PortOrDefault() int32 {
+	if c.Port != 0 {
+		return c.Port
+	}
+	return 3306
+}
+
+// GtidOrDefault returns the Gtid used to connect to the upstream server, falling back to the default.
+func (c *ReplicationChannel) GtidOrDefault() Gtid {
+	if c.Gtid != nil {
+		return *c.Gtid
+	}
+	return GtidCurrentPos
+}
+
+// IsTLSEnabled indicates whether TLS is used to connect to the upstream server.
+func (c *ReplicationChannel) IsTLSEnabled() bool {
+	return c.TLS != nil && c.TLS.Enabled
+}
+
+// Validate returns an error if the ReplicationChannel is not valid.
+func (c *ReplicationChannel) Validate() error {
+	if !replicationChannelNameRegex.MatchString(c.Name) {
+		return fmt.Errorf("invalid name '%s', it must only contain alphanumeric characters, '-' and '_'", c.Name)
+	}
+	if c.Host == "" {
+		return errors.New("host must be set")
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+	if c.Username == "" {
+		return errors.New("username must be set")
+	}
+	if c.PasswordSecretKeyRef.Name == "" || c.PasswordSecretKeyRef.Key == "" {
+		return errors.New("passwordSecretKeyRef must be set")
+	}
+	if c.Gtid != nil {
+		if err := c.Gtid.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.TLS != nil && !c.TLS.Enabled && (c.TLS.CASecretKeyRef != nil || c.TLS.VerifyServerCert) {
+		return errors.New("TLS options require TLS to be enabled")
+	}
+	return nil
+}
+
+// ReplicationChannelStatus is the status of a replication channel.
+type ReplicationChannelStatus struct {
+	// Name of the channel.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Host is the hostname of the upstream server.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Host string `json:"host,omitempty"`
+	// IORunning indicates whether the channel is connected to the upstream server and receiving its binary log events.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IORunning bool `json:"ioRunning"`
+	// SQLRunning indicates whether the channel is applying the events received from the upstream server.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SQLRunning bool `json:"sqlRunning"`
+	// SecondsBehindSource is the replication lag of the channel, as reported by 'Seconds_Behind_Master'.
+	// It is not set when the replication threads are not running.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecondsBehindSource *int64 `json:"secondsBehindSource,omitempty"`
+	// GtidIOPos is the GTID position of the last event received from the upstream server.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GtidIOPos string `json:"gtidIOPos,omitempty"`
+	// LastError is the last error reported by the replication threads.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastError string `json:"lastError,omitempty"`
+	// ConfigHash is the hash of the configuration applied to the channel. The channel is reconfigured whenever it changes.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
+// ReplicationChannelsStatus is the status of the replication channels.
+type ReplicationChannelsStatus struct {
+	// Channels are the statuses of the replication channels, in the same order as in the spec.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Channels []ReplicationChannelStatus `json:"channels,omitempty"`
+	// LastCheckTime is the last time the replication channels were checked.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// Channel returns the status of a replication channel, or nil if not found.
+func (s *ReplicationChannelsStatus) Channel(name string) *ReplicationChannelStatus {
+	for i := range s.Channels {
+		if s.Channels[i].Name == name {
+			return &s.Channels[i]
+		}
+	}
 	return nil
 }
 
@@ -198,12 +862,61 @@ type ReplicationSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Replica *ReplicaReplication `json:"replica,omitempty"`
+	// SemiSync defines the semi-synchronous replication settings of the primary, which are applied dynamically.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SemiSync *SemiSyncReplication `json:"semiSync,omitempty"`
 	// SyncBinlog indicates whether the binary log should be synchronized to the disk after every event.
 	// It trades off performance for consistency.
 	// See: https://mariadb.com/kb/en/replication-and-binary-log-system-variables/#sync_binlog.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	SyncBinlog *bool `json:"syncBinlog,omitempty"`
+	// External defines a primary running outside of the cluster to replicate from, for example to migrate an existing database into Kubernetes.
+	// When set, the primary Pod is read-only and replicates from the external primary, the rest of the replicas replicate from the primary Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	External *ExternalReplication `json:"external,omitempty"`
+	// GtidStrictMode enables 'gtid_strict_mode' in all the Pods, rejecting out of order GTIDs and transactions already applied.
+	// It is applied dynamically, one Pod at a time.
+	// More info: https://mariadb.com/kb/en/gtid/#gtid_strict_mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	GtidStrictMode *bool `json:"gtidStrictMode,omitempty"`
+	// GtidDomainId is the 'gtid_domain_id' of the transactions executed in the cluster. It is applied dynamically, one Pod at a time.
+	// It cannot be set when replicating from an external primary, 'external.gtidDomainId' must be used instead.
+	// More info: https://mariadb.com/kb/en/gtid/#gtid_domain_id.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	GtidDomainId *uint32 `json:"gtidDomainId,omitempty"`
+	// BinlogFormat is the 'binlog_format' of all the Pods. It is applied dynamically, one Pod at a time.
+	// More info: https://mariadb.com/kb/en/binary-log-formats/.
+	// +optional
+	// +kubebuilder:validation:Enum=Row;Mixed;Statement
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BinlogFormat *BinlogFormat `json:"binlogFormat,omitempty"`
+}
+
+// Validate determines whether a ReplicationSpec is valid.
+func (r ReplicationSpec) Validate() error {
+	if r.BinlogFormat != nil {
+		if err := r.BinlogFormat.Validate(); err != nil {
+			return fmt.Errorf("invalid BinlogFormat: %v", err)
+		}
+	}
+	if r.GtidDomainId != nil && r.External != nil {
+		return errors.New("GtidDomainId cannot be set when replicating from an external primary, 'external.gtidDomainId' must be used instead")
+	}
+	return nil
+}
+
+// EffectiveGtidDomainId returns the 'gtid_domain_id' to be set in the Pods, if any.
+func (r ReplicationSpec) EffectiveGtidDomainId() *uint32 {
+	if r.External != nil {
+		domainId := r.External.GtidDomainIdOrDefault()
+		return &domainId
+	}
+	return r.GtidDomainId
 }
 
 // FillWithDefaults fills the current ReplicationSpec object with DefaultReplicationSpec.
@@ -227,6 +940,17 @@ func (r *ReplicationSpec) FillWithDefaults() {
 	}
 }
 
+// SemiSyncTimeout returns the timeout of semi-synchronous replication, defaulting to the replica connection timeout.
+func (r ReplicationSpec) SemiSyncTimeout() time.Duration {
+	if r.SemiSync != nil && r.SemiSync.Timeout != nil {
+		return r.SemiSync.Timeout.Duration
+	}
+	if r.Replica != nil && r.Replica.ConnectionTimeout != nil {
+		return r.Replica.ConnectionTimeout.Duration
+	}
+	return DefaultReplicationSpec.Replica.ConnectionTimeout.Duration
+}
+
 var (
 	tenSeconds = metav1.Duration{Duration: 10 * time.Second}
 
@@ -257,7 +981,138 @@ func (m *MariaDB) HasConfiguredReplication() bool {
 	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeReplicationConfigured)
 }
 
+// SecondaryMaxLag returns the maximum replication lag allowed for the replicas served by the secondary Services, if any.
+func (m *MariaDB) SecondaryMaxLag() *time.Duration {
+	replication := m.Spec.Replication
+	if replication == nil || !replication.Enabled || replication.Replica == nil || replication.Replica.MaxLag == nil {
+		return nil
+	}
+	return &replication.Replica.MaxLag.Duration
+}
+
+// IsReplicatingFromExternal indicates whether the MariaDB replicates from a primary running outside of the cluster.
+func (m *MariaDB) IsReplicatingFromExternal() bool {
+	return m.Replication().Enabled && m.Replication().External != nil
+}
+
+// ReplicationChannelsPodIndex returns the index of the Pod where the replication channels are configured.
+func (m *MariaDB) ReplicationChannelsPodIndex() int {
+	if m.Replication().Enabled && m.Status.CurrentPrimaryPodIndex != nil {
+		return *m.Status.CurrentPrimaryPodIndex
+	}
+	return 0
+}
+
 // IsSwitchingPrimary indicates whether the primary is being switched.
 func (m *MariaDB) IsSwitchingPrimary() bool {
 	return meta.IsStatusConditionFalse(m.Status.Conditions, ConditionTypePrimarySwitched)
 }
+
+// ReplicaGtidStatus is the GTID consistency status of a replica compared to the primary.
+type ReplicaGtidStatus struct {
+	// CurrentPos is the 'gtid_current_pos' of the replica.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CurrentPos string `json:"currentPos,omitempty"`
+	// ErrantGtids are the GTIDs executed by the replica that have not been executed by the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ErrantGtids string `json:"errantGtids,omitempty"`
+	// MissingDomains are the replication domains of the primary that are not present in the replica.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	MissingDomains []uint32 `json:"missingDomains,omitempty"`
+}
+
+// HasErrantGtids indicates whether the replica has executed transactions that have not been executed by the primary.
+func (r *ReplicaGtidStatus) HasErrantGtids() bool {
+	return r.ErrantGtids != ""
+}
+
+// HasGaps indicates whether the replica is missing replication domains of the primary.
+func (r *ReplicaGtidStatus) HasGaps() bool {
+	return len(r.MissingDomains) > 0
+}
+
+// GtidStatus is the result of comparing the GTID positions of the primary and the replicas.
+type GtidStatus struct {
+	// PrimaryCurrentPos is the 'gtid_current_pos' of the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PrimaryCurrentPos string `json:"primaryCurrentPos,omitempty"`
+	// Replicas are the GTID consistency statuses of the replicas, indexed by Pod name.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Replicas map[string]ReplicaGtidStatus `json:"replicas,omitempty"`
+	// LastCheckTime is the last time the GTID positions were compared.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// HasErrantGtids indicates whether the replica Pod has executed transactions that have not been executed by the primary.
+func (m *MariaDB) HasErrantGtids(podName string) bool {
+	if m.Status.Gtid == nil {
+		return false
+	}
+	replica, ok := m.Status.Gtid.Replicas[podName]
+	return ok && replica.HasErrantGtids()
+}
+
+// ReplicaStatus is the replication status of a replica.
+type ReplicaStatus struct {
+	// Name is the name of the replica Pod.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// IORunning indicates whether the replica is connected to the primary and receiving its binary log events.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IORunning bool `json:"ioRunning"`
+	// SQLRunning indicates whether the replica is applying the events received from the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SQLRunning bool `json:"sqlRunning"`
+	// SecondsBehindPrimary is the replication lag of the replica, as reported by 'Seconds_Behind_Master'.
+	// It is not set when the replication threads are not running.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecondsBehindPrimary *int64 `json:"secondsBehindPrimary,omitempty"`
+	// GtidIOPos is the GTID position of the last event received from the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GtidIOPos string `json:"gtidIOPos,omitempty"`
+	// LastError is the last error reported by the replication threads.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastError string `json:"lastError,omitempty"`
+}
+
+// IsLagging determines whether the replica lags more than maxLag behind the primary, or its replication is not running.
+func (r *ReplicaStatus) IsLagging(maxLag time.Duration) bool {
+	if !r.IORunning || !r.SQLRunning || r.SecondsBehindPrimary == nil {
+		return true
+	}
+	return time.Duration(*r.SecondsBehindPrimary)*time.Second > maxLag
+}
+
+// ReplicationStatus is the replication status of the replicas.
+type ReplicationStatus struct {
+	// Replicas are the replication statuses of the replicas, ordered by Pod index.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Replicas []ReplicaStatus `json:"replicas,omitempty"`
+	// LastCheckTime is the last time the replicas were checked.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// Replica returns the replication status of the replica Pod, or nil if not found.
+func (s *ReplicationStatus) Replica(podName string) *ReplicaStatus {
+	for i := range s.Replicas {
+		if s.Replicas[i].Name == podName {
+			return &s.Replicas[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RollingRestartStatus is the state of the rolling restart requested via 'spec.restartedAt'.
+type RollingRestartStatus struct {
+	// RestartedAt is the value of 'spec.restartedAt' that triggered the rolling restart.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RestartedAt metav1.Time `json:"restartedAt"`
+	// PendingPods are the Pods pending to be restarted, in order.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PendingPods []string `json:"pendingPods,omitempty"`
+	// CurrentPod is the Pod being restarted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CurrentPod *string `json:"currentPod,omitempty"`
+	// CurrentPodUID is the UID of the current Pod before being deleted, used to detect when it has been recreated.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CurrentPodUID *string `json:"currentPodUID,omitempty"`
+	// CompletedAt is the time when all the Pods were restarted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// IsRollingRestartRequested indicates whether a rolling restart has been requested via 'spec.restartedAt' and not started yet.
+func (m *MariaDB) IsRollingRestartRequested() bool {
+	if m.Spec.RestartedAt == nil {
+		return false
+	}
+	status := m.Status.RollingRestart
+	return status == nil || !status.RestartedAt.Equal(m.Spec.RestartedAt)
+}
+
+// IsRollingRestartInProgress indicates whether the Pods are being restarted.
+func (m *MariaDB) IsRollingRestartInProgress() bool {
+	status := m.Status.RollingRestart
+	return status != nil && status.CompletedAt == nil
+}
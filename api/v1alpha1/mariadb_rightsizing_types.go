@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RightSizing defines how the CPU and memory utilization of the MariaDB Pods is collected to recommend resource requests.
+type RightSizing struct {
+	// Interval is the time between utilization samples, collected from the metrics API. It defaults to 1m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Window is the period during which the peak utilization is tracked. Recommendations are based on the peak of the current
+	// and the previous windows, so they adapt to decreases in utilization after a full window. It defaults to 24h.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Window *metav1.Duration `json:"window,omitempty"`
+	// HeadroomPercent is the percentage added on top of the peak utilization in the recommendations. It defaults to 20.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	HeadroomPercent *int32 `json:"headroomPercent,omitempty"`
+	// VerticalPodAutoscaler creates a VerticalPodAutoscaler targeting the StatefulSet in recommendation-only mode,
+	// which never updates the Pods. It requires the VerticalPodAutoscaler CRDs to be installed in the cluster.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	VerticalPodAutoscaler bool `json:"verticalPodAutoscaler,omitempty"`
+}
+
+// IntervalOrDefault returns the sampling interval, or the default one if not provided.
+func (r *RightSizing) IntervalOrDefault() time.Duration {
+	if r.Interval != nil {
+		return r.Interval.Duration
+	}
+	return 1 * time.Minute
+}
+
+// WindowOrDefault returns the utilization window, or the default one if not provided.
+func (r *RightSizing) WindowOrDefault() time.Duration {
+	if r.Window != nil {
+		return r.Window.Duration
+	}
+	return 24 * time.Hour
+}
+
+// HeadroomPercentOrDefault returns the headroom percentage, or the default one if not provided.
+func (r *RightSizing) HeadroomPercentOrDefault() int32 {
+	if r.HeadroomPercent != nil {
+		return *r.HeadroomPercent
+	}
+	return 20
+}
+
+// Validate determines whether a RightSizing is valid.
+func (r *RightSizing) Validate() error {
+	if r.Interval != nil && r.Interval.Duration <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	if r.Window != nil && r.Window.Duration <= 0 {
+		return errors.New("window must be greater than zero")
+	}
+	if r.WindowOrDefault() < r.IntervalOrDefault() {
+		return errors.New("window must be greater or equal than interval")
+	}
+	return nil
+}
+
+// ContainerRightSizing is the utilization and the recommended resource requests of a container.
+type ContainerRightSizing struct {
+	// Name of the container.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Requests are the current resource requests of the container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// PeakUsage is the highest utilization observed across all the Pods during the current window.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PeakUsage corev1.ResourceList `json:"peakUsage,omitempty"`
+	// PreviousPeakUsage is the highest utilization observed across all the Pods during the previous window.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PreviousPeakUsage corev1.ResourceList `json:"previousPeakUsage,omitempty"`
+	// Recommendation are the recommended resource requests of the container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Recommendation corev1.ResourceList `json:"recommendation,omitempty"`
+}
+
+// RightSizingStatus is the state of the CPU and memory right-sizing recommendations.
+type RightSizingStatus struct {
+	// WindowStartTime is when the current utilization window started.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	WindowStartTime metav1.Time `json:"windowStartTime"`
+	// LastSampleTime is when the utilization was last sampled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastSampleTime *metav1.Time `json:"lastSampleTime,omitempty"`
+	// Samples is the number of utilization samples collected during the current window.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Samples int32 `json:"samples,omitempty"`
+	// Containers are the utilization and the recommendations of each container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Containers []ContainerRightSizing `json:"containers,omitempty"`
+}
+
+// Container returns the right-sizing of a container by name.
+func (s *RightSizingStatus) Container(name string) *ContainerRightSizing {
+	for i := range s.Containers {
+		if s.Containers[i].Name == name {
+			return &s.Containers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SpiderNode defines a MariaDB acting as a Spider data node, where the shards of the Spider tables are stored.
+type SpiderNode struct {
+	// MariaDBRef is a reference to a MariaDB managed by the operator, which may live in a different namespace.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDBRef MariaDBRef `json:"mariaDbRef"`
+	// ServerName is the name of the server in the Spider node list, to be referenced by the Spider tables. It must be a valid DNS label,
+	// as it is also used to name the User and Grant of the node. It defaults to the MariaDB name.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServerName string `json:"serverName,omitempty"`
+	// Database is the default database of the server. The privileges of the Spider user in the node are scoped to this database,
+	// all databases are granted when it is not provided.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty"`
+}
+
+// Server returns the name of the server in the Spider node list.
+func (n *SpiderNode) Server() string {
+	if n.ServerName != "" {
+		return n.ServerName
+	}
+	return n.MariaDBRef.Name
+}
+
+// Spider defines the Spider storage engine configuration, which shards tables across other MariaDBs managed by the operator.
+type Spider struct {
+	// Enabled is a flag to enable Spider.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Nodes are the MariaDBs acting as Spider data nodes. They are kept in sync with the server list of the Spider storage engine.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Nodes []SpiderNode `json:"nodes,omitempty"`
+	// Username is the user created in the nodes to be used by Spider. It defaults to '<mariadb-name>-spider'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username,omitempty" webhook:"inmutableinit"`
+	// PasswordSecretKeyRef is a reference to the password of the Spider user. A random password is generated if the Secret does not exist.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty" webhook:"inmutableinit"`
+}
+
+// Validate determines whether a Spider configuration is valid for the MariaDB with the given key.
+func (s *Spider) Validate(key types.NamespacedName) error {
+	servers := make(map[string]struct{})
+	mariadbs := make(map[types.NamespacedName]struct{})
+	for _, node := range s.Nodes {
+		if node.MariaDBRef.Name == "" {
+			return errors.New("node 'mariaDbRef.name' must be set")
+		}
+		namespace := node.MariaDBRef.Namespace
+		if namespace == "" {
+			namespace = key.Namespace
+		}
+		if node.MariaDBRef.Name == key.Name && namespace == key.Namespace {
+			return errors.New("a MariaDB cannot be a Spider node of itself")
+		}
+		nodeKey := types.NamespacedName{Name: node.MariaDBRef.Name, Namespace: namespace}
+		if _, ok := mariadbs[nodeKey]; ok {
+			return fmt.Errorf("MariaDB '%s' must only be referenced by one node", nodeKey)
+		}
+		mariadbs[nodeKey] = struct{}{}
+		server := node.Server()
+		if errs := validation.IsDNS1123Label(server); len(errs) > 0 {
+			return fmt.Errorf("invalid server name '%s': %v", server, errs)
+		}
+		if _, ok := servers[server]; ok {
+			return fmt.Errorf("server name '%s' must be unique", server)
+		}
+		servers[server] = struct{}{}
+	}
+	return nil
+}
+
+// IsSpiderEnabled indicates whether Spider is enabled.
+func (m *MariaDB) IsSpiderEnabled() bool {
+	return m.Spec.Spider != nil && m.Spec.Spider.Enabled
+}
+
+// SpiderPasswordSecretKeyRef defines the key selector for the Spider user password Secret.
+func (m *MariaDB) SpiderPasswordSecretKeyRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: m.generatedName(NamingSpiderPassword),
+		},
+		Key: "password",
+	}
+}
+
+// SpiderNodeKey defines the key for the User and Grant of the Spider user in a node.
+func (m *MariaDB) SpiderNodeKey(server string) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-spider-%s", m.Name, server),
+		Namespace: m.Namespace,
+	}
+}
@@ -0,0 +1,183 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CertManagerGroup is the API group of the cert-manager issuers.
+	CertManagerGroup = "cert-manager.io"
+	// IssuerKind is a namespaced cert-manager issuer.
+	IssuerKind = "Issuer"
+	// ClusterIssuerKind is a cluster scoped cert-manager issuer.
+	ClusterIssuerKind = "ClusterIssuer"
+)
+
+// IssuerRef references the cert-manager issuer that issues the certificates.
+type IssuerRef struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Kind of the issuer, either Issuer or ClusterIssuer. It defaults to Issuer.
+	// +optional
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. It defaults to cert-manager.io.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Group string `json:"group,omitempty"`
+}
+
+// KindOrDefault returns the kind of the issuer, defaulting to Issuer.
+func (i IssuerRef) KindOrDefault() string {
+	if i.Kind != "" {
+		return i.Kind
+	}
+	return IssuerKind
+}
+
+// GroupOrDefault returns the group of the issuer, defaulting to cert-manager.io.
+func (i IssuerRef) GroupOrDefault() string {
+	if i.Group != "" {
+		return i.Group
+	}
+	return CertManagerGroup
+}
+
+// MariaDBTLS defines the TLS configuration of the MariaDB server. The server certificate is requested to cert-manager or issued
+// by the operator, mounted in the Pods and configured via ssl_cert, ssl_key and ssl_ca.
+type MariaDBTLS struct {
+	// Enabled is a flag to enable TLS in the MariaDB server.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer that issues the server certificate.
+	// The issuer must populate the 'ca.crt' key of the certificate Secret, i.e. a CA or self-signed issuer.
+	// When not set, the certificate is issued by the operator with a CA that is managed by the operator as well.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	IssuerRef *IssuerRef `json:"issuerRef,omitempty"`
+	// Duration is the requested lifetime of the certificate. It defaults to 90 days when issued by cert-manager and to 1 year when issued by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// RenewBefore is how long before the expiration the certificate is renewed. It defaults to a third of the duration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// GaleraEnabled encrypts the Galera replication traffic between the Pods with the server certificate, via the socket.ssl provider options.
+	// All the Galera nodes must have the same setting, therefore it cannot be changed once the MariaDB has been created.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	GaleraEnabled bool `json:"galeraEnabled,omitempty"`
+	// ReplicationEnabled configures the replicas to connect to the primary via TLS (MASTER_SSL), presenting the server certificate
+	// and verifying the one of the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	ReplicationEnabled bool `json:"replicationEnabled,omitempty"`
+}
+
+// DurationOrDefault returns the lifetime of the certificates issued by the operator, defaulting to 1 year.
+func (t *MariaDBTLS) DurationOrDefault() time.Duration {
+	if t.Duration != nil {
+		return t.Duration.Duration
+	}
+	return 365 * 24 * time.Hour
+}
+
+// RenewBeforeOrDefault returns how long before the expiration the certificates issued by the operator are renewed,
+// defaulting to a third of the duration.
+func (t *MariaDBTLS) RenewBeforeOrDefault() time.Duration {
+	if t.RenewBefore != nil {
+		return t.RenewBefore.Duration
+	}
+	return t.DurationOrDefault() / 3
+}
+
+// IsIssuedByCertManager indicates whether the server certificate is requested to cert-manager.
+func (t *MariaDBTLS) IsIssuedByCertManager() bool {
+	return t.IssuerRef != nil
+}
+
+// Validate determines whether a MariaDBTLS is valid.
+func (t *MariaDBTLS) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.IssuerRef != nil && t.IssuerRef.Name == "" {
+		return errors.New("'issuerRef.name' must be set")
+	}
+	if t.Duration != nil && t.Duration.Duration < time.Hour {
+		return errors.New("'duration' must be at least 1h")
+	}
+	if t.RenewBefore != nil {
+		if t.RenewBefore.Duration <= 0 {
+			return errors.New("'renewBefore' must be greater than 0")
+		}
+		if (t.Duration != nil || !t.IsIssuedByCertManager()) && t.RenewBefore.Duration >= t.DurationOrDefault() {
+			return fmt.Errorf("'renewBefore' (%s) must be lower than 'duration' (%s)", t.RenewBefore.Duration, t.DurationOrDefault())
+		}
+	}
+	return nil
+}
+
+// MariaDBTLSStatus is the state of the server certificate loaded by the Pods.
+type MariaDBTLSStatus struct {
+	// CertSerial is the serial number, in hexadecimal, of the certificate loaded by all the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CertSerial string `json:"certSerial,omitempty"`
+	// CertNotAfter is the expiration time of the certificate loaded by all the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CertNotAfter *metav1.Time `json:"certNotAfter,omitempty"`
+	// ReloadedAt is the last time the certificate was reloaded without restarting the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ReloadedAt *metav1.Time `json:"reloadedAt,omitempty"`
+	// RolloutCertSerial is the serial number of the last certificate that could not be reloaded.
+	// It is part of the config checksum of the Pods, so they are rolled out to load it.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RolloutCertSerial string `json:"rolloutCertSerial,omitempty"`
+}
+
+// IsTLSEnabled indicates whether the MariaDB server has TLS enabled.
+func (m *MariaDB) IsTLSEnabled() bool {
+	return m.Spec.TLS != nil && m.Spec.TLS.Enabled
+}
+
+// IsGaleraTLSEnabled indicates whether the Galera replication traffic is encrypted.
+func (m *MariaDB) IsGaleraTLSEnabled() bool {
+	return m.IsTLSEnabled() && m.Spec.TLS.GaleraEnabled && m.Galera().Enabled
+}
+
+// IsReplicationTLSEnabled indicates whether the replicas connect to the primary via TLS.
+func (m *MariaDB) IsReplicationTLSEnabled() bool {
+	return m.IsTLSEnabled() && m.Spec.TLS.ReplicationEnabled && m.Replication().Enabled
+}
+
+// TLSCASecretKeyRef defines the key selector for the CA bundle that issued the server certificate.
+func (m *MariaDB) TLSCASecretKeyRef() corev1.SecretKeySelector {
+	if m.Spec.TLS != nil && m.Spec.TLS.IsIssuedByCertManager() {
+		return corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: m.TLSKey().Name,
+			},
+			Key: "ca.crt",
+		}
+	}
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: m.TLSCAKey().Name,
+		},
+		Key: "tls.crt",
+	}
+}
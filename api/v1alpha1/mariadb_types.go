@@ -3,6 +3,11 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
 	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
@@ -90,6 +95,11 @@ type Metrics struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty" webhook:"inmutableinit"`
+	// PasswordRotationInterval is the interval at which the password of the monitoring user is rotated.
+	// The exporter is rolled out with the new password after every rotation.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordRotationInterval *metav1.Duration `json:"passwordRotationInterval,omitempty"`
 }
 
 // PodDisruptionBudget is the Pod availability bundget for a MariaDb
@@ -152,6 +162,673 @@ type ServiceTemplate struct {
 	AllocateLoadBalancerNodePorts *bool `json:"allocateLoadBalancerNodePorts,omitempty"`
 }
 
+// SecondaryService defines an additional Service that selects a subset of the secondary replicas.
+type SecondaryService struct {
+	// Name of the Service. The Service object is named '<mariadb-name>-<name>'.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// PodIndexes are the StatefulSet indexes of the Pods selected by the Service. If not provided, all the secondary Pods are selected.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PodIndexes []int `json:"podIndexes,omitempty"`
+	// PodSelector are the labels that the Pods must have to be selected by the Service.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PodSelector map[string]string `json:"podSelector,omitempty"`
+	// ServiceTemplate defines templates to configure the Service object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServiceTemplate `json:",inline"`
+}
+
+// ScheduledScaling defines a recurring time window in which the MariaDB runs with a different number of replicas.
+type ScheduledScaling struct {
+	// Name identifies the scaling window.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Cron is a cron expression that defines the start of the window.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Cron string `json:"cron"`
+	// Duration of the window.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration metav1.Duration `json:"duration"`
+	// Replicas indicates the number of desired instances during the window.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=2
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:podCount"}
+	Replicas int32 `json:"replicas"`
+}
+
+func (s *ScheduledScaling) window() *MaintenanceWindow {
+	return &MaintenanceWindow{
+		Cron:     s.Cron,
+		Duration: s.Duration,
+	}
+}
+
+// Validate determines whether a ScheduledScaling is valid.
+func (s *ScheduledScaling) Validate() error {
+	if s.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if err := s.window().Validate(); err != nil {
+		return fmt.Errorf("invalid window: %v", err)
+	}
+	if s.Replicas < 2 {
+		return errors.New("replicas must be at least 2")
+	}
+	return nil
+}
+
+// IsActive indicates whether the window is active at the given time.
+func (s *ScheduledScaling) IsActive(now time.Time) bool {
+	return s.window().IsActive(now)
+}
+
+// NextTransition returns the next time after the given one when the window either starts or ends.
+func (s *ScheduledScaling) NextTransition(now time.Time) (time.Time, error) {
+	window := s.window()
+	next, err := window.NextStart(now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if window.IsActive(now) {
+		start, err := window.NextStart(now.Add(-s.Duration.Duration))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if end := start.Add(s.Duration.Duration); end.Before(next) {
+			return end, nil
+		}
+	}
+	return next, nil
+}
+
+// ScheduledScalingStatus is the state of the scheduled scaling.
+type ScheduledScalingStatus struct {
+	// BaseReplicas is the number of replicas outside of the scaling windows, defined by 'spec.replicas'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	BaseReplicas int32 `json:"baseReplicas,omitempty"`
+	// Replicas is the number of replicas currently run by the operator, which takes precedence over 'spec.replicas'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Replicas int32 `json:"replicas,omitempty"`
+	// TargetReplicas is the number of replicas that the operator is scaling to.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TargetReplicas int32 `json:"targetReplicas,omitempty"`
+	// ActiveWindow is the name of the scaling window currently active.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ActiveWindow *string `json:"activeWindow,omitempty"`
+}
+
+// DisruptiveActionType is the type of a disruptive action performed by the operator.
+type DisruptiveActionType string
+
+const (
+	// DisruptiveActionFailover is an automatic switch of the primary to another Pod.
+	DisruptiveActionFailover DisruptiveActionType = "Failover"
+	// DisruptiveActionPodDeletion is the deletion of a Pod in order to rebuild it, i.e. a Galera node that is not able to sync.
+	DisruptiveActionPodDeletion DisruptiveActionType = "PodDeletion"
+	// DisruptiveActionClusterBootstrap is the bootstrap of a new Galera cluster after a full outage.
+	DisruptiveActionClusterBootstrap DisruptiveActionType = "ClusterBootstrap"
+)
+
+// ActionRateLimit limits the number of disruptive actions that the operator may perform within a time window.
+type ActionRateLimit struct {
+	// MaxActions is the maximum number of disruptive actions allowed within the window.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxActions int32 `json:"maxActions"`
+	// Window is the sliding time window in which the disruptive actions are counted.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Window metav1.Duration `json:"window"`
+}
+
+// Validate determines whether an ActionRateLimit is valid.
+func (r *ActionRateLimit) Validate() error {
+	if r.MaxActions < 1 {
+		return errors.New("maxActions must be at least 1")
+	}
+	if r.Window.Duration <= 0 {
+		return errors.New("window must be greater than zero")
+	}
+	return nil
+}
+
+// RecentActions returns the actions performed within the window that ends at the given time.
+func (r *ActionRateLimit) RecentActions(actions []DisruptiveAction, now time.Time) []DisruptiveAction {
+	var recent []DisruptiveAction
+	for _, a := range actions {
+		if now.Sub(a.Time.Time) < r.Window.Duration {
+			recent = append(recent, a)
+		}
+	}
+	return recent
+}
+
+// RetryAfter returns how long to wait until a new action is allowed, zero if it is already allowed.
+func (r *ActionRateLimit) RetryAfter(actions []DisruptiveAction, now time.Time) time.Duration {
+	recent := r.RecentActions(actions, now)
+	if len(recent) < int(r.MaxActions) {
+		return 0
+	}
+	// the actions are sorted by time, the oldest ones have to leave the window for a new action to be allowed
+	oldest := recent[len(recent)-int(r.MaxActions)]
+	return oldest.Time.Add(r.Window.Duration).Sub(now)
+}
+
+// DisruptiveAction is a disruptive action performed by the operator.
+type DisruptiveAction struct {
+	// Time when the action was performed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Time metav1.Time `json:"time"`
+	// Type of the action.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Type DisruptiveActionType `json:"type"`
+	// Pod affected by the action.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Pod string `json:"pod,omitempty"`
+}
+
+// WarmUp defines the warm-up phase that replicas go through before being added to the secondary Services.
+type WarmUp struct {
+	// BufferPoolLoad waits for the InnoDB buffer pool to be loaded from the dump taken at shutdown,
+	// triggering the load when it has not been started.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	BufferPoolLoad bool `json:"bufferPoolLoad,omitempty"`
+	// Queries to be executed in the replica to warm up the caches, i.e. "SELECT COUNT(*) FROM app.orders".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Queries []string `json:"queries,omitempty"`
+	// Timeout after which the replica is added to the secondary Services regardless of the warm-up progress.
+	// It is measured since the MariaDB container started and it defaults to 5m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// TimeoutOrDefault returns the warm-up timeout, or the default one if not provided.
+func (w *WarmUp) TimeoutOrDefault() time.Duration {
+	if w.Timeout != nil {
+		return w.Timeout.Duration
+	}
+	return 5 * time.Minute
+}
+
+// Validate determines whether a WarmUp is valid.
+func (w *WarmUp) Validate() error {
+	if w.Timeout != nil && w.Timeout.Duration <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	for i, q := range w.Queries {
+		if strings.TrimSpace(q) == "" {
+			return fmt.Errorf("query %d must not be empty", i)
+		}
+	}
+	return nil
+}
+
+// CrashDiagnostics defines the diagnostics captured when the MariaDB container crashes.
+type CrashDiagnostics struct {
+	// Enabled is a flag to enable the crash diagnostics capture.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// LogTailLines is the number of lines of the error log of the crashed container to be captured. It defaults to 200.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	LogTailLines *int64 `json:"logTailLines,omitempty"`
+	// HistoryLimit is the number of diagnostics ConfigMaps kept per Pod, the oldest ones are deleted. It defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// LogTailLinesOrDefault returns the number of log lines to be captured.
+func (c *CrashDiagnostics) LogTailLinesOrDefault() int64 {
+	if c.LogTailLines != nil {
+		return *c.LogTailLines
+	}
+	return 200
+}
+
+// HistoryLimitOrDefault returns the number of diagnostics ConfigMaps kept per Pod.
+func (c *CrashDiagnostics) HistoryLimitOrDefault() int {
+	if c.HistoryLimit != nil {
+		return int(*c.HistoryLimit)
+	}
+	return 3
+}
+
+// SessionPolicy defines the timeouts that bound idle sessions and long running statements.
+// They are applied dynamically to all the MariaDB servers, without requiring a restart.
+type SessionPolicy struct {
+	// WaitTimeout is the time the server waits for activity on a non-interactive connection before closing it.
+	// It is applied to the wait_timeout system variable, with a precision of seconds.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+	// InteractiveTimeout is the time the server waits for activity on an interactive connection before closing it.
+	// It is applied to the interactive_timeout system variable, with a precision of seconds.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	InteractiveTimeout *metav1.Duration `json:"interactiveTimeout,omitempty"`
+	// MaxStatementTime is the maximum execution time of a statement, after which it is aborted. 0 means no limit.
+	// It is applied to the max_statement_time system variable.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxStatementTime *metav1.Duration `json:"maxStatementTime,omitempty"`
+}
+
+// Validate determines whether a SessionPolicy is valid.
+func (s *SessionPolicy) Validate() error {
+	if s.WaitTimeout != nil && s.WaitTimeout.Duration < time.Second {
+		return errors.New("'waitTimeout' must be at least 1s")
+	}
+	if s.InteractiveTimeout != nil && s.InteractiveTimeout.Duration < time.Second {
+		return errors.New("'interactiveTimeout' must be at least 1s")
+	}
+	if s.MaxStatementTime != nil && s.MaxStatementTime.Duration < 0 {
+		return errors.New("'maxStatementTime' must not be negative")
+	}
+	return nil
+}
+
+// SystemVariables returns the system variables to be set, in seconds.
+func (s *SessionPolicy) SystemVariables() map[string]string {
+	vars := make(map[string]string)
+	if s.WaitTimeout != nil {
+		vars["wait_timeout"] = strconv.FormatInt(int64(s.WaitTimeout.Seconds()), 10)
+	}
+	if s.InteractiveTimeout != nil {
+		vars["interactive_timeout"] = strconv.FormatInt(int64(s.InteractiveTimeout.Seconds()), 10)
+	}
+	if s.MaxStatementTime != nil {
+		vars["max_statement_time"] = strconv.FormatFloat(s.MaxStatementTime.Seconds(), 'f', -1, 64)
+	}
+	return vars
+}
+
+// NamingResource identifies a resource generated for a MariaDB, by the suffix added to its default name.
+type NamingResource string
+
+const (
+	// NamingService is the default Service, named after the MariaDB.
+	NamingService NamingResource = "service"
+	// NamingConnection is the default Connection, named after the MariaDB.
+	NamingConnection NamingResource = "connection"
+	// NamingInternal is the internal headless Service.
+	NamingInternal NamingResource = "internal"
+	// NamingPrimary is the primary Service.
+	NamingPrimary NamingResource = "primary"
+	// NamingPrimaryConnection is the primary Connection.
+	NamingPrimaryConnection NamingResource = "primary-connection"
+	// NamingSecondary is the secondary Service.
+	NamingSecondary NamingResource = "secondary"
+	// NamingSecondaryConnection is the secondary Connection.
+	NamingSecondaryConnection NamingResource = "secondary-connection"
+	// NamingMetrics is the exporter Deployment, Service, ServiceMonitor and metrics User.
+	NamingMetrics NamingResource = "metrics"
+	// NamingConfig is the my.cnf ConfigMap.
+	NamingConfig NamingResource = "config"
+	// NamingWsrepNotify is the wsrep_notify_cmd ConfigMap.
+	NamingWsrepNotify NamingResource = "wsrep-notify"
+	// NamingRoot is the root password Secret.
+	NamingRoot NamingResource = "root"
+	// NamingPassword is the initial user password Secret.
+	NamingPassword NamingResource = "password"
+	// NamingMetricsPassword is the metrics user password Secret.
+	NamingMetricsPassword NamingResource = "metrics-password"
+	// NamingMetricsConfig is the exporter configuration Secret.
+	NamingMetricsConfig NamingResource = "metrics-config"
+	// NamingOperatorPassword is the operator account password Secret.
+	NamingOperatorPassword NamingResource = "operator-password"
+	// NamingProbePassword is the probe account password Secret.
+	NamingProbePassword NamingResource = "probe-password"
+	// NamingSpiderPassword is the Spider user password Secret.
+	NamingSpiderPassword NamingResource = "spider-password"
+	// NamingRestore is the Restore used to bootstrap.
+	NamingRestore NamingResource = "restore"
+	// NamingSeedData is the Job that loads the seed data.
+	NamingSeedData NamingResource = "seed-data"
+	// NamingProvisioning is the prefix of the Jobs that provision new replicas, followed by the Pod index.
+	NamingProvisioning NamingResource = "provisioning"
+	// NamingGaleraArbitrator is the Galera arbitrator Deployment.
+	NamingGaleraArbitrator NamingResource = "arbitrator"
+	// NamingTLS is the server certificate Secret, and the cert-manager Certificate that issues it.
+	NamingTLS NamingResource = "tls"
+	// NamingTLSCA is the Secret of the CA that issues the server certificate when it is managed by the operator.
+	NamingTLSCA NamingResource = "tls-ca"
+)
+
+var namingResources = []NamingResource{
+	NamingService,
+	NamingConnection,
+	NamingInternal,
+	NamingPrimary,
+	NamingPrimaryConnection,
+	NamingSecondary,
+	NamingSecondaryConnection,
+	NamingMetrics,
+	NamingConfig,
+	NamingWsrepNotify,
+	NamingRoot,
+	NamingPassword,
+	NamingMetricsPassword,
+	NamingMetricsConfig,
+	NamingOperatorPassword,
+	NamingProbePassword,
+	NamingSpiderPassword,
+	NamingRestore,
+	NamingSeedData,
+	NamingProvisioning,
+	NamingGaleraArbitrator,
+	NamingTLS,
+	NamingTLSCA,
+}
+
+// Naming customizes the names of the resources generated for a MariaDB, in order to avoid collisions with pre-existing resources.
+// The StatefulSet, and therefore its Pods and PersistentVolumeClaims, are always named after the MariaDB.
+type Naming struct {
+	// Prefix is prepended to the names of the generated resources.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Prefix string `json:"prefix,omitempty"`
+	// Suffix is appended to the names of the generated resources.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Suffix string `json:"suffix,omitempty"`
+	// Overrides sets the full name of individual generated resources, taking precedence over the prefix and suffix.
+	// Valid keys are: service, connection, internal, primary, primary-connection, secondary, secondary-connection, metrics,
+	// config, wsrep-notify, root, password, metrics-password, metrics-config, operator-password, spider-password, restore,
+	// seed-data, provisioning, arbitrator, tls and tls-ca.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Overrides map[NamingResource]string `json:"overrides,omitempty"`
+}
+
+// Validate determines whether a Naming is valid.
+func (n *Naming) Validate() error {
+	for resource, name := range n.Overrides {
+		if !isValidNamingResource(resource) {
+			return fmt.Errorf("unsupported override '%s'", resource)
+		}
+		if name == "" {
+			return fmt.Errorf("override '%s' must not be empty", resource)
+		}
+	}
+	return nil
+}
+
+func (n *Naming) apply(name string) string {
+	return n.Prefix + name + n.Suffix
+}
+
+func isValidNamingResource(resource NamingResource) bool {
+	for _, r := range namingResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// BinlogArchive defines the continuous archiving of the binary logs into a S3 compatible storage.
+// Only the binary logs that have been rotated are archived, the one currently being written is archived after the next rotation.
+type BinlogArchive struct {
+	// S3 defines the storage where the binary logs are archived. All the Pods archive their binary logs under the same prefix,
+	// prepending the Pod name to the file names, so the binary logs can be replayed across primary failovers.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	S3 S3 `json:"s3"`
+	// Interval defines how often the closed binary logs are shipped to the storage. It defaults to 1m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// FlushInterval defines how often the binary logs are flushed, closing the one currently being written so it gets archived.
+	// It bounds the amount of data that may be lost in the absence of rotations. It defaults to 5m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+}
+
+// Validate determines whether a BinlogArchive is valid.
+func (b *BinlogArchive) Validate() error {
+	if err := b.S3.Validate(); err != nil {
+		return fmt.Errorf("invalid S3: %v", err)
+	}
+	if b.Interval != nil && b.Interval.Duration < time.Second {
+		return errors.New("'interval' must be at least 1s")
+	}
+	if b.FlushInterval != nil && b.FlushInterval.Duration < time.Second {
+		return errors.New("'flushInterval' must be at least 1s")
+	}
+	return nil
+}
+
+// IntervalOrDefault returns the archiving interval, defaulting to 1m.
+func (b *BinlogArchive) IntervalOrDefault() time.Duration {
+	if b.Interval != nil {
+		return b.Interval.Duration
+	}
+	return time.Minute
+}
+
+// FlushIntervalOrDefault returns the flush interval, defaulting to 5m.
+func (b *BinlogArchive) FlushIntervalOrDefault() time.Duration {
+	if b.FlushInterval != nil {
+		return b.FlushInterval.Duration
+	}
+	return 5 * time.Minute
+}
+
+// SeedFile defines a file with seed data stored in a S3 compatible storage.
+type SeedFile struct {
+	// Name of the object within the bucket and prefix. SQL files, with '.sql' extension, are executed and CSV files, with '.csv' extension,
+	// are imported into the table named after the file, skipping the first line with the column names.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// SHA256 is the hex encoded SHA-256 checksum of the file. The seed fails if the downloaded file does not match it.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SHA256 string `json:"sha256"`
+	// Database where the file is applied. It is required for CSV files.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty"`
+}
+
+// IsCSV determines whether the SeedFile contains CSV data.
+func (s *SeedFile) IsCSV() bool {
+	return strings.HasSuffix(s.Name, ".csv")
+}
+
+// Validate determines whether a SeedFile is valid.
+func (s *SeedFile) Validate() error {
+	if s.Name == "" || strings.Contains(s.Name, "/") {
+		return fmt.Errorf("invalid name '%s', it must not be empty nor contain '/'", s.Name)
+	}
+	if !strings.HasSuffix(s.Name, ".sql") && !s.IsCSV() {
+		return fmt.Errorf("unsupported file '%s', only '.sql' and '.csv' files are supported", s.Name)
+	}
+	if s.IsCSV() && s.Database == nil {
+		return fmt.Errorf("'database' must be set for CSV file '%s'", s.Name)
+	}
+	return nil
+}
+
+// SeedData defines data to be loaded from a S3 compatible storage when MariaDB is bootstrapped for the first time.
+type SeedData struct {
+	// S3 defines the storage where the seed files are located.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	S3 S3 `json:"s3"`
+	// Files to be loaded, in order.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Files []SeedFile `json:"files"`
+	// Resouces describes the compute resource requirements of the seed Job.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Validate determines whether a SeedData is valid.
+func (s *SeedData) Validate() error {
+	if err := s.S3.Validate(); err != nil {
+		return fmt.Errorf("invalid S3: %v", err)
+	}
+	if len(s.Files) == 0 {
+		return errors.New("at least one file must be provided")
+	}
+	names := make(map[string]struct{}, len(s.Files))
+	for _, f := range s.Files {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+		if _, ok := names[f.Name]; ok {
+			return fmt.Errorf("duplicated file '%s'", f.Name)
+		}
+		names[f.Name] = struct{}{}
+	}
+	return nil
+}
+
+// DefaultOperatorAccountPrivileges are the privileges granted to the operator account by default. They allow to manage
+// databases, users and grants, including the ones of the metrics exporter, without administrative privileges such as SUPER.
+// REPLICA MONITOR and SLAVE MONITOR are granted as REPLICATION CLIENT by servers older than 10.5.9, which do not support them.
+var DefaultOperatorAccountPrivileges = []string{
+	"SELECT",
+	"INSERT",
+	"UPDATE",
+	"DELETE",
+	"CREATE",
+	"DROP",
+	"ALTER",
+	"INDEX",
+	"REFERENCES",
+	"CREATE TEMPORARY TABLES",
+	"LOCK TABLES",
+	"EXECUTE",
+	"CREATE VIEW",
+	"SHOW VIEW",
+	"CREATE ROUTINE",
+	"ALTER ROUTINE",
+	"EVENT",
+	"TRIGGER",
+	"CREATE USER",
+	"RELOAD",
+	"PROCESS",
+	"REPLICATION CLIENT",
+	"REPLICA MONITOR",
+	"SLAVE MONITOR",
+}
+
+// OperatorAccount defines a dedicated account used by the operator to reconcile the Database, User and Grant resources instead of root.
+// The root account is still used to provision this account and to perform administrative operations, such as configuring replication.
+type OperatorAccount struct {
+	// Enabled is a flag to enable the operator account.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Username is the username of the operator account. It defaults to 'mariadb-operator'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username,omitempty" webhook:"inmutable"`
+	// PasswordSecretKeyRef is a reference to the password of the operator account. A random password is generated if the Secret does not exist.
+	// The password can be rotated by updating the Secret, the account is altered in the next reconciliation.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty"`
+	// Privileges granted globally to the operator account, with grant option. The operator is only able to grant the privileges it holds.
+	// It defaults to a set of privileges that allows to manage databases, users and grants.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Privileges []string `json:"privileges,omitempty"`
+}
+
+// ProbeAccount defines a dedicated account used by the liveness and readiness probes instead of root.
+// The account is created locally in every Pod, without being replicated, and it is only able to connect from localhost without privileges.
+type ProbeAccount struct {
+	// Enabled is a flag to enable the probe account.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Enabled bool `json:"enabled,omitempty"`
+	// Username is the username of the probe account. It defaults to 'mariadb-probe'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username,omitempty" webhook:"inmutable"`
+	// PasswordSecretKeyRef is a reference to the password of the probe account. A random password is generated if the Secret does not exist.
+	// The Secret is mounted in the Pods, so the password can be rotated by updating the Secret without restarting them.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty" webhook:"inmutableinit"`
+	// RotationInterval is the interval at which the password of the probe account is rotated.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+}
+
+// PreviousPasswordSecretKey is the key of the password Secret that holds the previous password during a rotation.
+func (p *ProbeAccount) PreviousPasswordSecretKey() string {
+	return fmt.Sprintf("%s-previous", p.PasswordSecretKeyRef.Key)
+}
+
+// Validate determines whether a ProbeAccount is valid.
+func (p *ProbeAccount) Validate() error {
+	if p.RotationInterval != nil && p.RotationInterval.Duration <= 0 {
+		return errors.New("rotation interval must be greater than zero")
+	}
+	return nil
+}
+
+// ProbeAccountStatus is the observed state of the probe account.
+type ProbeAccountStatus struct {
+	// Username of the provisioned probe account.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Username string `json:"username"`
+	// SecretResourceVersion is the version of the password Secret provisioned in the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecretResourceVersion string `json:"secretResourceVersion,omitempty"`
+	// LastRotationTime is the last time the password was provisioned in the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// RotationStartTime is the time when a change in the password Secret was detected. The password is provisioned once
+	// the Secret has been propagated to the Pods, so the probes do not fail in the meantime.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RotationStartTime *metav1.Time `json:"rotationStartTime,omitempty"`
+}
+
+// OperatorAccountStatus is the observed state of the operator account.
+type OperatorAccountStatus struct {
+	// Username of the provisioned operator account.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Username string `json:"username"`
+	// Privileges granted to the provisioned operator account.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Privileges []string `json:"privileges,omitempty"`
+}
+
 // MariaDBSpec defines the desired state of MariaDB
 type MariaDBSpec struct {
 	// ContainerTemplate defines templates to configure Container objects.
@@ -184,6 +861,12 @@ type MariaDBSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	RootPasswordSecretKeyRef corev1.SecretKeySelector `json:"rootPasswordSecretKeyRef,omitempty" webhook:"inmutableinit"`
+	// PasswordRotation defines the periodic rotation of the root password and, when replication is enabled, of the replication password.
+	// The root password is rolled out to the Pods afterwards, unless the Secret is annotated to skip the rollout.
+	// It requires the probe account to be enabled, so the probes do not depend on the root password.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordRotation *PasswordRotation `json:"passwordRotation,omitempty"`
 	// Database is the database to be created on bootstrap.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -213,6 +896,11 @@ type MariaDBSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	BootstrapFrom *RestoreSource `json:"bootstrapFrom,omitempty"`
+	// SeedData defines data to be loaded from a S3 compatible storage once MariaDB is ready for the first time, after bootstrapping
+	// from a backup if BootstrapFrom is set. It is only applied once and it cannot be added to existing instances.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SeedData *SeedData `json:"seedData,omitempty" webhook:"inmutable"`
 	// Metrics configures metrics and how to scrape them.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -221,6 +909,12 @@ type MariaDBSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Replication *Replication `json:"replication,omitempty"`
+	// ReplicationChannels are named replication connections to upstream servers, allowing to aggregate data from several of them via multi-source replication.
+	// They are configured in the primary Pod.
+	// More info: https://mariadb.com/kb/en/multi-source-replication/.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ReplicationChannels []ReplicationChannel `json:"replicationChannels,omitempty"`
 	// Replication configures high availability via Galera.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -229,6 +923,31 @@ type MariaDBSpec struct {
 	// +kubebuilder:default=1
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:podCount"}
 	Replicas int32 `json:"replicas,omitempty"`
+	// Hibernate scales the MariaDB down to zero Pods while preserving its PVCs, Secrets and status, i.e. to save costs in development environments
+	// overnight. Optionally, a final Backup is taken before scaling down. The previous topology is restored once the hibernation is disabled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Hibernate *Hibernate `json:"hibernate,omitempty"`
+	// RestartedAt requests a rolling restart of the Pods whenever it is set to a new value, i.e. the current time.
+	// The operator restarts one Pod at a time, the primary last, waiting for the Pods and the replication or Galera cluster to be healthy in between.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RestartedAt *metav1.Time `json:"restartedAt,omitempty"`
+	// TLS defines the TLS configuration of the MariaDB server, whose certificate is issued by cert-manager or by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TLS *MariaDBTLS `json:"tls,omitempty"`
+	// ScheduledScaling defines recurring time windows in which the MariaDB runs with a different number of replicas, i.e. during business hours.
+	// The operator scales 'spec.replicas' one replica at a time, and scales back to the previous number of replicas once the windows are over.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ScheduledScaling []ScheduledScaling `json:"scheduledScaling,omitempty"`
+	// ActionRateLimit limits the number of disruptive actions, such as failovers, Pod deletions and Galera cluster bootstraps, that the operator
+	// may perform within a time window. Once the limit is hit, the actions are blocked until the window elapses and the 'ActionsRateLimited' condition is set,
+	// preventing automation loops from amplifying incidents.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ActionRateLimit *ActionRateLimit `json:"actionRateLimit,omitempty"`
 	// Port where the instances will be listening for connections.
 	// +optional
 	// +kubebuilder:default=3306
@@ -238,6 +957,12 @@ type MariaDBSpec struct {
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	VolumeClaimTemplate VolumeClaimTemplate `json:"volumeClaimTemplate" webhook:"inmutable"`
+	// Ephemeral indicates that the data is stored in an emptyDir instead of in PVCs, using the storage requested
+	// in the VolumeClaimTemplate as size limit. Data is lost when the Pod is deleted, therefore it is only intended for testing purposes.
+	// It is only supported by standalone MariaDBs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Ephemeral bool `json:"ephemeral,omitempty" webhook:"inmutable"`
 	// PodDisruptionBudget defines the budget for replica availability.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -246,6 +971,15 @@ type MariaDBSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:updateStrategy"}
 	UpdateStrategy *appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+	// MaintenanceWindow restricts the Pod restarts caused by Pod metadata changes, i.e. 'spec.podAnnotations' and 'spec.inheritMetadata',
+	// to a recurring time window. Changes performed outside of the window are deferred and reported in the RestartPending condition.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Notifications defines the targets where the key events of this MariaDB, such as failovers or Backup failures, are notified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Notifications *Notifications `json:"notifications,omitempty"`
 	// Service defines templates to configure the general Service object.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -266,10 +1000,70 @@ type MariaDBSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SecondaryService *ServiceTemplate `json:"secondaryService,omitempty"`
+	// SecondaryServices defines additional Services that select a subset of the secondary replicas by index or labels,
+	// i.e. a 'reporting' Service pointing to a delayed replica.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SecondaryServices []SecondaryService `json:"secondaryServices,omitempty"`
+	// WarmUp defines a warm-up phase for replicas that have been rebuilt or restarted, which are only added to the secondary Services
+	// after warming up their caches.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WarmUp *WarmUp `json:"warmUp,omitempty"`
+	// UpgradePreflight enables checks performed before rolling out a new image, such as removed variables, plugins, storage headroom
+	// and replication health. The report is published in 'status.upgradePreflight' and the new image is not rolled out when critical checks fail.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	UpgradePreflight *UpgradePreflight `json:"upgradePreflight,omitempty"`
+	// RightSizing enables the collection of the CPU and memory utilization of the Pods via the metrics API, in order to publish
+	// resource requests recommendations in 'status.rightSizing'. The resources of the Pods are never updated by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RightSizing *RightSizing `json:"rightSizing,omitempty"`
 	// SecondaryConnection defines templates to configure the secondary Connection object.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SecondaryConnection *ConnectionTemplate `json:"secondaryConnection,omitempty" webhook:"inmutable"`
+	// StrictOwnership enables the detection of out-of-band modifications to the StatefulSet, Services and ConfigMaps generated by the operator.
+	// Modifications are reverted and reported via Events.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	StrictOwnership bool `json:"strictOwnership,omitempty"`
+	// HistoryLimit is the maximum number of entries kept in 'status.history', an audit trail of the spec changes and the actions
+	// performed by the operator. It defaults to 10, 0 disables the history.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+	// CrashDiagnostics captures the error log tail, the termination details and the Galera/replication state into a ConfigMap
+	// when the MariaDB container crashes. The ConfigMap is referenced from an Event.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CrashDiagnostics *CrashDiagnostics `json:"crashDiagnostics,omitempty"`
+	// SessionPolicy defines the timeouts that bound idle sessions and long running statements.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SessionPolicy *SessionPolicy `json:"sessionPolicy,omitempty"`
+	// BinlogArchive enables the binary logs and continuously ships them to a S3 compatible storage, enabling point-in-time recovery.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BinlogArchive *BinlogArchive `json:"binlogArchive,omitempty"`
+	// OperatorAccount defines a dedicated low-privilege account used by the operator to reconcile SQL resources instead of root.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OperatorAccount *OperatorAccount `json:"operatorAccount,omitempty"`
+	// ProbeAccount defines a dedicated account without privileges used by the liveness and readiness probes instead of root.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ProbeAccount *ProbeAccount `json:"probeAccount,omitempty"`
+	// Spider enables the Spider storage engine, keeping its server list in sync with other MariaDBs managed by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Spider *Spider `json:"spider,omitempty"`
+	// Naming customizes the names of the Services, Connections, Secrets, ConfigMaps and Jobs generated for this MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Naming *Naming `json:"naming,omitempty" webhook:"inmutable"`
 }
 
 // MariaDBStatus defines the observed state of MariaDB
@@ -281,6 +1075,10 @@ type MariaDBStatus struct {
 	// Replicas indicates the number of current instances.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:podCount"}
 	Replicas int32 `json:"replicas,omitempty"`
+	// ObservedGeneration is the last generation of the MariaDB that has been fully reconciled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// CurrentPrimaryPodIndex is the primary Pod index.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
@@ -289,10 +1087,108 @@ type MariaDBStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes:Pod"}
 	CurrentPrimary *string `json:"currentPrimary,omitempty"`
+	// Switchover is the state of the primary switchover in progress, if any.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Switchover *SwitchoverStatus `json:"switchover,omitempty"`
 	// GaleraRecovery is the Galera recovery current state.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	GaleraRecovery *GaleraRecoveryStatus `json:"galeraRecovery,omitempty"`
+	// GaleraNodeStates are the Galera node states reported via wsrep_notify_cmd, indexed by Pod name.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GaleraNodeStates map[string]string `json:"galeraNodeStates,omitempty"`
+	// GaleraConfigDrift are the wsrep settings that have different values across the Galera nodes, i.e. after a partial rollout.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GaleraConfigDrift []GaleraConfigDrift `json:"galeraConfigDrift,omitempty"`
+	// GaleraArbitrator is the state of the Galera arbitrator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	GaleraArbitrator *GaleraArbitratorStatus `json:"galeraArbitrator,omitempty"`
+	// Gtid is the GTID consistency status of the replicas, used to detect errant transactions and gaps.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Gtid *GtidStatus `json:"gtid,omitempty"`
+	// Replication is the replication status of the replicas, including their replication lag.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Replication *ReplicationStatus `json:"replication,omitempty"`
+	// ReplicationChannels is the status of the replication channels configured in the primary Pod.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ReplicationChannels *ReplicationChannelsStatus `json:"replicationChannels,omitempty"`
+	// SemiSync reports the effective semi-synchronous replication settings and state of the primary.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SemiSync *SemiSyncStatus `json:"semiSync,omitempty"`
+	// History is an audit trail of the spec changes and the actions performed by the operator, the oldest entries come first.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	History []HistoryEntry `json:"history,omitempty"`
+	// AuditedFields are the key fields of the spec last recorded in the history, used to detect changes.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	AuditedFields map[string]string `json:"auditedFields,omitempty"`
+	// OperatorAccount is the operator account that has been provisioned.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	OperatorAccount *OperatorAccountStatus `json:"operatorAccount,omitempty"`
+	// ProbeAccount is the probe account that has been provisioned in the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ProbeAccount *ProbeAccountStatus `json:"probeAccount,omitempty"`
+	// MetricsPasswordRotationTime is the last time the password of the monitoring user was rotated.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	MetricsPasswordRotationTime *metav1.Time `json:"metricsPasswordRotationTime,omitempty"`
+	// PasswordLastRotated is the last time the root and replication passwords were rotated.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PasswordLastRotated *metav1.Time `json:"passwordLastRotated,omitempty"`
+	// SpiderServers are the servers of the Spider node list managed by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SpiderServers []string `json:"spiderServers,omitempty"`
+	// SessionPolicyVariables are the system variables set by the operator according to the session policy.
+	// They are reset to their default value once they are removed from the session policy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SessionPolicyVariables []string `json:"sessionPolicyVariables,omitempty"`
+	// ScheduledScaling is the state of the scheduled scaling.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ScheduledScaling *ScheduledScalingStatus `json:"scheduledScaling,omitempty"`
+	// Hibernation is the state of the hibernation.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
+	// RollingRestart is the state of the last rolling restart requested via 'spec.restartedAt'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RollingRestart *RollingRestartStatus `json:"rollingRestart,omitempty"`
+	// DisruptiveActions are the disruptive actions performed by the operator within the 'spec.actionRateLimit' window, the oldest ones come first.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DisruptiveActions []DisruptiveAction `json:"disruptiveActions,omitempty"`
+	// UpgradePreflight is the report of the checks performed before rolling out the last image change.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	UpgradePreflight *UpgradePreflightStatus `json:"upgradePreflight,omitempty"`
+	// RightSizing is the CPU and memory utilization of the Pods and the recommended resource requests.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RightSizing *RightSizingStatus `json:"rightSizing,omitempty"`
+	// TLS is the state of the server certificate loaded by the Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TLS *MariaDBTLSStatus `json:"tls,omitempty"`
+	// WarmUpEnabled indicates that the Pods running when the warm-up was enabled have been considered warm.
+	// From then on, the replicas are only added to the secondary Services once they are warmed up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	WarmUpEnabled bool `json:"warmUpEnabled,omitempty"`
 }
 
 // SetCondition sets a status condition to MariaDB
@@ -373,6 +1269,33 @@ func (m *MariaDB) SetDefaults(env *environment.Environment) {
 			m.Spec.Metrics.PasswordSecretKeyRef = m.MetricsPasswordSecretKeyRef()
 		}
 	}
+	if m.IsOperatorAccountEnabled() {
+		if m.Spec.OperatorAccount.Username == "" {
+			m.Spec.OperatorAccount.Username = "mariadb-operator"
+		}
+		if m.Spec.OperatorAccount.PasswordSecretKeyRef == (corev1.SecretKeySelector{}) {
+			m.Spec.OperatorAccount.PasswordSecretKeyRef = m.OperatorAccountPasswordSecretKeyRef()
+		}
+		if len(m.Spec.OperatorAccount.Privileges) == 0 {
+			m.Spec.OperatorAccount.Privileges = slices.Clone(DefaultOperatorAccountPrivileges)
+		}
+	}
+	if m.IsProbeAccountEnabled() {
+		if m.Spec.ProbeAccount.Username == "" {
+			m.Spec.ProbeAccount.Username = "mariadb-probe"
+		}
+		if m.Spec.ProbeAccount.PasswordSecretKeyRef == (corev1.SecretKeySelector{}) {
+			m.Spec.ProbeAccount.PasswordSecretKeyRef = m.ProbeAccountPasswordSecretKeyRef()
+		}
+	}
+	if m.IsSpiderEnabled() {
+		if m.Spec.Spider.Username == "" {
+			m.Spec.Spider.Username = fmt.Sprintf("%s-spider", m.Name)
+		}
+		if m.Spec.Spider.PasswordSecretKeyRef == (corev1.SecretKeySelector{}) {
+			m.Spec.Spider.PasswordSecretKeyRef = m.SpiderPasswordSecretKeyRef()
+		}
+	}
 }
 
 // Replication with defaulting accessor
@@ -403,21 +1326,108 @@ func (m *MariaDB) AreMetricsEnabled() bool {
 	return m.Spec.Metrics != nil && m.Spec.Metrics.Enabled
 }
 
+// IsRestartPending indicates whether the MariaDB has Pods pending to be restarted.
+func (m *MariaDB) IsRestartPending() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeRestartPending)
+}
+
+// IsCrashDiagnosticsEnabled indicates whether the crash diagnostics capture is enabled
+func (m *MariaDB) IsCrashDiagnosticsEnabled() bool {
+	return m.Spec.CrashDiagnostics != nil && m.Spec.CrashDiagnostics.Enabled
+}
+
 // IsInitialDataEnabled indicates whether the MariaDB instance has initial data enabled
 func (m *MariaDB) IsInitialDataEnabled() bool {
 	return m.Spec.Username != nil
 }
 
+// ScheduledReplicas returns the number of replicas desired at the given time according to the scheduled scaling, along with the active window.
+// When multiple windows are active, the one with more replicas takes precedence. The base replicas are returned when no windows are active.
+func (m *MariaDB) ScheduledReplicas(baseReplicas int32, now time.Time) (int32, *ScheduledScaling) {
+	var active *ScheduledScaling
+	for i := range m.Spec.ScheduledScaling {
+		s := &m.Spec.ScheduledScaling[i]
+		if s.IsActive(now) && (active == nil || s.Replicas > active.Replicas) {
+			active = s
+		}
+	}
+	if active == nil {
+		return baseReplicas, nil
+	}
+	return active.Replicas, active
+}
+
+// ScaledReplicas returns the number of replicas currently run, which is set by the scheduled scaling when enabled
+// and defaults to 'spec.replicas'.
+func (m *MariaDB) ScaledReplicas() int32 {
+	if m.Status.ScheduledScaling != nil && m.Status.ScheduledScaling.Replicas > 0 {
+		return m.Status.ScheduledScaling.Replicas
+	}
+	return m.Spec.Replicas
+}
+
+// NextScheduledScalingTransition returns the next time after the given one when a scaling window either starts or ends.
+func (m *MariaDB) NextScheduledScalingTransition(now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, s := range m.Spec.ScheduledScaling {
+		t, err := s.NextTransition(now)
+		if err != nil {
+			continue
+		}
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// IsActionsRateLimited indicates whether the disruptive actions are being blocked by the rate limit.
+func (m *MariaDB) IsActionsRateLimited() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeActionsRateLimited)
+}
+
 // IsReady indicates whether the MariaDB instance is ready
 func (m *MariaDB) IsReady() bool {
 	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeReady)
 }
 
+// IsOperatorAccountEnabled indicates whether the MariaDB instance has the operator account enabled
+func (m *MariaDB) IsOperatorAccountEnabled() bool {
+	return m.Spec.OperatorAccount != nil && m.Spec.OperatorAccount.Enabled
+}
+
+// IsProbeAccountEnabled indicates whether the MariaDB instance has the probe account enabled
+func (m *MariaDB) IsProbeAccountEnabled() bool {
+	return m.Spec.ProbeAccount != nil && m.Spec.ProbeAccount.Enabled
+}
+
+// IsProbeAccountReady indicates whether the probe account has been provisioned in the Pods and can be used by the probes
+func (m *MariaDB) IsProbeAccountReady() bool {
+	return m.IsProbeAccountEnabled() && m.Status.ProbeAccount != nil &&
+		m.Status.ProbeAccount.Username == m.Spec.ProbeAccount.Username
+}
+
+// IsOperatorAccountReady indicates whether the operator account has been provisioned and can be used to reconcile SQL resources
+func (m *MariaDB) IsOperatorAccountReady() bool {
+	return m.IsOperatorAccountEnabled() && m.Status.OperatorAccount != nil &&
+		m.Status.OperatorAccount.Username == m.Spec.OperatorAccount.Username
+}
+
 // IsRestoringBackup indicates whether the MariaDB instance is restoring backup
 func (m *MariaDB) IsRestoringBackup() bool {
 	return meta.IsStatusConditionFalse(m.Status.Conditions, ConditionTypeBackupRestored)
 }
 
+// IsSeedingData indicates whether the MariaDB instance is loading the seed data
+func (m *MariaDB) IsSeedingData() bool {
+	return meta.IsStatusConditionFalse(m.Status.Conditions, ConditionTypeDataSeeded)
+}
+
+// HasSeededData indicates whether the MariaDB instance has loaded the seed data
+func (m *MariaDB) HasSeededData() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeDataSeeded)
+}
+
 // HasRestoredBackup indicates whether the MariaDB instance has restored a Backup
 func (m *MariaDB) HasRestoredBackup() bool {
 	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeBackupRestored)
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -368,6 +370,402 @@ var _ = Describe("MariaDB types", func() {
 				},
 				env,
 			),
+			Entry(
+				"Operator account",
+				&MariaDB{
+					ObjectMeta: objMeta,
+					Spec: MariaDBSpec{
+						OperatorAccount: &OperatorAccount{
+							Enabled: true,
+						},
+					},
+				},
+				&MariaDB{
+					ObjectMeta: objMeta,
+					Spec: MariaDBSpec{
+						Image: env.RelatedMariadbImage,
+						RootPasswordSecretKeyRef: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "mariadb-obj-root",
+							},
+							Key: "password",
+						},
+						Port: 3306,
+						OperatorAccount: &OperatorAccount{
+							Enabled:  true,
+							Username: "mariadb-operator",
+							PasswordSecretKeyRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "mariadb-obj-operator-password",
+								},
+								Key: "password",
+							},
+							Privileges: DefaultOperatorAccountPrivileges,
+						},
+					},
+				},
+				env,
+			),
+			Entry(
+				"Probe account",
+				&MariaDB{
+					ObjectMeta: objMeta,
+					Spec: MariaDBSpec{
+						ProbeAccount: &ProbeAccount{
+							Enabled: true,
+						},
+					},
+				},
+				&MariaDB{
+					ObjectMeta: objMeta,
+					Spec: MariaDBSpec{
+						Image: env.RelatedMariadbImage,
+						RootPasswordSecretKeyRef: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "mariadb-obj-root",
+							},
+							Key: "password",
+						},
+						Port: 3306,
+						ProbeAccount: &ProbeAccount{
+							Enabled:  true,
+							Username: "mariadb-probe",
+							PasswordSecretKeyRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "mariadb-obj-probe-password",
+								},
+								Key: "password",
+							},
+						},
+					},
+				},
+				env,
+			),
+			Entry(
+				"Disabled operator account",
+				&MariaDB{
+					ObjectMeta: objMeta,
+					Spec: MariaDBSpec{
+						OperatorAccount: &OperatorAccount{
+							Enabled: false,
+						},
+					},
+				},
+				&MariaDB{
+					ObjectMeta: objMeta,
+					Spec: MariaDBSpec{
+						Image: env.RelatedMariadbImage,
+						RootPasswordSecretKeyRef: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "mariadb-obj-root",
+							},
+							Key: "password",
+						},
+						Port: 3306,
+						OperatorAccount: &OperatorAccount{
+							Enabled: false,
+						},
+					},
+				},
+				env,
+			),
+		)
+	})
+
+	Context("When defaulting the operator account", func() {
+		It("Should not share the default privileges", func() {
+			mdb := &MariaDB{
+				ObjectMeta: objMeta,
+				Spec: MariaDBSpec{
+					OperatorAccount: &OperatorAccount{
+						Enabled: true,
+					},
+				},
+			}
+			mdb.SetDefaults(env)
+			Expect(mdb.Spec.OperatorAccount.Privileges).To(Equal(DefaultOperatorAccountPrivileges))
+
+			mdb.Spec.OperatorAccount.Privileges[0] = "ALL PRIVILEGES"
+			Expect(DefaultOperatorAccountPrivileges[0]).To(Equal("SELECT"))
+		})
+	})
+
+	Context("When getting the session policy system variables", func() {
+		DescribeTable(
+			"Should return the variables in seconds",
+			func(policy *SessionPolicy, expected map[string]string) {
+				Expect(policy.SystemVariables()).To(Equal(expected))
+			},
+			Entry(
+				"Empty",
+				&SessionPolicy{},
+				map[string]string{},
+			),
+			Entry(
+				"Timeouts",
+				&SessionPolicy{
+					WaitTimeout:        &metav1.Duration{Duration: 10 * time.Minute},
+					InteractiveTimeout: &metav1.Duration{Duration: 90500 * time.Millisecond},
+					MaxStatementTime:   &metav1.Duration{Duration: 1500 * time.Millisecond},
+				},
+				map[string]string{
+					"wait_timeout":        "600",
+					"interactive_timeout": "90",
+					"max_statement_time":  "1.5",
+				},
+			),
+		)
+	})
+
+	Context("When customizing the names of generated resources", func() {
+		DescribeTable(
+			"Should generate names",
+			func(naming *Naming, wantService, wantPrimary, wantRoot, wantSecondary string) {
+				mariadb := &MariaDB{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "mariadb",
+						Namespace: "test",
+					},
+					Spec: MariaDBSpec{
+						Naming: naming,
+					},
+				}
+				Expect(mariadb.ServiceKey().Name).To(Equal(wantService))
+				Expect(mariadb.PrimaryServiceKey().Name).To(Equal(wantPrimary))
+				Expect(mariadb.PrimaryConnectioneKey().Name).To(Equal(wantPrimary))
+				Expect(mariadb.RootPasswordSecretKeyRef().Name).To(Equal(wantRoot))
+				Expect(mariadb.NamedSecondaryServiceKey("reporting").Name).To(Equal(wantSecondary))
+			},
+			Entry(
+				"Default",
+				nil,
+				"mariadb",
+				"mariadb-primary",
+				"mariadb-root",
+				"mariadb-reporting",
+			),
+			Entry(
+				"Prefix and suffix",
+				&Naming{
+					Prefix: "new-",
+					Suffix: "-v2",
+				},
+				"new-mariadb-v2",
+				"new-mariadb-primary-v2",
+				"new-mariadb-root-v2",
+				"new-mariadb-reporting-v2",
+			),
+			Entry(
+				"Overrides",
+				&Naming{
+					Prefix: "new-",
+					Overrides: map[NamingResource]string{
+						NamingService: "db",
+						NamingRoot:    "db-root",
+					},
+				},
+				"db",
+				"new-mariadb-primary",
+				"db-root",
+				"new-mariadb-reporting",
+			),
+		)
+	})
+
+	Context("When rate limiting disruptive actions", func() {
+		now := time.Date(2023, 12, 19, 12, 0, 0, 0, time.UTC)
+		limit := &ActionRateLimit{
+			MaxActions: 2,
+			Window:     metav1.Duration{Duration: time.Hour},
+		}
+		action := func(ago time.Duration) DisruptiveAction {
+			return DisruptiveAction{
+				Time: metav1.NewTime(now.Add(-ago)),
+				Type: DisruptiveActionFailover,
+			}
+		}
+		DescribeTable(
+			"Should determine when the next action is allowed",
+			func(actions []DisruptiveAction, wantRecent int, wantRetryAfter time.Duration) {
+				Expect(limit.RecentActions(actions, now)).To(HaveLen(wantRecent))
+				Expect(limit.RetryAfter(actions, now)).To(Equal(wantRetryAfter))
+			},
+			Entry(
+				"No actions",
+				nil,
+				0,
+				time.Duration(0),
+			),
+			Entry(
+				"Below limit",
+				[]DisruptiveAction{
+					action(2 * time.Hour),
+					action(10 * time.Minute),
+				},
+				1,
+				time.Duration(0),
+			),
+			Entry(
+				"Limit reached",
+				[]DisruptiveAction{
+					action(2 * time.Hour),
+					action(40 * time.Minute),
+					action(10 * time.Minute),
+				},
+				2,
+				20*time.Minute,
+			),
+			Entry(
+				"Limit exceeded",
+				[]DisruptiveAction{
+					action(50 * time.Minute),
+					action(40 * time.Minute),
+					action(10 * time.Minute),
+				},
+				3,
+				20*time.Minute,
+			),
+		)
+	})
+
+	Context("When checking the replication lag of a replica", func() {
+		DescribeTable(
+			"Should determine whether it is lagging",
+			func(replica ReplicaStatus, maxLag time.Duration, wantLagging bool) {
+				Expect(replica.IsLagging(maxLag)).To(Equal(wantLagging))
+			},
+			Entry(
+				"Below max lag",
+				ReplicaStatus{
+					IORunning:            true,
+					SQLRunning:           true,
+					SecondsBehindPrimary: ptr.To(int64(5)),
+				},
+				10*time.Second,
+				false,
+			),
+			Entry(
+				"Above max lag",
+				ReplicaStatus{
+					IORunning:            true,
+					SQLRunning:           true,
+					SecondsBehindPrimary: ptr.To(int64(30)),
+				},
+				10*time.Second,
+				true,
+			),
+			Entry(
+				"SQL thread stopped",
+				ReplicaStatus{
+					IORunning:  true,
+					SQLRunning: false,
+				},
+				10*time.Second,
+				true,
+			),
+		)
+	})
+
+	Context("When getting the scheduled replicas", func() {
+		scheduledScaling := []ScheduledScaling{
+			{
+				Name:     "business-hours",
+				Cron:     "0 8 * * 1-5",
+				Duration: metav1.Duration{Duration: 10 * time.Hour},
+				Replicas: 5,
+			},
+			{
+				Name:     "reporting",
+				Cron:     "0 17 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+				Replicas: 4,
+			},
+		}
+		DescribeTable(
+			"Should return the replicas of the active window",
+			func(now time.Time, wantReplicas int32, wantWindow string, wantTransition time.Time) {
+				mariadb := &MariaDB{
+					Spec: MariaDBSpec{
+						Replicas:         3,
+						ScheduledScaling: scheduledScaling,
+					},
+				}
+				replicas, window := mariadb.ScheduledReplicas(2, now)
+				Expect(replicas).To(Equal(wantReplicas))
+				if wantWindow == "" {
+					Expect(window).To(BeNil())
+				} else {
+					Expect(window).NotTo(BeNil())
+					Expect(window.Name).To(Equal(wantWindow))
+				}
+
+				transition, ok := mariadb.NextScheduledScalingTransition(now)
+				Expect(ok).To(BeTrue())
+				Expect(transition).To(Equal(wantTransition))
+			},
+			Entry(
+				"No active windows",
+				time.Date(2023, 12, 19, 7, 0, 0, 0, time.Local),
+				int32(2),
+				"",
+				time.Date(2023, 12, 19, 8, 0, 0, 0, time.Local),
+			),
+			Entry(
+				"Single active window",
+				time.Date(2023, 12, 19, 9, 0, 0, 0, time.Local),
+				int32(5),
+				"business-hours",
+				time.Date(2023, 12, 19, 17, 0, 0, 0, time.Local),
+			),
+			Entry(
+				"Overlapping windows",
+				time.Date(2023, 12, 19, 17, 30, 0, 0, time.Local),
+				int32(5),
+				"business-hours",
+				time.Date(2023, 12, 19, 18, 0, 0, 0, time.Local),
+			),
+			Entry(
+				"Weekend window",
+				time.Date(2023, 12, 23, 18, 0, 0, 0, time.Local),
+				int32(4),
+				"reporting",
+				time.Date(2023, 12, 23, 19, 0, 0, 0, time.Local),
+			),
+		)
+	})
+
+	Context("When getting the scaled replicas", func() {
+		DescribeTable(
+			"Should prefer the scheduled replicas from the status",
+			func(status *ScheduledScalingStatus, wantReplicas int32) {
+				mariadb := &MariaDB{
+					Spec: MariaDBSpec{
+						Replicas: 3,
+					},
+					Status: MariaDBStatus{
+						ScheduledScaling: status,
+					},
+				}
+				Expect(mariadb.ScaledReplicas()).To(Equal(wantReplicas))
+			},
+			Entry(
+				"No scheduled scaling",
+				nil,
+				int32(3),
+			),
+			Entry(
+				"Scheduled scaling without replicas",
+				&ScheduledScalingStatus{},
+				int32(3),
+			),
+			Entry(
+				"Scheduled scaling",
+				&ScheduledScalingStatus{
+					BaseReplicas: 3,
+					Replicas:     5,
+				},
+				int32(5),
+			),
 		)
 	})
 })
@@ -0,0 +1,130 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradePreflightCheckName is the name of an upgrade pre-flight check.
+type UpgradePreflightCheckName string
+
+const (
+	// UpgradePreflightCheckVersion checks that the server is reachable and that the new image is not a downgrade.
+	UpgradePreflightCheckVersion UpgradePreflightCheckName = "Version"
+	// UpgradePreflightCheckVariables checks that the my.cnf does not contain variables removed or deprecated in the new version.
+	UpgradePreflightCheckVariables UpgradePreflightCheckName = "Variables"
+	// UpgradePreflightCheckPlugins checks that no active plugins have been removed in the new version.
+	UpgradePreflightCheckPlugins UpgradePreflightCheckName = "Plugins"
+	// UpgradePreflightCheckStorage checks that there is enough free storage to perform the upgrade.
+	UpgradePreflightCheckStorage UpgradePreflightCheckName = "Storage"
+	// UpgradePreflightCheckReplication checks that the replicas or the Galera nodes are healthy.
+	UpgradePreflightCheckReplication UpgradePreflightCheckName = "Replication"
+)
+
+var upgradePreflightChecks = []UpgradePreflightCheckName{
+	UpgradePreflightCheckVersion,
+	UpgradePreflightCheckVariables,
+	UpgradePreflightCheckPlugins,
+	UpgradePreflightCheckStorage,
+	UpgradePreflightCheckReplication,
+}
+
+// UpgradePreflightSeverity is the severity of an upgrade pre-flight check.
+type UpgradePreflightSeverity string
+
+const (
+	// UpgradePreflightSeverityCritical checks block the upgrade when they fail.
+	UpgradePreflightSeverityCritical UpgradePreflightSeverity = "Critical"
+	// UpgradePreflightSeverityWarning checks are reported without blocking the upgrade.
+	UpgradePreflightSeverityWarning UpgradePreflightSeverity = "Warning"
+)
+
+// UpgradePreflight defines the checks performed before rolling out a new MariaDB image.
+type UpgradePreflight struct {
+	// SkipChecks are the checks that are not performed. Valid values are: Version, Variables, Plugins, Storage and Replication.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SkipChecks []UpgradePreflightCheckName `json:"skipChecks,omitempty"`
+	// MinStorageHeadroomPercent is the minimum free storage, as a percentage of the storage size, required to upgrade. It defaults to 20.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MinStorageHeadroomPercent *int32 `json:"minStorageHeadroomPercent,omitempty"`
+	// Force rolls out the new image even if critical checks fail. The checks are still performed and reported.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Force bool `json:"force,omitempty"`
+}
+
+// MinStorageHeadroomPercentOrDefault returns the minimum free storage percentage, or the default one if not provided.
+func (u *UpgradePreflight) MinStorageHeadroomPercentOrDefault() int32 {
+	if u.MinStorageHeadroomPercent != nil {
+		return *u.MinStorageHeadroomPercent
+	}
+	return 20
+}
+
+// IsSkipped indicates whether a check is skipped.
+func (u *UpgradePreflight) IsSkipped(name UpgradePreflightCheckName) bool {
+	return slices.Contains(u.SkipChecks, name)
+}
+
+// Validate determines whether an UpgradePreflight is valid.
+func (u *UpgradePreflight) Validate() error {
+	for _, name := range u.SkipChecks {
+		if !slices.Contains(upgradePreflightChecks, name) {
+			return fmt.Errorf("unsupported check '%s'", name)
+		}
+	}
+	if len(u.SkipChecks) == len(upgradePreflightChecks) {
+		return errors.New("at least one check must not be skipped")
+	}
+	return nil
+}
+
+// UpgradePreflightCheck is the result of an upgrade pre-flight check.
+type UpgradePreflightCheck struct {
+	// Name of the check.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name UpgradePreflightCheckName `json:"name"`
+	// Severity of the check. Failed Critical checks block the upgrade.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Severity UpgradePreflightSeverity `json:"severity"`
+	// Passed indicates whether the check has passed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Passed bool `json:"passed"`
+	// Message is a human readable description of the result.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// IsBlocking indicates whether the check blocks the upgrade.
+func (c *UpgradePreflightCheck) IsBlocking() bool {
+	return !c.Passed && c.Severity == UpgradePreflightSeverityCritical
+}
+
+// UpgradePreflightStatus is the report of the upgrade pre-flight checks.
+type UpgradePreflightStatus struct {
+	// FromImage is the image currently rolled out.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	FromImage string `json:"fromImage"`
+	// ToImage is the image to be rolled out.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ToImage string `json:"toImage"`
+	// CheckTime is the time when the checks were performed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CheckTime metav1.Time `json:"checkTime"`
+	// Blocked indicates whether the upgrade is blocked by failed Critical checks.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Blocked bool `json:"blocked,omitempty"`
+	// Checks are the results of the checks.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Checks []UpgradePreflightCheck `json:"checks,omitempty"`
+}
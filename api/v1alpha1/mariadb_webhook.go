@@ -2,8 +2,15 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	log "sigs.k8s.io/controller-runtime/pkg/log"
@@ -14,8 +21,13 @@ import (
 var logger = log.Log.WithName("mariadb")
 
 func (r *MariaDB) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	policyWebhook := &mariadbPolicyWebhook{
+		client: mgr.GetClient(),
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(policyWebhook).
+		WithValidator(policyWebhook).
 		Complete()
 }
 
@@ -46,7 +58,10 @@ var _ webhook.Validator = &MariaDB{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *MariaDB) ValidateCreate() (admission.Warnings, error) {
 	logger.V(1).Info("Validate MariaDB creation", "mariadb", r.Name)
-	return nil, r.validate()
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r.warnings(), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -59,7 +74,13 @@ func (r *MariaDB) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.validate(); err != nil {
 		return nil, err
 	}
-	return nil, r.validatePrimarySwitchover(oldMariadb)
+	if err := r.validatePrimarySwitchover(oldMariadb); err != nil {
+		return nil, err
+	}
+	if err := r.validateGaleraTLSUpdate(oldMariadb); err != nil {
+		return nil, err
+	}
+	return newWarnings(r.warnings(), oldMariadb.warnings()), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -70,10 +91,30 @@ func (r *MariaDB) ValidateDelete() (admission.Warnings, error) {
 func (r *MariaDB) validate() error {
 	validateFns := []func() error{
 		r.validateHA,
+		r.validateEphemeral,
 		r.validateGalera,
 		r.validateReplication,
+		r.validateReplicationChannels,
 		r.validateBootstrapFrom,
 		r.validatePodDisruptionBudget,
+		r.validateMaintenanceWindow,
+		r.validateNotifications,
+		r.validateSecondaryServices,
+		r.validateSessionPolicy,
+		r.validateBinlogArchive,
+		r.validateSeedData,
+		r.validateWarmUp,
+		r.validateUpgradePreflight,
+		r.validateRightSizing,
+		r.validateScheduledScaling,
+		r.validateHibernate,
+		r.validateTLS,
+		r.validateActionRateLimit,
+		r.validateSpider,
+		r.validateProbeAccount,
+		r.validateMetrics,
+		r.validatePasswordRotation,
+		r.validateNaming,
 	}
 	for _, fn := range validateFns {
 		if err := fn(); err != nil {
@@ -104,6 +145,17 @@ func (r *MariaDB) validateHA() error {
 	return nil
 }
 
+func (r *MariaDB) validateEphemeral() error {
+	if r.Spec.Ephemeral && r.IsHAEnabled() {
+		return field.Invalid(
+			field.NewPath("spec").Child("ephemeral"),
+			r.Spec.Ephemeral,
+			"Ephemeral storage is only supported by standalone MariaDBs",
+		)
+	}
+	return nil
+}
+
 func (r *MariaDB) validateGalera() error {
 	if !r.Galera().Enabled {
 		return nil
@@ -129,6 +181,54 @@ func (r *MariaDB) validateGalera() error {
 			"'spec.galera.replicaThreads' must be at least 1",
 		)
 	}
+	if err := r.Galera().ValidateProviderOptions(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("galera").Child("providerOptions"),
+			r.Galera().ProviderOptions,
+			err.Error(),
+		)
+	}
+	if gcache := r.Galera().GCache; gcache != nil {
+		if err := gcache.Validate(); err != nil {
+			return field.Invalid(
+				field.NewPath("spec").Child("galera").Child("gcache"),
+				gcache,
+				err.Error(),
+			)
+		}
+		for opt := range gcache.ProviderOptions() {
+			if _, ok := r.Galera().ProviderOptions[opt]; ok {
+				return field.Invalid(
+					field.NewPath("spec").Child("galera").Child("providerOptions"),
+					r.Galera().ProviderOptions,
+					fmt.Sprintf("'%s' provider option cannot be set when it is defined in 'spec.galera.gcache'", opt),
+				)
+			}
+		}
+	}
+	if r.IsGaleraSegmentEnabled() {
+		if err := r.Galera().Segment.Validate(); err != nil {
+			return field.Invalid(
+				field.NewPath("spec").Child("galera").Child("segment").Child("segments"),
+				r.Galera().Segment.Segments,
+				err.Error(),
+			)
+		}
+		if _, ok := r.Galera().ProviderOptions["gmcast.segment"]; ok {
+			return field.Invalid(
+				field.NewPath("spec").Child("galera").Child("providerOptions"),
+				r.Galera().ProviderOptions,
+				"'gmcast.segment' provider option cannot be set when 'spec.galera.segment' is enabled",
+			)
+		}
+	}
+	if r.IsGaleraArbitratorEnabled() && r.Spec.Replicas%2 != 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("galera").Child("arbitrator").Child("enabled"),
+			r.Galera().Arbitrator.Enabled,
+			"the arbitrator requires an even number of 'spec.replicas', otherwise it results in an even number of votes",
+		)
+	}
 	return nil
 }
 
@@ -143,6 +243,13 @@ func (r *MariaDB) validateReplication() error {
 			"'spec.replication.primary.podIndex' out of 'spec.replicas' bounds",
 		)
 	}
+	if err := r.Replication().Primary.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("replication").Child("primary"),
+			r.Replication().Primary,
+			err.Error(),
+		)
+	}
 	if err := r.Replication().Replica.Validate(); err != nil {
 		return field.Invalid(
 			field.NewPath("spec").Child("replication").Child("replica"),
@@ -150,6 +257,60 @@ func (r *MariaDB) validateReplication() error {
 			err.Error(),
 		)
 	}
+	if semiSync := r.Replication().SemiSync; semiSync != nil {
+		if err := semiSync.Validate(); err != nil {
+			return field.Invalid(
+				field.NewPath("spec").Child("replication").Child("semiSync"),
+				semiSync,
+				err.Error(),
+			)
+		}
+	}
+	if external := r.Replication().External; external != nil {
+		if err := external.Validate(); err != nil {
+			return field.Invalid(
+				field.NewPath("spec").Child("replication").Child("external"),
+				external,
+				err.Error(),
+			)
+		}
+	}
+	if err := r.Replication().Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("replication"),
+			r.Replication(),
+			err.Error(),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateReplicationChannels() error {
+	if len(r.Spec.ReplicationChannels) == 0 {
+		return nil
+	}
+	if r.Galera().Enabled {
+		return field.Invalid(
+			field.NewPath("spec").Child("replicationChannels"),
+			r.Spec.ReplicationChannels,
+			"Replication channels are not supported by Galera",
+		)
+	}
+	names := make(map[string]struct{}, len(r.Spec.ReplicationChannels))
+	for i, channel := range r.Spec.ReplicationChannels {
+		path := field.NewPath("spec").Child("replicationChannels").Index(i)
+		if err := channel.Validate(); err != nil {
+			return field.Invalid(path, channel, fmt.Sprintf("invalid replication channel: %v", err))
+		}
+		if channel.Name == ReservedReplicationChannel {
+			return field.Invalid(path.Child("name"), channel.Name,
+				fmt.Sprintf("'%s' is reserved for the replication connections managed by the operator", ReservedReplicationChannel))
+		}
+		if _, ok := names[channel.Name]; ok {
+			return field.Duplicate(path.Child("name"), channel.Name)
+		}
+		names[channel.Name] = struct{}{}
+	}
 	return nil
 }
 
@@ -174,6 +335,21 @@ func (r *MariaDB) validatePrimarySwitchover(old *MariaDB) error {
 	return nil
 }
 
+// validateGaleraTLSUpdate forbids toggling the Galera TLS, as nodes with and without it are unable to communicate,
+// and the Pods are restarted one at a time.
+func (r *MariaDB) validateGaleraTLSUpdate(old *MariaDB) error {
+	galeraTLS := r.Spec.TLS != nil && r.Spec.TLS.GaleraEnabled
+	oldGaleraTLS := old.Spec.TLS != nil && old.Spec.TLS.GaleraEnabled
+	if galeraTLS != oldGaleraTLS {
+		return field.Invalid(
+			field.NewPath("spec").Child("tls").Child("galeraEnabled"),
+			galeraTLS,
+			"'spec.tls.galeraEnabled' field is inmutable",
+		)
+	}
+	return nil
+}
+
 func (r *MariaDB) validateBootstrapFrom() error {
 	if r.Spec.BootstrapFrom == nil {
 		return nil
@@ -201,3 +377,528 @@ func (r *MariaDB) validatePodDisruptionBudget() error {
 	}
 	return nil
 }
+
+func (r *MariaDB) validateNotifications() error {
+	if r.Spec.Notifications == nil {
+		return nil
+	}
+	if err := r.Spec.Notifications.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("notifications"),
+			r.Spec.Notifications,
+			fmt.Sprintf("invalid notifications: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateMaintenanceWindow() error {
+	if r.Spec.MaintenanceWindow == nil {
+		return nil
+	}
+	if err := r.Spec.MaintenanceWindow.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("maintenanceWindow"),
+			r.Spec.MaintenanceWindow,
+			fmt.Sprintf("invalid maintenance window: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateSessionPolicy() error {
+	if r.Spec.SessionPolicy == nil {
+		return nil
+	}
+	if err := r.Spec.SessionPolicy.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("sessionPolicy"),
+			r.Spec.SessionPolicy,
+			fmt.Sprintf("invalid session policy: %v", err),
+		)
+	}
+	return nil
+}
+
+var namingServiceResources = map[NamingResource]struct{}{
+	NamingService:   {},
+	NamingInternal:  {},
+	NamingPrimary:   {},
+	NamingSecondary: {},
+	NamingMetrics:   {},
+}
+
+// namingResourceKinds are the kinds of the objects generated for each NamingResource.
+// Generated names only collide when they are shared by objects of the same kind.
+var namingResourceKinds = map[NamingResource][]string{
+	NamingService:             {"Service"},
+	NamingConnection:          {"Connection"},
+	NamingInternal:            {"Service"},
+	NamingPrimary:             {"Service"},
+	NamingPrimaryConnection:   {"Connection"},
+	NamingSecondary:           {"Service"},
+	NamingSecondaryConnection: {"Connection"},
+	NamingMetrics:             {"Service", "Deployment"},
+	NamingConfig:              {"ConfigMap"},
+	NamingWsrepNotify:         {"ConfigMap"},
+	NamingRoot:                {"Secret"},
+	NamingPassword:            {"Secret"},
+	NamingMetricsPassword:     {"Secret"},
+	NamingMetricsConfig:       {"Secret"},
+	NamingOperatorPassword:    {"Secret"},
+	NamingProbePassword:       {"Secret"},
+	NamingSpiderPassword:      {"Secret"},
+	NamingRestore:             {"Restore"},
+	NamingSeedData:            {"Job"},
+	NamingProvisioning:        {"Job"},
+	NamingGaleraArbitrator:    {"Deployment"},
+	NamingTLS:                 {"Secret"},
+	NamingTLSCA:               {"Secret"},
+}
+
+func (r *MariaDB) validateNaming() error {
+	if r.Spec.Naming == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("naming")
+	if err := r.Spec.Naming.Validate(); err != nil {
+		return field.Invalid(
+			path,
+			r.Spec.Naming,
+			fmt.Sprintf("invalid naming: %v", err),
+		)
+	}
+	for _, resource := range namingResources {
+		name := r.generatedName(resource)
+		validateName := validation.IsDNS1123Subdomain
+		if _, ok := namingServiceResources[resource]; ok {
+			validateName = validation.IsDNS1123Label
+		}
+		if errs := validateName(name); len(errs) > 0 {
+			return field.Invalid(
+				path,
+				r.Spec.Naming,
+				fmt.Sprintf("invalid name '%s' for '%s': %v", name, resource, errs),
+			)
+		}
+	}
+	return r.validateNamingCollisions()
+}
+
+func (r *MariaDB) validateNamingCollisions() error {
+	generated := make(map[string]NamingResource)
+	for _, resource := range namingResources {
+		name := r.generatedName(resource)
+		for _, kind := range namingResourceKinds[resource] {
+			key := fmt.Sprintf("%s/%s", kind, name)
+			if other, ok := generated[key]; ok {
+				return field.Invalid(
+					field.NewPath("spec").Child("naming"),
+					r.Spec.Naming,
+					fmt.Sprintf("name '%s' of '%s' collides with '%s' %s", name, resource, other, kind),
+				)
+			}
+			generated[key] = resource
+		}
+	}
+	return nil
+}
+
+func (r *MariaDB) validateBinlogArchive() error {
+	if r.Spec.BinlogArchive == nil {
+		return nil
+	}
+	if err := r.Spec.BinlogArchive.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("binlogArchive"),
+			r.Spec.BinlogArchive,
+			fmt.Sprintf("invalid binlog archive: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateSeedData() error {
+	if r.Spec.SeedData == nil {
+		return nil
+	}
+	if err := r.Spec.SeedData.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("seedData"),
+			r.Spec.SeedData,
+			fmt.Sprintf("invalid seed data: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateWarmUp() error {
+	if r.Spec.WarmUp == nil {
+		return nil
+	}
+	if err := r.Spec.WarmUp.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("warmUp"),
+			r.Spec.WarmUp,
+			fmt.Sprintf("invalid warm-up: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateUpgradePreflight() error {
+	if r.Spec.UpgradePreflight == nil {
+		return nil
+	}
+	if err := r.Spec.UpgradePreflight.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("upgradePreflight"),
+			r.Spec.UpgradePreflight,
+			fmt.Sprintf("invalid upgrade pre-flight: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateRightSizing() error {
+	if r.Spec.RightSizing == nil {
+		return nil
+	}
+	if err := r.Spec.RightSizing.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("rightSizing"),
+			r.Spec.RightSizing,
+			fmt.Sprintf("invalid right-sizing: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateSpider() error {
+	if !r.IsSpiderEnabled() {
+		return nil
+	}
+	if r.IsHAEnabled() {
+		return field.Invalid(
+			field.NewPath("spec").Child("spider"),
+			r.Spec.Spider.Enabled,
+			"Spider is only supported by standalone MariaDBs",
+		)
+	}
+	if err := r.Spec.Spider.Validate(types.NamespacedName{Name: r.Name, Namespace: r.Namespace}); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("spider"),
+			r.Spec.Spider,
+			fmt.Sprintf("invalid Spider: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateProbeAccount() error {
+	if r.Spec.ProbeAccount == nil {
+		return nil
+	}
+	if err := r.Spec.ProbeAccount.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("probeAccount"),
+			r.Spec.ProbeAccount,
+			fmt.Sprintf("invalid probe account: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validatePasswordRotation() error {
+	if r.Spec.PasswordRotation == nil {
+		return nil
+	}
+	if err := r.Spec.PasswordRotation.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordRotation"),
+			r.Spec.PasswordRotation,
+			fmt.Sprintf("invalid password rotation: %v", err),
+		)
+	}
+	if r.Spec.ProbeAccount == nil || !r.Spec.ProbeAccount.Enabled {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordRotation"),
+			r.Spec.PasswordRotation,
+			"'spec.probeAccount' must be enabled to rotate the root password, as the probes would otherwise fail until the Pods are rolled out",
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateMetrics() error {
+	if r.Spec.Metrics == nil {
+		return nil
+	}
+	if interval := r.Spec.Metrics.PasswordRotationInterval; interval != nil && interval.Duration <= 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("metrics").Child("passwordRotationInterval"),
+			interval,
+			"password rotation interval must be greater than zero",
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateScheduledScaling() error {
+	if len(r.Spec.ScheduledScaling) == 0 {
+		return nil
+	}
+	path := field.NewPath("spec").Child("scheduledScaling")
+	if !r.IsHAEnabled() {
+		return field.Invalid(
+			path,
+			r.Spec.ScheduledScaling,
+			"'spec.scheduledScaling' requires either 'spec.replication' or 'spec.galera' to be enabled",
+		)
+	}
+	names := make(map[string]struct{}, len(r.Spec.ScheduledScaling))
+	for i, s := range r.Spec.ScheduledScaling {
+		scalingPath := path.Index(i)
+		if err := s.Validate(); err != nil {
+			return field.Invalid(scalingPath, s, fmt.Sprintf("invalid scheduled scaling: %v", err))
+		}
+		if _, ok := names[s.Name]; ok {
+			return field.Duplicate(scalingPath.Child("name"), s.Name)
+		}
+		names[s.Name] = struct{}{}
+
+		for _, svc := range r.Spec.SecondaryServices {
+			for _, podIndex := range svc.PodIndexes {
+				if podIndex >= int(s.Replicas) {
+					return field.Invalid(
+						scalingPath.Child("replicas"),
+						s.Replicas,
+						fmt.Sprintf("pod index %d of Service '%s' out of replicas bounds", podIndex, svc.Name),
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MariaDB) validateHibernate() error {
+	if r.Spec.Hibernate == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("hibernate")
+	if r.Spec.Ephemeral {
+		return field.Invalid(
+			path,
+			r.Spec.Hibernate,
+			"Hibernation is not supported by ephemeral MariaDBs, as the data would be lost",
+		)
+	}
+	if err := r.Spec.Hibernate.Validate(); err != nil {
+		return field.Invalid(
+			path,
+			r.Spec.Hibernate,
+			fmt.Sprintf("invalid hibernate: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateTLS() error {
+	if r.Spec.TLS == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("tls")
+	if err := r.Spec.TLS.Validate(); err != nil {
+		return field.Invalid(
+			path,
+			r.Spec.TLS,
+			fmt.Sprintf("invalid TLS: %v", err),
+		)
+	}
+	if r.Spec.TLS.GaleraEnabled {
+		if !r.Spec.TLS.Enabled || !r.Galera().Enabled {
+			return field.Invalid(
+				path.Child("galeraEnabled"),
+				r.Spec.TLS.GaleraEnabled,
+				"'spec.tls.galeraEnabled' requires 'spec.tls.enabled' and 'spec.galera.enabled'",
+			)
+		}
+		for opt := range r.Galera().ProviderOptions {
+			if strings.HasPrefix(opt, "socket.ssl") {
+				return field.Invalid(
+					field.NewPath("spec").Child("galera").Child("providerOptions"),
+					r.Galera().ProviderOptions,
+					fmt.Sprintf("'%s' provider option cannot be set when 'spec.tls.galeraEnabled' is enabled", opt),
+				)
+			}
+		}
+	}
+	if r.Spec.TLS.ReplicationEnabled && (!r.Spec.TLS.Enabled || !r.Replication().Enabled) {
+		return field.Invalid(
+			path.Child("replicationEnabled"),
+			r.Spec.TLS.ReplicationEnabled,
+			"'spec.tls.replicationEnabled' requires 'spec.tls.enabled' and 'spec.replication.enabled'",
+		)
+	}
+	return nil
+}
+
+func (r *MariaDB) validateActionRateLimit() error {
+	if r.Spec.ActionRateLimit == nil {
+		return nil
+	}
+	if err := r.Spec.ActionRateLimit.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("actionRateLimit"),
+			r.Spec.ActionRateLimit,
+			fmt.Sprintf("invalid action rate limit: %v", err),
+		)
+	}
+	return nil
+}
+
+// reservedServiceNames are the suffixes of the Services already managed by the operator.
+var reservedServiceNames = []string{"internal", "primary", "secondary", "metrics"}
+
+func (r *MariaDB) validateSecondaryServices() error {
+	if len(r.Spec.SecondaryServices) == 0 {
+		return nil
+	}
+	path := field.NewPath("spec").Child("secondaryServices")
+	if !r.IsHAEnabled() {
+		return field.Invalid(
+			path,
+			r.Spec.SecondaryServices,
+			"'spec.secondaryServices' can only be specified when 'spec.replication' or 'spec.galera' are configured",
+		)
+	}
+	names := make(map[string]struct{}, len(r.Spec.SecondaryServices))
+	for i, svc := range r.Spec.SecondaryServices {
+		svcPath := path.Index(i)
+		if errs := validation.IsDNS1123Label(svc.Name); len(errs) > 0 {
+			return field.Invalid(svcPath.Child("name"), svc.Name, fmt.Sprintf("invalid name: %v", errs))
+		}
+		for _, reserved := range reservedServiceNames {
+			if svc.Name == reserved {
+				return field.Invalid(svcPath.Child("name"), svc.Name, "name is reserved for the Services managed by the operator")
+			}
+		}
+		if _, ok := names[svc.Name]; ok {
+			return field.Duplicate(svcPath.Child("name"), svc.Name)
+		}
+		names[svc.Name] = struct{}{}
+
+		for j, podIndex := range svc.PodIndexes {
+			if podIndex < 0 || podIndex >= int(r.Spec.Replicas) {
+				return field.Invalid(
+					svcPath.Child("podIndexes").Index(j),
+					podIndex,
+					"pod index out of 'spec.replicas' bounds",
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// defaultBufferPoolSize is the default value of innodb_buffer_pool_size.
+const defaultBufferPoolSize = 128 * 1024 * 1024
+
+// bufferPoolStorageRatio is the ratio between the storage and the buffer pool size above which the buffer pool is considered too small.
+const bufferPoolStorageRatio = 100
+
+// warnings returns the configurations that are allowed but risky, to be reported at apply time without blocking the request.
+func (r *MariaDB) warnings() admission.Warnings {
+	warnFns := []func() string{
+		r.warnGaleraReplicas,
+		r.warnBufferPoolSize,
+	}
+	var warnings admission.Warnings
+	for _, fn := range warnFns {
+		if warning := fn(); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+func (r *MariaDB) warnGaleraReplicas() string {
+	if !r.Galera().Enabled || r.Spec.Replicas%2 != 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"'spec.replicas' is %d: a Galera cluster with an even number of nodes loses quorum when it is split in halves, an odd number is recommended",
+		r.Spec.Replicas,
+	)
+}
+
+func (r *MariaDB) warnBufferPoolSize() string {
+	storage, ok := r.Spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return ""
+	}
+	bufferPoolSize := int64(defaultBufferPoolSize)
+	if r.Spec.MyCnf != nil {
+		if size, ok := myCnfBufferPoolSize(*r.Spec.MyCnf); ok {
+			bufferPoolSize = size
+		}
+	} else if r.Spec.MyCnfConfigMapKeyRef != nil {
+		// the configuration is not known at admission time.
+		return ""
+	}
+	if storage.Value() <= bufferPoolSize*bufferPoolStorageRatio {
+		return ""
+	}
+	return fmt.Sprintf(
+		"'innodb_buffer_pool_size' (%s) is less than 1%% of the storage (%s), consider increasing it via 'spec.myCnf' to avoid excessive disk reads",
+		resource.NewQuantity(bufferPoolSize, resource.BinarySI).String(),
+		storage.String(),
+	)
+}
+
+// myCnfBufferPoolSize returns the innodb_buffer_pool_size defined in a my.cnf file, if any.
+func myCnfBufferPoolSize(myCnf string) (int64, bool) {
+	var size int64
+	var found bool
+	for _, line := range strings.Split(myCnf, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ReplaceAll(strings.TrimSpace(key), "-", "_")
+		if key != "innodb_buffer_pool_size" {
+			continue
+		}
+		if s, err := parseMyCnfSize(strings.TrimSpace(value)); err == nil {
+			size = s
+			found = true
+		}
+	}
+	return size, found
+}
+
+// parseMyCnfSize parses a size in bytes with an optional K, M, G or T suffix, as accepted by the MariaDB options.
+func parseMyCnfSize(value string) (int64, error) {
+	multiplier := int64(1)
+	if value != "" {
+		switch strings.ToUpper(value[len(value)-1:]) {
+		case "K":
+			multiplier = 1 << 10
+		case "M":
+			multiplier = 1 << 20
+		case "G":
+			multiplier = 1 << 30
+		case "T":
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			value = value[:len(value)-1]
+		}
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size '%s': %v", value, err)
+	}
+	return size * multiplier, nil
+}
@@ -67,17 +67,1601 @@ var _ = Describe("MariaDB webhook", func() {
 				},
 				true,
 			),
+			Entry(
+				"Valid seed data",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						SeedData: &SeedData{
+							S3: S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+							Files: []SeedFile{
+								{
+									Name:   "schema.sql",
+									SHA256: "c8f21c14cf5c13bb6698c667f6f10fe57958bb12933e421323fda212a3243646",
+								},
+								{
+									Name:     "users.csv",
+									SHA256:   "c8f21c14cf5c13bb6698c667f6f10fe57958bb12933e421323fda212a3243646",
+									Database: func() *string { d := "demo"; return &d }(),
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid seed data",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						SeedData: &SeedData{
+							S3: S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+							Files: []SeedFile{
+								{
+									Name:   "users.csv",
+									SHA256: "c8f21c14cf5c13bb6698c667f6f10fe57958bb12933e421323fda212a3243646",
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid maintenance window",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						MaintenanceWindow: &MaintenanceWindow{
+							Cron:     "0 3 * * 0",
+							Duration: metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid maintenance window",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						MaintenanceWindow: &MaintenanceWindow{
+							Cron: "foo",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid session policy",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						SessionPolicy: &SessionPolicy{
+							WaitTimeout:        &metav1.Duration{Duration: 10 * time.Minute},
+							InteractiveTimeout: &metav1.Duration{Duration: time.Hour},
+							MaxStatementTime:   &metav1.Duration{Duration: 30 * time.Second},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid session policy",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						SessionPolicy: &SessionPolicy{
+							WaitTimeout: &metav1.Duration{Duration: 500 * time.Millisecond},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid naming",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Naming: &Naming{
+							Prefix: "new-",
+							Overrides: map[NamingResource]string{
+								NamingService: "mariadb-migrated",
+								NamingRoot:    "mariadb-root-migrated",
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid naming override",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Naming: &Naming{
+							Overrides: map[NamingResource]string{
+								"statefulset": "mariadb-migrated",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid naming Service name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Naming: &Naming{
+							Suffix: ".migrated",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid colliding naming overrides",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Naming: &Naming{
+							Overrides: map[NamingResource]string{
+								NamingRoot:     "mariadb-credentials",
+								NamingPassword: "mariadb-credentials",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid naming override colliding with a generated name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Naming: &Naming{
+							Overrides: map[NamingResource]string{
+								NamingInternal: meta.Name,
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid naming overrides shared across kinds",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Naming: &Naming{
+							Overrides: map[NamingResource]string{
+								NamingConfig: "mariadb-shared",
+								NamingRoot:   "mariadb-shared",
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid ephemeral",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Ephemeral: true,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid ephemeral with replication",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas:  3,
+						Ephemeral: true,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid binlog archive",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						BinlogArchive: &BinlogArchive{
+							S3: S3{
+								Bucket:   "binlogs",
+								Endpoint: "minio:9000",
+								Prefix:   "mariadb",
+							},
+							Interval: &metav1.Duration{Duration: 30 * time.Second},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid binlog archive interval",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						BinlogArchive: &BinlogArchive{
+							S3: S3{
+								Bucket:   "binlogs",
+								Endpoint: "minio:9000",
+							},
+							Interval: &metav1.Duration{Duration: 100 * time.Millisecond},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid notifications",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Notifications: &Notifications{
+							Targets: []NotificationTarget{
+								{
+									Name: "slack",
+									Type: NotificationTargetTypeSlack,
+									URLSecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "notifications",
+										},
+										Key: "slack-url",
+									},
+									Events: []NotificationEvent{
+										NotificationEventFailover,
+										NotificationEventBackupFailed,
+									},
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid notification target",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Notifications: &Notifications{
+							Targets: []NotificationTarget{
+								{
+									Name: "pagerduty",
+									Type: NotificationTargetTypePagerDuty,
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid notification event",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Notifications: &Notifications{
+							Targets: []NotificationTarget{
+								{
+									Name: "webhook",
+									Type: NotificationTargetTypeWebhook,
+									URLSecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "notifications",
+										},
+										Key: "webhook-url",
+									},
+									Events: []NotificationEvent{
+										"foo",
+									},
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid secondary Services",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						SecondaryServices: []SecondaryService{
+							{
+								Name:       "reporting",
+								PodIndexes: []int{2},
+							},
+							{
+								Name: "app-read",
+								PodSelector: map[string]string{
+									"tier": "read",
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Secondary Services without HA",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						SecondaryServices: []SecondaryService{
+							{
+								Name: "reporting",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Reserved secondary Service name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						SecondaryServices: []SecondaryService{
+							{
+								Name: "primary",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated secondary Service name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						SecondaryServices: []SecondaryService{
+							{
+								Name: "reporting",
+							},
+							{
+								Name: "reporting",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Secondary Service Pod index out of bounds",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						SecondaryServices: []SecondaryService{
+							{
+								Name:       "reporting",
+								PodIndexes: []int{3},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid warm-up",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						WarmUp: &WarmUp{
+							BufferPoolLoad: true,
+							Queries: []string{
+								"SELECT COUNT(*) FROM app.orders",
+							},
+							Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid scheduled scaling",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						ScheduledScaling: []ScheduledScaling{
+							{
+								Name:     "business-hours",
+								Cron:     "0 8 * * 1-5",
+								Duration: metav1.Duration{Duration: 10 * time.Hour},
+								Replicas: 5,
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Scheduled scaling without HA",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replicas: 1,
+						ScheduledScaling: []ScheduledScaling{
+							{
+								Name:     "business-hours",
+								Cron:     "0 8 * * 1-5",
+								Duration: metav1.Duration{Duration: 10 * time.Hour},
+								Replicas: 5,
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid scheduled scaling cron",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						ScheduledScaling: []ScheduledScaling{
+							{
+								Name:     "business-hours",
+								Cron:     "foo",
+								Duration: metav1.Duration{Duration: 10 * time.Hour},
+								Replicas: 5,
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Scheduled scaling out of Secondary Service bounds",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 4,
+						SecondaryServices: []SecondaryService{
+							{
+								Name:       "reporting",
+								PodIndexes: []int{3},
+							},
+						},
+						ScheduledScaling: []ScheduledScaling{
+							{
+								Name:     "night",
+								Cron:     "0 22 * * *",
+								Duration: metav1.Duration{Duration: 8 * time.Hour},
+								Replicas: 2,
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid action rate limit",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						ActionRateLimit: &ActionRateLimit{
+							MaxActions: 3,
+							Window:     metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid probe account rotation interval",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						ProbeAccount: &ProbeAccount{
+							Enabled:          true,
+							RotationInterval: &metav1.Duration{Duration: -time.Hour},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid probe account",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						ProbeAccount: &ProbeAccount{
+							Enabled:          true,
+							RotationInterval: &metav1.Duration{Duration: 24 * time.Hour},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid metrics password rotation interval",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Metrics: &Metrics{
+							Enabled:                  true,
+							PasswordRotationInterval: &metav1.Duration{Duration: -time.Hour},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid password rotation interval",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						PasswordRotation: &PasswordRotation{
+							Interval: metav1.Duration{Duration: -time.Hour},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid password rotation without probe account",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						PasswordRotation: &PasswordRotation{
+							Interval: metav1.Duration{Duration: 720 * time.Hour},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid password rotation",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						PasswordRotation: &PasswordRotation{
+							Interval: metav1.Duration{Duration: 720 * time.Hour},
+						},
+						ProbeAccount: &ProbeAccount{
+							Enabled: true,
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid hibernate with ephemeral storage",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Ephemeral: true,
+						Hibernate: &Hibernate{
+							Enabled: true,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid hibernate final backup",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Hibernate: &Hibernate{
+							Enabled: true,
+							FinalBackup: &HibernateBackup{
+								Storage: BackupStorage{},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid hibernate",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						Hibernate: &Hibernate{
+							Enabled: true,
+							FinalBackup: &HibernateBackup{
+								Storage: BackupStorage{
+									S3: &S3{
+										Bucket:   "backups",
+										Endpoint: "s3.amazonaws.com",
+									},
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid TLS issuer without name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						TLS: &MariaDBTLS{
+							Enabled:   true,
+							IssuerRef: &IssuerRef{},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid TLS renewBefore",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						TLS: &MariaDBTLS{
+							Enabled: true,
+							IssuerRef: &IssuerRef{
+								Name: "ca",
+							},
+							Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+							RenewBefore: &metav1.Duration{Duration: 48 * time.Hour},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid TLS",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						TLS: &MariaDBTLS{
+							Enabled: true,
+							IssuerRef: &IssuerRef{
+								Name: "ca",
+								Kind: ClusterIssuerKind,
+							},
+							Duration:    &metav1.Duration{Duration: 90 * 24 * time.Hour},
+							RenewBefore: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid Galera TLS without Galera",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						TLS: &MariaDBTLS{
+							Enabled:       true,
+							GaleraEnabled: true,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid Galera TLS with socket.ssl provider options",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								ProviderOptions: map[string]string{
+									"socket.ssl_cipher": "AES128-SHA256",
+								},
+							},
+						},
+						Replicas: 3,
+						TLS: &MariaDBTLS{
+							Enabled:       true,
+							GaleraEnabled: true,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid Galera TLS",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+						},
+						Replicas: 3,
+						TLS: &MariaDBTLS{
+							Enabled:       true,
+							GaleraEnabled: true,
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid replication TLS without replication",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						TLS: &MariaDBTLS{
+							Enabled:            true,
+							ReplicationEnabled: true,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid GTID settings",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								GtidStrictMode: ptr.To(true),
+								GtidDomainId:   ptr.To(uint32(10)),
+								BinlogFormat:   ptr.To(BinlogFormatRow),
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid binlog format",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								BinlogFormat: ptr.To(BinlogFormat("foo")),
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid action rate limit max actions",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						ActionRateLimit: &ActionRateLimit{
+							MaxActions: 0,
+							Window:     metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid action rate limit window",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						ActionRateLimit: &ActionRateLimit{
+							MaxActions: 3,
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid warm-up query",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						WarmUp: &WarmUp{
+							Queries: []string{
+								" ",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid warm-up timeout",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+						WarmUp: &WarmUp{
+							Timeout: &metav1.Duration{},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid upgrade pre-flight",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						UpgradePreflight: &UpgradePreflight{
+							SkipChecks: []UpgradePreflightCheckName{
+								UpgradePreflightCheckPlugins,
+							},
+							MinStorageHeadroomPercent: func() *int32 { p := int32(30); return &p }(),
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid upgrade pre-flight check",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						UpgradePreflight: &UpgradePreflight{
+							SkipChecks: []UpgradePreflightCheckName{
+								"foo",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid upgrade pre-flight skipping all checks",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						UpgradePreflight: &UpgradePreflight{
+							SkipChecks: []UpgradePreflightCheckName{
+								UpgradePreflightCheckVersion,
+								UpgradePreflightCheckVariables,
+								UpgradePreflightCheckPlugins,
+								UpgradePreflightCheckStorage,
+								UpgradePreflightCheckReplication,
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid Spider",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Spider: &Spider{
+							Enabled: true,
+							Nodes: []SpiderNode{
+								{
+									MariaDBRef: MariaDBRef{
+										ObjectReference: corev1.ObjectReference{
+											Name: "shard-1",
+										},
+									},
+								},
+								{
+									MariaDBRef: MariaDBRef{
+										ObjectReference: corev1.ObjectReference{
+											Name:      "shard-2",
+											Namespace: "shards",
+										},
+									},
+									ServerName: "shard-2",
+									Database:   func() *string { d := "app"; return &d }(),
+								},
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid Spider with HA",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Spider: &Spider{
+							Enabled: true,
+						},
+						Replication: &Replication{
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid Spider node referencing itself",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Spider: &Spider{
+							Enabled: true,
+							Nodes: []SpiderNode{
+								{
+									MariaDBRef: MariaDBRef{
+										ObjectReference: corev1.ObjectReference{
+											Name: meta.Name,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid Spider duplicated server names",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Spider: &Spider{
+							Enabled: true,
+							Nodes: []SpiderNode{
+								{
+									MariaDBRef: MariaDBRef{
+										ObjectReference: corev1.ObjectReference{
+											Name: "shard-1",
+										},
+									},
+									ServerName: "shard",
+								},
+								{
+									MariaDBRef: MariaDBRef{
+										ObjectReference: corev1.ObjectReference{
+											Name: "shard-2",
+										},
+									},
+									ServerName: "shard",
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid Spider server name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Spider: &Spider{
+							Enabled: true,
+							Nodes: []SpiderNode{
+								{
+									MariaDBRef: MariaDBRef{
+										ObjectReference: corev1.ObjectReference{
+											Name: "shard-1",
+										},
+									},
+									ServerName: "Shard_1",
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
 			Entry(
 				"Valid Galera",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
-						Galera: &Galera{
-							Enabled: true,
-							GaleraSpec: GaleraSpec{
-								SST:            &sst,
-								ReplicaThreads: &replicaThreads,
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								SST:            &sst,
+								ReplicaThreads: &replicaThreads,
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid Galera arbitrator",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								Arbitrator: &GaleraArbitrator{
+									Enabled: true,
+								},
+							},
+						},
+						Replicas: 2,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid Galera arbitrator with odd replicas",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								Arbitrator: &GaleraArbitrator{
+									Enabled: true,
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid Galera provider options",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								ProviderOptions: map[string]string{
+									"gcache.size":         "2G",
+									"evs.suspect_timeout": "PT10S",
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid Galera provider options",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								ProviderOptions: map[string]string{
+									"gcache.size": "2G;gcs.fc_limit=128",
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid Galera segments",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								Segment: &GaleraSegment{
+									Enabled: true,
+									Segments: map[string]int32{
+										"eu-west-1a": 0,
+										"eu-west-1b": 1,
+									},
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid Galera segments out of range",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								Segment: &GaleraSegment{
+									Enabled: true,
+									Segments: map[string]int32{
+										"eu-west-1a": 256,
+									},
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid Galera segments with gmcast.segment provider option",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								ProviderOptions: map[string]string{
+									"gmcast.segment": "1",
+								},
+								Segment: &GaleraSegment{
+									Enabled: true,
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid Galera gcache",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								GCache: &GaleraGCache{
+									Size:    func() *resource.Quantity { q := resource.MustParse("2Gi"); return &q }(),
+									Recover: func() *bool { r := true; return &r }(),
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid Galera gcache with gcache.size provider option",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								ProviderOptions: map[string]string{
+									"gcache.size": "1G",
+								},
+								GCache: &GaleraGCache{
+									Size: func() *resource.Quantity { q := resource.MustParse("2Gi"); return &q }(),
+								},
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid replication",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									PodIndex: func() *int { i := 0; return &i }(),
+								},
+								SyncBinlog: func() *bool { f := true; return &f }(),
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid HA",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									PodIndex: func() *int { i := 0; return &i }(),
+								},
+								SyncBinlog: func() *bool { f := true; return &f }(),
+							},
+							Enabled: true,
+						},
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								SST:            &sst,
+								ReplicaThreads: &replicaThreads,
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Fewer replicas required",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replicas: 4,
+					},
+				},
+				true,
+			),
+			Entry(
+				"More replicas required",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								SST: &sst,
+							},
+						},
+						Replicas: 1,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid SST",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								SST: func() *SST {
+									s := SST("foo")
+									return &s
+								}(),
+								ReplicaThreads: &replicaThreads,
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replica threads",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								SST: &sst,
+								ReplicaThreads: func() *int {
+									r := -1
+									return &r
+								}(),
+							},
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replication primary pod index",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									PodIndex: func() *int { i := 4; return &i }(),
+								},
+								Replica: &ReplicaReplication{
+									WaitPoint:         func() *WaitPoint { w := WaitPointAfterCommit; return &w }(),
+									ConnectionTimeout: &metav1.Duration{Duration: time.Duration(1 * time.Second)},
+									ConnectionRetries: func() *int { r := 3; return &r }(),
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replication failover delay",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									FailoverDelay: &metav1.Duration{Duration: -1 * time.Second},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replication failover cooldown",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									FailoverCooldown: &metav1.Duration{Duration: -1 * time.Second},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replication failover retry interval",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									FailoverRetryInterval: &metav1.Duration{Duration: -1 * time.Second},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid replication failover timers",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									FailoverDelay:         &metav1.Duration{Duration: 10 * time.Second},
+									FailoverCooldown:      &metav1.Duration{Duration: 5 * time.Minute},
+									FailoverRetryInterval: &metav1.Duration{Duration: 30 * time.Second},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid replication connection draining",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									ConnectionDraining: &ConnectionDraining{
+										GracePeriod: &metav1.Duration{Duration: time.Minute},
+										KillPolicy:  KillPolicyQuery,
+										ExemptUsers: []string{"batch"},
+									},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid replication connection draining grace period",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									ConnectionDraining: &ConnectionDraining{
+										GracePeriod: &metav1.Duration{Duration: -1 * time.Second},
+									},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid replication fencing",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									Fencing: &Fencing{
+										Timeout:   &metav1.Duration{Duration: 10 * time.Second},
+										DeletePod: true,
+									},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid replication fencing timeout",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Primary: &PrimaryReplication{
+									Fencing: &Fencing{
+										Timeout: &metav1.Duration{Duration: 0},
+									},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replication parallel mode",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Replica: &ReplicaReplication{
+									ParallelMode: func() *ParallelMode { p := ParallelModeOptimistic; return &p }(),
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid replica max lag",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Replica: &ReplicaReplication{
+									MaxLag: &metav1.Duration{Duration: 0},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid replica max lag",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Replica: &ReplicaReplication{
+									MaxLag: &metav1.Duration{Duration: 30 * time.Second},
+								},
+							},
+							Enabled: true,
+						},
+						Replicas: 3,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid replication parallel mode",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Replica: &ReplicaReplication{
+									ParallelThreads: func() *int32 { t := int32(4); return &t }(),
+									ParallelMode:    func() *ParallelMode { p := ParallelModeOptimistic; return &p }(),
+								},
 							},
+							Enabled: true,
 						},
 						Replicas: 3,
 					},
@@ -85,90 +1669,235 @@ var _ = Describe("MariaDB webhook", func() {
 				false,
 			),
 			Entry(
-				"Valid replication",
+				"Invalid right-sizing",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						RightSizing: &RightSizing{
+							Interval: &metav1.Duration{Duration: 10 * time.Minute},
+							Window:   &metav1.Duration{Duration: 5 * time.Minute},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid right-sizing",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						RightSizing: &RightSizing{
+							Interval:              &metav1.Duration{Duration: 1 * time.Minute},
+							Window:                &metav1.Duration{Duration: 24 * time.Hour},
+							VerticalPodAutoscaler: true,
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid semi-sync timeout",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
 						Replication: &Replication{
 							ReplicationSpec: ReplicationSpec{
-								Primary: &PrimaryReplication{
-									PodIndex: func() *int { i := 0; return &i }(),
+								SemiSync: &SemiSyncReplication{
+									Timeout: &metav1.Duration{Duration: -1 * time.Second},
 								},
-								SyncBinlog: func() *bool { f := true; return &f }(),
 							},
 							Enabled: true,
 						},
 						Replicas: 3,
 					},
 				},
-				false,
+				true,
 			),
 			Entry(
-				"Invalid HA",
+				"Invalid external primary",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
 						Replication: &Replication{
 							ReplicationSpec: ReplicationSpec{
-								Primary: &PrimaryReplication{
-									PodIndex: func() *int { i := 0; return &i }(),
+								External: &ExternalReplication{
+									Host: "mariadb.example.com",
+									PasswordSecretKeyRef: corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "external",
+										},
+										Key: "password",
+									},
 								},
-								SyncBinlog: func() *bool { f := true; return &f }(),
 							},
 							Enabled: true,
 						},
-						Galera: &Galera{
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid external primary TLS",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								External: &ExternalReplication{
+									Host:     "mariadb.example.com",
+									Username: "repl",
+									PasswordSecretKeyRef: corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "external",
+										},
+										Key: "password",
+									},
+									TLS: &ExternalReplicationTLS{
+										VerifyServerCert: true,
+									},
+								},
+							},
 							Enabled: true,
-							GaleraSpec: GaleraSpec{
-								SST:            &sst,
-								ReplicaThreads: &replicaThreads,
+						},
+						Replicas: 3,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid external primary",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								External: &ExternalReplication{
+									Host:     "mariadb.example.com",
+									Port:     3307,
+									Username: "repl",
+									PasswordSecretKeyRef: corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "external",
+										},
+										Key: "password",
+									},
+									TLS: &ExternalReplicationTLS{
+										Enabled: true,
+										CASecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: "external-ca",
+											},
+											Key: "ca.crt",
+										},
+										VerifyServerCert: true,
+									},
+								},
 							},
+							Enabled: true,
 						},
 						Replicas: 3,
 					},
 				},
+				false,
+			),
+			Entry(
+				"Invalid replication channel name",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						ReplicationChannels: []ReplicationChannel{
+							{
+								Name:     "upstream'1",
+								Host:     "upstream.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "upstream",
+									},
+									Key: "password",
+								},
+							},
+						},
+					},
+				},
 				true,
 			),
 			Entry(
-				"Fewer replicas required",
+				"Reserved replication channel name",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
-						Replicas: 4,
+						ReplicationChannels: []ReplicationChannel{
+							{
+								Name:     "mariadb-operator",
+								Host:     "mariadb-operator.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "mariadb-operator",
+									},
+									Key: "password",
+								},
+							},
+						},
 					},
 				},
 				true,
 			),
 			Entry(
-				"More replicas required",
+				"Duplicated replication channel",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
-						Galera: &Galera{
-							Enabled: true,
-							GaleraSpec: GaleraSpec{
-								SST: &sst,
+						ReplicationChannels: []ReplicationChannel{
+							{
+								Name:     "upstream",
+								Host:     "upstream.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "upstream",
+									},
+									Key: "password",
+								},
+							},
+							{
+								Name:     "upstream",
+								Host:     "upstream.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "upstream",
+									},
+									Key: "password",
+								},
 							},
 						},
-						Replicas: 1,
 					},
 				},
 				true,
 			),
 			Entry(
-				"Invalid SST",
+				"Replication channels with Galera",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
+						ReplicationChannels: []ReplicationChannel{
+							{
+								Name:     "upstream",
+								Host:     "upstream.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "upstream",
+									},
+									Key: "password",
+								},
+							},
+						},
 						Galera: &Galera{
 							Enabled: true,
-							GaleraSpec: GaleraSpec{
-								SST: func() *SST {
-									s := SST("foo")
-									return &s
-								}(),
-								ReplicaThreads: &replicaThreads,
-							},
 						},
 						Replicas: 3,
 					},
@@ -176,19 +1905,56 @@ var _ = Describe("MariaDB webhook", func() {
 				true,
 			),
 			Entry(
-				"Invalid replica threads",
+				"Valid replication channels",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
-						Galera: &Galera{
-							Enabled: true,
-							GaleraSpec: GaleraSpec{
-								SST: &sst,
-								ReplicaThreads: func() *int {
-									r := -1
-									return &r
-								}(),
+						ReplicationChannels: []ReplicationChannel{
+							{
+								Name:     "upstream_1",
+								Host:     "upstream-1.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "upstream-1",
+									},
+									Key: "password",
+								},
+							},
+							{
+								Name:     "upstream-2",
+								Host:     "upstream-2.example.com",
+								Username: "repl",
+								PasswordSecretKeyRef: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "upstream-2",
+									},
+									Key: "password",
+								},
+								Gtid: func() *Gtid { g := GtidSlavePos; return &g }(),
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid replica provisioning",
+				&MariaDB{
+					ObjectMeta: meta,
+					Spec: MariaDBSpec{
+						Replication: &Replication{
+							ReplicationSpec: ReplicationSpec{
+								Replica: &ReplicaReplication{
+									Provisioning: &ReplicaProvisioning{
+										Source: ReplicaProvisioningSourcePrimary,
+										BackupRef: &corev1.LocalObjectReference{
+											Name: "backup",
+										},
+									},
+								},
 							},
+							Enabled: true,
 						},
 						Replicas: 3,
 					},
@@ -196,19 +1962,18 @@ var _ = Describe("MariaDB webhook", func() {
 				true,
 			),
 			Entry(
-				"Invalid replication primary pod index",
+				"Valid replica provisioning",
 				&MariaDB{
 					ObjectMeta: meta,
 					Spec: MariaDBSpec{
 						Replication: &Replication{
 							ReplicationSpec: ReplicationSpec{
-								Primary: &PrimaryReplication{
-									PodIndex: func() *int { i := 4; return &i }(),
-								},
 								Replica: &ReplicaReplication{
-									WaitPoint:         func() *WaitPoint { w := WaitPointAfterCommit; return &w }(),
-									ConnectionTimeout: &metav1.Duration{Duration: time.Duration(1 * time.Second)},
-									ConnectionRetries: func() *int { r := 3; return &r }(),
+									Provisioning: &ReplicaProvisioning{
+										BackupRef: &corev1.LocalObjectReference{
+											Name: "backup",
+										},
+									},
 								},
 							},
 							Enabled: true,
@@ -216,7 +1981,7 @@ var _ = Describe("MariaDB webhook", func() {
 						Replicas: 3,
 					},
 				},
-				true,
+				false,
 			),
 			Entry(
 				"Invalid Galera primary pod index",
@@ -665,6 +2430,28 @@ var _ = Describe("MariaDB webhook", func() {
 				false,
 			),
 		)
+
+		It("Should not allow toggling Galera TLS", func() {
+			old := &MariaDB{
+				Spec: MariaDBSpec{
+					Galera: &Galera{
+						Enabled: true,
+					},
+					Replicas: 3,
+					TLS: &MariaDBTLS{
+						Enabled: true,
+					},
+				},
+			}
+			mdb := old.DeepCopy()
+			mdb.Spec.TLS.GaleraEnabled = true
+			Expect(mdb.validateGaleraTLSUpdate(old)).To(HaveOccurred())
+			Expect(old.validateGaleraTLSUpdate(mdb)).To(HaveOccurred())
+
+			updated := mdb.DeepCopy()
+			updated.Spec.TLS.ReplicationEnabled = false
+			Expect(updated.validateGaleraTLSUpdate(mdb)).ToNot(HaveOccurred())
+		})
 	})
 
 	Context("When updating MariaDB primary pod index", Ordered, func() {
@@ -835,4 +2622,127 @@ var _ = Describe("MariaDB webhook", func() {
 			),
 		)
 	})
+
+	Context("When applying risky configurations", func() {
+		storage := func(size string) VolumeClaimTemplate {
+			return VolumeClaimTemplate{
+				PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse(size),
+						},
+					},
+				},
+			}
+		}
+		DescribeTable(
+			"Should warn",
+			func(mdb *MariaDB, wantWarnings int) {
+				warnings, err := mdb.ValidateCreate()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(HaveLen(wantWarnings))
+			},
+			Entry(
+				"No warnings",
+				&MariaDB{
+					Spec: MariaDBSpec{
+						VolumeClaimTemplate: storage("1Gi"),
+						Replicas:            1,
+					},
+				},
+				0,
+			),
+			Entry(
+				"Even Galera replicas",
+				&MariaDB{
+					Spec: MariaDBSpec{
+						Galera: &Galera{
+							Enabled: true,
+							GaleraSpec: GaleraSpec{
+								Primary: &PrimaryGalera{
+									PodIndex: ptr.To(0),
+								},
+								SST:            ptr.To(SSTMariaBackup),
+								ReplicaThreads: ptr.To(1),
+							},
+						},
+						VolumeClaimTemplate: storage("1Gi"),
+						Replicas:            4,
+					},
+				},
+				1,
+			),
+			Entry(
+				"Default buffer pool with large storage",
+				&MariaDB{
+					Spec: MariaDBSpec{
+						VolumeClaimTemplate: storage("100Gi"),
+						Replicas:            1,
+					},
+				},
+				1,
+			),
+			Entry(
+				"Large buffer pool with large storage",
+				&MariaDB{
+					Spec: MariaDBSpec{
+						MyCnf: ptr.To(`[mariadb]
+innodb_buffer_pool_size=4G`),
+						VolumeClaimTemplate: storage("100Gi"),
+						Replicas:            1,
+					},
+				},
+				0,
+			),
+			Entry(
+				"Unknown buffer pool with large storage",
+				&MariaDB{
+					Spec: MariaDBSpec{
+						MyCnfConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "mariadb-config",
+							},
+							Key: "my.cnf",
+						},
+						VolumeClaimTemplate: storage("100Gi"),
+						Replicas:            1,
+					},
+				},
+				0,
+			),
+		)
+
+		It("Should only warn about the risks introduced by an update", func() {
+			old := &MariaDB{
+				Spec: MariaDBSpec{
+					VolumeClaimTemplate: storage("100Gi"),
+					Replicas:            1,
+				},
+			}
+			mdb := old.DeepCopy()
+			warnings, err := mdb.ValidateUpdate(old)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		DescribeTable(
+			"Should parse my.cnf sizes",
+			func(value string, wantSize int64, wantErr bool) {
+				size, err := parseMyCnfSize(value)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(size).To(Equal(wantSize))
+				}
+			},
+			Entry("Bytes", "134217728", int64(134217728), false),
+			Entry("Kilobytes", "512K", int64(512*1024), false),
+			Entry("Megabytes", "256m", int64(256*1024*1024), false),
+			Entry("Gigabytes", "4G", int64(4*1024*1024*1024), false),
+			Entry("Terabytes", "1T", int64(1024*1024*1024*1024), false),
+			Entry("Invalid", "foo", int64(0), true),
+			Entry("Empty", "", int64(0), true),
+		)
+	})
 })
@@ -0,0 +1,180 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MariaDBFleetTemplate defines the MariaDB objects stamped out by the MariaDBFleet.
+type MariaDBFleetTemplate struct {
+	// Metadata defines the labels and annotations to be added to the MariaDB objects.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Metadata *InheritMetadata `json:"metadata,omitempty"`
+	// Spec is the specification of the MariaDB objects.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Spec MariaDBSpec `json:"spec"`
+}
+
+// MariaDBFleetMember defines a MariaDB object managed by the MariaDBFleet.
+type MariaDBFleetMember struct {
+	// Name of the MariaDB object.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Namespace of the MariaDB object.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+	// Image overrides the image of the template for this member, i.e. to canary an upgrade.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Image *string `json:"image,omitempty"`
+}
+
+// Key returns the key of the MariaDB object.
+func (m *MariaDBFleetMember) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      m.Name,
+		Namespace: m.Namespace,
+	}
+}
+
+// MariaDBFleetRolloutStrategy defines how the template changes are rolled out to the members.
+type MariaDBFleetRolloutStrategy struct {
+	// MaxUnavailable is the maximum number of members that can be not ready while rolling out template changes.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxUnavailable int32 `json:"maxUnavailable,omitempty"`
+}
+
+// MariaDBFleetSpec defines the desired state of MariaDBFleet
+type MariaDBFleetSpec struct {
+	// Template defines the MariaDB objects stamped out by the fleet.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Template MariaDBFleetTemplate `json:"template"`
+	// Members are the MariaDB objects managed by the fleet.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Members []MariaDBFleetMember `json:"members,omitempty"`
+	// NamespaceSelector selects the namespaces where a MariaDB object named after the fleet is created, in addition to the members.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Prune deletes the MariaDB objects that are no longer members of the fleet.
+	// Otherwise, they are orphaned, so the fleet stops managing them but they keep running.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Prune bool `json:"prune,omitempty"`
+	// RolloutStrategy defines how the template changes are rolled out to the members.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RolloutStrategy MariaDBFleetRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// MariaDBFleetMemberStatus is the observed state of a member of the MariaDBFleet.
+type MariaDBFleetMemberStatus struct {
+	// Name of the MariaDB object.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Namespace of the MariaDB object.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Namespace string `json:"namespace"`
+	// Ready indicates whether the MariaDB is ready.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Ready bool `json:"ready,omitempty"`
+	// Updated indicates whether the MariaDB is running the current template.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Updated bool `json:"updated,omitempty"`
+	// Image is the image of the MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Image string `json:"image,omitempty"`
+	// Message is the message of the MariaDB Ready condition, or the error found reconciling the member.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// MariaDBFleetStatus defines the observed state of MariaDBFleet
+type MariaDBFleetStatus struct {
+	// Conditions for the MariaDBFleet object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Members is the aggregated status of the members of the fleet.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Members []MariaDBFleetMemberStatus `json:"members,omitempty"`
+	// TotalMembers is the number of members of the fleet.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	TotalMembers int32 `json:"totalMembers"`
+	// ReadyMembers is the number of ready members.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ReadyMembers int32 `json:"readyMembers"`
+	// UpdatedMembers is the number of members running the current template.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	UpdatedMembers int32 `json:"updatedMembers"`
+}
+
+func (s *MariaDBFleetStatus) SetCondition(condition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=mdbf
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="Members",type="integer",JSONPath=".status.totalMembers"
+// +kubebuilder:printcolumn:name="Ready Members",type="integer",JSONPath=".status.readyMembers"
+// +kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=".status.updatedMembers"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{MariaDBFleet,v1alpha1},{MariaDB,v1alpha1}}
+
+// MariaDBFleet is the Schema for the mariadbfleets API. It stamps out, upgrades and monitors MariaDB objects across namespaces.
+type MariaDBFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MariaDBFleetSpec   `json:"spec,omitempty"`
+	Status MariaDBFleetStatus `json:"status,omitempty"`
+}
+
+func (m *MariaDBFleet) IsReady() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeReady)
+}
+
+// MaxUnavailable returns the maximum number of members that can be not ready while rolling out template changes.
+func (m *MariaDBFleet) MaxUnavailable() int {
+	if m.Spec.RolloutStrategy.MaxUnavailable <= 0 {
+		return 1
+	}
+	return int(m.Spec.RolloutStrategy.MaxUnavailable)
+}
+
+//+kubebuilder:object:root=true
+
+// MariaDBFleetList contains a list of MariaDBFleet
+type MariaDBFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MariaDBFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MariaDBFleet{}, &MariaDBFleetList{})
+}
@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (r *MariaDBFleet) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint
+//+kubebuilder:webhook:path=/validate-mariadb-mmontes-io-v1alpha1-mariadbfleet,mutating=false,failurePolicy=fail,sideEffects=None,groups=mariadb.mmontes.io,resources=mariadbfleets,verbs=create;update,versions=v1alpha1,name=vmariadbfleet.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MariaDBFleet{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (m *MariaDBFleet) ValidateCreate() (admission.Warnings, error) {
+	return nil, m.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (m *MariaDBFleet) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	return nil, m.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (m *MariaDBFleet) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (m *MariaDBFleet) validate() error {
+	validateFns := []func() error{
+		m.validateMembers,
+		m.validateNamespaceSelector,
+		m.validateTemplate,
+	}
+	for _, fn := range validateFns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MariaDBFleet) validateMembers() error {
+	if len(m.Spec.Members) == 0 && m.Spec.NamespaceSelector == nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("members"),
+			m.Spec.Members,
+			"'spec.members' or 'spec.namespaceSelector' must be set",
+		)
+	}
+	seen := make(map[string]struct{}, len(m.Spec.Members))
+	for i, member := range m.Spec.Members {
+		key := member.Key().String()
+		if _, ok := seen[key]; ok {
+			return field.Invalid(
+				field.NewPath("spec").Child("members").Index(i),
+				key,
+				"duplicated member",
+			)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+func (m *MariaDBFleet) validateNamespaceSelector() error {
+	if m.Spec.NamespaceSelector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(m.Spec.NamespaceSelector); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("namespaceSelector"),
+			m.Spec.NamespaceSelector,
+			fmt.Sprintf("invalid namespace selector: %v", err),
+		)
+	}
+	return nil
+}
+
+func (m *MariaDBFleet) validateTemplate() error {
+	mariadb := MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.Name,
+		},
+		Spec: *m.Spec.Template.Spec.DeepCopy(),
+	}
+	mariadb.Default()
+	if err := mariadb.validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("template").Child("spec"),
+			m.Spec.Template.Spec,
+			fmt.Sprintf("invalid MariaDB template: %v", err),
+		)
+	}
+	return nil
+}
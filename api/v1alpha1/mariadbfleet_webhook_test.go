@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("MariaDBFleet webhook", func() {
+	Context("When creating a MariaDBFleet", func() {
+		objMeta := metav1.ObjectMeta{
+			Name: "mariadbfleet-create-webhook",
+		}
+		template := MariaDBFleetTemplate{
+			Spec: MariaDBSpec{
+				Replicas: 1,
+			},
+		}
+		DescribeTable(
+			"Should validate",
+			func(m *MariaDBFleet, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, m)
+				err := k8sClient.Create(testCtx, m)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"No members",
+				&MariaDBFleet{
+					ObjectMeta: objMeta,
+					Spec: MariaDBFleetSpec{
+						Template: template,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated members",
+				&MariaDBFleet{
+					ObjectMeta: objMeta,
+					Spec: MariaDBFleetSpec{
+						Template: template,
+						Members: []MariaDBFleetMember{
+							{
+								Name:      "mariadb",
+								Namespace: "team-a",
+							},
+							{
+								Name:      "mariadb",
+								Namespace: "team-a",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid template",
+				&MariaDBFleet{
+					ObjectMeta: objMeta,
+					Spec: MariaDBFleetSpec{
+						Template: MariaDBFleetTemplate{
+							Spec: MariaDBSpec{
+								Replication: &Replication{
+									Enabled: true,
+								},
+								Galera: &Galera{
+									Enabled: true,
+								},
+								Replicas: 3,
+							},
+						},
+						Members: []MariaDBFleetMember{
+							{
+								Name:      "mariadb",
+								Namespace: "team-a",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid members",
+				&MariaDBFleet{
+					ObjectMeta: objMeta,
+					Spec: MariaDBFleetSpec{
+						Template: template,
+						Members: []MariaDBFleetMember{
+							{
+								Name:      "mariadb",
+								Namespace: "team-a",
+							},
+							{
+								Name:      "mariadb",
+								Namespace: "team-b",
+							},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid namespace selector",
+				&MariaDBFleet{
+					ObjectMeta: objMeta,
+					Spec: MariaDBFleetSpec{
+						Template: template,
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"mariadb.mmontes.io/fleet": "true",
+							},
+						},
+					},
+				},
+				false,
+			),
+		)
+	})
+})
@@ -0,0 +1,185 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// MariaDBDefaults defines the values set in the MariaDB objects that do not specify them.
+type MariaDBDefaults struct {
+	// StorageClassName is the StorageClass used by the PVCs of the MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Storage is the size requested by the PVCs of the MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Storage *resource.Quantity `json:"storage,omitempty"`
+	// Resources are the compute resources of the MariaDB container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Apply sets the defaults in the MariaDB fields that are not set.
+func (d *MariaDBDefaults) Apply(mariadb *MariaDB) {
+	if mariadb.Spec.Ephemeral {
+		return
+	}
+	vct := &mariadb.Spec.VolumeClaimTemplate
+	if d.StorageClassName != nil && vct.StorageClassName == nil {
+		storageClassName := *d.StorageClassName
+		vct.StorageClassName = &storageClassName
+	}
+	if d.Storage != nil {
+		if _, ok := vct.Resources.Requests[corev1.ResourceStorage]; !ok {
+			if vct.Resources.Requests == nil {
+				vct.Resources.Requests = corev1.ResourceList{}
+			}
+			vct.Resources.Requests[corev1.ResourceStorage] = d.Storage.DeepCopy()
+		}
+		if len(vct.AccessModes) == 0 {
+			vct.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		}
+	}
+	if d.Resources != nil && mariadb.Spec.Resources == nil {
+		mariadb.Spec.Resources = d.Resources.DeepCopy()
+	}
+}
+
+// MariaDBLimits defines the constraints that the MariaDB objects must satisfy.
+type MariaDBLimits struct {
+	// MaxStorage is the maximum size that can be requested by the PVCs of the MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxStorage *resource.Quantity `json:"maxStorage,omitempty"`
+	// AllowedStorageClasses are the StorageClasses that can be used by the PVCs of the MariaDB.
+	// When set, the MariaDB must explicitly specify one of them.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AllowedStorageClasses []string `json:"allowedStorageClasses,omitempty"`
+	// MaxReplicas is the maximum number of replicas of the MariaDB.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// Validate returns an error if the MariaDB does not satisfy the limits.
+func (l *MariaDBLimits) Validate(mariadb *MariaDB) error {
+	if l.MaxReplicas != nil && mariadb.Spec.Replicas > *l.MaxReplicas {
+		return fmt.Errorf("replicas %d exceed the maximum of %d", mariadb.Spec.Replicas, *l.MaxReplicas)
+	}
+	if mariadb.Spec.Ephemeral {
+		return nil
+	}
+	vct := mariadb.Spec.VolumeClaimTemplate
+	if l.MaxStorage != nil {
+		if storage, ok := vct.Resources.Requests[corev1.ResourceStorage]; ok && storage.Cmp(*l.MaxStorage) > 0 {
+			return fmt.Errorf("storage '%s' exceeds the maximum of '%s'", storage.String(), l.MaxStorage.String())
+		}
+	}
+	if len(l.AllowedStorageClasses) > 0 {
+		if vct.StorageClassName == nil {
+			return fmt.Errorf("a StorageClass must be specified, allowed StorageClasses: %v", l.AllowedStorageClasses)
+		}
+		if !l.isStorageClassAllowed(*vct.StorageClassName) {
+			return fmt.Errorf("StorageClass '%s' is not allowed, allowed StorageClasses: %v", *vct.StorageClassName, l.AllowedStorageClasses)
+		}
+	}
+	return nil
+}
+
+func (l *MariaDBLimits) isStorageClassAllowed(storageClassName string) bool {
+	for _, allowed := range l.AllowedStorageClasses {
+		if allowed == storageClassName {
+			return true
+		}
+	}
+	return false
+}
+
+// BackupRequirements defines the constraints that the Backup objects must satisfy.
+type BackupRequirements struct {
+	// RequireSchedule indicates that Backups must define a schedule that is not suspended.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	RequireSchedule bool `json:"requireSchedule,omitempty"`
+}
+
+// Validate returns an error if the Backup does not satisfy the requirements.
+func (b *BackupRequirements) Validate(backup *Backup) error {
+	if !b.RequireSchedule {
+		return nil
+	}
+	if backup.Spec.Schedule == nil {
+		return fmt.Errorf("a schedule must be specified")
+	}
+	if backup.Spec.Schedule.Suspend {
+		return fmt.Errorf("the schedule cannot be suspended")
+	}
+	return nil
+}
+
+// MariaDBNamespacePolicySpec defines the desired state of MariaDBNamespacePolicy
+type MariaDBNamespacePolicySpec struct {
+	// NamespaceSelector selects the namespaces where the policy applies. It applies to all namespaces when not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Defaults are set in the MariaDB objects that do not specify them.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Defaults *MariaDBDefaults `json:"defaults,omitempty"`
+	// Limits are the constraints that the MariaDB objects must satisfy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Limits *MariaDBLimits `json:"limits,omitempty"`
+	// Backup defines the constraints that the Backup objects must satisfy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Backup *BackupRequirements `json:"backup,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=mdbnp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{MariaDBNamespacePolicy,v1alpha1}}
+
+// MariaDBNamespacePolicy is the Schema for the mariadbnamespacepolicies API. It allows platform admins to define the defaults
+// and limits enforced by the webhooks on the MariaDB and Backup objects created in the selected namespaces.
+type MariaDBNamespacePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MariaDBNamespacePolicySpec `json:"spec,omitempty"`
+}
+
+// AppliesTo determines whether the policy applies to the given Namespace.
+func (p *MariaDBNamespacePolicy) AppliesTo(namespace *corev1.Namespace) (bool, error) {
+	if p.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("error parsing namespace selector: %v", err)
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+//+kubebuilder:object:root=true
+
+// MariaDBNamespacePolicyList contains a list of MariaDBNamespacePolicy
+type MariaDBNamespacePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MariaDBNamespacePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MariaDBNamespacePolicy{}, &MariaDBNamespacePolicyList{})
+}
@@ -0,0 +1,291 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (p *MariaDBNamespacePolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(p).
+		Complete()
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbnamespacepolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+//nolint
+//+kubebuilder:webhook:path=/validate-mariadb-mmontes-io-v1alpha1-mariadbnamespacepolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=mariadb.mmontes.io,resources=mariadbnamespacepolicies,verbs=create;update,versions=v1alpha1,name=vmariadbnamespacepolicy.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MariaDBNamespacePolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (p *MariaDBNamespacePolicy) ValidateCreate() (admission.Warnings, error) {
+	return nil, p.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (p *MariaDBNamespacePolicy) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	return nil, p.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (p *MariaDBNamespacePolicy) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (p *MariaDBNamespacePolicy) validate() error {
+	validateFns := []func() error{
+		p.validateNamespaceSelector,
+		p.validateDefaults,
+	}
+	for _, fn := range validateFns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *MariaDBNamespacePolicy) validateNamespaceSelector() error {
+	if _, err := p.AppliesTo(&corev1.Namespace{}); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("namespaceSelector"),
+			p.Spec.NamespaceSelector,
+			fmt.Sprintf("invalid namespace selector: %v", err),
+		)
+	}
+	return nil
+}
+
+func (p *MariaDBNamespacePolicy) validateDefaults() error {
+	if p.Spec.Defaults == nil {
+		return nil
+	}
+	if storage := p.Spec.Defaults.Storage; storage != nil && storage.Sign() <= 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("defaults").Child("storage"),
+			storage.String(),
+			"storage must be greater than zero",
+		)
+	}
+	limits := p.Spec.Limits
+	if limits == nil {
+		return nil
+	}
+	if storage := p.Spec.Defaults.Storage; storage != nil && limits.MaxStorage != nil && storage.Cmp(*limits.MaxStorage) > 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("defaults").Child("storage"),
+			storage.String(),
+			fmt.Sprintf("storage exceeds the maximum of '%s'", limits.MaxStorage.String()),
+		)
+	}
+	if sc := p.Spec.Defaults.StorageClassName; sc != nil && len(limits.AllowedStorageClasses) > 0 && !limits.isStorageClassAllowed(*sc) {
+		return field.Invalid(
+			field.NewPath("spec").Child("defaults").Child("storageClassName"),
+			*sc,
+			"StorageClass is not allowed by the limits",
+		)
+	}
+	return nil
+}
+
+// namespacePolicies returns the MariaDBNamespacePolicies that apply to a namespace, sorted by name.
+func namespacePolicies(ctx context.Context, c client.Reader, namespace string) ([]MariaDBNamespacePolicy, error) {
+	var policyList MariaDBNamespacePolicyList
+	if err := c.List(ctx, &policyList); err != nil {
+		return nil, fmt.Errorf("error listing MariaDBNamespacePolicies: %v", err)
+	}
+	if len(policyList.Items) == 0 {
+		return nil, nil
+	}
+	var ns corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return nil, fmt.Errorf("error getting Namespace: %v", err)
+	}
+
+	var policies []MariaDBNamespacePolicy
+	for _, p := range policyList.Items {
+		applies, err := p.AppliesTo(&ns)
+		if err != nil {
+			return nil, fmt.Errorf("error checking MariaDBNamespacePolicy '%s': %v", p.Name, err)
+		}
+		if applies {
+			policies = append(policies, p)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
+
+// requestNamespace returns the namespace of the object being admitted, which may not be set in the object on creation.
+func requestNamespace(ctx context.Context, obj client.Object) string {
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Namespace != "" {
+		return req.Namespace
+	}
+	return obj.GetNamespace()
+}
+
+// policyViolation returns an error when the object violates a policy. Objects that already violated the policy
+// before the update are allowed to be updated, so policies created afterwards do not block their reconciliation.
+func policyViolation(policy *MariaDBNamespacePolicy, validate func(obj runtime.Object) error, obj, oldObj runtime.Object) error {
+	err := validate(obj)
+	if err == nil || (oldObj != nil && validate(oldObj) != nil) {
+		return nil
+	}
+	return field.Forbidden(
+		field.NewPath("spec"),
+		fmt.Sprintf("violates MariaDBNamespacePolicy '%s': %v", policy.Name, err),
+	)
+}
+
+// mariadbPolicyWebhook applies the defaults and enforces the limits of the MariaDBNamespacePolicies on the MariaDB objects,
+// in addition to the MariaDB defaulting and validation.
+type mariadbPolicyWebhook struct {
+	client client.Reader
+}
+
+var _ admission.CustomDefaulter = &mariadbPolicyWebhook{}
+var _ admission.CustomValidator = &mariadbPolicyWebhook{}
+
+// Default implements admission.CustomDefaulter.
+func (w *mariadbPolicyWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	mariadb := obj.(*MariaDB)
+	mariadb.Default()
+
+	if req, err := admission.RequestFromContext(ctx); err != nil || req.Operation != admissionv1.Create {
+		return nil
+	}
+	policies, err := namespacePolicies(ctx, w.client, requestNamespace(ctx, mariadb))
+	if err != nil {
+		return err
+	}
+	for i := range policies {
+		if p := &policies[i]; p.Spec.Defaults != nil {
+			logger.V(1).Info("Defaulting MariaDB from policy", "mariadb", mariadb.Name, "policy", p.Name)
+			p.Spec.Defaults.Apply(mariadb)
+		}
+	}
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (w *mariadbPolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	mariadb := obj.(*MariaDB)
+	warnings, err := mariadb.ValidateCreate()
+	if err != nil {
+		return nil, err
+	}
+	return warnings, w.validatePolicies(ctx, mariadb, nil)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *mariadbPolicyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	mariadb := newObj.(*MariaDB)
+	warnings, err := mariadb.ValidateUpdate(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, w.validatePolicies(ctx, mariadb, oldObj.(*MariaDB))
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *mariadbPolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return obj.(*MariaDB).ValidateDelete()
+}
+
+func (w *mariadbPolicyWebhook) validatePolicies(ctx context.Context, mariadb, oldMariadb *MariaDB) error {
+	policies, err := namespacePolicies(ctx, w.client, requestNamespace(ctx, mariadb))
+	if err != nil {
+		return err
+	}
+	var oldObj runtime.Object
+	if oldMariadb != nil {
+		oldObj = oldMariadb
+	}
+	for i := range policies {
+		p := &policies[i]
+		limits := p.Spec.Limits
+		if limits == nil {
+			continue
+		}
+		validate := func(obj runtime.Object) error {
+			return limits.Validate(obj.(*MariaDB))
+		}
+		if err := policyViolation(p, validate, mariadb, oldObj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backupPolicyWebhook enforces the backup requirements of the MariaDBNamespacePolicies on the Backup objects,
+// in addition to the Backup validation.
+type backupPolicyWebhook struct {
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &backupPolicyWebhook{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (w *backupPolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	backup := obj.(*Backup)
+	warnings, err := backup.ValidateCreate()
+	if err != nil {
+		return nil, err
+	}
+	return warnings, w.validatePolicies(ctx, backup, nil)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *backupPolicyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	backup := newObj.(*Backup)
+	warnings, err := backup.ValidateUpdate(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, w.validatePolicies(ctx, backup, oldObj.(*Backup))
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *backupPolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return obj.(*Backup).ValidateDelete()
+}
+
+func (w *backupPolicyWebhook) validatePolicies(ctx context.Context, backup, oldBackup *Backup) error {
+	policies, err := namespacePolicies(ctx, w.client, requestNamespace(ctx, backup))
+	if err != nil {
+		return err
+	}
+	var oldObj runtime.Object
+	if oldBackup != nil {
+		oldObj = oldBackup
+	}
+	for i := range policies {
+		p := &policies[i]
+		requirements := p.Spec.Backup
+		if requirements == nil {
+			continue
+		}
+		validate := func(obj runtime.Object) error {
+			return requirements.Validate(obj.(*Backup))
+		}
+		if err := policyViolation(p, validate, backup, oldObj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MariaDBNamespacePolicy webhook", func() {
+	Context("When creating a MariaDBNamespacePolicy", func() {
+		objMeta := metav1.ObjectMeta{
+			Name: "mariadbnamespacepolicy-create-webhook",
+		}
+		DescribeTable(
+			"Should validate",
+			func(p *MariaDBNamespacePolicy, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, p)
+				err := k8sClient.Create(testCtx, p)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+				_ = k8sClient.Delete(testCtx, p)
+			},
+			Entry(
+				"Invalid namespace selector",
+				&MariaDBNamespacePolicy{
+					ObjectMeta: objMeta,
+					Spec: MariaDBNamespacePolicySpec{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{
+									Key:      "tenant",
+									Operator: "invalid",
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Default storage exceeding limits",
+				&MariaDBNamespacePolicy{
+					ObjectMeta: objMeta,
+					Spec: MariaDBNamespacePolicySpec{
+						Defaults: &MariaDBDefaults{
+							Storage: ptr.To(resource.MustParse("20Gi")),
+						},
+						Limits: &MariaDBLimits{
+							MaxStorage: ptr.To(resource.MustParse("10Gi")),
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Default StorageClass not allowed",
+				&MariaDBNamespacePolicy{
+					ObjectMeta: objMeta,
+					Spec: MariaDBNamespacePolicySpec{
+						Defaults: &MariaDBDefaults{
+							StorageClassName: ptr.To("slow"),
+						},
+						Limits: &MariaDBLimits{
+							AllowedStorageClasses: []string{"standard"},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid",
+				&MariaDBNamespacePolicy{
+					ObjectMeta: objMeta,
+					Spec: MariaDBNamespacePolicySpec{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"tenant": "true",
+							},
+						},
+						Defaults: &MariaDBDefaults{
+							StorageClassName: ptr.To("standard"),
+							Storage:          ptr.To(resource.MustParse("1Gi")),
+						},
+						Limits: &MariaDBLimits{
+							MaxStorage:            ptr.To(resource.MustParse("10Gi")),
+							AllowedStorageClasses: []string{"standard"},
+							MaxReplicas:           ptr.To(int32(3)),
+						},
+						Backup: &BackupRequirements{
+							RequireSchedule: true,
+						},
+					},
+				},
+				false,
+			),
+		)
+	})
+
+	Context("When a MariaDBNamespacePolicy applies to a namespace", Ordered, func() {
+		namespace := "mariadbnamespacepolicy-webhook"
+		policy := MariaDBNamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mariadbnamespacepolicy-enforce-webhook",
+			},
+			Spec: MariaDBNamespacePolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"mariadb.mmontes.io/tenant": "true",
+					},
+				},
+				Defaults: &MariaDBDefaults{
+					StorageClassName: ptr.To("standard"),
+					Storage:          ptr.To(resource.MustParse("1Gi")),
+				},
+				Limits: &MariaDBLimits{
+					MaxStorage:            ptr.To(resource.MustParse("10Gi")),
+					AllowedStorageClasses: []string{"standard"},
+					MaxReplicas:           ptr.To(int32(3)),
+				},
+				Backup: &BackupRequirements{
+					RequireSchedule: true,
+				},
+			},
+		}
+
+		BeforeAll(func() {
+			ns := corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+					Labels: map[string]string{
+						"mariadb.mmontes.io/tenant": "true",
+					},
+				},
+			}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(testCtx, &ns))).To(Succeed())
+			Expect(k8sClient.Create(testCtx, &policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &policy)).To(Succeed())
+			})
+
+			By("Waiting for the webhook to enforce the policy")
+			mariadb := MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb-policy-sync",
+					Namespace: namespace,
+				},
+				Spec: MariaDBSpec{
+					Replicas: 5,
+				},
+			}
+			Eventually(func() error {
+				return k8sClient.Create(testCtx, &mariadb, client.DryRunAll)
+			}, 10*time.Second, 250*time.Millisecond).ShouldNot(Succeed())
+		})
+
+		It("Should apply defaults", func() {
+			mariadb := MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb-policy-defaults",
+					Namespace: namespace,
+				},
+				Spec: MariaDBSpec{
+					Replicas: 1,
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &mariadb)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &mariadb)).To(Succeed())
+			})
+
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(&mariadb), &mariadb)).To(Succeed())
+			Expect(mariadb.Spec.VolumeClaimTemplate.StorageClassName).To(Equal(ptr.To("standard")))
+			storage := mariadb.Spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage]
+			Expect(storage.Cmp(resource.MustParse("1Gi"))).To(Equal(0))
+		})
+
+		DescribeTable(
+			"Should enforce limits",
+			func(spec MariaDBSpec) {
+				mariadb := MariaDB{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "mariadb-policy-limits",
+						Namespace: namespace,
+					},
+					Spec: spec,
+				}
+				Expect(k8sClient.Create(testCtx, &mariadb)).ToNot(Succeed())
+			},
+			Entry(
+				"Too many replicas",
+				MariaDBSpec{
+					Replicas: 5,
+				},
+			),
+			Entry(
+				"Storage exceeding the maximum",
+				MariaDBSpec{
+					Replicas: 1,
+					VolumeClaimTemplate: VolumeClaimTemplate{
+						PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceStorage: resource.MustParse("100Gi"),
+								},
+							},
+							AccessModes: []corev1.PersistentVolumeAccessMode{
+								corev1.ReadWriteOnce,
+							},
+						},
+					},
+				},
+			),
+			Entry(
+				"StorageClass not allowed",
+				MariaDBSpec{
+					Replicas: 1,
+					VolumeClaimTemplate: VolumeClaimTemplate{
+						PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: ptr.To("fast"),
+						},
+					},
+				},
+			),
+		)
+
+		It("Should require scheduled Backups", func() {
+			backup := Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup-policy",
+					Namespace: namespace,
+				},
+				Spec: BackupSpec{
+					MariaDBRef: MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: "mariadb",
+						},
+					},
+					Storage: BackupStorage{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceStorage: resource.MustParse("1Gi"),
+								},
+							},
+							AccessModes: []corev1.PersistentVolumeAccessMode{
+								corev1.ReadWriteOnce,
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &backup)).ToNot(Succeed())
+
+			backup.Spec.Schedule = &Schedule{
+				Cron: "*/1 * * * *",
+			}
+			Expect(k8sClient.Create(testCtx, &backup)).To(Succeed())
+			Expect(k8sClient.Delete(testCtx, &backup)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,166 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	defaultMariaDBTestDatabase = "test"
+	defaultMariaDBTestUsername = "test"
+	defaultMariaDBTestStorage  = resource.MustParse("1Gi")
+)
+
+// MariaDBTestSpec defines the desired state of MariaDBTest
+type MariaDBTestSpec struct {
+	// Image name to be used by the MariaDB instance. The supported format is `<image>:<tag>`.
+	// It defaults to the image used by the operator for MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Image string `json:"image,omitempty" webhook:"inmutable"`
+	// Database is the name of the database to be created. It defaults to 'test'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty" webhook:"inmutable"`
+	// Username is the name of the user to be created, with all privileges in the database. It defaults to 'test'.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username *string `json:"username,omitempty" webhook:"inmutable"`
+	// MyCnf allows to specify the my.cnf file mounted by Mariadb.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MyCnf *string `json:"myCnf,omitempty" webhook:"inmutable"`
+	// Resouces describes the compute resource requirements of the MariaDB instance.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty" webhook:"inmutable"`
+	// Storage is the size limit of the ephemeral storage of the MariaDB instance. It defaults to 1Gi.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Storage *resource.Quantity `json:"storage,omitempty" webhook:"inmutable"`
+	// TTL is the time to live of the MariaDBTest, counting from its creation. Once expired, the MariaDBTest is deleted
+	// along with its MariaDB instance. It may be updated to extend the lifetime of the MariaDBTest. It defaults to 1h.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// MariaDBTestStatus defines the observed state of MariaDBTest
+type MariaDBTestStatus struct {
+	// Conditions for the MariaDBTest object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ExpirationTime is the time when the MariaDBTest will be deleted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// SecretName is the name of the Secret containing the connection details: DSN, username, password, host, port and database.
+	// It is available once the MariaDBTest is ready.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SecretName *string `json:"secretName,omitempty"`
+}
+
+func (s *MariaDBTestStatus) SetCondition(condition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=tmdb
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.secretName"
+// +kubebuilder:printcolumn:name="Expiration",type="string",JSONPath=".status.expirationTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{MariaDBTest,v1alpha1},{MariaDB,v1alpha1}}
+
+// MariaDBTest is the Schema for the mariadbtests API. It provisions a single node MariaDB with ephemeral storage
+// intended for integration tests, which is deleted once its TTL expires.
+type MariaDBTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MariaDBTestSpec   `json:"spec,omitempty"`
+	Status MariaDBTestStatus `json:"status,omitempty"`
+}
+
+func (m *MariaDBTest) IsReady() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeReady)
+}
+
+// TTL returns the time to live of the MariaDBTest.
+func (m *MariaDBTest) TTL() time.Duration {
+	if m.Spec.TTL != nil {
+		return m.Spec.TTL.Duration
+	}
+	return 1 * time.Hour
+}
+
+// ExpirationTime returns the time when the MariaDBTest expires.
+func (m *MariaDBTest) ExpirationTime() time.Time {
+	return m.CreationTimestamp.Add(m.TTL())
+}
+
+// Database returns the name of the database to be created.
+func (m *MariaDBTest) Database() string {
+	if m.Spec.Database != nil {
+		return *m.Spec.Database
+	}
+	return defaultMariaDBTestDatabase
+}
+
+// Username returns the name of the user to be created.
+func (m *MariaDBTest) Username() string {
+	if m.Spec.Username != nil {
+		return *m.Spec.Username
+	}
+	return defaultMariaDBTestUsername
+}
+
+// Storage returns the size limit of the ephemeral storage.
+func (m *MariaDBTest) Storage() resource.Quantity {
+	if m.Spec.Storage != nil {
+		return *m.Spec.Storage
+	}
+	return defaultMariaDBTestStorage
+}
+
+// MariaDBKey defines the key for the MariaDB instance.
+func (m *MariaDBTest) MariaDBKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-test", m.Name),
+		Namespace: m.Namespace,
+	}
+}
+
+// SecretKey defines the key for the Secret containing the connection details.
+func (m *MariaDBTest) SecretKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-test-connection", m.Name),
+		Namespace: m.Namespace,
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// MariaDBTestList contains a list of MariaDBTest
+type MariaDBTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MariaDBTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MariaDBTest{}, &MariaDBTestList{})
+}
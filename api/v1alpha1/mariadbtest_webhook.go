@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (r *MariaDBTest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint
+//+kubebuilder:webhook:path=/validate-mariadb-mmontes-io-v1alpha1-mariadbtest,mutating=false,failurePolicy=fail,sideEffects=None,groups=mariadb.mmontes.io,resources=mariadbtests,verbs=create;update,versions=v1alpha1,name=vmariadbtest.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MariaDBTest{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *MariaDBTest) ValidateCreate() (admission.Warnings, error) {
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *MariaDBTest) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := inmutableWebhook.ValidateUpdate(r, old.(*MariaDBTest)); err != nil {
+		return nil, err
+	}
+	return nil, r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *MariaDBTest) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *MariaDBTest) validate() error {
+	if errs := validation.IsDNS1123Label(r.MariaDBKey().Name); len(errs) > 0 {
+		return field.Invalid(
+			field.NewPath("metadata").Child("name"),
+			r.Name,
+			"name is too long to derive the names of the MariaDB resources",
+		)
+	}
+	if r.Spec.TTL != nil && r.Spec.TTL.Duration <= 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("ttl"),
+			r.Spec.TTL,
+			"ttl must be greater than zero",
+		)
+	}
+	if r.Spec.Storage != nil && r.Spec.Storage.Sign() <= 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("storage"),
+			r.Spec.Storage,
+			"storage must be greater than zero",
+		)
+	}
+	return nil
+}
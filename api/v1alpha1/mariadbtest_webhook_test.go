@@ -0,0 +1,142 @@
+package v1alpha1
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MariaDBTest webhook", func() {
+	Context("When creating a MariaDBTest", func() {
+		objMeta := metav1.ObjectMeta{
+			Name:      "mariadbtest-create-webhook",
+			Namespace: testNamespace,
+		}
+		DescribeTable(
+			"Should validate",
+			func(t *MariaDBTest, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, t)
+				err := k8sClient.Create(testCtx, t)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Name too long",
+				&MariaDBTest{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      strings.Repeat("a", 60),
+						Namespace: testNamespace,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid TTL",
+				&MariaDBTest{
+					ObjectMeta: objMeta,
+					Spec: MariaDBTestSpec{
+						TTL: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid storage",
+				&MariaDBTest{
+					ObjectMeta: objMeta,
+					Spec: MariaDBTestSpec{
+						Storage: ptr.To(resource.MustParse("0")),
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid",
+				&MariaDBTest{
+					ObjectMeta: objMeta,
+					Spec: MariaDBTestSpec{
+						Database: ptr.To("app"),
+						Username: ptr.To("app"),
+						Storage:  ptr.To(resource.MustParse("500Mi")),
+						TTL:      &metav1.Duration{Duration: 30 * time.Minute},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid with defaults",
+				&MariaDBTest{
+					ObjectMeta: objMeta,
+				},
+				false,
+			),
+		)
+	})
+
+	Context("When updating a MariaDBTest", Ordered, func() {
+		key := types.NamespacedName{
+			Name:      "mariadbtest-update-webhook",
+			Namespace: testNamespace,
+		}
+		BeforeAll(func() {
+			test := MariaDBTest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: MariaDBTestSpec{
+					Database: ptr.To("app"),
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &test)).To(Succeed())
+		})
+		DescribeTable(
+			"Should validate",
+			func(patchFn func(t *MariaDBTest), wantErr bool) {
+				var test MariaDBTest
+				Expect(k8sClient.Get(testCtx, key, &test)).To(Succeed())
+
+				patch := client.MergeFrom(test.DeepCopy())
+				patchFn(&test)
+
+				err := k8sClient.Patch(testCtx, &test, patch)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Updating Database",
+				func(t *MariaDBTest) {
+					t.Spec.Database = ptr.To("foo")
+				},
+				true,
+			),
+			Entry(
+				"Updating Storage",
+				func(t *MariaDBTest) {
+					t.Spec.Storage = ptr.To(resource.MustParse("2Gi"))
+				},
+				true,
+			),
+			Entry(
+				"Extending TTL",
+				func(t *MariaDBTest) {
+					t.Spec.TTL = &metav1.Duration{Duration: 2 * time.Hour}
+				},
+				false,
+			),
+		)
+	})
+})
@@ -0,0 +1,413 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MaxScaleRouter is the router module used by a MaxScale service.
+type MaxScaleRouter string
+
+const (
+	// MaxScaleRouterReadWriteSplit routes writes to the primary and balances reads across the replicas.
+	MaxScaleRouterReadWriteSplit MaxScaleRouter = "readwritesplit"
+	// MaxScaleRouterReadConnRoute balances connections across the servers selected by the 'router_options' param.
+	MaxScaleRouterReadConnRoute MaxScaleRouter = "readconnroute"
+)
+
+// MaxScaleMonitorModule is the monitor module used to track the state of the MariaDB servers.
+type MaxScaleMonitorModule string
+
+const (
+	// MaxScaleMonitorModuleMariadb monitors a primary-replica topology.
+	MaxScaleMonitorModuleMariadb MaxScaleMonitorModule = "mariadbmon"
+	// MaxScaleMonitorModuleGalera monitors a Galera cluster.
+	MaxScaleMonitorModuleGalera MaxScaleMonitorModule = "galeramon"
+)
+
+// MaxScaleCausalReadsMode is the consistency level of the reads routed to the replicas after a write.
+type MaxScaleCausalReadsMode string
+
+const (
+	// MaxScaleCausalReadsLocal guarantees that the reads of a session see its own writes.
+	MaxScaleCausalReadsLocal MaxScaleCausalReadsMode = "local"
+	// MaxScaleCausalReadsGlobal guarantees that the reads see the writes of all the sessions done before the read.
+	MaxScaleCausalReadsGlobal MaxScaleCausalReadsMode = "global"
+	// MaxScaleCausalReadsFast routes the reads of a session to the primary until the replicas catch up with its writes.
+	MaxScaleCausalReadsFast MaxScaleCausalReadsMode = "fast"
+	// MaxScaleCausalReadsFastGlobal routes the reads to the primary until the replicas catch up with the writes of all the sessions.
+	MaxScaleCausalReadsFastGlobal MaxScaleCausalReadsMode = "fast_global"
+	// MaxScaleCausalReadsUniversal guarantees that the reads see the latest GTID of the primary at the time of the read.
+	MaxScaleCausalReadsUniversal MaxScaleCausalReadsMode = "universal"
+	// MaxScaleCausalReadsFastUniversal routes the reads to the primary until the replicas catch up with its latest GTID.
+	MaxScaleCausalReadsFastUniversal MaxScaleCausalReadsMode = "fast_universal"
+)
+
+// maxScaleReservedParams are the params managed by the operator, which cannot be overridden.
+var maxScaleReservedParams = []string{
+	"type",
+	"module",
+	"router",
+	"servers",
+	"cluster",
+	"user",
+	"password",
+	"service",
+	"protocol",
+	"port",
+}
+
+// MaxScaleListener defines the port where a MaxScale service accepts client connections.
+type MaxScaleListener struct {
+	// Port where the listener accepts connections. It is also exposed by the MaxScale Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Port int32 `json:"port"`
+	// Params defines extra parameters to be added to the listener section of the MaxScale config.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// MaxScaleService defines a MaxScale service, which routes the client connections received by its listener.
+type MaxScaleService struct {
+	// Name of the service. It is used as section name in the MaxScale config and as port name in the Service,
+	// so it must be a valid port name.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Router is the MaxScale router module used by the service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=readwritesplit;readconnroute
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Router MaxScaleRouter `json:"router"`
+	// Listener defines the port where the service accepts connections.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Listener MaxScaleListener `json:"listener"`
+	// CausalReads enables read-your-writes semantics in the reads routed to the replicas.
+	// It is only supported by the readwritesplit router.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	CausalReads *MaxScaleCausalReads `json:"causalReads,omitempty"`
+	// Params defines extra parameters to be added to the service section of the MaxScale config.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Validate determines whether a MaxScaleService is valid.
+func (s *MaxScaleService) Validate() error {
+	if errs := validation.IsValidPortName(s.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid name '%s': %v", s.Name, errs)
+	}
+	if err := validateMaxScaleParams(s.Params); err != nil {
+		return err
+	}
+	if s.CausalReads != nil {
+		if s.Router != MaxScaleRouterReadWriteSplit {
+			return fmt.Errorf("causal reads are only supported by the '%s' router", MaxScaleRouterReadWriteSplit)
+		}
+		if err := s.CausalReads.Validate(); err != nil {
+			return err
+		}
+		for _, p := range []string{"causal_reads", "causal_reads_timeout"} {
+			if _, ok := s.Params[p]; ok {
+				return fmt.Errorf("param '%s' is managed by causalReads", p)
+			}
+		}
+	}
+	return validateMaxScaleParams(s.Listener.Params)
+}
+
+// MaxScaleCausalReads defines the causal reads of a readwritesplit service. Before routing a read to a replica,
+// MaxScale waits for the replica to replicate the GTID of the previous writes, falling back to the primary after the timeout.
+type MaxScaleCausalReads struct {
+	// Mode is the consistency level of the reads. It defaults to local, which guarantees that a session reads its own writes.
+	// +optional
+	// +kubebuilder:validation:Enum=local;global;fast;fast_global;universal;fast_universal
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Mode MaxScaleCausalReadsMode `json:"mode,omitempty"`
+	// Timeout is the maximum time to wait for a replica to catch up before routing the read to the primary. It defaults to 10s.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// Validate determines whether a MaxScaleCausalReads is valid.
+func (c *MaxScaleCausalReads) Validate() error {
+	if c.Timeout != nil && c.Timeout.Duration <= 0 {
+		return errors.New("causal reads timeout must be greater than zero")
+	}
+	return nil
+}
+
+// ModeOrDefault returns the causal reads mode, defaulting to local.
+func (c *MaxScaleCausalReads) ModeOrDefault() MaxScaleCausalReadsMode {
+	if c.Mode == "" {
+		return MaxScaleCausalReadsLocal
+	}
+	return c.Mode
+}
+
+// MaxScaleMonitor defines the MaxScale monitor that tracks the state of the MariaDB servers.
+type MaxScaleMonitor struct {
+	// Module is the monitor module. It defaults to galeramon when Galera is enabled in the MariaDB, and to mariadbmon otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=mariadbmon;galeramon
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Module MaxScaleMonitorModule `json:"module,omitempty"`
+	// Interval is the time between monitor checks. It defaults to 2s.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Params defines extra parameters to be added to the monitor section of the MaxScale config.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Validate determines whether a MaxScaleMonitor is valid.
+func (m *MaxScaleMonitor) Validate() error {
+	if m.Interval != nil && m.Interval.Duration <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	return validateMaxScaleParams(m.Params)
+}
+
+// MaxScaleAuth defines the credentials used by MaxScale to connect to MariaDB.
+type MaxScaleAuth struct {
+	// Username is the name of the user used by the MaxScale services and monitor. It defaults to the MaxScale name.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Username string `json:"username,omitempty" webhook:"inmutableinit"`
+	// PasswordSecretKeyRef is a reference to the password of the user. A random password is generated if the Secret does not exist.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty" webhook:"inmutableinit"`
+}
+
+// MaxScaleSpec defines the desired state of MaxScale
+type MaxScaleSpec struct {
+	// ContainerTemplate defines a template to configure the MaxScale container.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ContainerTemplate `json:",inline"`
+	// MariaDBRef is a reference to the MariaDB that MaxScale routes connections to.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
+	// Image name to be used by the MaxScale instances. The supported format is `<image>:<tag>`.
+	// It defaults to the MaxScale image configured in the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy is the image pull policy. One of `Always`, `Never` or `IfNotPresent`. If not defined, it defaults to `IfNotPresent`.
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"}
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Replicas indicates the number of MaxScale instances. When more than one, the monitors coordinate through cooperative locks.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:podCount"}
+	Replicas int32 `json:"replicas,omitempty"`
+	// Services define the MaxScale services and their listeners. It defaults to a readwritesplit service listening on port 3306
+	// and a readconnroute service balancing connections across the replicas on port 3307.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Services []MaxScaleService `json:"services,omitempty"`
+	// Monitor defines the monitor that tracks the state of the MariaDB servers.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Monitor MaxScaleMonitor `json:"monitor,omitempty"`
+	// Auth defines the credentials used by MaxScale to connect to MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Auth MaxScaleAuth `json:"auth,omitempty"`
+	// KubernetesService defines a template for the Kubernetes Service exposing the listeners.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KubernetesService *ServiceTemplate `json:"kubernetesService,omitempty"`
+	// Affinity to be used in the MaxScale Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// NodeSelector to be used in the MaxScale Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations to be used in the MaxScale Pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// MaxScaleStatus defines the observed state of MaxScale
+type MaxScaleStatus struct {
+	// Conditions for the MaxScale object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Replicas indicates the number of ready MaxScale instances.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:podCount"}
+	Replicas int32 `json:"replicas,omitempty"`
+	// PrimaryServer is the MariaDB server where the writes are routed to, as tracked by the MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PrimaryServer *string `json:"primaryServer,omitempty"`
+}
+
+func (s *MaxScaleStatus) SetCondition(condition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=mxs
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="Primary",type="string",JSONPath=".status.primaryServer"
+// +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{MaxScale,v1alpha1},{User,v1alpha1},{Grant,v1alpha1},{ConfigMap,v1},{Service,v1},{Deployment,v1}}
+
+// MaxScale is the Schema for the maxscales API. It deploys MaxScale in front of a MariaDB,
+// providing read/write splitting and connection routing that follow the primary.
+type MaxScale struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaxScaleSpec   `json:"spec,omitempty"`
+	Status MaxScaleStatus `json:"status,omitempty"`
+}
+
+func (m *MaxScale) IsReady() bool {
+	return meta.IsStatusConditionTrue(m.Status.Conditions, ConditionTypeReady)
+}
+
+// SetDefaults sets default values.
+func (m *MaxScale) SetDefaults(env *environment.Environment) {
+	if m.Spec.Image == "" {
+		m.Spec.Image = env.RelatedMaxscaleImage
+	}
+	if m.Spec.Replicas == 0 {
+		m.Spec.Replicas = 1
+	}
+	if len(m.Spec.Services) == 0 {
+		m.Spec.Services = []MaxScaleService{
+			{
+				Name:   "rw-router",
+				Router: MaxScaleRouterReadWriteSplit,
+				Listener: MaxScaleListener{
+					Port: 3306,
+				},
+			},
+			{
+				Name:   "rconn-router",
+				Router: MaxScaleRouterReadConnRoute,
+				Listener: MaxScaleListener{
+					Port: 3307,
+				},
+				Params: map[string]string{
+					"router_options": "slave",
+				},
+			},
+		}
+	}
+	if m.Spec.Auth.Username == "" {
+		m.Spec.Auth.Username = m.Name
+	}
+	if m.Spec.Auth.PasswordSecretKeyRef == (corev1.SecretKeySelector{}) {
+		m.Spec.Auth.PasswordSecretKeyRef = m.AuthPasswordSecretKeyRef()
+	}
+}
+
+// MonitorModule returns the monitor module, defaulting it based on the HA method of the MariaDB.
+func (m *MaxScale) MonitorModule(mariadb *MariaDB) MaxScaleMonitorModule {
+	if m.Spec.Monitor.Module != "" {
+		return m.Spec.Monitor.Module
+	}
+	if mariadb.Galera().Enabled {
+		return MaxScaleMonitorModuleGalera
+	}
+	return MaxScaleMonitorModuleMariadb
+}
+
+// AuthPasswordSecretKeyRef defines the key selector for the password Secret.
+func (m *MaxScale) AuthPasswordSecretKeyRef() corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: fmt.Sprintf("%s-maxscale-auth", m.Name),
+		},
+		Key: "password",
+	}
+}
+
+// ConfigMapKeyRef defines the key selector for the MaxScale config ConfigMap.
+func (m *MaxScale) ConfigMapKeyRef() corev1.ConfigMapKeySelector {
+	return corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: fmt.Sprintf("%s-maxscale-config", m.Name),
+		},
+		Key: "maxscale.cnf",
+	}
+}
+
+// ServiceGrantKey defines the key for the Grant on the system tables used by the MaxScale services to authenticate clients.
+func (m *MaxScale) ServiceGrantKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-maxscale-service", m.Name),
+		Namespace: m.Namespace,
+	}
+}
+
+// MonitorGrantKey defines the key for the Grant used by the MaxScale monitor.
+func (m *MaxScale) MonitorGrantKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-maxscale-monitor", m.Name),
+		Namespace: m.Namespace,
+	}
+}
+
+func validateMaxScaleParams(params map[string]string) error {
+	for _, p := range maxScaleReservedParams {
+		if _, ok := params[p]; ok {
+			return fmt.Errorf("param '%s' is managed by the operator", p)
+		}
+	}
+	for k, v := range params {
+		if k == "" || strings.ContainsAny(k, "=\n") || strings.Contains(v, "\n") {
+			return fmt.Errorf("invalid param '%s'", k)
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+
+// MaxScaleList contains a list of MaxScale
+type MaxScaleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaxScale `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaxScale{}, &MaxScaleList{})
+}
@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (r *MaxScale) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint
+//+kubebuilder:webhook:path=/validate-mariadb-mmontes-io-v1alpha1-maxscale,mutating=false,failurePolicy=fail,sideEffects=None,groups=mariadb.mmontes.io,resources=maxscales,verbs=create;update,versions=v1alpha1,name=vmaxscale.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &MaxScale{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *MaxScale) ValidateCreate() (admission.Warnings, error) {
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *MaxScale) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := inmutableWebhook.ValidateUpdate(r, old.(*MaxScale)); err != nil {
+		return nil, err
+	}
+	return nil, r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *MaxScale) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *MaxScale) validate() error {
+	validateFns := []func() error{
+		r.validateServices,
+		r.validateMonitor,
+	}
+	for _, fn := range validateFns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MaxScale) validateServices() error {
+	names := make(map[string]struct{})
+	ports := make(map[int32]struct{})
+	for i, svc := range r.Spec.Services {
+		path := field.NewPath("spec").Child("services").Index(i)
+		if err := svc.Validate(); err != nil {
+			return field.Invalid(path, svc, fmt.Sprintf("invalid service: %v", err))
+		}
+		if _, ok := names[svc.Name]; ok {
+			return field.Invalid(path.Child("name"), svc.Name, "service names must be unique")
+		}
+		names[svc.Name] = struct{}{}
+		if _, ok := ports[svc.Listener.Port]; ok {
+			return field.Invalid(path.Child("listener").Child("port"), svc.Listener.Port, "listener ports must be unique")
+		}
+		ports[svc.Listener.Port] = struct{}{}
+	}
+	return nil
+}
+
+func (r *MaxScale) validateMonitor() error {
+	if err := r.Spec.Monitor.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("monitor"),
+			r.Spec.Monitor,
+			fmt.Sprintf("invalid monitor: %v", err),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package v1alpha1
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MaxScale webhook", func() {
+	Context("When creating a MaxScale", func() {
+		objMeta := metav1.ObjectMeta{
+			Name:      "maxscale-create-webhook",
+			Namespace: testNamespace,
+		}
+		mariaDbRef := MariaDBRef{
+			ObjectReference: corev1.ObjectReference{
+				Name: "mariadb",
+			},
+		}
+		DescribeTable(
+			"Should validate",
+			func(mxs *MaxScale, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, mxs)
+				err := k8sClient.Create(testCtx, mxs)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Invalid service name",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   strings.Repeat("a", 20),
+								Router: MaxScaleRouterReadWriteSplit,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated listener ports",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   "rw-router",
+								Router: MaxScaleRouterReadWriteSplit,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+							},
+							{
+								Name:   "rconn-router",
+								Router: MaxScaleRouterReadConnRoute,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Reserved param",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   "rw-router",
+								Router: MaxScaleRouterReadWriteSplit,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+								Params: map[string]string{
+									"password": "foo",
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Causal reads with readconnroute",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   "rconn-router",
+								Router: MaxScaleRouterReadConnRoute,
+								Listener: MaxScaleListener{
+									Port: 3307,
+								},
+								CausalReads: &MaxScaleCausalReads{},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Causal reads param",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   "rw-router",
+								Router: MaxScaleRouterReadWriteSplit,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+								CausalReads: &MaxScaleCausalReads{
+									Mode: MaxScaleCausalReadsGlobal,
+								},
+								Params: map[string]string{
+									"causal_reads": "local",
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid causal reads timeout",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   "rw-router",
+								Router: MaxScaleRouterReadWriteSplit,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+								CausalReads: &MaxScaleCausalReads{
+									Timeout: &metav1.Duration{Duration: -time.Second},
+								},
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid monitor interval",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Monitor: MaxScaleMonitor{
+							Interval: &metav1.Duration{Duration: -time.Second},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+						Services: []MaxScaleService{
+							{
+								Name:   "rw-router",
+								Router: MaxScaleRouterReadWriteSplit,
+								Listener: MaxScaleListener{
+									Port: 3306,
+								},
+								CausalReads: &MaxScaleCausalReads{
+									Mode:    MaxScaleCausalReadsLocal,
+									Timeout: &metav1.Duration{Duration: 5 * time.Second},
+								},
+								Params: map[string]string{
+									"transaction_replay": "true",
+								},
+							},
+						},
+						Monitor: MaxScaleMonitor{
+							Module:   MaxScaleMonitorModuleMariadb,
+							Interval: &metav1.Duration{Duration: time.Second},
+						},
+					},
+				},
+				false,
+			),
+			Entry(
+				"Valid with defaults",
+				&MaxScale{
+					ObjectMeta: objMeta,
+					Spec: MaxScaleSpec{
+						MariaDBRef: mariaDbRef,
+					},
+				},
+				false,
+			),
+		)
+	})
+
+	Context("When updating a MaxScale", Ordered, func() {
+		key := types.NamespacedName{
+			Name:      "maxscale-update-webhook",
+			Namespace: testNamespace,
+		}
+		BeforeAll(func() {
+			mxs := MaxScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: MaxScaleSpec{
+					MariaDBRef: MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: "mariadb",
+						},
+					},
+					Auth: MaxScaleAuth{
+						Username: "maxscale",
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &mxs)).To(Succeed())
+		})
+		DescribeTable(
+			"Should validate",
+			func(patchFn func(mxs *MaxScale), wantErr bool) {
+				var mxs MaxScale
+				Expect(k8sClient.Get(testCtx, key, &mxs)).To(Succeed())
+
+				patch := client.MergeFrom(mxs.DeepCopy())
+				patchFn(&mxs)
+
+				err := k8sClient.Patch(testCtx, &mxs, patch)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Updating MariaDBRef",
+				func(mxs *MaxScale) {
+					mxs.Spec.MariaDBRef.Name = "another-mariadb"
+				},
+				true,
+			),
+			Entry(
+				"Updating Username",
+				func(mxs *MaxScale) {
+					mxs.Spec.Auth.Username = "foo"
+				},
+				true,
+			),
+			Entry(
+				"Updating Replicas",
+				func(mxs *MaxScale) {
+					mxs.Spec.Replicas = 3
+				},
+				false,
+			),
+			Entry(
+				"Updating Monitor",
+				func(mxs *MaxScale) {
+					mxs.Spec.Monitor.Params = map[string]string{
+						"failcount": "3",
+					}
+				},
+				false,
+			),
+		)
+	})
+})
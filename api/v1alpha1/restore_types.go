@@ -1,11 +1,45 @@
 package v1alpha1
 
 import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// RestoreMode defines which parts of the backup are restored.
+type RestoreMode string
+
+const (
+	// RestoreModeAll restores both the schema and the data.
+	RestoreModeAll RestoreMode = "All"
+	// RestoreModeSchemaOnly restores the schema, skipping the data. Useful for rebuilding the structure in a new environment.
+	RestoreModeSchemaOnly RestoreMode = "SchemaOnly"
+	// RestoreModeDataOnly restores the data into the existing schema, replacing the rows of the tables present in the backup.
+	RestoreModeDataOnly RestoreMode = "DataOnly"
+)
+
+// Validate returns an error if the RestoreMode is not valid.
+func (r RestoreMode) Validate() error {
+	switch r {
+	case "", RestoreModeAll, RestoreModeSchemaOnly, RestoreModeDataOnly:
+		return nil
+	default:
+		return fmt.Errorf("invalid RestoreMode: %v", r)
+	}
+}
+
+// RestoreBinlogs defines the archived binary logs to be replayed after restoring the backup.
+// The binary logs archived by all the Pods are replayed in the order they were created.
+type RestoreBinlogs struct {
+	// S3 defines the storage where the binary logs were archived. It should match the 'spec.binlogArchive.s3' of the archiving MariaDB.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	S3 S3 `json:"s3"`
+}
+
 // RestoreSpec defines the desired state of restore
 type RestoreSpec struct {
 	// RestoreSource defines a source for restoring a MariaDB.
@@ -16,6 +50,39 @@ type RestoreSpec struct {
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
+	// DependsOnBackups defines dependencies with Backup objects. The Restore will not be executed until all of them have successfully completed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DependsOnBackups []corev1.LocalObjectReference `json:"dependsOnBackups,omitempty" webhook:"inmutable"`
+	// WriteFreeze places the MariaDB in read-only mode while the Restore is in progress, and blocks the execution of new SqlJobs,
+	// so applications are not able to write into a partially restored database. Writes are allowed again once the Restore is completed or deleted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	WriteFreeze bool `json:"writeFreeze,omitempty" webhook:"inmutable"`
+	// Mode defines which parts of the backup are restored. It defaults to 'All'.
+	// +optional
+	// +kubebuilder:default=All
+	// +kubebuilder:validation:Enum=All;SchemaOnly;DataOnly
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Mode RestoreMode `json:"mode,omitempty" webhook:"inmutable"`
+	// Databases to be restored from the backup. By default, all the databases in the backup are restored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Databases []string `json:"databases,omitempty" webhook:"inmutable"`
+	// Tables to be restored from the backup, in '<database>.<table>' format. The existing tables are replaced by the ones in the backup.
+	// By default, all the tables in the backup are restored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tables []string `json:"tables,omitempty" webhook:"inmutable"`
+	// SkipCompatibilityCheck disables the validation of the backup manifest against the target MariaDB before restoring the backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	SkipCompatibilityCheck bool `json:"skipCompatibilityCheck,omitempty" webhook:"inmutable"`
+	// Binlogs defines the archived binary logs to be replayed after restoring the backup, up to 'spec.targetRecoveryTime'.
+	// It requires MariaDB 10.8 or later, as GTID positions are used to determine the first event to be replayed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Binlogs *RestoreBinlogs `json:"binlogs,omitempty" webhook:"inmutable"`
 	// LogLevel to be used n the Backup Job. It defaults to 'info'.
 	// +optional
 	// +kubebuilder:default=info
@@ -26,6 +93,24 @@ type RestoreSpec struct {
 	// +kubebuilder:default=5
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
 	BackoffLimit int32 `json:"backoffLimit,omitempty"`
+	// TTLSecondsAfterFinished defines the number of seconds after which a finished Restore Job is deleted, along with its Pods.
+	// The Job is not recreated once the Restore is complete. It must be at least 60 seconds, so the result of the Job can be
+	// observed by the operator before it is deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// ActiveDeadlineSeconds defines the maximum duration in seconds of the Restore Job. Once it is exceeded, the Pods are terminated and the Job is marked as failed.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// PodFailurePolicy defines how the failures of the Restore Pods are handled, allowing to fail fast or to retry depending on the exit code
+	// or the Pod conditions. It requires the RestartPolicy to be Never. When not provided and the RestartPolicy is Never, Pod disruptions,
+	// such as node evictions or preemptions, are retried without counting towards the BackoffLimit.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PodFailurePolicy *batchv1.PodFailurePolicy `json:"podFailurePolicy,omitempty" webhook:"inmutable"`
 	// RestartPolicy to be added to the Backup Job.
 	// +optional
 	// +kubebuilder:default=OnFailure
@@ -87,6 +172,15 @@ func (r *Restore) IsComplete() bool {
 	return meta.IsStatusConditionTrue(r.Status.Conditions, ConditionTypeComplete)
 }
 
+// IsSelective indicates whether only a subset of the databases and tables of the backup is restored.
+func (r *Restore) IsSelective() bool {
+	return len(r.Spec.Databases) > 0 || len(r.Spec.Tables) > 0
+}
+
+func (r *Restore) IsWriteFrozen() bool {
+	return meta.IsStatusConditionTrue(r.Status.Conditions, ConditionTypeWriteFrozen)
+}
+
 // +kubebuilder:object:root=true
 
 // RestoreList contains a list of restore
@@ -2,8 +2,10 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -42,5 +44,80 @@ func (r *Restore) validate() (admission.Warnings, error) {
 	if err := r.Spec.RestoreSource.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid restore: %v", err)
 	}
+	if err := r.Spec.Mode.Validate(); err != nil {
+		return nil, field.Invalid(
+			field.NewPath("spec").Child("mode"),
+			r.Spec.Mode,
+			err.Error(),
+		)
+	}
+	if err := r.validateSelection(); err != nil {
+		return nil, err
+	}
+	if err := r.validateBinlogs(); err != nil {
+		return nil, err
+	}
+	if err := validatePodFailurePolicy(r.Spec.PodFailurePolicy, r.Spec.RestartPolicy); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
+
+func (r *Restore) validateSelection() error {
+	for i, db := range r.Spec.Databases {
+		if strings.TrimSpace(db) == "" || strings.ContainsAny(db, "`,'") {
+			return field.Invalid(
+				field.NewPath("spec").Child("databases").Index(i),
+				db,
+				"invalid database name",
+			)
+		}
+	}
+	for i, table := range r.Spec.Tables {
+		db, tbl, ok := strings.Cut(table, ".")
+		if !ok || strings.TrimSpace(db) == "" || strings.TrimSpace(tbl) == "" || strings.ContainsAny(table, "`,'") {
+			return field.Invalid(
+				field.NewPath("spec").Child("tables").Index(i),
+				table,
+				"tables must be in '<database>.<table>' format",
+			)
+		}
+	}
+	return nil
+}
+
+func (r *Restore) validateBinlogs() error {
+	binlogs := r.Spec.Binlogs
+	if binlogs == nil {
+		return nil
+	}
+	if r.Spec.TargetRecoveryTime == nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("targetRecoveryTime"),
+			r.Spec.TargetRecoveryTime,
+			"'spec.targetRecoveryTime' must be set when replaying binary logs",
+		)
+	}
+	if r.Spec.Mode != "" && r.Spec.Mode != RestoreModeAll {
+		return field.Invalid(
+			field.NewPath("spec").Child("mode"),
+			r.Spec.Mode,
+			"Binary logs can only be replayed when restoring 'All'",
+		)
+	}
+	if r.IsSelective() {
+		return field.Invalid(
+			field.NewPath("spec").Child("binlogs"),
+			binlogs,
+			"Binary logs can not be replayed when restoring a subset of databases or tables",
+		)
+	}
+	if err := binlogs.S3.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("binlogs").Child("s3"),
+			binlogs.S3,
+			fmt.Sprintf("invalid S3: %v", err),
+		)
+	}
+	return nil
+}
@@ -91,6 +91,74 @@ var _ = Describe("Restore webhook", func() {
 				},
 				false,
 			),
+			Entry(
+				"GCS source",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							GCS: &GCS{
+								Bucket: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Azure Blob source",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							AzureBlob: &AzureBlob{
+								Container:      "test",
+								StorageAccount: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				false,
+			),
+			Entry(
+				"S3 and GCS source",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							S3: &S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+							GCS: &GCS{
+								Bucket: "test",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
 			Entry(
 				"Volume source",
 				&Restore{
@@ -167,6 +235,172 @@ var _ = Describe("Restore webhook", func() {
 				},
 				false,
 			),
+			Entry(
+				"Binlogs without target recovery time",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							BackupRef: &corev1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Binlogs: &RestoreBinlogs{
+							S3: S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Binlogs with schema only mode",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							BackupRef: &corev1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+							TargetRecoveryTime: &metav1.Time{Time: time.Now()},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Mode: RestoreModeSchemaOnly,
+						Binlogs: &RestoreBinlogs{
+							S3: S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Selective restore",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							BackupRef: &corev1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Databases: []string{
+							"app",
+						},
+						Tables: []string{
+							"billing.invoices",
+						},
+						BackoffLimit: 10,
+					},
+				},
+				false,
+			),
+			Entry(
+				"Invalid selective restore table",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							BackupRef: &corev1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Tables: []string{
+							"invoices",
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Binlogs with selective restore",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							BackupRef: &corev1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+							TargetRecoveryTime: &metav1.Time{Time: time.Now()},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Databases: []string{
+							"app",
+						},
+						Binlogs: &RestoreBinlogs{
+							S3: S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						BackoffLimit: 10,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Binlogs",
+				&Restore{
+					ObjectMeta: objMeta,
+					Spec: RestoreSpec{
+						RestoreSource: RestoreSource{
+							BackupRef: &corev1.LocalObjectReference{
+								Name: "backup-webhook",
+							},
+							TargetRecoveryTime: &metav1.Time{Time: time.Now()},
+						},
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+							WaitForIt: true,
+						},
+						Binlogs: &RestoreBinlogs{
+							S3: S3{
+								Bucket:   "test",
+								Endpoint: "test",
+							},
+						},
+						BackoffLimit: 10,
+					},
+				},
+				false,
+			),
 		)
 	})
 
@@ -0,0 +1,213 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// RestoreRehearsalPhase is the phase of a RestoreRehearsal.
+type RestoreRehearsalPhase string
+
+const (
+	// RestoreRehearsalPhaseProvisioning indicates that the temporary MariaDB is being provisioned and the backup restored.
+	RestoreRehearsalPhaseProvisioning RestoreRehearsalPhase = "Provisioning"
+	// RestoreRehearsalPhaseSucceeded indicates that the backup was restored and all the validations passed.
+	RestoreRehearsalPhaseSucceeded RestoreRehearsalPhase = "Succeeded"
+	// RestoreRehearsalPhaseFailed indicates that the backup could not be restored or any of the validations failed.
+	RestoreRehearsalPhaseFailed RestoreRehearsalPhase = "Failed"
+)
+
+// RestoreRehearsalValidation is a SQL query executed against the restored data to validate it.
+type RestoreRehearsalValidation struct {
+	// Name of the validation.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Database to run the query against.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty"`
+	// Sql is the query to be executed.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Sql string `json:"sql"`
+	// Expected is the value expected in the first column of the first row returned by the query.
+	// If not provided, the validation passes when the query is executed successfully.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Expected *string `json:"expected,omitempty"`
+}
+
+// RestoreRehearsalSpec defines the desired state of RestoreRehearsal
+type RestoreRehearsalSpec struct {
+	// RestoreSource defines a source for restoring the temporary MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RestoreSource `json:",inline"`
+	// MariaDBRef is a reference to the MariaDB used as template for the temporary MariaDB.
+	// The temporary MariaDB runs a single standalone replica, regardless of the topology of the referenced MariaDB.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
+	// Validations are the SQL queries executed against the restored data.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Validations []RestoreRehearsalValidation `json:"validations,omitempty"`
+	// VolumeClaimTemplate overrides the storage of the temporary MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	VolumeClaimTemplate *VolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+	// Resouces overrides the compute resources of the temporary MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Timeout defines the maximum duration of the rehearsal, including provisioning and restoring. It defaults to 1h.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// KeepOnFailure keeps the temporary MariaDB when the rehearsal fails, so it can be inspected.
+	// It will be deleted when the next rehearsal starts or when the RestoreRehearsal is deleted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	KeepOnFailure bool `json:"keepOnFailure,omitempty"`
+	// Schedule defines when the rehearsal is repeated. If not provided, the rehearsal will be executed only once.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Schedule *Schedule `json:"schedule,omitempty"`
+}
+
+// RestoreRehearsalValidationResult is the result of a validation.
+type RestoreRehearsalValidationResult struct {
+	// Name of the validation.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+	// Passed indicates whether the validation passed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Passed bool `json:"passed"`
+	// Value is the first column of the first row returned by the query.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Value *string `json:"value,omitempty"`
+	// Message describes why the validation failed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+}
+
+// RestoreRehearsalStatus defines the observed state of RestoreRehearsal
+type RestoreRehearsalStatus struct {
+	// Conditions for the RestoreRehearsal object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase of the current rehearsal.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Phase RestoreRehearsalPhase `json:"phase,omitempty"`
+	// Message describes the outcome of the current rehearsal.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Message string `json:"message,omitempty"`
+	// StartTime is the time when the current rehearsal started.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// RestoreTime is the time when the backup was restored into the temporary MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+	// CompletionTime is the time when the current rehearsal completed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Results of the validations.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Results []RestoreRehearsalValidationResult `json:"results,omitempty"`
+}
+
+func (r *RestoreRehearsalStatus) SetCondition(condition metav1.Condition) {
+	if r.Conditions == nil {
+		r.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&r.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=rrmdb
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Complete",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Complete\")].message"
+// +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:resources={{RestoreRehearsal,v1alpha1},{MariaDB,v1alpha1}}
+
+// RestoreRehearsal is the Schema for the restorerehearsals API. It restores a backup into a temporary MariaDB,
+// validates the restored data and tears the temporary MariaDB down.
+type RestoreRehearsal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestoreRehearsalSpec   `json:"spec,omitempty"`
+	Status RestoreRehearsalStatus `json:"status,omitempty"`
+}
+
+func (r *RestoreRehearsal) IsComplete() bool {
+	return meta.IsStatusConditionTrue(r.Status.Conditions, ConditionTypeComplete)
+}
+
+// IsFinished indicates whether the current rehearsal has either succeeded or failed.
+func (r *RestoreRehearsal) IsFinished() bool {
+	return r.Status.Phase == RestoreRehearsalPhaseSucceeded || r.Status.Phase == RestoreRehearsalPhaseFailed
+}
+
+// Timeout returns the maximum duration of the rehearsal.
+func (r *RestoreRehearsal) Timeout() time.Duration {
+	if r.Spec.Timeout != nil {
+		return r.Spec.Timeout.Duration
+	}
+	return 1 * time.Hour
+}
+
+// NextScheduleTime returns the time when the next rehearsal should start.
+func (r *RestoreRehearsal) NextScheduleTime() (time.Time, error) {
+	if r.Spec.Schedule == nil {
+		return time.Time{}, fmt.Errorf("schedule not provided")
+	}
+	schedule, err := cronParser.Parse(r.Spec.Schedule.Cron)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing schedule: %v", err)
+	}
+	last := r.CreationTimestamp.Time
+	if r.Status.StartTime != nil {
+		last = r.Status.StartTime.Time
+	}
+	return schedule.Next(last), nil
+}
+
+// MariaDBKey defines the key for the temporary MariaDB.
+func (r *RestoreRehearsal) MariaDBKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-rehearsal", r.Name),
+		Namespace: r.Namespace,
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// RestoreRehearsalList contains a list of RestoreRehearsal
+type RestoreRehearsalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RestoreRehearsal `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RestoreRehearsal{}, &RestoreRehearsalList{})
+}
@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func (r *RestoreRehearsal) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//nolint
+//+kubebuilder:webhook:path=/validate-mariadb-mmontes-io-v1alpha1-restorerehearsal,mutating=false,failurePolicy=fail,sideEffects=None,groups=mariadb.mmontes.io,resources=restorerehearsals,verbs=create;update,versions=v1alpha1,name=vrestorerehearsal.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &RestoreRehearsal{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *RestoreRehearsal) ValidateCreate() (admission.Warnings, error) {
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *RestoreRehearsal) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	if err := inmutableWebhook.ValidateUpdate(r, old.(*RestoreRehearsal)); err != nil {
+		return nil, err
+	}
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *RestoreRehearsal) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *RestoreRehearsal) validate() (admission.Warnings, error) {
+	if err := r.Spec.RestoreSource.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid restore: %v", err)
+	}
+	if err := r.validateValidations(); err != nil {
+		return nil, err
+	}
+	if err := r.validateTimeout(); err != nil {
+		return nil, err
+	}
+	if err := r.validateSchedule(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *RestoreRehearsal) validateValidations() error {
+	seen := make(map[string]struct{}, len(r.Spec.Validations))
+	for i, v := range r.Spec.Validations {
+		if _, ok := seen[v.Name]; ok {
+			return field.Invalid(
+				field.NewPath("spec").Child("validations").Index(i).Child("name"),
+				v.Name,
+				"duplicated validation",
+			)
+		}
+		seen[v.Name] = struct{}{}
+	}
+	return nil
+}
+
+func (r *RestoreRehearsal) validateTimeout() error {
+	if r.Spec.Timeout != nil && r.Spec.Timeout.Duration <= 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("timeout"),
+			r.Spec.Timeout,
+			"timeout must be greater than zero",
+		)
+	}
+	return nil
+}
+
+func (r *RestoreRehearsal) validateSchedule() error {
+	if r.Spec.Schedule == nil {
+		return nil
+	}
+	if err := r.Spec.Schedule.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("schedule"),
+			r.Spec.Schedule,
+			fmt.Sprintf("invalid schedule: %v", err),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("RestoreRehearsal webhook", func() {
+	Context("When creating a RestoreRehearsal", func() {
+		objMeta := metav1.ObjectMeta{
+			Name:      "restorerehearsal-create-webhook",
+			Namespace: testNamespace,
+		}
+		mariaDBRef := MariaDBRef{
+			ObjectReference: corev1.ObjectReference{
+				Name: "foo",
+			},
+		}
+		restoreSource := RestoreSource{
+			BackupRef: &corev1.LocalObjectReference{
+				Name: "backup",
+			},
+		}
+		DescribeTable(
+			"Should validate",
+			func(r *RestoreRehearsal, wantErr bool) {
+				_ = k8sClient.Delete(testCtx, r)
+				err := k8sClient.Create(testCtx, r)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"No source",
+				&RestoreRehearsal{
+					ObjectMeta: objMeta,
+					Spec: RestoreRehearsalSpec{
+						MariaDBRef: mariaDBRef,
+					},
+				},
+				true,
+			),
+			Entry(
+				"Duplicated validations",
+				&RestoreRehearsal{
+					ObjectMeta: objMeta,
+					Spec: RestoreRehearsalSpec{
+						RestoreSource: restoreSource,
+						MariaDBRef:    mariaDBRef,
+						Validations: []RestoreRehearsalValidation{
+							{
+								Name: "users",
+								Sql:  "SELECT COUNT(*) > 0 FROM users",
+							},
+							{
+								Name: "users",
+								Sql:  "SELECT COUNT(*) FROM users",
+							},
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid timeout",
+				&RestoreRehearsal{
+					ObjectMeta: objMeta,
+					Spec: RestoreRehearsalSpec{
+						RestoreSource: restoreSource,
+						MariaDBRef:    mariaDBRef,
+						Timeout:       &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Invalid schedule",
+				&RestoreRehearsal{
+					ObjectMeta: objMeta,
+					Spec: RestoreRehearsalSpec{
+						RestoreSource: restoreSource,
+						MariaDBRef:    mariaDBRef,
+						Schedule: &Schedule{
+							Cron: "foo",
+						},
+					},
+				},
+				true,
+			),
+			Entry(
+				"Valid",
+				&RestoreRehearsal{
+					ObjectMeta: objMeta,
+					Spec: RestoreRehearsalSpec{
+						RestoreSource: restoreSource,
+						MariaDBRef:    mariaDBRef,
+						Validations: []RestoreRehearsalValidation{
+							{
+								Name:     "users",
+								Database: ptr.To("mariadb"),
+								Sql:      "SELECT COUNT(*) > 0 FROM users",
+								Expected: ptr.To("1"),
+							},
+						},
+						Timeout: &metav1.Duration{Duration: 30 * time.Minute},
+						Schedule: &Schedule{
+							Cron: "0 3 * * 0",
+						},
+					},
+				},
+				false,
+			),
+		)
+	})
+
+	Context("When updating a RestoreRehearsal", Ordered, func() {
+		key := types.NamespacedName{
+			Name:      "restorerehearsal-update-webhook",
+			Namespace: testNamespace,
+		}
+		BeforeAll(func() {
+			rehearsal := RestoreRehearsal{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: RestoreRehearsalSpec{
+					RestoreSource: RestoreSource{
+						BackupRef: &corev1.LocalObjectReference{
+							Name: "backup",
+						},
+					},
+					MariaDBRef: MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: "foo",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &rehearsal)).To(Succeed())
+		})
+		DescribeTable(
+			"Should validate",
+			func(patchFn func(r *RestoreRehearsal), wantErr bool) {
+				var rehearsal RestoreRehearsal
+				Expect(k8sClient.Get(testCtx, key, &rehearsal)).To(Succeed())
+
+				patch := client.MergeFrom(rehearsal.DeepCopy())
+				patchFn(&rehearsal)
+
+				err := k8sClient.Patch(testCtx, &rehearsal, patch)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Updating MariaDBRef",
+				func(r *RestoreRehearsal) {
+					r.Spec.MariaDBRef.Name = "bar"
+				},
+				true,
+			),
+			Entry(
+				"Updating Validations",
+				func(r *RestoreRehearsal) {
+					r.Spec.Validations = []RestoreRehearsalValidation{
+						{
+							Name: "users",
+							Sql:  "SELECT 1",
+						},
+					}
+				},
+				false,
+			),
+		)
+	})
+})
@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoleSpec defines the desired state of Role
+type RoleSpec struct {
+	// SQLTemplate defines templates to configure SQL objects.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SQLTemplate `json:",inline"`
+	// MariaDBRef is a reference to a MariaDB object.
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
+	// Name overrides the default Role name provided by metadata.name.
+	// +optional
+	// +kubebuilder:validation:MaxLength=80
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name,omitempty" webhook:"inmutable"`
+}
+
+// RoleStatus defines the observed state of Role
+type RoleStatus struct {
+	// Conditions for the Role object.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (r *RoleStatus) SetCondition(condition metav1.Condition) {
+	if r.Conditions == nil {
+		r.Conditions = make([]metav1.Condition, 0)
+	}
+	meta.SetStatusCondition(&r.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=rlmdb
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="MariaDB",type="string",JSONPath=".spec.mariaDbRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +operator-sdk:csv:customresourcedefinitions:resources={{Role,v1alpha1}}
+
+// Role is the Schema for the roles API. It manages a MariaDB role, which groups privileges that can be granted to Users.
+type Role struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleSpec   `json:"spec,omitempty"`
+	Status RoleStatus `json:"status,omitempty"`
+}
+
+func (r *Role) RoleNameOrDefault() string {
+	if r.Spec.Name != "" {
+		return r.Spec.Name
+	}
+	return r.Name
+}
+
+func (r *Role) IsBeingDeleted() bool {
+	return !r.DeletionTimestamp.IsZero()
+}
+
+func (r *Role) IsReady() bool {
+	return meta.IsStatusConditionTrue(r.Status.Conditions, ConditionTypeReady)
+}
+
+func (r *Role) MariaDBRef() *MariaDBRef {
+	return &r.Spec.MariaDBRef
+}
+
+func (r *Role) RequeueInterval() *metav1.Duration {
+	return r.Spec.RequeueInterval
+}
+
+func (r *Role) RetryInterval() *metav1.Duration {
+	return r.Spec.RetryInterval
+}
+
+func (r *Role) SqlTimeout() *metav1.Duration {
+	return r.Spec.Timeout
+}
+
+// +kubebuilder:object:root=true
+
+// RoleList contains a list of Role
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+}
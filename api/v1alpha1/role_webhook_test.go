@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Role webhook", func() {
+	Context("When updating a Role", Ordered, func() {
+		key := types.NamespacedName{
+			Name:      "role-mariadb-webhook",
+			Namespace: testNamespace,
+		}
+		BeforeAll(func() {
+			role := Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: RoleSpec{
+					MariaDBRef: MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: "mariadb-webhook",
+						},
+						WaitForIt: true,
+					},
+					Name: "foo",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &role)).To(Succeed())
+		})
+
+		DescribeTable(
+			"Should validate",
+			func(patchFn func(role *Role), wantErr bool) {
+				var role Role
+				Expect(k8sClient.Get(testCtx, key, &role)).To(Succeed())
+
+				patch := client.MergeFrom(role.DeepCopy())
+				patchFn(&role)
+
+				err := k8sClient.Patch(testCtx, &role, patch)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry(
+				"Updating MariaDBRef",
+				func(role *Role) {
+					role.Spec.MariaDBRef.Name = "another-mariadb"
+				},
+				true,
+			),
+			Entry(
+				"Updating Name",
+				func(role *Role) {
+					role.Spec.Name = "bar"
+				},
+				true,
+			),
+			Entry(
+				"Updating RetryInterval",
+				func(role *Role) {
+					role.Spec.RetryInterval = &metav1.Duration{Duration: 10 * time.Second}
+				},
+				false,
+			),
+		)
+	})
+})
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +33,10 @@ type SqlJobSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	DependsOn []corev1.LocalObjectReference `json:"dependsOn,omitempty" webhook:"inmutable"`
+	// DependsOnBackups defines dependencies with Backup objects. The SqlJob will not be executed until all of them have successfully completed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DependsOnBackups []corev1.LocalObjectReference `json:"dependsOnBackups,omitempty" webhook:"inmutable"`
 	// Sql is the script to be executed by the SqlJob.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -46,6 +51,34 @@ type SqlJobSpec struct {
 	// +kubebuilder:default=5
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
 	BackoffLimit int32 `json:"backoffLimit,omitempty"`
+	// TTLSecondsAfterFinished defines the number of seconds after which a finished SqlJob Job is deleted, along with its Pods.
+	// The Job is not recreated once the SqlJob is complete. It must be at least 60 seconds, so the result of the Job can be
+	// observed by the operator before it is deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// ActiveDeadlineSeconds defines the maximum duration in seconds of the SqlJob Job. Once it is exceeded, the Pods are terminated and the Job is marked as failed.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// PodFailurePolicy defines how the failures of the SqlJob Pods are handled, allowing to fail fast or to retry depending on the exit code
+	// or the Pod conditions. It requires the RestartPolicy to be Never. When not provided and the RestartPolicy is Never, Pod disruptions,
+	// such as node evictions or preemptions, are retried without counting towards the BackoffLimit.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PodFailurePolicy *batchv1.PodFailurePolicy `json:"podFailurePolicy,omitempty" webhook:"inmutable"`
+	// SuccessfulJobsHistoryLimit defines the number of successful Jobs to be kept when the SqlJob is scheduled. It defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit defines the number of failed Jobs to be kept when the SqlJob is scheduled. It defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
 	// RestartPolicy to be added to the SqlJob Pod.
 	// +optional
 	// +kubebuilder:default=OnFailure
@@ -46,6 +46,9 @@ func (s *SqlJob) validate() (admission.Warnings, error) {
 	if err := s.validateSchedule(); err != nil {
 		return nil, err
 	}
+	if err := validatePodFailurePolicy(s.Spec.PodFailurePolicy, s.Spec.RestartPolicy); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
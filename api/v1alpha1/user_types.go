@@ -1,13 +1,76 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AuthenticationPlugin is the plugin used to authenticate a User.
+type AuthenticationPlugin string
+
+const (
+	// AuthenticationPluginNativePassword authenticates via a SHA1 based password hash.
+	AuthenticationPluginNativePassword AuthenticationPlugin = "mysql_native_password"
+	// AuthenticationPluginEd25519 authenticates via an Ed25519 based password signature, which is stronger than mysql_native_password.
+	AuthenticationPluginEd25519 AuthenticationPlugin = "ed25519"
+	// AuthenticationPluginUnixSocket authenticates the local connections of the operating system user with the same name, without password.
+	AuthenticationPluginUnixSocket AuthenticationPlugin = "unix_socket"
+)
+
+var (
+	nativePasswordHashRegex  = regexp.MustCompile(`^\*[0-9A-Fa-f]{40}$`)
+	ed25519PasswordHashRegex = regexp.MustCompile(`^[A-Za-z0-9+/]{43}$`)
+)
+
+// ValidatePasswordHash checks that a password hash has the format expected by the plugin. Hashes are mysql_native_password ones,
+// i.e. an asterisk followed by 40 hexadecimal characters, unless the plugin is ed25519, which uses 43 base64 characters.
+func (p AuthenticationPlugin) ValidatePasswordHash(hash string) error {
+	if p == AuthenticationPluginEd25519 {
+		if !ed25519PasswordHashRegex.MatchString(hash) {
+			return errors.New("ed25519 password hashes must be 43 base64 characters")
+		}
+		return nil
+	}
+	if !nativePasswordHashRegex.MatchString(hash) {
+		return errors.New("mysql_native_password hashes must be an asterisk followed by 40 hexadecimal characters")
+	}
+	return nil
+}
+
+// UserRequireType is the TLS requirement of the connections of a User.
+type UserRequireType string
+
+const (
+	// UserRequireNone does not require the connections to use TLS.
+	UserRequireNone UserRequireType = "NONE"
+	// UserRequireSSL requires the connections to use TLS.
+	UserRequireSSL UserRequireType = "SSL"
+	// UserRequireX509 requires the connections to use TLS with a valid client certificate.
+	UserRequireX509 UserRequireType = "X509"
+)
+
+// UserRequire defines the TLS requirements of the connections of a User.
+type UserRequire struct {
+	// Type of the requirement, either NONE, SSL or X509.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=NONE;SSL;X509
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Type UserRequireType `json:"type"`
+	// Subject that the client certificate must have. It can only be specified along with the X509 type.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Subject string `json:"subject,omitempty"`
+	// Issuer of the client certificate. It can only be specified along with the X509 type.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Issuer string `json:"issuer,omitempty"`
+}
+
 // UserSpec defines the desired state of User
 type UserSpec struct {
 	// SQLTemplate defines templates to configure SQL objects.
@@ -19,14 +82,52 @@ type UserSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	MariaDBRef MariaDBRef `json:"mariaDbRef" webhook:"inmutable"`
 	// PasswordSecretKeyRef is a reference to the password to be used by the User.
-	// +kubebuilder:validation:Required
+	// Either PasswordSecretKeyRef or PasswordHashSecretKeyRef is required unless the User is authenticated via the unix_socket plugin.
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	PasswordSecretKeyRef corev1.SecretKeySelector `json:"passwordSecretKeyRef" webhook:"inmutable"`
+	PasswordSecretKeyRef *corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty" webhook:"inmutable"`
+	// PasswordHashSecretKeyRef is a reference to the hash of the password to be used by the User, so the plaintext password is not
+	// stored in the cluster. It is a mysql_native_password hash, i.e. the output of the PASSWORD() function, or an ed25519 one
+	// when the User is authenticated via the ed25519 plugin. It cannot be used along with PasswordSecretKeyRef.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordHashSecretKeyRef *corev1.SecretKeySelector `json:"passwordHashSecretKeyRef,omitempty" webhook:"inmutable"`
+	// AuthenticationPlugin is the plugin used to authenticate the User, either mysql_native_password, ed25519 or unix_socket.
+	// When not specified, the User is identified by password via the default plugin of the server, and the plugin is not managed.
+	// +optional
+	// +kubebuilder:validation:Enum=mysql_native_password;ed25519;unix_socket
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AuthenticationPlugin AuthenticationPlugin `json:"authenticationPlugin,omitempty"`
+	// PasswordRotation defines the periodic rotation of the password of the User. A new random password is set in MariaDB and
+	// in the Secret referenced by PasswordSecretKeyRef, and the Connections using the Secret are refreshed afterwards.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PasswordRotation *PasswordRotation `json:"passwordRotation,omitempty"`
 	// MaxUserConnections defines the maximum number of connections that the User can have.
 	// +optional
 	// +kubebuilder:default=10
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
 	MaxUserConnections int32 `json:"maxUserConnections,omitempty" webhook:"inmutable"`
+	// MaxStatementTime is the maximum execution time of the statements of the User, after which they are aborted. 0 means no limit.
+	// It takes precedence over the max_statement_time system variable and the MariaDB session policy.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxStatementTime *metav1.Duration `json:"maxStatementTime,omitempty"`
+	// Require defines the TLS requirements of the connections of the User, applied via ALTER USER ... REQUIRE.
+	// The requirements of the account are not managed when it is not specified.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Require *UserRequire `json:"require,omitempty"`
+	// Roles granted to the User, which may be managed by Role resources. They are reconciled as a set: roles removed from
+	// the list are revoked from the accounts of the User.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Roles []string `json:"roles,omitempty"`
+	// DefaultRole is the role enabled by default when the User connects, as MariaDB supports a single default role per account.
+	// It must be one of Roles.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DefaultRole string `json:"defaultRole,omitempty"`
 	// Name overrides the default name provided by metadata.name.
 	// +optional
 	// +kubebuilder:validation:MaxLength=80
@@ -37,6 +138,11 @@ type UserSpec struct {
 	// +kubebuilder:validation:MaxLength=255
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Host string `json:"host,omitempty" webhook:"inmutable"`
+	// Hosts is a list of hosts for which the same account will be created, i.e. '%', '10.0.%'. They are reconciled as a set:
+	// accounts for new hosts are created and accounts for removed hosts are dropped. It cannot be used along with Host.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Hosts []string `json:"hosts,omitempty"`
 }
 
 // UserStatus defines the observed state of User
@@ -45,6 +151,27 @@ type UserStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Hosts for which the account has been created.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Hosts []string `json:"hosts,omitempty"`
+	// Roles granted to the accounts of the User.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Roles []string `json:"roles,omitempty"`
+	// PasswordLastChanged is the time when the password was last changed in MariaDB.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PasswordLastChanged *metav1.Time `json:"passwordLastChanged,omitempty"`
+	// PasswordExpiresAt is the time when the password expires, according to the password_lifetime of the account
+	// or the default_password_lifetime system variable. It is not set when the password does not expire.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PasswordExpiresAt *metav1.Time `json:"passwordExpiresAt,omitempty"`
+	// PasswordLastRotated is the last time the password was rotated by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	PasswordLastRotated *metav1.Time `json:"passwordLastRotated,omitempty"`
 }
 
 func (u *UserStatus) SetCondition(condition metav1.Condition) {
@@ -74,7 +201,29 @@ type User struct {
 }
 
 func (u *User) AccountName() string {
-	return fmt.Sprintf("'%s'@'%s'", u.usernameOrDefault(), u.hostnameOrDefault())
+	return u.AccountNameWithHost(u.hostnameOrDefault())
+}
+
+// AccountNameWithHost returns the account name of the User for a given host.
+func (u *User) AccountNameWithHost(host string) string {
+	return fmt.Sprintf("'%s'@'%s'", u.usernameOrDefault(), host)
+}
+
+// HostsOrDefault returns the hosts for which the account should be created.
+func (u *User) HostsOrDefault() []string {
+	if len(u.Spec.Hosts) > 0 {
+		return u.Spec.Hosts
+	}
+	return []string{u.hostnameOrDefault()}
+}
+
+// AppliedHosts returns the hosts for which the account has been created. Users that became ready before the hosts were
+// recorded in the status have a single account, created for the default host.
+func (u *User) AppliedHosts() []string {
+	if len(u.Status.Hosts) > 0 || !u.IsReady() {
+		return u.Status.Hosts
+	}
+	return []string{u.hostnameOrDefault()}
 }
 
 func (u *User) IsBeingDeleted() bool {
@@ -85,6 +234,11 @@ func (u *User) IsReady() bool {
 	return meta.IsStatusConditionTrue(u.Status.Conditions, ConditionTypeReady)
 }
 
+// IsPasswordExpiring indicates whether the password of the User is about to expire, or it has already expired.
+func (u *User) IsPasswordExpiring() bool {
+	return meta.IsStatusConditionTrue(u.Status.Conditions, ConditionTypePasswordExpiring)
+}
+
 func (u *User) MariaDBRef() *MariaDBRef {
 	return &u.Spec.MariaDBRef
 }
@@ -97,6 +251,15 @@ func (u *User) RetryInterval() *metav1.Duration {
 	return u.Spec.RetryInterval
 }
 
+func (u *User) SqlTimeout() *metav1.Duration {
+	return u.Spec.Timeout
+}
+
+// Username returns the name of the account in MariaDB.
+func (u *User) Username() string {
+	return u.usernameOrDefault()
+}
+
 func (u *User) usernameOrDefault() string {
 	if u.Spec.Name != "" {
 		return u.Spec.Name
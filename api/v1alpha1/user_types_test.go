@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("User types", func() {
+	objMeta := metav1.ObjectMeta{
+		Name:      "user-obj",
+		Namespace: testNamespace,
+	}
+	ready := []metav1.Condition{
+		{
+			Type:   ConditionTypeReady,
+			Status: metav1.ConditionTrue,
+		},
+	}
+	Context("When getting the applied hosts", func() {
+		DescribeTable(
+			"Should return",
+			func(user *User, expected []string) {
+				Expect(user.AppliedHosts()).To(Equal(expected))
+			},
+			Entry(
+				"No hosts for a new User",
+				&User{
+					ObjectMeta: objMeta,
+					Spec: UserSpec{
+						Hosts: []string{"10.0.%"},
+					},
+				},
+				nil,
+			),
+			Entry(
+				"Recorded hosts",
+				&User{
+					ObjectMeta: objMeta,
+					Spec: UserSpec{
+						Hosts: []string{"10.0.%"},
+					},
+					Status: UserStatus{
+						Conditions: ready,
+						Hosts:      []string{"%", "10.0.%"},
+					},
+				},
+				[]string{"%", "10.0.%"},
+			),
+			Entry(
+				"Default host for a User ready before recording hosts",
+				&User{
+					ObjectMeta: objMeta,
+					Spec: UserSpec{
+						Hosts: []string{"10.0.%"},
+					},
+					Status: UserStatus{
+						Conditions: ready,
+					},
+				},
+				[]string{"%"},
+			),
+			Entry(
+				"Host for a User ready before recording hosts",
+				&User{
+					ObjectMeta: objMeta,
+					Spec: UserSpec{
+						Host: "127.0.0.1",
+					},
+					Status: UserStatus{
+						Conditions: ready,
+					},
+				},
+				[]string{"127.0.0.1"},
+			),
+		)
+	})
+})
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -28,7 +30,7 @@ var _ = Describe("User webhook", Ordered, func() {
 						},
 						WaitForIt: true,
 					},
-					PasswordSecretKeyRef: corev1.SecretKeySelector{
+					PasswordSecretKeyRef: &corev1.SecretKeySelector{
 						LocalObjectReference: corev1.LocalObjectReference{
 							Name: "user-mariadb-webhook-root",
 						},
@@ -76,6 +78,192 @@ var _ = Describe("User webhook", Ordered, func() {
 				},
 				true,
 			),
+			Entry(
+				"Updating MaxStatementTime",
+				func(umdb *User) {
+					umdb.Spec.MaxStatementTime = &metav1.Duration{Duration: 30 * time.Second}
+				},
+				false,
+			),
+			Entry(
+				"Updating MaxStatementTime with negative value",
+				func(umdb *User) {
+					umdb.Spec.MaxStatementTime = &metav1.Duration{Duration: -time.Second}
+				},
+				true,
+			),
+			Entry(
+				"Updating Require",
+				func(umdb *User) {
+					umdb.Spec.Require = &UserRequire{
+						Type: UserRequireSSL,
+					}
+				},
+				false,
+			),
+			Entry(
+				"Updating Require with subject and issuer",
+				func(umdb *User) {
+					umdb.Spec.Require = &UserRequire{
+						Type:    UserRequireX509,
+						Subject: "/CN=user",
+						Issuer:  "/CN=mariadb-ca",
+					}
+				},
+				false,
+			),
+			Entry(
+				"Updating Require with subject and SSL type",
+				func(umdb *User) {
+					umdb.Spec.Require = &UserRequire{
+						Type:    UserRequireSSL,
+						Subject: "/CN=user",
+					}
+				},
+				true,
+			),
+			Entry(
+				"Updating AuthenticationPlugin",
+				func(umdb *User) {
+					umdb.Spec.AuthenticationPlugin = AuthenticationPluginEd25519
+				},
+				false,
+			),
+			Entry(
+				"Updating AuthenticationPlugin to unix_socket with password",
+				func(umdb *User) {
+					umdb.Spec.AuthenticationPlugin = AuthenticationPluginUnixSocket
+				},
+				true,
+			),
+			Entry(
+				"Updating PasswordRotation",
+				func(umdb *User) {
+					umdb.Spec.PasswordRotation = &PasswordRotation{
+						Interval: metav1.Duration{Duration: 720 * time.Hour},
+					}
+				},
+				false,
+			),
+			Entry(
+				"Updating PasswordRotation with invalid interval",
+				func(umdb *User) {
+					umdb.Spec.PasswordRotation = &PasswordRotation{
+						Interval: metav1.Duration{Duration: 0},
+					}
+				},
+				true,
+			),
+			Entry(
+				"Updating PasswordHashSecretKeyRef",
+				func(umdb *User) {
+					umdb.Spec.PasswordHashSecretKeyRef = &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "user-mariadb-webhook-root",
+						},
+						Key: "passwordHash",
+					}
+				},
+				true,
+			),
+			Entry(
+				"Updating Roles",
+				func(umdb *User) {
+					umdb.Spec.Roles = []string{"readonly", "readwrite"}
+					umdb.Spec.DefaultRole = "readonly"
+				},
+				false,
+			),
+			Entry(
+				"Updating Roles with duplicates",
+				func(umdb *User) {
+					umdb.Spec.Roles = []string{"readonly", "readonly"}
+				},
+				true,
+			),
+			Entry(
+				"Updating DefaultRole not in Roles",
+				func(umdb *User) {
+					umdb.Spec.DefaultRole = "admin"
+				},
+				true,
+			),
+			Entry(
+				"Updating Hosts",
+				func(umdb *User) {
+					umdb.Spec.Hosts = []string{"%", "10.0.%"}
+				},
+				false,
+			),
+			Entry(
+				"Updating Hosts with duplicates",
+				func(umdb *User) {
+					umdb.Spec.Hosts = []string{"%", "%"}
+				},
+				true,
+			),
+		)
+	})
+
+	Context("When validating a password hash", func() {
+		DescribeTable(
+			"Should validate",
+			func(plugin AuthenticationPlugin, hash string, wantErr bool) {
+				err := plugin.ValidatePasswordHash(hash)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry("Native", AuthenticationPluginNativePassword, "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19", false),
+			Entry("Native by default", AuthenticationPlugin(""), "*2470c0c06dee42fd1618bb99005adca2ec9d1e19", false),
+			Entry("Native without asterisk", AuthenticationPluginNativePassword, "2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19", true),
+			Entry("Native too short", AuthenticationPluginNativePassword, "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E", true),
+			Entry("Native plaintext", AuthenticationPluginNativePassword, "MariaDB11!", true),
+			Entry("Ed25519", AuthenticationPluginEd25519, "ZIgUREUg5PVgQ6LskhXmO+eZLS0nC8be6HPjYWR4YJY", false),
+			Entry("Ed25519 native hash", AuthenticationPluginEd25519, "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19", true),
+			Entry("Ed25519 too long", AuthenticationPluginEd25519, "ZIgUREUg5PVgQ6LskhXmO+eZLS0nC8be6HPjYWR4YJY=", true),
 		)
 	})
+
+	Context("When creating a User with a password hash", func() {
+		It("Should validate the hash format", func() {
+			secret := corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "user-hash-webhook",
+					Namespace: testNamespace,
+				},
+				StringData: map[string]string{
+					"native":  "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+					"invalid": "MariaDB11!",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &secret)).To(Succeed())
+
+			userWithHashKey := func(name, key string) *User {
+				return &User{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: testNamespace,
+					},
+					Spec: UserSpec{
+						MariaDBRef: MariaDBRef{
+							ObjectReference: corev1.ObjectReference{
+								Name: "mariadb-webhook",
+							},
+						},
+						PasswordHashSecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: secret.Name,
+							},
+							Key: key,
+						},
+					},
+				}
+			}
+			Expect(k8sClient.Create(testCtx, userWithHashKey("user-hash-webhook-valid", "native"))).To(Succeed())
+			Expect(k8sClient.Create(testCtx, userWithHashKey("user-hash-webhook-invalid", "invalid"))).ToNot(Succeed())
+		})
+	})
 })
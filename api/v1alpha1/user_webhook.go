@@ -1,8 +1,17 @@
 package v1alpha1
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -10,6 +19,9 @@ import (
 func (r *User) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(&userWebhook{
+			client: mgr.GetAPIReader(),
+		}).
 		Complete()
 }
 
@@ -20,15 +32,239 @@ var _ webhook.Validator = &User{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *User) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *User) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
-	return nil, inmutableWebhook.ValidateUpdate(r, old.(*User))
+	if err := inmutableWebhook.ValidateUpdate(r, old.(*User)); err != nil {
+		return nil, err
+	}
+	return nil, r.validate()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *User) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
+
+func (r *User) validate() error {
+	if err := r.validateHosts(); err != nil {
+		return err
+	}
+	if err := r.validateMaxStatementTime(); err != nil {
+		return err
+	}
+	if err := r.validateAuthenticationPlugin(); err != nil {
+		return err
+	}
+	if err := r.validatePasswordRotation(); err != nil {
+		return err
+	}
+	if err := r.validateRoles(); err != nil {
+		return err
+	}
+	return r.validateRequire()
+}
+
+func (r *User) validatePasswordRotation() error {
+	rotation := r.Spec.PasswordRotation
+	if rotation == nil {
+		return nil
+	}
+	if r.Spec.PasswordSecretKeyRef == nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordRotation"),
+			rotation,
+			"'passwordRotation' requires 'passwordSecretKeyRef' to be specified",
+		)
+	}
+	if err := rotation.Validate(); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordRotation"),
+			rotation,
+			fmt.Sprintf("invalid password rotation: %v", err),
+		)
+	}
+	return nil
+}
+
+func (r *User) validateAuthenticationPlugin() error {
+	if r.Spec.PasswordSecretKeyRef != nil && r.Spec.PasswordHashSecretKeyRef != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordHashSecretKeyRef"),
+			r.Spec.PasswordHashSecretKeyRef,
+			"'passwordSecretKeyRef' and 'passwordHashSecretKeyRef' cannot be specified simultaneously",
+		)
+	}
+	if r.Spec.AuthenticationPlugin != AuthenticationPluginUnixSocket {
+		if r.Spec.PasswordSecretKeyRef == nil && r.Spec.PasswordHashSecretKeyRef == nil {
+			return field.Invalid(
+				field.NewPath("spec").Child("passwordSecretKeyRef"),
+				r.Spec.PasswordSecretKeyRef,
+				fmt.Sprintf("either 'passwordSecretKeyRef' or 'passwordHashSecretKeyRef' must be specified unless 'authenticationPlugin' is '%s'",
+					AuthenticationPluginUnixSocket),
+			)
+		}
+		return nil
+	}
+	if r.Spec.PasswordSecretKeyRef != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordSecretKeyRef"),
+			r.Spec.PasswordSecretKeyRef,
+			fmt.Sprintf("'passwordSecretKeyRef' cannot be specified along with the '%s' authentication plugin", AuthenticationPluginUnixSocket),
+		)
+	}
+	if r.Spec.PasswordHashSecretKeyRef != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordHashSecretKeyRef"),
+			r.Spec.PasswordHashSecretKeyRef,
+			fmt.Sprintf("'passwordHashSecretKeyRef' cannot be specified along with the '%s' authentication plugin", AuthenticationPluginUnixSocket),
+		)
+	}
+	for _, host := range r.HostsOrDefault() {
+		if host != "localhost" {
+			return field.Invalid(
+				field.NewPath("spec").Child("host"),
+				host,
+				fmt.Sprintf("the '%s' authentication plugin only supports the 'localhost' host", AuthenticationPluginUnixSocket),
+			)
+		}
+	}
+	return nil
+}
+
+func (r *User) validateRequire() error {
+	require := r.Spec.Require
+	if require == nil || require.Type == UserRequireX509 {
+		return nil
+	}
+	if require.Subject != "" || require.Issuer != "" {
+		return field.Invalid(
+			field.NewPath("spec").Child("require"),
+			require.Type,
+			"'subject' and 'issuer' can only be specified along with the X509 type",
+		)
+	}
+	return nil
+}
+
+func (r *User) validateMaxStatementTime() error {
+	if r.Spec.MaxStatementTime != nil && r.Spec.MaxStatementTime.Duration < 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("maxStatementTime"),
+			r.Spec.MaxStatementTime,
+			"'maxStatementTime' must not be negative",
+		)
+	}
+	return nil
+}
+
+func (r *User) validateHosts() error {
+	if r.Spec.Host != "" && len(r.Spec.Hosts) > 0 {
+		return field.Invalid(
+			field.NewPath("spec").Child("hosts"),
+			r.Spec.Hosts,
+			"'spec.host' and 'spec.hosts' cannot be specified simultaneously",
+		)
+	}
+	seen := make(map[string]struct{}, len(r.Spec.Hosts))
+	for _, host := range r.Spec.Hosts {
+		if _, ok := seen[host]; ok {
+			return field.Invalid(
+				field.NewPath("spec").Child("hosts"),
+				r.Spec.Hosts,
+				fmt.Sprintf("duplicated host '%s'", host),
+			)
+		}
+		seen[host] = struct{}{}
+	}
+	return nil
+}
+
+func (r *User) validateRoles() error {
+	seen := make(map[string]struct{}, len(r.Spec.Roles))
+	for _, role := range r.Spec.Roles {
+		if _, ok := seen[role]; ok {
+			return field.Invalid(
+				field.NewPath("spec").Child("roles"),
+				r.Spec.Roles,
+				fmt.Sprintf("duplicated role '%s'", role),
+			)
+		}
+		seen[role] = struct{}{}
+	}
+	if r.Spec.DefaultRole != "" && !slices.Contains(r.Spec.Roles, r.Spec.DefaultRole) {
+		return field.Invalid(
+			field.NewPath("spec").Child("defaultRole"),
+			r.Spec.DefaultRole,
+			"'defaultRole' must be one of 'roles'",
+		)
+	}
+	return nil
+}
+
+// userWebhook validates the User objects, including the format of the password hash referenced by them.
+// Secrets are read directly from the API server, as caching all the Secrets of the cluster in the webhook is not desirable.
+type userWebhook struct {
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &userWebhook{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (w *userWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	user := obj.(*User)
+	warnings, err := user.ValidateCreate()
+	if err != nil {
+		return nil, err
+	}
+	return warnings, w.validatePasswordHash(ctx, user)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (w *userWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	user := newObj.(*User)
+	warnings, err := user.ValidateUpdate(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, w.validatePasswordHash(ctx, user)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (w *userWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return obj.(*User).ValidateDelete()
+}
+
+// validatePasswordHash checks the format of the password hash against the authentication plugin. The Secret may be created
+// after the User, in which case the hash is validated by the controller when reconciling the User.
+func (w *userWebhook) validatePasswordHash(ctx context.Context, user *User) error {
+	ref := user.Spec.PasswordHashSecretKeyRef
+	if ref == nil {
+		return nil
+	}
+	var secret corev1.Secret
+	key := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: requestNamespace(ctx, user),
+	}
+	if err := w.client.Get(ctx, key, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error getting password hash Secret: %v", err)
+	}
+	hash, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil
+	}
+	if err := user.Spec.AuthenticationPlugin.ValidatePasswordHash(string(hash)); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("passwordHashSecretKeyRef"),
+			ref,
+			fmt.Sprintf("invalid password hash: %v", err),
+		)
+	}
+	return nil
+}
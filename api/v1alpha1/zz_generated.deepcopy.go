@@ -32,12 +32,54 @@ package v1alpha1
 import (
 	"github.com/mariadb-operator/agent/pkg/galera"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionRateLimit) DeepCopyInto(out *ActionRateLimit) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionRateLimit.
+func (in *ActionRateLimit) DeepCopy() *ActionRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureBlob) DeepCopyInto(out *AzureBlob) {
+	*out = *in
+	if in.AccountKeySecretKeyRef != nil {
+		in, out := &in.AccountKeySecretKeyRef, &out.AccountKeySecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBlob.
+func (in *AzureBlob) DeepCopy() *AzureBlob {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureBlob)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
@@ -65,6 +107,101 @@ func (in *Backup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupArtifact) DeepCopyInto(out *BackupArtifact) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.RestoreLatency != nil {
+		in, out := &in.RestoreLatency, &out.RestoreLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupArtifact.
+func (in *BackupArtifact) DeepCopy() *BackupArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCompression) DeepCopyInto(out *BackupCompression) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCompression.
+func (in *BackupCompression) DeepCopy() *BackupCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHook) DeepCopyInto(out *BackupHook) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(v1.ExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHook.
+func (in *BackupHook) DeepCopy() *BackupHook {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHooks) DeepCopyInto(out *BackupHooks) {
+	*out = *in
+	if in.BeforeBackup != nil {
+		in, out := &in.BeforeBackup, &out.BeforeBackup
+		*out = make([]BackupHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AfterBackup != nil {
+		in, out := &in.AfterBackup, &out.AfterBackup
+		*out = make([]BackupHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHooks.
+func (in *BackupHooks) DeepCopy() *BackupHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupList) DeepCopyInto(out *BackupList) {
 	*out = *in
@@ -97,6 +234,45 @@ func (in *BackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRequirements) DeepCopyInto(out *BackupRequirements) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRequirements.
+func (in *BackupRequirements) DeepCopy() *BackupRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetentionStatus) DeepCopyInto(out *BackupRetentionStatus) {
+	*out = *in
+	if in.LastPruneTime != nil {
+		in, out := &in.LastPruneTime, &out.LastPruneTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PrunedFiles != nil {
+		in, out := &in.PrunedFiles, &out.PrunedFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetentionStatus.
+func (in *BackupRetentionStatus) DeepCopy() *BackupRetentionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetentionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 	*out = *in
@@ -107,12 +283,64 @@ func (in *BackupSpec) DeepCopyInto(out *BackupSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(BackupCompression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(BackupHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Schedule != nil {
 		in, out := &in.Schedule, &out.Schedule
 		*out = new(Schedule)
 		**out = **in
 	}
 	out.MaxRetention = in.MaxRetention
+	if in.MaxBackups != nil {
+		in, out := &in.MaxBackups, &out.MaxBackups
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Tiering != nil {
+		in, out := &in.Tiering, &out.Tiering
+		*out = make([]BackupTieringRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = new(batchv1.PodFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
@@ -159,6 +387,18 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetentionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]BackupArtifact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -179,6 +419,16 @@ func (in *BackupStorage) DeepCopyInto(out *BackupStorage) {
 		*out = new(S3)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(AzureBlob)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PersistentVolumeClaim != nil {
 		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
 		*out = new(v1.PersistentVolumeClaimSpec)
@@ -201,6 +451,53 @@ func (in *BackupStorage) DeepCopy() *BackupStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTieringRule) DeepCopyInto(out *BackupTieringRule) {
+	*out = *in
+	out.MinAge = in.MinAge
+	if in.RestoreLatency != nil {
+		in, out := &in.RestoreLatency, &out.RestoreLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTieringRule.
+func (in *BackupTieringRule) DeepCopy() *BackupTieringRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTieringRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinlogArchive) DeepCopyInto(out *BinlogArchive) {
+	*out = *in
+	in.S3.DeepCopyInto(&out.S3)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogArchive.
+func (in *BinlogArchive) DeepCopy() *BinlogArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(BinlogArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Connection) DeepCopyInto(out *Connection) {
 	*out = *in
@@ -228,6 +525,31 @@ func (in *Connection) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDraining) DeepCopyInto(out *ConnectionDraining) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExemptUsers != nil {
+		in, out := &in.ExemptUsers, &out.ExemptUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDraining.
+func (in *ConnectionDraining) DeepCopy() *ConnectionDraining {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDraining)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionList) DeepCopyInto(out *ConnectionList) {
 	*out = *in
@@ -363,6 +685,49 @@ func (in *Container) DeepCopy() *Container {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRightSizing) DeepCopyInto(out *ContainerRightSizing) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.PeakUsage != nil {
+		in, out := &in.PeakUsage, &out.PeakUsage
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.PreviousPeakUsage != nil {
+		in, out := &in.PreviousPeakUsage, &out.PreviousPeakUsage
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Recommendation != nil {
+		in, out := &in.Recommendation, &out.Recommendation
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRightSizing.
+func (in *ContainerRightSizing) DeepCopy() *ContainerRightSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRightSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerTemplate) DeepCopyInto(out *ContainerTemplate) {
 	*out = *in
@@ -429,6 +794,31 @@ func (in *ContainerTemplate) DeepCopy() *ContainerTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashDiagnostics) DeepCopyInto(out *CrashDiagnostics) {
+	*out = *in
+	if in.LogTailLines != nil {
+		in, out := &in.LogTailLines, &out.LogTailLines
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashDiagnostics.
+func (in *CrashDiagnostics) DeepCopy() *CrashDiagnostics {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashDiagnostics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Database) DeepCopyInto(out *Database) {
 	*out = *in
@@ -457,17 +847,42 @@ func (in *Database) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatabaseList) DeepCopyInto(out *DatabaseList) {
+func (in *DatabaseInitSQL) DeepCopyInto(out *DatabaseInitSQL) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Database, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInitSQL.
+func (in *DatabaseInitSQL) DeepCopy() *DatabaseInitSQL {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInitSQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseList) DeepCopyInto(out *DatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Database, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseList.
@@ -488,11 +903,37 @@ func (in *DatabaseList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseQuota) DeepCopyInto(out *DatabaseQuota) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseQuota.
+func (in *DatabaseQuota) DeepCopy() *DatabaseQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 	*out = *in
 	in.SQLTemplate.DeepCopyInto(&out.SQLTemplate)
 	out.MariaDBRef = in.MariaDBRef
+	if in.InitSQL != nil {
+		in, out := &in.InitSQL, &out.InitSQL
+		*out = new(DatabaseInitSQL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(DatabaseQuota)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
@@ -515,6 +956,16 @@ func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.QuotaRevokedAccounts != nil {
+		in, out := &in.QuotaRevokedAccounts, &out.QuotaRevokedAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
@@ -527,6 +978,22 @@ func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptiveAction) DeepCopyInto(out *DisruptiveAction) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptiveAction.
+func (in *DisruptiveAction) DeepCopy() *DisruptiveAction {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptiveAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Exporter) DeepCopyInto(out *Exporter) {
 	*out = *in
@@ -543,6 +1010,112 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalReplication) DeepCopyInto(out *ExternalReplication) {
+	*out = *in
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalReplicationTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GtidDomainId != nil {
+		in, out := &in.GtidDomainId, &out.GtidDomainId
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalReplication.
+func (in *ExternalReplication) DeepCopy() *ExternalReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalReplicationTLS) DeepCopyInto(out *ExternalReplicationTLS) {
+	*out = *in
+	if in.CASecretKeyRef != nil {
+		in, out := &in.CASecretKeyRef, &out.CASecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalReplicationTLS.
+func (in *ExternalReplicationTLS) DeepCopy() *ExternalReplicationTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalReplicationTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fencing) DeepCopyInto(out *Fencing) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fencing.
+func (in *Fencing) DeepCopy() *Fencing {
+	if in == nil {
+		return nil
+	}
+	out := new(Fencing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldChange) DeepCopyInto(out *FieldChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldChange.
+func (in *FieldChange) DeepCopy() *FieldChange {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCS) DeepCopyInto(out *GCS) {
+	*out = *in
+	if in.CredentialsSecretKeyRef != nil {
+		in, out := &in.CredentialsSecretKeyRef, &out.CredentialsSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCS.
+func (in *GCS) DeepCopy() *GCS {
+	if in == nil {
+		return nil
+	}
+	out := new(GCS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Galera) DeepCopyInto(out *Galera) {
 	*out = *in
@@ -578,6 +1151,11 @@ func (in *GaleraAgent) DeepCopyInto(out *GaleraAgent) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.WsrepNotify != nil {
+		in, out := &in.WsrepNotify, &out.WsrepNotify
+		*out = new(WsrepNotify)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraAgent.
@@ -590,6 +1168,103 @@ func (in *GaleraAgent) DeepCopy() *GaleraAgent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraArbitrator) DeepCopyInto(out *GaleraArbitrator) {
+	*out = *in
+	in.ContainerTemplate.DeepCopyInto(&out.ContainerTemplate)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraArbitrator.
+func (in *GaleraArbitrator) DeepCopy() *GaleraArbitrator {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraArbitrator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraArbitratorStatus) DeepCopyInto(out *GaleraArbitratorStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraArbitratorStatus.
+func (in *GaleraArbitratorStatus) DeepCopy() *GaleraArbitratorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraArbitratorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraConfigDrift) DeepCopyInto(out *GaleraConfigDrift) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraConfigDrift.
+func (in *GaleraConfigDrift) DeepCopy() *GaleraConfigDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraConfigDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraGCache) DeepCopyInto(out *GaleraGCache) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Recover != nil {
+		in, out := &in.Recover, &out.Recover
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraGCache.
+func (in *GaleraGCache) DeepCopy() *GaleraGCache {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraGCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GaleraRecovery) DeepCopyInto(out *GaleraRecovery) {
 	*out = *in
@@ -649,6 +1324,46 @@ func (in *GaleraRecoveryBootstrap) DeepCopy() *GaleraRecoveryBootstrap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraRecoveryPlan) DeepCopyInto(out *GaleraRecoveryPlan) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]GaleraRecoveryPlanPod, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraRecoveryPlan.
+func (in *GaleraRecoveryPlan) DeepCopy() *GaleraRecoveryPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraRecoveryPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraRecoveryPlanPod) DeepCopyInto(out *GaleraRecoveryPlanPod) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraRecoveryPlanPod.
+func (in *GaleraRecoveryPlanPod) DeepCopy() *GaleraRecoveryPlanPod {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraRecoveryPlanPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GaleraRecoveryStatus) DeepCopyInto(out *GaleraRecoveryStatus) {
 	*out = *in
@@ -687,6 +1402,11 @@ func (in *GaleraRecoveryStatus) DeepCopyInto(out *GaleraRecoveryStatus) {
 		*out = new(GaleraRecoveryBootstrap)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(GaleraRecoveryPlan)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraRecoveryStatus.
@@ -699,6 +1419,28 @@ func (in *GaleraRecoveryStatus) DeepCopy() *GaleraRecoveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GaleraSegment) DeepCopyInto(out *GaleraSegment) {
+	*out = *in
+	if in.Segments != nil {
+		in, out := &in.Segments, &out.Segments
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraSegment.
+func (in *GaleraSegment) DeepCopy() *GaleraSegment {
+	if in == nil {
+		return nil
+	}
+	out := new(GaleraSegment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GaleraSpec) DeepCopyInto(out *GaleraSpec) {
 	*out = *in
@@ -717,10 +1459,17 @@ func (in *GaleraSpec) DeepCopyInto(out *GaleraSpec) {
 		*out = new(int)
 		**out = **in
 	}
-	if in.Agent != nil {
-		in, out := &in.Agent, &out.Agent
-		*out = new(GaleraAgent)
-		(*in).DeepCopyInto(*out)
+	if in.ProviderOptions != nil {
+		in, out := &in.ProviderOptions, &out.ProviderOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(GaleraAgent)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Recovery != nil {
 		in, out := &in.Recovery, &out.Recovery
@@ -737,6 +1486,21 @@ func (in *GaleraSpec) DeepCopyInto(out *GaleraSpec) {
 		*out = new(VolumeClaimTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Arbitrator != nil {
+		in, out := &in.Arbitrator, &out.Arbitrator
+		*out = new(GaleraArbitrator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Segment != nil {
+		in, out := &in.Segment, &out.Segment
+		*out = new(GaleraSegment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCache != nil {
+		in, out := &in.GCache, &out.GCache
+		*out = new(GaleraGCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraSpec.
@@ -857,6 +1621,32 @@ func (in *GrantStatus) DeepCopy() *GrantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GtidStatus) DeepCopyInto(out *GtidStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make(map[string]ReplicaGtidStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GtidStatus.
+func (in *GtidStatus) DeepCopy() *GtidStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GtidStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -870,6 +1660,21 @@ func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
@@ -882,6 +1687,92 @@ func (in *HealthCheck) DeepCopy() *HealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hibernate) DeepCopyInto(out *Hibernate) {
+	*out = *in
+	if in.FinalBackup != nil {
+		in, out := &in.FinalBackup, &out.FinalBackup
+		*out = new(HibernateBackup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hibernate.
+func (in *Hibernate) DeepCopy() *Hibernate {
+	if in == nil {
+		return nil
+	}
+	out := new(Hibernate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernateBackup) DeepCopyInto(out *HibernateBackup) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(BackupCompression)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernateBackup.
+func (in *HibernateBackup) DeepCopy() *HibernateBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernateBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationStatus) DeepCopyInto(out *HibernationStatus) {
+	*out = *in
+	if in.FinalBackup != nil {
+		in, out := &in.FinalBackup, &out.FinalBackup
+		*out = new(string)
+		**out = **in
+	}
+	if in.HibernatedAt != nil {
+		in, out := &in.HibernatedAt, &out.HibernatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationStatus.
+func (in *HibernationStatus) DeepCopy() *HibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistoryEntry) DeepCopyInto(out *HistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]FieldChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistoryEntry.
+func (in *HistoryEntry) DeepCopy() *HistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(HistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InheritMetadata) DeepCopyInto(out *InheritMetadata) {
 	*out = *in
@@ -911,6 +1802,21 @@ func (in *InheritMetadata) DeepCopy() *InheritMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerRef) DeepCopyInto(out *IssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerRef.
+func (in *IssuerRef) DeepCopy() *IssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesAuth) DeepCopyInto(out *KubernetesAuth) {
 	*out = *in
@@ -927,7 +1833,7 @@ func (in *KubernetesAuth) DeepCopy() *KubernetesAuth {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MariaDB) DeepCopyInto(out *MariaDB) {
+func (in *MaintenanceJob) DeepCopyInto(out *MaintenanceJob) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -935,18 +1841,18 @@ func (in *MariaDB) DeepCopyInto(out *MariaDB) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDB.
-func (in *MariaDB) DeepCopy() *MariaDB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceJob.
+func (in *MaintenanceJob) DeepCopy() *MaintenanceJob {
 	if in == nil {
 		return nil
 	}
-	out := new(MariaDB)
+	out := new(MaintenanceJob)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MariaDB) DeepCopyObject() runtime.Object {
+func (in *MaintenanceJob) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -954,31 +1860,31 @@ func (in *MariaDB) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MariaDBList) DeepCopyInto(out *MariaDBList) {
+func (in *MaintenanceJobList) DeepCopyInto(out *MaintenanceJobList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MariaDB, len(*in))
+		*out = make([]MaintenanceJob, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBList.
-func (in *MariaDBList) DeepCopy() *MariaDBList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceJobList.
+func (in *MaintenanceJobList) DeepCopy() *MaintenanceJobList {
 	if in == nil {
 		return nil
 	}
-	out := new(MariaDBList)
+	out := new(MaintenanceJobList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MariaDBList) DeepCopyObject() runtime.Object {
+func (in *MaintenanceJobList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -986,144 +1892,62 @@ func (in *MariaDBList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MariaDBRef) DeepCopyInto(out *MariaDBRef) {
-	*out = *in
-	out.ObjectReference = in.ObjectReference
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBRef.
-func (in *MariaDBRef) DeepCopy() *MariaDBRef {
-	if in == nil {
-		return nil
-	}
-	out := new(MariaDBRef)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MariaDBSpec) DeepCopyInto(out *MariaDBSpec) {
+func (in *MaintenanceJobSpec) DeepCopyInto(out *MaintenanceJobSpec) {
 	*out = *in
-	in.ContainerTemplate.DeepCopyInto(&out.ContainerTemplate)
-	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+	out.MariaDBRef = in.MariaDBRef
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.InheritMetadata != nil {
-		in, out := &in.InheritMetadata, &out.InheritMetadata
-		*out = new(InheritMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	in.RootPasswordSecretKeyRef.DeepCopyInto(&out.RootPasswordSecretKeyRef)
-	if in.Database != nil {
-		in, out := &in.Database, &out.Database
-		*out = new(string)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
 		**out = **in
 	}
-	if in.Username != nil {
-		in, out := &in.Username, &out.Username
-		*out = new(string)
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.PasswordSecretKeyRef != nil {
-		in, out := &in.PasswordSecretKeyRef, &out.PasswordSecretKeyRef
-		*out = new(v1.SecretKeySelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.MyCnf != nil {
-		in, out := &in.MyCnf, &out.MyCnf
-		*out = new(string)
-		**out = **in
-	}
-	if in.MyCnfConfigMapKeyRef != nil {
-		in, out := &in.MyCnfConfigMapKeyRef, &out.MyCnfConfigMapKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.PodAnnotations != nil {
-		in, out := &in.PodAnnotations, &out.PodAnnotations
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.BootstrapFrom != nil {
-		in, out := &in.BootstrapFrom, &out.BootstrapFrom
-		*out = new(RestoreSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(Metrics)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Replication != nil {
-		in, out := &in.Replication, &out.Replication
-		*out = new(Replication)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Galera != nil {
-		in, out := &in.Galera, &out.Galera
-		*out = new(Galera)
-		(*in).DeepCopyInto(*out)
-	}
-	in.VolumeClaimTemplate.DeepCopyInto(&out.VolumeClaimTemplate)
-	if in.PodDisruptionBudget != nil {
-		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
-		*out = new(PodDisruptionBudget)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.UpdateStrategy != nil {
-		in, out := &in.UpdateStrategy, &out.UpdateStrategy
-		*out = new(appsv1.StatefulSetUpdateStrategy)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Service != nil {
-		in, out := &in.Service, &out.Service
-		*out = new(ServiceTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(ConnectionTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PrimaryService != nil {
-		in, out := &in.PrimaryService, &out.PrimaryService
-		*out = new(ServiceTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PrimaryConnection != nil {
-		in, out := &in.PrimaryConnection, &out.PrimaryConnection
-		*out = new(ConnectionTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecondaryService != nil {
-		in, out := &in.SecondaryService, &out.SecondaryService
-		*out = new(ServiceTemplate)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecondaryConnection != nil {
-		in, out := &in.SecondaryConnection, &out.SecondaryConnection
-		*out = new(ConnectionTemplate)
-		(*in).DeepCopyInto(*out)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBSpec.
-func (in *MariaDBSpec) DeepCopy() *MariaDBSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceJobSpec.
+func (in *MaintenanceJobSpec) DeepCopy() *MaintenanceJobSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MariaDBSpec)
+	out := new(MaintenanceJobSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MariaDBStatus) DeepCopyInto(out *MariaDBStatus) {
+func (in *MaintenanceJobStatus) DeepCopyInto(out *MaintenanceJobStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1132,339 +1956,2208 @@ func (in *MariaDBStatus) DeepCopyInto(out *MariaDBStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.CurrentPrimaryPodIndex != nil {
-		in, out := &in.CurrentPrimaryPodIndex, &out.CurrentPrimaryPodIndex
-		*out = new(int)
-		**out = **in
-	}
-	if in.CurrentPrimary != nil {
-		in, out := &in.CurrentPrimary, &out.CurrentPrimary
-		*out = new(string)
-		**out = **in
-	}
-	if in.GaleraRecovery != nil {
-		in, out := &in.GaleraRecovery, &out.GaleraRecovery
-		*out = new(GaleraRecoveryStatus)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBStatus.
-func (in *MariaDBStatus) DeepCopy() *MariaDBStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceJobStatus.
+func (in *MaintenanceJobStatus) DeepCopy() *MaintenanceJobStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MariaDBStatus)
+	out := new(MaintenanceJobStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metrics) DeepCopyInto(out *Metrics) {
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
 	*out = *in
-	in.Exporter.DeepCopyInto(&out.Exporter)
-	out.ServiceMonitor = in.ServiceMonitor
-	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	out.Duration = in.Duration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
-func (in *Metrics) DeepCopy() *Metrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(Metrics)
+	out := new(MaintenanceWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+func (in *MariaDB) DeepCopyInto(out *MariaDB) {
 	*out = *in
-	if in.MinAvailable != nil {
-		in, out := &in.MinAvailable, &out.MinAvailable
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
-func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDB.
+func (in *MariaDB) DeepCopy() *MariaDB {
 	if in == nil {
 		return nil
 	}
-	out := new(PodDisruptionBudget)
+	out := new(MariaDB)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDB) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+func (in *MariaDBDefaults) DeepCopyInto(out *MariaDBDefaults) {
 	*out = *in
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.SidecarContainers != nil {
-		in, out := &in.SidecarContainers, &out.SidecarContainers
-		*out = make([]Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
 	}
-	if in.PodSecurityContext != nil {
-		in, out := &in.PodSecurityContext, &out.PodSecurityContext
-		*out = new(v1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
-func (in *PodTemplate) DeepCopy() *PodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBDefaults.
+func (in *MariaDBDefaults) DeepCopy() *MariaDBDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplate)
+	out := new(MariaDBDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrimaryGalera) DeepCopyInto(out *PrimaryGalera) {
+func (in *MariaDBFleet) DeepCopyInto(out *MariaDBFleet) {
 	*out = *in
-	if in.PodIndex != nil {
-		in, out := &in.PodIndex, &out.PodIndex
-		*out = new(int)
-		**out = **in
-	}
-	if in.AutomaticFailover != nil {
-		in, out := &in.AutomaticFailover, &out.AutomaticFailover
-		*out = new(bool)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryGalera.
-func (in *PrimaryGalera) DeepCopy() *PrimaryGalera {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleet.
+func (in *MariaDBFleet) DeepCopy() *MariaDBFleet {
 	if in == nil {
 		return nil
 	}
-	out := new(PrimaryGalera)
+	out := new(MariaDBFleet)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBFleet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrimaryReplication) DeepCopyInto(out *PrimaryReplication) {
+func (in *MariaDBFleetList) DeepCopyInto(out *MariaDBFleetList) {
 	*out = *in
-	if in.PodIndex != nil {
-		in, out := &in.PodIndex, &out.PodIndex
-		*out = new(int)
-		**out = **in
-	}
-	if in.AutomaticFailover != nil {
-		in, out := &in.AutomaticFailover, &out.AutomaticFailover
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MariaDBFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryReplication.
-func (in *PrimaryReplication) DeepCopy() *PrimaryReplication {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetList.
+func (in *MariaDBFleetList) DeepCopy() *MariaDBFleetList {
 	if in == nil {
 		return nil
 	}
-	out := new(PrimaryReplication)
+	out := new(MariaDBFleetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicaReplication) DeepCopyInto(out *ReplicaReplication) {
+func (in *MariaDBFleetMember) DeepCopyInto(out *MariaDBFleetMember) {
 	*out = *in
-	if in.WaitPoint != nil {
-		in, out := &in.WaitPoint, &out.WaitPoint
-		*out = new(WaitPoint)
-		**out = **in
-	}
-	if in.Gtid != nil {
-		in, out := &in.Gtid, &out.Gtid
-		*out = new(Gtid)
-		**out = **in
-	}
-	if in.ReplPasswordSecretKeyRef != nil {
-		in, out := &in.ReplPasswordSecretKeyRef, &out.ReplPasswordSecretKeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ConnectionTimeout != nil {
-		in, out := &in.ConnectionTimeout, &out.ConnectionTimeout
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.ConnectionRetries != nil {
-		in, out := &in.ConnectionRetries, &out.ConnectionRetries
-		*out = new(int)
-		**out = **in
-	}
-	if in.SyncTimeout != nil {
-		in, out := &in.SyncTimeout, &out.SyncTimeout
-		*out = new(metav1.Duration)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaReplication.
-func (in *ReplicaReplication) DeepCopy() *ReplicaReplication {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetMember.
+func (in *MariaDBFleetMember) DeepCopy() *MariaDBFleetMember {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicaReplication)
+	out := new(MariaDBFleetMember)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Replication) DeepCopyInto(out *Replication) {
+func (in *MariaDBFleetMemberStatus) DeepCopyInto(out *MariaDBFleetMemberStatus) {
 	*out = *in
-	in.ReplicationSpec.DeepCopyInto(&out.ReplicationSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Replication.
-func (in *Replication) DeepCopy() *Replication {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetMemberStatus.
+func (in *MariaDBFleetMemberStatus) DeepCopy() *MariaDBFleetMemberStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Replication)
+	out := new(MariaDBFleetMemberStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSpec) DeepCopyInto(out *ReplicationSpec) {
+func (in *MariaDBFleetRolloutStrategy) DeepCopyInto(out *MariaDBFleetRolloutStrategy) {
 	*out = *in
-	if in.Primary != nil {
-		in, out := &in.Primary, &out.Primary
-		*out = new(PrimaryReplication)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Replica != nil {
-		in, out := &in.Replica, &out.Replica
-		*out = new(ReplicaReplication)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SyncBinlog != nil {
-		in, out := &in.SyncBinlog, &out.SyncBinlog
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSpec.
-func (in *ReplicationSpec) DeepCopy() *ReplicationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetRolloutStrategy.
+func (in *MariaDBFleetRolloutStrategy) DeepCopy() *MariaDBFleetRolloutStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSpec)
+	out := new(MariaDBFleetRolloutStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Restore) DeepCopyInto(out *Restore) {
+func (in *MariaDBFleetSpec) DeepCopyInto(out *MariaDBFleetSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]MariaDBFleetMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.RolloutStrategy = in.RolloutStrategy
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
-func (in *Restore) DeepCopy() *Restore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetSpec.
+func (in *MariaDBFleetSpec) DeepCopy() *MariaDBFleetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Restore)
+	out := new(MariaDBFleetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Restore) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreList) DeepCopyInto(out *RestoreList) {
+func (in *MariaDBFleetStatus) DeepCopyInto(out *MariaDBFleetStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Restore, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]MariaDBFleetMemberStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreList.
-func (in *RestoreList) DeepCopy() *RestoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetStatus.
+func (in *MariaDBFleetStatus) DeepCopy() *MariaDBFleetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreList)
+	out := new(MariaDBFleetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestoreList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBFleetTemplate) DeepCopyInto(out *MariaDBFleetTemplate) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(InheritMetadata)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
-	*out = *in
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBFleetTemplate.
+func (in *MariaDBFleetTemplate) DeepCopy() *MariaDBFleetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBFleetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBLimits) DeepCopyInto(out *MariaDBLimits) {
+	*out = *in
+	if in.MaxStorage != nil {
+		in, out := &in.MaxStorage, &out.MaxStorage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AllowedStorageClasses != nil {
+		in, out := &in.AllowedStorageClasses, &out.AllowedStorageClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBLimits.
+func (in *MariaDBLimits) DeepCopy() *MariaDBLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBList) DeepCopyInto(out *MariaDBList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MariaDB, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBList.
+func (in *MariaDBList) DeepCopy() *MariaDBList {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBNamespacePolicy) DeepCopyInto(out *MariaDBNamespacePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBNamespacePolicy.
+func (in *MariaDBNamespacePolicy) DeepCopy() *MariaDBNamespacePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBNamespacePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBNamespacePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBNamespacePolicyList) DeepCopyInto(out *MariaDBNamespacePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MariaDBNamespacePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBNamespacePolicyList.
+func (in *MariaDBNamespacePolicyList) DeepCopy() *MariaDBNamespacePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBNamespacePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBNamespacePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBNamespacePolicySpec) DeepCopyInto(out *MariaDBNamespacePolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(MariaDBDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(MariaDBLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupRequirements)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBNamespacePolicySpec.
+func (in *MariaDBNamespacePolicySpec) DeepCopy() *MariaDBNamespacePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBNamespacePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBRef) DeepCopyInto(out *MariaDBRef) {
+	*out = *in
+	out.ObjectReference = in.ObjectReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBRef.
+func (in *MariaDBRef) DeepCopy() *MariaDBRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBSpec) DeepCopyInto(out *MariaDBSpec) {
+	*out = *in
+	in.ContainerTemplate.DeepCopyInto(&out.ContainerTemplate)
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.InheritMetadata != nil {
+		in, out := &in.InheritMetadata, &out.InheritMetadata
+		*out = new(InheritMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	in.RootPasswordSecretKeyRef.DeepCopyInto(&out.RootPasswordSecretKeyRef)
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotation)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecretKeyRef != nil {
+		in, out := &in.PasswordSecretKeyRef, &out.PasswordSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MyCnf != nil {
+		in, out := &in.MyCnf, &out.MyCnf
+		*out = new(string)
+		**out = **in
+	}
+	if in.MyCnfConfigMapKeyRef != nil {
+		in, out := &in.MyCnfConfigMapKeyRef, &out.MyCnfConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BootstrapFrom != nil {
+		in, out := &in.BootstrapFrom, &out.BootstrapFrom
+		*out = new(RestoreSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SeedData != nil {
+		in, out := &in.SeedData, &out.SeedData
+		*out = new(SeedData)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(Metrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(Replication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationChannels != nil {
+		in, out := &in.ReplicationChannels, &out.ReplicationChannels
+		*out = make([]ReplicationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Galera != nil {
+		in, out := &in.Galera, &out.Galera
+		*out = new(Galera)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernate != nil {
+		in, out := &in.Hibernate, &out.Hibernate
+		*out = new(Hibernate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartedAt != nil {
+		in, out := &in.RestartedAt, &out.RestartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MariaDBTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScheduledScaling != nil {
+		in, out := &in.ScheduledScaling, &out.ScheduledScaling
+		*out = make([]ScheduledScaling, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActionRateLimit != nil {
+		in, out := &in.ActionRateLimit, &out.ActionRateLimit
+		*out = new(ActionRateLimit)
+		**out = **in
+	}
+	in.VolumeClaimTemplate.DeepCopyInto(&out.VolumeClaimTemplate)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(appsv1.StatefulSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(Notifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(ConnectionTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrimaryService != nil {
+		in, out := &in.PrimaryService, &out.PrimaryService
+		*out = new(ServiceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrimaryConnection != nil {
+		in, out := &in.PrimaryConnection, &out.PrimaryConnection
+		*out = new(ConnectionTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryService != nil {
+		in, out := &in.SecondaryService, &out.SecondaryService
+		*out = new(ServiceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryServices != nil {
+		in, out := &in.SecondaryServices, &out.SecondaryServices
+		*out = make([]SecondaryService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(WarmUp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePreflight != nil {
+		in, out := &in.UpgradePreflight, &out.UpgradePreflight
+		*out = new(UpgradePreflight)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RightSizing != nil {
+		in, out := &in.RightSizing, &out.RightSizing
+		*out = new(RightSizing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecondaryConnection != nil {
+		in, out := &in.SecondaryConnection, &out.SecondaryConnection
+		*out = new(ConnectionTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CrashDiagnostics != nil {
+		in, out := &in.CrashDiagnostics, &out.CrashDiagnostics
+		*out = new(CrashDiagnostics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionPolicy != nil {
+		in, out := &in.SessionPolicy, &out.SessionPolicy
+		*out = new(SessionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BinlogArchive != nil {
+		in, out := &in.BinlogArchive, &out.BinlogArchive
+		*out = new(BinlogArchive)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorAccount != nil {
+		in, out := &in.OperatorAccount, &out.OperatorAccount
+		*out = new(OperatorAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProbeAccount != nil {
+		in, out := &in.ProbeAccount, &out.ProbeAccount
+		*out = new(ProbeAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spider != nil {
+		in, out := &in.Spider, &out.Spider
+		*out = new(Spider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(Naming)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBSpec.
+func (in *MariaDBSpec) DeepCopy() *MariaDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBStatus) DeepCopyInto(out *MariaDBStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CurrentPrimaryPodIndex != nil {
+		in, out := &in.CurrentPrimaryPodIndex, &out.CurrentPrimaryPodIndex
+		*out = new(int)
+		**out = **in
+	}
+	if in.CurrentPrimary != nil {
+		in, out := &in.CurrentPrimary, &out.CurrentPrimary
+		*out = new(string)
+		**out = **in
+	}
+	if in.Switchover != nil {
+		in, out := &in.Switchover, &out.Switchover
+		*out = new(SwitchoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GaleraRecovery != nil {
+		in, out := &in.GaleraRecovery, &out.GaleraRecovery
+		*out = new(GaleraRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GaleraNodeStates != nil {
+		in, out := &in.GaleraNodeStates, &out.GaleraNodeStates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GaleraConfigDrift != nil {
+		in, out := &in.GaleraConfigDrift, &out.GaleraConfigDrift
+		*out = make([]GaleraConfigDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GaleraArbitrator != nil {
+		in, out := &in.GaleraArbitrator, &out.GaleraArbitrator
+		*out = new(GaleraArbitratorStatus)
+		**out = **in
+	}
+	if in.Gtid != nil {
+		in, out := &in.Gtid, &out.Gtid
+		*out = new(GtidStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationChannels != nil {
+		in, out := &in.ReplicationChannels, &out.ReplicationChannels
+		*out = new(ReplicationChannelsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SemiSync != nil {
+		in, out := &in.SemiSync, &out.SemiSync
+		*out = new(SemiSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]HistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AuditedFields != nil {
+		in, out := &in.AuditedFields, &out.AuditedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OperatorAccount != nil {
+		in, out := &in.OperatorAccount, &out.OperatorAccount
+		*out = new(OperatorAccountStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProbeAccount != nil {
+		in, out := &in.ProbeAccount, &out.ProbeAccount
+		*out = new(ProbeAccountStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsPasswordRotationTime != nil {
+		in, out := &in.MetricsPasswordRotationTime, &out.MetricsPasswordRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PasswordLastRotated != nil {
+		in, out := &in.PasswordLastRotated, &out.PasswordLastRotated
+		*out = (*in).DeepCopy()
+	}
+	if in.SpiderServers != nil {
+		in, out := &in.SpiderServers, &out.SpiderServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SessionPolicyVariables != nil {
+		in, out := &in.SessionPolicyVariables, &out.SessionPolicyVariables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScheduledScaling != nil {
+		in, out := &in.ScheduledScaling, &out.ScheduledScaling
+		*out = new(ScheduledScalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RollingRestart != nil {
+		in, out := &in.RollingRestart, &out.RollingRestart
+		*out = new(RollingRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptiveActions != nil {
+		in, out := &in.DisruptiveActions, &out.DisruptiveActions
+		*out = make([]DisruptiveAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradePreflight != nil {
+		in, out := &in.UpgradePreflight, &out.UpgradePreflight
+		*out = new(UpgradePreflightStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RightSizing != nil {
+		in, out := &in.RightSizing, &out.RightSizing
+		*out = new(RightSizingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MariaDBTLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBStatus.
+func (in *MariaDBStatus) DeepCopy() *MariaDBStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBTLS) DeepCopyInto(out *MariaDBTLS) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(IssuerRef)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBTLS.
+func (in *MariaDBTLS) DeepCopy() *MariaDBTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBTLSStatus) DeepCopyInto(out *MariaDBTLSStatus) {
+	*out = *in
+	if in.CertNotAfter != nil {
+		in, out := &in.CertNotAfter, &out.CertNotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.ReloadedAt != nil {
+		in, out := &in.ReloadedAt, &out.ReloadedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBTLSStatus.
+func (in *MariaDBTLSStatus) DeepCopy() *MariaDBTLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBTLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBTest) DeepCopyInto(out *MariaDBTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBTest.
+func (in *MariaDBTest) DeepCopy() *MariaDBTest {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBTestList) DeepCopyInto(out *MariaDBTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MariaDBTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBTestList.
+func (in *MariaDBTestList) DeepCopy() *MariaDBTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MariaDBTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBTestSpec) DeepCopyInto(out *MariaDBTestSpec) {
+	*out = *in
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.MyCnf != nil {
+		in, out := &in.MyCnf, &out.MyCnf
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBTestSpec.
+func (in *MariaDBTestSpec) DeepCopy() *MariaDBTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBTestStatus) DeepCopyInto(out *MariaDBTestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SecretName != nil {
+		in, out := &in.SecretName, &out.SecretName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBTestStatus.
+func (in *MariaDBTestStatus) DeepCopy() *MariaDBTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScale) DeepCopyInto(out *MaxScale) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScale.
+func (in *MaxScale) DeepCopy() *MaxScale {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaxScale) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleAuth) DeepCopyInto(out *MaxScaleAuth) {
+	*out = *in
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleAuth.
+func (in *MaxScaleAuth) DeepCopy() *MaxScaleAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleCausalReads) DeepCopyInto(out *MaxScaleCausalReads) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleCausalReads.
+func (in *MaxScaleCausalReads) DeepCopy() *MaxScaleCausalReads {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleCausalReads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleList) DeepCopyInto(out *MaxScaleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaxScale, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleList.
+func (in *MaxScaleList) DeepCopy() *MaxScaleList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaxScaleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleListener) DeepCopyInto(out *MaxScaleListener) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleListener.
+func (in *MaxScaleListener) DeepCopy() *MaxScaleListener {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleMonitor) DeepCopyInto(out *MaxScaleMonitor) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleMonitor.
+func (in *MaxScaleMonitor) DeepCopy() *MaxScaleMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleService) DeepCopyInto(out *MaxScaleService) {
+	*out = *in
+	in.Listener.DeepCopyInto(&out.Listener)
+	if in.CausalReads != nil {
+		in, out := &in.CausalReads, &out.CausalReads
+		*out = new(MaxScaleCausalReads)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleService.
+func (in *MaxScaleService) DeepCopy() *MaxScaleService {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleSpec) DeepCopyInto(out *MaxScaleSpec) {
+	*out = *in
+	in.ContainerTemplate.DeepCopyInto(&out.ContainerTemplate)
+	out.MariaDBRef = in.MariaDBRef
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]MaxScaleService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Monitor.DeepCopyInto(&out.Monitor)
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.KubernetesService != nil {
+		in, out := &in.KubernetesService, &out.KubernetesService
+		*out = new(ServiceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleSpec.
+func (in *MaxScaleSpec) DeepCopy() *MaxScaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxScaleStatus) DeepCopyInto(out *MaxScaleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrimaryServer != nil {
+		in, out := &in.PrimaryServer, &out.PrimaryServer
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxScaleStatus.
+func (in *MaxScaleStatus) DeepCopy() *MaxScaleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxScaleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metrics) DeepCopyInto(out *Metrics) {
+	*out = *in
+	in.Exporter.DeepCopyInto(&out.Exporter)
+	out.ServiceMonitor = in.ServiceMonitor
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	if in.PasswordRotationInterval != nil {
+		in, out := &in.PasswordRotationInterval, &out.PasswordRotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
+func (in *Metrics) DeepCopy() *Metrics {
+	if in == nil {
+		return nil
+	}
+	out := new(Metrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Naming) DeepCopyInto(out *Naming) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make(map[NamingResource]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Naming.
+func (in *Naming) DeepCopy() *Naming {
+	if in == nil {
+		return nil
+	}
+	out := new(Naming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTarget) DeepCopyInto(out *NotificationTarget) {
+	*out = *in
+	if in.URLSecretKeyRef != nil {
+		in, out := &in.URLSecretKeyRef, &out.URLSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoutingKeySecretKeyRef != nil {
+		in, out := &in.RoutingKeySecretKeyRef, &out.RoutingKeySecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTarget.
+func (in *NotificationTarget) DeepCopy() *NotificationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notifications) DeepCopyInto(out *Notifications) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]NotificationTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notifications.
+func (in *Notifications) DeepCopy() *Notifications {
+	if in == nil {
+		return nil
+	}
+	out := new(Notifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorAccount) DeepCopyInto(out *OperatorAccount) {
+	*out = *in
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorAccount.
+func (in *OperatorAccount) DeepCopy() *OperatorAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorAccountStatus) DeepCopyInto(out *OperatorAccountStatus) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorAccountStatus.
+func (in *OperatorAccountStatus) DeepCopy() *OperatorAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotation) DeepCopyInto(out *PasswordRotation) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotation.
+func (in *PasswordRotation) DeepCopy() *PasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryGalera) DeepCopyInto(out *PrimaryGalera) {
+	*out = *in
+	if in.PodIndex != nil {
+		in, out := &in.PodIndex, &out.PodIndex
+		*out = new(int)
+		**out = **in
+	}
+	if in.AutomaticFailover != nil {
+		in, out := &in.AutomaticFailover, &out.AutomaticFailover
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryGalera.
+func (in *PrimaryGalera) DeepCopy() *PrimaryGalera {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryGalera)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryReplication) DeepCopyInto(out *PrimaryReplication) {
+	*out = *in
+	if in.PodIndex != nil {
+		in, out := &in.PodIndex, &out.PodIndex
+		*out = new(int)
+		**out = **in
+	}
+	if in.AutomaticFailover != nil {
+		in, out := &in.AutomaticFailover, &out.AutomaticFailover
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailoverDelay != nil {
+		in, out := &in.FailoverDelay, &out.FailoverDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailoverCooldown != nil {
+		in, out := &in.FailoverCooldown, &out.FailoverCooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailoverRetryInterval != nil {
+		in, out := &in.FailoverRetryInterval, &out.FailoverRetryInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ConnectionDraining != nil {
+		in, out := &in.ConnectionDraining, &out.ConnectionDraining
+		*out = new(ConnectionDraining)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fencing != nil {
+		in, out := &in.Fencing, &out.Fencing
+		*out = new(Fencing)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryReplication.
+func (in *PrimaryReplication) DeepCopy() *PrimaryReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeAccount) DeepCopyInto(out *ProbeAccount) {
+	*out = *in
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeAccount.
+func (in *ProbeAccount) DeepCopy() *ProbeAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeAccountStatus) DeepCopyInto(out *ProbeAccountStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RotationStartTime != nil {
+		in, out := &in.RotationStartTime, &out.RotationStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeAccountStatus.
+func (in *ProbeAccountStatus) DeepCopy() *ProbeAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaGtidStatus) DeepCopyInto(out *ReplicaGtidStatus) {
+	*out = *in
+	if in.MissingDomains != nil {
+		in, out := &in.MissingDomains, &out.MissingDomains
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaGtidStatus.
+func (in *ReplicaGtidStatus) DeepCopy() *ReplicaGtidStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaGtidStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaProvisioning) DeepCopyInto(out *ReplicaProvisioning) {
+	*out = *in
+	if in.BackupRef != nil {
+		in, out := &in.BackupRef, &out.BackupRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaProvisioning.
+func (in *ReplicaProvisioning) DeepCopy() *ReplicaProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaReplication) DeepCopyInto(out *ReplicaReplication) {
+	*out = *in
+	if in.WaitPoint != nil {
+		in, out := &in.WaitPoint, &out.WaitPoint
+		*out = new(WaitPoint)
+		**out = **in
+	}
+	if in.Gtid != nil {
+		in, out := &in.Gtid, &out.Gtid
+		*out = new(Gtid)
+		**out = **in
+	}
+	if in.ReplPasswordSecretKeyRef != nil {
+		in, out := &in.ReplPasswordSecretKeyRef, &out.ReplPasswordSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionTimeout != nil {
+		in, out := &in.ConnectionTimeout, &out.ConnectionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ConnectionRetries != nil {
+		in, out := &in.ConnectionRetries, &out.ConnectionRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.SyncTimeout != nil {
+		in, out := &in.SyncTimeout, &out.SyncTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ParallelThreads != nil {
+		in, out := &in.ParallelThreads, &out.ParallelThreads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ParallelMode != nil {
+		in, out := &in.ParallelMode, &out.ParallelMode
+		*out = new(ParallelMode)
+		**out = **in
+	}
+	if in.ParallelMaxQueued != nil {
+		in, out := &in.ParallelMaxQueued, &out.ParallelMaxQueued
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(ReplicaProvisioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxLag != nil {
+		in, out := &in.MaxLag, &out.MaxLag
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaReplication.
+func (in *ReplicaReplication) DeepCopy() *ReplicaReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+	*out = *in
+	if in.SecondsBehindPrimary != nil {
+		in, out := &in.SecondsBehindPrimary, &out.SecondsBehindPrimary
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
+func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Replication) DeepCopyInto(out *Replication) {
+	*out = *in
+	in.ReplicationSpec.DeepCopyInto(&out.ReplicationSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Replication.
+func (in *Replication) DeepCopy() *Replication {
+	if in == nil {
+		return nil
+	}
+	out := new(Replication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationChannel) DeepCopyInto(out *ReplicationChannel) {
+	*out = *in
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	if in.Gtid != nil {
+		in, out := &in.Gtid, &out.Gtid
+		*out = new(Gtid)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalReplicationTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannel.
+func (in *ReplicationChannel) DeepCopy() *ReplicationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationChannelStatus) DeepCopyInto(out *ReplicationChannelStatus) {
+	*out = *in
+	if in.SecondsBehindSource != nil {
+		in, out := &in.SecondsBehindSource, &out.SecondsBehindSource
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannelStatus.
+func (in *ReplicationChannelStatus) DeepCopy() *ReplicationChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationChannelsStatus) DeepCopyInto(out *ReplicationChannelsStatus) {
+	*out = *in
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]ReplicationChannelStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannelsStatus.
+func (in *ReplicationChannelsStatus) DeepCopy() *ReplicationChannelsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationChannelsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSpec) DeepCopyInto(out *ReplicationSpec) {
+	*out = *in
+	if in.Primary != nil {
+		in, out := &in.Primary, &out.Primary
+		*out = new(PrimaryReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replica != nil {
+		in, out := &in.Replica, &out.Replica
+		*out = new(ReplicaReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SemiSync != nil {
+		in, out := &in.SemiSync, &out.SemiSync
+		*out = new(SemiSyncReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncBinlog != nil {
+		in, out := &in.SyncBinlog, &out.SyncBinlog
+		*out = new(bool)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GtidStrictMode != nil {
+		in, out := &in.GtidStrictMode, &out.GtidStrictMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GtidDomainId != nil {
+		in, out := &in.GtidDomainId, &out.GtidDomainId
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.BinlogFormat != nil {
+		in, out := &in.BinlogFormat, &out.BinlogFormat
+		*out = new(BinlogFormat)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSpec.
+func (in *ReplicationSpec) DeepCopy() *ReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]ReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
+func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restore) DeepCopyInto(out *Restore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
+func (in *Restore) DeepCopy() *Restore {
+	if in == nil {
+		return nil
+	}
+	out := new(Restore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Restore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreBinlogs) DeepCopyInto(out *RestoreBinlogs) {
+	*out = *in
+	in.S3.DeepCopyInto(&out.S3)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreBinlogs.
+func (in *RestoreBinlogs) DeepCopy() *RestoreBinlogs {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreBinlogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreList) DeepCopyInto(out *RestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Restore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreList.
+func (in *RestoreList) DeepCopy() *RestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsal) DeepCopyInto(out *RestoreRehearsal) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsal.
+func (in *RestoreRehearsal) DeepCopy() *RestoreRehearsal {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreRehearsal) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsalList) DeepCopyInto(out *RestoreRehearsalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestoreRehearsal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsalList.
+func (in *RestoreRehearsalList) DeepCopy() *RestoreRehearsalList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreRehearsalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsalSpec) DeepCopyInto(out *RestoreRehearsalSpec) {
+	*out = *in
+	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
+	out.MariaDBRef = in.MariaDBRef
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]RestoreRehearsalValidation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(VolumeClaimTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsalSpec.
+func (in *RestoreRehearsalSpec) DeepCopy() *RestoreRehearsalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsalStatus) DeepCopyInto(out *RestoreRehearsalStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]RestoreRehearsalValidationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsalStatus.
+func (in *RestoreRehearsalStatus) DeepCopy() *RestoreRehearsalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsalValidation) DeepCopyInto(out *RestoreRehearsalValidation) {
+	*out = *in
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expected != nil {
+		in, out := &in.Expected, &out.Expected
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsalValidation.
+func (in *RestoreRehearsalValidation) DeepCopy() *RestoreRehearsalValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsalValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsalValidationResult) DeepCopyInto(out *RestoreRehearsalValidationResult) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsalValidationResult.
+func (in *RestoreRehearsalValidationResult) DeepCopy() *RestoreRehearsalValidationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsalValidationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
+	*out = *in
 	if in.BackupRef != nil {
 		in, out := &in.BackupRef, &out.BackupRef
 		*out = new(v1.LocalObjectReference)
@@ -1475,6 +4168,16 @@ func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
 		*out = new(S3)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(AzureBlob)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volume != nil {
 		in, out := &in.Volume, &out.Volume
 		*out = new(v1.VolumeSource)
@@ -1486,59 +4189,249 @@ func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
-func (in *RestoreSource) DeepCopy() *RestoreSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
+func (in *RestoreSource) DeepCopy() *RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
+	*out = *in
+	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
+	out.MariaDBRef = in.MariaDBRef
+	if in.DependsOnBackups != nil {
+		in, out := &in.DependsOnBackups, &out.DependsOnBackups
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Binlogs != nil {
+		in, out := &in.Binlogs, &out.Binlogs
+		*out = new(RestoreBinlogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = new(batchv1.PodFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
+func (in *RestoreSpec) DeepCopy() *RestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
+func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RightSizing) DeepCopyInto(out *RightSizing) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HeadroomPercent != nil {
+		in, out := &in.HeadroomPercent, &out.HeadroomPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RightSizing.
+func (in *RightSizing) DeepCopy() *RightSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(RightSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RightSizingStatus) DeepCopyInto(out *RightSizingStatus) {
+	*out = *in
+	in.WindowStartTime.DeepCopyInto(&out.WindowStartTime)
+	if in.LastSampleTime != nil {
+		in, out := &in.LastSampleTime, &out.LastSampleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerRightSizing, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RightSizingStatus.
+func (in *RightSizingStatus) DeepCopy() *RightSizingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RightSizingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Role) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleList) DeepCopyInto(out *RoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Role, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreSource)
+	out := new(RoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 	*out = *in
-	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
+	in.SQLTemplate.DeepCopyInto(&out.SQLTemplate)
 	out.MariaDBRef = in.MariaDBRef
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
-func (in *RestoreSpec) DeepCopy() *RestoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreSpec)
+	out := new(RoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1549,12 +4442,47 @@ func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
-func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreStatus)
+	out := new(RoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingRestartStatus) DeepCopyInto(out *RollingRestartStatus) {
+	*out = *in
+	in.RestartedAt.DeepCopyInto(&out.RestartedAt)
+	if in.PendingPods != nil {
+		in, out := &in.PendingPods, &out.PendingPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentPod != nil {
+		in, out := &in.CurrentPod, &out.CurrentPod
+		*out = new(string)
+		**out = **in
+	}
+	if in.CurrentPodUID != nil {
+		in, out := &in.CurrentPodUID, &out.CurrentPodUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingRestartStatus.
+func (in *RollingRestartStatus) DeepCopy() *RollingRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingRestartStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1574,6 +4502,11 @@ func (in *S3) DeepCopyInto(out *S3) {
 		*out = new(TLS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SSE != nil {
+		in, out := &in.SSE, &out.SSE
+		*out = new(SSE)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3.
@@ -1599,6 +4532,11 @@ func (in *SQLTemplate) DeepCopyInto(out *SQLTemplate) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLTemplate.
@@ -1611,6 +4549,31 @@ func (in *SQLTemplate) DeepCopy() *SQLTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSE) DeepCopyInto(out *SSE) {
+	*out = *in
+	if in.KMSKeyID != nil {
+		in, out := &in.KMSKeyID, &out.KMSKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomerKeySecretKeyRef != nil {
+		in, out := &in.CustomerKeySecretKeyRef, &out.CustomerKeySecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSE.
+func (in *SSE) DeepCopy() *SSE {
+	if in == nil {
+		return nil
+	}
+	out := new(SSE)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Schedule) DeepCopyInto(out *Schedule) {
 	*out = *in
@@ -1621,71 +4584,253 @@ func (in *Schedule) DeepCopy() *Schedule {
 	if in == nil {
 		return nil
 	}
-	out := new(Schedule)
+	out := new(Schedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScaling) DeepCopyInto(out *ScheduledScaling) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScaling.
+func (in *ScheduledScaling) DeepCopy() *ScheduledScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScalingStatus) DeepCopyInto(out *ScheduledScalingStatus) {
+	*out = *in
+	if in.ActiveWindow != nil {
+		in, out := &in.ActiveWindow, &out.ActiveWindow
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScalingStatus.
+func (in *ScheduledScalingStatus) DeepCopy() *ScheduledScalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryService) DeepCopyInto(out *SecondaryService) {
+	*out = *in
+	if in.PodIndexes != nil {
+		in, out := &in.PodIndexes, &out.PodIndexes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ServiceTemplate.DeepCopyInto(&out.ServiceTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryService.
+func (in *SecondaryService) DeepCopy() *SecondaryService {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretTemplate) DeepCopyInto(out *SecretTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(string)
+		**out = **in
+	}
+	if in.UsernameKey != nil {
+		in, out := &in.UsernameKey, &out.UsernameKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordKey != nil {
+		in, out := &in.PasswordKey, &out.PasswordKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostKey != nil {
+		in, out := &in.HostKey, &out.HostKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.PortKey != nil {
+		in, out := &in.PortKey, &out.PortKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.DatabaseKey != nil {
+		in, out := &in.DatabaseKey, &out.DatabaseKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.OptionsFileKey != nil {
+		in, out := &in.OptionsFileKey, &out.OptionsFileKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]SecretTemplateFile, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTemplate.
+func (in *SecretTemplate) DeepCopy() *SecretTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretTemplateFile) DeepCopyInto(out *SecretTemplateFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTemplateFile.
+func (in *SecretTemplateFile) DeepCopy() *SecretTemplateFile {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretTemplateFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedData) DeepCopyInto(out *SeedData) {
+	*out = *in
+	in.S3.DeepCopyInto(&out.S3)
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]SeedFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedData.
+func (in *SeedData) DeepCopy() *SeedData {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedFile) DeepCopyInto(out *SeedFile) {
+	*out = *in
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedFile.
+func (in *SeedFile) DeepCopy() *SeedFile {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedFile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretTemplate) DeepCopyInto(out *SecretTemplate) {
+func (in *SemiSyncReplication) DeepCopyInto(out *SemiSyncReplication) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Key != nil {
-		in, out := &in.Key, &out.Key
-		*out = new(string)
-		**out = **in
-	}
-	if in.Format != nil {
-		in, out := &in.Format, &out.Format
-		*out = new(string)
-		**out = **in
-	}
-	if in.UsernameKey != nil {
-		in, out := &in.UsernameKey, &out.UsernameKey
-		*out = new(string)
-		**out = **in
-	}
-	if in.PasswordKey != nil {
-		in, out := &in.PasswordKey, &out.PasswordKey
-		*out = new(string)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.HostKey != nil {
-		in, out := &in.HostKey, &out.HostKey
-		*out = new(string)
+	if in.WaitNoSlave != nil {
+		in, out := &in.WaitNoSlave, &out.WaitNoSlave
+		*out = new(bool)
 		**out = **in
 	}
-	if in.PortKey != nil {
-		in, out := &in.PortKey, &out.PortKey
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SemiSyncReplication.
+func (in *SemiSyncReplication) DeepCopy() *SemiSyncReplication {
+	if in == nil {
+		return nil
 	}
-	if in.DatabaseKey != nil {
-		in, out := &in.DatabaseKey, &out.DatabaseKey
-		*out = new(string)
+	out := new(SemiSyncReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SemiSyncStatus) DeepCopyInto(out *SemiSyncStatus) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTemplate.
-func (in *SecretTemplate) DeepCopy() *SecretTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SemiSyncStatus.
+func (in *SemiSyncStatus) DeepCopy() *SemiSyncStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretTemplate)
+	out := new(SemiSyncStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1759,6 +4904,80 @@ func (in *ServiceTemplate) DeepCopy() *ServiceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionPolicy) DeepCopyInto(out *SessionPolicy) {
+	*out = *in
+	if in.WaitTimeout != nil {
+		in, out := &in.WaitTimeout, &out.WaitTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InteractiveTimeout != nil {
+		in, out := &in.InteractiveTimeout, &out.InteractiveTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxStatementTime != nil {
+		in, out := &in.MaxStatementTime, &out.MaxStatementTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionPolicy.
+func (in *SessionPolicy) DeepCopy() *SessionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Spider) DeepCopyInto(out *Spider) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]SpiderNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spider.
+func (in *Spider) DeepCopy() *Spider {
+	if in == nil {
+		return nil
+	}
+	out := new(Spider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderNode) DeepCopyInto(out *SpiderNode) {
+	*out = *in
+	out.MariaDBRef = in.MariaDBRef
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderNode.
+func (in *SpiderNode) DeepCopy() *SpiderNode {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SqlJob) DeepCopyInto(out *SqlJob) {
 	*out = *in
@@ -1838,6 +5057,11 @@ func (in *SqlJobSpec) DeepCopyInto(out *SqlJobSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependsOnBackups != nil {
+		in, out := &in.DependsOnBackups, &out.DependsOnBackups
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Sql != nil {
 		in, out := &in.Sql, &out.Sql
 		*out = new(string)
@@ -1848,6 +5072,31 @@ func (in *SqlJobSpec) DeepCopyInto(out *SqlJobSpec) {
 		*out = new(v1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = new(batchv1.PodFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
@@ -1906,6 +5155,26 @@ func (in *SqlJobStatus) DeepCopy() *SqlJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchoverStatus) DeepCopyInto(out *SwitchoverStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.DrainStartTime != nil {
+		in, out := &in.DrainStartTime, &out.DrainStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchoverStatus.
+func (in *SwitchoverStatus) DeepCopy() *SwitchoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -1926,6 +5195,67 @@ func (in *TLS) DeepCopy() *TLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreflight) DeepCopyInto(out *UpgradePreflight) {
+	*out = *in
+	if in.SkipChecks != nil {
+		in, out := &in.SkipChecks, &out.SkipChecks
+		*out = make([]UpgradePreflightCheckName, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinStorageHeadroomPercent != nil {
+		in, out := &in.MinStorageHeadroomPercent, &out.MinStorageHeadroomPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreflight.
+func (in *UpgradePreflight) DeepCopy() *UpgradePreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreflight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreflightCheck) DeepCopyInto(out *UpgradePreflightCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreflightCheck.
+func (in *UpgradePreflightCheck) DeepCopy() *UpgradePreflightCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreflightCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreflightStatus) DeepCopyInto(out *UpgradePreflightStatus) {
+	*out = *in
+	in.CheckTime.DeepCopyInto(&out.CheckTime)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]UpgradePreflightCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreflightStatus.
+func (in *UpgradePreflightStatus) DeepCopy() *UpgradePreflightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreflightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -1985,12 +5315,61 @@ func (in *UserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserRequire) DeepCopyInto(out *UserRequire) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserRequire.
+func (in *UserRequire) DeepCopy() *UserRequire {
+	if in == nil {
+		return nil
+	}
+	out := new(UserRequire)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 	*out = *in
 	in.SQLTemplate.DeepCopyInto(&out.SQLTemplate)
 	out.MariaDBRef = in.MariaDBRef
-	in.PasswordSecretKeyRef.DeepCopyInto(&out.PasswordSecretKeyRef)
+	if in.PasswordSecretKeyRef != nil {
+		in, out := &in.PasswordSecretKeyRef, &out.PasswordSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordHashSecretKeyRef != nil {
+		in, out := &in.PasswordHashSecretKeyRef, &out.PasswordHashSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotation)
+		**out = **in
+	}
+	if in.MaxStatementTime != nil {
+		in, out := &in.MaxStatementTime, &out.MaxStatementTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Require != nil {
+		in, out := &in.Require, &out.Require
+		*out = new(UserRequire)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSpec.
@@ -2013,6 +5392,28 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordLastChanged != nil {
+		in, out := &in.PasswordLastChanged, &out.PasswordLastChanged
+		*out = (*in).DeepCopy()
+	}
+	if in.PasswordExpiresAt != nil {
+		in, out := &in.PasswordExpiresAt, &out.PasswordExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PasswordLastRotated != nil {
+		in, out := &in.PasswordLastRotated, &out.PasswordLastRotated
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
@@ -2054,3 +5455,43 @@ func (in *VolumeClaimTemplate) DeepCopy() *VolumeClaimTemplate {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmUp) DeepCopyInto(out *WarmUp) {
+	*out = *in
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmUp.
+func (in *WarmUp) DeepCopy() *WarmUp {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmUp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WsrepNotify) DeepCopyInto(out *WsrepNotify) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WsrepNotify.
+func (in *WsrepNotify) DeepCopy() *WsrepNotify {
+	if in == nil {
+		return nil
+	}
+	out := new(WsrepNotify)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,269 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/backup"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/spf13/cobra"
+)
+
+var (
+	binlogIndex             string
+	binlogInterval          time.Duration
+	binlogFlushInterval     time.Duration
+	binlogPodName           string
+	binlogMariadbHost       string
+	binlogMariadbPort       int32
+	binlogStartPositionPath string
+)
+
+// replayBinlogBaseName is the base name of the pulled binary logs, which are renamed to be replayed in order.
+const replayBinlogBaseName = "replay-bin"
+
+func init() {
+	binlogArchiveCommand.Flags().StringVar(&binlogIndex, "binlog-index", "mariadb-bin.index",
+		"Name of the binary log index file, relative to the path.")
+	binlogArchiveCommand.Flags().DurationVar(&binlogInterval, "interval", time.Minute,
+		"The interval at which the closed binary logs are archived.")
+	binlogArchiveCommand.Flags().DurationVar(&binlogFlushInterval, "flush-interval", 0,
+		"The interval at which the binary logs are flushed, closing the one currently being written so it gets archived. "+
+			"Disabled if 0. The credentials are read from the "+userEnv+" and "+passwordEnv+" environment variables.")
+	binlogArchiveCommand.Flags().StringVar(&binlogPodName, "pod-name", "",
+		"Name of the Pod. It prefixes the names of the archived binary logs, so they do not collide with the ones of other Pods.")
+	binlogArchiveCommand.Flags().StringVar(&binlogMariadbHost, "mariadb-host", "127.0.0.1",
+		"Host of the MariaDB whose binary logs are flushed.")
+	binlogArchiveCommand.Flags().Int32Var(&binlogMariadbPort, "mariadb-port", 3306,
+		"Port of the MariaDB whose binary logs are flushed.")
+
+	binlogPullCommand.Flags().StringVar(&binlogStartPositionPath, "start-position-file-path", "/backup/0-binlog-start-position.txt",
+		"Path to a file where the GTID position of the backup, where the replay of the binary logs starts, is written.")
+
+	RootCmd.AddCommand(binlogArchiveCommand)
+	RootCmd.AddCommand(binlogPullCommand)
+}
+
+var binlogArchiveCommand = &cobra.Command{
+	Use:   "binlog-archive",
+	Short: "Archive binary logs.",
+	Long: `Periodically ships the closed binary logs to the backup storage. ` +
+		`The name of the last archived binary log is written to the target file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setupLogger(cmd); err != nil {
+			fmt.Printf("error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info(
+			"starting binlog archiving",
+			"interval", binlogInterval.String(),
+			"flush-interval", binlogFlushInterval.String(),
+		)
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		var storageOpts []backup.S3BackupStorageOpt
+		if binlogPodName != "" {
+			storageOpts = append(storageOpts, backup.WithFileNamePrefix(binlogPodName+"."))
+		}
+		binlogStorage, err := getBinlogStorage(nil, storageOpts...)
+		if err != nil {
+			logger.Error(err, "error getting binlog storage")
+			os.Exit(1)
+		}
+
+		ticker := time.NewTicker(binlogInterval)
+		defer ticker.Stop()
+		var flushC <-chan time.Time
+		if binlogFlushInterval > 0 {
+			flushTicker := time.NewTicker(binlogFlushInterval)
+			defer flushTicker.Stop()
+			flushC = flushTicker.C
+		}
+		for {
+			if err := archiveBinlogs(ctx, binlogStorage); err != nil {
+				logger.Error(err, "error archiving binlogs")
+			}
+			select {
+			case <-ctx.Done():
+				logger.Info("stopping binlog archiving")
+				return
+			case <-ticker.C:
+			case <-flushC:
+				if err := flushBinlogs(ctx); err != nil {
+					logger.Error(err, "error flushing binlogs")
+				}
+			}
+		}
+	},
+}
+
+var binlogPullCommand = &cobra.Command{
+	Use:   "binlog-pull",
+	Short: "Pull binary logs.",
+	Long: `Pulls the archived binary logs from the backup storage and writes the GTID position of the target backup, ` +
+		`from where the binary logs are replayed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setupLogger(cmd); err != nil {
+			fmt.Printf("error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("starting binlog pull")
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		progress, err := startProgress(ctx, "binlog-pull")
+		if err != nil {
+			logger.Error(err, "error starting progress")
+			os.Exit(1)
+		}
+
+		binlogStorage, err := getBinlogStorage(progress)
+		if err != nil {
+			logger.Error(err, "error getting binlog storage")
+			os.Exit(1)
+		}
+
+		logger.Info("reading target file", "path", targetFilePath)
+		backupTargetFiles, err := readTargetFile()
+		if err != nil {
+			logger.Error(err, "error reading target file", "path", targetFilePath)
+			os.Exit(1)
+		}
+		backupTargetFile := backupTargetFiles[0]
+		if len(backupTargetFiles) > 1 || backup.DatabaseInBackupFile(backupTargetFile) != "" {
+			logger.Error(
+				errors.New("point-in-time recovery is not supported by per-database backups"),
+				"invalid target backup",
+				"files", backupTargetFiles,
+			)
+			os.Exit(1)
+		}
+		gtidPosition, err := getBackupGtidPosition(backupTargetFile)
+		if err != nil {
+			logger.Error(err, "error getting backup GTID position", "file", backupTargetFile)
+			os.Exit(1)
+		}
+		logger.Info("obtained backup GTID position", "file", backupTargetFile, "gtid", gtidPosition)
+
+		progress.SetPhase(backup.PhaseListing)
+		binlogs, err := binlogStorage.List(ctx)
+		if err != nil {
+			logger.Error(err, "error listing binlogs")
+			os.Exit(1)
+		}
+		if len(binlogs) == 0 {
+			logger.Error(errors.New("no binlogs found"), "error pulling binlogs")
+			os.Exit(1)
+		}
+
+		progress.SetPhase(backup.PhaseDownloading)
+		for _, binlog := range backup.SortBinlogs(binlogs) {
+			logger.Info("pulling binlog", "file", binlog)
+			if err := binlogStorage.Pull(ctx, binlog); err != nil {
+				logger.Error(err, "error pulling binlog", "file", binlog)
+				os.Exit(1)
+			}
+		}
+		if err := renameBinlogsForReplay(binlogs); err != nil {
+			logger.Error(err, "error renaming binlogs for replay")
+			os.Exit(1)
+		}
+
+		logger.Info("writing start position file", "path", binlogStartPositionPath)
+		if err := os.WriteFile(binlogStartPositionPath, []byte(gtidPosition), 0644); err != nil {
+			logger.Error(err, "error writing start position file", "path", binlogStartPositionPath)
+			os.Exit(1)
+		}
+		progress.SetPhase(backup.PhaseCompleted)
+	},
+}
+
+func getBinlogStorage(progress *backup.Progress, storageOpts ...backup.S3BackupStorageOpt) (backup.BackupStorage, error) {
+	if !s3 {
+		return nil, errors.New("binlogs are only supported in S3 storage")
+	}
+	return getS3BackupStorage(progress, append(storageOpts, backup.WithFileFilter(backup.IsValidBinlogFile))...)
+}
+
+// flushBinlogs closes the binary log currently being written, so it is archived even if it has not reached max_binlog_size.
+func flushBinlogs(ctx context.Context) error {
+	client, err := sqlClient.NewClient(
+		sqlClient.WithUsername(os.Getenv(userEnv)),
+		sqlClient.WithPassword(os.Getenv(passwordEnv)),
+		sqlClient.WitHost(binlogMariadbHost),
+		sqlClient.WithPort(binlogMariadbPort),
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer client.Close()
+
+	logger.V(1).Info("flushing binlogs")
+	return client.FlushBinaryLogs(ctx)
+}
+
+// renameBinlogsForReplay renames the pulled binary logs, archived by all the Pods, after their creation order, so they
+// are replayed in the order they were written regardless of the Pod that was primary at the time.
+func renameBinlogsForReplay(binlogs []string) error {
+	sorted, err := backup.SortBinlogsForReplay(path, binlogs)
+	if err != nil {
+		return err
+	}
+	for i, binlog := range sorted {
+		replayBinlog := fmt.Sprintf("%s.%06d", replayBinlogBaseName, i+1)
+		logger.V(1).Info("renaming binlog", "file", binlog, "replay-file", replayBinlog)
+		if err := os.Rename(filepath.Join(path, binlog), filepath.Join(path, replayBinlog)); err != nil {
+			return fmt.Errorf("error renaming binlog %s: %v", binlog, err)
+		}
+	}
+	return nil
+}
+
+// archiveBinlogs pushes the closed binary logs that have not been archived yet.
+func archiveBinlogs(ctx context.Context, binlogStorage backup.BackupStorage) error {
+	index, err := backup.ReadBinlogIndex(filepath.Join(path, binlogIndex))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.V(1).Info("binlog index not found", "file", binlogIndex)
+			return nil
+		}
+		return err
+	}
+	archived, err := binlogStorage.List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing archived binlogs: %v", err)
+	}
+
+	for _, binlog := range backup.GetBinlogsToArchive(index, archived) {
+		logger.Info("archiving binlog", "file", binlog)
+		if err := binlogStorage.Push(ctx, binlog); err != nil {
+			return fmt.Errorf("error pushing binlog %s: %v", binlog, err)
+		}
+		if err := os.WriteFile(targetFilePath, []byte(binlog), 0644); err != nil {
+			return fmt.Errorf("error writing target file: %v", err)
+		}
+	}
+	return nil
+}
+
+func getBackupGtidPosition(backupTargetFile string) (string, error) {
+	manifest, err := backup.NewManifest(
+		filepath.Join(path, backupTargetFile),
+		backup.ManifestTopology{},
+		backup.EncryptionNone,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error reading backup: %v", err)
+	}
+	if manifest.GtidPosition == "" {
+		return "", errors.New("backup does not contain a GTID position")
+	}
+	return manifest.GtidPosition, nil
+}
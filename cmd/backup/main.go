@@ -2,14 +2,20 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/mariadb-operator/mariadb-operator/pkg/backup"
 	"github.com/mariadb-operator/mariadb-operator/pkg/log"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -20,11 +26,40 @@ var (
 	targetFilePath string
 	s3             bool
 	s3Bucket       string
+	s3Prefix       string
 	s3Endpoint     string
 	s3Region       string
 	s3TLS          bool
 	s3CACertPath   string
+	s3Insecure     bool
+	s3PathStyle    bool
+	s3SSE          string
+	s3SSEKMSKeyID  string
+	gcs            bool
+	gcsBucket      string
+	gcsPrefix      string
+	gcsCredentials string
+	azureBlob      bool
+	azureContainer string
+	azurePrefix    string
+	azureAccount   string
+	azureEndpoint  string
 	maxRetention   time.Duration
+	maxBackups     int
+	pruneResult    string
+	tieringRules   []string
+	streaming      bool
+	topology       string
+	replicas       int32
+	metricsAddr    string
+	logInterval    time.Duration
+)
+
+const (
+	s3SSECustomerKeyEnv = "MARIADB_OPERATOR_S3_SSE_CUSTOMER_KEY"
+	azureAccountKeyEnv  = "MARIADB_OPERATOR_AZURE_STORAGE_ACCOUNT_KEY"
+	// streamStatusTimeout is the time to wait for the exit code of the process writing a streamed backup, after it has been uploaded.
+	streamStatusTimeout = time.Minute
 )
 
 func init() {
@@ -42,14 +77,59 @@ func init() {
 
 	RootCmd.PersistentFlags().BoolVar(&s3, "s3", false, "Enable S3 backup storage.")
 	RootCmd.PersistentFlags().StringVar(&s3Bucket, "s3-bucket", "backups", "Name of the bucket to store backups.")
+	RootCmd.PersistentFlags().StringVar(&s3Prefix, "s3-prefix", "", "Path within the bucket where the backups are stored.")
 	RootCmd.PersistentFlags().StringVar(&s3Endpoint, "s3-endpoint", "s3.amazonaws.com", "S3 API endpoint without scheme.")
 	RootCmd.PersistentFlags().StringVar(&s3Region, "s3-region", "us-east-1", "S3 region name to use.")
 	RootCmd.PersistentFlags().BoolVar(&s3TLS, "s3-tls", false, "Enable S3 TLS connections.")
-	RootCmd.PersistentFlags().StringVar(&s3CACertPath, "s3-ca-cert-path", "s3/pki/tls.crt",
-		"Path to the CA to be trusted when connecting to S3.")
+	RootCmd.PersistentFlags().StringVar(&s3CACertPath, "s3-ca-cert-path", "",
+		"Path to the CA bundle to be trusted when connecting to S3. The system CAs are trusted by default.")
+	RootCmd.PersistentFlags().BoolVar(&s3Insecure, "s3-tls-insecure-skip-verify", false,
+		"Skip the verification of the S3 server certificate.")
+	RootCmd.PersistentFlags().BoolVar(&s3PathStyle, "s3-path-style", false,
+		"Use path-style addressing instead of virtual-hosted-style when connecting to S3.")
+	RootCmd.PersistentFlags().StringVar(&s3SSE, "s3-sse", "",
+		"Server-side encryption type to use in S3. Supported values are 'S3', 'KMS' and 'Customer'. "+
+			"The customer key is read from the "+s3SSECustomerKeyEnv+" environment variable.")
+	RootCmd.PersistentFlags().StringVar(&s3SSEKMSKeyID, "s3-sse-kms-key-id", "",
+		"KMS key id to be used when the server-side encryption type is 'KMS'.")
+
+	RootCmd.PersistentFlags().BoolVar(&gcs, "gcs", false, "Enable Google Cloud Storage backup storage.")
+	RootCmd.PersistentFlags().StringVar(&gcsBucket, "gcs-bucket", "backups", "Name of the GCS bucket to store backups.")
+	RootCmd.PersistentFlags().StringVar(&gcsPrefix, "gcs-prefix", "", "Path within the GCS bucket where the backups are stored.")
+	RootCmd.PersistentFlags().StringVar(&gcsCredentials, "gcs-credentials-path", "",
+		"Path to a Google service account key in JSON format. The credentials are obtained from the environment by default.")
+
+	RootCmd.PersistentFlags().BoolVar(&azureBlob, "azure-blob", false, "Enable Azure Blob Storage backup storage.")
+	RootCmd.PersistentFlags().StringVar(&azureContainer, "azure-blob-container", "backups",
+		"Name of the Azure Blob Storage container to store backups.")
+	RootCmd.PersistentFlags().StringVar(&azurePrefix, "azure-blob-prefix", "",
+		"Path within the Azure Blob Storage container where the backups are stored.")
+	RootCmd.PersistentFlags().StringVar(&azureAccount, "azure-blob-storage-account", "", "Name of the Azure storage account.")
+	RootCmd.PersistentFlags().StringVar(&azureEndpoint, "azure-blob-endpoint", "",
+		"Azure Blob Storage service endpoint. It defaults to https://<storage-account>.blob.core.windows.net.")
+
+	RootCmd.PersistentFlags().StringVar(&metricsAddr, "metrics-addr", "",
+		"The address the progress metrics endpoint binds to. The endpoint is disabled if not provided.")
+	RootCmd.PersistentFlags().DurationVar(&logInterval, "progress-log-interval", 10*time.Second,
+		"The interval at which the progress of the transfers is logged.")
 
 	RootCmd.Flags().DurationVar(&maxRetention, "max-retention", 30*24*time.Hour,
 		"Defines the retention policy for backups. Older backups will be deleted.")
+	RootCmd.Flags().IntVar(&maxBackups, "max-backups", 0,
+		"Maximum number of backups to keep. The oldest backups exceeding this number will be deleted. Disabled by default.")
+	RootCmd.Flags().StringVar(&pruneResult, "prune-result-path", "",
+		"Path to a file where the backups deleted by the retention policy, along with the available ones, are reported in JSON format, "+
+			"such as the Pod termination message. Disabled by default.")
+	RootCmd.Flags().StringArrayVar(&tieringRules, "tiering-rule", nil,
+		"Rule to move the backups older than a given age to a storage class, with the '<min-age>=<storage-class>' format. "+
+			"It can be specified multiple times. Only supported by object storages.")
+	RootCmd.Flags().BoolVar(&streaming, "streaming", false,
+		"Upload the target backup while it is being streamed into a named pipe, instead of reading it from a regular file. "+
+			"The backup is deleted from the storage if the process writing it does not finish successfully.")
+	RootCmd.Flags().StringVar(&topology, "mariadb-topology", string(backup.TopologyStandalone),
+		"Topology of the MariaDB being backed up, to be recorded in the backup manifest.")
+	RootCmd.Flags().Int32Var(&replicas, "mariadb-replicas", 1,
+		"Number of replicas of the MariaDB being backed up, to be recorded in the backup manifest.")
 
 	RootCmd.AddCommand(restoreCommand)
 }
@@ -69,49 +149,172 @@ var RootCmd = &cobra.Command{
 		ctx, cancel := newContext()
 		defer cancel()
 
-		backupStorage, err := getBackupStorage()
+		progress, err := startProgress(ctx, "backup")
+		if err != nil {
+			logger.Error(err, "error starting progress")
+			abortStreams()
+			os.Exit(1)
+		}
+
+		backupStorage, err := getBackupStorage(progress)
 		if err != nil {
 			logger.Error(err, "error getting backup storage")
+			abortStreams()
 			os.Exit(1)
 		}
 
 		logger.Info("reading target file", "path", targetFilePath)
-		backupTargetFile, err := readTargetFile()
+		backupTargetFiles, err := readTargetFile()
 		if err != nil {
 			logger.Error(err, "error reading target file", "path", targetFilePath)
 			os.Exit(1)
 		}
-		logger.Info("obtained target backup", "file", backupTargetFile)
+		logger.Info("obtained target backup", "files", backupTargetFiles)
 
-		logger.Info("pushing target backup", "file", backupTargetFile)
-		if err := backupStorage.Push(ctx, backupTargetFile); err != nil {
-			logger.Error(err, "error pushing target backup", "file", backupTargetFile)
-			os.Exit(1)
+		if !streaming {
+			var dumpedBytes int64
+			for _, file := range backupTargetFiles {
+				if info, err := os.Stat(filepath.Join(path, file)); err == nil {
+					dumpedBytes += info.Size()
+				}
+			}
+			progress.SetDumpedBytes(dumpedBytes)
 		}
 
+		progress.SetPhase(backup.PhaseUploading)
+		for _, backupTargetFile := range backupTargetFiles {
+			logger.Info("pushing target backup", "file", backupTargetFile, "streaming", streaming)
+			if err := backupStorage.Push(ctx, backupTargetFile); err != nil {
+				logger.Error(err, "error pushing target backup", "file", backupTargetFile)
+				abortStreams()
+				os.Exit(1)
+			}
+			if streaming {
+				if err := checkStream(ctx, backupStorage, backupTargetFile, progress); err != nil {
+					logger.Error(err, "error streaming target backup", "file", backupTargetFile)
+					os.Exit(1)
+				}
+			}
+
+			manifestFile := backup.ManifestFileName(backupTargetFile)
+			logger.Info("writing backup manifest", "file", manifestFile)
+			if err := writeManifest(backupTargetFile); err != nil {
+				logger.Error(err, "error writing backup manifest", "file", manifestFile)
+				os.Exit(1)
+			}
+			logger.Info("pushing backup manifest", "file", manifestFile)
+			if err := backupStorage.Push(ctx, manifestFile); err != nil {
+				logger.Error(err, "error pushing backup manifest", "file", manifestFile)
+				os.Exit(1)
+			}
+		}
+
+		progress.SetPhase(backup.PhaseListing)
 		backupNames, err := backupStorage.List(ctx)
 		if err != nil {
 			logger.Error(err, "error listing backup files")
 			os.Exit(1)
 		}
 
+		progress.SetPhase(backup.PhaseCleanup)
+		defer progress.SetPhase(backup.PhaseCompleted)
 		logger.Info("cleaning up old backups")
-		oldBackups := backup.GetOldBackupFiles(backupNames, maxRetention, logger.WithName("backup-cleanup"))
-		if len(oldBackups) == 0 {
+		result := backup.Prune(ctx, backupStorage, backupNames, backup.RetentionPolicy{
+			MaxRetention: maxRetention,
+			MaxBackups:   maxBackups,
+		}, logger.WithName("backup-cleanup"))
+		if result.Pruned == 0 {
 			logger.Info("no old backups were found")
-			os.Exit(0)
+		} else {
+			logger.Info("old backups deleted", "backups", result.Pruned, "retained", result.Retained)
 		}
-		logger.Info("old backups to delete", "backups", len(oldBackups))
 
-		for _, backup := range oldBackups {
-			logger.V(1).Info("deleting old backup", "backup", backup)
-			if err := backupStorage.Delete(ctx, backup); err != nil {
-				logger.Error(err, "error removing old backup", "backup", backup)
+		if len(tieringRules) > 0 {
+			progress.SetPhase(backup.PhaseTiering)
+			if err := tierBackups(ctx, backupStorage); err != nil {
+				logger.Error(err, "error tiering backups")
+			}
+		}
+
+		if pruneResult != "" {
+			if backupNames, err := backupStorage.List(ctx); err == nil {
+				result.Artifacts = backup.GetArtifacts(ctx, backupStorage, backupNames, logger.WithName("backup-inventory"))
+			} else {
+				logger.Error(err, "error listing backup artifacts")
+			}
+			if err := backup.WritePruneResult(pruneResult, result); err != nil {
+				logger.Error(err, "error writing prune result", "path", pruneResult)
 			}
 		}
 	},
 }
 
+// abortStreams signals the processes writing the streamed backups to stop, as they are not going to be uploaded.
+func abortStreams() {
+	if !streaming {
+		return
+	}
+	files, err := readTargetFile()
+	if err != nil {
+		logger.Error(err, "error reading target file to abort streams", "path", targetFilePath)
+		return
+	}
+	for _, file := range files {
+		abortFile := filepath.Join(path, backup.StreamAbortFileName(file))
+		logger.Info("aborting stream", "file", abortFile)
+		if err := backup.AbortStream(abortFile); err != nil {
+			logger.Error(err, "error aborting stream", "file", abortFile)
+		}
+	}
+}
+
+// checkStream ensures that a streamed backup has been completely written, deleting it from the storage otherwise.
+func checkStream(ctx context.Context, backupStorage backup.BackupStorage, backupTargetFile string, progress *backup.Progress) error {
+	statusCtx, cancel := context.WithTimeout(ctx, streamStatusTimeout)
+	defer cancel()
+	statusFile := filepath.Join(path, backup.StreamStatusFileName(backupTargetFile))
+	logger.Info("waiting for stream status", "file", statusFile)
+	if err := backup.WaitForStreamStatus(statusCtx, statusFile, time.Second); err != nil {
+		logger.Info("deleting incomplete backup", "file", backupTargetFile)
+		if err := backupStorage.Delete(ctx, backupTargetFile); err != nil {
+			logger.Error(err, "error deleting incomplete backup", "file", backupTargetFile)
+		}
+		return err
+	}
+
+	info, err := backupStorage.Stat(ctx, backupTargetFile)
+	if err != nil {
+		return fmt.Errorf("error getting streamed backup info: %v", err)
+	}
+	progress.SetDumpedBytes(info.Size)
+	return nil
+}
+
+func tierBackups(ctx context.Context, backupStorage backup.BackupStorage) error {
+	var rules []backup.TieringRule
+	for _, r := range tieringRules {
+		rule, err := backup.ParseTieringRule(r)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, *rule)
+	}
+	backupNames, err := backupStorage.List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing backup files: %v", err)
+	}
+
+	logger.Info("tiering backups", "rules", tieringRules)
+	result, err := backup.Tier(ctx, backupStorage, backupNames, rules, logger.WithName("backup-tiering"))
+	if err != nil {
+		return err
+	}
+	if result.Tiered > 0 || result.Failed > 0 {
+		logger.Info("backups tiered", "backups", result.Tiered, "failed", result.Failed)
+	}
+	return nil
+}
+
 func setupLogger(cmd *cobra.Command) error {
 	logLevel, err := cmd.Flags().GetString("log-level")
 	if err != nil {
@@ -139,21 +342,116 @@ func newContext() (context.Context, context.CancelFunc) {
 	)
 }
 
-func getBackupStorage() (backup.BackupStorage, error) {
+// startProgress tracks the progress of the operation, periodically logging it and exposing it via the metrics endpoint.
+func startProgress(ctx context.Context, operation string) (*backup.Progress, error) {
+	progress := backup.NewProgress(operation, logger.WithName("progress"))
+	go progress.LogPeriodically(ctx, logInterval)
+
+	if metricsAddr == "" {
+		return progress, nil
+	}
+	registry := prometheus.NewRegistry()
+	if err := progress.Register(registry); err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:              metricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		logger.Info("serving progress metrics", "addr", metricsAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "error serving progress metrics")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			logger.Error(err, "error closing progress metrics server")
+		}
+	}()
+	return progress, nil
+}
+
+func getBackupStorage(progress *backup.Progress) (backup.BackupStorage, error) {
 	if s3 {
 		logger.Info("configuring S3 backup storage")
-		return getS3BackupStorage()
+		return getS3BackupStorage(progress)
+	}
+	if gcs {
+		logger.Info("configuring GCS backup storage")
+		return getGCSBackupStorage(progress)
+	}
+	if azureBlob {
+		logger.Info("configuring Azure Blob backup storage")
+		return getAzureBlobBackupStorage(progress)
 	}
 	logger.Info("configuring filesystem backup storage")
 	return backup.NewFileSystemBackupStorage(path, logger.WithName("file-system-storage")), nil
 }
 
-func getS3BackupStorage() (backup.BackupStorage, error) {
+func getGCSBackupStorage(progress *backup.Progress) (backup.BackupStorage, error) {
+	opts := []backup.GCSBackupStorageOpt{
+		backup.WithGCSPrefix(gcsPrefix),
+		backup.WithGCSProgress(progress),
+	}
+	if gcsCredentials != "" {
+		opts = append(opts, backup.WithGCSCredentials(gcsCredentials))
+	}
+	return backup.NewGCSBackupStorage(
+		context.Background(),
+		path,
+		gcsBucket,
+		logger.WithName("gcs-storage"),
+		opts...,
+	)
+}
+
+func getAzureBlobBackupStorage(progress *backup.Progress) (backup.BackupStorage, error) {
+	opts := []backup.AzureBlobBackupStorageOpt{
+		backup.WithAzureBlobPrefix(azurePrefix),
+		backup.WithAzureBlobProgress(progress),
+	}
+	if azureEndpoint != "" {
+		opts = append(opts, backup.WithAzureBlobEndpoint(azureEndpoint))
+	}
+	if accountKey := os.Getenv(azureAccountKeyEnv); accountKey != "" {
+		opts = append(opts, backup.WithAzureBlobAccountKey(accountKey))
+	}
+	return backup.NewAzureBlobBackupStorage(
+		path,
+		azureContainer,
+		azureAccount,
+		logger.WithName("azure-blob-storage"),
+		opts...,
+	)
+}
+
+func getS3BackupStorage(progress *backup.Progress, storageOpts ...backup.S3BackupStorageOpt) (backup.BackupStorage, error) {
 	opts := []backup.S3BackupStorageOpt{
 		backup.WithRegion(s3Region),
+		backup.WithPrefix(s3Prefix),
+		backup.WithProgress(progress),
 	}
+	opts = append(opts, storageOpts...)
 	if s3TLS {
 		opts = append(opts, backup.WithTLS(s3CACertPath))
+		if s3Insecure {
+			opts = append(opts, backup.WithInsecureSkipVerify())
+		}
+	}
+	if s3PathStyle {
+		opts = append(opts, backup.WithPathStyle())
+	}
+	if s3SSE != "" {
+		sse, err := getS3SSE()
+		if err != nil {
+			return nil, fmt.Errorf("error getting S3 server-side encryption: %v", err)
+		}
+		opts = append(opts, backup.WithSSE(sse))
 	}
 	return backup.NewS3BackupStorage(
 		path,
@@ -164,10 +462,52 @@ func getS3BackupStorage() (backup.BackupStorage, error) {
 	)
 }
 
-func readTargetFile() (string, error) {
+func getS3SSE() (encrypt.ServerSide, error) {
+	switch s3SSE {
+	case "S3":
+		return encrypt.NewSSE(), nil
+	case "KMS":
+		return encrypt.NewSSEKMS(s3SSEKMSKeyID, nil)
+	case "Customer":
+		key := os.Getenv(s3SSECustomerKeyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("environment variable '%s' not provided", s3SSECustomerKeyEnv)
+		}
+		return encrypt.NewSSEC([]byte(key))
+	default:
+		return nil, fmt.Errorf("unsupported server-side encryption type: %s", s3SSE)
+	}
+}
+
+func writeManifest(backupTargetFile string) error {
+	encryption := backup.EncryptionNone
+	if s3 && s3SSE != "" {
+		encryption = s3SSE
+	}
+	manifest, err := backup.NewManifest(
+		filepath.Join(path, backupTargetFile),
+		backup.ManifestTopology{
+			Type:     backup.Topology(topology),
+			Replicas: replicas,
+		},
+		encryption,
+	)
+	if err != nil {
+		return fmt.Errorf("error building manifest: %v", err)
+	}
+	return backup.WriteManifest(filepath.Join(path, backup.ManifestFileName(backupTargetFile)), manifest)
+}
+
+// readTargetFile reads the backup files listed in the target file.
+// Per-database backups list one file per database, whereas the rest of backups list a single file.
+func readTargetFile() ([]string, error) {
 	bytes, err := os.ReadFile(targetFilePath)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	files := backup.ParseTargetFiles(string(bytes))
+	if len(files) == 0 {
+		return nil, errors.New("no backup files found in target file")
 	}
-	return string(bytes), nil
+	return files, nil
 }
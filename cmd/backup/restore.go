@@ -1,19 +1,46 @@
 package backup
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/mariadb-operator/mariadb-operator/pkg/backup"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	"github.com/spf13/cobra"
 )
 
-var targetTimeRaw string
+var (
+	targetTimeRaw          string
+	mariadbHost            string
+	mariadbPort            int32
+	skipCompatibilityCheck bool
+	beforeTargetTime       bool
+	restoreDatabases       []string
+)
+
+const (
+	userEnv     = "MARIADB_OPERATOR_USER"
+	passwordEnv = "MARIADB_OPERATOR_PASSWORD"
+)
 
 func init() {
 	restoreCommand.Flags().StringVar(&targetTimeRaw, "target-time", "",
 		"RFC3339 (1970-01-01T00:00:00Z) date and time that defines the backup target time.")
+	restoreCommand.Flags().StringVar(&mariadbHost, "mariadb-host", "",
+		"Host of the MariaDB where the backup is restored. The compatibility check is skipped if not provided. "+
+			"The credentials are read from the "+userEnv+" and "+passwordEnv+" environment variables.")
+	restoreCommand.Flags().Int32Var(&mariadbPort, "mariadb-port", 3306, "Port of the MariaDB where the backup is restored.")
+	restoreCommand.Flags().BoolVar(&skipCompatibilityCheck, "skip-compatibility-check", false,
+		"Skip the validation of the backup manifest against the MariaDB where the backup is restored.")
+	restoreCommand.Flags().BoolVar(&beforeTargetTime, "before-target-time", false,
+		"Only consider the backups taken before the target time, as required to replay the binary logs afterwards.")
+	restoreCommand.Flags().StringArrayVar(&restoreDatabases, "database", nil,
+		"Database to be restored. Per-database backups of other databases are not pulled. It may be specified multiple times.")
 }
 
 var restoreCommand = &cobra.Command{
@@ -30,7 +57,13 @@ var restoreCommand = &cobra.Command{
 		ctx, cancel := newContext()
 		defer cancel()
 
-		backupStorage, err := getBackupStorage()
+		progress, err := startProgress(ctx, "restore")
+		if err != nil {
+			logger.Error(err, "error starting progress")
+			os.Exit(1)
+		}
+
+		backupStorage, err := getBackupStorage(progress)
 		if err != nil {
 			logger.Error(err, "error getting backup storage")
 			os.Exit(1)
@@ -43,30 +76,49 @@ var restoreCommand = &cobra.Command{
 		}
 		logger.Info("obtained target time", "time", targetTime.String())
 
+		progress.SetPhase(backup.PhaseListing)
 		backupFileNames, err := backupStorage.List(ctx)
 		if err != nil {
 			logger.Error(err, "error listing backup files")
 			os.Exit(1)
 		}
 
-		backupTargetFile, err := backup.GetBackupTargetFile(backupFileNames, targetTime, logger.WithName("point-in-time-recovery"))
+		backupFileNames = backup.FilterBackupFilesByDatabase(backupFileNames, restoreDatabases)
+		backupFileNames = backup.RemoveArchivedBackupFiles(ctx, backupStorage, backupFileNames, logger.WithName("tiering"))
+
+		getTargetFile := backup.GetBackupTargetFile
+		if beforeTargetTime {
+			getTargetFile = backup.GetBackupTargetFileBefore
+		}
+		backupTargetFile, err := getTargetFile(backupFileNames, targetTime, logger.WithName("point-in-time-recovery"))
 		if err != nil {
 			logger.Error(err, "error reading getting target backup")
 			os.Exit(1)
 		}
-		logger.Info("obtained target backup", "file", backupTargetFile)
+		backupTargetFiles := backup.GetBackupTargetFiles(backupFileNames, backupTargetFile)
+		logger.Info("obtained target backup", "files", backupTargetFiles)
+
+		progress.SetPhase(backup.PhaseDownloading)
+		for _, file := range backupTargetFiles {
+			logger.Info("pulling target backup", "file", file)
+			if err := backupStorage.Pull(ctx, file); err != nil {
+				logger.Error(err, "error pulling target backup", "file", file)
+				os.Exit(1)
+			}
+		}
 
-		logger.Info("pulling target backup", "file", backupTargetFile)
-		if err := backupStorage.Pull(ctx, backupTargetFile); err != nil {
-			logger.Error(err, "error pulling target backup", "file", backupTargetFile)
+		// per-database backups are taken at the same time from the same server, checking one of them is enough
+		if err := checkCompatibility(ctx, backupStorage, backupTargetFiles[0]); err != nil {
+			logger.Error(err, "backup is not compatible with the target MariaDB", "file", backupTargetFiles[0])
 			os.Exit(1)
 		}
 
 		logger.Info("writing target file", "path", targetFilePath)
-		if err := writeTargetFile(backupTargetFile); err != nil {
+		if err := writeTargetFile(backupTargetFiles); err != nil {
 			logger.Error(err, "error writing target file", "path", targetFilePath)
 			os.Exit(1)
 		}
+		progress.SetPhase(backup.PhaseCompleted)
 	},
 }
 
@@ -77,6 +129,53 @@ func getTargetTime() (time.Time, error) {
 	return backup.ParseBackupDate(targetTimeRaw)
 }
 
-func writeTargetFile(backupTargetFilePath string) error {
-	return os.WriteFile(targetFilePath, []byte(backupTargetFilePath), 0777)
+func writeTargetFile(backupTargetFiles []string) error {
+	return os.WriteFile(targetFilePath, []byte(strings.Join(backupTargetFiles, "\n")), 0777)
+}
+
+// checkCompatibility validates the manifest of the backup file against the target MariaDB.
+// Backups without manifest, taken by previous versions of the operator, are not validated.
+func checkCompatibility(ctx context.Context, backupStorage backup.BackupStorage, backupTargetFile string) error {
+	if skipCompatibilityCheck || mariadbHost == "" {
+		logger.Info("skipping compatibility check")
+		return nil
+	}
+	manifestFile := backup.ManifestFileName(backupTargetFile)
+	logger.Info("pulling backup manifest", "file", manifestFile)
+	if err := backupStorage.Pull(ctx, manifestFile); err != nil {
+		if errors.Is(err, backup.ErrFileNotFound) {
+			logger.Info("backup manifest not found. Skipping compatibility check", "file", manifestFile)
+			return nil
+		}
+		return fmt.Errorf("error pulling backup manifest: %v", err)
+	}
+	manifest, err := backup.ReadManifest(filepath.Join(path, manifestFile))
+	if err != nil {
+		return fmt.Errorf("error reading backup manifest: %v", err)
+	}
+
+	client, err := sqlClient.NewClient(
+		sqlClient.WithUsername(os.Getenv(userEnv)),
+		sqlClient.WithPassword(os.Getenv(passwordEnv)),
+		sqlClient.WitHost(mariadbHost),
+		sqlClient.WithPort(mariadbPort),
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer client.Close()
+
+	version, err := client.SystemVariable(ctx, "version")
+	if err != nil {
+		return fmt.Errorf("error getting MariaDB version: %v", err)
+	}
+	logger.Info(
+		"checking compatibility",
+		"backup-version", manifest.ServerVersion,
+		"target-version", version,
+		"topology", manifest.Topology.Type,
+		"compression", manifest.Compression,
+		"encryption", manifest.Encryption,
+	)
+	return manifest.CheckCompatibility(version)
 }
@@ -0,0 +1,72 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/backup"
+	"github.com/spf13/cobra"
+)
+
+var seedFiles []string
+
+func init() {
+	seedPullCommand.Flags().StringArrayVar(&seedFiles, "file", nil,
+		"Seed file to be pulled with the '<name>=<sha256>' format. It can be specified multiple times.")
+
+	RootCmd.AddCommand(seedPullCommand)
+}
+
+var seedPullCommand = &cobra.Command{
+	Use:   "seed-pull",
+	Short: "Pull seed data.",
+	Long:  `Pulls the seed data files from the backup storage and verifies their checksums.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setupLogger(cmd); err != nil {
+			fmt.Printf("error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("starting seed data pull")
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		progress, err := startProgress(ctx, "seed-pull")
+		if err != nil {
+			logger.Error(err, "error starting progress")
+			os.Exit(1)
+		}
+
+		if !s3 {
+			logger.Error(errors.New("seed data is only supported in S3 storage"), "error getting seed storage")
+			os.Exit(1)
+		}
+		seedStorage, err := getS3BackupStorage(progress)
+		if err != nil {
+			logger.Error(err, "error getting seed storage")
+			os.Exit(1)
+		}
+
+		progress.SetPhase(backup.PhaseDownloading)
+		for _, seedFile := range seedFiles {
+			name, checksum, err := backup.ParseSeedFile(seedFile)
+			if err != nil {
+				logger.Error(err, "error parsing seed file")
+				os.Exit(1)
+			}
+			logger.Info("pulling seed file", "file", name)
+			if err := seedStorage.Pull(ctx, name); err != nil {
+				logger.Error(err, "error pulling seed file", "file", name)
+				os.Exit(1)
+			}
+			if err := backup.VerifyChecksum(filepath.Join(path, name), checksum); err != nil {
+				logger.Error(err, "error verifying seed file", "file", name)
+				os.Exit(1)
+			}
+			logger.Info("verified seed file checksum", "file", name)
+		}
+		progress.SetPhase(backup.PhaseCompleted)
+	},
+}
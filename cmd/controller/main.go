@@ -30,7 +30,9 @@ import (
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	backupcmd "github.com/mariadb-operator/mariadb-operator/cmd/backup"
+	wsrepnotifycmd "github.com/mariadb-operator/mariadb-operator/cmd/wsrepnotify"
 	"github.com/mariadb-operator/mariadb-operator/controller"
+	"github.com/mariadb-operator/mariadb-operator/pkg/audit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/batch"
@@ -38,6 +40,8 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/deployment"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/endpoints"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/galera"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/rbac"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/replication"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
@@ -47,11 +51,14 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
 	"github.com/mariadb-operator/mariadb-operator/pkg/log"
 	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/notification"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	"github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -59,17 +66,22 @@ import (
 )
 
 var (
-	scheme            = runtime.NewScheme()
-	setupLog          = ctrl.Log.WithName("setup")
-	metricsAddr       string
-	healthAddr        string
-	logLevel          string
-	logTimeEncoder    string
-	logDev            bool
-	leaderElect       bool
-	requeueConnection time.Duration
-	requeueSql        time.Duration
-	requeueSqlJob     time.Duration
+	scheme              = runtime.NewScheme()
+	setupLog            = ctrl.Log.WithName("setup")
+	metricsAddr         string
+	healthAddr          string
+	logLevel            string
+	logTimeEncoder      string
+	logDev              bool
+	leaderElect         bool
+	requeueMariadb      time.Duration
+	requeueConnection   time.Duration
+	requeueSql          time.Duration
+	requeueSqlJob       time.Duration
+	sqlTimeout          time.Duration
+	reconcileCapacity   int
+	reconcileWait       time.Duration
+	notificationsConfig string
 )
 
 func init() {
@@ -85,9 +97,21 @@ func init() {
 		"epoch, millis, nano, iso8601, rfc3339 or rfc3339nano")
 	rootCmd.PersistentFlags().BoolVar(&logDev, "log-dev", false, "Enable development logs.")
 	rootCmd.PersistentFlags().BoolVar(&leaderElect, "leader-elect", false, "Enable leader election for controller manager.")
+	rootCmd.Flags().DurationVar(&requeueMariadb, "requeue-mariadb", 0, "The interval at which MariaDBs are requeued "+
+		"to revert changes in the generated resources. Disabled by default.")
 	rootCmd.Flags().DurationVar(&requeueConnection, "requeue-connection", 30*time.Second, "The interval at which Connections are requeued.")
 	rootCmd.Flags().DurationVar(&requeueSql, "requeue-sql", 30*time.Second, "The interval at which SQL objects are requeued.")
 	rootCmd.Flags().DurationVar(&requeueSqlJob, "requeue-sqljob", 5*time.Second, "The interval at which SqlJobs are requeued.")
+	rootCmd.Flags().DurationVar(&sqlTimeout, "sql-timeout", 30*time.Second, "The timeout applied to the SQL statements executed "+
+		"by the operator. It can be overridden per object. Use 0 to disable it.")
+	rootCmd.Flags().IntVar(&reconcileCapacity, "reconcile-capacity", 0, "The maximum number of reconciliations running "+
+		"concurrently across controllers. Critical reconciliations, such as failovers and Galera recovery, are always admitted "+
+		"and take precedence over the queued ones, whereas bulk work, such as User and Grant drift checks, is admitted last. "+
+		"Disabled by default.")
+	rootCmd.Flags().DurationVar(&reconcileWait, "reconcile-wait-timeout", 30*time.Second, "The maximum time that a "+
+		"reconciliation waits for capacity before being requeued. Only applies when --reconcile-capacity is set.")
+	rootCmd.Flags().StringVar(&notificationsConfig, "notifications-config", "", "Path to a YAML file containing the global "+
+		"notification targets, applied to all the MariaDBs.")
 }
 
 var rootCmd = &cobra.Command{
@@ -97,6 +121,8 @@ var rootCmd = &cobra.Command{
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		log.SetupLogger(logLevel, logTimeEncoder, logDev)
+		priority.SetDefaultCapacity(reconcileCapacity)
+		priority.SetDefaultWaitTimeout(reconcileWait)
 
 		ctx, cancel := signal.NotifyContext(context.Background(), []os.Signal{
 			syscall.SIGINT,
@@ -112,6 +138,7 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to get config")
 			os.Exit(1)
 		}
+		clientOpts := log.SetupKubeAPIWarningLogger(restConfig)
 		env, err := environment.GetEnvironment(ctx)
 		if err != nil {
 			setupLog.Error(err, "Error getting environment")
@@ -120,6 +147,7 @@ var rootCmd = &cobra.Command{
 
 		mgrOpts := ctrl.Options{
 			Scheme: scheme,
+			Client: clientOpts,
 			Metrics: metricsserver.Options{
 				BindAddress: metricsAddr,
 			},
@@ -149,8 +177,6 @@ var rootCmd = &cobra.Command{
 
 		client := mgr.GetClient()
 		scheme := mgr.GetScheme()
-		galeraRecorder := mgr.GetEventRecorderFor("galera")
-		replRecorder := mgr.GetEventRecorderFor("replication")
 
 		discoveryClient, err := discovery.NewDiscoveryClient(restConfig)
 		if err != nil {
@@ -158,8 +184,41 @@ var rootCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		kubeClient, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			setupLog.Error(err, "Error getting Kubernetes client")
+			os.Exit(1)
+		}
+
 		builder := builder.NewBuilder(scheme, env)
 		refResolver := refresolver.New(client)
+		sqlOpts := []sql.Opt{
+			sql.WithQueryTimeout(sqlTimeout),
+		}
+
+		var notifierOpts []notification.Option
+		if notificationsConfig != "" {
+			config, err := notification.ParseConfigFile(notificationsConfig)
+			if err != nil {
+				setupLog.Error(err, "Error parsing notifications config")
+				os.Exit(1)
+			}
+			notifierOpts = append(notifierOpts, notification.WithGlobalTargets(config.Targets))
+		}
+		notifier := notification.NewNotifier(refResolver, notifierOpts...)
+		auditor := audit.NewAuditor(client)
+		mariadbRecorder := notification.NewRecorder(
+			audit.NewRecorder(mgr.GetEventRecorderFor("mariadb"), auditor, "mariadb"),
+			notifier,
+		)
+		galeraRecorder := notification.NewRecorder(
+			audit.NewRecorder(mgr.GetEventRecorderFor("galera"), auditor, "galera"),
+			notifier,
+		)
+		replRecorder := notification.NewRecorder(
+			audit.NewRecorder(mgr.GetEventRecorderFor("replication"), auditor, "replication"),
+			notifier,
+		)
 
 		conditionReady := condition.NewReady()
 		conditionComplete := condition.NewComplete(client)
@@ -172,6 +231,7 @@ var rootCmd = &cobra.Command{
 		rbacReconciler := rbac.NewRBACReconiler(client, builder)
 		deployReconciler := deployment.NewDeploymentReconciler(client)
 		svcMonitorReconciler := servicemonitor.NewServiceMonitorReconciler(client)
+		actionLimiter := ratelimit.NewActionLimiter(client, mariadbRecorder)
 
 		replConfig := replication.NewReplicationConfig(client, builder, secretReconciler)
 		replicationReconciler := replication.NewReplicationReconciler(
@@ -182,6 +242,7 @@ var rootCmd = &cobra.Command{
 			replication.WithRefResolver(refResolver),
 			replication.WithSecretReconciler(secretReconciler),
 			replication.WithServiceReconciler(serviceReconciler),
+			replication.WithSqlOpts(sqlOpts...),
 		)
 		galeraReconciler := galera.NewGaleraReconciler(
 			client,
@@ -191,6 +252,9 @@ var rootCmd = &cobra.Command{
 			galera.WithRefResolver(refResolver),
 			galera.WithConfigMapReconciler(configMapReconciler),
 			galera.WithServiceReconciler(serviceReconciler),
+			galera.WithDeploymentReconciler(deployReconciler),
+			galera.WithActionLimiter(actionLimiter),
+			galera.WithSqlOpts(sqlOpts...),
 		)
 
 		podReplicationController := controller.NewPodController(
@@ -202,6 +266,8 @@ var rootCmd = &cobra.Command{
 				builder,
 				refResolver,
 				replConfig,
+				actionLimiter,
+				sqlOpts...,
 			),
 			[]string{
 				metadata.MariadbAnnotation,
@@ -211,7 +277,7 @@ var rootCmd = &cobra.Command{
 		podGaleraController := controller.NewPodController(
 			client,
 			refResolver,
-			controller.NewPodGaleraController(client, galeraRecorder),
+			controller.NewPodGaleraController(client, galeraRecorder, actionLimiter),
 			[]string{
 				metadata.MariadbAnnotation,
 				metadata.GaleraAnnotation,
@@ -221,13 +287,14 @@ var rootCmd = &cobra.Command{
 		if err = (&controller.MariaDBReconciler{
 			Client:   client,
 			Scheme:   scheme,
-			Recorder: mgr.GetEventRecorderFor("mariadb"),
+			Recorder: mariadbRecorder,
 
 			Environment:     env,
 			Builder:         builder,
 			RefResolver:     refResolver,
 			ConditionReady:  conditionReady,
 			DiscoveryClient: discoveryClient,
+			Auditor:         auditor,
 
 			ConfigMapReconciler:      configMapReconciler,
 			SecretReconciler:         secretReconciler,
@@ -236,9 +303,13 @@ var rootCmd = &cobra.Command{
 			RBACReconciler:           rbacReconciler,
 			DeploymentReconciler:     deployReconciler,
 			ServiceMonitorReconciler: svcMonitorReconciler,
+			ActionLimiter:            actionLimiter,
 
 			ReplicationReconciler: replicationReconciler,
 			GaleraReconciler:      galeraReconciler,
+
+			SqlOpts:         sqlOpts,
+			RequeueInterval: requeueMariadb,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "MariaDB")
 			os.Exit(1)
@@ -250,6 +321,7 @@ var rootCmd = &cobra.Command{
 			RefResolver:       refResolver,
 			ConditionComplete: conditionComplete,
 			BatchReconciler:   batchReconciler,
+			Recorder:          notification.NewRecorder(mgr.GetEventRecorderFor("backup"), notifier),
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "Backup")
 			os.Exit(1)
@@ -261,19 +333,26 @@ var rootCmd = &cobra.Command{
 			RefResolver:       refResolver,
 			ConditionComplete: conditionComplete,
 			BatchReconciler:   batchReconciler,
+			SqlOpts:           sqlOpts,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "restore")
 			os.Exit(1)
 		}
-		if err = controller.NewUserReconciler(client, refResolver, conditionReady, requeueSql).SetupWithManager(mgr); err != nil {
+		if err = controller.NewUserReconciler(client, refResolver, conditionReady,
+			notification.NewRecorder(mgr.GetEventRecorderFor("user"), notifier), requeueSql, sqlOpts...).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "User")
 			os.Exit(1)
 		}
-		if err = controller.NewGrantReconciler(client, refResolver, conditionReady, requeueSql).SetupWithManager(mgr); err != nil {
+		if err = controller.NewGrantReconciler(client, refResolver, conditionReady, requeueSql, sqlOpts...).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "Grant")
 			os.Exit(1)
 		}
-		if err = controller.NewDatabaseReconciler(client, refResolver, conditionReady, requeueSql).SetupWithManager(mgr); err != nil {
+		if err = controller.NewRoleReconciler(client, refResolver, conditionReady, requeueSql, sqlOpts...).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "Role")
+			os.Exit(1)
+		}
+		if err = controller.NewDatabaseReconciler(client, refResolver, conditionReady,
+			notification.NewRecorder(mgr.GetEventRecorderFor("database"), notifier), requeueSql, sqlOpts...).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "Database")
 			os.Exit(1)
 		}
@@ -300,6 +379,56 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to create controller", "controller", "SqlJob")
 			os.Exit(1)
 		}
+		if err = (&controller.MaintenanceJobReconciler{
+			Client:            client,
+			Scheme:            scheme,
+			Builder:           builder,
+			RefResolver:       refResolver,
+			ConditionComplete: conditionComplete,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "MaintenanceJob")
+			os.Exit(1)
+		}
+		if err = (&controller.MariaDBFleetReconciler{
+			Client:  client,
+			Scheme:  scheme,
+			Builder: builder,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "MariaDBFleet")
+			os.Exit(1)
+		}
+		if err = (&controller.RestoreRehearsalReconciler{
+			Client:      client,
+			Scheme:      scheme,
+			Builder:     builder,
+			RefResolver: refResolver,
+			SqlOpts:     sqlOpts,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "RestoreRehearsal")
+			os.Exit(1)
+		}
+		if err = (&controller.MariaDBTestReconciler{
+			Client:  client,
+			Scheme:  scheme,
+			Builder: builder,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "MariaDBTest")
+			os.Exit(1)
+		}
+		if err = (&controller.MaxScaleReconciler{
+			Client:               client,
+			Scheme:               scheme,
+			Builder:              builder,
+			RefResolver:          refResolver,
+			ConditionReady:       conditionReady,
+			Environment:          env,
+			SecretReconciler:     secretReconciler,
+			ServiceReconciler:    serviceReconciler,
+			DeploymentReconciler: deployReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "MaxScale")
+			os.Exit(1)
+		}
 		if err = podReplicationController.SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "PodReplication")
 			os.Exit(1)
@@ -308,10 +437,48 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to create controller", "controller", "PodGalera")
 			os.Exit(1)
 		}
+		if err := controller.NewPodGaleraStateController(client, refResolver, galeraRecorder).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "PodGaleraState")
+			os.Exit(1)
+		}
+		if err := controller.NewPodGaleraSegmentController(client, refResolver).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "PodGaleraSegment")
+			os.Exit(1)
+		}
+		if err := controller.NewPodCrashController(
+			client,
+			kubeClient,
+			refResolver,
+			configMapReconciler,
+			mariadbRecorder,
+			sqlOpts...,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "PodCrash")
+			os.Exit(1)
+		}
+		if err := controller.NewPodErrorLogController(
+			client,
+			kubeClient,
+			refResolver,
+			mariadbRecorder,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "PodErrorLog")
+			os.Exit(1)
+		}
+		if err := controller.NewPodGaleraStateTransferController(
+			client,
+			kubeClient,
+			refResolver,
+			galeraRecorder,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "PodGaleraStateTransfer")
+			os.Exit(1)
+		}
 		if err = (&controller.StatefulSetGaleraReconciler{
 			Client:      client,
 			RefResolver: refResolver,
 			Recorder:    galeraRecorder,
+			SqlOpts:     sqlOpts,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "StatefulSetGalera")
 			os.Exit(1)
@@ -329,6 +496,7 @@ func main() {
 	rootCmd.AddCommand(certControllerCmd)
 	rootCmd.AddCommand(webhookCmd)
 	rootCmd.AddCommand(backupcmd.RootCmd)
+	rootCmd.AddCommand(wsrepnotifycmd.RootCmd)
 
 	cobra.CheckErr(rootCmd.Execute())
 }
@@ -111,6 +111,10 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to create webhook", "webhook", "Grant")
 			os.Exit(1)
 		}
+		if err = (&mariadbv1alpha1.Role{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "Role")
+			os.Exit(1)
+		}
 		if err = (&mariadbv1alpha1.Database{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create webhook", "webhook", "Database")
 			os.Exit(1)
@@ -123,6 +127,30 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, "Unable to create webhook", "webhook", "SqlJob")
 			os.Exit(1)
 		}
+		if err = (&mariadbv1alpha1.MaintenanceJob{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "MaintenanceJob")
+			os.Exit(1)
+		}
+		if err = (&mariadbv1alpha1.MariaDBFleet{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "MariaDBFleet")
+			os.Exit(1)
+		}
+		if err = (&mariadbv1alpha1.MariaDBNamespacePolicy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "MariaDBNamespacePolicy")
+			os.Exit(1)
+		}
+		if err = (&mariadbv1alpha1.RestoreRehearsal{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "RestoreRehearsal")
+			os.Exit(1)
+		}
+		if err = (&mariadbv1alpha1.MariaDBTest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "MariaDBTest")
+			os.Exit(1)
+		}
+		if err = (&mariadbv1alpha1.MaxScale{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "MaxScale")
+			os.Exit(1)
+		}
 
 		if err := mgr.AddReadyzCheck("certs", func(_ *http.Request) error {
 			return checkCerts(dnsName, time.Now())
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	scheme      = runtime.NewScheme()
+	kubeconfig  string
+	kubeContext string
+	namespace   string
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(mariadbv1alpha1.AddToScheme(scheme))
+
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use.")
+	rootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "The name of the kubeconfig context to use.")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "The namespace of the MariaDB. "+
+		"It defaults to the namespace of the current kubeconfig context.")
+}
+
+var rootCmd = &cobra.Command{
+	Use:          "kubectl-mariadb",
+	Short:        "kubectl plugin for mariadb-operator.",
+	Long:         `Operate the MariaDB instances managed by mariadb-operator.`,
+	SilenceUsage: true,
+}
+
+// kubeClient returns a REST config, a client and the namespace to be used based on the kubeconfig flags.
+func kubeClient() (*rest.Config, client.Client, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		loadingRules.ExplicitPath = kubeconfig
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules,
+		&clientcmd.ConfigOverrides{
+			CurrentContext: kubeContext,
+		},
+	)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error getting REST config: %v", err)
+	}
+	ns := namespace
+	if ns == "" {
+		ns, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("error getting namespace: %v", err)
+		}
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error creating client: %v", err)
+	}
+	return restConfig, k8sClient, ns, nil
+}
+
+func main() {
+	rootCmd.AddCommand(sqlCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
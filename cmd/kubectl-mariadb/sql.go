@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/portforward"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	rootUser     = "root"
+	localHost    = "127.0.0.1"
+	passwordEnv  = "MYSQL_PWD"
+	nullValue    = "NULL"
+	primaryIndex = -1
+)
+
+var (
+	podIndex     int
+	execute      string
+	database     string
+	clientBinary string
+)
+
+// shellClients are the MariaDB command line clients looked up in the PATH to open interactive shells.
+var shellClients = []string{"mariadb", "mysql"}
+
+func init() {
+	sqlCmd.Flags().IntVar(&podIndex, "pod-index", primaryIndex, "Index of the Pod to connect to. "+
+		"It defaults to the current primary Pod.")
+	sqlCmd.Flags().StringVarP(&execute, "execute", "e", "", "Run a one-off query and exit instead of opening an interactive shell.")
+	sqlCmd.Flags().StringVarP(&database, "database", "d", "", "Database to connect to.")
+	sqlCmd.Flags().StringVar(&clientBinary, "client", "", "Path to the MariaDB command line client used by the interactive shell. "+
+		"By default, 'mariadb' and 'mysql' are looked up in the PATH.")
+}
+
+var sqlCmd = &cobra.Command{
+	Use:     "sql <mariadb>",
+	Aliases: []string{"shell"},
+	Short:   "Open an interactive MariaDB shell or run a one-off query.",
+	Long: `Port-forwards to the primary Pod, or to the Pod specified by --pod-index, fetches the root credentials ` +
+		`from the Secret referenced by the MariaDB and opens an interactive shell, or runs the query provided via --execute.`,
+	Example: `  kubectl mariadb sql mariadb
+  kubectl mariadb sql mariadb-repl --pod-index 1 -n databases
+  kubectl mariadb sql mariadb -d mariadb -e "SELECT * FROM users"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer cancel()
+
+		restConfig, k8sClient, ns, err := kubeClient()
+		if err != nil {
+			return err
+		}
+
+		var mariadb mariadbv1alpha1.MariaDB
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: args[0], Namespace: ns}, &mariadb); err != nil {
+			return fmt.Errorf("error getting MariaDB: %v", err)
+		}
+		pod, err := targetPod(&mariadb)
+		if err != nil {
+			return err
+		}
+		password, err := refresolver.New(k8sClient).SecretKeyRef(ctx, mariadb.Spec.RootPasswordSecretKeyRef, ns)
+		if err != nil {
+			return fmt.Errorf("error getting root password: %v", err)
+		}
+
+		forwarder := portforward.NewPortForwarder(restConfig, ns, pod, mariadb.Spec.Port, os.Stderr)
+		localPort, stop, err := forwarder.Start(ctx)
+		if err != nil {
+			return fmt.Errorf("error port-forwarding to Pod '%s': %v", pod, err)
+		}
+		defer stop()
+
+		if execute != "" {
+			return runQuery(ctx, cmd.OutOrStdout(), int32(localPort), password)
+		}
+		// The shell handles the interrupts by itself, i.e. Ctrl+C cancels the current query.
+		signal.Ignore(syscall.SIGINT)
+		return runShell(cmd, localPort, password)
+	},
+}
+
+func targetPod(mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	if podIndex != primaryIndex {
+		if podIndex < 0 || podIndex >= int(mariadb.ScaledReplicas()) {
+			return "", fmt.Errorf("invalid Pod index %d, it must be between 0 and %d", podIndex, mariadb.ScaledReplicas()-1)
+		}
+		return statefulset.PodName(mariadb.ObjectMeta, podIndex), nil
+	}
+	if mariadb.Status.CurrentPrimary != nil {
+		return *mariadb.Status.CurrentPrimary, nil
+	}
+	return statefulset.PodName(mariadb.ObjectMeta, 0), nil
+}
+
+func runQuery(ctx context.Context, out io.Writer, port int32, password string) error {
+	db, err := sqlClient.ConnectWithOpts(sqlClient.Opts{
+		Username: rootUser,
+		Password: password,
+		Host:     localHost,
+		Port:     port,
+		Database: database,
+	})
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, execute)
+	if err != nil {
+		return fmt.Errorf("error running query: %v", err)
+	}
+	defer rows.Close()
+
+	return printRows(out, rows)
+}
+
+func printRows(out io.Writer, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("error getting columns: %v", err)
+	}
+	if len(columns) == 0 {
+		fmt.Fprintln(out, "Query OK")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("error scanning row: %v", err)
+		}
+		fields := make([]string, len(values))
+		for i, v := range values {
+			if v.Valid {
+				fields[i] = v.String
+			} else {
+				fields[i] = nullValue
+			}
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading rows: %v", err)
+	}
+	return w.Flush()
+}
+
+func runShell(cmd *cobra.Command, port uint16, password string) error {
+	binary, err := shellBinary()
+	if err != nil {
+		return err
+	}
+	shellArgs := []string{
+		"--host", localHost,
+		"--port", strconv.Itoa(int(port)),
+		"--user", rootUser,
+	}
+	if database != "" {
+		shellArgs = append(shellArgs, "--database", database)
+	}
+
+	shell := exec.Command(binary, shellArgs...)
+	shell.Stdin = os.Stdin
+	shell.Stdout = cmd.OutOrStdout()
+	shell.Stderr = cmd.ErrOrStderr()
+	shell.Env = append(os.Environ(), fmt.Sprintf("%s=%s", passwordEnv, password))
+
+	if err := shell.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("shell exited with code %d", exitErr.ExitCode())
+		}
+		return fmt.Errorf("error running shell: %v", err)
+	}
+	return nil
+}
+
+func shellBinary() (string, error) {
+	if clientBinary != "" {
+		return clientBinary, nil
+	}
+	for _, c := range shellClients {
+		if path, err := exec.LookPath(c); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("unable to find any of %v in the PATH. Install a MariaDB client, specify it via --client "+
+		"or run a one-off query via --execute", shellClients)
+}
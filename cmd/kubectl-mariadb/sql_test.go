@@ -0,0 +1,157 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestTargetPod(t *testing.T) {
+	objMeta := metav1.ObjectMeta{
+		Name:      "mariadb",
+		Namespace: "default",
+	}
+	tests := []struct {
+		name     string
+		mariadb  *mariadbv1alpha1.MariaDB
+		podIndex int
+		wantPod  string
+		wantErr  bool
+	}{
+		{
+			name: "current primary",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replicas: 3,
+				},
+				Status: mariadbv1alpha1.MariaDBStatus{
+					CurrentPrimary: ptr.To("mariadb-1"),
+				},
+			},
+			podIndex: primaryIndex,
+			wantPod:  "mariadb-1",
+		},
+		{
+			name: "no current primary",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replicas: 3,
+				},
+			},
+			podIndex: primaryIndex,
+			wantPod:  "mariadb-0",
+		},
+		{
+			name: "pod index",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replicas: 3,
+				},
+				Status: mariadbv1alpha1.MariaDBStatus{
+					CurrentPrimary: ptr.To("mariadb-1"),
+				},
+			},
+			podIndex: 2,
+			wantPod:  "mariadb-2",
+		},
+		{
+			name: "pod index out of bounds",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replicas: 3,
+				},
+			},
+			podIndex: 3,
+			wantErr:  true,
+		},
+		{
+			name: "negative pod index",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replicas: 3,
+				},
+			},
+			podIndex: -2,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podIndex = tt.podIndex
+			defer func() { podIndex = primaryIndex }()
+
+			pod, err := targetPod(tt.mariadb)
+			if tt.wantErr && err == nil {
+				t.Fatal("expecting error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pod != tt.wantPod {
+				t.Errorf("expecting Pod to be '%s', got '%s'", tt.wantPod, pod)
+			}
+		})
+	}
+}
+
+func TestShellBinary(t *testing.T) {
+	binDir := t.TempDir()
+	mysqlPath := filepath.Join(binDir, "mysql")
+	if err := os.WriteFile(mysqlPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("unexpected error writing client: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		clientBinary string
+		wantBinary   string
+		wantErr      bool
+	}{
+		{
+			name:         "client flag",
+			path:         binDir,
+			clientBinary: "/usr/local/bin/mariadb",
+			wantBinary:   "/usr/local/bin/mariadb",
+		},
+		{
+			name:       "client in PATH",
+			path:       binDir,
+			wantBinary: mysqlPath,
+		},
+		{
+			name:    "no client in PATH",
+			path:    t.TempDir(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PATH", tt.path)
+			clientBinary = tt.clientBinary
+			defer func() { clientBinary = "" }()
+
+			binary, err := shellBinary()
+			if tt.wantErr && err == nil {
+				t.Fatal("expecting error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if binary != tt.wantBinary {
+				t.Errorf("expecting binary to be '%s', got '%s'", tt.wantBinary, binary)
+			}
+		})
+	}
+}
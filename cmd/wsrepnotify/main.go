@@ -0,0 +1,90 @@
+package wsrepnotify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/log"
+	"github.com/mariadb-operator/mariadb-operator/pkg/wsrepnotify"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	logger       = ctrl.Log
+	stateFile    string
+	podName      string
+	podNamespace string
+	interval     time.Duration
+)
+
+func init() {
+	RootCmd.Flags().StringVar(&stateFile, "state-file", "/var/lib/mysql/wsrep-notify.state",
+		"Path to the file where wsrep_notify_cmd writes the Galera node state.")
+	RootCmd.Flags().StringVar(&podName, "pod-name", "", "Name of the Pod whose annotations are patched.")
+	RootCmd.Flags().StringVar(&podNamespace, "pod-namespace", "", "Namespace of the Pod whose annotations are patched.")
+	RootCmd.Flags().DurationVar(&interval, "interval", time.Second, "The interval at which the state file is read.")
+	for _, flag := range []string{"pod-name", "pod-namespace"} {
+		if err := RootCmd.MarkFlagRequired(flag); err != nil {
+			fmt.Printf("error marking '%s' flag as required: %v", flag, err)
+			os.Exit(1)
+		}
+	}
+}
+
+var RootCmd = &cobra.Command{
+	Use:   "wsrep-notify",
+	Short: "Publish Galera node states.",
+	Long:  `Publishes the Galera node state written by wsrep_notify_cmd in the annotations of the Pod.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setupLogger(cmd); err != nil {
+			fmt.Printf("error setting up logger: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("starting wsrep notify", "state-file", stateFile, "pod", podName)
+
+		ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer cancel()
+
+		restConfig, err := ctrl.GetConfig()
+		if err != nil {
+			logger.Error(err, "error getting Kubernetes config")
+			os.Exit(1)
+		}
+		k8sClient, err := client.New(restConfig, client.Options{})
+		if err != nil {
+			logger.Error(err, "error creating Kubernetes client")
+			os.Exit(1)
+		}
+
+		podKey := types.NamespacedName{
+			Name:      podName,
+			Namespace: podNamespace,
+		}
+		wsrepnotify.NewStateWriter(k8sClient, stateFile, podKey, logger).Run(ctx, interval)
+		logger.Info("stopping wsrep notify")
+	},
+}
+
+func setupLogger(cmd *cobra.Command) error {
+	logLevel, err := cmd.Flags().GetString("log-level")
+	if err != nil {
+		return fmt.Errorf("error getting 'log-level' flag: %v\n", err)
+	}
+	logTimeEncoder, err := cmd.Flags().GetString("log-time-encoder")
+	if err != nil {
+		return fmt.Errorf("error getting 'log-time-encoder' flag: %v\n", err)
+	}
+	logDev, err := cmd.Flags().GetBool("log-dev")
+	if err != nil {
+		return fmt.Errorf("error getting 'log-dev' flag: %v\n", err)
+	}
+	log.SetupLogger(logLevel, logTimeEncoder, logDev)
+	return nil
+}
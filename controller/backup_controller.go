@@ -10,11 +10,14 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/batch"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,6 +30,7 @@ type BackupReconciler struct {
 	RefResolver       *refresolver.RefResolver
 	ConditionComplete *condition.Complete
 	BatchReconciler   *batch.BatchReconciler
+	Recorder          record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=backups,verbs=get;list;watch;create;update;patch;delete
@@ -35,6 +39,7 @@ type BackupReconciler struct {
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -71,15 +76,25 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	batchErr = multierror.Append(batchErr, err)
 
 	patcher, err := r.patcher(ctx, err, req.NamespacedName, &backup)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return ctrl.Result{}, client.IgnoreNotFound(err)
-		}
+	if err != nil && !apierrors.IsNotFound(err) {
 		return ctrl.Result{}, fmt.Errorf("error getting patcher for Backup: %v", err)
 	}
+	// the Job may have been garbage collected after finishing, keeping the status of the Backup.
+	if patcher != nil {
+		wasFailed := backup.IsFailed()
+		err = r.patchStatus(ctx, &backup, patcher)
+		batchErr = multierror.Append(batchErr, err)
+
+		if err == nil && !wasFailed && backup.IsFailed() {
+			r.Recorder.Eventf(&backup, corev1.EventTypeWarning, mariadbv1alpha1.ReasonBackupFailed, "Backup '%s' failed", backup.Name)
+		}
+	}
 
-	err = r.patchStatus(ctx, &backup, patcher)
-	batchErr = multierror.Append(batchErr, err)
+	if backup.IsComplete() {
+		if err := r.reconcileRetention(ctx, &backup); err != nil {
+			batchErr = multierror.Append(batchErr, fmt.Errorf("error reconciling retention: %v", err))
+		}
+	}
 
 	if err := batchErr.ErrorOrNil(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error creating Job: %v", err)
@@ -125,5 +140,5 @@ func (r *BackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&mariadbv1alpha1.Backup{}).
 		Owns(&batchv1.CronJob{}).
 		Owns(&batchv1.Job{}).
-		Complete(r)
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
 }
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	backuppkg "github.com/mariadb-operator/mariadb-operator/pkg/backup"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// jobNameLabel is set by the Job controller in the Pods, the batch.kubernetes.io/job-name label is only available from Kubernetes 1.27.
+const jobNameLabel = "job-name"
+
+// reconcileRetention reports the backups deleted by the retention policy in the last completed Backup Job,
+// along with the artifacts available in the storage, which are read from the termination message of the mariadb-operator container.
+// The finished Jobs are only garbage collected once their result has been recorded in the Backup status.
+func (r *BackupReconciler) reconcileRetention(ctx context.Context, backup *mariadbv1alpha1.Backup) error {
+	jobs, err := r.backupJobs(ctx, backup)
+	if err != nil {
+		return err
+	}
+	if job := lastCompletedJob(jobs); job != nil && !isRetentionReported(backup, job) {
+		if err := r.reportRetention(ctx, backup, job); err != nil {
+			return err
+		}
+	}
+	return r.expireJobs(ctx, backup, jobs)
+}
+
+func (r *BackupReconciler) reportRetention(ctx context.Context, backup *mariadbv1alpha1.Backup, job *batchv1.Job) error {
+	result, err := r.pruneResult(ctx, job)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	patch := client.MergeFrom(backup.DeepCopy())
+	backup.Status.Retention = &mariadbv1alpha1.BackupRetentionStatus{
+		LastPruneTime:   job.Status.CompletionTime,
+		PrunedBackups:   int32(result.Pruned),
+		RetainedBackups: int32(result.Retained),
+		PrunedFiles:     result.Files,
+	}
+	if result.Artifacts != nil {
+		backup.Status.Artifacts = backupArtifacts(backup, result.Artifacts)
+	}
+	if err := r.Client.Status().Patch(ctx, backup, patch); err != nil {
+		return fmt.Errorf("error patching Backup status: %v", err)
+	}
+
+	if result.Pruned > 0 {
+		log.FromContext(ctx).Info("Old backups pruned", "pruned", result.Pruned, "retained", result.Retained)
+		r.Recorder.Eventf(backup, corev1.EventTypeNormal, mariadbv1alpha1.ReasonBackupsPruned,
+			"Pruned %d backups, %d retained: %s", result.Pruned, result.Retained, strings.Join(result.Files, ", "))
+	}
+	return nil
+}
+
+// expireJobs sets the TTL of the finished Backup Jobs, which is not set when building them
+// so the Jobs are not garbage collected before their result is recorded in the Backup status.
+func (r *BackupReconciler) expireJobs(ctx context.Context, backup *mariadbv1alpha1.Backup, jobs []batchv1.Job) error {
+	ttl := backup.Spec.TTLSecondsAfterFinished
+	if ttl == nil {
+		return nil
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		if !isJobFinished(job) || ptr.Equal(job.Spec.TTLSecondsAfterFinished, ttl) {
+			continue
+		}
+		patch := client.MergeFrom(job.DeepCopy())
+		job.Spec.TTLSecondsAfterFinished = ptr.To(*ttl)
+		if err := r.Patch(ctx, job, patch); err != nil {
+			return fmt.Errorf("error patching Job '%s': %v", job.Name, err)
+		}
+	}
+	return nil
+}
+
+// backupJobs returns the Jobs of the Backup, either created by the operator or by the CronJob.
+func (r *BackupReconciler) backupJobs(ctx context.Context, backup *mariadbv1alpha1.Backup) ([]batchv1.Job, error) {
+	var jobList batchv1.JobList
+	if err := r.List(ctx, &jobList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{labels.BackupLabel: backup.Name}); err != nil {
+		return nil, fmt.Errorf("error listing Jobs: %v", err)
+	}
+
+	var jobs []batchv1.Job
+	for _, job := range jobList.Items {
+		owner := metav1.GetControllerOf(&job)
+		if owner == nil || owner.Name != backup.Name || (owner.Kind != "Backup" && owner.Kind != "CronJob") {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// lastCompletedJob returns the most recent successful Job.
+func lastCompletedJob(jobs []batchv1.Job) *batchv1.Job {
+	var lastJob *batchv1.Job
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime == nil {
+			continue
+		}
+		if lastJob == nil || lastJob.Status.CompletionTime.Before(job.Status.CompletionTime) {
+			lastJob = job
+		}
+	}
+	return lastJob
+}
+
+func isRetentionReported(backup *mariadbv1alpha1.Backup, job *batchv1.Job) bool {
+	retention := backup.Status.Retention
+	return retention != nil && retention.LastPruneTime != nil && !retention.LastPruneTime.Before(job.Status.CompletionTime)
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BackupReconciler) pruneResult(ctx context.Context, job *batchv1.Job) (*backuppkg.PruneResult, error) {
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(job.Namespace), client.MatchingLabels{jobNameLabel: job.Name}); err != nil {
+		return nil, fmt.Errorf("error listing Job Pods: %v", err)
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if status.Name != "mariadb-operator" || terminated == nil || terminated.Message == "" {
+				continue
+			}
+			result, err := backuppkg.ParsePruneResult(terminated.Message)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing prune result of Pod '%s': %v", pod.Name, err)
+			}
+			return result, nil
+		}
+	}
+	return nil, nil
+}
+
+// backupArtifacts converts the artifacts reported by the Backup Job, marking the ones moved to a colder storage class by a tiering rule.
+func backupArtifacts(backup *mariadbv1alpha1.Backup, artifacts []backuppkg.Artifact) []mariadbv1alpha1.BackupArtifact {
+	backupArtifacts := make([]mariadbv1alpha1.BackupArtifact, len(artifacts))
+	for i, a := range artifacts {
+		backupArtifacts[i] = mariadbv1alpha1.BackupArtifact{
+			Name:         a.Name,
+			Database:     a.Database,
+			Size:         a.Size,
+			Timestamp:    metav1.NewTime(a.Timestamp),
+			Type:         mariadbv1alpha1.BackupArtifactType(a.Type),
+			StorageClass: a.StorageClass,
+		}
+		if a.StorageClass == "" {
+			continue
+		}
+		if rule := backup.TieringRule(a.StorageClass); rule != nil {
+			backupArtifacts[i].Tiered = true
+			if latency := rule.RestoreLatencyOrDefault(); latency > 0 {
+				backupArtifacts[i].RestoreLatency = &metav1.Duration{Duration: latency}
+			}
+		}
+	}
+	return backupArtifacts
+}
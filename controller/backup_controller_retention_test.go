@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Backup retention", func() {
+	newBackup := func() *mariadbv1alpha1.Backup {
+		return &mariadbv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup-retention",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.BackupSpec{
+				TTLSecondsAfterFinished: ptr.To(int32(3600)),
+			},
+		}
+	}
+	newJob := func(name string, backupLabel string, completionTime *time.Time) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: mariadbv1alpha1.GroupVersion.String(),
+						Kind:       "Backup",
+						Name:       "backup-retention",
+						Controller: ptr.To(true),
+					},
+				},
+			},
+		}
+		if backupLabel != "" {
+			job.Labels = map[string]string{
+				labels.BackupLabel: backupLabel,
+			}
+		}
+		if completionTime != nil {
+			job.Status.CompletionTime = ptr.To(metav1.NewTime(*completionTime))
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				},
+			}
+		}
+		return job
+	}
+	newJobPod := func(job *batchv1.Job, message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      job.Name + "-pod",
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					jobNameLabel: job.Name,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "mariadb-operator",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: message,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	newReconciler := func(objs ...client.Object) (*BackupReconciler, client.Client) {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&mariadbv1alpha1.Backup{}).
+			Build()
+		return &BackupReconciler{
+			Client:   c,
+			Recorder: record.NewFakeRecorder(10),
+		}, c
+	}
+
+	It("Should get the last completed Job labeled with the Backup", func() {
+		backup := newBackup()
+		now := time.Now().Truncate(time.Second)
+		r, _ := newReconciler(
+			newJob("backup-retention-old", backup.Name, ptr.To(now.Add(-2*time.Hour))),
+			newJob("backup-retention-last", backup.Name, ptr.To(now.Add(-time.Hour))),
+			newJob("backup-retention-running", backup.Name, nil),
+			newJob("backup-retention-unlabeled", "", ptr.To(now)),
+			newJob("backup-retention-other", "other", ptr.To(now)),
+		)
+
+		jobs, err := r.backupJobs(testCtx, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(jobs).To(HaveLen(3))
+
+		job := lastCompletedJob(jobs)
+		Expect(job).ToNot(BeNil())
+		Expect(job.Name).To(Equal("backup-retention-last"))
+	})
+
+	It("Should not get any Job when none has completed", func() {
+		Expect(lastCompletedJob([]batchv1.Job{*newJob("backup-retention-running", "backup-retention", nil)})).To(BeNil())
+	})
+
+	It("Should record the retention before setting the TTL of the Jobs", func() {
+		backup := newBackup()
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		job := newJob("backup-retention-last", backup.Name, &completionTime)
+		running := newJob("backup-retention-running", backup.Name, nil)
+		r, c := newReconciler(
+			backup,
+			job,
+			running,
+			newJobPod(job, `{"pruned":1,"retained":2,"files":["backup.2023-12-18T09:00:00Z.sql"]}`),
+		)
+
+		Expect(r.reconcileRetention(testCtx, backup)).To(Succeed())
+
+		var gotBackup mariadbv1alpha1.Backup
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(backup), &gotBackup)).To(Succeed())
+		Expect(gotBackup.Status.Retention).ToNot(BeNil())
+		Expect(gotBackup.Status.Retention.LastPruneTime.Time).To(BeTemporally("==", completionTime))
+		Expect(gotBackup.Status.Retention.PrunedBackups).To(Equal(int32(1)))
+		Expect(gotBackup.Status.Retention.RetainedBackups).To(Equal(int32(2)))
+
+		var gotJob batchv1.Job
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(job), &gotJob)).To(Succeed())
+		Expect(gotJob.Spec.TTLSecondsAfterFinished).To(Equal(ptr.To(int32(3600))))
+
+		var gotRunning batchv1.Job
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(running), &gotRunning)).To(Succeed())
+		Expect(gotRunning.Spec.TTLSecondsAfterFinished).To(BeNil())
+	})
+
+	It("Should not set the TTL of the Jobs when the retention could not be recorded", func() {
+		backup := newBackup()
+		job := newJob("backup-retention-last", backup.Name, ptr.To(time.Now()))
+		r, c := newReconciler(
+			backup,
+			job,
+			newJobPod(job, "invalid"),
+		)
+
+		Expect(r.reconcileRetention(testCtx, backup)).ToNot(Succeed())
+
+		var gotJob batchv1.Job
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(job), &gotJob)).To(Succeed())
+		Expect(gotJob.Spec.TTLSecondsAfterFinished).To(BeNil())
+	})
+
+	It("Should not report the retention again", func() {
+		backup := newBackup()
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		backup.Status.Retention = &mariadbv1alpha1.BackupRetentionStatus{
+			LastPruneTime: ptr.To(metav1.NewTime(completionTime)),
+		}
+		job := newJob("backup-retention-last", backup.Name, &completionTime)
+
+		Expect(isRetentionReported(backup, job)).To(BeTrue())
+		backup.Status.Retention.LastPruneTime = ptr.To(metav1.NewTime(completionTime.Add(-time.Hour)))
+		Expect(isRetentionReported(backup, job)).To(BeFalse())
+	})
+})
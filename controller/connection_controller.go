@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/url"
 	"strconv"
 	"strings"
@@ -15,7 +16,9 @@ import (
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/health"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	clientsql "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
@@ -146,7 +149,7 @@ func (r *ConnectionReconciler) reconcileSecret(ctx context.Context, conn *mariad
 		}
 		host = statefulset.ServiceFQDN(objMeta)
 	} else {
-		host = statefulset.ServiceFQDN(mdb.ObjectMeta)
+		host = statefulset.ServiceFQDNWithService(mdb.ObjectMeta, mdb.ServiceKey().Name)
 	}
 	mdbOpts := clientsql.Opts{
 		Username: conn.Spec.Username,
@@ -161,6 +164,9 @@ func (r *ConnectionReconciler) reconcileSecret(ctx context.Context, conn *mariad
 
 	var existingSecret corev1.Secret
 	if err := r.Get(ctx, key, &existingSecret); err == nil {
+		if err := r.refreshSecret(ctx, conn, &existingSecret, mdbOpts); err != nil {
+			return err
+		}
 		if err := r.healthCheck(ctx, conn, mdbOpts); err != nil {
 			log.FromContext(ctx).Info("Error checking connection health", "err", err)
 			return errConnHealthCheck
@@ -168,91 +174,143 @@ func (r *ConnectionReconciler) reconcileSecret(ctx context.Context, conn *mariad
 		return nil
 	}
 
-	dsn, err := clientsql.BuildDSN(mdbOpts)
+	data, err := connSecretData(conn, mdbOpts)
 	if err != nil {
-		return fmt.Errorf("error building DSN: %v", err)
+		return err
 	}
-
 	secretOpts := builder.SecretOpts{
-		MariaDB: mdb,
-		Key:     key,
-		Data: map[string][]byte{
-			conn.SecretKey(): []byte(dsn),
-		},
+		MariaDB:     mdb,
+		Key:         key,
+		Data:        data,
 		Labels:      conn.Spec.SecretTemplate.Labels,
 		Annotations: conn.Spec.SecretTemplate.Annotations,
 	}
+	if rotatedAt, ok := conn.Annotations[metadata.PasswordRotatedAtAnnotation]; ok {
+		secretOpts.Annotations = maps.Clone(secretOpts.Annotations)
+		if secretOpts.Annotations == nil {
+			secretOpts.Annotations = map[string]string{}
+		}
+		secretOpts.Annotations[metadata.PasswordRotatedAtAnnotation] = rotatedAt
+	}
+
+	secret, err := r.Builder.BuildSecret(secretOpts, conn)
+	if err != nil {
+		return fmt.Errorf("error building Secret: %v", err)
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return fmt.Errorf("error creating Secret: %v", err)
+	}
+	return nil
+}
+
+// connSecretData renders the keys of the Connection Secret: the DSN and the ones defined in the Secret template.
+func connSecretData(conn *mariadbv1alpha1.Connection, mdbOpts clientsql.Opts) (map[string][]byte, error) {
+	dsn, err := clientsql.BuildDSN(mdbOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error building DSN: %v", err)
+	}
+	data := map[string][]byte{
+		conn.SecretKey(): []byte(dsn),
+	}
 
+	templateData := connTemplateData(mdbOpts)
 	if formatString := conn.Spec.SecretTemplate.Format; formatString != nil {
-		tmpl := template.Must(template.New("").Parse(*formatString))
-		builder := &strings.Builder{}
-
-		err := tmpl.Execute(builder, map[string]string{
-			"Username": mdbOpts.Username,
-			"Password": mdbOpts.Password,
-			"Host":     mdbOpts.Host,
-			"Port":     strconv.Itoa(int(mdbOpts.Port)),
-			"Database": mdbOpts.Database,
-			"Params": func() string {
-				v := url.Values{}
-				for key, value := range mdbOpts.Params {
-					v.Add(key, value)
-				}
-
-				s := v.Encode()
-				if s == "" {
-					return s
-				}
-				return fmt.Sprintf("?%s", s)
-			}(),
-		})
+		dsn, err := renderConnTemplate(*formatString, templateData)
 		if err != nil {
-			return fmt.Errorf("error parsing DSN template: %v", err)
+			return nil, fmt.Errorf("error parsing DSN template: %v", err)
 		}
-		secretOpts.Data[conn.SecretKey()] = []byte(builder.String())
+		data[conn.SecretKey()] = []byte(dsn)
 	}
 	if usernameKey := conn.Spec.SecretTemplate.UsernameKey; usernameKey != nil {
-		secretOpts.Data[*usernameKey] = []byte(mdbOpts.Username)
+		data[*usernameKey] = []byte(mdbOpts.Username)
 	}
 	if passwordKey := conn.Spec.SecretTemplate.PasswordKey; passwordKey != nil {
-		secretOpts.Data[*passwordKey] = []byte(mdbOpts.Password)
+		data[*passwordKey] = []byte(mdbOpts.Password)
 	}
 	if hostKey := conn.Spec.SecretTemplate.HostKey; hostKey != nil {
-		secretOpts.Data[*hostKey] = []byte(mdbOpts.Host)
+		data[*hostKey] = []byte(mdbOpts.Host)
 	}
 	if portKey := conn.Spec.SecretTemplate.PortKey; portKey != nil {
-		secretOpts.Data[*portKey] = []byte(strconv.Itoa(int(mdbOpts.Port)))
+		data[*portKey] = []byte(strconv.Itoa(int(mdbOpts.Port)))
 	}
 	if databaseKey := conn.Spec.SecretTemplate.DatabaseKey; databaseKey != nil && mdbOpts.Database != "" {
-		secretOpts.Data[*databaseKey] = []byte(mdbOpts.Database)
+		data[*databaseKey] = []byte(mdbOpts.Database)
+	}
+	if optionsFileKey := conn.Spec.SecretTemplate.OptionsFileKey; optionsFileKey != nil {
+		data[*optionsFileKey] = []byte(connOptionsFile(mdbOpts))
+	}
+	for _, f := range conn.Spec.SecretTemplate.Files {
+		content, err := renderConnTemplate(f.Format, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template for Secret key '%s': %v", f.Key, err)
+		}
+		data[f.Key] = []byte(content)
 	}
+	return data, nil
+}
 
-	secret, err := r.Builder.BuildSecret(secretOpts, conn)
-	if err != nil {
-		return fmt.Errorf("error building Secret: %v", err)
+func connTemplateData(opts clientsql.Opts) map[string]string {
+	return map[string]string{
+		"Username": opts.Username,
+		"Password": opts.Password,
+		"Host":     opts.Host,
+		"Port":     strconv.Itoa(int(opts.Port)),
+		"Database": opts.Database,
+		"Params": func() string {
+			v := url.Values{}
+			for key, value := range opts.Params {
+				v.Add(key, value)
+			}
+
+			s := v.Encode()
+			if s == "" {
+				return s
+			}
+			return fmt.Sprintf("?%s", s)
+		}(),
 	}
+}
 
-	if err := r.Create(ctx, secret); err != nil {
-		return fmt.Errorf("error creating Secret: %v", err)
+func renderConnTemplate(format string, data map[string]string) (string, error) {
+	tmpl, err := template.New("").Parse(format)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	builder := &strings.Builder{}
+	if err := tmpl.Execute(builder, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// connOptionsFile renders a MariaDB client option file, see: https://mariadb.com/kb/en/configuring-mariadb-with-option-files/.
+func connOptionsFile(opts clientsql.Opts) string {
+	b := &strings.Builder{}
+	b.WriteString("[client]\n")
+	fmt.Fprintf(b, "user=%s\n", opts.Username)
+	fmt.Fprintf(b, "password=\"%s\"\n", strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(opts.Password))
+	fmt.Fprintf(b, "host=%s\n", opts.Host)
+	fmt.Fprintf(b, "port=%d\n", opts.Port)
+	if opts.Database != "" {
+		fmt.Fprintf(b, "database=%s\n", opts.Database)
+	}
+	return b.String()
 }
 
 func (r *ConnectionReconciler) healthCheck(ctx context.Context, conn *mariadbv1alpha1.Connection, clientOpts clientsql.Opts) error {
-	log.FromContext(ctx).V(1).Info("Checking connection health")
-	db, err := clientsql.ConnectWithOpts(clientOpts)
-	if err != nil {
+	log.FromContext(ctx).V(1).Info("Checking connection health", "type", conn.Spec.HealthCheck.TypeOrDefault())
+	if err := checkConnectionHealth(ctx, conn.Spec.HealthCheck, clientOpts); err != nil {
 		var connErr *multierror.Error
 		connErr = multierror.Append(connErr, err)
 
 		patchErr := r.patchStatus(
 			ctx,
 			conn,
-			r.ConditionReady.PatcherHealthy(fmt.Errorf("failed to connect: %v", err)),
+			r.ConditionReady.PatcherHealthy(err),
 		)
 		return multierror.Append(connErr, patchErr)
 	}
-	defer db.Close()
 
 	if err := r.patchStatus(ctx, conn, r.ConditionReady.PatcherHealthy(nil)); err != nil {
 		return fmt.Errorf("error patching connection status: %v", err)
@@ -301,5 +359,5 @@ func (r *ConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&mariadbv1alpha1.Connection{}).
 		Owns(&corev1.Secret{}).
-		Complete(r)
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
 }
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	clientsql "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+)
+
+// checkConnectionHealth performs the health check configured in the Connection.
+func checkConnectionHealth(ctx context.Context, healthCheck *mariadbv1alpha1.HealthCheck, clientOpts clientsql.Opts) error {
+	if healthCheck != nil && healthCheck.Timeout != nil {
+		timeout := healthCheck.Timeout.Duration
+		clientOpts.Timeout = &timeout
+		clientOpts.QueryTimeout = &timeout
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	checkType := healthCheck.TypeOrDefault()
+	if checkType == mariadbv1alpha1.HealthCheckTypeTCP {
+		return checkTCP(ctx, clientOpts)
+	}
+
+	client, err := clientsql.NewClientWithOpts(clientOpts)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %v", err)
+	}
+	defer client.Close()
+
+	switch checkType {
+	case mariadbv1alpha1.HealthCheckTypeSelect:
+		if err := client.Ping(ctx); err != nil {
+			return fmt.Errorf("failed to execute 'SELECT 1': %v", err)
+		}
+	case mariadbv1alpha1.HealthCheckTypeReplicationLag:
+		lag, err := client.ReplicationLag(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get replication lag: %v", err)
+		}
+		if lag != nil && *lag > healthCheck.MaxReplicationLag.Duration {
+			return fmt.Errorf("replication lag '%s' exceeds the maximum allowed '%s'", *lag, healthCheck.MaxReplicationLag.Duration)
+		}
+	case mariadbv1alpha1.HealthCheckTypeQuery:
+		if err := client.ReadOnlyQuery(ctx, *healthCheck.Query); err != nil {
+			return fmt.Errorf("failed to execute health check query: %v", err)
+		}
+	}
+	return nil
+}
+
+func checkTCP(ctx context.Context, clientOpts clientsql.Opts) error {
+	dialer := net.Dialer{
+		Timeout: 5 * time.Second,
+	}
+	if clientOpts.Timeout != nil {
+		dialer.Timeout = *clientOpts.Timeout
+	}
+	addr := net.JoinHostPort(clientOpts.Host, strconv.Itoa(int(clientOpts.Port)))
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %v", err)
+	}
+	return conn.Close()
+}
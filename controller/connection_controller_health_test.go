@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	clientsql "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Connection health check", func() {
+	tcpHealthCheck := &mariadbv1alpha1.HealthCheck{
+		Type:    mariadbv1alpha1.HealthCheckTypeTCP,
+		Timeout: &metav1.Duration{Duration: time.Second},
+	}
+	listen := func() (net.Listener, clientsql.Opts) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		host, port, err := net.SplitHostPort(listener.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		portNumber, err := strconv.Atoi(port)
+		Expect(err).ToNot(HaveOccurred())
+
+		return listener, clientsql.Opts{
+			Host: host,
+			Port: int32(portNumber),
+		}
+	}
+
+	It("Should succeed when the TCP port is open", func() {
+		listener, opts := listen()
+		defer listener.Close()
+
+		Expect(checkConnectionHealth(testCtx, tcpHealthCheck, opts)).To(Succeed())
+	})
+
+	It("Should fail when the TCP port is closed", func() {
+		listener, opts := listen()
+		Expect(listener.Close()).To(Succeed())
+
+		err := checkConnectionHealth(testCtx, tcpHealthCheck, opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to connect"))
+	})
+})
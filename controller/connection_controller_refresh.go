@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	clientsql "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const passwordCacheSyncTimeout = 30 * time.Second
+
+// refreshConnections annotates the Connections that use a rotated password Secret, so their Secrets are rendered again
+// with the new password. The Connections are only annotated once the new password is visible in the cache, otherwise
+// they could be rendered with the previous one.
+func refreshConnections(ctx context.Context, c client.Client, namespace string, secretKeyRef corev1.SecretKeySelector,
+	password string, rotatedAt time.Time) error {
+	key := types.NamespacedName{
+		Name:      secretKeyRef.Name,
+		Namespace: namespace,
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 1*time.Second, passwordCacheSyncTimeout, true, func(ctx context.Context) (bool, error) {
+		var secret corev1.Secret
+		if err := c.Get(ctx, key, &secret); err != nil {
+			return false, nil
+		}
+		return string(secret.Data[secretKeyRef.Key]) == password, nil
+	}); err != nil {
+		return fmt.Errorf("error waiting for password Secret to be synced: %v", err)
+	}
+
+	var connList mariadbv1alpha1.ConnectionList
+	if err := c.List(ctx, &connList, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("error listing Connections: %v", err)
+	}
+	value := rotatedAt.UTC().Format(time.RFC3339)
+	var errBundle *multierror.Error
+	for _, conn := range connList.Items {
+		ref := conn.Spec.PasswordSecretKeyRef
+		if ref.Name != secretKeyRef.Name || ref.Key != secretKeyRef.Key || conn.Annotations[metadata.PasswordRotatedAtAnnotation] == value {
+			continue
+		}
+		patch := client.MergeFrom(conn.DeepCopy())
+		if conn.Annotations == nil {
+			conn.Annotations = map[string]string{}
+		}
+		conn.Annotations[metadata.PasswordRotatedAtAnnotation] = value
+		if err := c.Patch(ctx, &conn, patch); err != nil {
+			errBundle = multierror.Append(errBundle, fmt.Errorf("error patching Connection '%s': %v", conn.Name, err))
+		}
+	}
+	return errBundle.ErrorOrNil()
+}
+
+// refreshSecret renders the Secret of the Connection again when its password has been rotated since the Secret was rendered.
+func (r *ConnectionReconciler) refreshSecret(ctx context.Context, conn *mariadbv1alpha1.Connection, secret *corev1.Secret,
+	mdbOpts clientsql.Opts) error {
+	rotatedAt, ok := conn.Annotations[metadata.PasswordRotatedAtAnnotation]
+	if !ok || secret.Annotations[metadata.PasswordRotatedAtAnnotation] == rotatedAt {
+		return nil
+	}
+	data, err := connSecretData(conn, mdbOpts)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	secret.Data = data
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[metadata.PasswordRotatedAtAnnotation] = rotatedAt
+	if err := r.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("error patching Secret: %v", err)
+	}
+	log.FromContext(ctx).Info("Refreshed Connection Secret after password rotation", "secret", secret.Name)
+	return nil
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"fmt"
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
@@ -233,11 +234,18 @@ var _ = Describe("Connection controller", func() {
 				Spec: mariadbv1alpha1.ConnectionSpec{
 					ConnectionTemplate: mariadbv1alpha1.ConnectionTemplate{
 						SecretTemplate: &mariadbv1alpha1.SecretTemplate{
-							UsernameKey: func() *string { k := "user"; return &k }(),
-							PasswordKey: func() *string { k := "pass"; return &k }(),
-							HostKey:     func() *string { k := "host"; return &k }(),
-							PortKey:     func() *string { k := "port"; return &k }(),
-							DatabaseKey: func() *string { k := "name"; return &k }(),
+							UsernameKey:    func() *string { k := "user"; return &k }(),
+							PasswordKey:    func() *string { k := "pass"; return &k }(),
+							HostKey:        func() *string { k := "host"; return &k }(),
+							PortKey:        func() *string { k := "port"; return &k }(),
+							DatabaseKey:    func() *string { k := "name"; return &k }(),
+							OptionsFileKey: func() *string { k := "my.cnf"; return &k }(),
+							Files: []mariadbv1alpha1.SecretTemplateFile{
+								{
+									Key:    "application.properties",
+									Format: "db.url=jdbc:mariadb://{{ .Host }}:{{ .Port }}/{{ .Database }}\ndb.user={{ .Username }}\n",
+								},
+							},
 						},
 					},
 					MariaDBRef: mariadbv1alpha1.MariaDBRef{
@@ -287,6 +295,20 @@ var _ = Describe("Connection controller", func() {
 			database, ok := secret.Data["name"]
 			Expect(ok).To(BeTrue())
 			Expect(string(database)).To(Equal(testDatabase))
+			optionsFile, ok := secret.Data["my.cnf"]
+			Expect(ok).To(BeTrue())
+			Expect(string(optionsFile)).To(Equal(fmt.Sprintf(
+				"[client]\nuser=%s\npassword=\"test\"\nhost=mariadb-test.default.svc.cluster.local\nport=3306\ndatabase=%s\n",
+				testUser,
+				testDatabase,
+			)))
+			properties, ok := secret.Data["application.properties"]
+			Expect(ok).To(BeTrue())
+			Expect(string(properties)).To(Equal(fmt.Sprintf(
+				"db.url=jdbc:mariadb://mariadb-test.default.svc.cluster.local:3306/%s\ndb.user=%s\n",
+				testDatabase,
+				testUser,
+			)))
 
 			By("Deleting Connection")
 			Expect(k8sClient.Delete(testCtx, &conn)).To(Succeed())
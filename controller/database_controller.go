@@ -2,14 +2,18 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/sql"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -19,16 +23,20 @@ type DatabaseReconciler struct {
 	client.Client
 	RefResolver     *refresolver.RefResolver
 	ConditionReady  *condition.Ready
+	Recorder        record.EventRecorder
 	RequeueInterval time.Duration
+	SqlOpts         []sqlClient.Opt
 }
 
 func NewDatabaseReconciler(client client.Client, refResolver *refresolver.RefResolver, conditionReady *condition.Ready,
-	requeueInterval time.Duration) *DatabaseReconciler {
+	recorder record.EventRecorder, requeueInterval time.Duration, sqlOpts ...sqlClient.Opt) *DatabaseReconciler {
 	return &DatabaseReconciler{
 		Client:          client,
 		RefResolver:     refResolver,
 		ConditionReady:  conditionReady,
+		Recorder:        recorder,
 		RequeueInterval: requeueInterval,
+		SqlOpts:         sqlOpts,
 	}
 }
 
@@ -44,10 +52,10 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	wr := newWrappedDatabaseReconciler(r.Client, r.RefResolver, &database)
+	wr := newWrappedDatabaseReconciler(r.Client, r.RefResolver, r.Recorder, &database, r.SqlOpts)
 	wf := newWrappedDatabaseFinalizer(r.Client, &database)
-	tf := sql.NewSqlFinalizer(r.Client, wf)
-	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval)
+	tf := sql.NewSqlFinalizer(r.Client, wf, r.SqlOpts...)
+	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval, r.SqlOpts...)
 
 	result, err := tr.Reconcile(ctx, &database)
 	if err != nil {
@@ -60,21 +68,25 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 func (r *DatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&mariadbv1alpha1.Database{}).
-		Complete(r)
+		Complete(priority.NewReconciler(priority.PriorityBulk, r))
 }
 
 type wrappedDatabaseReconciler struct {
 	client.Client
 	refResolver *refresolver.RefResolver
+	recorder    record.EventRecorder
 	database    *mariadbv1alpha1.Database
+	sqlOpts     []sqlClient.Opt
 }
 
-func newWrappedDatabaseReconciler(client client.Client, refResolver *refresolver.RefResolver,
-	database *mariadbv1alpha1.Database) sql.WrappedReconciler {
+func newWrappedDatabaseReconciler(client client.Client, refResolver *refresolver.RefResolver, recorder record.EventRecorder,
+	database *mariadbv1alpha1.Database, sqlOpts []sqlClient.Opt) sql.WrappedReconciler {
 	return &wrappedDatabaseReconciler{
 		Client:      client,
 		refResolver: refResolver,
+		recorder:    recorder,
 		database:    database,
+		sqlOpts:     sqlOpts,
 	}
 }
 
@@ -84,11 +96,88 @@ func (wr *wrappedDatabaseReconciler) Reconcile(ctx context.Context, mdbClient *s
 		Collate:      wr.database.Spec.Collate,
 	}
 	if err := mdbClient.CreateDatabase(ctx, wr.database.DatabaseNameOrDefault(), opts); err != nil {
-		return fmt.Errorf("error creating database in MariaDB: %v", err)
+		return fmt.Errorf("error creating database in MariaDB: %w", err)
+	}
+	if err := wr.reconcileInitSQL(ctx); err != nil {
+		return fmt.Errorf("error applying init SQL: %v", err)
+	}
+	if err := wr.reconcileQuota(ctx, mdbClient); err != nil {
+		return fmt.Errorf("error reconciling quota: %v", err)
 	}
 	return nil
 }
 
+func (wr *wrappedDatabaseReconciler) reconcileInitSQL(ctx context.Context) error {
+	if wr.database.Spec.InitSQL == nil || wr.database.Status.InitSQLApplied {
+		return nil
+	}
+	initSQL, err := wr.initSQL(ctx)
+	if err != nil {
+		return err
+	}
+
+	mariadb, err := wr.refResolver.MariaDB(ctx, &wr.database.Spec.MariaDBRef, wr.database.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting MariaDB: %v", err)
+	}
+	opts := append(
+		slices.Clip(wr.sqlOpts),
+		sqlClient.WithDatabase(wr.database.DatabaseNameOrDefault()),
+		sqlClient.WithParams(map[string]string{
+			"multiStatements": "true",
+		}),
+	)
+	if timeout := wr.database.SqlTimeout(); timeout != nil {
+		opts = append(opts, sqlClient.WithQueryTimeout(timeout.Duration))
+	}
+	mdbClient, err := sqlClient.NewOperatorClientWithMariaDB(ctx, mariadb, wr.refResolver, opts...)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer mdbClient.Close()
+
+	// The init SQL is recorded as applied before executing it, so it is not executed again if the status cannot be patched
+	// afterwards. The optimistic lock prevents executing it based on a stale Database that does not reflect a previous run.
+	if err := wr.patchInitSQLApplied(ctx, true); err != nil {
+		return err
+	}
+	if err := mdbClient.Exec(ctx, initSQL); err != nil {
+		if patchErr := wr.patchInitSQLApplied(ctx, false); patchErr != nil {
+			return fmt.Errorf("error executing init SQL: %v, %v", err, patchErr)
+		}
+		return fmt.Errorf("error executing init SQL: %v", err)
+	}
+	return nil
+}
+
+func (wr *wrappedDatabaseReconciler) patchInitSQLApplied(ctx context.Context, applied bool) error {
+	patch := client.MergeFromWithOptions(wr.database.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	wr.database.Status.InitSQLApplied = applied
+	if err := wr.Client.Status().Patch(ctx, wr.database, patch); err != nil {
+		return fmt.Errorf("error patching Database status: %v", err)
+	}
+	return nil
+}
+
+func (wr *wrappedDatabaseReconciler) initSQL(ctx context.Context) (string, error) {
+	initSQL := wr.database.Spec.InitSQL
+	if initSQL.ConfigMapKeyRef != nil {
+		sql, err := wr.refResolver.ConfigMapKeyRef(ctx, initSQL.ConfigMapKeyRef, wr.database.Namespace)
+		if err != nil {
+			return "", fmt.Errorf("error getting init SQL from ConfigMap: %v", err)
+		}
+		return sql, nil
+	}
+	if initSQL.SecretKeyRef != nil {
+		sql, err := wr.refResolver.SecretKeyRef(ctx, *initSQL.SecretKeyRef, wr.database.Namespace)
+		if err != nil {
+			return "", fmt.Errorf("error getting init SQL from Secret: %v", err)
+		}
+		return sql, nil
+	}
+	return "", errors.New("init SQL source not specified")
+}
+
 func (wr *wrappedDatabaseReconciler) PatchStatus(ctx context.Context, patcher condition.Patcher) error {
 	patch := client.MergeFrom(wr.database.DeepCopy())
 	patcher(&wr.database.Status)
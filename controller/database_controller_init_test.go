@@ -0,0 +1,78 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Database init SQL", func() {
+	newDatabase := func() *mariadbv1alpha1.Database {
+		return &mariadbv1alpha1.Database{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "database-init-sql",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.DatabaseSpec{
+				InitSQL: &mariadbv1alpha1.DatabaseInitSQL{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "database-init-sql",
+						},
+						Key: "init.sql",
+					},
+				},
+			},
+		}
+	}
+	newReconciler := func(database *mariadbv1alpha1.Database) (*wrappedDatabaseReconciler, client.Client) {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(database).
+			WithStatusSubresource(&mariadbv1alpha1.Database{}).
+			Build()
+		return &wrappedDatabaseReconciler{
+			Client: c,
+		}, c
+	}
+
+	It("Should record the init SQL as applied", func() {
+		database := newDatabase()
+		wr, c := newReconciler(database)
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(database), database)).To(Succeed())
+		wr.database = database
+
+		Expect(wr.patchInitSQLApplied(testCtx, true)).To(Succeed())
+
+		var got mariadbv1alpha1.Database
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(database), &got)).To(Succeed())
+		Expect(got.Status.InitSQLApplied).To(BeTrue())
+	})
+
+	It("Should not record the init SQL as applied from a stale Database", func() {
+		database := newDatabase()
+		wr, c := newReconciler(database)
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(database), database)).To(Succeed())
+		stale := database.DeepCopy()
+
+		wr.database = database
+		Expect(wr.patchInitSQLApplied(testCtx, true)).To(Succeed())
+
+		wr.database = stale
+		Expect(wr.patchInitSQLApplied(testCtx, true)).ToNot(Succeed())
+	})
+
+	It("Should skip the init SQL once applied", func() {
+		database := newDatabase()
+		database.Status.InitSQLApplied = true
+		wr, _ := newReconciler(database)
+		wr.database = database
+
+		Expect(wr.reconcileInitSQL(testCtx)).To(Succeed())
+	})
+})
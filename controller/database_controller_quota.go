@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var quotaPrivileges = []string{"INSERT"}
+
+func (wr *wrappedDatabaseReconciler) reconcileQuota(ctx context.Context, mdbClient *sqlClient.Client) error {
+	quota := wr.database.Spec.Quota
+	if quota == nil {
+		return wr.reconcileQuotaRemoved(ctx, mdbClient)
+	}
+	databaseName := wr.database.DatabaseNameOrDefault()
+
+	sizeBytes, err := mdbClient.DatabaseSize(ctx, databaseName)
+	if err != nil {
+		return fmt.Errorf("error getting Database size: %v", err)
+	}
+	size := resource.NewQuantity(sizeBytes, resource.BinarySI)
+	exceeded := size.Cmp(quota.Size) > 0
+
+	wasExceeded := wr.database.IsQuotaExceeded()
+	revokedAccounts := wr.database.Status.QuotaRevokedAccounts
+	if exceeded && quota.Enforcement == mariadbv1alpha1.DatabaseQuotaEnforcementRevokeInsert {
+		if revokedAccounts, err = wr.revokeQuotaPrivileges(ctx, mdbClient, databaseName); err != nil {
+			return fmt.Errorf("error revoking privileges: %v", err)
+		}
+		if !wasExceeded {
+			if err := wr.reportNotRevokedAccounts(ctx, mdbClient, databaseName); err != nil {
+				return fmt.Errorf("error reporting accounts not revoked: %v", err)
+			}
+		}
+	} else if len(revokedAccounts) > 0 {
+		if err := wr.restoreQuotaPrivileges(ctx, mdbClient, databaseName); err != nil {
+			return fmt.Errorf("error restoring privileges: %v", err)
+		}
+		revokedAccounts = nil
+	}
+
+	if err := wr.patchQuotaStatus(ctx, func(status *mariadbv1alpha1.DatabaseStatus) {
+		status.Size = size
+		status.QuotaRevokedAccounts = revokedAccounts
+		if exceeded {
+			condition.SetQuotaExceeded(status, size, &quota.Size)
+		} else {
+			condition.SetQuotaNotExceeded(status, size, &quota.Size)
+		}
+	}); err != nil {
+		return err
+	}
+
+	if exceeded && !wasExceeded {
+		wr.recorder.Eventf(wr.database, corev1.EventTypeWarning, mariadbv1alpha1.ReasonDatabaseQuotaExceeded,
+			"Database size %s exceeds quota %s", size.String(), quota.Size.String())
+	}
+	if !exceeded && wasExceeded {
+		wr.recorder.Eventf(wr.database, corev1.EventTypeNormal, mariadbv1alpha1.ReasonDatabaseQuotaRecovered,
+			"Database size %s within quota %s", size.String(), quota.Size.String())
+	}
+	return nil
+}
+
+func (wr *wrappedDatabaseReconciler) reconcileQuotaRemoved(ctx context.Context, mdbClient *sqlClient.Client) error {
+	status := wr.database.Status
+	if status.Size == nil && len(status.QuotaRevokedAccounts) == 0 &&
+		meta.FindStatusCondition(status.Conditions, mariadbv1alpha1.ConditionTypeQuotaExceeded) == nil {
+		return nil
+	}
+	if err := wr.restoreQuotaPrivileges(ctx, mdbClient, wr.database.DatabaseNameOrDefault()); err != nil {
+		return fmt.Errorf("error restoring privileges: %v", err)
+	}
+	return wr.patchQuotaStatus(ctx, func(status *mariadbv1alpha1.DatabaseStatus) {
+		status.Size = nil
+		status.QuotaRevokedAccounts = nil
+		meta.RemoveStatusCondition(&status.Conditions, mariadbv1alpha1.ConditionTypeQuotaExceeded)
+	})
+}
+
+// revokeQuotaPrivileges revokes the quota privileges from the accounts that have them, including the ones created
+// after the quota was exceeded. It returns all the accounts whose privileges have been revoked.
+func (wr *wrappedDatabaseReconciler) revokeQuotaPrivileges(ctx context.Context, mdbClient *sqlClient.Client,
+	databaseName string) ([]string, error) {
+	accounts, err := mdbClient.DatabaseInsertAccounts(ctx, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting accounts: %v", err)
+	}
+	revokedAccounts := wr.database.Status.QuotaRevokedAccounts
+	for _, account := range accounts {
+		log.FromContext(ctx).Info("Revoking privileges due to exceeded quota", "account", account, "privileges", quotaPrivileges)
+		if err := mdbClient.Revoke(ctx, quotaPrivileges, databaseName, "*", account); err != nil {
+			return nil, fmt.Errorf("error revoking privileges from account %s: %v", account, err)
+		}
+		if !wr.database.IsQuotaRevokedAccount(account) {
+			revokedAccounts = append(revokedAccounts, account)
+		}
+	}
+	return revokedAccounts, nil
+}
+
+// reportNotRevokedAccounts emits a warning event with the accounts that keep the INSERT privilege on the database
+// after revoking it, as they have it via a global or a table-level grant, which are not revoked.
+func (wr *wrappedDatabaseReconciler) reportNotRevokedAccounts(ctx context.Context, mdbClient *sqlClient.Client,
+	databaseName string) error {
+	accounts, err := mdbClient.GlobalOrTableInsertAccounts(ctx, databaseName)
+	if err != nil {
+		return fmt.Errorf("error getting accounts: %v", err)
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+	wr.recorder.Eventf(wr.database, corev1.EventTypeWarning, mariadbv1alpha1.ReasonDatabaseQuotaNotEnforced,
+		"INSERT privilege not revoked from accounts with global or table-level grants: %s", strings.Join(accounts, ", "))
+	return nil
+}
+
+func (wr *wrappedDatabaseReconciler) restoreQuotaPrivileges(ctx context.Context, mdbClient *sqlClient.Client,
+	databaseName string) error {
+	for _, account := range wr.database.Status.QuotaRevokedAccounts {
+		exists, err := mdbClient.AccountExists(ctx, account)
+		if err != nil {
+			return fmt.Errorf("error checking account %s: %v", account, err)
+		}
+		if !exists {
+			continue
+		}
+		log.FromContext(ctx).Info("Restoring privileges revoked due to exceeded quota", "account", account, "privileges", quotaPrivileges)
+		if err := mdbClient.Grant(ctx, quotaPrivileges, databaseName, "*", account); err != nil {
+			return fmt.Errorf("error granting privileges to account %s: %v", account, err)
+		}
+	}
+	return nil
+}
+
+func (wr *wrappedDatabaseReconciler) patchQuotaStatus(ctx context.Context,
+	patcher func(*mariadbv1alpha1.DatabaseStatus)) error {
+	patch := client.MergeFrom(wr.database.DeepCopy())
+	patcher(&wr.database.Status)
+	if err := wr.Client.Status().Patch(ctx, wr.database, patch); err != nil {
+		return fmt.Errorf("error patching Database status: %v", err)
+	}
+	return nil
+}
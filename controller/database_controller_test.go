@@ -1,12 +1,16 @@
 package controller
 
 import (
+	"strings"
+
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -56,4 +60,124 @@ var _ = Describe("Database controller", func() {
 			Expect(k8sClient.Delete(testCtx, &database)).To(Succeed())
 		})
 	})
+
+	Context("When creating a Database with quota", func() {
+		It("Should report its size", func() {
+			By("Creating a Database")
+			databaseKey := types.NamespacedName{
+				Name:      "data-quota-test",
+				Namespace: testNamespace,
+			}
+			database := mariadbv1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      databaseKey.Name,
+					Namespace: databaseKey.Namespace,
+				},
+				Spec: mariadbv1alpha1.DatabaseSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbKey.Name,
+						},
+						WaitForIt: true,
+					},
+					Quota: &mariadbv1alpha1.DatabaseQuota{
+						Size:        resource.MustParse("1Gi"),
+						Enforcement: mariadbv1alpha1.DatabaseQuotaEnforcementRevokeInsert,
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &database)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &database)).To(Succeed())
+			})
+
+			By("Expecting Database to report its size eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, databaseKey, &database); err != nil {
+					return false
+				}
+				return database.IsReady() && database.Status.Size != nil
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			Expect(database.IsQuotaExceeded()).To(BeFalse())
+			Expect(database.Status.QuotaRevokedAccounts).To(BeEmpty())
+		})
+
+		It("Should report the accounts with global grants when exceeded", func() {
+			By("Creating init SQL ConfigMap")
+			configMap := corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "data-quota-exceeded-test",
+					Namespace: testNamespace,
+				},
+				Data: map[string]string{
+					"init.sql": "CREATE TABLE quota (id INT PRIMARY KEY); INSERT INTO quota VALUES (1);",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &configMap)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &configMap)).To(Succeed())
+			})
+
+			By("Creating a Database")
+			databaseKey := types.NamespacedName{
+				Name:      "data-quota-exceeded-test",
+				Namespace: testNamespace,
+			}
+			database := mariadbv1alpha1.Database{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      databaseKey.Name,
+					Namespace: databaseKey.Namespace,
+				},
+				Spec: mariadbv1alpha1.DatabaseSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbKey.Name,
+						},
+						WaitForIt: true,
+					},
+					InitSQL: &mariadbv1alpha1.DatabaseInitSQL{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: configMap.Name,
+							},
+							Key: "init.sql",
+						},
+					},
+					Quota: &mariadbv1alpha1.DatabaseQuota{
+						Size:        resource.MustParse("1"),
+						Enforcement: mariadbv1alpha1.DatabaseQuotaEnforcementRevokeInsert,
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &database)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &database)).To(Succeed())
+			})
+
+			By("Expecting Database to exceed its quota eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, databaseKey, &database); err != nil {
+					return false
+				}
+				return database.IsQuotaExceeded()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting root to be reported as not revoked eventually")
+			Eventually(func() bool {
+				var events corev1.EventList
+				if err := k8sClient.List(testCtx, &events, client.InNamespace(testNamespace)); err != nil {
+					return false
+				}
+				for _, event := range events.Items {
+					if event.InvolvedObject.Name == database.Name &&
+						event.Reason == mariadbv1alpha1.ReasonDatabaseQuotaNotEnforced &&
+						strings.Contains(event.Message, "'root'@") {
+						return true
+					}
+				}
+				return false
+			}, testTimeout, testInterval).Should(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	dependsOnBackupsField = ".spec.dependsOnBackups"
+)
+
+// backupDependenciesMessage checks whether the Backup dependencies have successfully completed.
+// It returns a message describing the first unmet dependency, or an empty string if all of them are met.
+func backupDependenciesMessage(ctx context.Context, refResolver *refresolver.RefResolver, deps []corev1.LocalObjectReference,
+	namespace string) (string, error) {
+	for _, dep := range deps {
+		backup, err := refResolver.Backup(ctx, &dep, namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Sprintf("Backup dependency '%s' not found", dep.Name), nil
+			}
+			return "", fmt.Errorf("error getting Backup dependency: %v", err)
+		}
+		if !backup.IsSuccessful() {
+			return fmt.Sprintf("Backup dependency '%s' not complete", dep.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// indexBackupDependencies indexes the objects by the Backups they depend on, so they can be reconciled when the Backups change.
+func indexBackupDependencies(mgr ctrl.Manager, obj client.Object,
+	depsFn func(client.Object) []corev1.LocalObjectReference) error {
+	indexFn := func(rawObj client.Object) []string {
+		return backupDependencyNames(depsFn(rawObj))
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), obj, dependsOnBackupsField, indexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field: %v", dependsOnBackupsField, err)
+	}
+	return nil
+}
+
+func backupDependencyNames(deps []corev1.LocalObjectReference) []string {
+	var names []string
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+	}
+	return names
+}
+
+// mapBackupToDependents returns the requests of the objects of the list that depend on a Backup.
+func mapBackupToDependents(ctx context.Context, c client.Client, list client.ObjectList,
+	backup client.Object) []reconcile.Request {
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(dependsOnBackupsField, backup.GetName()),
+		Namespace:     backup.GetNamespace(),
+	}
+	if err := c.List(ctx, list, listOpts); err != nil {
+		return []reconcile.Request{}
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      obj.GetName(),
+				Namespace: obj.GetNamespace(),
+			},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Backup dependencies", func() {
+	backup := &mariadbv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup-dependency",
+			Namespace: testNamespace,
+		},
+	}
+	dependsOnBackups := []corev1.LocalObjectReference{
+		{
+			Name: backup.Name,
+		},
+	}
+
+	It("Should map Backups to the objects that depend on them", func() {
+		restore := &mariadbv1alpha1.Restore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "restore-backup-dependency",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.RestoreSpec{
+				DependsOnBackups: dependsOnBackups,
+			},
+		}
+		sqlJob := &mariadbv1alpha1.SqlJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sqljob-backup-dependency",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.SqlJobSpec{
+				DependsOnBackups: dependsOnBackups,
+			},
+		}
+		independentSqlJob := &mariadbv1alpha1.SqlJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sqljob-no-backup-dependency",
+				Namespace: testNamespace,
+			},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(restore, sqlJob, independentSqlJob).
+			WithIndex(&mariadbv1alpha1.Restore{}, dependsOnBackupsField, func(obj client.Object) []string {
+				return backupDependencyNames(obj.(*mariadbv1alpha1.Restore).Spec.DependsOnBackups)
+			}).
+			WithIndex(&mariadbv1alpha1.SqlJob{}, dependsOnBackupsField, func(obj client.Object) []string {
+				return backupDependencyNames(obj.(*mariadbv1alpha1.SqlJob).Spec.DependsOnBackups)
+			}).
+			Build()
+
+		Expect(mapBackupToDependents(testCtx, c, &mariadbv1alpha1.RestoreList{}, backup)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(restore)},
+		))
+		Expect(mapBackupToDependents(testCtx, c, &mariadbv1alpha1.SqlJobList{}, backup)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sqlJob)},
+		))
+	})
+
+	It("Should wait for the Backup without requeuing", func() {
+		sqlJob := &mariadbv1alpha1.SqlJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sqljob-waiting-backup",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.SqlJobSpec{
+				DependsOnBackups: dependsOnBackups,
+			},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(sqlJob, backup).
+			WithStatusSubresource(&mariadbv1alpha1.SqlJob{}).
+			Build()
+		r := &SqlJobReconciler{
+			Client:            c,
+			RefResolver:       refresolver.New(c),
+			ConditionComplete: condition.NewComplete(c),
+		}
+
+		ok, result, err := r.waitForDependencies(testCtx, sqlJob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(result).To(Equal(ctrl.Result{}))
+
+		var got mariadbv1alpha1.SqlJob
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(sqlJob), &got)).To(Succeed())
+		complete := meta.FindStatusCondition(got.Status.Conditions, mariadbv1alpha1.ConditionTypeComplete)
+		Expect(complete).NotTo(BeNil())
+		Expect(complete.Status).To(Equal(metav1.ConditionFalse))
+		Expect(complete.Reason).To(Equal(mariadbv1alpha1.ConditionReasonWaitingForDependencies))
+	})
+})
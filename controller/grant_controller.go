@@ -3,10 +3,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/sql"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
@@ -23,6 +25,7 @@ import (
 
 const (
 	usernameField = ".spec.username"
+	roleField     = ".spec.role"
 )
 
 // GrantReconciler reconciles a Grant object
@@ -31,15 +34,17 @@ type GrantReconciler struct {
 	RefResolver     *refresolver.RefResolver
 	ConditionReady  *condition.Ready
 	RequeueInterval time.Duration
+	SqlOpts         []sqlClient.Opt
 }
 
 func NewGrantReconciler(client client.Client, refResolver *refresolver.RefResolver, conditionReady *condition.Ready,
-	requeueInterval time.Duration) *GrantReconciler {
+	requeueInterval time.Duration, sqlOpts ...sqlClient.Opt) *GrantReconciler {
 	return &GrantReconciler{
 		Client:          client,
 		RefResolver:     refResolver,
 		ConditionReady:  conditionReady,
 		RequeueInterval: requeueInterval,
+		SqlOpts:         sqlOpts,
 	}
 }
 
@@ -57,8 +62,8 @@ func (r *GrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	wr := newWrappedGrantReconciler(r.Client, *r.RefResolver, &grant)
 	wf := newWrappedGrantFinalizer(r.Client, &grant)
-	tf := sql.NewSqlFinalizer(r.Client, wf)
-	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval)
+	tf := sql.NewSqlFinalizer(r.Client, wf, r.SqlOpts...)
+	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval, r.SqlOpts...)
 
 	result, err := tr.Reconcile(ctx, &grant)
 	if err != nil {
@@ -84,7 +89,16 @@ func (r *GrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				},
 			}),
 		).
-		Complete(r)
+		Watches(
+			&mariadbv1alpha1.Role{},
+			handler.EnqueueRequestsFromMapFunc(r.mapRoleToRequests),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(ce event.CreateEvent) bool {
+					return true
+				},
+			}),
+		).
+		Complete(priority.NewReconciler(priority.PriorityBulk, r))
 }
 
 func (r *GrantReconciler) createIndex(mgr ctrl.Manager) error {
@@ -98,14 +112,33 @@ func (r *GrantReconciler) createIndex(mgr ctrl.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.Grant{}, usernameField, indexFn); err != nil {
 		return fmt.Errorf("error indexing '%s' field in Grant: %v", usernameField, err)
 	}
+
+	roleIndexFn := func(rawObj client.Object) []string {
+		grant := rawObj.(*mariadbv1alpha1.Grant)
+		if grant.Spec.Role == "" {
+			return nil
+		}
+		return []string{grant.Spec.Role}
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.Grant{}, roleField, roleIndexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field in Grant: %v", roleField, err)
+	}
 	return nil
 }
 
 func (r *GrantReconciler) mapUserToRequests(ctx context.Context, user client.Object) []reconcile.Request {
+	return r.mapGranteeToRequests(ctx, usernameField, user)
+}
+
+func (r *GrantReconciler) mapRoleToRequests(ctx context.Context, role client.Object) []reconcile.Request {
+	return r.mapGranteeToRequests(ctx, roleField, role)
+}
+
+func (r *GrantReconciler) mapGranteeToRequests(ctx context.Context, field string, obj client.Object) []reconcile.Request {
 	grantsToReconcile := &mariadbv1alpha1.GrantList{}
 	listOpts := &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(usernameField, user.GetName()),
-		Namespace:     user.GetNamespace(),
+		FieldSelector: fields.OneTermEqualSelector(field, obj.GetName()),
+		Namespace:     obj.GetNamespace(),
 	}
 
 	if err := r.List(context.Background(), grantsToReconcile, listOpts); err != nil {
@@ -144,19 +177,57 @@ func (wr *wrappedGrantReconciler) Reconcile(ctx context.Context, mdbClient *sqlC
 	if wr.grant.Spec.GrantOption {
 		opts = append(opts, sqlClient.WithGrantOption())
 	}
+	accountName, err := granteeAccountName(ctx, wr.Client, wr.grant)
+	if err != nil {
+		return fmt.Errorf("error getting grantee account name: %v", err)
+	}
+	privileges, err := wr.privileges(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("error getting privileges: %v", err)
+	}
+	if len(privileges) == 0 {
+		return nil
+	}
 	if err := mdbClient.Grant(
 		ctx,
-		wr.grant.Spec.Privileges,
+		privileges,
 		wr.grant.Spec.Database,
 		wr.grant.Spec.Table,
-		wr.grant.AccountName(),
+		accountName,
 		opts...,
 	); err != nil {
-		return fmt.Errorf("error granting privileges in MariaDB: %v", err)
+		return fmt.Errorf("error granting privileges in MariaDB: %w", err)
 	}
 	return nil
 }
 
+// privileges returns the privileges to be granted, excluding the ones revoked by the quota of the Database.
+func (wr *wrappedGrantReconciler) privileges(ctx context.Context, accountName string) ([]string, error) {
+	var databases mariadbv1alpha1.DatabaseList
+	if err := wr.List(ctx, &databases, client.InNamespace(wr.grant.Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing Databases: %v", err)
+	}
+	for _, db := range databases.Items {
+		if db.Spec.MariaDBRef.Name != wr.grant.Spec.MariaDBRef.Name ||
+			db.Spec.MariaDBRef.Namespace != wr.grant.Spec.MariaDBRef.Namespace ||
+			db.DatabaseNameOrDefault() != wr.grant.Spec.Database {
+			continue
+		}
+		if !db.IsQuotaRevokedAccount(accountName) {
+			continue
+		}
+		var privileges []string
+		for _, p := range wr.grant.Spec.Privileges {
+			if strings.EqualFold(p, "INSERT") {
+				continue
+			}
+			privileges = append(privileges, p)
+		}
+		return privileges, nil
+	}
+	return wr.grant.Spec.Privileges, nil
+}
+
 func (wr *wrappedGrantReconciler) PatchStatus(ctx context.Context, patcher condition.Patcher) error {
 	patch := client.MergeFrom(wr.grant.DeepCopy())
 	patcher(&wr.grant.Status)
@@ -167,9 +238,30 @@ func (wr *wrappedGrantReconciler) PatchStatus(ctx context.Context, patcher condi
 	return nil
 }
 
-func userKey(grant *mariadbv1alpha1.Grant) types.NamespacedName {
-	return types.NamespacedName{
+// grantee returns the User or Role that the privileges are granted to, along with its key.
+func grantee(grant *mariadbv1alpha1.Grant) (client.Object, types.NamespacedName) {
+	if grant.Spec.Role != "" {
+		return &mariadbv1alpha1.Role{}, types.NamespacedName{
+			Name:      grant.Spec.Role,
+			Namespace: grant.Namespace,
+		}
+	}
+	return &mariadbv1alpha1.User{}, types.NamespacedName{
 		Name:      grant.Spec.Username,
 		Namespace: grant.Namespace,
 	}
 }
+
+// granteeAccountName returns the account that the privileges are granted to. Roles are resolved to their name in MariaDB,
+// which may be different from the name of the Role object.
+func granteeAccountName(ctx context.Context, c client.Client, grant *mariadbv1alpha1.Grant) (string, error) {
+	if grant.Spec.Role == "" {
+		return grant.AccountName(), nil
+	}
+	_, key := grantee(grant)
+	var role mariadbv1alpha1.Role
+	if err := c.Get(ctx, key, &role); err != nil {
+		return "", fmt.Errorf("error getting Role: %v", err)
+	}
+	return fmt.Sprintf("'%s'", role.RoleNameOrDefault()), nil
+}
@@ -53,9 +53,9 @@ func (wf *wrappedGrantFinalizer) ContainsFinalizer() bool {
 }
 
 func (wf *wrappedGrantFinalizer) Reconcile(ctx context.Context, mdbClient *sqlClient.Client) error {
+	obj, key := grantee(wf.grant)
 	err := wait.PollUntilContextTimeout(ctx, 1*time.Second, 10*time.Second, true, func(ctx context.Context) (bool, error) {
-		var user mariadbv1alpha1.User
-		if err := wf.Get(ctx, userKey(wf.grant), &user); err != nil {
+		if err := wf.Get(ctx, key, obj); err != nil {
 			if apierrors.IsNotFound(err) {
 				return true, nil
 			}
@@ -63,14 +63,18 @@ func (wf *wrappedGrantFinalizer) Reconcile(ctx context.Context, mdbClient *sqlCl
 		}
 		return false, nil
 	})
-	// User does not exist
+	// User or Role does not exist
 	if err == nil {
 		return nil
 	}
 	if err != nil && !wait.Interrupted(err) {
-		return fmt.Errorf("error checking if user exists in MariaDB: %v", err)
+		return fmt.Errorf("error checking if grantee exists in MariaDB: %v", err)
 	}
 
+	accountName, err := granteeAccountName(ctx, wf.Client, wf.grant)
+	if err != nil {
+		return fmt.Errorf("error getting grantee account name: %v", err)
+	}
 	var opts []sqlClient.GrantOption
 	if wf.grant.Spec.GrantOption {
 		opts = append(opts, sqlClient.WithGrantOption())
@@ -80,7 +84,7 @@ func (wf *wrappedGrantFinalizer) Reconcile(ctx context.Context, mdbClient *sqlCl
 		wf.grant.Spec.Privileges,
 		wf.grant.Spec.Database,
 		wf.grant.Spec.Table,
-		wf.grant.AccountName(),
+		accountName,
 		opts...,
 	); err != nil {
 		return fmt.Errorf("error revoking grant in MariaDB: %v", err)
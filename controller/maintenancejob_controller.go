@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaintenanceJobReconciler reconciles a MaintenanceJob object
+type MaintenanceJobReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	Builder           *builder.Builder
+	RefResolver       *refresolver.RefResolver
+	ConditionComplete *condition.Complete
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=maintenancejobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=maintenancejobs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=maintenancejobs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=list;watch;create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *MaintenanceJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var maintenanceJob mariadbv1alpha1.MaintenanceJob
+	if err := r.Get(ctx, req.NamespacedName, &maintenanceJob); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	mariadb, err := r.RefResolver.MariaDB(ctx, &maintenanceJob.Spec.MariaDBRef, maintenanceJob.Namespace)
+	if err != nil {
+		var mariaDbErr *multierror.Error
+		mariaDbErr = multierror.Append(mariaDbErr, err)
+
+		err = r.patchStatus(ctx, &maintenanceJob, r.ConditionComplete.PatcherRefResolver(err, mariadb))
+		mariaDbErr = multierror.Append(mariaDbErr, err)
+
+		return ctrl.Result{}, fmt.Errorf("error getting MariaDB: %v", mariaDbErr)
+	}
+
+	if maintenanceJob.Spec.MariaDBRef.WaitForIt && !mariadb.IsReady() {
+		if err := r.patchStatus(ctx, &maintenanceJob, r.ConditionComplete.PatcherFailed("MariaDB not ready")); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching MaintenanceJob: %v", err)
+		}
+		return ctrl.Result{}, errors.New("MariaDB not ready")
+	}
+
+	var jobErr *multierror.Error
+	err = r.reconcileBatch(ctx, &maintenanceJob, mariadb, req.NamespacedName)
+	jobErr = multierror.Append(jobErr, err)
+
+	patcher, err := r.patcher(ctx, &maintenanceJob, err, req.NamespacedName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{}, fmt.Errorf("error getting patcher for MaintenanceJob: %v", err)
+	}
+
+	err = r.patchStatus(ctx, &maintenanceJob, patcher)
+	jobErr = multierror.Append(jobErr, err)
+
+	if err := jobErr.ErrorOrNil(); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling MaintenanceJob: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *MaintenanceJobReconciler) reconcileBatch(ctx context.Context, maintenanceJob *mariadbv1alpha1.MaintenanceJob,
+	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) error {
+	if maintenanceJob.Spec.Schedule != nil {
+		return r.reconcileCronJob(ctx, maintenanceJob, mariadb, key)
+	}
+	return r.reconcileJob(ctx, maintenanceJob, mariadb, key)
+}
+
+func (r *MaintenanceJobReconciler) patcher(ctx context.Context, maintenanceJob *mariadbv1alpha1.MaintenanceJob, err error,
+	key types.NamespacedName) (condition.Patcher, error) {
+	if maintenanceJob.Spec.Schedule != nil {
+		return r.ConditionComplete.PatcherWithCronJob(ctx, err, key)
+	}
+	return r.ConditionComplete.PatcherWithJob(ctx, err, key)
+}
+
+func (r *MaintenanceJobReconciler) reconcileJob(ctx context.Context, maintenanceJob *mariadbv1alpha1.MaintenanceJob,
+	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) error {
+	desiredJob, err := r.Builder.BuildMaintenanceJob(key, maintenanceJob, mariadb)
+	if err != nil {
+		return fmt.Errorf("error building Job: %v", err)
+	}
+
+	var existingJob batchv1.Job
+	if err := r.Get(ctx, key, &existingJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting Job: %v", err)
+		}
+
+		if err := r.Create(ctx, desiredJob); err != nil {
+			return fmt.Errorf("error creating Job: %v", err)
+		}
+		return nil
+	}
+
+	patch := client.MergeFrom(existingJob.DeepCopy())
+	existingJob.Spec.BackoffLimit = desiredJob.Spec.BackoffLimit
+	existingJob.Spec.ActiveDeadlineSeconds = desiredJob.Spec.ActiveDeadlineSeconds
+
+	if err := r.Patch(ctx, &existingJob, patch); err != nil {
+		return fmt.Errorf("error patching Job: %v", err)
+	}
+	return nil
+}
+
+func (r *MaintenanceJobReconciler) reconcileCronJob(ctx context.Context, maintenanceJob *mariadbv1alpha1.MaintenanceJob,
+	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) error {
+	desiredCronJob, err := r.Builder.BuildMaintenanceCronJob(key, maintenanceJob, mariadb)
+	if err != nil {
+		return fmt.Errorf("error building CronJob: %v", err)
+	}
+
+	var existingCronJob batchv1.CronJob
+	if err := r.Get(ctx, key, &existingCronJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting CronJob: %v", err)
+		}
+
+		if err := r.Create(ctx, desiredCronJob); err != nil {
+			return fmt.Errorf("error creating CronJob: %v", err)
+		}
+		return nil
+	}
+
+	patch := client.MergeFrom(existingCronJob.DeepCopy())
+	existingCronJob.Spec.Schedule = desiredCronJob.Spec.Schedule
+	existingCronJob.Spec.Suspend = desiredCronJob.Spec.Suspend
+	existingCronJob.Spec.JobTemplate.Spec.BackoffLimit = desiredCronJob.Spec.JobTemplate.Spec.BackoffLimit
+	existingCronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds = desiredCronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds
+
+	if err := r.Patch(ctx, &existingCronJob, patch); err != nil {
+		return fmt.Errorf("error patching CronJob: %v", err)
+	}
+	return nil
+}
+
+func (r *MaintenanceJobReconciler) patchStatus(ctx context.Context, maintenanceJob *mariadbv1alpha1.MaintenanceJob,
+	patcher condition.Patcher) error {
+	patch := client.MergeFrom(maintenanceJob.DeepCopy())
+	patcher(&maintenanceJob.Status)
+
+	if err := r.Client.Status().Patch(ctx, maintenanceJob, patch); err != nil {
+		return fmt.Errorf("error patching MaintenanceJob status: %v", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MaintenanceJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.MaintenanceJob{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&batchv1.Job{}).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/audit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
@@ -15,6 +18,8 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/endpoints"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/galera"
 	galeraresources "github.com/mariadb-operator/mariadb-operator/pkg/controller/galera/resources"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/rbac"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/replication"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
@@ -23,8 +28,11 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/discovery"
 	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
 	"github.com/mariadb-operator/mariadb-operator/pkg/health"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -35,6 +43,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -49,6 +58,7 @@ type MariaDBReconciler struct {
 	ConditionReady  *condition.Ready
 	Environment     *environment.Environment
 	DiscoveryClient *discovery.DiscoveryClient
+	Auditor         *audit.Auditor
 
 	ConfigMapReconciler      *configmap.ConfigMapReconciler
 	SecretReconciler         *secret.SecretReconciler
@@ -57,9 +67,13 @@ type MariaDBReconciler struct {
 	RBACReconciler           *rbac.RBACReconciler
 	DeploymentReconciler     *deployment.DeploymentReconciler
 	ServiceMonitorReconciler *servicemonitor.ServiceMonitorReconciler
+	ActionLimiter            *ratelimit.ActionLimiter
 
 	ReplicationReconciler *replication.ReplicationReconciler
 	GaleraReconciler      *galera.GaleraReconciler
+
+	SqlOpts         []sqlClient.Opt
+	RequeueInterval time.Duration
 }
 
 type reconcilePhase struct {
@@ -72,28 +86,38 @@ type patcher func(*mariadbv1alpha1.MariaDBStatus) error
 //+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbs/finalizers,verbs=update
-//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restores;connections,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restores;connections;backups,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
-//+kubebuilder:rbac:groups="",resources=services,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=services,verbs=list;watch;create;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=endpoints,verbs=create;patch;get;list;watch
 //+kubebuilder:rbac:groups="",resources=endpoints/restricted,verbs=create;patch;get;list;watch
-//+kubebuilder:rbac:groups="",resources=pods,verbs=get;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;patch;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;patch
 //+kubebuilder:rbac:groups="",resources=events,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings;clusterrolebindings,verbs=list;watch;create;patch
 //+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 //+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get
+//+kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;create;patch;delete
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;create;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var mariadb mariadbv1alpha1.MariaDB
 	if err := r.Get(ctx, req.NamespacedName, &mariadb); err != nil {
+		if apierrors.IsNotFound(err) {
+			replication.DeleteReplicationMetrics(req.NamespacedName)
+			replication.DeleteReplicationChannelMetrics(req.NamespacedName)
+			galera.DeleteGaleraMetrics(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	if err := r.patchStatus(ctx, &mariadb, r.patcher(ctx, &mariadb)); err != nil && !apierrors.IsNotFound(err) {
@@ -110,16 +134,45 @@ func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			Reconcile: r.setStatusDefaults,
 		},
 		{
-			Name:      "Secret",
-			Reconcile: r.reconcileSecret,
+			Name:      "History",
+			Reconcile: r.reconcileHistory,
+		},
+		{
+			Name:      "ScheduledScaling",
+			Reconcile: r.reconcileScheduledScaling,
 		},
 		{
-			Name:      "ConfigMap",
-			Reconcile: r.reconcileConfigMap,
+			Name:      "ActionRateLimit",
+			Reconcile: r.reconcileActionRateLimit,
 		},
 		{
-			Name:      "RBAC",
-			Reconcile: r.reconcileRBAC,
+			Name:      "Hibernation",
+			Reconcile: r.reconcileHibernation,
+		},
+		{
+			Name: "Resources",
+			Reconcile: reconcileConcurrently(
+				reconcilePhase{
+					Name:      "Secret",
+					Reconcile: r.reconcileSecret,
+				},
+				reconcilePhase{
+					Name:      "ConfigMap",
+					Reconcile: r.reconcileConfigMap,
+				},
+				reconcilePhase{
+					Name:      "RBAC",
+					Reconcile: r.reconcileRBAC,
+				},
+				reconcilePhase{
+					Name:      "Service",
+					Reconcile: r.reconcileService,
+				},
+			),
+		},
+		{
+			Name:      "TLS",
+			Reconcile: r.reconcileTLS,
 		},
 		{
 			Name:      "StatefulSet",
@@ -130,9 +183,12 @@ func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			Reconcile: r.reconcilePodDisruptionBudget,
 		},
 		{
-			Name:      "Service",
-			Reconcile: r.reconcileService,
+			Name:      "GaleraArbitrator",
+			Reconcile: r.reconcileGaleraArbitrator,
 		},
+	}
+	// podPhases require the Pods to be running, they are skipped while the MariaDB is hibernated.
+	podPhases := []reconcilePhase{
 		{
 			Name:      "Connection",
 			Reconcile: r.reconcileConnection,
@@ -141,6 +197,10 @@ func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			Name:      "Replication",
 			Reconcile: r.reconcileReplication,
 		},
+		{
+			Name:      "ReplicationChannels",
+			Reconcile: r.reconcileReplicationChannels,
+		},
 		{
 			Name:      "Galera",
 			Reconcile: r.reconcileGalera,
@@ -149,14 +209,88 @@ func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			Name:      "Restore",
 			Reconcile: r.reconcileRestore,
 		},
+		{
+			Name:      "SeedData",
+			Reconcile: r.reconcileSeedData,
+		},
+		{
+			Name:      "OperatorAccount",
+			Reconcile: r.reconcileOperatorAccount,
+		},
+		{
+			Name:      "ProbeAccount",
+			Reconcile: r.reconcileProbeAccount,
+		},
+		{
+			Name:      "PasswordRotation",
+			Reconcile: r.reconcilePasswordRotation,
+		},
+		{
+			Name:      "Spider",
+			Reconcile: r.reconcileSpider,
+		},
 		{
 			Name:      "Metrics",
 			Reconcile: r.reconcileMetrics,
 		},
+		{
+			Name:      "SessionPolicy",
+			Reconcile: r.reconcileSessionPolicy,
+		},
+		{
+			Name:      "RightSizing",
+			Reconcile: r.reconcileRightSizing,
+		},
+		{
+			Name:      "RollingRestart",
+			Reconcile: r.reconcileRollingRestart,
+		},
+		{
+			Name:      "TLSReload",
+			Reconcile: r.reconcileTLSReload,
+		},
+	}
+
+	if result, err := r.reconcilePhases(ctx, &mariadb, phases); !result.IsZero() || err != nil {
+		return result, err
+	}
+	var warmUpResult ctrl.Result
+	if !mariadb.IsHibernated() {
+		if result, err := r.reconcilePhases(ctx, &mariadb, podPhases); !result.IsZero() || err != nil {
+			return result, err
+		}
+		// The warm-up requeues until the replicas are warmed up, which must not hold back the rest of the phases.
+		result, err := r.reconcileWarmUp(ctx, &mariadb)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error reconciling WarmUp: %v", err)
+		}
+		warmUpResult = result
+	}
+
+	if mariadb.Status.ObservedGeneration != mariadb.Generation {
+		if err := r.patchStatus(ctx, &mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.ObservedGeneration = mariadb.Generation
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching observed generation: %v", err)
+		}
 	}
 
+	result := minResult(restartPendingResult(&mariadb), scheduledScalingResult(&mariadb), actionRateLimitResult(&mariadb),
+		upgradePreflightResult(&mariadb), rightSizingResult(&mariadb), replicationLagResult(&mariadb), probeAccountResult(&mariadb),
+		metricsPasswordRotationResult(&mariadb), tlsRenewalResult(&mariadb), passwordRotationResult(&mariadb), warmUpResult)
+	if r.RequeueInterval > 0 && (result.IsZero() || r.RequeueInterval < result.RequeueAfter) {
+		log.FromContext(ctx).V(1).Info("Requeuing MariaDB")
+		return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+	}
+	return result, nil
+}
+
+// reconcilePhases runs the phases in order, stopping at the first one that fails or requeues.
+func (r *MariaDBReconciler) reconcilePhases(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	phases []reconcilePhase) (ctrl.Result, error) {
 	for _, p := range phases {
-		result, err := p.Reconcile(ctx, &mariadb)
+		result, err := p.Reconcile(ctx, mariadb)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				continue
@@ -166,9 +300,13 @@ func (r *MariaDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			errBundle = multierror.Append(errBundle, err)
 
 			msg := fmt.Sprintf("Error reconciling %s: %v", p.Name, err)
-			patchErr := r.patchStatus(ctx, &mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
-				patcher := r.ConditionReady.PatcherFailed(msg)
+			patcher := r.ConditionReady.PatcherFailed(msg)
+			if sqlClient.IsUnsupported(err) {
+				patcher = r.ConditionReady.PatcherUnsupported(fmt.Sprintf("Unsupported by MariaDB: %v", err))
+			}
+			patchErr := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
 				patcher(s)
+				condition.SetMariaDBHealth(s, nil)
 				return nil
 			})
 			if apierrors.IsNotFound(patchErr) {
@@ -224,8 +362,32 @@ func (r *MariaDBReconciler) reconcileConfigMap(ctx context.Context, mariadb *mar
 				configMapKeyRef.Key: *mariadb.Spec.MyCnf,
 			},
 		}
-		err := r.ConfigMapReconciler.Reconcile(ctx, &req)
-		return ctrl.Result{}, err
+		if err := r.ConfigMapReconciler.Reconcile(ctx, &req); err != nil {
+			return ctrl.Result{}, err
+		}
+		if mariadb.Spec.StrictOwnership {
+			if err := r.reconcileConfigMapDrift(ctx, mariadb, req.Key, req.Data); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	if mariadb.Galera().Enabled && mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+		req := configmap.ReconcileRequest{
+			Mariadb: mariadb,
+			Owner:   mariadb,
+			Key:     mariadb.WsrepNotifyKey(),
+			Data: map[string]string{
+				galeraresources.WsrepNotifyScriptKey: galeraresources.WsrepNotifyScript,
+			},
+		}
+		if err := r.ConfigMapReconciler.Reconcile(ctx, &req); err != nil {
+			return ctrl.Result{}, err
+		}
+		if mariadb.Spec.StrictOwnership {
+			if err := r.reconcileConfigMapDrift(ctx, mariadb, req.Key, req.Data); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 	return ctrl.Result{}, nil
 }
@@ -241,6 +403,9 @@ func (r *MariaDBReconciler) reconcileStatefulSet(ctx context.Context, mariadb *m
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error building StatefulSet: %v", err)
 	}
+	if err := r.setConfigChecksum(ctx, mariadb, desiredSts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error setting config checksum: %v", err)
+	}
 
 	var existingSts appsv1.StatefulSet
 	if err := r.Get(ctx, key, &existingSts); err != nil {
@@ -253,10 +418,37 @@ func (r *MariaDBReconciler) reconcileStatefulSet(ctx context.Context, mariadb *m
 		return ctrl.Result{}, nil
 	}
 
+	deferred := deferPodTemplateMetadata(mariadb, desiredSts, &existingSts)
+	if err := r.reconcileRestartPending(ctx, mariadb, &existingSts, deferred); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling restart pending: %v", err)
+	}
+
+	if mariadb.Spec.StrictOwnership {
+		r.recordStatefulSetDrift(mariadb, desiredSts, &existingSts)
+	}
+
+	if err := r.reconcileUpgradePreflight(ctx, mariadb, desiredSts, &existingSts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling upgrade pre-flight: %v", err)
+	}
+
 	patch := client.MergeFrom(existingSts.DeepCopy())
+	r.reconcileUpgrade(mariadb, desiredSts, &existingSts)
 	existingSts.Spec.Template = desiredSts.Spec.Template
 	existingSts.Spec.Replicas = desiredSts.Spec.Replicas
-	return ctrl.Result{}, r.Patch(ctx, &existingSts, patch)
+	if mariadb.Spec.StrictOwnership {
+		if existingSts.Annotations == nil {
+			existingSts.Annotations = map[string]string{}
+		}
+		existingSts.Annotations[metadata.GenerationAnnotation] = strconv.FormatInt(mariadb.Generation, 10)
+	}
+	if err := r.Patch(ctx, &existingSts, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching StatefulSet: %v", err)
+	}
+
+	if err := r.reconcilePVCMetadata(ctx, mariadb, desiredSts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling PVC metadata: %v", err)
+	}
+	return ctrl.Result{}, nil
 }
 
 func (r *MariaDBReconciler) reconcilePodDisruptionBudget(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
@@ -274,6 +466,9 @@ func (r *MariaDBReconciler) reconcileService(ctx context.Context, mariadb *maria
 		if err := r.reconcileSecondaryService(ctx, mariadb); err != nil {
 			return ctrl.Result{}, err
 		}
+		if err := r.reconcileSecondaryServices(ctx, mariadb); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 	if err := r.reconcileInternalService(ctx, mariadb); err != nil {
 		return ctrl.Result{}, err
@@ -308,13 +503,32 @@ func (r *MariaDBReconciler) reconcileConnection(ctx context.Context, mariadb *ma
 }
 
 func (r *MariaDBReconciler) reconcileReplication(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
-	return ctrl.Result{}, r.ReplicationReconciler.Reconcile(ctx, mariadb)
+	return r.ReplicationReconciler.Reconcile(ctx, mariadb)
+}
+
+func (r *MariaDBReconciler) reconcileReplicationChannels(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	return ctrl.Result{}, r.ReplicationReconciler.ReconcileChannels(ctx, mariadb)
+}
+
+// replicationLagCheckInterval is the interval in which the replication lag is checked when the secondary Services
+// only serve the replicas that are not lagging behind, or when replication channels are configured.
+const replicationLagCheckInterval = 10 * time.Second
+
+func replicationLagResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if mariadb.SecondaryMaxLag() == nil && len(mariadb.Spec.ReplicationChannels) == 0 {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: replicationLagCheckInterval}
 }
 
 func (r *MariaDBReconciler) reconcileGalera(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	return ctrl.Result{}, r.GaleraReconciler.Reconcile(ctx, mariadb)
 }
 
+func (r *MariaDBReconciler) reconcileGaleraArbitrator(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	return ctrl.Result{}, r.GaleraReconciler.ReconcileArbitrator(ctx, mariadb)
+}
+
 func (r *MariaDBReconciler) reconcileRestore(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	if mariadb.Spec.BootstrapFrom == nil {
 		return ctrl.Result{}, nil
@@ -419,7 +633,7 @@ func (r *MariaDBReconciler) reconcileHighAvailabilityPDB(ctx context.Context, ma
 }
 
 func (r *MariaDBReconciler) reconcileDefaultService(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
-	key := client.ObjectKeyFromObject(mariadb)
+	key := mariadb.ServiceKey()
 	opts := builder.ServiceOpts{
 		Ports: []corev1.ServicePort{
 			{
@@ -435,7 +649,7 @@ func (r *MariaDBReconciler) reconcileDefaultService(ctx context.Context, mariadb
 	if err != nil {
 		return fmt.Errorf("error building Service: %v", err)
 	}
-	return r.ServiceReconciler.Reconcile(ctx, desiredSvc)
+	return r.reconcileDesiredService(ctx, mariadb, desiredSvc)
 }
 
 func (r *MariaDBReconciler) reconcileInternalService(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
@@ -475,7 +689,7 @@ func (r *MariaDBReconciler) reconcileInternalService(ctx context.Context, mariad
 	if err != nil {
 		return fmt.Errorf("error building internal Service: %v", err)
 	}
-	return r.ServiceReconciler.Reconcile(ctx, desiredSvc)
+	return r.reconcileDesiredService(ctx, mariadb, desiredSvc)
 }
 
 func (r *MariaDBReconciler) reconcilePrimaryService(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
@@ -504,7 +718,7 @@ func (r *MariaDBReconciler) reconcilePrimaryService(ctx context.Context, mariadb
 	if err != nil {
 		return fmt.Errorf("error building Service: %v", err)
 	}
-	return r.ServiceReconciler.Reconcile(ctx, desiredSvc)
+	return r.reconcileDesiredService(ctx, mariadb, desiredSvc)
 }
 
 func (r *MariaDBReconciler) reconcileSecondaryService(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
@@ -525,10 +739,17 @@ func (r *MariaDBReconciler) reconcileSecondaryService(ctx context.Context, maria
 	if err != nil {
 		return fmt.Errorf("error building Service: %v", err)
 	}
-	if err := r.ServiceReconciler.Reconcile(ctx, desiredSvc); err != nil {
+	if err := r.reconcileDesiredService(ctx, mariadb, desiredSvc); err != nil {
 		return err
 	}
-	if err := r.EndpointsReconciler.Reconcile(ctx, mariadb.SecondaryServiceKey(), mariadb); err != nil {
+	var endpointsOpts []endpoints.EndpointsOpt
+	if mariadb.Spec.WarmUp != nil && mariadb.Status.WarmUpEnabled {
+		endpointsOpts = append(endpointsOpts, endpoints.WithWarmUp())
+	}
+	if maxLag := mariadb.SecondaryMaxLag(); maxLag != nil {
+		endpointsOpts = append(endpointsOpts, endpoints.WithMaxLag(*maxLag))
+	}
+	if err := r.EndpointsReconciler.Reconcile(ctx, mariadb.SecondaryServiceKey(), mariadb, endpointsOpts...); err != nil {
 		if errors.Is(err, endpoints.ErrNoAddressesAvailable) {
 			log.FromContext(ctx).V(1).Info("No addresses available for secondary Endpoints")
 			return nil
@@ -542,7 +763,7 @@ func (r *MariaDBReconciler) reconcileDefaultConnection(ctx context.Context, mari
 	if mariadb.Spec.Connection == nil || !mariadb.IsInitialDataEnabled() || !mariadb.IsReady() {
 		return nil
 	}
-	key := client.ObjectKeyFromObject(mariadb)
+	key := mariadb.ConnectionKey()
 	var existingConn mariadbv1alpha1.Connection
 	if err := r.Get(ctx, key, &existingConn); err == nil {
 		return nil
@@ -630,12 +851,14 @@ func (r *MariaDBReconciler) patcher(ctx context.Context, mariadb *mariadbv1alpha
 		}
 		mariadb.Status.Replicas = sts.Status.ReadyReplicas
 
-		if mariadb.IsRestoringBackup() ||
-			mariadb.IsConfiguringReplication() || mariadb.IsSwitchingPrimary() ||
-			mariadb.HasGaleraNotReadyCondition() {
-			return nil
+		if mariadb.IsHibernated() {
+			condition.SetReadyHibernated(&mariadb.Status)
+		} else if !mariadb.IsRestoringBackup() &&
+			!mariadb.IsConfiguringReplication() && !mariadb.IsSwitchingPrimary() &&
+			!mariadb.HasGaleraNotReadyCondition() {
+			condition.SetReadyWithStatefulSet(&mariadb.Status, &sts)
 		}
-		condition.SetReadyWithStatefulSet(&mariadb.Status, &sts)
+		condition.SetMariaDBHealth(&mariadb.Status, &sts)
 		return nil
 	}
 }
@@ -658,10 +881,15 @@ func (r *MariaDBReconciler) patch(ctx context.Context, mariadb *mariadbv1alpha1.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MariaDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := r.createIndex(mgr); err != nil {
+		return fmt.Errorf("error creating index: %v", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&mariadbv1alpha1.MariaDB{}).
 		Owns(&mariadbv1alpha1.Connection{}).
 		Owns(&mariadbv1alpha1.Restore{}).
+		Owns(&mariadbv1alpha1.Backup{}).
 		Owns(&mariadbv1alpha1.User{}).
 		Owns(&mariadbv1alpha1.Grant{}).
 		Owns(&corev1.ConfigMap{}).
@@ -671,9 +899,34 @@ func (r *MariaDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&rbacv1.ClusterRoleBinding{}).
-		Complete(r)
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.mapSecretToRequests),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToRequests),
+		).
+		Watches(
+			&mariadbv1alpha1.MariaDB{},
+			handler.EnqueueRequestsFromMapFunc(r.mapSpiderNodeToRequests),
+		).
+		Complete(priority.NewReconcilerWithFunc(r.reconcilePriority, r))
+}
+
+// reconcilePriority gives critical priority to the MariaDBs that are switching the primary or recovering the Galera cluster.
+func (r *MariaDBReconciler) reconcilePriority(ctx context.Context, req ctrl.Request) priority.Priority {
+	var mariadb mariadbv1alpha1.MariaDB
+	if err := r.Get(ctx, req.NamespacedName, &mariadb); err != nil {
+		return priority.PriorityNormal
+	}
+	if mariadb.IsSwitchingPrimary() || (mariadb.Galera().Enabled && mariadb.HasGaleraNotReadyCondition()) {
+		return priority.PriorityCritical
+	}
+	return priority.PriorityNormal
 }
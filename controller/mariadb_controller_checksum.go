@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	rootPasswordSecretField = ".spec.rootPasswordSecretKeyRef.name"
+	tlsSecretField          = ".spec.tls.secretName"
+	myCnfConfigMapField     = ".spec.myCnfConfigMapKeyRef.name"
+)
+
+// setConfigChecksum annotates the Pod template with a checksum of the referenced ConfigMaps, so the Pods are rolled out when
+// any of them changes. References annotated with skip-rollout are not taken into account. The root password is not part of
+// the checksum, as it is changed in the server via SQL and rotating it must not restart the Pods.
+func (r *MariaDBReconciler) setConfigChecksum(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	sts *appsv1.StatefulSet) error {
+	checksum, err := r.configChecksum(ctx, mariadb)
+	if err != nil {
+		return err
+	}
+	if checksum == "" {
+		return nil
+	}
+	if sts.Spec.Template.Annotations == nil {
+		sts.Spec.Template.Annotations = map[string]string{}
+	}
+	sts.Spec.Template.Annotations[metadata.ConfigChecksumAnnotation] = checksum
+	return nil
+}
+
+func (r *MariaDBReconciler) configChecksum(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	var entries []string
+
+	if ref := mariadb.Spec.MyCnfConfigMapKeyRef; ref != nil {
+		var configMap corev1.ConfigMap
+		key := types.NamespacedName{Name: ref.Name, Namespace: mariadb.Namespace}
+		if err := r.Get(ctx, key, &configMap); err != nil {
+			return "", fmt.Errorf("error getting my.cnf ConfigMap: %v", err)
+		}
+		if !skipRollout(&configMap) {
+			entries = append(entries, fmt.Sprintf("configmap/%s/%s=%s", ref.Name, ref.Key, configMap.Data[ref.Key]))
+		}
+	}
+
+	// Renewed certificates are reloaded by the Pods, they are only rolled out when the certificate could not be reloaded.
+	if mariadb.IsTLSEnabled() && mariadb.Status.TLS != nil && mariadb.Status.TLS.RolloutCertSerial != "" {
+		entries = append(entries, fmt.Sprintf("tls/serial=%s", mariadb.Status.TLS.RolloutCertSerial))
+	}
+
+	if len(entries) == 0 {
+		return "", nil
+	}
+	sort.Strings(entries)
+
+	hash := sha256.New()
+	for _, e := range entries {
+		hash.Write([]byte(e))
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func skipRollout(obj client.Object) bool {
+	return obj.GetAnnotations()[metadata.SkipRolloutAnnotation] == "true"
+}
+
+func (r *MariaDBReconciler) createIndex(mgr ctrl.Manager) error {
+	secretIndexFn := func(rawObj client.Object) []string {
+		mariadb := rawObj.(*mariadbv1alpha1.MariaDB)
+		if mariadb.Spec.RootPasswordSecretKeyRef.Name == "" {
+			return nil
+		}
+		return []string{mariadb.Spec.RootPasswordSecretKeyRef.Name}
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.MariaDB{}, rootPasswordSecretField,
+		secretIndexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field in MariaDB: %v", rootPasswordSecretField, err)
+	}
+
+	tlsSecretIndexFn := func(rawObj client.Object) []string {
+		mariadb := rawObj.(*mariadbv1alpha1.MariaDB)
+		if !mariadb.IsTLSEnabled() {
+			return nil
+		}
+		names := []string{mariadb.TLSKey().Name}
+		if caName := mariadb.TLSCASecretKeyRef().Name; caName != names[0] {
+			names = append(names, caName)
+		}
+		return names
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.MariaDB{}, tlsSecretField,
+		tlsSecretIndexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field in MariaDB: %v", tlsSecretField, err)
+	}
+
+	configMapIndexFn := func(rawObj client.Object) []string {
+		mariadb := rawObj.(*mariadbv1alpha1.MariaDB)
+		if mariadb.Spec.MyCnfConfigMapKeyRef == nil || mariadb.Spec.MyCnfConfigMapKeyRef.Name == "" {
+			return nil
+		}
+		return []string{mariadb.Spec.MyCnfConfigMapKeyRef.Name}
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.MariaDB{}, myCnfConfigMapField,
+		configMapIndexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field in MariaDB: %v", myCnfConfigMapField, err)
+	}
+
+	spiderNodeIndexFn := func(rawObj client.Object) []string {
+		mariadb := rawObj.(*mariadbv1alpha1.MariaDB)
+		if !mariadb.IsSpiderEnabled() {
+			return nil
+		}
+		var values []string
+		for _, node := range mariadb.Spec.Spider.Nodes {
+			namespace := node.MariaDBRef.Namespace
+			if namespace == "" {
+				namespace = mariadb.Namespace
+			}
+			values = append(values, spiderNodeIndexValue(node.MariaDBRef.Name, namespace))
+		}
+		return values
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.MariaDB{}, spiderNodeField,
+		spiderNodeIndexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field in MariaDB: %v", spiderNodeField, err)
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) mapSecretToRequests(ctx context.Context, secret client.Object) []reconcile.Request {
+	return append(
+		r.mapFieldToRequests(ctx, rootPasswordSecretField, secret),
+		r.mapFieldToRequests(ctx, tlsSecretField, secret)...,
+	)
+}
+
+func (r *MariaDBReconciler) mapConfigMapToRequests(ctx context.Context, configMap client.Object) []reconcile.Request {
+	return r.mapFieldToRequests(ctx, myCnfConfigMapField, configMap)
+}
+
+func (r *MariaDBReconciler) mapFieldToRequests(ctx context.Context, field string, obj client.Object) []reconcile.Request {
+	mariadbsToReconcile := &mariadbv1alpha1.MariaDBList{}
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(field, obj.GetName()),
+		Namespace:     obj.GetNamespace(),
+	}
+
+	if err := r.List(ctx, mariadbsToReconcile, listOpts); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(mariadbsToReconcile.Items))
+	for i, item := range mariadbsToReconcile.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MariaDB config checksum", func() {
+	newMariaDB := func() *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-checksum",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				RootPasswordSecretKeyRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "mariadb-checksum-root",
+					},
+					Key: "password",
+				},
+				MyCnfConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "mariadb-checksum-config",
+					},
+					Key: "my.cnf",
+				},
+			},
+		}
+	}
+	newSecret := func(password string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-checksum-root",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				"password": []byte(password),
+			},
+		}
+	}
+	newConfigMap := func(myCnf string, annotations map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mariadb-checksum-config",
+				Namespace:   testNamespace,
+				Annotations: annotations,
+			},
+			Data: map[string]string{
+				"my.cnf": myCnf,
+			},
+		}
+	}
+	checksum := func(mariadb *mariadbv1alpha1.MariaDB, secret *corev1.Secret, configMap *corev1.ConfigMap) string {
+		r := &MariaDBReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(secret, configMap).
+				Build(),
+		}
+		checksum, err := r.configChecksum(testCtx, mariadb)
+		Expect(err).ToNot(HaveOccurred())
+		return checksum
+	}
+
+	It("Should not change when the root password is rotated", func() {
+		mariadb := newMariaDB()
+		configMap := newConfigMap("[mariadb]", nil)
+
+		before := checksum(mariadb, newSecret("MariaDB11!"), configMap)
+		Expect(before).ToNot(BeEmpty())
+		Expect(checksum(mariadb, newSecret("rotated"), configMap)).To(Equal(before))
+	})
+
+	It("Should change when the my.cnf ConfigMap changes", func() {
+		mariadb := newMariaDB()
+		secret := newSecret("MariaDB11!")
+
+		before := checksum(mariadb, secret, newConfigMap("[mariadb]", nil))
+		Expect(checksum(mariadb, secret, newConfigMap("[mariadb]\nmax_allowed_packet=256M", nil))).ToNot(Equal(before))
+	})
+
+	It("Should skip the ConfigMaps annotated with skip-rollout", func() {
+		mariadb := newMariaDB()
+		configMap := newConfigMap("[mariadb]", map[string]string{
+			metadata.SkipRolloutAnnotation: "true",
+		})
+		Expect(checksum(mariadb, newSecret("MariaDB11!"), configMap)).To(BeEmpty())
+	})
+
+	It("Should change when the certificate could not be reloaded", func() {
+		mariadb := newMariaDB()
+		secret := newSecret("MariaDB11!")
+		configMap := newConfigMap("[mariadb]", nil)
+		before := checksum(mariadb, secret, configMap)
+
+		mariadb.Spec.TLS = &mariadbv1alpha1.MariaDBTLS{
+			Enabled: true,
+		}
+		mariadb.Status.TLS = &mariadbv1alpha1.MariaDBTLSStatus{
+			RolloutCertSerial: "1234",
+		}
+		Expect(checksum(mariadb, secret, configMap)).ToNot(Equal(before))
+	})
+})
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// reconcileConcurrently returns a reconcile function that runs independent phases concurrently.
+// All the phases are run to completion: errors are aggregated and the shortest requeue is returned.
+// Phases must only read the MariaDB object, as it is shared between goroutines.
+func reconcileConcurrently(phases ...reconcilePhase) func(context.Context, *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	return func(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+		results := make([]ctrl.Result, len(phases))
+		errs := make([]error, len(phases))
+
+		var wg sync.WaitGroup
+		for i, p := range phases {
+			wg.Add(1)
+			go func(i int, p reconcilePhase) {
+				defer wg.Done()
+				results[i], errs[i] = p.Reconcile(ctx, mariadb)
+			}(i, p)
+		}
+		wg.Wait()
+
+		var errBundle *multierror.Error
+		for i, err := range errs {
+			if err == nil || apierrors.IsNotFound(err) {
+				continue
+			}
+			errBundle = multierror.Append(errBundle, fmt.Errorf("error reconciling %s: %v", phases[i].Name, err))
+		}
+		if err := errBundle.ErrorOrNil(); err != nil {
+			return ctrl.Result{}, err
+		}
+		return minResult(results...), nil
+	}
+}
+
+func errorPhase(name string, fn func(context.Context, *mariadbv1alpha1.MariaDB) error) reconcilePhase {
+	return reconcilePhase{
+		Name: name,
+		Reconcile: func(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+			return ctrl.Result{}, fn(ctx, mariadb)
+		},
+	}
+}
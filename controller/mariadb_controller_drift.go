@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (r *MariaDBReconciler) reconcileDesiredService(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	desiredSvc *corev1.Service) error {
+	if mariadb.Spec.StrictOwnership {
+		key := client.ObjectKeyFromObject(desiredSvc)
+		var existingSvc corev1.Service
+		if err := r.Get(ctx, key, &existingSvc); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error getting Service: %v", err)
+			}
+		} else {
+			r.recordDrift(mariadb, "Service", key, serviceDrift(desiredSvc, &existingSvc))
+		}
+	}
+	return r.ServiceReconciler.Reconcile(ctx, desiredSvc)
+}
+
+func (r *MariaDBReconciler) reconcileConfigMapDrift(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	key types.NamespacedName, data map[string]string) error {
+	var existingConfigMap corev1.ConfigMap
+	if err := r.Get(ctx, key, &existingConfigMap); err != nil {
+		return fmt.Errorf("error getting ConfigMap: %v", err)
+	}
+
+	var drift []string
+	for k, v := range data {
+		if existingConfigMap.Data[k] != v {
+			drift = append(drift, fmt.Sprintf("data.%s", k))
+		}
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(existingConfigMap.DeepCopy())
+	if existingConfigMap.Data == nil {
+		existingConfigMap.Data = map[string]string{}
+	}
+	for k, v := range data {
+		existingConfigMap.Data[k] = v
+	}
+	if err := r.Patch(ctx, &existingConfigMap, patch); err != nil {
+		return fmt.Errorf("error patching ConfigMap: %v", err)
+	}
+	r.recordDrift(mariadb, "ConfigMap", key, drift)
+	return nil
+}
+
+func (r *MariaDBReconciler) recordStatefulSetDrift(mariadb *mariadbv1alpha1.MariaDB, desiredSts,
+	existingSts *appsv1.StatefulSet) {
+	// Differences introduced by MariaDB spec changes are expected, only report drift when the
+	// StatefulSet has already been reconciled with the current MariaDB generation.
+	if existingSts.Annotations[metadata.GenerationAnnotation] != strconv.FormatInt(mariadb.Generation, 10) {
+		return
+	}
+	r.recordDrift(mariadb, "StatefulSet", client.ObjectKeyFromObject(existingSts), statefulSetDrift(desiredSts, existingSts))
+}
+
+func (r *MariaDBReconciler) recordDrift(mariadb *mariadbv1alpha1.MariaDB, kind string, key types.NamespacedName,
+	drift []string) {
+	if len(drift) == 0 {
+		return
+	}
+	r.Recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonDriftReverted,
+		"Reverted out-of-band modifications in %s '%s': %s", kind, key.Name, strings.Join(drift, ", "))
+}
+
+func statefulSetDrift(desired, existing *appsv1.StatefulSet) []string {
+	var drift []string
+	if desired.Spec.Replicas != nil && existing.Spec.Replicas != nil && *desired.Spec.Replicas != *existing.Spec.Replicas {
+		drift = append(drift, "spec.replicas")
+	}
+	if !equality.Semantic.DeepDerivative(desired.Spec.Template.ObjectMeta, existing.Spec.Template.ObjectMeta) {
+		drift = append(drift, "spec.template.metadata")
+	}
+	if !equality.Semantic.DeepDerivative(desired.Spec.Template.Spec, existing.Spec.Template.Spec) {
+		drift = append(drift, "spec.template.spec")
+	}
+	return drift
+}
+
+func serviceDrift(desired, existing *corev1.Service) []string {
+	var drift []string
+	if !equality.Semantic.DeepDerivative(desired.Spec.Ports, existing.Spec.Ports) {
+		drift = append(drift, "spec.ports")
+	}
+	if !equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+		drift = append(drift, "spec.selector")
+	}
+	if desired.Spec.Type != "" && desired.Spec.Type != existing.Spec.Type {
+		drift = append(drift, "spec.type")
+	}
+	for k, v := range desired.Labels {
+		if existing.Labels[k] != v {
+			drift = append(drift, "metadata.labels")
+			break
+		}
+	}
+	for k, v := range desired.Annotations {
+		if existing.Annotations[k] != v {
+			drift = append(drift, "metadata.annotations")
+			break
+		}
+	}
+	return drift
+}
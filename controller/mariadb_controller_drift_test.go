@@ -0,0 +1,186 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MariaDB drift", func() {
+	newMariaDB := func() *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "mariadb-drift",
+				Namespace:  testNamespace,
+				Generation: 2,
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				StrictOwnership: true,
+			},
+		}
+	}
+	newStatefulSet := func(replicas int32, image string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-drift",
+				Namespace: testNamespace,
+				Annotations: map[string]string{
+					metadata.GenerationAnnotation: "2",
+				},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "mariadb",
+								Image: image,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	newService := func(port int32, labels map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-drift",
+				Namespace: testNamespace,
+				Labels:    labels,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeClusterIP,
+				Ports: []corev1.ServicePort{
+					{
+						Name: "mariadb",
+						Port: port,
+					},
+				},
+				Selector: map[string]string{
+					"app.kubernetes.io/instance": "mariadb-drift",
+				},
+			},
+		}
+	}
+	newReconciler := func(objs ...client.Object) (*MariaDBReconciler, *record.FakeRecorder, client.Client) {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(objs...).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		return &MariaDBReconciler{
+			Client:   c,
+			Recorder: recorder,
+		}, recorder, c
+	}
+
+	Context("When comparing StatefulSets", func() {
+		It("Should not report drift when they match", func() {
+			Expect(statefulSetDrift(newStatefulSet(3, "mariadb:11.0.3"), newStatefulSet(3, "mariadb:11.0.3"))).To(BeEmpty())
+		})
+
+		It("Should report drift in the replicas and the Pod template", func() {
+			Expect(statefulSetDrift(newStatefulSet(3, "mariadb:11.0.3"), newStatefulSet(1, "mariadb:10.11.6"))).
+				To(ConsistOf("spec.replicas", "spec.template.spec"))
+		})
+
+		It("Should ignore the fields defaulted by the API server", func() {
+			existing := newStatefulSet(3, "mariadb:11.0.3")
+			existing.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+			existing.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+			Expect(statefulSetDrift(newStatefulSet(3, "mariadb:11.0.3"), existing)).To(BeEmpty())
+		})
+	})
+
+	Context("When comparing Services", func() {
+		It("Should not report drift when they match", func() {
+			Expect(serviceDrift(newService(3306, nil), newService(3306, nil))).To(BeEmpty())
+		})
+
+		It("Should report drift in the ports, selector, type and labels", func() {
+			desired := newService(3306, map[string]string{"app": "mariadb"})
+			existing := newService(3307, map[string]string{"app": "other"})
+			existing.Spec.Type = corev1.ServiceTypeLoadBalancer
+			existing.Spec.Selector = map[string]string{"app": "other"}
+			Expect(serviceDrift(desired, existing)).
+				To(ConsistOf("spec.ports", "spec.selector", "spec.type", "metadata.labels"))
+		})
+
+		It("Should ignore the labels not managed by the operator", func() {
+			existing := newService(3306, map[string]string{"team": "dba"})
+			Expect(serviceDrift(newService(3306, nil), existing)).To(BeEmpty())
+		})
+	})
+
+	Context("When recording StatefulSet drift", func() {
+		It("Should report drift once the StatefulSet is reconciled with the current generation", func() {
+			r, recorder, _ := newReconciler()
+			r.recordStatefulSetDrift(newMariaDB(), newStatefulSet(3, "mariadb:11.0.3"), newStatefulSet(1, "mariadb:11.0.3"))
+			Expect(recorder.Events).To(Receive(ContainSubstring(mariadbv1alpha1.ReasonDriftReverted)))
+		})
+
+		It("Should not report drift caused by a MariaDB spec change", func() {
+			r, recorder, _ := newReconciler()
+			existing := newStatefulSet(1, "mariadb:11.0.3")
+			existing.Annotations[metadata.GenerationAnnotation] = "1"
+			r.recordStatefulSetDrift(newMariaDB(), newStatefulSet(3, "mariadb:11.0.3"), existing)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("When reconciling ConfigMap drift", func() {
+		key := types.NamespacedName{
+			Name:      "mariadb-drift-config",
+			Namespace: testNamespace,
+		}
+		newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+			return &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Data: data,
+			}
+		}
+
+		It("Should revert the modified keys", func() {
+			r, recorder, c := newReconciler(newConfigMap(map[string]string{
+				"my.cnf": "[mariadb]\nmax_connections=10",
+				"extra":  "kept",
+			}))
+			Expect(r.reconcileConfigMapDrift(testCtx, newMariaDB(), key, map[string]string{
+				"my.cnf": "[mariadb]",
+			})).To(Succeed())
+
+			var configMap corev1.ConfigMap
+			Expect(c.Get(testCtx, key, &configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{
+				"my.cnf": "[mariadb]",
+				"extra":  "kept",
+			}))
+			Expect(recorder.Events).To(Receive(ContainSubstring("data.my.cnf")))
+		})
+
+		It("Should not report drift when the keys match", func() {
+			r, recorder, _ := newReconciler(newConfigMap(map[string]string{
+				"my.cnf": "[mariadb]",
+			}))
+			Expect(r.reconcileConfigMapDrift(testCtx, newMariaDB(), key, map[string]string{
+				"my.cnf": "[mariadb]",
+			})).To(Succeed())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
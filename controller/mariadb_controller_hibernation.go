@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	hibernationBackupRequeueInterval = 10 * time.Second
+	hibernationBackupRetryInterval   = time.Minute
+)
+
+// reconcileHibernation marks the MariaDB as hibernated once the final Backup, if any, has completed, and unmarks it when the
+// hibernation is disabled. The StatefulSet is scaled down to zero Pods by the builder while the MariaDB is hibernated,
+// keeping the PVCs, so the previous topology is restored once it is scaled back.
+func (r *MariaDBReconciler) reconcileHibernation(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mariadb.IsHibernateEnabled() {
+		if mariadb.Status.Hibernation == nil {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.resume(ctx, mariadb)
+	}
+	if mariadb.IsHibernated() {
+		return ctrl.Result{}, nil
+	}
+
+	if mariadb.Spec.Hibernate.FinalBackup != nil {
+		if result, err := r.reconcileHibernationBackup(ctx, mariadb); !result.IsZero() || err != nil {
+			return result, err
+		}
+	}
+
+	log.FromContext(ctx).Info("Hibernating MariaDB")
+	r.Recorder.Event(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonMariaDBHibernated, "Scaling down to zero Pods")
+
+	return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		if s.Hibernation == nil {
+			s.Hibernation = &mariadbv1alpha1.HibernationStatus{}
+		}
+		s.Hibernation.HibernatedAt = ptr.To(metav1.Now())
+		condition.SetReadyHibernated(s)
+		return nil
+	})
+}
+
+// reconcileHibernationBackup takes the final Backup, requeuing until it has completed successfully.
+func (r *MariaDBReconciler) reconcileHibernationBackup(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if mariadb.Status.Hibernation == nil || mariadb.Status.Hibernation.FinalBackup == nil {
+		name := mariadb.HibernationBackupKey(time.Now()).Name
+		if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.Hibernation = &mariadbv1alpha1.HibernationStatus{
+				FinalBackup: &name,
+			}
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching hibernation status: %v", err)
+		}
+	}
+	key := types.NamespacedName{
+		Name:      *mariadb.Status.Hibernation.FinalBackup,
+		Namespace: mariadb.Namespace,
+	}
+
+	var backup mariadbv1alpha1.Backup
+	if err := r.Get(ctx, key, &backup); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting final Backup: %v", err)
+		}
+		desiredBackup, err := r.Builder.BuildHibernationBackup(mariadb, key)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error building final Backup: %v", err)
+		}
+		if err := r.Create(ctx, desiredBackup); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating final Backup: %v", err)
+		}
+		log.FromContext(ctx).Info("Taking final Backup before hibernating", "backup", key.Name)
+		return ctrl.Result{RequeueAfter: hibernationBackupRequeueInterval}, nil
+	}
+
+	if backup.IsFailed() {
+		return r.retryHibernationBackup(ctx, mariadb, &backup)
+	}
+	if !backup.IsSuccessful() {
+		log.FromContext(ctx).V(1).Info("Waiting for final Backup to complete", "backup", key.Name)
+		return ctrl.Result{RequeueAfter: hibernationBackupRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// retryHibernationBackup deletes a failed final Backup, so a new one is taken in the next reconciliation.
+func (r *MariaDBReconciler) retryHibernationBackup(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	backup *mariadbv1alpha1.Backup) (ctrl.Result, error) {
+	log.FromContext(ctx).Info("Final Backup failed, retrying", "backup", backup.Name)
+	r.Recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonHibernationBackupFailed,
+		"Final Backup '%s' failed, retrying in %s", backup.Name, hibernationBackupRetryInterval)
+
+	if err := r.Delete(ctx, backup); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("error deleting failed final Backup: %v", err)
+	}
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.Hibernation = nil
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching hibernation status: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: hibernationBackupRetryInterval}, nil
+}
+
+// resume scales the MariaDB back after being hibernated. As all the Galera nodes have been stopped,
+// the cluster is bootstrapped again by the Galera recovery.
+func (r *MariaDBReconciler) resume(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	wasHibernated := mariadb.IsHibernated()
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.Hibernation = nil
+		if wasHibernated && mariadb.Galera().Enabled {
+			s.GaleraRecovery = nil
+			condition.SetGaleraNotReady(s, mariadb)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error patching hibernation status: %v", err)
+	}
+	if wasHibernated {
+		log.FromContext(ctx).Info("Resuming MariaDB")
+		r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonMariaDBResumed,
+			"Scaling back to %d replicas", mariadb.ScaledReplicas())
+	}
+	return nil
+}
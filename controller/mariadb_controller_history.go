@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/audit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileHistory records the changes of the key fields of the spec in the MariaDB history.
+func (r *MariaDBReconciler) reconcileHistory(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if mariadb.HistoryLimit() == 0 {
+		if mariadb.Status.History == nil && mariadb.Status.AuditedFields == nil {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.History = nil
+			s.AuditedFields = nil
+			return nil
+		})
+	}
+
+	fields := audit.KeyFields(mariadb)
+	if mariadb.Status.AuditedFields == nil {
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.AuditedFields = fields
+			return nil
+		})
+	}
+	changes := audit.Diff(mariadb.Status.AuditedFields, fields)
+	if len(changes) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	actor := audit.SpecManager(mariadb)
+	msg := audit.ChangesMessage(changes)
+	log.FromContext(ctx).Info("Spec changed", "actor", actor, "changes", msg)
+
+	updated, err := r.Auditor.Record(ctx, client.ObjectKeyFromObject(mariadb), mariadbv1alpha1.HistoryEntry{
+		Time:    metav1.Now(),
+		Type:    mariadbv1alpha1.HistoryEntryTypeSpecChange,
+		Actor:   actor,
+		Reason:  mariadbv1alpha1.ReasonSpecChanged,
+		Message: msg,
+		Changes: changes,
+	}, fields)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error recording spec changes: %v", err)
+	}
+	mariadb.Status.History = updated.Status.History
+	mariadb.Status.AuditedFields = updated.Status.AuditedFields
+
+	r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonSpecChanged, "Spec changed by '%s': %s", actor, msg)
+	return ctrl.Result{}, nil
+}
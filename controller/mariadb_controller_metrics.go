@@ -3,6 +3,7 @@ package controller
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"text/template"
@@ -11,10 +12,15 @@ import (
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -55,19 +61,19 @@ func (r *MariaDBReconciler) reconcileMetrics(ctx context.Context, mariadb *maria
 	if result, err := r.reconcileMetricsGrant(ctx, mariadb); !result.IsZero() || err != nil {
 		return result, err
 	}
-	if err := r.reconcileExporterConfig(ctx, mariadb); err != nil {
+	if err := r.reconcileMetricsPasswordRotation(ctx, mariadb); err != nil {
 		return ctrl.Result{}, err
 	}
-	if err := r.reconcileExporterDeployment(ctx, mariadb); err != nil {
-		return ctrl.Result{}, err
-	}
-	if err := r.reconcileExporterService(ctx, mariadb); err != nil {
+	if err := r.reconcileExporterConfig(ctx, mariadb); err != nil {
 		return ctrl.Result{}, err
 	}
-	if err := r.reconcileServiceMonitor(ctx, mariadb); err != nil {
+	if err := r.reconcileExporterDeployment(ctx, mariadb); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	return reconcileConcurrently(
+		errorPhase("exporter Service", r.reconcileExporterService),
+		errorPhase("ServiceMonitor", r.reconcileServiceMonitor),
+	)(ctx, mariadb)
 }
 
 func (r *MariaDBReconciler) reconcileMetricsPassword(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
@@ -134,16 +140,63 @@ func (r *MariaDBReconciler) reconcileMetricsGrant(ctx context.Context, mariadb *
 	return ctrl.Result{}, r.Create(ctx, grant)
 }
 
+// reconcileMetricsPasswordRotation rotates the password of the monitoring user once the rotation interval has elapsed since the last rotation.
+// The exporter config is updated with the new password afterwards, rolling out the exporter.
+func (r *MariaDBReconciler) reconcileMetricsPasswordRotation(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	interval := mariadb.Spec.Metrics.PasswordRotationInterval
+	if interval == nil {
+		return nil
+	}
+	lastRotation := mariadb.Status.MetricsPasswordRotationTime
+	if lastRotation != nil && time.Since(lastRotation.Time) < interval.Duration {
+		return nil
+	}
+	if lastRotation != nil {
+		log.FromContext(ctx).Info("Rotating metrics password")
+		secretKeyRef := mariadb.Spec.Metrics.PasswordSecretKeyRef
+		key := types.NamespacedName{
+			Name:      secretKeyRef.Name,
+			Namespace: mariadb.Namespace,
+		}
+		password, err := r.SecretReconciler.RotateRandomPassword(ctx, key, secretKeyRef.Key, "")
+		if err != nil {
+			return fmt.Errorf("error rotating metrics password: %v", err)
+		}
+
+		client, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+		if err != nil {
+			return fmt.Errorf("error connecting to MariaDB: %v", err)
+		}
+		defer client.Close()
+		if err := client.AlterUser(ctx, mariadb.Spec.Metrics.Username, password); err != nil {
+			return fmt.Errorf("error updating metrics user password: %v", err)
+		}
+	}
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.MetricsPasswordRotationTime = ptr.To(metav1.Now())
+		return nil
+	})
+}
+
+func metricsPasswordRotationResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if !mariadb.AreMetricsEnabled() || mariadb.Spec.Metrics.PasswordRotationInterval == nil ||
+		mariadb.Status.MetricsPasswordRotationTime == nil {
+		return ctrl.Result{}
+	}
+	remaining := mariadb.Spec.Metrics.PasswordRotationInterval.Duration - time.Since(mariadb.Status.MetricsPasswordRotationTime.Time)
+	if remaining <= 0 {
+		return ctrl.Result{RequeueAfter: time.Second}
+	}
+	return ctrl.Result{RequeueAfter: remaining}
+}
+
+// reconcileExporterConfig keeps the exporter config in sync with the password of the monitoring user.
 func (r *MariaDBReconciler) reconcileExporterConfig(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
 	secretKeyRef := mariadb.MetricsConfigSecretKeyRef()
 	key := types.NamespacedName{
 		Name:      secretKeyRef.Name,
 		Namespace: mariadb.Namespace,
 	}
-	var existingSecret corev1.Secret
-	if err := r.Get(ctx, key, &existingSecret); err == nil {
-		return nil
-	}
 
 	passwordSecretKeyRef := mariadb.Spec.Metrics.PasswordSecretKeyRef
 	passwordSecretKey := types.NamespacedName{
@@ -171,6 +224,19 @@ password = {{ .Password }}`)
 		return fmt.Errorf("error rendering exporter config: %v", err)
 	}
 
+	var existingSecret corev1.Secret
+	if err := r.Get(ctx, key, &existingSecret); err == nil {
+		if bytes.Equal(existingSecret.Data[secretKeyRef.Key], buf.Bytes()) {
+			return nil
+		}
+		patch := client.MergeFrom(existingSecret.DeepCopy())
+		if existingSecret.Data == nil {
+			existingSecret.Data = map[string][]byte{}
+		}
+		existingSecret.Data[secretKeyRef.Key] = buf.Bytes()
+		return r.Patch(ctx, &existingSecret, patch)
+	}
+
 	secretOpts := builder.SecretOpts{
 		MariaDB: mariadb,
 		Key:     key,
@@ -191,6 +257,19 @@ func (r *MariaDBReconciler) reconcileExporterDeployment(ctx context.Context, mar
 	if err != nil {
 		return fmt.Errorf("error building exporter Deployment: %v", err)
 	}
+
+	secretKeyRef := mariadb.MetricsConfigSecretKeyRef()
+	var configSecret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Name: secretKeyRef.Name, Namespace: mariadb.Namespace}, &configSecret); err != nil {
+		return fmt.Errorf("error getting exporter config Secret: %v", err)
+	}
+	// The exporter only reads its config on startup, so it is rolled out when the config changes.
+	if desiredDeploy.Spec.Template.Annotations == nil {
+		desiredDeploy.Spec.Template.Annotations = map[string]string{}
+	}
+	desiredDeploy.Spec.Template.Annotations[metadata.ConfigChecksumAnnotation] =
+		fmt.Sprintf("%x", sha256.Sum256(configSecret.Data[secretKeyRef.Key]))
+
 	return r.DeploymentReconciler.Reconcile(ctx, desiredDeploy)
 }
 
@@ -216,7 +295,7 @@ func (r *MariaDBReconciler) reconcileExporterService(ctx context.Context, mariad
 	if err != nil {
 		return fmt.Errorf("error building exporter Service: %v", err)
 	}
-	return r.ServiceReconciler.Reconcile(ctx, desiredSvc)
+	return r.reconcileDesiredService(ctx, mariadb, desiredSvc)
 }
 
 func (r *MariaDBReconciler) reconcileServiceMonitor(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
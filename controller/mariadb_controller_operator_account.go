@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileOperatorAccount provisions the account used by the operator to reconcile SQL resources, using the root account.
+// The account is verified on every reconciliation by connecting with it, so password rotations and restored backups that
+// do not contain the account are detected and fixed.
+func (r *MariaDBReconciler) reconcileOperatorAccount(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mariadb.IsReady() || mariadb.IsRestoringBackup() {
+		return ctrl.Result{}, nil
+	}
+	if !mariadb.IsOperatorAccountEnabled() {
+		return ctrl.Result{}, r.reconcileOperatorAccountRemoved(ctx, mariadb)
+	}
+	account := mariadb.Spec.OperatorAccount
+
+	key := types.NamespacedName{
+		Name:      account.PasswordSecretKeyRef.Name,
+		Namespace: mariadb.Namespace,
+	}
+	password, err := r.SecretReconciler.ReconcileRandomPassword(ctx, key, account.PasswordSecretKeyRef.Key, mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling operator account password: %v", err)
+	}
+	if r.isOperatorAccountProvisioned(ctx, mariadb, password) {
+		return ctrl.Result{}, nil
+	}
+
+	rootClient, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer rootClient.Close()
+
+	logger := log.FromContext(ctx).WithName("operator-account")
+	logger.Info("Provisioning operator account", "username", account.Username)
+
+	accountName := operatorAccountName(account.Username)
+	if err := rootClient.CreateUser(ctx, accountName, sqlClient.CreateUserOpts{IdentifiedBy: password}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error creating operator account: %v", err)
+	}
+	if err := rootClient.AlterUser(ctx, account.Username, password); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating operator account password: %v", err)
+	}
+	if status := mariadb.Status.OperatorAccount; status != nil && status.Username == account.Username &&
+		len(status.Privileges) > 0 && !reflect.DeepEqual(status.Privileges, account.Privileges) {
+		logger.Info("Revoking operator account privileges", "privileges", status.Privileges)
+		if err := rootClient.Revoke(ctx, status.Privileges, "*", "*", accountName, sqlClient.WithGrantOption()); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error revoking operator account privileges: %v", err)
+		}
+	}
+	// the privileges are adapted to the server version when granting and revoking, for instance,
+	// REPLICA MONITOR and SLAVE MONITOR are granted as REPLICATION CLIENT by servers older than 10.5.9.
+	if err := rootClient.Grant(ctx, account.Privileges, "*", "*", accountName, sqlClient.WithGrantOption()); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error granting operator account privileges: %v", err)
+	}
+
+	return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.OperatorAccount = &mariadbv1alpha1.OperatorAccountStatus{
+			Username:   account.Username,
+			Privileges: account.Privileges,
+		}
+		return nil
+	})
+}
+
+// isOperatorAccountProvisioned determines whether the operator account matches the status and it is able to connect.
+func (r *MariaDBReconciler) isOperatorAccountProvisioned(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	password string) bool {
+	if !mariadb.IsOperatorAccountReady() ||
+		!reflect.DeepEqual(mariadb.Status.OperatorAccount.Privileges, mariadb.Spec.OperatorAccount.Privileges) {
+		return false
+	}
+	opts := append(
+		slices.Clip(r.SqlOpts),
+		sqlClient.WithUsername(mariadb.Spec.OperatorAccount.Username),
+		sqlClient.WithPassword(password),
+	)
+	client, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, opts...)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to connect with operator account", "err", err)
+		return false
+	}
+	defer client.Close()
+	return true
+}
+
+func (r *MariaDBReconciler) reconcileOperatorAccountRemoved(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if mariadb.Status.OperatorAccount == nil {
+		return nil
+	}
+	rootClient, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer rootClient.Close()
+
+	username := mariadb.Status.OperatorAccount.Username
+	log.FromContext(ctx).WithName("operator-account").Info("Dropping operator account", "username", username)
+	if err := rootClient.DropUser(ctx, operatorAccountName(username)); err != nil {
+		return fmt.Errorf("error dropping operator account: %v", err)
+	}
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.OperatorAccount = nil
+		return nil
+	})
+}
+
+func operatorAccountName(username string) string {
+	return fmt.Sprintf("'%s'@'%%'", username)
+}
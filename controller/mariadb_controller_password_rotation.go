@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const rootUser = "root"
+
+// reconcilePasswordRotation rotates the replication and root passwords once the rotation interval has elapsed since the last rotation.
+// The replication password is rotated first, as the root password is used to connect to the Pods. The Pods pick up the new root
+// password in the subsequent rollout triggered by the config checksum, and the Connections using it are refreshed afterwards.
+func (r *MariaDBReconciler) reconcilePasswordRotation(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	rotation := mariadb.Spec.PasswordRotation
+	if rotation == nil {
+		return ctrl.Result{}, nil
+	}
+	now := time.Now()
+	lastRotated := mariadb.Status.PasswordLastRotated
+	if lastRotated == nil {
+		return ctrl.Result{}, r.patchPasswordLastRotated(ctx, mariadb, now)
+	}
+	rootSecretKeyRef := mariadb.Spec.RootPasswordSecretKeyRef
+	if !rotation.IsDue(lastRotated.Time, now) {
+		password, err := r.RefResolver.SecretKeyRef(ctx, rootSecretKeyRef, mariadb.Namespace)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting root password: %v", err)
+		}
+		return ctrl.Result{}, refreshConnections(ctx, r.Client, mariadb.Namespace, rootSecretKeyRef, password, lastRotated.Time)
+	}
+	if !mariadb.IsReady() || mariadb.IsRestoringBackup() || mariadb.IsSwitchingPrimary() ||
+		(mariadb.Replication().Enabled && !mariadb.HasConfiguredReplication()) {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithName("password-rotation")
+
+	if mariadb.Replication().Enabled {
+		if err := r.ReplicationReconciler.RotatePassword(ctx, mariadb); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error rotating replication password: %v", err)
+		}
+		logger.Info("Rotated replication password")
+	}
+	password, err := r.rotateRootPassword(ctx, mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error rotating root password: %v", err)
+	}
+	logger.Info("Rotated root password")
+	r.Recorder.Event(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPasswordRotated, "Passwords rotated")
+
+	if err := r.patchPasswordLastRotated(ctx, mariadb, now); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, refreshConnections(ctx, r.Client, mariadb.Namespace, rootSecretKeyRef, password, now)
+}
+
+// rotateRootPassword sets a new random password to all the root accounts and to the root password Secret.
+// The new password is persisted under a pending key of the Secret before altering any account, so it is not lost if the
+// rotation is interrupted: the next attempt resumes with the pending password. The accounts get the current password back
+// if any of them cannot be altered.
+func (r *MariaDBReconciler) rotateRootPassword(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	secretKeyRef := mariadb.Spec.RootPasswordSecretKeyRef
+	key := types.NamespacedName{
+		Name:      secretKeyRef.Name,
+		Namespace: mariadb.Namespace,
+	}
+	var rootSecret corev1.Secret
+	if err := r.Get(ctx, key, &rootSecret); err != nil {
+		return "", fmt.Errorf("error getting root password Secret: %v", err)
+	}
+	password := string(rootSecret.Data[secretKeyRef.Key])
+	pendingKey := pendingPasswordSecretKey(secretKeyRef.Key)
+
+	newPassword, ok := rootSecret.Data[pendingKey]
+	if !ok {
+		generated, err := secret.GeneratePassword()
+		if err != nil {
+			return "", fmt.Errorf("error generating root password: %v", err)
+		}
+		if err := r.SecretReconciler.UpdatePassword(ctx, key, pendingKey, generated); err != nil {
+			return "", fmt.Errorf("error persisting pending root password: %v", err)
+		}
+		newPassword = []byte(generated)
+	}
+
+	// The accounts may already have the pending password if a previous attempt was interrupted.
+	client, err := r.newRootClient(ctx, mariadb, password, string(newPassword))
+	if err != nil {
+		return "", fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer client.Close()
+
+	hosts, err := client.UserHosts(ctx, rootUser)
+	if err != nil {
+		return "", fmt.Errorf("error getting root accounts: %v", err)
+	}
+	var altered []string
+	revert := func(err error) error {
+		for _, accountName := range altered {
+			if revertErr := client.SetPassword(ctx, accountName, password); revertErr != nil {
+				return fmt.Errorf("error reverting password of %s after failing to rotate it (%v): %v", accountName, err, revertErr)
+			}
+		}
+		if deleteErr := r.SecretReconciler.DeleteKey(ctx, key, pendingKey); deleteErr != nil {
+			return fmt.Errorf("error deleting pending root password after failing to rotate it (%v): %v", err, deleteErr)
+		}
+		return err
+	}
+	for _, host := range hosts {
+		accountName := fmt.Sprintf("'%s'@'%s'", rootUser, host)
+		if err := client.SetPassword(ctx, accountName, string(newPassword)); err != nil {
+			return "", revert(fmt.Errorf("error setting password of %s: %v", accountName, err))
+		}
+		altered = append(altered, accountName)
+	}
+
+	if err := r.SecretReconciler.UpdatePassword(ctx, key, secretKeyRef.Key, string(newPassword)); err != nil {
+		return "", fmt.Errorf("error updating root password Secret: %v", err)
+	}
+	if err := r.SecretReconciler.DeleteKey(ctx, key, pendingKey); err != nil {
+		return "", fmt.Errorf("error deleting pending root password: %v", err)
+	}
+	return string(newPassword), nil
+}
+
+// newRootClient connects to MariaDB as root, trying the given passwords in order.
+func (r *MariaDBReconciler) newRootClient(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	passwords ...string) (*sqlClient.Client, error) {
+	var errs []error
+	for _, password := range passwords {
+		opts := append(slices.Clone(r.SqlOpts), sqlClient.WithPassword(password))
+		client, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, opts...)
+		if err == nil {
+			return client, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func pendingPasswordSecretKey(secretKey string) string {
+	return fmt.Sprintf("%s-pending", secretKey)
+}
+
+func (r *MariaDBReconciler) patchPasswordLastRotated(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, lastRotated time.Time) error {
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.PasswordLastRotated = ptr.To(metav1.NewTime(lastRotated))
+		return nil
+	})
+}
+
+func passwordRotationResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if mariadb.Spec.PasswordRotation == nil || mariadb.Status.PasswordLastRotated == nil {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: remainingOrDefault(mariadb.Status.PasswordLastRotated, mariadb.Spec.PasswordRotation.Interval.Duration)}
+}
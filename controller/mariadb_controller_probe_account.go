@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	mdbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// probePasswordPropagationDelay is the time given to the kubelet to refresh the password Secret mounted in the Pods,
+	// before the new password of the probe account is provisioned.
+	probePasswordPropagationDelay = 2 * time.Minute
+	probeAccountRequeueInterval   = 5 * time.Second
+	probeAccountHost              = "localhost"
+)
+
+// reconcileProbeAccount provisions the account used by the probes in every Pod, using the root account. The account is created
+// locally, without being replicated, so it is provisioned in the Pods regardless of their readiness and replication state.
+// The probes switch to the account once it has been provisioned in all the Pods.
+func (r *MariaDBReconciler) reconcileProbeAccount(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mariadb.IsProbeAccountEnabled() {
+		if mariadb.Status.ProbeAccount == nil {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+			status.ProbeAccount = nil
+			return nil
+		})
+	}
+	if mariadb.IsRestoringBackup() {
+		return ctrl.Result{}, nil
+	}
+	account := mariadb.Spec.ProbeAccount
+	logger := log.FromContext(ctx).WithName("probe-account")
+
+	key := types.NamespacedName{
+		Name:      account.PasswordSecretKeyRef.Name,
+		Namespace: mariadb.Namespace,
+	}
+	if _, err := r.SecretReconciler.ReconcileRandomPassword(ctx, key, account.PasswordSecretKeyRef.Key, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling probe account password: %v", err)
+	}
+	if isProbePasswordRotationDue(mariadb) {
+		logger.Info("Rotating probe account password")
+		if _, err := r.SecretReconciler.RotateRandomPassword(ctx, key, account.PasswordSecretKeyRef.Key,
+			account.PreviousPasswordSecretKey()); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error rotating probe account password: %v", err)
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+			status.ProbeAccount.RotationStartTime = ptr.To(metav1.Now())
+			return nil
+		})
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting probe account password Secret: %v", err)
+	}
+	password := string(secret.Data[account.PasswordSecretKeyRef.Key])
+	if password == "" {
+		return ctrl.Result{}, errors.New("probe account password not found in Secret")
+	}
+
+	status := mariadb.Status.ProbeAccount
+	upToDate := mariadb.IsProbeAccountReady() && status.SecretResourceVersion == secret.ResourceVersion &&
+		status.RotationStartTime == nil
+	if mariadb.IsProbeAccountReady() && !upToDate {
+		if status.RotationStartTime == nil {
+			logger.Info("Probe account password changed, waiting for the Secret to be propagated to the Pods")
+			return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+				status.ProbeAccount.RotationStartTime = ptr.To(metav1.Now())
+				return nil
+			})
+		}
+		if time.Since(status.RotationStartTime.Time) < probePasswordPropagationDelay {
+			return ctrl.Result{}, nil
+		}
+	}
+
+	provisioned, err := r.provisionProbeAccount(ctx, mariadb, password)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !provisioned || upToDate {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Probe account provisioned", "username", account.Username)
+	return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.ProbeAccount = &mariadbv1alpha1.ProbeAccountStatus{
+			Username:              account.Username,
+			SecretResourceVersion: secret.ResourceVersion,
+			LastRotationTime:      ptr.To(metav1.Now()),
+		}
+		return nil
+	})
+}
+
+// provisionProbeAccount provisions the probe account in the Pods where the MariaDB container is running.
+// It returns whether the account has been provisioned in all the Pods.
+func (r *MariaDBReconciler) provisionProbeAccount(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	password string) (bool, error) {
+	var podList corev1.PodList
+	listOpts := &client.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(
+			labels.NewLabelsBuilder().
+				WithMariaDB(mariadb).
+				Build(),
+		),
+		Namespace: mariadb.Namespace,
+	}
+	if err := r.List(ctx, &podList, listOpts); err != nil {
+		return false, fmt.Errorf("error listing Pods: %v", err)
+	}
+
+	provisioned := len(podList.Items) >= int(mariadb.ScaledReplicas())
+	for _, pod := range podList.Items {
+		if mdbpod.ContainerStartedAt(&pod, builder.MariaDbContainerName) == nil {
+			provisioned = false
+			continue
+		}
+		podIndex, err := statefulset.PodIndex(pod.Name)
+		if err != nil {
+			return false, fmt.Errorf("error getting Pod '%s' index: %v", pod.Name, err)
+		}
+		if err := r.provisionProbeAccountInPod(ctx, mariadb, *podIndex, password); err != nil {
+			log.FromContext(ctx).V(1).Info("Unable to provision probe account", "pod", pod.Name, "err", err)
+			provisioned = false
+		}
+	}
+	return provisioned, nil
+}
+
+func (r *MariaDBReconciler) provisionProbeAccountInPod(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, podIndex int,
+	password string) error {
+	// The account is local to each Pod: it is neither written to the binary log nor replicated by Galera.
+	params := map[string]string{
+		"sql_log_bin": "0",
+	}
+	if mariadb.Galera().Enabled {
+		params["wsrep_on"] = "OFF"
+	}
+	client, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.RefResolver, podIndex,
+		append(slices.Clip(r.SqlOpts), sqlClient.WithParams(params))...)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer client.Close()
+
+	username := mariadb.Spec.ProbeAccount.Username
+	matches, err := client.AccountPasswordMatches(ctx, username, probeAccountHost, password)
+	if err != nil {
+		return fmt.Errorf("error checking probe account: %v", err)
+	}
+	if matches {
+		return nil
+	}
+
+	accountName := fmt.Sprintf("'%s'@'%s'", username, probeAccountHost)
+	if err := client.CreateUser(ctx, accountName, sqlClient.CreateUserOpts{IdentifiedBy: password}); err != nil {
+		return fmt.Errorf("error creating probe account: %v", err)
+	}
+	if err := client.AlterAccountPassword(ctx, accountName, password); err != nil {
+		return fmt.Errorf("error updating probe account password: %v", err)
+	}
+	return nil
+}
+
+func isProbePasswordRotationDue(mariadb *mariadbv1alpha1.MariaDB) bool {
+	if !mariadb.IsProbeAccountReady() || mariadb.Spec.ProbeAccount.RotationInterval == nil {
+		return false
+	}
+	status := mariadb.Status.ProbeAccount
+	return status.RotationStartTime == nil && status.LastRotationTime != nil &&
+		time.Since(status.LastRotationTime.Time) >= mariadb.Spec.ProbeAccount.RotationInterval.Duration
+}
+
+func probeAccountResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if !mariadb.IsProbeAccountEnabled() {
+		return ctrl.Result{}
+	}
+	if !mariadb.IsProbeAccountReady() {
+		return ctrl.Result{RequeueAfter: probeAccountRequeueInterval}
+	}
+	status := mariadb.Status.ProbeAccount
+	if status.RotationStartTime != nil {
+		return ctrl.Result{RequeueAfter: remainingOrDefault(status.RotationStartTime, probePasswordPropagationDelay)}
+	}
+	if interval := mariadb.Spec.ProbeAccount.RotationInterval; interval != nil && status.LastRotationTime != nil {
+		return ctrl.Result{RequeueAfter: remainingOrDefault(status.LastRotationTime, interval.Duration)}
+	}
+	return ctrl.Result{}
+}
+
+// remainingOrDefault returns the time remaining until the duration elapses since the given time,
+// defaulting to probeAccountRequeueInterval when it has already elapsed.
+func remainingOrDefault(since *metav1.Time, d time.Duration) time.Duration {
+	if remaining := d - time.Since(since.Time); remaining > 0 {
+		return remaining
+	}
+	return probeAccountRequeueInterval
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcilePVCMetadata propagates the labels and annotations of the volume claim templates to the existing PVCs.
+// The volume claim templates of a StatefulSet are immutable, so the PVCs only get the metadata defined at creation time.
+// Labels and annotations are added or updated, never removed.
+func (r *MariaDBReconciler) reconcilePVCMetadata(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	sts *appsv1.StatefulSet) error {
+	var pvcList corev1.PersistentVolumeClaimList
+	listOpts := []client.ListOption{
+		client.InNamespace(mariadb.Namespace),
+		client.MatchingLabels(labels.NewLabelsBuilder().WithMariaDB(mariadb).Build()),
+	}
+	if err := r.List(ctx, &pvcList, listOpts...); err != nil {
+		return fmt.Errorf("error listing PVCs: %v", err)
+	}
+
+	for _, pvc := range pvcList.Items {
+		for _, vctpl := range sts.Spec.VolumeClaimTemplates {
+			if !strings.HasPrefix(pvc.Name, fmt.Sprintf("%s-%s-", vctpl.Name, sts.Name)) {
+				continue
+			}
+			if err := r.patchPVCMetadata(ctx, &pvc, &vctpl); err != nil {
+				return fmt.Errorf("error patching PVC '%s': %v", pvc.Name, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) patchPVCMetadata(ctx context.Context, pvc *corev1.PersistentVolumeClaim,
+	vctpl *corev1.PersistentVolumeClaim) error {
+	if isSubset(vctpl.Labels, pvc.Labels) && isSubset(vctpl.Annotations, pvc.Annotations) {
+		return nil
+	}
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if pvc.Labels == nil {
+		pvc.Labels = map[string]string{}
+	}
+	for k, v := range vctpl.Labels {
+		pvc.Labels[k] = v
+	}
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	for k, v := range vctpl.Annotations {
+		pvc.Annotations[k] = v
+	}
+	return r.Patch(ctx, pvc, patch)
+}
+
+func isSubset(subset, set map[string]string) bool {
+	for k, v := range subset {
+		if got, ok := set[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MariaDB PVC metadata", func() {
+	It("Should propagate the inherited metadata to existing PVCs", func() {
+		mariadb := &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-pvc-metadata",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Image:    "mariadb:11.0.3",
+				Port:     3306,
+				Replicas: 1,
+				InheritMetadata: &mariadbv1alpha1.InheritMetadata{
+					Labels: map[string]string{
+						"database.myorg.io": "mariadb",
+					},
+					Annotations: map[string]string{
+						"database.myorg.io": "mariadb",
+					},
+				},
+			},
+		}
+		newPVC := func(name string) *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+					Labels: labels.NewLabelsBuilder().
+						WithMariaDB(mariadb).
+						WithLabels(map[string]string{
+							"pvc.myorg.io": "keep",
+						}).
+						Build(),
+				},
+			}
+		}
+		storagePVC := newPVC("storage-mariadb-pvc-metadata-0")
+		unknownPVC := newPVC("unknown-mariadb-pvc-metadata-0")
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(storagePVC, unknownPVC).
+			Build()
+		r := &MariaDBReconciler{
+			Client:  c,
+			Builder: builder.NewBuilder(scheme.Scheme, &environment.Environment{}),
+		}
+
+		sts, err := r.Builder.BuildStatefulSet(mariadb, client.ObjectKeyFromObject(mariadb))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.reconcilePVCMetadata(testCtx, mariadb, sts)).To(Succeed())
+
+		var pvc corev1.PersistentVolumeClaim
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(storagePVC), &pvc)).To(Succeed())
+		Expect(pvc.Labels).To(HaveKeyWithValue("database.myorg.io", "mariadb"))
+		Expect(pvc.Labels).To(HaveKeyWithValue("pvc.myorg.io", "keep"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue("database.myorg.io", "mariadb"))
+
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(unknownPVC), &pvc)).To(Succeed())
+		Expect(pvc.Labels).ToNot(HaveKey("database.myorg.io"))
+		Expect(pvc.Annotations).ToNot(HaveKey("database.myorg.io"))
+	})
+})
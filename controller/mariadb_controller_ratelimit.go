@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// reconcileActionRateLimit forgets the disruptive actions that are outside of the rate limit window,
+// lifting the ActionsRateLimited condition once the operator is allowed to perform them again.
+func (r *MariaDBReconciler) reconcileActionRateLimit(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if r.ActionLimiter == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, r.ActionLimiter.Reconcile(ctx, mariadb)
+}
+
+// actionRateLimitResult requeues the MariaDB when the ActionsRateLimited condition is to be lifted.
+func actionRateLimitResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if mariadb.Spec.ActionRateLimit == nil || !mariadb.IsActionsRateLimited() {
+		return ctrl.Result{}
+	}
+	retryAfter := mariadb.Spec.ActionRateLimit.RetryAfter(mariadb.Status.DisruptiveActions, time.Now())
+	if retryAfter <= 0 {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: retryAfter}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deferPodTemplateMetadata keeps the current Pod template metadata when it has changed outside of the maintenance window,
+// preventing the Pods from being restarted. It returns whether the changes have been deferred.
+func deferPodTemplateMetadata(mariadb *mariadbv1alpha1.MariaDB, desiredSts, existingSts *appsv1.StatefulSet) bool {
+	window := mariadb.Spec.MaintenanceWindow
+	if window == nil || window.IsActive(time.Now()) {
+		return false
+	}
+	desiredMeta := desiredSts.Spec.Template.ObjectMeta
+	existingMeta := existingSts.Spec.Template.ObjectMeta
+	if equality.Semantic.DeepEqual(desiredMeta.Labels, existingMeta.Labels) &&
+		equality.Semantic.DeepEqual(desiredMeta.Annotations, existingMeta.Annotations) {
+		return false
+	}
+	desiredSts.Spec.Template.ObjectMeta = existingMeta
+	return true
+}
+
+func (r *MariaDBReconciler) reconcileRestartPending(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	sts *appsv1.StatefulSet, deferred bool) error {
+	condition := restartPendingCondition(mariadb, sts, deferred)
+	current := meta.FindStatusCondition(mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypeRestartPending)
+	if condition == nil && current == nil {
+		return nil
+	}
+	if condition != nil && current != nil && current.Status == condition.Status &&
+		current.Reason == condition.Reason && current.Message == condition.Message {
+		return nil
+	}
+	if condition != nil {
+		log.FromContext(ctx).Info("Pod restart pending", "reason", condition.Reason)
+	}
+
+	return r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		if condition == nil {
+			meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeRestartPending)
+			return nil
+		}
+		s.SetCondition(*condition)
+		return nil
+	})
+}
+
+func restartPendingCondition(mariadb *mariadbv1alpha1.MariaDB, sts *appsv1.StatefulSet, deferred bool) *metav1.Condition {
+	if deferred {
+		msg := "Pod restart deferred until the next maintenance window"
+		if next, err := mariadb.Spec.MaintenanceWindow.NextStart(time.Now()); err == nil {
+			msg = fmt.Sprintf("Pod restart deferred until the next maintenance window at %s", next.UTC().Format(time.RFC3339))
+		}
+		return &metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeRestartPending,
+			Status:  metav1.ConditionTrue,
+			Reason:  mariadbv1alpha1.ConditionReasonMaintenanceWindow,
+			Message: msg,
+		}
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType &&
+		sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		return &metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeRestartPending,
+			Status:  metav1.ConditionTrue,
+			Reason:  mariadbv1alpha1.ConditionReasonOnDeleteStrategy,
+			Message: "Pods will be updated once they are deleted",
+		}
+	}
+	return nil
+}
+
+// restartPendingResult requeues the MariaDB at the start of the next maintenance window when there is a deferred restart.
+func restartPendingResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	condition := meta.FindStatusCondition(mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypeRestartPending)
+	if condition == nil || condition.Status != metav1.ConditionTrue ||
+		condition.Reason != mariadbv1alpha1.ConditionReasonMaintenanceWindow || mariadb.Spec.MaintenanceWindow == nil {
+		return ctrl.Result{}
+	}
+	now := time.Now()
+	next, err := mariadb.Spec.MaintenanceWindow.NextStart(now)
+	if err != nil {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}
+}
@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	"github.com/mariadb-operator/mariadb-operator/pkg/rightsizing"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileRightSizing samples the CPU and memory usage of the Pods from the metrics API, publishing resource requests
+// recommendations based on the peak usage of the current and the previous windows.
+func (r *MariaDBReconciler) reconcileRightSizing(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	rightSizing := mariadb.Spec.RightSizing
+	if rightSizing == nil {
+		if mariadb.Status.RightSizing == nil {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.RightSizing = nil
+			return nil
+		})
+	}
+	logger := log.FromContext(ctx).WithName("right-sizing")
+
+	if err := r.reconcileVerticalPodAutoscaler(ctx, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling VerticalPodAutoscaler: %v", err)
+	}
+
+	now := time.Now()
+	status := mariadb.Status.RightSizing
+	if status != nil && status.LastSampleTime != nil && now.Sub(status.LastSampleTime.Time) < rightSizing.IntervalOrDefault() {
+		return ctrl.Result{}, nil
+	}
+
+	usage, requests, err := r.containersUsage(ctx, mariadb)
+	if err != nil {
+		if rightsizing.IsMetricsUnavailable(err) {
+			logger.V(1).Info("Metrics not available", "err", err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("error getting containers usage: %v", err)
+	}
+	if len(usage) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	desiredStatus := rightSizingStatus(status, rightSizing, usage, requests, now)
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.RightSizing = desiredStatus
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching right-sizing status: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// containersUsage returns the peak usage across the ready Pods and the resource requests of each container.
+func (r *MariaDBReconciler) containersUsage(ctx context.Context,
+	mariadb *mariadbv1alpha1.MariaDB) (map[string]corev1.ResourceList, map[string]corev1.ResourceList, error) {
+	var podList corev1.PodList
+	listOpts := &client.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(
+			labels.NewLabelsBuilder().
+				WithMariaDB(mariadb).
+				Build(),
+		),
+		Namespace: mariadb.Namespace,
+	}
+	if err := r.List(ctx, &podList, listOpts); err != nil {
+		return nil, nil, fmt.Errorf("error listing Pods: %v", err)
+	}
+
+	usage := make(map[string]corev1.ResourceList)
+	requests := make(map[string]corev1.ResourceList)
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		podUsage, err := rightsizing.PodUsage(ctx, r.Client, client.ObjectKeyFromObject(&pod))
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		for _, container := range pod.Spec.Containers {
+			containerUsage, ok := podUsage[container.Name]
+			if !ok {
+				continue
+			}
+			usage[container.Name] = rightsizing.Peak(usage[container.Name], containerUsage)
+			requests[container.Name] = container.Resources.Requests
+		}
+	}
+	return usage, requests, nil
+}
+
+// rightSizingStatus adds a usage sample to the right-sizing status, starting a new window when the current one has elapsed.
+func rightSizingStatus(status *mariadbv1alpha1.RightSizingStatus, rightSizing *mariadbv1alpha1.RightSizing,
+	usage, requests map[string]corev1.ResourceList, now time.Time) *mariadbv1alpha1.RightSizingStatus {
+	desiredStatus := &mariadbv1alpha1.RightSizingStatus{
+		WindowStartTime: metav1.NewTime(now),
+	}
+	if status != nil {
+		desiredStatus = status.DeepCopy()
+		if now.Sub(status.WindowStartTime.Time) >= rightSizing.WindowOrDefault() {
+			desiredStatus.WindowStartTime = metav1.NewTime(now)
+			desiredStatus.Samples = 0
+			for i := range desiredStatus.Containers {
+				c := &desiredStatus.Containers[i]
+				c.PreviousPeakUsage = c.PeakUsage
+				c.PeakUsage = nil
+			}
+		}
+	}
+	desiredStatus.LastSampleTime = &metav1.Time{Time: now}
+	desiredStatus.Samples++
+
+	containers := make([]mariadbv1alpha1.ContainerRightSizing, 0, len(usage))
+	for name, containerUsage := range usage {
+		container := mariadbv1alpha1.ContainerRightSizing{
+			Name: name,
+		}
+		if c := desiredStatus.Container(name); c != nil {
+			container = *c
+		}
+		container.Requests = requests[name]
+		container.PeakUsage = rightsizing.Peak(container.PeakUsage, containerUsage)
+		container.Recommendation = rightsizing.Recommend(
+			rightsizing.Peak(container.PeakUsage, container.PreviousPeakUsage),
+			rightSizing.HeadroomPercentOrDefault(),
+		)
+		containers = append(containers, container)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].Name < containers[j].Name
+	})
+	desiredStatus.Containers = containers
+	return desiredStatus
+}
+
+// reconcileVerticalPodAutoscaler creates a VerticalPodAutoscaler in recommendation-only mode when enabled, or deletes it otherwise.
+func (r *MariaDBReconciler) reconcileVerticalPodAutoscaler(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	key := client.ObjectKeyFromObject(mariadb)
+	existingVpa := &unstructured.Unstructured{}
+	existingVpa.SetGroupVersionKind(builder.VerticalPodAutoscalerGVK)
+
+	if !mariadb.Spec.RightSizing.VerticalPodAutoscaler {
+		if err := r.Get(ctx, key, existingVpa); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				return nil
+			}
+			return fmt.Errorf("error getting VerticalPodAutoscaler: %v", err)
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, existingVpa))
+	}
+
+	exist, err := r.DiscoveryClient.VerticalPodAutoscalerExist()
+	if err != nil {
+		return err
+	}
+	if !exist {
+		r.Recorder.Event(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonCRDNotFound,
+			"Unable to reconcile VerticalPodAutoscaler: VerticalPodAutoscaler CRD not installed in the cluster")
+		log.FromContext(ctx).Error(errors.New("VerticalPodAutoscaler CRD not installed in the cluster"),
+			"Unable to reconcile VerticalPodAutoscaler")
+		return nil
+	}
+
+	desiredVpa, err := r.Builder.BuildVerticalPodAutoscaler(mariadb, key)
+	if err != nil {
+		return fmt.Errorf("error building VerticalPodAutoscaler: %v", err)
+	}
+	if err := r.Get(ctx, key, existingVpa); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting VerticalPodAutoscaler: %v", err)
+		}
+		if err := r.Create(ctx, desiredVpa); err != nil {
+			return fmt.Errorf("error creating VerticalPodAutoscaler: %v", err)
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(existingVpa.Object["spec"], desiredVpa.Object["spec"]) {
+		return nil
+	}
+	patch := client.MergeFrom(existingVpa.DeepCopy())
+	existingVpa.Object["spec"] = desiredVpa.Object["spec"]
+	return r.Patch(ctx, existingVpa, patch)
+}
+
+func rightSizingResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	rightSizing := mariadb.Spec.RightSizing
+	if rightSizing == nil {
+		return ctrl.Result{}
+	}
+	interval := rightSizing.IntervalOrDefault()
+	if status := mariadb.Status.RightSizing; status != nil && status.LastSampleTime != nil {
+		if remaining := interval - time.Since(status.LastSampleTime.Time); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}
+		}
+	}
+	return ctrl.Result{RequeueAfter: interval}
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/health"
+	mdbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const rollingRestartRequeueInterval = 5 * time.Second
+
+// reconcileRollingRestart restarts the Pods one at a time when 'spec.restartedAt' changes, starting with the replicas and
+// finishing with the primary, which is switched over to a replica before being restarted.
+// Before deleting each Pod, all the Pods must be ready and the replication or Galera cluster healthy.
+func (r *MariaDBReconciler) reconcileRollingRestart(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if mariadb.IsRollingRestartRequested() {
+		return r.startRollingRestart(ctx, mariadb)
+	}
+	if !mariadb.IsRollingRestartInProgress() {
+		return ctrl.Result{}, nil
+	}
+	status := mariadb.Status.RollingRestart
+	logger := log.FromContext(ctx).WithName("rolling-restart")
+
+	if status.CurrentPod != nil {
+		restarted, err := r.isPodRestarted(ctx, mariadb, *status.CurrentPod, ptr.Deref(status.CurrentPodUID, ""))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !restarted {
+			logger.V(1).Info("Waiting for Pod to be restarted", "pod", *status.CurrentPod)
+			return ctrl.Result{RequeueAfter: rollingRestartRequeueInterval}, nil
+		}
+		logger.Info("Pod restarted", "pod", *status.CurrentPod)
+		if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.RollingRestart.CurrentPod = nil
+			s.RollingRestart.CurrentPodUID = nil
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching rolling restart status: %v", err)
+		}
+	}
+
+	if issues := r.rollingRestartIssues(ctx, mariadb); len(issues) > 0 {
+		logger.V(1).Info("Waiting for MariaDB to be healthy", "issues", strings.Join(issues, ", "))
+		return ctrl.Result{RequeueAfter: rollingRestartRequeueInterval}, nil
+	}
+
+	if len(status.PendingPods) == 0 {
+		logger.Info("Rolling restart completed")
+		r.Recorder.Event(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonRollingRestartCompleted,
+			"Rolling restart completed")
+		if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.RollingRestart.CompletedAt = ptr.To(metav1.Now())
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching rolling restart status: %v", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	podName := status.PendingPods[0]
+	if isRollingRestartPrimary(mariadb, podName) {
+		return r.switchPrimaryBeforeRestart(ctx, mariadb, podName)
+	}
+	allowed, retryAfter, err := r.ActionLimiter.Allow(ctx, mariadb, mariadbv1alpha1.DisruptiveActionPodDeletion, podName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error checking action rate limit: %v", err)
+	}
+	if !allowed {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	uid, err := r.deleteRollingRestartPod(ctx, mariadb, podName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	logger.Info("Restarting Pod", "pod", podName)
+	r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonRollingRestartPod,
+		"Restarting Pod '%s'", podName)
+
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.RollingRestart.PendingPods = s.RollingRestart.PendingPods[1:]
+		if uid != "" {
+			s.RollingRestart.CurrentPod = ptr.To(podName)
+			s.RollingRestart.CurrentPodUID = ptr.To(uid)
+		}
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching rolling restart status: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: rollingRestartRequeueInterval}, nil
+}
+
+func (r *MariaDBReconciler) startRollingRestart(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	pods := rollingRestartPods(mariadb)
+	log.FromContext(ctx).WithName("rolling-restart").Info("Starting rolling restart", "pods", strings.Join(pods, ", "))
+	r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonRollingRestartStarted,
+		"Rolling restart started, Pods will be restarted in the following order: %s", strings.Join(pods, ", "))
+
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.RollingRestart = &mariadbv1alpha1.RollingRestartStatus{
+			RestartedAt: *mariadb.Spec.RestartedAt,
+			PendingPods: pods,
+		}
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching rolling restart status: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: rollingRestartRequeueInterval}, nil
+}
+
+// rollingRestartPods returns the Pods to be restarted, the replicas in ascending order followed by the current primary.
+func rollingRestartPods(mariadb *mariadbv1alpha1.MariaDB) []string {
+	primaryPodIndex := -1
+	if mariadb.Status.CurrentPrimaryPodIndex != nil {
+		primaryPodIndex = *mariadb.Status.CurrentPrimaryPodIndex
+	}
+	var pods []string
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		if i == primaryPodIndex {
+			continue
+		}
+		pods = append(pods, statefulset.PodName(mariadb.ObjectMeta, i))
+	}
+	if primaryPodIndex >= 0 && primaryPodIndex < int(mariadb.ScaledReplicas()) {
+		pods = append(pods, statefulset.PodName(mariadb.ObjectMeta, primaryPodIndex))
+	}
+	return pods
+}
+
+// rollingRestartIssues returns the reasons why the next Pod cannot be restarted yet.
+func (r *MariaDBReconciler) rollingRestartIssues(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) []string {
+	var sts appsv1.StatefulSet
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mariadb), &sts); err != nil {
+		return []string{fmt.Sprintf("Unable to get StatefulSet: %v", err)}
+	}
+	if sts.Status.ReadyReplicas != mariadb.ScaledReplicas() {
+		return []string{fmt.Sprintf("%d out of %d Pods are ready", sts.Status.ReadyReplicas, mariadb.ScaledReplicas())}
+	}
+	if mariadb.IsSwitchingPrimary() {
+		return []string{"Primary switchover in progress"}
+	}
+	if index := desiredPrimaryPodIndex(mariadb); index != nil && mariadb.Status.CurrentPrimaryPodIndex != nil &&
+		*index != *mariadb.Status.CurrentPrimaryPodIndex {
+		return []string{"Primary switchover pending"}
+	}
+	if mariadb.Replication().Enabled || mariadb.Galera().Enabled {
+		return r.upgradePreflightReplicationIssues(ctx, mariadb)
+	}
+	return nil
+}
+
+// isRollingRestartPrimary indicates whether the Pod is the current primary and it can be switched over to a replica.
+func isRollingRestartPrimary(mariadb *mariadbv1alpha1.MariaDB, podName string) bool {
+	if !mariadb.IsHAEnabled() || mariadb.ScaledReplicas() <= 1 || mariadb.Status.CurrentPrimaryPodIndex == nil {
+		return false
+	}
+	return podName == statefulset.PodName(mariadb.ObjectMeta, *mariadb.Status.CurrentPrimaryPodIndex)
+}
+
+// switchPrimaryBeforeRestart switches the primary to a healthy replica, so the primary Pod is restarted as a replica
+// without write downtime nor triggering a failover. The Pod is restarted once the switchover has completed.
+func (r *MariaDBReconciler) switchPrimaryBeforeRestart(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	podName string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("rolling-restart")
+	toIndex, err := health.HealthyReplica(ctx, r.Client, mariadb)
+	if err != nil {
+		logger.Info("Unable to switch primary before restarting. Retrying", "pod", podName, "err", err)
+		return ctrl.Result{RequeueAfter: rollingRestartRequeueInterval}, nil
+	}
+	logger.Info("Switching primary before restarting", "pod", podName, "to-index", *toIndex)
+	r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPrimarySwitching,
+		"Switching primary from '%s' to index '%d' before restarting it", podName, *toIndex)
+
+	if err := r.patchPrimaryPodIndex(ctx, mariadb, *toIndex); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error switching primary: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: rollingRestartRequeueInterval}, nil
+}
+
+// patchPrimaryPodIndex requests a primary switchover to the given Pod index.
+func (r *MariaDBReconciler) patchPrimaryPodIndex(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, index int) error {
+	return r.patch(ctx, mariadb, func(m *mariadbv1alpha1.MariaDB) {
+		if m.Spec.Replication != nil && m.Spec.Replication.Enabled {
+			if m.Spec.Replication.Primary == nil {
+				m.Spec.Replication.Primary = &mariadbv1alpha1.PrimaryReplication{}
+			}
+			m.Spec.Replication.Primary.PodIndex = ptr.To(index)
+		}
+		if m.Spec.Galera != nil && m.Spec.Galera.Enabled {
+			if m.Spec.Galera.Primary == nil {
+				m.Spec.Galera.Primary = &mariadbv1alpha1.PrimaryGalera{}
+			}
+			m.Spec.Galera.Primary.PodIndex = ptr.To(index)
+		}
+	})
+}
+
+// desiredPrimaryPodIndex returns the index of the primary Pod requested in the spec.
+func desiredPrimaryPodIndex(mariadb *mariadbv1alpha1.MariaDB) *int {
+	if mariadb.Replication().Enabled {
+		return mariadb.Replication().Primary.PodIndex
+	}
+	if mariadb.Galera().Enabled {
+		return mariadb.Galera().Primary.PodIndex
+	}
+	return nil
+}
+
+// isPodRestarted indicates whether the Pod has been recreated with a different UID and it is ready.
+func (r *MariaDBReconciler) isPodRestarted(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, podName, uid string) (bool, error) {
+	var pod corev1.Pod
+	key := types.NamespacedName{
+		Name:      podName,
+		Namespace: mariadb.Namespace,
+	}
+	if err := r.Get(ctx, key, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting Pod '%s': %v", podName, err)
+	}
+	return string(pod.UID) != uid && mdbpod.PodReady(&pod), nil
+}
+
+// deleteRollingRestartPod deletes the Pod so it gets recreated by the StatefulSet, returning its UID.
+// An empty UID is returned when the Pod does not exist.
+func (r *MariaDBReconciler) deleteRollingRestartPod(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, podName string) (string, error) {
+	var pod corev1.Pod
+	key := types.NamespacedName{
+		Name:      podName,
+		Namespace: mariadb.Namespace,
+	}
+	if err := r.Get(ctx, key, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error getting Pod '%s': %v", podName, err)
+	}
+	if err := r.Delete(ctx, &pod, client.Preconditions{UID: &pod.UID}); client.IgnoreNotFound(err) != nil {
+		return "", fmt.Errorf("error deleting Pod '%s': %v", podName, err)
+	}
+	return string(pod.UID), nil
+}
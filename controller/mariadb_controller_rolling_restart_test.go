@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MariaDB rolling restart", func() {
+	Context("When selecting the Pods to restart", func() {
+		mariadb := &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-repl",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Replication: &mariadbv1alpha1.Replication{
+					Enabled: true,
+				},
+				Replicas: 3,
+			},
+			Status: mariadbv1alpha1.MariaDBStatus{
+				CurrentPrimaryPodIndex: ptr.To(1),
+			},
+		}
+
+		It("Should restart the primary last", func() {
+			Expect(rollingRestartPods(mariadb)).To(Equal([]string{"mariadb-repl-0", "mariadb-repl-2", "mariadb-repl-1"}))
+		})
+
+		It("Should switch over the primary before restarting it", func() {
+			Expect(isRollingRestartPrimary(mariadb, "mariadb-repl-1")).To(BeTrue())
+			Expect(isRollingRestartPrimary(mariadb, "mariadb-repl-0")).To(BeFalse())
+		})
+
+		It("Should not switch over a single Pod", func() {
+			single := mariadb.DeepCopy()
+			single.Spec.Replicas = 1
+			single.Status.CurrentPrimaryPodIndex = ptr.To(0)
+			Expect(isRollingRestartPrimary(single, "mariadb-repl-0")).To(BeFalse())
+		})
+
+		It("Should not switch over a standalone MariaDB", func() {
+			standalone := mariadb.DeepCopy()
+			standalone.Spec.Replication = nil
+			Expect(isRollingRestartPrimary(standalone, "mariadb-repl-1")).To(BeFalse())
+		})
+	})
+
+	Context("When restarting a MariaDB with replication", func() {
+		It("Should restart all the Pods switching over the primary", func() {
+			key := types.NamespacedName{
+				Name:      "mariadb-repl-rolling-restart",
+				Namespace: testNamespace,
+			}
+			mdb := mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					VolumeClaimTemplate: mariadbv1alpha1.VolumeClaimTemplate{
+						PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"storage": resource.MustParse("100Mi"),
+								},
+							},
+							AccessModes: []corev1.PersistentVolumeAccessMode{
+								corev1.ReadWriteOnce,
+							},
+						},
+					},
+					Replication: &mariadbv1alpha1.Replication{
+						ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+							Primary: &mariadbv1alpha1.PrimaryReplication{
+								PodIndex: ptr.To(0),
+							},
+						},
+						Enabled: true,
+					},
+					Replicas: 3,
+				},
+			}
+			By("Creating MariaDB with replication")
+			Expect(k8sClient.Create(testCtx, &mdb)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &mdb)).To(Succeed())
+			})
+
+			By("Expecting MariaDB to be ready eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				return mdb.IsReady()
+			}, testVeryHighTimeout, testInterval).Should(BeTrue())
+
+			By("Getting Pod UIDs")
+			uids := make(map[string]types.UID)
+			for i := 0; i < int(mdb.Spec.Replicas); i++ {
+				var pod corev1.Pod
+				podKey := types.NamespacedName{
+					Name:      statefulset.PodName(mdb.ObjectMeta, i),
+					Namespace: key.Namespace,
+				}
+				Expect(k8sClient.Get(testCtx, podKey, &pod)).To(Succeed())
+				uids[pod.Name] = pod.UID
+			}
+
+			By("Requesting a rolling restart")
+			Expect(k8sClient.Get(testCtx, key, &mdb)).To(Succeed())
+			patch := client.MergeFrom(mdb.DeepCopy())
+			mdb.Spec.RestartedAt = ptr.To(metav1.NewTime(time.Now()))
+			Expect(k8sClient.Patch(testCtx, &mdb, patch)).To(Succeed())
+
+			By("Expecting rolling restart to complete eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				return mdb.Status.RollingRestart != nil && mdb.Status.RollingRestart.CompletedAt != nil
+			}, testVeryHighTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting primary to be switched over")
+			Expect(mdb.Status.CurrentPrimaryPodIndex).ToNot(BeNil())
+			Expect(*mdb.Status.CurrentPrimaryPodIndex).ToNot(Equal(0))
+
+			By("Expecting all Pods to be restarted")
+			for name, uid := range uids {
+				var pod corev1.Pod
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: name, Namespace: key.Namespace}, &pod)).To(Succeed())
+				Expect(pod.UID).ToNot(Equal(uid))
+			}
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var scheduledScalingRequeueInterval = 30 * time.Second
+
+// reconcileScheduledScaling scales the replicas towards the replicas of the active scaling window, or back to 'spec.replicas'
+// when no windows are active. The replicas are recorded in the status, which takes precedence over 'spec.replicas', so the spec
+// is never modified. Replicas are added and removed one at a time, only when the MariaDB is ready, and the Pod of the primary
+// is never removed: the scale down waits for the primary to be switched to a lower index.
+func (r *MariaDBReconciler) reconcileScheduledScaling(ctx context.Context,
+	mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	status := mariadb.Status.ScheduledScaling
+	if (len(mariadb.Spec.ScheduledScaling) == 0 && status == nil) || mariadb.IsHibernated() {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithName("scheduled-scaling")
+
+	baseReplicas := mariadb.Spec.Replicas
+	currentReplicas := mariadb.ScaledReplicas()
+	targetReplicas, window := mariadb.ScheduledReplicas(baseReplicas, time.Now())
+
+	if len(mariadb.Spec.ScheduledScaling) == 0 && currentReplicas == baseReplicas {
+		logger.Info("Scheduled scaling removed")
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.ScheduledScaling = nil
+			return nil
+		})
+	}
+
+	replicas := currentReplicas
+	if replicas != targetReplicas && isScalingSafe(mariadb) {
+		if targetReplicas > replicas {
+			replicas++
+		} else {
+			replicas--
+		}
+	}
+	if replicas < currentReplicas && isPrimaryRemoved(mariadb, replicas) {
+		logger.Info("Waiting for the primary to be switched to a lower index before scaling down", "replicas", currentReplicas,
+			"target", targetReplicas)
+		replicas = currentReplicas
+	}
+
+	var activeWindow *string
+	if window != nil {
+		activeWindow = ptr.To(window.Name)
+	}
+	desiredStatus := &mariadbv1alpha1.ScheduledScalingStatus{
+		BaseReplicas:   baseReplicas,
+		Replicas:       replicas,
+		TargetReplicas: targetReplicas,
+		ActiveWindow:   activeWindow,
+	}
+	if reflect.DeepEqual(status, desiredStatus) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.ScheduledScaling = desiredStatus
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching scheduled scaling status: %v", err)
+	}
+	if replicas != currentReplicas {
+		logger.Info("Scaling replicas", "from", currentReplicas, "to", replicas, "target", targetReplicas)
+		r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonMariaDBScaled,
+			"Scaled replicas from %d to %d, target %d", currentReplicas, replicas, targetReplicas)
+	}
+	return ctrl.Result{}, nil
+}
+
+// isPrimaryRemoved determines whether either the current or the desired primary would be removed by scaling to the given replicas.
+func isPrimaryRemoved(mariadb *mariadbv1alpha1.MariaDB, replicas int32) bool {
+	if index := mariadb.Status.CurrentPrimaryPodIndex; index != nil && *index >= int(replicas) {
+		return true
+	}
+	if r := mariadb.Spec.Replication; r != nil && r.Enabled && r.Primary != nil && r.Primary.PodIndex != nil &&
+		*r.Primary.PodIndex >= int(replicas) {
+		return true
+	}
+	if g := mariadb.Spec.Galera; g != nil && g.Enabled && g.Primary != nil && g.Primary.PodIndex != nil &&
+		*g.Primary.PodIndex >= int(replicas) {
+		return true
+	}
+	return false
+}
+
+// isScalingSafe determines whether the MariaDB can be scaled, waiting for the previous scaling steps and
+// other operations, such as switchovers or recoveries, to complete.
+func isScalingSafe(mariadb *mariadbv1alpha1.MariaDB) bool {
+	return mariadb.IsReady() && mariadb.Status.Replicas == mariadb.ScaledReplicas() &&
+		!mariadb.IsSwitchingPrimary() && !mariadb.IsConfiguringReplication() &&
+		!mariadb.HasGaleraNotReadyCondition() && !mariadb.IsRestoringBackup()
+}
+
+// scheduledScalingResult requeues the MariaDB when the scaling is in progress or when the next scaling window transition happens.
+func scheduledScalingResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if status := mariadb.Status.ScheduledScaling; status != nil && status.TargetReplicas != mariadb.ScaledReplicas() {
+		return ctrl.Result{RequeueAfter: scheduledScalingRequeueInterval}
+	}
+	now := time.Now()
+	next, ok := mariadb.NextScheduledScalingTransition(now)
+	if !ok {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}
+}
+
+// minResult returns the result that requeues first, ignoring the ones that do not requeue.
+func minResult(results ...ctrl.Result) ctrl.Result {
+	var shortest ctrl.Result
+	for _, result := range results {
+		if result.IsZero() {
+			continue
+		}
+		if shortest.IsZero() || result.RequeueAfter < shortest.RequeueAfter {
+			shortest = result
+		}
+	}
+	return shortest
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MariaDB scheduled scaling", func() {
+	newMariaDB := func(window mariadbv1alpha1.ScheduledScaling, primaryIndex int,
+		status *mariadbv1alpha1.ScheduledScalingStatus) *mariadbv1alpha1.MariaDB {
+		mariadb := &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-scaling",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Replication: &mariadbv1alpha1.Replication{
+					Enabled: true,
+					ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+						Primary: &mariadbv1alpha1.PrimaryReplication{
+							PodIndex: ptr.To(primaryIndex),
+						},
+					},
+				},
+				Replicas:         2,
+				ScheduledScaling: []mariadbv1alpha1.ScheduledScaling{window},
+			},
+			Status: mariadbv1alpha1.MariaDBStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               mariadbv1alpha1.ConditionTypeReady,
+						Status:             metav1.ConditionTrue,
+						Reason:             mariadbv1alpha1.ConditionReasonStatefulSetReady,
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+				CurrentPrimaryPodIndex: ptr.To(primaryIndex),
+				ScheduledScaling:       status,
+			},
+		}
+		mariadb.Status.Replicas = mariadb.ScaledReplicas()
+		return mariadb
+	}
+	reconcile := func(mariadb *mariadbv1alpha1.MariaDB) *mariadbv1alpha1.MariaDB {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(mariadb).
+			WithStatusSubresource(mariadb).
+			Build()
+		r := &MariaDBReconciler{
+			Client:   c,
+			Recorder: record.NewFakeRecorder(10),
+		}
+		_, err := r.reconcileScheduledScaling(testCtx, mariadb)
+		Expect(err).ToNot(HaveOccurred())
+
+		var scaled mariadbv1alpha1.MariaDB
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(mariadb), &scaled)).To(Succeed())
+		return &scaled
+	}
+	activeWindow := mariadbv1alpha1.ScheduledScaling{
+		Name:     "always",
+		Cron:     "* * * * *",
+		Duration: metav1.Duration{Duration: time.Hour},
+		Replicas: 4,
+	}
+	inactiveWindow := mariadbv1alpha1.ScheduledScaling{
+		Name:     "never",
+		Cron:     "0 0 30 2 *",
+		Duration: metav1.Duration{Duration: time.Hour},
+		Replicas: 4,
+	}
+
+	It("Should scale up via status without changing the spec", func() {
+		scaled := reconcile(newMariaDB(activeWindow, 0, nil))
+
+		Expect(scaled.Spec.Replicas).To(Equal(int32(2)))
+		Expect(scaled.Status.ScheduledScaling).ToNot(BeNil())
+		Expect(scaled.Status.ScheduledScaling.BaseReplicas).To(Equal(int32(2)))
+		Expect(scaled.Status.ScheduledScaling.Replicas).To(Equal(int32(3)))
+		Expect(scaled.Status.ScheduledScaling.TargetReplicas).To(Equal(int32(4)))
+		Expect(scaled.ScaledReplicas()).To(Equal(int32(3)))
+	})
+
+	It("Should scale down to the replicas in the spec", func() {
+		scaled := reconcile(newMariaDB(inactiveWindow, 0, &mariadbv1alpha1.ScheduledScalingStatus{
+			BaseReplicas:   2,
+			Replicas:       4,
+			TargetReplicas: 4,
+		}))
+
+		Expect(scaled.Spec.Replicas).To(Equal(int32(2)))
+		Expect(scaled.Status.ScheduledScaling.Replicas).To(Equal(int32(3)))
+		Expect(scaled.Status.ScheduledScaling.TargetReplicas).To(Equal(int32(2)))
+	})
+
+	It("Should not remove the Pod of the primary nor switch it over", func() {
+		scaled := reconcile(newMariaDB(inactiveWindow, 3, &mariadbv1alpha1.ScheduledScalingStatus{
+			BaseReplicas:   2,
+			Replicas:       4,
+			TargetReplicas: 4,
+		}))
+
+		Expect(scaled.Status.ScheduledScaling.Replicas).To(Equal(int32(4)))
+		Expect(scaled.Status.ScheduledScaling.TargetReplicas).To(Equal(int32(2)))
+		Expect(*scaled.Spec.Replication.Primary.PodIndex).To(Equal(3))
+	})
+})
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/endpoints"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileSecondaryServices reconciles the additional secondary Services and their Endpoints,
+// deleting the ones that are no longer defined in the MariaDB.
+func (r *MariaDBReconciler) reconcileSecondaryServices(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	for _, svc := range mariadb.Spec.SecondaryServices {
+		if err := r.reconcileNamedSecondaryService(ctx, mariadb, svc); err != nil {
+			return fmt.Errorf("error reconciling secondary Service '%s': %v", svc.Name, err)
+		}
+	}
+	return r.cleanupSecondaryServices(ctx, mariadb)
+}
+
+func (r *MariaDBReconciler) reconcileNamedSecondaryService(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	secondarySvc mariadbv1alpha1.SecondaryService) error {
+	key := mariadb.NamedSecondaryServiceKey(secondarySvc.Name)
+	opts := builder.ServiceOpts{
+		ServiceTemplate:       secondarySvc.ServiceTemplate,
+		ExcludeSelectorLabels: true,
+		Ports: []corev1.ServicePort{
+			{
+				Name: builder.MariaDbPortName,
+				Port: mariadb.Spec.Port,
+			},
+		},
+	}
+	opts.Labels = labels.NewLabelsBuilder().
+		WithLabels(secondarySvc.Labels).
+		WithSecondaryService(secondarySvc.Name).
+		Build()
+
+	desiredSvc, err := r.Builder.BuildService(mariadb, key, opts)
+	if err != nil {
+		return fmt.Errorf("error building Service: %v", err)
+	}
+	if err := r.reconcileDesiredService(ctx, mariadb, desiredSvc); err != nil {
+		return err
+	}
+
+	var endpointsOpts []endpoints.EndpointsOpt
+	if secondarySvc.PodIndexes != nil {
+		endpointsOpts = append(endpointsOpts, endpoints.WithPodIndexes(secondarySvc.PodIndexes))
+	}
+	if secondarySvc.PodSelector != nil {
+		endpointsOpts = append(endpointsOpts, endpoints.WithPodSelector(secondarySvc.PodSelector))
+	}
+	if mariadb.Spec.WarmUp != nil && mariadb.Status.WarmUpEnabled {
+		endpointsOpts = append(endpointsOpts, endpoints.WithWarmUp())
+	}
+	if maxLag := mariadb.SecondaryMaxLag(); maxLag != nil {
+		endpointsOpts = append(endpointsOpts, endpoints.WithMaxLag(*maxLag))
+	}
+	if err := r.EndpointsReconciler.Reconcile(ctx, key, mariadb, endpointsOpts...); err != nil {
+		if errors.Is(err, endpoints.ErrNoAddressesAvailable) {
+			log.FromContext(ctx).V(1).Info("No addresses available for secondary Endpoints", "service", key.Name)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) cleanupSecondaryServices(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	requirement, err := klabels.NewRequirement(labels.SecondaryServiceLabel, selection.Exists, nil)
+	if err != nil {
+		return fmt.Errorf("error building label requirement: %v", err)
+	}
+	var svcList corev1.ServiceList
+	listOpts := &client.ListOptions{
+		LabelSelector: klabels.NewSelector().Add(*requirement),
+		Namespace:     mariadb.Namespace,
+	}
+	if err := r.List(ctx, &svcList, listOpts); err != nil {
+		return fmt.Errorf("error listing Services: %v", err)
+	}
+
+	desired := make(map[string]struct{}, len(mariadb.Spec.SecondaryServices))
+	for _, svc := range mariadb.Spec.SecondaryServices {
+		desired[mariadb.NamedSecondaryServiceKey(svc.Name).Name] = struct{}{}
+	}
+	for _, svc := range svcList.Items {
+		if !metav1.IsControlledBy(&svc, mariadb) {
+			continue
+		}
+		if _, ok := desired[svc.Name]; ok {
+			continue
+		}
+		log.FromContext(ctx).Info("Deleting secondary Service", "service", svc.Name)
+		if err := r.Delete(ctx, &svc); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("error deleting Service '%s': %v", svc.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MariaDB secondary Services", func() {
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-secondary-services",
+			Namespace: testNamespace,
+			UID:       types.UID("mariadb-secondary-services"),
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			SecondaryServices: []mariadbv1alpha1.SecondaryService{
+				{
+					Name: "reporting",
+				},
+			},
+		},
+	}
+	newService := func(name string, controlled bool) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      mariadb.NamedSecondaryServiceKey(name).Name,
+				Namespace: testNamespace,
+				Labels: labels.NewLabelsBuilder().
+					WithSecondaryService(name).
+					Build(),
+			},
+		}
+		if controlled {
+			svc.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: mariadbv1alpha1.GroupVersion.String(),
+					Kind:       "MariaDB",
+					Name:       mariadb.Name,
+					UID:        mariadb.UID,
+					Controller: ptr.To(true),
+				},
+			}
+		}
+		return svc
+	}
+
+	It("Should delete the Services that are no longer defined", func() {
+		reporting := newService("reporting", true)
+		analytics := newService("analytics", true)
+		external := newService("external", false)
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(reporting, analytics, external).
+			Build()
+		r := &MariaDBReconciler{
+			Client: c,
+		}
+
+		Expect(r.cleanupSecondaryServices(testCtx, mariadb)).To(Succeed())
+
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(reporting), &corev1.Service{})).To(Succeed())
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(external), &corev1.Service{})).To(Succeed())
+		err := c.Get(testCtx, client.ObjectKeyFromObject(analytics), &corev1.Service{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should name the Services after the MariaDB", func() {
+		Expect(mariadb.NamedSecondaryServiceKey("reporting")).To(Equal(types.NamespacedName{
+			Name:      "mariadb-secondary-services-reporting",
+			Namespace: testNamespace,
+		}))
+	})
+})
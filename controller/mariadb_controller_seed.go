@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const seedDataRequeueInterval = 10 * time.Second
+
+// reconcileSeedData loads the seed data into MariaDB by running a Job, only once during the first bootstrap.
+// When bootstrapping from a backup, the seed data is loaded after the backup has been restored. A failed Job is retried.
+func (r *MariaDBReconciler) reconcileSeedData(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if mariadb.Spec.SeedData == nil || mariadb.HasSeededData() {
+		return ctrl.Result{}, nil
+	}
+	if !mariadb.IsReady() || mariadb.IsRestoringBackup() {
+		return ctrl.Result{}, nil
+	}
+	if mariadb.Spec.BootstrapFrom != nil && !mariadb.HasRestoredBackup() {
+		return ctrl.Result{}, nil
+	}
+
+	var existingJob batchv1.Job
+	if err := r.Get(ctx, mariadb.SeedDataJobKey(), &existingJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting seed data Job: %v", err)
+		}
+		job, err := r.Builder.BuildSeedDataJob(mariadb.SeedDataJobKey(), mariadb)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error building seed data Job: %v", err)
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating seed data Job: %v", err)
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+			condition.SetSeedingData(status)
+			return nil
+		})
+	}
+
+	// The event is only recorded when the failure is first observed.
+	recordFailure := isJobFailed(&existingJob) && !isSeedDataFailed(mariadb)
+	if err := r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		condition.SetSeedDataWithJob(status, &existingJob)
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching status: %v", err)
+	}
+	if recordFailure {
+		r.Recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonSeedDataFailed,
+			"Seed data Job '%s' failed", existingJob.Name)
+	}
+	if isJobFailed(&existingJob) {
+		// The failed Job is deleted, so it is created again in the next reconciliation.
+		err := r.Delete(ctx, &existingJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err := client.IgnoreNotFound(err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error deleting seed data Job: %v", err)
+		}
+		return ctrl.Result{RequeueAfter: seedDataRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func isSeedDataFailed(mariadb *mariadbv1alpha1.MariaDB) bool {
+	c := meta.FindStatusCondition(mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypeDataSeeded)
+	return c != nil && c.Reason == mariadbv1alpha1.ConditionReasonFailed
+}
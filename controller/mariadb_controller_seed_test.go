@@ -0,0 +1,95 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MariaDB seed data", func() {
+	newMariaDB := func() *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-seed",
+				Namespace: testNamespace,
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				SeedData: &mariadbv1alpha1.SeedData{
+					S3: mariadbv1alpha1.S3{
+						Bucket:   "seed",
+						Endpoint: "minio:9000",
+					},
+					Files: []mariadbv1alpha1.SeedFile{
+						{
+							Name:   "schema.sql",
+							SHA256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+						},
+					},
+				},
+			},
+			Status: mariadbv1alpha1.MariaDBStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   mariadbv1alpha1.ConditionTypeReady,
+						Status: metav1.ConditionTrue,
+						Reason: mariadbv1alpha1.ConditionReasonStatefulSetReady,
+					},
+				},
+			},
+		}
+	}
+	newFailedJob := func(mariadb *mariadbv1alpha1.MariaDB) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      mariadb.SeedDataJobKey().Name,
+				Namespace: mariadb.SeedDataJobKey().Namespace,
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:   batchv1.JobFailed,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	It("Should delete the failed Job and requeue", func() {
+		mariadb := newMariaDB()
+		job := newFailedJob(mariadb)
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(mariadb, job).
+			WithStatusSubresource(&mariadbv1alpha1.MariaDB{}).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &MariaDBReconciler{
+			Client:   c,
+			Recorder: recorder,
+		}
+
+		result, err := r.reconcileSeedData(testCtx, mariadb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(seedDataRequeueInterval))
+
+		err = c.Get(testCtx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		var got mariadbv1alpha1.MariaDB
+		Expect(c.Get(testCtx, client.ObjectKeyFromObject(mariadb), &got)).To(Succeed())
+		seeded := meta.FindStatusCondition(got.Status.Conditions, mariadbv1alpha1.ConditionTypeDataSeeded)
+		Expect(seeded).ToNot(BeNil())
+		Expect(seeded.Reason).To(Equal(mariadbv1alpha1.ConditionReasonFailed))
+		Expect(recorder.Events).To(Receive(ContainSubstring(mariadbv1alpha1.ReasonSeedDataFailed)))
+	})
+})
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClientSet "github.com/mariadb-operator/mariadb-operator/pkg/sqlset"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileSessionPolicy sets the session policy system variables in all the MariaDB servers. As the variables are not persisted,
+// they are periodically reconciled to cover the Pods that have been restarted. The variables removed from the session policy
+// are reset to their default value.
+func (r *MariaDBReconciler) reconcileSessionPolicy(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mariadb.IsReady() || mariadb.IsRestoringBackup() {
+		return ctrl.Result{}, nil
+	}
+	vars := make(map[string]string)
+	if mariadb.Spec.SessionPolicy != nil {
+		vars = mariadb.Spec.SessionPolicy.SystemVariables()
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resetNames := sessionPolicyResetVariables(mariadb.Status.SessionPolicyVariables, vars)
+	if len(names) == 0 && len(resetNames) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	clientSet := sqlClientSet.NewClientSet(mariadb, r.RefResolver, r.SqlOpts...)
+	defer clientSet.Close()
+
+	logger := log.FromContext(ctx).WithName("session-policy")
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		podName := statefulset.PodName(mariadb.ObjectMeta, i)
+		client, err := clientSet.ClientForIndex(ctx, i)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting client for Pod '%s': %v", podName, err)
+		}
+		for _, name := range names {
+			current, err := client.SystemVariable(ctx, name)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("error getting variable '%s' in Pod '%s': %v", name, podName, err)
+			}
+			if equalSeconds(current, vars[name]) {
+				continue
+			}
+			logger.Info("Setting session policy variable", "pod", podName, "variable", name, "value", vars[name])
+			if err := client.SetSystemVariable(ctx, name, vars[name]); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error setting variable '%s' in Pod '%s': %v", name, podName, err)
+			}
+		}
+		for _, name := range resetNames {
+			logger.Info("Resetting session policy variable", "pod", podName, "variable", name)
+			if err := client.SetSystemVariable(ctx, name, "DEFAULT"); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error resetting variable '%s' in Pod '%s': %v", name, podName, err)
+			}
+		}
+	}
+
+	if !slices.Equal(names, mariadb.Status.SessionPolicyVariables) {
+		if err := r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+			status.SessionPolicyVariables = names
+			return nil
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching session policy variables: %v", err)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// sessionPolicyResetVariables returns the variables previously set by the operator that are no longer part of the session policy.
+func sessionPolicyResetVariables(applied []string, vars map[string]string) []string {
+	var names []string
+	for _, name := range applied {
+		if _, ok := vars[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// equalSeconds compares two durations in seconds, as the server may report them with a different format, i.e. '1.500000'.
+func equalSeconds(a, b string) bool {
+	fa, err := strconv.ParseFloat(a, 64)
+	if err != nil {
+		return false
+	}
+	fb, err := strconv.ParseFloat(b, 64)
+	if err != nil {
+		return false
+	}
+	return fa == fb
+}
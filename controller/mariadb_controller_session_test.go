@@ -0,0 +1,46 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MariaDB session policy", func() {
+	DescribeTable(
+		"Should reset the variables removed from the session policy",
+		func(applied []string, vars map[string]string, wantReset []string) {
+			Expect(sessionPolicyResetVariables(applied, vars)).To(Equal(wantReset))
+		},
+		Entry(
+			"No variables applied",
+			nil,
+			map[string]string{
+				"wait_timeout": "600",
+			},
+			nil,
+		),
+		Entry(
+			"Same variables",
+			[]string{"max_statement_time", "wait_timeout"},
+			map[string]string{
+				"max_statement_time": "1.5",
+				"wait_timeout":       "600",
+			},
+			nil,
+		),
+		Entry(
+			"Variable removed",
+			[]string{"max_statement_time", "wait_timeout"},
+			map[string]string{
+				"wait_timeout": "600",
+			},
+			[]string{"max_statement_time"},
+		),
+		Entry(
+			"Session policy removed",
+			[]string{"interactive_timeout", "wait_timeout"},
+			map[string]string{},
+			[]string{"interactive_timeout", "wait_timeout"},
+		),
+	)
+})
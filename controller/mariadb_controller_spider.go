@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/spider"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	spiderNodeField = ".spec.spider.nodes"
+	// spiderMaxUserConnections leaves room for the connections opened by Spider to the node, which may be one per table and session.
+	spiderMaxUserConnections  = 100
+	spiderNodeNotReadyRequeue = 10 * time.Second
+)
+
+var spiderPrivileges = []string{
+	"SELECT",
+	"INSERT",
+	"UPDATE",
+	"DELETE",
+	"CREATE TEMPORARY TABLES",
+	"LOCK TABLES",
+}
+
+// reconcileSpider installs the Spider storage engine and keeps its server list in sync with the nodes,
+// provisioning a Spider user in each of them. Nodes that are not ready yet are retried later, without dropping their servers.
+func (r *MariaDBReconciler) reconcileSpider(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mariadb.IsReady() || mariadb.IsRestoringBackup() {
+		return ctrl.Result{}, nil
+	}
+	if !mariadb.IsSpiderEnabled() {
+		return ctrl.Result{}, r.reconcileSpiderRemoved(ctx, mariadb)
+	}
+	spiderSpec := mariadb.Spec.Spider
+	logger := log.FromContext(ctx).WithName("spider")
+
+	key := types.NamespacedName{
+		Name:      spiderSpec.PasswordSecretKeyRef.Name,
+		Namespace: mariadb.Namespace,
+	}
+	password, err := r.SecretReconciler.ReconcileRandomPassword(ctx, key, spiderSpec.PasswordSecretKeyRef.Key, mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling Spider password: %v", err)
+	}
+
+	desired := make(map[string]sqlClient.ForeignServerOpts)
+	nodeServers := make(map[string]struct{})
+	for _, node := range spiderSpec.Nodes {
+		nodeServers[node.Server()] = struct{}{}
+
+		nodeMariadb, err := r.RefResolver.MariaDB(ctx, &node.MariaDBRef, mariadb.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Info("Spider node not found", "server", node.Server())
+				continue
+			}
+			return ctrl.Result{}, fmt.Errorf("error getting Spider node '%s': %v", node.Server(), err)
+		}
+		ready, err := r.reconcileSpiderNodeUser(ctx, mariadb, nodeMariadb, &node)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error reconciling Spider user in node '%s': %v", node.Server(), err)
+		}
+		if !ready {
+			logger.V(1).Info("Spider node not ready", "server", node.Server())
+			continue
+		}
+		desired[node.Server()] = sqlClient.ForeignServerOpts{
+			Host:     sqlClient.PrimaryHost(nodeMariadb),
+			Port:     nodeMariadb.Spec.Port,
+			Database: ptr.Deref(node.Database, ""),
+			Username: spiderSpec.Username,
+			Password: password,
+		}
+	}
+
+	var removed []string
+	for _, server := range mariadb.Status.SpiderServers {
+		if _, ok := nodeServers[server]; !ok {
+			removed = append(removed, server)
+		}
+	}
+
+	rootClient, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer rootClient.Close()
+
+	plugins, err := rootClient.ActivePlugins(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting active plugins: %v", err)
+	}
+	if !slices.Contains(plugins, spider.PluginName) {
+		logger.Info("Installing Spider storage engine")
+		if err := rootClient.InstallSoname(ctx, spider.Soname); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error installing Spider storage engine: %v", err)
+		}
+	}
+
+	current, err := rootClient.ForeignServers(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting Spider servers: %v", err)
+	}
+	upsert, drop := spider.Diff(current, desired, removed)
+	for _, server := range upsert {
+		logger.Info("Syncing Spider server", "server", server)
+		if err := rootClient.CreateOrReplaceForeignServer(ctx, server, desired[server]); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error syncing Spider server '%s': %v", server, err)
+		}
+	}
+	for _, server := range drop {
+		logger.Info("Dropping Spider server", "server", server)
+		if err := rootClient.DropForeignServer(ctx, server); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error dropping Spider server '%s': %v", server, err)
+		}
+	}
+	if err := r.deleteSpiderNodeUsers(ctx, mariadb, removed); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var servers []string
+	for server := range nodeServers {
+		_, isDesired := desired[server]
+		_, isCurrent := current[server]
+		if isDesired || isCurrent {
+			servers = append(servers, server)
+		}
+	}
+	sort.Strings(servers)
+	if err := r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.SpiderServers = servers
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching Spider servers: %v", err)
+	}
+
+	if len(desired) < len(spiderSpec.Nodes) {
+		return ctrl.Result{RequeueAfter: spiderNodeNotReadyRequeue}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileSpiderNodeUser creates the User and the Grant used by Spider in a node. It returns whether they are ready to be used.
+func (r *MariaDBReconciler) reconcileSpiderNodeUser(ctx context.Context, mariadb, nodeMariadb *mariadbv1alpha1.MariaDB,
+	node *mariadbv1alpha1.SpiderNode) (bool, error) {
+	spiderSpec := mariadb.Spec.Spider
+	key := mariadb.SpiderNodeKey(node.Server())
+
+	var user mariadbv1alpha1.User
+	if err := r.Get(ctx, key, &user); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("error getting User: %v", err)
+		}
+		opts := builder.UserOpts{
+			Key:                  key,
+			PasswordSecretKeyRef: spiderSpec.PasswordSecretKeyRef,
+			MaxUserConnections:   spiderMaxUserConnections,
+			Name:                 spiderSpec.Username,
+			Owner:                mariadb,
+		}
+		desiredUser, err := r.Builder.BuildUser(nodeMariadb, opts)
+		if err != nil {
+			return false, fmt.Errorf("error building User: %v", err)
+		}
+		return false, r.Create(ctx, desiredUser)
+	}
+	if !user.IsReady() {
+		return false, nil
+	}
+
+	var grant mariadbv1alpha1.Grant
+	if err := r.Get(ctx, key, &grant); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("error getting Grant: %v", err)
+		}
+		opts := builder.GrantOpts{
+			Key:        key,
+			Privileges: spiderPrivileges,
+			Database:   ptr.Deref(node.Database, "*"),
+			Table:      "*",
+			Username:   spiderSpec.Username,
+			Owner:      mariadb,
+		}
+		desiredGrant, err := r.Builder.BuildGrant(nodeMariadb, opts)
+		if err != nil {
+			return false, fmt.Errorf("error building Grant: %v", err)
+		}
+		return false, r.Create(ctx, desiredGrant)
+	}
+	return grant.IsReady(), nil
+}
+
+// deleteSpiderNodeUsers deletes the Users and Grants of the removed servers, which drops the Spider user from the nodes.
+func (r *MariaDBReconciler) deleteSpiderNodeUsers(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, servers []string) error {
+	for _, server := range servers {
+		key := mariadb.SpiderNodeKey(server)
+		objs := []client.Object{
+			&mariadbv1alpha1.Grant{},
+			&mariadbv1alpha1.User{},
+		}
+		for _, obj := range objs {
+			if err := r.Get(ctx, key, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("error getting Spider node resource: %v", err)
+			}
+			if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("error deleting Spider node resource: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) reconcileSpiderRemoved(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	servers := mariadb.Status.SpiderServers
+	if len(servers) == 0 {
+		return nil
+	}
+	rootClient, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer rootClient.Close()
+
+	logger := log.FromContext(ctx).WithName("spider")
+	for _, server := range servers {
+		logger.Info("Dropping Spider server", "server", server)
+		if err := rootClient.DropForeignServer(ctx, server); err != nil {
+			return fmt.Errorf("error dropping Spider server '%s': %v", server, err)
+		}
+	}
+	if err := r.deleteSpiderNodeUsers(ctx, mariadb, servers); err != nil {
+		return err
+	}
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) error {
+		status.SpiderServers = nil
+		return nil
+	})
+}
+
+// spiderNodeIndexValue is the value used to index the Spider nodes of a MariaDB.
+func spiderNodeIndexValue(name, namespace string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// mapSpiderNodeToRequests enqueues the MariaDBs that use a MariaDB as Spider node, so its server is kept in sync.
+func (r *MariaDBReconciler) mapSpiderNodeToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	var mariadbs mariadbv1alpha1.MariaDBList
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(spiderNodeField, spiderNodeIndexValue(obj.GetName(), obj.GetNamespace())),
+	}
+	if err := r.List(ctx, &mariadbs, listOpts); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(mariadbs.Items))
+	for i, item := range mariadbs.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&item),
+		}
+	}
+	return requests
+}
@@ -310,6 +310,93 @@ var _ = Describe("MariaDB controller", func() {
 			Expect(k8sClient.Delete(testCtx, &updateMariaDB)).To(Succeed())
 		})
 	})
+
+	Context("When hibernating a MariaDB", func() {
+		It("Should scale down and resume", func() {
+			By("Creating MariaDB")
+			key := types.NamespacedName{
+				Name:      "test-hibernate-mariadb",
+				Namespace: testNamespace,
+			}
+			mdb := mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					VolumeClaimTemplate: mariadbv1alpha1.VolumeClaimTemplate{
+						PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"storage": resource.MustParse("100Mi"),
+								},
+							},
+							AccessModes: []corev1.PersistentVolumeAccessMode{
+								corev1.ReadWriteOnce,
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &mdb)).To(Succeed())
+
+			By("Expecting MariaDB to be ready eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				return mdb.IsReady()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Enabling hibernation")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				mdb.Spec.Hibernate = &mariadbv1alpha1.Hibernate{
+					Enabled: true,
+				}
+				return k8sClient.Update(testCtx, &mdb) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting MariaDB to be hibernated eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				return mdb.IsHibernated()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting StatefulSet to be scaled down to zero eventually")
+			Eventually(func() bool {
+				var sts appsv1.StatefulSet
+				if err := k8sClient.Get(testCtx, key, &sts); err != nil {
+					return false
+				}
+				return sts.Spec.Replicas != nil && *sts.Spec.Replicas == 0
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Disabling hibernation")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				mdb.Spec.Hibernate.Enabled = false
+				return k8sClient.Update(testCtx, &mdb) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting MariaDB to be ready eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				return !mdb.IsHibernated() && mdb.IsReady()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Deleting MariaDB")
+			Expect(k8sClient.Delete(testCtx, &mdb)).To(Succeed())
+		})
+	})
 })
 
 var _ = Describe("MariaDB replication", func() {
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	certctrl "github.com/mariadb-operator/mariadb-operator/pkg/controller/certificate"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const tlsRequeueInterval = 5 * time.Second
+
+// reconcileTLS provisions the server certificate, either issued by the operator or requested to cert-manager.
+// The StatefulSet is not reconciled until the certificate has been issued, as the Pods mount its Secret.
+func (r *MariaDBReconciler) reconcileTLS(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if mariadb.Spec.TLS == nil {
+		return ctrl.Result{}, nil
+	}
+	if !mariadb.IsTLSEnabled() || !mariadb.Spec.TLS.IsIssuedByCertManager() {
+		if err := r.deleteCertificate(ctx, mariadb); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if !mariadb.IsTLSEnabled() {
+		return ctrl.Result{}, nil
+	}
+	if mariadb.Spec.TLS.IsIssuedByCertManager() {
+		return r.reconcileCertManagerTLS(ctx, mariadb)
+	}
+	return ctrl.Result{}, r.reconcileOperatorTLS(ctx, mariadb)
+}
+
+// reconcileOperatorTLS issues the server certificate with a CA managed by the operator. Both are stored in Secrets
+// owned by the MariaDB, and they are renewed when they are about to expire.
+func (r *MariaDBReconciler) reconcileOperatorTLS(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	tls := mariadb.Spec.TLS
+	certReconciler := certctrl.NewCertReconciler(
+		r.Client,
+		mariadb.TLSCAKey(),
+		fmt.Sprintf("%s-ca", mariadb.Name),
+		mariadb.TLSKey(),
+		builder.ServerCertificateCommonName(mariadb),
+		builder.ServerCertificateDNSNames(mariadb),
+		certctrl.WithCAValidity(4*tls.DurationOrDefault()),
+		certctrl.WithCertValidity(tls.DurationOrDefault()),
+		certctrl.WithLookaheadValidity(tls.RenewBeforeOrDefault()),
+		certctrl.WithCertExtKeyUsage(x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth),
+		certctrl.WithOwner(mariadb),
+	)
+	result, err := certReconciler.Reconcile(ctx)
+	if err != nil {
+		return fmt.Errorf("error reconciling certificate: %v", err)
+	}
+	if result.RefreshedCert {
+		log.FromContext(ctx).Info("Issued server certificate", "secret", mariadb.TLSKey().Name,
+			"not-after", result.CertKeyPair.Cert.NotAfter)
+	}
+	return nil
+}
+
+// reconcileCertManagerTLS requests the server certificate to cert-manager.
+func (r *MariaDBReconciler) reconcileCertManagerTLS(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	exist, err := r.DiscoveryClient.CertificateExist()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !exist {
+		r.Recorder.Event(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonCRDNotFound,
+			"Unable to reconcile Certificate: cert-manager Certificate CRD not installed in the cluster")
+		return ctrl.Result{}, errors.New("cert-manager Certificate CRD not installed in the cluster")
+	}
+	key := mariadb.TLSKey()
+
+	desiredCert, err := r.Builder.BuildServerCertificate(mariadb, key)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error building Certificate: %v", err)
+	}
+	existingCert := &unstructured.Unstructured{}
+	existingCert.SetGroupVersionKind(builder.CertificateGVK)
+	if err := r.Get(ctx, key, existingCert); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting Certificate: %v", err)
+		}
+		if err := r.Create(ctx, desiredCert); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating Certificate: %v", err)
+		}
+	} else if !reflect.DeepEqual(existingCert.Object["spec"], desiredCert.Object["spec"]) {
+		patch := client.MergeFrom(existingCert.DeepCopy())
+		existingCert.Object["spec"] = desiredCert.Object["spec"]
+		if err := r.Patch(ctx, existingCert, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching Certificate: %v", err)
+		}
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting TLS Secret: %v", err)
+		}
+	}
+	if len(secret.Data[builder.ServerTLSCertFile]) == 0 {
+		log.FromContext(ctx).Info("Waiting for cert-manager to issue the server certificate", "certificate", key.Name)
+		return ctrl.Result{RequeueAfter: tlsRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteCertificate deletes the cert-manager Certificate, if any, when TLS is disabled or the certificate is issued by the operator.
+func (r *MariaDBReconciler) deleteCertificate(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(builder.CertificateGVK)
+	if err := r.Get(ctx, mariadb.TLSKey(), cert); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("error getting Certificate: %v", err)
+	}
+	if err := r.Delete(ctx, cert); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("error deleting Certificate: %v", err)
+	}
+	return nil
+}
+
+// tlsRenewalResult requeues the MariaDB when the certificate issued by the operator is due for renewal.
+// Certificates issued by cert-manager are renewed by cert-manager, which updates the Secret watched by the operator.
+func tlsRenewalResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if !mariadb.IsTLSEnabled() || mariadb.Spec.TLS.IsIssuedByCertManager() ||
+		mariadb.Status.TLS == nil || mariadb.Status.TLS.CertNotAfter == nil {
+		return ctrl.Result{}
+	}
+	renewAt := mariadb.Status.TLS.CertNotAfter.Add(-mariadb.Spec.TLS.RenewBeforeOrDefault())
+	remaining := time.Until(renewAt)
+	if remaining <= 0 {
+		return ctrl.Result{RequeueAfter: time.Second}
+	}
+	return ctrl.Result{RequeueAfter: remaining}
+}
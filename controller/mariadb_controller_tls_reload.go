@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/pki"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	sqlClientSet "github.com/mariadb-operator/mariadb-operator/pkg/sqlset"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileTLSReload makes the Pods load the server certificate after it has been renewed, without restarting them.
+// Once the kubelet has refreshed the mounted Secret, the certificate is reloaded via FLUSH SSL, and via socket.ssl_reload
+// for the Galera traffic. A Pod has loaded the certificate when the expiration time reported by the server matches.
+// The Pods are rolled out instead when the server version does not support reloading the certificate.
+func (r *MariaDBReconciler) reconcileTLSReload(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if !mariadb.IsTLSEnabled() {
+		if mariadb.Status.TLS == nil {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.TLS = nil
+			return nil
+		})
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, mariadb.TLSKey(), &secret); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting TLS Secret: %v", err)
+	}
+	cert, err := pki.ParseCert(secret.Data[builder.ServerTLSCertFile])
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error parsing server certificate: %v", err)
+	}
+	serial := cert.SerialNumber.Text(16)
+	if mariadb.Status.TLS != nil && mariadb.Status.TLS.CertSerial == serial {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithName("tls")
+
+	var sts appsv1.StatefulSet
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mariadb), &sts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting StatefulSet: %v", err)
+	}
+	if sts.Status.ReadyReplicas != mariadb.ScaledReplicas() {
+		logger.V(1).Info("Waiting for Pods to be ready to load the certificate", "serial", serial)
+		return ctrl.Result{RequeueAfter: tlsRequeueInterval}, nil
+	}
+
+	clientSet := sqlClientSet.NewClientSet(mariadb, r.RefResolver, r.SqlOpts...)
+	defer clientSet.Close()
+
+	var reloaded, pending []int
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		client, err := clientSet.ClientForIndex(ctx, i)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting client for Pod %d: %v", i, err)
+		}
+		loaded, err := isCertLoaded(ctx, client, cert)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error checking certificate in Pod %d: %v", i, err)
+		}
+		if loaded {
+			continue
+		}
+
+		if err := reloadCert(ctx, mariadb, client); err != nil {
+			if sqlClient.IsUnsupported(err) {
+				return r.rolloutCert(ctx, mariadb, serial, err)
+			}
+			return ctrl.Result{}, fmt.Errorf("error reloading certificate in Pod %d: %v", i, err)
+		}
+		if loaded, err = isCertLoaded(ctx, client, cert); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error checking certificate in Pod %d: %v", i, err)
+		}
+		if !loaded {
+			pending = append(pending, i)
+			continue
+		}
+		logger.Info("Reloaded certificate", "pod-index", i, "serial", serial)
+		reloaded = append(reloaded, i)
+	}
+	if len(pending) > 0 {
+		logger.V(1).Info("Waiting for the kubelet to refresh the certificate", "pod-indexes", pending, "serial", serial)
+		return ctrl.Result{RequeueAfter: tlsRequeueInterval}, nil
+	}
+
+	if len(reloaded) > 0 {
+		r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonTLSCertReloaded,
+			"Certificate %s reloaded by the Pods without restarting them", serial)
+	}
+	return ctrl.Result{}, r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		if s.TLS == nil {
+			s.TLS = &mariadbv1alpha1.MariaDBTLSStatus{}
+		}
+		s.TLS.CertSerial = serial
+		s.TLS.CertNotAfter = ptr.To(metav1.NewTime(cert.NotAfter))
+		if len(reloaded) > 0 {
+			s.TLS.ReloadedAt = ptr.To(metav1.Now())
+		}
+		return nil
+	})
+}
+
+// rolloutCert records the certificate serial in the status, which is part of the config checksum of the Pods.
+// The StatefulSet is rolled out in the next reconciliation, respecting the maintenance window, if any.
+func (r *MariaDBReconciler) rolloutCert(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, serial string,
+	reason error) (ctrl.Result, error) {
+	if mariadb.Status.TLS != nil && mariadb.Status.TLS.RolloutCertSerial == serial {
+		return ctrl.Result{}, nil
+	}
+	log.FromContext(ctx).WithName("tls").Info("Rolling out Pods to load the certificate", "serial", serial, "reason", reason.Error())
+	r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonTLSCertRollout,
+		"Rolling out Pods to load certificate %s: %v", serial, reason)
+
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		if s.TLS == nil {
+			s.TLS = &mariadbv1alpha1.MariaDBTLSStatus{}
+		}
+		s.TLS.RolloutCertSerial = serial
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching TLS status: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: tlsRequeueInterval}, nil
+}
+
+func reloadCert(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client) error {
+	if err := client.FlushSSL(ctx); err != nil {
+		return err
+	}
+	if mariadb.IsGaleraTLSEnabled() {
+		if err := client.ReloadGaleraSSL(ctx); err != nil {
+			return fmt.Errorf("error reloading Galera certificate: %v", err)
+		}
+	}
+	return nil
+}
+
+// isCertLoaded indicates whether the server has loaded the certificate. The expiration time is compared,
+// as it is the only attribute of the certificate reported by the server.
+func isCertLoaded(ctx context.Context, client *sqlClient.Client, cert *x509.Certificate) (bool, error) {
+	notAfter, err := client.SSLServerNotAfter(ctx)
+	if err != nil {
+		return false, err
+	}
+	return notAfter.Equal(cert.NotAfter), nil
+}
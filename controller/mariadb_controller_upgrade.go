@@ -0,0 +1,270 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	"github.com/mariadb-operator/mariadb-operator/pkg/upgrade"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// upgradePreflightInterval is the interval at which the pre-flight checks are performed again while the upgrade is blocked.
+const upgradePreflightInterval = 1 * time.Minute
+
+// reconcileUpgradePreflight performs the upgrade pre-flight checks before a new MariaDB image is rolled out,
+// keeping the existing image in the desired StatefulSet while critical checks fail.
+func (r *MariaDBReconciler) reconcileUpgradePreflight(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	desiredSts, existingSts *appsv1.StatefulSet) error {
+	preflight := mariadb.Spec.UpgradePreflight
+	desiredImage := mariadbImage(desiredSts)
+	existingImage := mariadbImage(existingSts)
+	if preflight == nil || desiredImage == "" || existingImage == "" {
+		return nil
+	}
+	status := mariadb.Status.UpgradePreflight
+
+	if desiredImage == existingImage {
+		if status != nil && status.Blocked {
+			return r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+				s.UpgradePreflight.Blocked = false
+				return nil
+			})
+		}
+		return nil
+	}
+	if _, upgrading := existingSts.Annotations[metadata.UpgradeFromAnnotation]; upgrading {
+		return nil
+	}
+
+	isOutdated := status == nil || status.FromImage != existingImage || status.ToImage != desiredImage ||
+		(status.Blocked && time.Since(status.CheckTime.Time) >= upgradePreflightInterval)
+	if isOutdated {
+		wasBlocked := status != nil && status.Blocked && status.FromImage == existingImage && status.ToImage == desiredImage
+		checks := r.runUpgradePreflight(ctx, mariadb, desiredImage)
+		blocked := upgrade.IsBlocked(checks)
+
+		if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+			s.UpgradePreflight = &mariadbv1alpha1.UpgradePreflightStatus{
+				FromImage: existingImage,
+				ToImage:   desiredImage,
+				CheckTime: metav1.Now(),
+				Blocked:   blocked,
+				Checks:    checks,
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error patching upgrade pre-flight status: %v", err)
+		}
+		if blocked && !wasBlocked {
+			r.Recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonUpgradeBlocked,
+				"Upgrade from '%s' to '%s' blocked by failed pre-flight checks: %s",
+				existingImage, desiredImage, strings.Join(upgrade.BlockingChecks(checks), ", "))
+		}
+	}
+
+	if mariadb.Status.UpgradePreflight.Blocked && !preflight.Force {
+		log.FromContext(ctx).V(1).Info("Upgrade blocked by pre-flight checks", "from", existingImage, "to", desiredImage)
+		setMariadbImage(desiredSts, existingImage)
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) runUpgradePreflight(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	desiredImage string) []mariadbv1alpha1.UpgradePreflightCheck {
+	preflight := mariadb.Spec.UpgradePreflight
+	target, targetErr := upgrade.ImageVersion(desiredImage)
+
+	client, clientErr := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+	if clientErr == nil {
+		defer client.Close()
+	}
+	withClient := func(fn func(*sqlClient.Client) error) error {
+		if clientErr != nil {
+			return fmt.Errorf("error connecting to MariaDB: %v", clientErr)
+		}
+		return fn(client)
+	}
+
+	var checks []mariadbv1alpha1.UpgradePreflightCheck
+	if !preflight.IsSkipped(mariadbv1alpha1.UpgradePreflightCheckVersion) {
+		var current *sqlClient.Version
+		err := withClient(func(c *sqlClient.Client) (err error) {
+			current, err = c.ServerVersion(ctx)
+			return err
+		})
+		checks = append(checks, upgrade.CheckVersion(current, err, target, targetErr))
+	}
+	if !preflight.IsSkipped(mariadbv1alpha1.UpgradePreflightCheckVariables) {
+		myCnf, err := r.upgradePreflightMyCnf(ctx, mariadb)
+		checks = append(checks, upgrade.CheckVariables(myCnf, err, target))
+	}
+	if !preflight.IsSkipped(mariadbv1alpha1.UpgradePreflightCheckPlugins) {
+		var plugins []string
+		err := withClient(func(c *sqlClient.Client) (err error) {
+			plugins, err = c.ActivePlugins(ctx)
+			return err
+		})
+		checks = append(checks, upgrade.CheckPlugins(plugins, err, target))
+	}
+	if !preflight.IsSkipped(mariadbv1alpha1.UpgradePreflightCheckStorage) {
+		var dataSize int64
+		err := withClient(func(c *sqlClient.Client) (err error) {
+			dataSize, err = c.DataSize(ctx)
+			return err
+		})
+		var storageSize int64
+		if storage, ok := mariadb.Spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage]; ok {
+			storageSize = storage.Value()
+		}
+		checks = append(checks, upgrade.CheckStorage(dataSize, err, storageSize, preflight.MinStorageHeadroomPercentOrDefault()))
+	}
+	if !preflight.IsSkipped(mariadbv1alpha1.UpgradePreflightCheckReplication) &&
+		(mariadb.Replication().Enabled || mariadb.Galera().Enabled) {
+		checks = append(checks, upgrade.CheckReplication(r.upgradePreflightReplicationIssues(ctx, mariadb)))
+	}
+	return checks
+}
+
+func (r *MariaDBReconciler) upgradePreflightMyCnf(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	if mariadb.Spec.MyCnf != nil {
+		return *mariadb.Spec.MyCnf, nil
+	}
+	if mariadb.Spec.MyCnfConfigMapKeyRef != nil {
+		return r.RefResolver.ConfigMapKeyRef(ctx, mariadb.Spec.MyCnfConfigMapKeyRef, mariadb.Namespace)
+	}
+	return "", nil
+}
+
+func (r *MariaDBReconciler) upgradePreflightReplicationIssues(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) []string {
+	if mariadb.Galera().Enabled {
+		client, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, r.SqlOpts...)
+		if err != nil {
+			return []string{fmt.Sprintf("Unable to connect to MariaDB: %v", err)}
+		}
+		defer client.Close()
+
+		var issues []string
+		clusterStatus, err := client.GaleraClusterStatus(ctx)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("Unable to get Galera cluster status: %v", err))
+		} else if clusterStatus != "Primary" {
+			issues = append(issues, fmt.Sprintf("Galera cluster status is '%s'", clusterStatus))
+		}
+		clusterSize, err := client.GaleraClusterSize(ctx)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("Unable to get Galera cluster size: %v", err))
+		} else if clusterSize != mariadb.GaleraClusterSize() {
+			issues = append(issues, fmt.Sprintf("Galera cluster size is %d, expected %d", clusterSize, mariadb.GaleraClusterSize()))
+		}
+		return issues
+	}
+
+	var issues []string
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		if mariadb.Status.CurrentPrimaryPodIndex != nil && *mariadb.Status.CurrentPrimaryPodIndex == i {
+			continue
+		}
+		podName := statefulset.PodName(mariadb.ObjectMeta, i)
+		if mariadb.HasErrantGtids(podName) {
+			issues = append(issues, fmt.Sprintf("Pod '%s' has errant GTIDs", podName))
+		}
+		if err := r.checkReplicaHealth(ctx, mariadb, i); err != nil {
+			issues = append(issues, fmt.Sprintf("Pod '%s' %v", podName, err))
+		}
+	}
+	return issues
+}
+
+func (r *MariaDBReconciler) checkReplicaHealth(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, podIndex int) error {
+	client, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.RefResolver, podIndex, r.SqlOpts...)
+	if err != nil {
+		return fmt.Errorf("is not reachable: %v", err)
+	}
+	defer client.Close()
+
+	lag, err := client.ReplicationLag(ctx)
+	if err != nil {
+		return fmt.Errorf("replication is not healthy: %v", err)
+	}
+	if lag == nil {
+		return fmt.Errorf("is not replicating")
+	}
+	return nil
+}
+
+// upgradePreflightResult requeues the MariaDB while the upgrade is blocked by the pre-flight checks.
+func upgradePreflightResult(mariadb *mariadbv1alpha1.MariaDB) ctrl.Result {
+	if mariadb.Spec.UpgradePreflight == nil || mariadb.Spec.UpgradePreflight.Force {
+		return ctrl.Result{}
+	}
+	if status := mariadb.Status.UpgradePreflight; status != nil && status.Blocked {
+		return ctrl.Result{RequeueAfter: upgradePreflightInterval}
+	}
+	return ctrl.Result{}
+}
+
+// reconcileUpgrade tracks MariaDB image changes in the existing StatefulSet annotations,
+// recording an Event once all the Pods have been rolled out with the new image.
+func (r *MariaDBReconciler) reconcileUpgrade(mariadb *mariadbv1alpha1.MariaDB, desiredSts, existingSts *appsv1.StatefulSet) {
+	desiredImage := mariadbImage(desiredSts)
+	existingImage := mariadbImage(existingSts)
+	if desiredImage == "" || existingImage == "" {
+		return
+	}
+
+	if existingSts.Annotations == nil {
+		existingSts.Annotations = map[string]string{}
+	}
+	fromImage, upgrading := existingSts.Annotations[metadata.UpgradeFromAnnotation]
+
+	if desiredImage != existingImage {
+		if !upgrading {
+			existingSts.Annotations[metadata.UpgradeFromAnnotation] = existingImage
+		}
+		return
+	}
+	if !upgrading || !isRolloutComplete(existingSts) {
+		return
+	}
+	r.Recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonMariaDBUpgraded,
+		"MariaDB upgraded from '%s' to '%s'", fromImage, existingImage)
+	delete(existingSts.Annotations, metadata.UpgradeFromAnnotation)
+}
+
+func mariadbImage(sts *appsv1.StatefulSet) string {
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == builder.MariaDbContainerName {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+func setMariadbImage(sts *appsv1.StatefulSet, image string) {
+	for i, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == builder.MariaDbContainerName {
+			sts.Spec.Template.Spec.Containers[i].Image = image
+		}
+	}
+}
+
+func isRolloutComplete(sts *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	return sts.Status.ObservedGeneration >= sts.Generation &&
+		sts.Status.UpdatedReplicas == replicas &&
+		sts.Status.ReadyReplicas == replicas
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	mdbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	corev1 "k8s.io/api/core/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const warmUpRequeueInterval = 5 * time.Second
+
+// reconcileWarmUp warms up the replicas that have been rebuilt or restarted before they are added to the secondary Services.
+// The progress is tracked via annotations in the Pods, identifying each run of the MariaDB container by its start time.
+// Pods are not watched, so it requeues while any replica is still warming up.
+func (r *MariaDBReconciler) reconcileWarmUp(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	if mariadb.Spec.WarmUp == nil {
+		if mariadb.Status.WarmUpEnabled {
+			return ctrl.Result{}, r.patchWarmUpEnabled(ctx, mariadb, false)
+		}
+		return ctrl.Result{}, nil
+	}
+	if !mariadb.IsHAEnabled() || mariadb.Status.CurrentPrimaryPodIndex == nil {
+		return ctrl.Result{}, nil
+	}
+
+	var podList corev1.PodList
+	listOpts := &client.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(
+			labels.NewLabelsBuilder().
+				WithMariaDB(mariadb).
+				Build(),
+		),
+		Namespace: mariadb.Namespace,
+	}
+	if err := r.List(ctx, &podList, listOpts); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error listing Pods: %v", err)
+	}
+
+	if !mariadb.Status.WarmUpEnabled {
+		// The Pods already running when the warm-up is enabled are considered warm, so they are kept in the secondary Services.
+		for _, pod := range podList.Items {
+			id := mdbpod.WarmUpID(&pod, builder.MariaDbContainerName)
+			if id == "" || mdbpod.PodWarmedUp(&pod, builder.MariaDbContainerName) {
+				continue
+			}
+			if err := r.patchPodAnnotation(ctx, &pod, metadata.WarmedUpAnnotation, id); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: warmUpRequeueInterval}, r.patchWarmUpEnabled(ctx, mariadb, true)
+	}
+
+	var result ctrl.Result
+	for _, pod := range podList.Items {
+		podIndex, err := statefulset.PodIndex(pod.Name)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting Pod '%s' index: %v", pod.Name, err)
+		}
+		if *podIndex == *mariadb.Status.CurrentPrimaryPodIndex || mdbpod.PodWarmedUp(&pod, builder.MariaDbContainerName) {
+			continue
+		}
+		if !mdbpod.PodReady(&pod) {
+			result = ctrl.Result{RequeueAfter: warmUpRequeueInterval}
+			continue
+		}
+		warmedUp, err := r.warmUpPod(ctx, mariadb, &pod, *podIndex)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Error warming up Pod", "pod", pod.Name)
+		}
+		if !warmedUp {
+			result = ctrl.Result{RequeueAfter: warmUpRequeueInterval}
+		}
+	}
+	return result, nil
+}
+
+func (r *MariaDBReconciler) warmUpPod(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, pod *corev1.Pod,
+	podIndex int) (bool, error) {
+	warmUp := mariadb.Spec.WarmUp
+	logger := log.FromContext(ctx).WithValues("pod", pod.Name)
+
+	id := mdbpod.WarmUpID(pod, builder.MariaDbContainerName)
+	startedAt := mdbpod.ContainerStartedAt(pod, builder.MariaDbContainerName)
+	if id == "" || startedAt == nil {
+		return false, nil
+	}
+	remaining := warmUp.TimeoutOrDefault() - time.Since(startedAt.Time)
+	if remaining <= 0 {
+		logger.Info("Warm-up timed out, adding replica to the secondary Services")
+		return true, r.patchPodAnnotation(ctx, pod, metadata.WarmedUpAnnotation, id)
+	}
+
+	client, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.RefResolver, podIndex,
+		append(slices.Clip(r.SqlOpts), sqlClient.WithQueryTimeout(remaining))...)
+	if err != nil {
+		return false, fmt.Errorf("error getting SQL client: %v", err)
+	}
+	defer client.Close()
+
+	if pod.Annotations[metadata.WarmUpStartedAnnotation] != id {
+		logger.Info("Warming up replica")
+		if warmUp.BufferPoolLoad {
+			status, err := client.StatusVariable(ctx, "Innodb_buffer_pool_load_status")
+			if err != nil {
+				return false, fmt.Errorf("error getting buffer pool load status: %v", err)
+			}
+			if status == "" || strings.Contains(status, "not started") {
+				if err := client.SetSystemVariable(ctx, "innodb_buffer_pool_load_now", "ON"); err != nil {
+					return false, fmt.Errorf("error loading buffer pool: %v", err)
+				}
+			}
+		}
+		for _, query := range warmUp.Queries {
+			if err := client.ReadOnlyQuery(ctx, query); err != nil {
+				return false, fmt.Errorf("error executing warm-up query: %v", err)
+			}
+		}
+		if err := r.patchPodAnnotation(ctx, pod, metadata.WarmUpStartedAnnotation, id); err != nil {
+			return false, err
+		}
+	}
+
+	if warmUp.BufferPoolLoad {
+		status, err := client.StatusVariable(ctx, "Innodb_buffer_pool_load_status")
+		if err != nil {
+			return false, fmt.Errorf("error getting buffer pool load status: %v", err)
+		}
+		if !strings.Contains(status, "completed") && !strings.Contains(status, "aborted") {
+			logger.V(1).Info("Waiting for buffer pool load", "status", status)
+			return false, nil
+		}
+	}
+
+	logger.Info("Replica warmed up")
+	return true, r.patchPodAnnotation(ctx, pod, metadata.WarmedUpAnnotation, id)
+}
+
+func (r *MariaDBReconciler) patchWarmUpEnabled(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, enabled bool) error {
+	if err := r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) error {
+		s.WarmUpEnabled = enabled
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error patching warm-up status: %v", err)
+	}
+	return nil
+}
+
+func (r *MariaDBReconciler) patchPodAnnotation(ctx context.Context, pod *corev1.Pod, key, value string) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[key] = value
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("error patching Pod annotation '%s': %v", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// MariaDBFleetReconciler reconciles a MariaDBFleet object
+type MariaDBFleetReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Builder *builder.Builder
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbfleets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbfleets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbfleets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *MariaDBFleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var fleet mariadbv1alpha1.MariaDBFleet
+	if err := r.Get(ctx, req.NamespacedName, &fleet); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	members, err := r.members(ctx, &fleet)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting members: %v", err)
+	}
+	existing, err := r.existingMembers(ctx, &fleet)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error listing members: %v", err)
+	}
+	if err := r.reconcileStaleMembers(ctx, &fleet, members, existing); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling stale members: %v", err)
+	}
+
+	status := r.reconcileMembers(ctx, &fleet, members, existing)
+
+	if err := r.patchStatus(ctx, &fleet, func(s *mariadbv1alpha1.MariaDBFleetStatus) {
+		s.Members = status.Members
+		s.TotalMembers = status.TotalMembers
+		s.ReadyMembers = status.ReadyMembers
+		s.UpdatedMembers = status.UpdatedMembers
+		condition.SetReadyWithFleetStatus(s, s)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching MariaDBFleet: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// members returns the desired members of the fleet, including the ones selected by the namespace selector.
+func (r *MariaDBFleetReconciler) members(ctx context.Context,
+	fleet *mariadbv1alpha1.MariaDBFleet) ([]mariadbv1alpha1.MariaDBFleetMember, error) {
+	members := make([]mariadbv1alpha1.MariaDBFleetMember, 0, len(fleet.Spec.Members))
+	seen := make(map[types.NamespacedName]struct{}, len(fleet.Spec.Members))
+	for _, m := range fleet.Spec.Members {
+		members = append(members, m)
+		seen[m.Key()] = struct{}{}
+	}
+	if fleet.Spec.NamespaceSelector == nil {
+		return members, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(fleet.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing namespace selector: %v", err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("error listing namespaces: %v", err)
+	}
+	for _, ns := range namespaces.Items {
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+		member := mariadbv1alpha1.MariaDBFleetMember{
+			Name:      fleet.Name,
+			Namespace: ns.Name,
+		}
+		if _, ok := seen[member.Key()]; ok {
+			continue
+		}
+		members = append(members, member)
+		seen[member.Key()] = struct{}{}
+	}
+	return members, nil
+}
+
+func (r *MariaDBFleetReconciler) existingMembers(ctx context.Context,
+	fleet *mariadbv1alpha1.MariaDBFleet) (map[types.NamespacedName]*mariadbv1alpha1.MariaDB, error) {
+	var mariadbs mariadbv1alpha1.MariaDBList
+	listOpts := client.MatchingLabels(
+		labels.NewLabelsBuilder().
+			WithFleet(fleet.Name).
+			Build(),
+	)
+	if err := r.List(ctx, &mariadbs, listOpts); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[types.NamespacedName]*mariadbv1alpha1.MariaDB, len(mariadbs.Items))
+	for i := range mariadbs.Items {
+		mdb := &mariadbs.Items[i]
+		if !metav1.IsControlledBy(mdb, fleet) {
+			continue
+		}
+		existing[client.ObjectKeyFromObject(mdb)] = mdb
+	}
+	return existing, nil
+}
+
+// reconcileStaleMembers deletes the MariaDBs that are no longer members of the fleet when pruning is enabled,
+// otherwise they are orphaned by removing the fleet owner reference and label.
+func (r *MariaDBFleetReconciler) reconcileStaleMembers(ctx context.Context, fleet *mariadbv1alpha1.MariaDBFleet,
+	members []mariadbv1alpha1.MariaDBFleetMember, existing map[types.NamespacedName]*mariadbv1alpha1.MariaDB) error {
+	desired := make(map[types.NamespacedName]struct{}, len(members))
+	for _, m := range members {
+		desired[m.Key()] = struct{}{}
+	}
+	logger := log.FromContext(ctx)
+
+	for key, mdb := range existing {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if fleet.Spec.Prune {
+			logger.Info("Deleting member no longer in fleet", "mariadb", key.String())
+			if err := r.Delete(ctx, mdb); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("error deleting MariaDB '%s': %v", key.String(), err)
+			}
+		} else {
+			logger.Info("Orphaning member no longer in fleet", "mariadb", key.String())
+			if err := r.orphanMember(ctx, fleet, mdb); err != nil {
+				return fmt.Errorf("error orphaning MariaDB '%s': %v", key.String(), err)
+			}
+		}
+		delete(existing, key)
+	}
+	return nil
+}
+
+func (r *MariaDBFleetReconciler) orphanMember(ctx context.Context, fleet *mariadbv1alpha1.MariaDBFleet,
+	mdb *mariadbv1alpha1.MariaDB) error {
+	patch := client.MergeFrom(mdb.DeepCopy())
+	ownerRefs := make([]metav1.OwnerReference, 0, len(mdb.OwnerReferences))
+	for _, ref := range mdb.OwnerReferences {
+		if ref.UID != fleet.UID {
+			ownerRefs = append(ownerRefs, ref)
+		}
+	}
+	mdb.OwnerReferences = ownerRefs
+	delete(mdb.Labels, labels.FleetLabel)
+	delete(mdb.Annotations, metadata.FleetSpecHashAnnotation)
+	return client.IgnoreNotFound(r.Patch(ctx, mdb, patch))
+}
+
+// reconcileMembers creates the missing members and rolls out template changes to the existing ones,
+// limiting the number of members that are not ready at the same time to the max unavailable of the rollout strategy.
+func (r *MariaDBFleetReconciler) reconcileMembers(ctx context.Context, fleet *mariadbv1alpha1.MariaDBFleet,
+	members []mariadbv1alpha1.MariaDBFleetMember,
+	existing map[types.NamespacedName]*mariadbv1alpha1.MariaDB) *mariadbv1alpha1.MariaDBFleetStatus {
+	notReady := 0
+	for _, m := range members {
+		if mdb, ok := existing[m.Key()]; !ok || !mdb.IsReady() {
+			notReady++
+		}
+	}
+	maxUnavailable := fleet.MaxUnavailable()
+	status := &mariadbv1alpha1.MariaDBFleetStatus{
+		Members: make([]mariadbv1alpha1.MariaDBFleetMemberStatus, 0, len(members)),
+	}
+
+	for i := range members {
+		member := &members[i]
+		memberStatus := mariadbv1alpha1.MariaDBFleetMemberStatus{
+			Name:      member.Name,
+			Namespace: member.Namespace,
+		}
+
+		desired, err := r.Builder.BuildFleetMember(fleet, member)
+		if err != nil {
+			memberStatus.Message = fmt.Sprintf("Error building MariaDB: %v", err)
+			status.Members = append(status.Members, memberStatus)
+			continue
+		}
+		memberStatus.Image = desired.Spec.Image
+
+		mdb, ok := existing[member.Key()]
+		if !ok {
+			if err := r.Create(ctx, desired); err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					memberStatus.Message = "MariaDB already exists and it is not managed by the fleet"
+				} else {
+					memberStatus.Message = fmt.Sprintf("Error creating MariaDB: %v", err)
+				}
+			} else {
+				memberStatus.Updated = true
+				memberStatus.Message = "Created"
+			}
+			status.Members = append(status.Members, memberStatus)
+			continue
+		}
+
+		memberStatus.Ready = mdb.IsReady()
+		memberStatus.Updated = isFleetMemberUpdated(mdb, desired)
+		memberStatus.Image = mdb.Spec.Image
+		memberStatus.Message = readyMessage(mdb)
+
+		if !memberStatus.Updated {
+			// Members that are not ready are already accounted as unavailable, so they can be updated right away.
+			if memberStatus.Ready && notReady >= maxUnavailable {
+				memberStatus.Message = "Waiting for other members to be updated"
+			} else {
+				if err := r.updateMember(ctx, mdb, desired); err != nil {
+					memberStatus.Message = fmt.Sprintf("Error updating MariaDB: %v", err)
+				} else {
+					if memberStatus.Ready {
+						notReady++
+					}
+					memberStatus.Updated = true
+					memberStatus.Image = mdb.Spec.Image
+					memberStatus.Message = "Updated"
+				}
+			}
+		}
+		status.Members = append(status.Members, memberStatus)
+	}
+
+	sort.Slice(status.Members, func(i, j int) bool {
+		if status.Members[i].Namespace != status.Members[j].Namespace {
+			return status.Members[i].Namespace < status.Members[j].Namespace
+		}
+		return status.Members[i].Name < status.Members[j].Name
+	})
+	for _, m := range status.Members {
+		status.TotalMembers++
+		if m.Ready {
+			status.ReadyMembers++
+		}
+		if m.Updated {
+			status.UpdatedMembers++
+		}
+	}
+	return status
+}
+
+func (r *MariaDBFleetReconciler) updateMember(ctx context.Context, existing, desired *mariadbv1alpha1.MariaDB) error {
+	spec, err := mergeFleetMemberSpec(&existing.Spec, &desired.Spec)
+	if err != nil {
+		return fmt.Errorf("error merging spec: %v", err)
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Spec = *spec
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	for k, v := range desired.Labels {
+		existing.Labels[k] = v
+	}
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	for k, v := range desired.Annotations {
+		existing.Annotations[k] = v
+	}
+	return r.Patch(ctx, existing, patch)
+}
+
+// mergeFleetMemberSpec merges the fields set in the template into the spec of an existing member, keeping the rest of
+// fields, such as the ones defaulted by the webhook. The primary Pod index is owned by the member, as it is updated by the
+// switchovers and failovers, so it is never overwritten.
+func mergeFleetMemberSpec(existing, desired *mariadbv1alpha1.MariaDBSpec) (*mariadbv1alpha1.MariaDBSpec, error) {
+	existingBytes, err := json.Marshal(existing)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling existing spec: %v", err)
+	}
+	desiredBytes, err := json.Marshal(desired)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling desired spec: %v", err)
+	}
+	mergedBytes, err := jsonpatch.MergePatch(existingBytes, desiredBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error merging spec: %v", err)
+	}
+	var merged mariadbv1alpha1.MariaDBSpec
+	if err := json.Unmarshal(mergedBytes, &merged); err != nil {
+		return nil, fmt.Errorf("error unmarshaling merged spec: %v", err)
+	}
+
+	if existing.Replication != nil && existing.Replication.Primary != nil && existing.Replication.Primary.PodIndex != nil &&
+		merged.Replication != nil {
+		if merged.Replication.Primary == nil {
+			merged.Replication.Primary = &mariadbv1alpha1.PrimaryReplication{}
+		}
+		merged.Replication.Primary.PodIndex = ptr.To(*existing.Replication.Primary.PodIndex)
+	}
+	if existing.Galera != nil && existing.Galera.Primary != nil && existing.Galera.Primary.PodIndex != nil &&
+		merged.Galera != nil {
+		if merged.Galera.Primary == nil {
+			merged.Galera.Primary = &mariadbv1alpha1.PrimaryGalera{}
+		}
+		merged.Galera.Primary.PodIndex = ptr.To(*existing.Galera.Primary.PodIndex)
+	}
+	return &merged, nil
+}
+
+func isFleetMemberUpdated(existing, desired *mariadbv1alpha1.MariaDB) bool {
+	return existing.Annotations[metadata.FleetSpecHashAnnotation] == desired.Annotations[metadata.FleetSpecHashAnnotation]
+}
+
+func readyMessage(mdb *mariadbv1alpha1.MariaDB) string {
+	if c := meta.FindStatusCondition(mdb.Status.Conditions, mariadbv1alpha1.ConditionTypeReady); c != nil {
+		return c.Message
+	}
+	return ""
+}
+
+func (r *MariaDBFleetReconciler) patchStatus(ctx context.Context, fleet *mariadbv1alpha1.MariaDBFleet,
+	patcher func(*mariadbv1alpha1.MariaDBFleetStatus)) error {
+	patch := client.MergeFrom(fleet.DeepCopy())
+	patcher(&fleet.Status)
+
+	if err := r.Client.Status().Patch(ctx, fleet, patch); err != nil {
+		return fmt.Errorf("error patching MariaDBFleet status: %v", err)
+	}
+	return nil
+}
+
+func (r *MariaDBFleetReconciler) mapNamespaceToRequests(ctx context.Context, _ client.Object) []reconcile.Request {
+	var fleets mariadbv1alpha1.MariaDBFleetList
+	if err := r.List(ctx, &fleets); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, fleet := range fleets.Items {
+		if fleet.Spec.NamespaceSelector == nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: fleet.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MariaDBFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.MariaDBFleet{}).
+		Owns(&mariadbv1alpha1.MariaDB{}).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToRequests),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
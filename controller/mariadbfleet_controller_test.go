@@ -0,0 +1,132 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MariaDBFleet controller", func() {
+	Context("When merging the template into a member", func() {
+		It("Should only overwrite the fields set in the template", func() {
+			existing := &mariadbv1alpha1.MariaDBSpec{
+				Image:    "mariadb:11.0.3",
+				Replicas: 3,
+				Port:     3306,
+				Replication: &mariadbv1alpha1.Replication{
+					Enabled: true,
+					ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+						Primary: &mariadbv1alpha1.PrimaryReplication{
+							PodIndex:          ptr.To(2),
+							AutomaticFailover: ptr.To(true),
+						},
+					},
+				},
+			}
+			desired := &mariadbv1alpha1.MariaDBSpec{
+				Image:    "mariadb:11.1.3",
+				Replicas: 3,
+				Replication: &mariadbv1alpha1.Replication{
+					Enabled: true,
+					ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+						Primary: &mariadbv1alpha1.PrimaryReplication{
+							PodIndex: ptr.To(0),
+						},
+					},
+				},
+			}
+
+			merged, err := mergeFleetMemberSpec(existing, desired)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(merged.Image).To(Equal("mariadb:11.1.3"))
+			Expect(merged.Port).To(Equal(int32(3306)))
+			Expect(merged.Replication).ToNot(BeNil())
+			Expect(merged.Replication.Primary).ToNot(BeNil())
+			Expect(merged.Replication.Primary.PodIndex).To(Equal(ptr.To(2)))
+			Expect(merged.Replication.Primary.AutomaticFailover).To(Equal(ptr.To(true)))
+		})
+	})
+
+	Context("When creating a MariaDBFleet", func() {
+		It("Should reconcile", func() {
+			By("Creating MariaDBFleet")
+			fleet := mariadbv1alpha1.MariaDBFleet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadbfleet-test",
+				},
+				Spec: mariadbv1alpha1.MariaDBFleetSpec{
+					Template: mariadbv1alpha1.MariaDBFleetTemplate{
+						Spec: mariadbv1alpha1.MariaDBSpec{
+							VolumeClaimTemplate: mariadbv1alpha1.VolumeClaimTemplate{
+								PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											"storage": resource.MustParse("100Mi"),
+										},
+									},
+									AccessModes: []corev1.PersistentVolumeAccessMode{
+										corev1.ReadWriteOnce,
+									},
+								},
+							},
+						},
+					},
+					Members: []mariadbv1alpha1.MariaDBFleetMember{
+						{
+							Name:      "mariadbfleet-test",
+							Namespace: testNamespace,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &fleet)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &fleet)).To(Succeed())
+			})
+
+			By("Expecting to create a MariaDB member")
+			memberKey := types.NamespacedName{
+				Name:      "mariadbfleet-test",
+				Namespace: testNamespace,
+			}
+			var mdb mariadbv1alpha1.MariaDB
+			Eventually(func() bool {
+				return k8sClient.Get(testCtx, memberKey, &mdb) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(mdb.Labels).To(HaveKeyWithValue(labels.FleetLabel, fleet.Name))
+			Expect(mdb.Annotations).To(HaveKey(metadata.FleetSpecHashAnnotation))
+			Expect(metav1.IsControlledBy(&mdb, &fleet)).To(BeTrue())
+
+			By("Expecting MariaDBFleet status to be aggregated")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(&fleet), &fleet); err != nil {
+					return false
+				}
+				return fleet.Status.TotalMembers == 1 && fleet.Status.UpdatedMembers == 1
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Updating MariaDBFleet template")
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(&fleet), &fleet)).To(Succeed())
+			patch := client.MergeFrom(fleet.DeepCopy())
+			fleet.Spec.Members[0].Image = ptr.To("mariadb:11.1.3")
+			fleet.Spec.RolloutStrategy.MaxUnavailable = 1
+			Expect(k8sClient.Patch(testCtx, &fleet, patch)).To(Succeed())
+
+			By("Expecting MariaDB member to be updated eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, memberKey, &mdb); err != nil {
+					return false
+				}
+				return mdb.Spec.Image == "mariadb:11.1.3"
+			}, testTimeout, testInterval).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var mariaDBTestRequeueInterval = 5 * time.Second
+
+// MariaDBTestReconciler reconciles a MariaDBTest object
+type MariaDBTestReconciler struct {
+	client.Client
+	Scheme  *runtime.Scheme
+	Builder *builder.Builder
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbtests,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbtests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbtests/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=connections,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *MariaDBTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var test mariadbv1alpha1.MariaDBTest
+	if err := r.Get(ctx, req.NamespacedName, &test); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if test.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	expiration := test.ExpirationTime()
+	if !time.Now().Before(expiration) {
+		log.FromContext(ctx).Info("MariaDBTest expired, deleting", "ttl", test.TTL())
+		// The MariaDB is garbage collected along with the MariaDBTest, and its storage is ephemeral.
+		if err := r.Delete(ctx, &test, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	var mariadb mariadbv1alpha1.MariaDB
+	if err := r.Get(ctx, test.MariaDBKey(), &mariadb); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting MariaDB: %v", err)
+		}
+		if err := r.createMariaDB(ctx, &test); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	ready, err := r.isReady(ctx, &test, &mariadb)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.patchStatus(ctx, &test, func(s *mariadbv1alpha1.MariaDBTestStatus) {
+		expirationTime := metav1.NewTime(expiration)
+		s.ExpirationTime = &expirationTime
+		if ready {
+			secretName := test.SecretKey().Name
+			s.SecretName = &secretName
+			condition.SetReadyHealthty(s)
+		} else {
+			s.SecretName = nil
+			condition.SetReadyProvisioning(s)
+		}
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	requeueAfter := time.Until(expiration)
+	if !ready && requeueAfter > mariaDBTestRequeueInterval {
+		requeueAfter = mariaDBTestRequeueInterval
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *MariaDBTestReconciler) createMariaDB(ctx context.Context, test *mariadbv1alpha1.MariaDBTest) error {
+	mariadb, err := r.Builder.BuildMariaDBTestMariaDB(test)
+	if err != nil {
+		return fmt.Errorf("error building MariaDB: %v", err)
+	}
+	log.FromContext(ctx).Info("Provisioning MariaDB", "mariadb", mariadb.Name)
+	if err := r.Create(ctx, mariadb); err != nil {
+		return fmt.Errorf("error creating MariaDB: %v", err)
+	}
+	return nil
+}
+
+// isReady checks whether the MariaDB is ready and its connection details have been published.
+func (r *MariaDBTestReconciler) isReady(ctx context.Context, test *mariadbv1alpha1.MariaDBTest,
+	mariadb *mariadbv1alpha1.MariaDB) (bool, error) {
+	if !mariadb.IsReady() {
+		return false, nil
+	}
+	var conn mariadbv1alpha1.Connection
+	if err := r.Get(ctx, mariadb.ConnectionKey(), &conn); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting Connection: %v", err)
+	}
+	return conn.IsReady(), nil
+}
+
+func (r *MariaDBTestReconciler) patchStatus(ctx context.Context, test *mariadbv1alpha1.MariaDBTest,
+	patcher func(*mariadbv1alpha1.MariaDBTestStatus)) error {
+	patch := client.MergeFrom(test.DeepCopy())
+	patcher(&test.Status)
+
+	if err := r.Client.Status().Patch(ctx, test, patch); err != nil {
+		return fmt.Errorf("error patching MariaDBTest status: %v", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MariaDBTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.MariaDBTest{}).
+		Owns(&mariadbv1alpha1.MariaDB{}).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
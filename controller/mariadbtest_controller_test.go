@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MariaDBTest controller", func() {
+	Context("When creating a MariaDBTest", func() {
+		It("Should reconcile", func() {
+			By("Creating MariaDBTest")
+			test := mariadbv1alpha1.MariaDBTest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadbtest-test",
+					Namespace: testNamespace,
+				},
+				Spec: mariadbv1alpha1.MariaDBTestSpec{
+					Database: ptr.To("app"),
+					Username: ptr.To("app"),
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &test)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(testCtx, &test))).To(Succeed())
+			})
+
+			By("Expecting MariaDBTest to have an expiration time")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(&test), &test); err != nil {
+					return false
+				}
+				return test.Status.ExpirationTime != nil
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting to create an ephemeral MariaDB")
+			var mdb mariadbv1alpha1.MariaDB
+			Eventually(func() bool {
+				return k8sClient.Get(testCtx, test.MariaDBKey(), &mdb) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(mdb.Labels).To(HaveKeyWithValue(labels.MariaDBTestLabel, test.Name))
+			Expect(metav1.IsControlledBy(&mdb, &test)).To(BeTrue())
+			Expect(mdb.Spec.Replicas).To(BeEquivalentTo(1))
+			Expect(mdb.Spec.Ephemeral).To(BeTrue())
+			Expect(mdb.Spec.Database).To(Equal(ptr.To("app")))
+			Expect(mdb.Spec.Username).To(Equal(ptr.To("app")))
+			Expect(mdb.Spec.Connection).NotTo(BeNil())
+			Expect(mdb.Spec.Connection.SecretName).To(Equal(ptr.To(test.SecretKey().Name)))
+		})
+	})
+
+	Context("When a MariaDBTest expires", func() {
+		It("Should delete it", func() {
+			By("Creating MariaDBTest")
+			test := mariadbv1alpha1.MariaDBTest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadbtest-expired-test",
+					Namespace: testNamespace,
+				},
+				Spec: mariadbv1alpha1.MariaDBTestSpec{
+					TTL: &metav1.Duration{Duration: 5 * time.Second},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &test)).To(Succeed())
+
+			By("Expecting MariaDBTest to be deleted")
+			Eventually(func() bool {
+				err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(&test), &test)
+				return apierrors.IsNotFound(err)
+			}, testTimeout, testInterval).Should(BeTrue())
+		})
+	})
+})
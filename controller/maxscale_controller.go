@@ -0,0 +1,329 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/deployment"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/service"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	"github.com/mariadb-operator/mariadb-operator/pkg/maxscale"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// maxScaleMaxUserConnections leaves room for the monitor connections of every MaxScale replica to every server.
+const maxScaleMaxUserConnections = 100
+
+var (
+	maxScaleServicePrivileges = []string{
+		"SELECT",
+	}
+	maxScaleMonitorPrivileges = []string{
+		"SHOW DATABASES",
+		"PROCESS",
+		"REPLICATION CLIENT",
+		"REPLICA MONITOR",
+		"SLAVE MONITOR",
+	}
+)
+
+// MaxScaleReconciler reconciles a MaxScale object
+type MaxScaleReconciler struct {
+	client.Client
+	Scheme               *runtime.Scheme
+	Builder              *builder.Builder
+	RefResolver          *refresolver.RefResolver
+	ConditionReady       *condition.Ready
+	Environment          *environment.Environment
+	SecretReconciler     *secret.SecretReconciler
+	ServiceReconciler    *service.ServiceReconciler
+	DeploymentReconciler *deployment.DeploymentReconciler
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=maxscales,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=maxscales/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=maxscales/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=users;grants,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=services,verbs=list;watch;create;patch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=list;watch;create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *MaxScaleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var mxs mariadbv1alpha1.MaxScale
+	if err := r.Get(ctx, req.NamespacedName, &mxs); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if err := r.setDefaults(ctx, &mxs); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error defaulting MaxScale: %v", err)
+	}
+
+	mariadb, err := r.RefResolver.MariaDB(ctx, &mxs.Spec.MariaDBRef, mxs.Namespace)
+	if err != nil {
+		var mariaDbErr *multierror.Error
+		mariaDbErr = multierror.Append(mariaDbErr, err)
+
+		err = r.patchStatus(ctx, &mxs, r.ConditionReady.PatcherRefResolver(err, mariadb))
+		mariaDbErr = multierror.Append(mariaDbErr, err)
+
+		return ctrl.Result{}, fmt.Errorf("error getting MariaDB: %v", mariaDbErr)
+	}
+
+	if mxs.Spec.MariaDBRef.WaitForIt && !mariadb.IsReady() {
+		if err := r.patchStatus(ctx, &mxs, r.ConditionReady.PatcherFailed("MariaDB not ready")); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching MaxScale: %v", err)
+		}
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if err := r.reconcilePassword(ctx, &mxs, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling password: %v", err)
+	}
+	if err := r.reconcileUser(ctx, &mxs, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling User: %v", err)
+	}
+	if result, err := r.reconcileGrants(ctx, &mxs, mariadb); !result.IsZero() || err != nil {
+		return result, err
+	}
+	config, err := r.reconcileConfig(ctx, &mxs, mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling config: %v", err)
+	}
+	if err := r.reconcileDeployment(ctx, &mxs, config); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling Deployment: %v", err)
+	}
+	if err := r.reconcileService(ctx, &mxs); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling Service: %v", err)
+	}
+	if err := r.reconcileStatus(ctx, &mxs, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling status: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *MaxScaleReconciler) setDefaults(ctx context.Context, mxs *mariadbv1alpha1.MaxScale) error {
+	patch := client.MergeFrom(mxs.DeepCopy())
+	mxs.SetDefaults(r.Environment)
+	return r.Patch(ctx, mxs, patch)
+}
+
+func (r *MaxScaleReconciler) reconcilePassword(ctx context.Context, mxs *mariadbv1alpha1.MaxScale,
+	mariadb *mariadbv1alpha1.MariaDB) error {
+	secretKeyRef := mxs.Spec.Auth.PasswordSecretKeyRef
+	key := types.NamespacedName{
+		Name:      secretKeyRef.Name,
+		Namespace: mxs.Namespace,
+	}
+	_, err := r.SecretReconciler.ReconcileRandomPasswordWithOwner(ctx, key, secretKeyRef.Key, mariadb, mxs)
+	return err
+}
+
+func (r *MaxScaleReconciler) reconcileUser(ctx context.Context, mxs *mariadbv1alpha1.MaxScale,
+	mariadb *mariadbv1alpha1.MariaDB) error {
+	key := client.ObjectKeyFromObject(mxs)
+	var existingUser mariadbv1alpha1.User
+	if err := r.Get(ctx, key, &existingUser); err == nil {
+		return nil
+	}
+
+	opts := builder.UserOpts{
+		Key:                  key,
+		PasswordSecretKeyRef: mxs.Spec.Auth.PasswordSecretKeyRef,
+		MaxUserConnections:   maxScaleMaxUserConnections,
+		Name:                 mxs.Spec.Auth.Username,
+		Owner:                mxs,
+	}
+	user, err := r.Builder.BuildUser(mariadb, opts)
+	if err != nil {
+		return fmt.Errorf("error building User: %v", err)
+	}
+	return r.Create(ctx, user)
+}
+
+func (r *MaxScaleReconciler) reconcileGrants(ctx context.Context, mxs *mariadbv1alpha1.MaxScale,
+	mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	var user mariadbv1alpha1.User
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mxs), &user); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("error getting MaxScale User: %v", err)
+	}
+	if !user.IsReady() {
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+
+	grants := []builder.GrantOpts{
+		{
+			Key:        mxs.ServiceGrantKey(),
+			Privileges: maxScaleServicePrivileges,
+			Database:   "mysql",
+			Table:      "*",
+		},
+		{
+			Key:        mxs.MonitorGrantKey(),
+			Privileges: maxScaleMonitorPrivileges,
+			Database:   "*",
+			Table:      "*",
+		},
+	}
+	for _, opts := range grants {
+		var existingGrant mariadbv1alpha1.Grant
+		if err := r.Get(ctx, opts.Key, &existingGrant); err == nil {
+			continue
+		}
+		opts.Username = mxs.Spec.Auth.Username
+		opts.Owner = mxs
+
+		grant, err := r.Builder.BuildGrant(mariadb, opts)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error building MaxScale Grant: %v", err)
+		}
+		if err := r.Create(ctx, grant); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating MaxScale Grant: %v", err)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *MaxScaleReconciler) reconcileConfig(ctx context.Context, mxs *mariadbv1alpha1.MaxScale,
+	mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	configMapKeyRef := mxs.ConfigMapKeyRef()
+	config := maxscale.Config(mxs, mariadb)
+	opts := builder.ConfigMapOpts{
+		MariaDB: mariadb,
+		Key: types.NamespacedName{
+			Name:      configMapKeyRef.Name,
+			Namespace: mxs.Namespace,
+		},
+		Data: map[string]string{
+			configMapKeyRef.Key: config,
+		},
+	}
+	desiredConfigMap, err := r.Builder.BuildConfigMap(opts, mxs)
+	if err != nil {
+		return "", fmt.Errorf("error building ConfigMap: %v", err)
+	}
+
+	var existingConfigMap corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desiredConfigMap), &existingConfigMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("error getting ConfigMap: %v", err)
+		}
+		if err := r.Create(ctx, desiredConfigMap); err != nil {
+			return "", fmt.Errorf("error creating ConfigMap: %v", err)
+		}
+		return config, nil
+	}
+
+	patch := client.MergeFrom(existingConfigMap.DeepCopy())
+	existingConfigMap.Data = desiredConfigMap.Data
+	if err := r.Patch(ctx, &existingConfigMap, patch); err != nil {
+		return "", fmt.Errorf("error patching ConfigMap: %v", err)
+	}
+	return config, nil
+}
+
+func (r *MaxScaleReconciler) reconcileDeployment(ctx context.Context, mxs *mariadbv1alpha1.MaxScale, config string) error {
+	// The config is only read on startup, the Pods are rolled out when it changes.
+	podAnnotations := map[string]string{
+		metadata.ConfigChecksumAnnotation: fmt.Sprintf("%x", sha256.Sum256([]byte(config))),
+	}
+	desiredDeploy, err := r.Builder.BuildMaxScaleDeployment(mxs, client.ObjectKeyFromObject(mxs), podAnnotations)
+	if err != nil {
+		return fmt.Errorf("error building Deployment: %v", err)
+	}
+	return r.DeploymentReconciler.Reconcile(ctx, desiredDeploy)
+}
+
+func (r *MaxScaleReconciler) reconcileService(ctx context.Context, mxs *mariadbv1alpha1.MaxScale) error {
+	desiredSvc, err := r.Builder.BuildMaxScaleService(mxs, client.ObjectKeyFromObject(mxs))
+	if err != nil {
+		return fmt.Errorf("error building Service: %v", err)
+	}
+	return r.ServiceReconciler.Reconcile(ctx, desiredSvc)
+}
+
+func (r *MaxScaleReconciler) reconcileStatus(ctx context.Context, mxs *mariadbv1alpha1.MaxScale,
+	mariadb *mariadbv1alpha1.MariaDB) error {
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mxs), &deploy); err != nil {
+		return fmt.Errorf("error getting Deployment: %v", err)
+	}
+	return r.patchStatus(ctx, mxs, func(c condition.Conditioner) {
+		mxs.Status.Replicas = deploy.Status.ReadyReplicas
+		mxs.Status.PrimaryServer = mariadb.Status.CurrentPrimary
+		condition.SetReadyWithDeployment(c, &deploy)
+	})
+}
+
+func (r *MaxScaleReconciler) patchStatus(ctx context.Context, mxs *mariadbv1alpha1.MaxScale,
+	patcher condition.Patcher) error {
+	patch := client.MergeFrom(mxs.DeepCopy())
+	patcher(&mxs.Status)
+
+	if err := r.Client.Status().Patch(ctx, mxs, patch); err != nil {
+		return fmt.Errorf("error patching MaxScale status: %v", err)
+	}
+	return nil
+}
+
+// mapMariaDBToRequests enqueues the MaxScales routing connections to a MariaDB, so topology changes are propagated.
+func (r *MaxScaleReconciler) mapMariaDBToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	var maxscales mariadbv1alpha1.MaxScaleList
+	if err := r.List(ctx, &maxscales); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, mxs := range maxscales.Items {
+		namespace := mxs.Spec.MariaDBRef.Namespace
+		if namespace == "" {
+			namespace = mxs.Namespace
+		}
+		if mxs.Spec.MariaDBRef.Name == obj.GetName() && namespace == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&mxs),
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MaxScaleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.MaxScale{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Secret{}).
+		Owns(&mariadbv1alpha1.User{}).
+		Owns(&mariadbv1alpha1.Grant{}).
+		Watches(
+			&mariadbv1alpha1.MariaDB{},
+			handler.EnqueueRequestsFromMapFunc(r.mapMariaDBToRequests),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
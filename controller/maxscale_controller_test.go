@@ -0,0 +1,98 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MaxScale controller", func() {
+	Context("When creating a MaxScale", func() {
+		It("Should reconcile", func() {
+			By("Creating MaxScale")
+			mxs := mariadbv1alpha1.MaxScale{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "maxscale-test",
+					Namespace: testNamespace,
+				},
+				Spec: mariadbv1alpha1.MaxScaleSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbName,
+						},
+						WaitForIt: true,
+					},
+					Replicas: 2,
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &mxs)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(testCtx, &mxs))).To(Succeed())
+			})
+
+			By("Expecting MaxScale to be defaulted")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(&mxs), &mxs); err != nil {
+					return false
+				}
+				return len(mxs.Spec.Services) > 0
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(mxs.Spec.Image).NotTo(BeEmpty())
+			Expect(mxs.Spec.Auth.Username).To(Equal(mxs.Name))
+			Expect(mxs.Spec.Auth.PasswordSecretKeyRef).To(Equal(mxs.AuthPasswordSecretKeyRef()))
+
+			By("Expecting to create a User")
+			var user mariadbv1alpha1.User
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(&mxs), &user); err != nil {
+					return false
+				}
+				return user.IsReady()
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(metav1.IsControlledBy(&user, &mxs)).To(BeTrue())
+
+			By("Expecting to create Grants")
+			for _, key := range []types.NamespacedName{mxs.ServiceGrantKey(), mxs.MonitorGrantKey()} {
+				var grant mariadbv1alpha1.Grant
+				Eventually(func() bool {
+					if err := k8sClient.Get(testCtx, key, &grant); err != nil {
+						return false
+					}
+					return grant.IsReady()
+				}, testTimeout, testInterval).Should(BeTrue())
+			}
+
+			By("Expecting to create a config ConfigMap")
+			var configMap corev1.ConfigMap
+			configMapKey := types.NamespacedName{
+				Name:      mxs.ConfigMapKeyRef().Name,
+				Namespace: mxs.Namespace,
+			}
+			Eventually(func() bool {
+				return k8sClient.Get(testCtx, configMapKey, &configMap) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(configMap.Data).To(HaveKey(mxs.ConfigMapKeyRef().Key))
+
+			By("Expecting to create a Deployment")
+			var deploy appsv1.Deployment
+			Eventually(func() bool {
+				return k8sClient.Get(testCtx, client.ObjectKeyFromObject(&mxs), &deploy) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(deploy.Spec.Replicas).To(Equal(&mxs.Spec.Replicas))
+			Expect(deploy.Spec.Template.Annotations).To(HaveKey(metadata.ConfigChecksumAnnotation))
+
+			By("Expecting to create a Service")
+			var svc corev1.Service
+			Eventually(func() bool {
+				return k8sClient.Get(testCtx, client.ObjectKeyFromObject(&mxs), &svc) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(svc.Spec.Ports).To(HaveLen(len(mxs.Spec.Services)))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	mariadbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
@@ -16,8 +17,8 @@ import (
 )
 
 type PodReadinessController interface {
-	ReconcilePodReady(context.Context, corev1.Pod, *mariadbv1alpha1.MariaDB) error
-	ReconcilePodNotReady(context.Context, corev1.Pod, *mariadbv1alpha1.MariaDB) error
+	ReconcilePodReady(context.Context, corev1.Pod, *mariadbv1alpha1.MariaDB) (ctrl.Result, error)
+	ReconcilePodNotReady(context.Context, corev1.Pod, *mariadbv1alpha1.MariaDB) (ctrl.Result, error)
 }
 
 // PodController reconciles a Pod object
@@ -57,15 +58,17 @@ func (r *PodController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	}
 
 	if mariadbpod.PodReady(&pod) {
-		if err := r.podReadinessController.ReconcilePodReady(ctx, pod, mariadb); err != nil {
+		result, err := r.podReadinessController.ReconcilePodReady(ctx, pod, mariadb)
+		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("error reconciling Pod '%s' in Ready state: %v", pod.Name, err)
 		}
-	} else {
-		if err := r.podReadinessController.ReconcilePodNotReady(ctx, pod, mariadb); err != nil {
-			return ctrl.Result{}, fmt.Errorf("error reconciling Pod '%s' in non Ready state: %v", pod.Name, err)
-		}
+		return result, nil
+	}
+	result, err := r.podReadinessController.ReconcilePodNotReady(ctx, pod, mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling Pod '%s' in non Ready state: %v", pod.Name, err)
 	}
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -78,7 +81,7 @@ func (r *PodController) SetupWithManager(mgr ctrl.Manager) error {
 				podHasChanged,
 			),
 		).
-		Complete(r)
+		Complete(priority.NewReconciler(priority.PriorityCritical, r))
 }
 
 func podHasChanged(old, new client.Object) bool {
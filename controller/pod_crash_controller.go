@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/configmap"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	crashErrorLogKey    = "error.log"
+	crashTerminationKey = "termination"
+	crashStateKey       = "state"
+)
+
+// PodCrashController captures diagnostics when the MariaDB container crashes.
+type PodCrashController struct {
+	client.Client
+	kubeClient          kubernetes.Interface
+	refResolver         *refresolver.RefResolver
+	configMapReconciler *configmap.ConfigMapReconciler
+	recorder            record.EventRecorder
+	sqlOpts             []sqlClient.Opt
+}
+
+func NewPodCrashController(client client.Client, kubeClient kubernetes.Interface, refResolver *refresolver.RefResolver,
+	configMapReconciler *configmap.ConfigMapReconciler, recorder record.EventRecorder, sqlOpts ...sqlClient.Opt) *PodCrashController {
+	return &PodCrashController{
+		Client:              client,
+		kubeClient:          kubeClient,
+		refResolver:         refResolver,
+		configMapReconciler: configMapReconciler,
+		recorder:            recorder,
+		sqlOpts:             sqlOpts,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=list;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PodCrashController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	mariadb, err := r.refResolver.MariaDBFromAnnotation(ctx, pod.ObjectMeta)
+	if err != nil {
+		if errors.Is(err, refresolver.ErrMariaDBAnnotationNotFound) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mariadb.IsCrashDiagnosticsEnabled() {
+		return ctrl.Result{}, nil
+	}
+
+	status := mariadbContainerStatus(&pod)
+	if status == nil || status.RestartCount == 0 || status.LastTerminationState.Terminated == nil {
+		return ctrl.Result{}, nil
+	}
+	terminated := status.LastTerminationState.Terminated
+	if terminated.ExitCode == 0 {
+		return ctrl.Result{}, nil
+	}
+	key := mariadb.CrashDiagnosticsKey(pod.Name, status.RestartCount)
+
+	var existingConfigMap corev1.ConfigMap
+	if err := r.Get(ctx, key, &existingConfigMap); err == nil {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx)
+	logger.Info("MariaDB container crashed. Capturing diagnostics", "pod", pod.Name, "restarts", status.RestartCount)
+
+	configMapReq := configmap.ReconcileRequest{
+		Mariadb: mariadb,
+		Owner:   mariadb,
+		Key:     key,
+		Data: map[string]string{
+			crashErrorLogKey:    r.errorLogTail(ctx, &pod, mariadb),
+			crashTerminationKey: terminationDiagnostics(status),
+			crashStateKey:       r.stateDiagnostics(ctx, &pod, mariadb),
+		},
+	}
+	if err := r.configMapReconciler.Reconcile(ctx, &configMapReq); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling crash diagnostics ConfigMap: %v", err)
+	}
+	if err := r.pruneDiagnostics(ctx, &pod, mariadb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error pruning crash diagnostics ConfigMaps: %v", err)
+	}
+
+	r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonMariaDBCrashed,
+		"Container '%s' in Pod '%s' crashed with exit code %d. Diagnostics captured in ConfigMap '%s'",
+		builder.MariaDbContainerName, pod.Name, terminated.ExitCode, key.Name)
+	return ctrl.Result{}, nil
+}
+
+// pruneDiagnostics deletes the oldest diagnostics ConfigMaps of the Pod, keeping the last 'spec.crashDiagnostics.historyLimit'.
+func (r *PodCrashController) pruneDiagnostics(ctx context.Context, pod *corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) error {
+	var configMapList corev1.ConfigMapList
+	if err := r.List(ctx, &configMapList, client.InNamespace(mariadb.Namespace)); err != nil {
+		return fmt.Errorf("error listing ConfigMaps: %v", err)
+	}
+	limit := mariadb.Spec.CrashDiagnostics.HistoryLimitOrDefault()
+	for _, cm := range staleCrashDiagnostics(configMapList.Items, mariadb, pod.Name, limit) {
+		log.FromContext(ctx).V(1).Info("Deleting crash diagnostics ConfigMap", "configmap", cm.Name)
+		if err := r.Delete(ctx, &cm); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("error deleting ConfigMap '%s': %v", cm.Name, err)
+		}
+	}
+	return nil
+}
+
+// staleCrashDiagnostics returns the diagnostics ConfigMaps of the Pod that exceed the limit, the ones with the lowest restart count.
+func staleCrashDiagnostics(configMaps []corev1.ConfigMap, mariadb *mariadbv1alpha1.MariaDB, podName string,
+	limit int) []corev1.ConfigMap {
+	prefix := podName + "-crash-"
+	restarts := make(map[string]int)
+	var diagnostics []corev1.ConfigMap
+	for _, cm := range configMaps {
+		if !strings.HasPrefix(cm.Name, prefix) || !metav1.IsControlledBy(&cm, mariadb) {
+			continue
+		}
+		restartCount, err := strconv.Atoi(strings.TrimPrefix(cm.Name, prefix))
+		if err != nil {
+			continue
+		}
+		restarts[cm.Name] = restartCount
+		diagnostics = append(diagnostics, cm)
+	}
+	if len(diagnostics) <= limit {
+		return nil
+	}
+	sort.Slice(diagnostics, func(i, j int) bool {
+		return restarts[diagnostics[i].Name] < restarts[diagnostics[j].Name]
+	})
+	return diagnostics[:len(diagnostics)-limit]
+}
+
+func (r *PodCrashController) errorLogTail(ctx context.Context, pod *corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) string {
+	tailLines := mariadb.Spec.CrashDiagnostics.LogTailLinesOrDefault()
+	logReq := r.kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: builder.MariaDbContainerName,
+		Previous:  true,
+		TailLines: &tailLines,
+	})
+	stream, err := logReq.Stream(ctx)
+	if err != nil {
+		return fmt.Sprintf("error getting logs: %v", err)
+	}
+	defer stream.Close()
+
+	bytes, err := io.ReadAll(stream)
+	if err != nil {
+		return fmt.Sprintf("error reading logs: %v", err)
+	}
+	return string(bytes)
+}
+
+func (r *PodCrashController) stateDiagnostics(ctx context.Context, pod *corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) string {
+	var b strings.Builder
+	if mariadb.Status.CurrentPrimary != nil {
+		fmt.Fprintf(&b, "currentPrimary: %s\n", *mariadb.Status.CurrentPrimary)
+	}
+	if mariadb.Galera().Enabled {
+		if state, ok := mariadb.Status.GaleraNodeStates[pod.Name]; ok {
+			fmt.Fprintf(&b, "galeraNodeState: %s\n", state)
+		}
+		if mariadb.Status.GaleraRecovery != nil {
+			fmt.Fprintf(&b, "galeraRecoveryInProgress: true\n")
+		}
+	}
+
+	if mariadb.Status.CurrentPrimaryPodIndex == nil ||
+		statefulset.PodName(mariadb.ObjectMeta, *mariadb.Status.CurrentPrimaryPodIndex) == pod.Name {
+		return b.String()
+	}
+	mdbClient, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.refResolver, *mariadb.Status.CurrentPrimaryPodIndex,
+		append(slices.Clip(r.sqlOpts), sqlClient.WithTimeout(5*time.Second))...)
+	if err != nil {
+		fmt.Fprintf(&b, "error connecting to primary: %v\n", err)
+		return b.String()
+	}
+	defer mdbClient.Close()
+
+	var vars []string
+	var getter func(context.Context, string) (string, error)
+	if mariadb.Galera().Enabled {
+		vars = []string{"wsrep_cluster_size", "wsrep_cluster_status", "wsrep_local_state_comment", "wsrep_last_committed"}
+		getter = mdbClient.StatusVariable
+	} else {
+		vars = []string{"gtid_binlog_pos", "gtid_current_pos", "gtid_slave_pos"}
+		getter = mdbClient.SystemVariable
+	}
+	for _, v := range vars {
+		val, err := getter(ctx, v)
+		if err != nil {
+			fmt.Fprintf(&b, "primary.%s: error: %v\n", v, err)
+			continue
+		}
+		fmt.Fprintf(&b, "primary.%s: %s\n", v, val)
+	}
+	return b.String()
+}
+
+func terminationDiagnostics(status *corev1.ContainerStatus) string {
+	terminated := status.LastTerminationState.Terminated
+	var b strings.Builder
+	fmt.Fprintf(&b, "restartCount: %d\n", status.RestartCount)
+	fmt.Fprintf(&b, "exitCode: %d\n", terminated.ExitCode)
+	fmt.Fprintf(&b, "reason: %s\n", terminated.Reason)
+	if terminated.Message != "" {
+		fmt.Fprintf(&b, "message: %s\n", terminated.Message)
+	}
+	fmt.Fprintf(&b, "startedAt: %s\n", terminated.StartedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "finishedAt: %s\n", terminated.FinishedAt.UTC().Format(time.RFC3339))
+
+	signal := terminated.Signal
+	if signal == 0 && terminated.ExitCode > 128 {
+		signal = terminated.ExitCode - 128
+	}
+	if signal != 0 {
+		fmt.Fprintf(&b, "signal: %d\n", signal)
+		fmt.Fprintf(&b, "coreDump: %t\n", isCoreDumpSignal(signal))
+	}
+	return b.String()
+}
+
+// isCoreDumpSignal returns whether the default action of the signal is to produce a core dump.
+func isCoreDumpSignal(signal int32) bool {
+	switch signal {
+	case 3, 4, 5, 6, 7, 8, 11, 24, 25, 31:
+		return true
+	default:
+		return false
+	}
+}
+
+func mariadbContainerStatus(pod *corev1.Pod) *corev1.ContainerStatus {
+	for i, s := range pod.Status.ContainerStatuses {
+		if s.Name == builder.MariaDbContainerName {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodCrashController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod-crash").
+		For(&corev1.Pod{}).
+		WithEventFilter(
+			predicate.PredicateChangedWithAnnotations(
+				[]string{
+					metadata.MariadbAnnotation,
+				},
+				mariadbRestartCountHasChanged,
+			),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
+
+func mariadbRestartCountHasChanged(old, new client.Object) bool {
+	oldPod, ok := old.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	newPod, ok := new.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	oldStatus := mariadbContainerStatus(oldPod)
+	newStatus := mariadbContainerStatus(newPod)
+	if newStatus == nil {
+		return false
+	}
+	return oldStatus == nil || oldStatus.RestartCount != newStatus.RestartCount
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("PodCrash controller", func() {
+	Context("When pruning the crash diagnostics", func() {
+		mariadb := &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-crash",
+				Namespace: testNamespace,
+				UID:       types.UID("mariadb-crash-uid"),
+			},
+		}
+		newConfigMap := func(name string, owner types.UID) corev1.ConfigMap {
+			return corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: mariadbv1alpha1.GroupVersion.String(),
+							Kind:       "MariaDB",
+							Name:       mariadb.Name,
+							UID:        owner,
+							Controller: ptr.To(true),
+						},
+					},
+				},
+			}
+		}
+		names := func(configMaps []corev1.ConfigMap) []string {
+			var names []string
+			for _, cm := range configMaps {
+				names = append(names, cm.Name)
+			}
+			return names
+		}
+
+		It("Should delete the oldest ConfigMaps of the Pod exceeding the limit", func() {
+			configMaps := []corev1.ConfigMap{
+				newConfigMap("mariadb-crash-0-crash-10", mariadb.UID),
+				newConfigMap("mariadb-crash-0-crash-2", mariadb.UID),
+				newConfigMap("mariadb-crash-0-crash-7", mariadb.UID),
+				newConfigMap("mariadb-crash-0-crash-1", mariadb.UID),
+				newConfigMap("mariadb-crash-1-crash-1", mariadb.UID),
+				newConfigMap("mariadb-crash-0-crash-3", types.UID("another-mariadb-uid")),
+				newConfigMap("mariadb-crash-0-crash-config", mariadb.UID),
+			}
+			Expect(names(staleCrashDiagnostics(configMaps, mariadb, "mariadb-crash-0", 2))).
+				To(Equal([]string{"mariadb-crash-0-crash-1", "mariadb-crash-0-crash-2"}))
+		})
+
+		It("Should not delete anything within the limit", func() {
+			configMaps := []corev1.ConfigMap{
+				newConfigMap("mariadb-crash-0-crash-1", mariadb.UID),
+				newConfigMap("mariadb-crash-0-crash-2", mariadb.UID),
+			}
+			Expect(staleCrashDiagnostics(configMaps, mariadb, "mariadb-crash-0", 3)).To(BeEmpty())
+		})
+	})
+})
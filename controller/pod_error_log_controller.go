@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const errorLogTailLines int64 = 100
+
+// PodErrorLogController reports the root cause of MariaDB containers failing to become ready.
+// The root cause is read from the error log and attached to an Event and to the PodFailed condition of the MariaDB.
+type PodErrorLogController struct {
+	client.Client
+	kubeClient  kubernetes.Interface
+	refResolver *refresolver.RefResolver
+	recorder    record.EventRecorder
+}
+
+func NewPodErrorLogController(client client.Client, kubeClient kubernetes.Interface, refResolver *refresolver.RefResolver,
+	recorder record.EventRecorder) *PodErrorLogController {
+	return &PodErrorLogController{
+		Client:      client,
+		kubeClient:  kubeClient,
+		refResolver: refResolver,
+		recorder:    recorder,
+	}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PodErrorLogController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var p corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &p); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	mariadb, err := r.refResolver.MariaDBFromAnnotation(ctx, p.ObjectMeta)
+	if err != nil {
+		if errors.Is(err, refresolver.ErrMariaDBAnnotationNotFound) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := mariadbContainerStatus(&p)
+	if status == nil {
+		return ctrl.Result{}, nil
+	}
+	if status.Ready {
+		return ctrl.Result{}, r.clearPodFailed(ctx, mariadb, &p)
+	}
+
+	previous := status.RestartCount > 0 && status.LastTerminationState.Terminated != nil
+	if !previous && status.State.Running == nil {
+		return ctrl.Result{}, nil
+	}
+	logs, err := mariadbContainerLogs(ctx, r.kubeClient, &p, previous, errorLogTailLines)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to read error log", "pod", p.Name, "err", err)
+		return ctrl.Result{}, nil
+	}
+	rootCause := pod.ErrorLogRootCause(logs)
+	if rootCause == "" {
+		return ctrl.Result{}, nil
+	}
+	msg := podFailedMessage(&p, rootCause)
+
+	current := meta.FindStatusCondition(mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypePodFailed)
+	if current != nil && current.Message == msg {
+		return ctrl.Result{}, nil
+	}
+	log.FromContext(ctx).Info("MariaDB container not ready", "pod", p.Name, "rootCause", rootCause)
+	r.recorder.Event(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonMariaDBNotReady, msg)
+
+	patch := client.MergeFrom(mariadb.DeepCopy())
+	mariadb.Status.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypePodFailed,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonErrorLog,
+		Message: msg,
+	})
+	if err := r.Status().Patch(ctx, mariadb, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching MariaDB status: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// mariadbContainerLogs returns the last lines logged by the MariaDB container, either the current or the previous one.
+func mariadbContainerLogs(ctx context.Context, kubeClient kubernetes.Interface, p *corev1.Pod, previous bool,
+	tailLines int64) (string, error) {
+	logReq := kubeClient.CoreV1().Pods(p.Namespace).GetLogs(p.Name, &corev1.PodLogOptions{
+		Container: builder.MariaDbContainerName,
+		Previous:  previous,
+		TailLines: &tailLines,
+	})
+	stream, err := logReq.Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error getting logs: %v", err)
+	}
+	defer stream.Close()
+
+	bytes, err := io.ReadAll(stream)
+	if err != nil {
+		return "", fmt.Errorf("error reading logs: %v", err)
+	}
+	return string(bytes), nil
+}
+
+func (r *PodErrorLogController) clearPodFailed(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, p *corev1.Pod) error {
+	current := meta.FindStatusCondition(mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypePodFailed)
+	if current == nil || !strings.HasPrefix(current.Message, podFailedPrefix(p)) {
+		return nil
+	}
+	patch := client.MergeFrom(mariadb.DeepCopy())
+	meta.RemoveStatusCondition(&mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypePodFailed)
+	if err := r.Status().Patch(ctx, mariadb, patch); err != nil {
+		return fmt.Errorf("error patching MariaDB status: %v", err)
+	}
+	return nil
+}
+
+func podFailedPrefix(p *corev1.Pod) string {
+	return fmt.Sprintf("Pod '%s'", p.Name)
+}
+
+func podFailedMessage(p *corev1.Pod, rootCause string) string {
+	return fmt.Sprintf("%s not ready: %s", podFailedPrefix(p), rootCause)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodErrorLogController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod-error-log").
+		For(&corev1.Pod{}).
+		WithEventFilter(
+			predicate.PredicateChangedWithAnnotations(
+				[]string{
+					metadata.MariadbAnnotation,
+				},
+				mariadbReadinessHasChanged,
+			),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
+
+func mariadbReadinessHasChanged(old, new client.Object) bool {
+	if mariadbRestartCountHasChanged(old, new) {
+		return true
+	}
+	oldPod, ok := old.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	newPod, ok := new.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	oldStatus := mariadbContainerStatus(oldPod)
+	newStatus := mariadbContainerStatus(newPod)
+	if newStatus == nil {
+		return false
+	}
+	return oldStatus == nil || oldStatus.Ready != newStatus.Ready
+}
@@ -6,12 +6,14 @@ import (
 	"fmt"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/health"
 	mdbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -20,21 +22,23 @@ import (
 type PodGaleraController struct {
 	client.Client
 	recorder record.EventRecorder
+	limiter  *ratelimit.ActionLimiter
 }
 
-func NewPodGaleraController(client client.Client, recorder record.EventRecorder) PodReadinessController {
+func NewPodGaleraController(client client.Client, recorder record.EventRecorder, limiter *ratelimit.ActionLimiter) PodReadinessController {
 	return &PodGaleraController{
 		Client:   client,
 		recorder: recorder,
+		limiter:  limiter,
 	}
 }
 
-func (r *PodGaleraController) ReconcilePodReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) error {
+func (r *PodGaleraController) ReconcilePodReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	if !r.shouldReconcile(mariadb) || !*mariadb.Galera().Primary.AutomaticFailover {
-		return nil
+		return ctrl.Result{}, nil
 	}
 	if mariadb.Status.CurrentPrimaryPodIndex == nil {
-		return errors.New("'status.currentPrimaryPodIndex' must be set")
+		return ctrl.Result{}, errors.New("'status.currentPrimaryPodIndex' must be set")
 	}
 	logger := log.FromContext(ctx)
 	logger.V(1).Info("Reconciling Pod in Ready state", "pod", pod.Name)
@@ -45,74 +49,91 @@ func (r *PodGaleraController) ReconcilePodReady(ctx context.Context, pod corev1.
 	}
 	var currentPrimaryPod corev1.Pod
 	if err := r.Get(ctx, currentPrimaryPodKey, &currentPrimaryPod); err != nil {
-		return fmt.Errorf("error getting current primary Pod: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error getting current primary Pod: %v", err)
 	}
 	if mdbpod.PodReady(&currentPrimaryPod) {
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	fromIndex := mariadb.Status.CurrentPrimaryPodIndex
 	toIndex, err := statefulset.PodIndex(pod.Name)
 	if err != nil {
-		return fmt.Errorf("error getting Pod index: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error getting Pod index: %v", err)
 	}
 	if *fromIndex == *toIndex {
-		return nil
+		return ctrl.Result{}, nil
+	}
+
+	allowed, retryAfter, err := r.limiter.Allow(ctx, mariadb, mariadbv1alpha1.DisruptiveActionFailover, currentPrimaryPod.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error checking action rate limit: %v", err)
+	}
+	if !allowed {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
 	}
 
 	logger.Info("Switching primary", "from-index", *fromIndex, "to-index", *toIndex)
 	if err := r.patch(ctx, mariadb, func(mdb *mariadbv1alpha1.MariaDB) {
 		mdb.Galera().Primary.PodIndex = toIndex
 	}); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	logger.Info("Switching primary", "from-index", *fromIndex, "to-index", *toIndex)
 	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPrimarySwitching,
 		"Switching primary from index '%d' to index '%d'", *fromIndex, *toIndex)
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
-func (r *PodGaleraController) ReconcilePodNotReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) error {
+func (r *PodGaleraController) ReconcilePodNotReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	if !r.shouldReconcile(mariadb) || !*mariadb.Galera().Primary.AutomaticFailover {
-		return nil
+		return ctrl.Result{}, nil
 	}
 	if mariadb.Status.CurrentPrimaryPodIndex == nil {
-		return errors.New("'status.currentPrimaryPodIndex' must be set")
+		return ctrl.Result{}, errors.New("'status.currentPrimaryPodIndex' must be set")
 	}
 	logger := log.FromContext(ctx)
 	logger.V(1).Info("Reconciling Pod in non Ready state", "pod", pod.Name)
 
 	index, err := statefulset.PodIndex(pod.Name)
 	if err != nil {
-		return fmt.Errorf("error getting Pod index: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error getting Pod index: %v", err)
 	}
 	if *index != *mariadb.Status.CurrentPrimaryPodIndex {
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	fromIndex := mariadb.Status.CurrentPrimaryPodIndex
 	toIndex, err := health.HealthyReplica(ctx, r, mariadb)
 	if err != nil {
-		return fmt.Errorf("error getting healthy replica: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error getting healthy replica: %v", err)
+	}
+
+	allowed, retryAfter, err := r.limiter.Allow(ctx, mariadb, mariadbv1alpha1.DisruptiveActionFailover, pod.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error checking action rate limit: %v", err)
+	}
+	if !allowed {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
 	}
 
 	if err := r.patch(ctx, mariadb, func(mdb *mariadbv1alpha1.MariaDB) {
 		mdb.Galera().Primary.PodIndex = toIndex
 	}); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	logger.Info("Switching primary", "from-index", *fromIndex, "to-index", *toIndex)
 	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPrimarySwitching,
 		"Switching primary from index '%d' to index '%d'", *fromIndex, *toIndex)
 
-	return nil
+	return ctrl.Result{}, nil
 }
 
 func (r *PodGaleraController) shouldReconcile(mariadb *mariadbv1alpha1.MariaDB) bool {
-	return mariadb.Galera().Enabled && mariadb.HasGaleraConfiguredCondition() && !mariadb.IsRestoringBackup()
+	return mariadb.Galera().Enabled && mariadb.HasGaleraConfiguredCondition() && !mariadb.IsRestoringBackup() &&
+		!mariadb.IsHibernated()
 }
 
 func (r *PodGaleraController) patch(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PodGaleraSegmentController annotates the Galera Pods with the segment derived from the topology of the Node where they are scheduled.
+// The annotation is exposed to the MariaDB container via the downward API and used as gmcast.segment.
+type PodGaleraSegmentController struct {
+	client.Client
+	refResolver *refresolver.RefResolver
+}
+
+func NewPodGaleraSegmentController(client client.Client, refResolver *refresolver.RefResolver) *PodGaleraSegmentController {
+	return &PodGaleraSegmentController{
+		Client:      client,
+		refResolver: refResolver,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PodGaleraSegmentController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if pod.Spec.NodeName == "" {
+		return ctrl.Result{}, nil
+	}
+
+	mariadb, err := r.refResolver.MariaDBFromAnnotation(ctx, pod.ObjectMeta)
+	if err != nil {
+		if errors.Is(err, refresolver.ErrMariaDBAnnotationNotFound) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mariadb.IsGaleraSegmentEnabled() {
+		return ctrl.Result{}, nil
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, &node); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting Node '%s': %v", pod.Spec.NodeName, err)
+	}
+	segmentSpec := mariadb.Galera().Segment
+	topology := node.Labels[segmentSpec.TopologyKeyOrDefault()]
+	segment := strconv.Itoa(int(segmentSpec.SegmentForTopology(topology)))
+
+	if pod.Annotations[metadata.GaleraSegmentAnnotation] == segment {
+		return ctrl.Result{}, nil
+	}
+	log.FromContext(ctx).V(1).Info("Setting Galera segment", "pod", pod.Name, "node", node.Name, "topology", topology, "segment", segment)
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[metadata.GaleraSegmentAnnotation] = segment
+	if err := r.Patch(ctx, &pod, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching Galera segment: %v", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodGaleraSegmentController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod-galera-segment").
+		For(&corev1.Pod{}).
+		WithEventFilter(
+			predicate.PredicateChangedWithAnnotations(
+				[]string{
+					metadata.MariadbAnnotation,
+					metadata.GaleraAnnotation,
+				},
+				galeraSegmentHasChanged,
+			),
+		).
+		Complete(priority.NewReconciler(priority.PriorityCritical, r))
+}
+
+func galeraSegmentHasChanged(old, new client.Object) bool {
+	oldPod, ok := old.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	newPod, ok := new.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	return oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+		old.GetAnnotations()[metadata.GaleraSegmentAnnotation] != new.GetAnnotations()[metadata.GaleraSegmentAnnotation]
+}
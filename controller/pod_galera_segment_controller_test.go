@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"strconv"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("PodGaleraSegment controller", func() {
+	Context("When creating a MariaDB Galera with segments", func() {
+		It("Should annotate the Pods with the segment of their Node", func() {
+			topologyKey := "kubernetes.io/hostname"
+
+			By("Listing Nodes")
+			var nodeList corev1.NodeList
+			Expect(k8sClient.List(testCtx, &nodeList)).To(Succeed())
+			Expect(nodeList.Items).ToNot(BeEmpty())
+
+			segments := make(map[string]int32)
+			for i, node := range nodeList.Items {
+				segments[node.Labels[topologyKey]] = int32(i + 1)
+			}
+
+			By("Creating MariaDB Galera")
+			key := types.NamespacedName{
+				Name:      "mariadb-galera-segment",
+				Namespace: testNamespace,
+			}
+			mdb := mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					VolumeClaimTemplate: mariadbv1alpha1.VolumeClaimTemplate{
+						PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									"storage": resource.MustParse("100Mi"),
+								},
+							},
+							AccessModes: []corev1.PersistentVolumeAccessMode{
+								corev1.ReadWriteOnce,
+							},
+						},
+					},
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+						GaleraSpec: mariadbv1alpha1.GaleraSpec{
+							Segment: &mariadbv1alpha1.GaleraSegment{
+								Enabled:     true,
+								TopologyKey: topologyKey,
+								Segments:    segments,
+							},
+							VolumeClaimTemplate: &mariadbv1alpha1.VolumeClaimTemplate{
+								PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											"storage": resource.MustParse("100Mi"),
+										},
+									},
+									AccessModes: []corev1.PersistentVolumeAccessMode{
+										corev1.ReadWriteOnce,
+									},
+								},
+							},
+						},
+					},
+					Replicas: 3,
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &mdb)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &mdb)).To(Succeed())
+			})
+
+			By("Expecting MariaDB to be ready eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, key, &mdb); err != nil {
+					return false
+				}
+				return mdb.IsReady()
+			}, testVeryHighTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting Pods to have the segment of their Node")
+			for i := 0; i < int(mdb.Spec.Replicas); i++ {
+				var pod corev1.Pod
+				podKey := types.NamespacedName{
+					Name:      statefulset.PodName(mdb.ObjectMeta, i),
+					Namespace: key.Namespace,
+				}
+				Expect(k8sClient.Get(testCtx, podKey, &pod)).To(Succeed())
+
+				var node corev1.Node
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Name: pod.Spec.NodeName}, &node)).To(Succeed())
+
+				segment := strconv.Itoa(int(segments[node.Labels[topologyKey]]))
+				Expect(pod.Annotations).To(HaveKeyWithValue(metadata.GaleraSegmentAnnotation, segment))
+			}
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PodGaleraStateController reconciles the Galera node states pushed by the agent via wsrep_notify_cmd.
+type PodGaleraStateController struct {
+	client.Client
+	refResolver *refresolver.RefResolver
+	recorder    record.EventRecorder
+}
+
+func NewPodGaleraStateController(client client.Client, refResolver *refresolver.RefResolver,
+	recorder record.EventRecorder) *PodGaleraStateController {
+	return &PodGaleraStateController{
+		Client:      client,
+		refResolver: refResolver,
+		recorder:    recorder,
+	}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PodGaleraStateController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	mariadb, err := r.refResolver.MariaDBFromAnnotation(ctx, pod.ObjectMeta)
+	if err != nil {
+		if errors.Is(err, refresolver.ErrMariaDBAnnotationNotFound) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mariadb.Galera().Enabled || !mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+		return ctrl.Result{}, nil
+	}
+
+	state := pod.Annotations[metadata.GaleraStateAnnotation]
+	if state == "" || mariadb.Status.GaleraNodeStates[pod.Name] == state {
+		return ctrl.Result{}, nil
+	}
+	log.FromContext(ctx).V(1).Info("Galera state changed", "pod", pod.Name, "state", state)
+
+	patch := client.MergeFrom(mariadb.DeepCopy())
+	if mariadb.Status.GaleraNodeStates == nil {
+		mariadb.Status.GaleraNodeStates = make(map[string]string)
+	}
+	mariadb.Status.GaleraNodeStates[pod.Name] = state
+	if err := r.Status().Patch(ctx, mariadb, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching Galera node state: %v", err)
+	}
+
+	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraPodStateChanged,
+		"Pod '%s' Galera state changed to '%s'", pod.Name, state)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodGaleraStateController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod-galera-state").
+		For(&corev1.Pod{}).
+		WithEventFilter(
+			predicate.PredicateChangedWithAnnotations(
+				[]string{
+					metadata.MariadbAnnotation,
+					metadata.GaleraAnnotation,
+					metadata.GaleraStateAnnotation,
+				},
+				galeraStateHasChanged,
+			),
+		).
+		Complete(priority.NewReconciler(priority.PriorityCritical, r))
+}
+
+func galeraStateHasChanged(old, new client.Object) bool {
+	return old.GetAnnotations()[metadata.GaleraStateAnnotation] != new.GetAnnotations()[metadata.GaleraStateAnnotation]
+}
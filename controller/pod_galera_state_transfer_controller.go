@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const stateTransferLogTailLines int64 = 500
+
+// PodGaleraStateTransferController reports whether the Galera Pods joined the cluster via IST or SST.
+// The state transfer is read from the MariaDB container logs once it becomes ready, and it is attached to an Event in the Pod.
+// The reported state transfer is recorded in a Pod annotation, so the Event is only emitted once per container start.
+type PodGaleraStateTransferController struct {
+	client.Client
+	kubeClient  kubernetes.Interface
+	refResolver *refresolver.RefResolver
+	recorder    record.EventRecorder
+}
+
+func NewPodGaleraStateTransferController(client client.Client, kubeClient kubernetes.Interface, refResolver *refresolver.RefResolver,
+	recorder record.EventRecorder) *PodGaleraStateTransferController {
+	return &PodGaleraStateTransferController{
+		Client:      client,
+		kubeClient:  kubeClient,
+		refResolver: refResolver,
+		recorder:    recorder,
+	}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PodGaleraStateTransferController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var p corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &p); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	status := mariadbContainerStatus(&p)
+	if status == nil || !status.Ready || isStateTransferReported(&p, status.ContainerID) {
+		return ctrl.Result{}, nil
+	}
+
+	mariadb, err := r.refResolver.MariaDBFromAnnotation(ctx, p.ObjectMeta)
+	if err != nil {
+		if errors.Is(err, refresolver.ErrMariaDBAnnotationNotFound) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mariadb.Galera().Enabled {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx)
+
+	logs, err := mariadbContainerLogs(ctx, r.kubeClient, &p, false, stateTransferLogTailLines)
+	if err != nil {
+		logger.V(1).Info("Unable to read logs", "pod", p.Name, "err", err)
+		return ctrl.Result{}, nil
+	}
+	stateTransfer := pod.GaleraStateTransfer(logs)
+	if stateTransfer == "" {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(p.DeepCopy())
+	if p.Annotations == nil {
+		p.Annotations = map[string]string{}
+	}
+	p.Annotations[metadata.GaleraStateTransferAnnotation] = stateTransferAnnotation(stateTransfer, status.ContainerID)
+	if err := r.Patch(ctx, &p, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error patching Pod: %v", err)
+	}
+
+	logger.Info("Pod joined the Galera cluster", "pod", p.Name, "state-transfer", stateTransfer)
+	r.recorder.Eventf(&p, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraPodStateTransfer,
+		"Pod joined the Galera cluster via %s", stateTransfer)
+	return ctrl.Result{}, nil
+}
+
+// stateTransferAnnotation identifies the state transfer by the MariaDB container that performed it, as a new one takes place
+// every time the container restarts.
+func stateTransferAnnotation(stateTransfer pod.StateTransfer, containerID string) string {
+	return fmt.Sprintf("%s/%s", stateTransfer, containerID)
+}
+
+func isStateTransferReported(p *corev1.Pod, containerID string) bool {
+	value, ok := p.Annotations[metadata.GaleraStateTransferAnnotation]
+	return ok && containerID != "" && strings.HasSuffix(value, "/"+containerID)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodGaleraStateTransferController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod-galera-state-transfer").
+		For(&corev1.Pod{}).
+		WithEventFilter(
+			predicate.PredicateChangedWithAnnotations(
+				[]string{
+					metadata.MariadbAnnotation,
+					metadata.GaleraAnnotation,
+				},
+				mariadbReadinessHasChanged,
+			),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
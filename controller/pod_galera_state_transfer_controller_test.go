@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Pod Galera state transfer", func() {
+	newPod := func(containerID string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mariadb-galera-0",
+				Namespace:   testNamespace,
+				Annotations: annotations,
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:        builder.MariaDbContainerName,
+						ContainerID: containerID,
+						Ready:       true,
+					},
+				},
+			},
+		}
+	}
+
+	It("Should identify the reported state transfer by container", func() {
+		p := newPod("containerd://1234", map[string]string{
+			metadata.GaleraStateTransferAnnotation: stateTransferAnnotation(pod.StateTransferIST, "containerd://1234"),
+		})
+		Expect(isStateTransferReported(p, "containerd://1234")).To(BeTrue())
+		Expect(isStateTransferReported(p, "containerd://5678")).To(BeFalse())
+		Expect(isStateTransferReported(p, "")).To(BeFalse())
+		Expect(isStateTransferReported(newPod("containerd://1234", nil), "containerd://1234")).To(BeFalse())
+	})
+
+	It("Should not report the state transfer again", func() {
+		p := newPod("containerd://1234", map[string]string{
+			metadata.GaleraStateTransferAnnotation: stateTransferAnnotation(pod.StateTransferSST, "containerd://1234"),
+		})
+		recorder := record.NewFakeRecorder(10)
+		r := NewPodGaleraStateTransferController(
+			fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(p).Build(),
+			nil,
+			nil,
+			recorder,
+		)
+
+		result, err := r.Reconcile(testCtx, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      p.Name,
+				Namespace: p.Namespace,
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -4,18 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/replication"
 	"github.com/mariadb-operator/mariadb-operator/pkg/health"
+	mariadbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -27,73 +33,111 @@ type PodReplicationController struct {
 	builder     *builder.Builder
 	refResolver *refresolver.RefResolver
 	replConfig  *replication.ReplicationConfig
+	limiter     *ratelimit.ActionLimiter
+	sqlOpts     []sqlClient.Opt
 }
 
 func NewPodReplicationController(client client.Client, recorder record.EventRecorder, builder *builder.Builder,
-	refResolver *refresolver.RefResolver, replConfig *replication.ReplicationConfig) PodReadinessController {
+	refResolver *refresolver.RefResolver, replConfig *replication.ReplicationConfig, limiter *ratelimit.ActionLimiter,
+	sqlOpts ...sqlClient.Opt) PodReadinessController {
 	return &PodReplicationController{
 		Client:      client,
 		recorder:    recorder,
 		builder:     builder,
 		refResolver: refResolver,
 		replConfig:  replConfig,
+		limiter:     limiter,
+		sqlOpts:     sqlOpts,
 	}
 }
 
-func (r *PodReplicationController) ReconcilePodReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) error {
+func (r *PodReplicationController) ReconcilePodReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	if !r.shouldReconcile(mariadb) {
-		return nil
+		return ctrl.Result{}, nil
 	}
 	if mariadb.Status.CurrentPrimaryPodIndex == nil {
-		return errors.New("'status.currentPrimaryPodIndex' must be set")
+		return ctrl.Result{}, errors.New("'status.currentPrimaryPodIndex' must be set")
 	}
 	log.FromContext(ctx).V(1).Info("Reconciling Pod in Ready state", "pod", pod.Name)
 
 	index, err := statefulset.PodIndex(pod.Name)
 	if err != nil {
-		return fmt.Errorf("error getting Pod index: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error getting Pod index: %v", err)
 	}
 
-	client, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.refResolver, *index)
+	client, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.refResolver, *index, r.sqlOpts...)
 	if err != nil {
-		return fmt.Errorf("error connecting to replica '%d': %v", *index, err)
+		return ctrl.Result{}, fmt.Errorf("error connecting to replica '%d': %v", *index, err)
 	}
 	defer client.Close()
 
 	if *index == *mariadb.Status.CurrentPrimaryPodIndex {
 		if err := r.replConfig.ConfigurePrimary(ctx, mariadb, client, *index); err != nil {
-			return fmt.Errorf("error configuring primary in replica '%d': %v", *index, err)
+			return ctrl.Result{}, fmt.Errorf("error configuring primary in replica '%d': %v", *index, err)
 		}
-		return nil
+		return ctrl.Result{}, nil
+	}
+
+	provisioned, result, err := r.reconcileProvisioning(ctx, mariadb, client, &pod, *index)
+	if !provisioned || err != nil {
+		return result, err
 	}
 	if err := r.replConfig.ConfigureReplica(ctx, mariadb, client, *index, *mariadb.Status.CurrentPrimaryPodIndex, false); err != nil {
-		return fmt.Errorf("error configuring replication in replica '%d': %v", *index, err)
+		return ctrl.Result{}, fmt.Errorf("error configuring replication in replica '%d': %v", *index, err)
 	}
-	return nil
+	if err := r.finishProvisioning(ctx, mariadb, &pod, *index); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error finishing provisioning of replica '%d': %v", *index, err)
+	}
+	if err := replication.PatchFencedAnnotation(ctx, r.Client, &pod, false); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
 }
 
-func (r *PodReplicationController) ReconcilePodNotReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) error {
+func (r *PodReplicationController) ReconcilePodNotReady(ctx context.Context, pod corev1.Pod, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
 	if !r.shouldReconcile(mariadb) || !*mariadb.Replication().Primary.AutomaticFailover {
-		return nil
+		return ctrl.Result{}, nil
 	}
 	if mariadb.Status.CurrentPrimaryPodIndex == nil {
-		return errors.New("'status.currentPrimaryPodIndex' must be set")
+		return ctrl.Result{}, errors.New("'status.currentPrimaryPodIndex' must be set")
 	}
 	logger := log.FromContext(ctx)
 	logger.V(1).Info("Reconciling Pod in non Ready state", "pod", pod.Name)
 
 	index, err := statefulset.PodIndex(pod.Name)
 	if err != nil {
-		return fmt.Errorf("error getting Pod index: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error getting Pod index: %v", err)
 	}
 	if *index != *mariadb.Status.CurrentPrimaryPodIndex {
-		return nil
+		return ctrl.Result{}, nil
+	}
+
+	primary := mariadb.Replication().Primary
+	if remaining := failoverDelayRemaining(&pod, primary.FailoverDelay, time.Now()); remaining > 0 {
+		logger.V(1).Info("Delaying primary failover", "pod", pod.Name, "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if remaining := failoverCooldownRemaining(mariadb, primary.FailoverCooldown, time.Now()); remaining > 0 {
+		logger.Info("Primary failover in cooldown", "pod", pod.Name, "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
 	}
 
 	fromIndex := mariadb.Status.CurrentPrimaryPodIndex
 	toIndex, err := health.HealthyReplica(ctx, r, mariadb)
 	if err != nil {
-		return fmt.Errorf("error getting healthy replica: %v", err)
+		if primary.FailoverRetryInterval != nil {
+			logger.Info("Unable to failover primary. Retrying", "err", err, "retry-interval", primary.FailoverRetryInterval.Duration)
+			return ctrl.Result{RequeueAfter: primary.FailoverRetryInterval.Duration}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("error getting healthy replica: %v", err)
+	}
+
+	allowed, retryAfter, err := r.limiter.Allow(ctx, mariadb, mariadbv1alpha1.DisruptiveActionFailover, pod.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error checking action rate limit: %v", err)
+	}
+	if !allowed {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
 	}
 
 	var errBundle *multierror.Error
@@ -108,18 +152,43 @@ func (r *PodReplicationController) ReconcilePodNotReady(ctx context.Context, pod
 	errBundle = multierror.Append(errBundle, err)
 
 	if err := errBundle.ErrorOrNil(); err != nil {
-		return fmt.Errorf("error patching MariaDB: %v", err)
+		return ctrl.Result{}, fmt.Errorf("error patching MariaDB: %v", err)
 	}
 
 	logger.Info("Switching primary", "from-index", fromIndex, "to-index", *toIndex)
 	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPrimarySwitching,
 		"Switching primary from index '%d' to index '%d'", *fromIndex, *toIndex)
 
-	return nil
+	return ctrl.Result{}, nil
+}
+
+// failoverDelayRemaining returns the time left until the primary Pod has been not ready for longer than the failover delay.
+func failoverDelayRemaining(pod *corev1.Pod, delay *metav1.Duration, now time.Time) time.Duration {
+	if delay == nil || delay.Duration <= 0 {
+		return 0
+	}
+	readyCondition := mariadbpod.PodReadyCondition(pod)
+	if readyCondition == nil || readyCondition.LastTransitionTime.IsZero() {
+		return 0
+	}
+	return readyCondition.LastTransitionTime.Add(delay.Duration).Sub(now)
+}
+
+// failoverCooldownRemaining returns the time left until the cooldown after the last primary switch has elapsed.
+func failoverCooldownRemaining(mariadb *mariadbv1alpha1.MariaDB, cooldown *metav1.Duration, now time.Time) time.Duration {
+	if cooldown == nil || cooldown.Duration <= 0 {
+		return 0
+	}
+	switched := meta.FindStatusCondition(mariadb.Status.Conditions, mariadbv1alpha1.ConditionTypePrimarySwitched)
+	if switched == nil || switched.Status != metav1.ConditionTrue {
+		return 0
+	}
+	return switched.LastTransitionTime.Add(cooldown.Duration).Sub(now)
 }
 
 func (r *PodReplicationController) shouldReconcile(mariadb *mariadbv1alpha1.MariaDB) bool {
-	return mariadb.Replication().Enabled && mariadb.HasConfiguredReplication() && !mariadb.IsRestoringBackup()
+	return mariadb.Replication().Enabled && mariadb.HasConfiguredReplication() && !mariadb.IsRestoringBackup() &&
+		!mariadb.IsHibernated()
 }
 
 func (r *PodReplicationController) patch(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
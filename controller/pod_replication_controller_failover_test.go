@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("PodReplication failover", func() {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	Context("When delaying the failover", func() {
+		newPod := func(notReadySince time.Time) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb-repl-0",
+					Namespace: testNamespace,
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               corev1.PodReady,
+							Status:             corev1.ConditionFalse,
+							LastTransitionTime: metav1.NewTime(notReadySince),
+						},
+					},
+				},
+			}
+		}
+
+		DescribeTable(
+			"Should return the remaining time",
+			func(pod *corev1.Pod, delay *metav1.Duration, expected time.Duration) {
+				Expect(failoverDelayRemaining(pod, delay, now)).To(Equal(expected))
+			},
+			Entry(
+				"No delay",
+				newPod(now.Add(-5*time.Second)),
+				nil,
+				time.Duration(0),
+			),
+			Entry(
+				"Zero delay",
+				newPod(now.Add(-5*time.Second)),
+				&metav1.Duration{},
+				time.Duration(0),
+			),
+			Entry(
+				"No ready condition",
+				&corev1.Pod{},
+				&metav1.Duration{Duration: 30 * time.Second},
+				time.Duration(0),
+			),
+			Entry(
+				"Delay not elapsed",
+				newPod(now.Add(-5*time.Second)),
+				&metav1.Duration{Duration: 30 * time.Second},
+				25*time.Second,
+			),
+			Entry(
+				"Delay elapsed",
+				newPod(now.Add(-1*time.Minute)),
+				&metav1.Duration{Duration: 30 * time.Second},
+				-30*time.Second,
+			),
+		)
+	})
+
+	Context("When cooling down the failover", func() {
+		newMariaDB := func(status metav1.ConditionStatus, switchedAt time.Time) *mariadbv1alpha1.MariaDB {
+			return &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb-repl",
+					Namespace: testNamespace,
+				},
+				Status: mariadbv1alpha1.MariaDBStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:               mariadbv1alpha1.ConditionTypePrimarySwitched,
+							Status:             status,
+							LastTransitionTime: metav1.NewTime(switchedAt),
+						},
+					},
+				},
+			}
+		}
+
+		DescribeTable(
+			"Should return the remaining time",
+			func(mariadb *mariadbv1alpha1.MariaDB, cooldown *metav1.Duration, expected time.Duration) {
+				Expect(failoverCooldownRemaining(mariadb, cooldown, now)).To(Equal(expected))
+			},
+			Entry(
+				"No cooldown",
+				newMariaDB(metav1.ConditionTrue, now.Add(-1*time.Minute)),
+				nil,
+				time.Duration(0),
+			),
+			Entry(
+				"No primary switch",
+				&mariadbv1alpha1.MariaDB{},
+				&metav1.Duration{Duration: 5 * time.Minute},
+				time.Duration(0),
+			),
+			Entry(
+				"Primary switch in progress",
+				newMariaDB(metav1.ConditionFalse, now.Add(-1*time.Minute)),
+				&metav1.Duration{Duration: 5 * time.Minute},
+				time.Duration(0),
+			),
+			Entry(
+				"Cooldown not elapsed",
+				newMariaDB(metav1.ConditionTrue, now.Add(-1*time.Minute)),
+				&metav1.Duration{Duration: 5 * time.Minute},
+				4*time.Minute,
+			),
+			Entry(
+				"Cooldown elapsed",
+				newMariaDB(metav1.ConditionTrue, now.Add(-10*time.Minute)),
+				&metav1.Duration{Duration: 5 * time.Minute},
+				-5*time.Minute,
+			),
+		)
+	})
+})
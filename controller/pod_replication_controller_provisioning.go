@@ -0,0 +1,254 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	mdbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const provisioningRequeueInterval = 10 * time.Second
+
+var errNoProvisioningBackup = errors.New("no complete Backup found")
+
+// reconcileProvisioning seeds a new replica with the latest backup, or with a dump of the primary, before replication is configured.
+// The Pod is annotated while provisioning, so it is kept out of the secondary Endpoints until it has all the data.
+// The returned bool indicates whether replication can be configured in the replica.
+func (r *PodReplicationController) reconcileProvisioning(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	client *sqlClient.Client, pod *corev1.Pod, index int) (bool, ctrl.Result, error) {
+	provisioning := mariadb.Replication().Replica.Provisioning
+	if provisioning == nil {
+		return true, ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithValues("pod-index", index)
+	key := mariadb.ProvisioningJobKey(index)
+
+	var job batchv1.Job
+	if err := r.Get(ctx, key, &job); err == nil {
+		if isJobFailed(&job) {
+			r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonReplicaProvisioningFailed,
+				"Provisioning Job '%s' failed for replica '%d'", job.Name, index)
+			if err := r.deleteProvisioningJob(ctx, &job); err != nil {
+				return false, ctrl.Result{}, err
+			}
+			return false, ctrl.Result{}, fmt.Errorf("provisioning Job '%s' failed", job.Name)
+		}
+		if !isJobComplete(&job) {
+			if err := r.patchProvisioningAnnotation(ctx, pod, true); err != nil {
+				return false, ctrl.Result{}, err
+			}
+			logger.V(1).Info("Waiting for replica provisioning", "job", job.Name)
+			return false, ctrl.Result{RequeueAfter: provisioningRequeueInterval}, nil
+		}
+		return true, ctrl.Result{}, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, ctrl.Result{}, fmt.Errorf("error getting provisioning Job: %v", err)
+	}
+
+	needsProvisioning, err := r.needsProvisioning(ctx, mariadb, client)
+	if err != nil {
+		return false, ctrl.Result{}, fmt.Errorf("error checking whether replica '%d' needs provisioning: %v", index, err)
+	}
+	if !needsProvisioning {
+		return true, ctrl.Result{}, nil
+	}
+
+	var restoreSource *mariadbv1alpha1.RestoreSource
+	if provisioning.SourceOrDefault() == mariadbv1alpha1.ReplicaProvisioningSourceBackup {
+		backup, err := r.provisioningBackup(ctx, mariadb, provisioning)
+		if err != nil {
+			if errors.Is(err, errNoProvisioningBackup) {
+				logger.Info("Unable to provision replica. Replicating from the primary", "err", err)
+				r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonReplicaProvisioningFailed,
+					"Unable to provision replica '%d': %v. Replicating the full binary log history of the primary", index, err)
+				return true, ctrl.Result{}, nil
+			}
+			return false, ctrl.Result{}, fmt.Errorf("error getting provisioning Backup: %v", err)
+		}
+		restoreSource = &mariadbv1alpha1.RestoreSource{}
+		if err := restoreSource.SetDefaultsWithBackup(backup); err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("error setting restore source from Backup '%s': %v", backup.Name, err)
+		}
+	}
+
+	newJob, err := r.builder.BuildReplicaProvisioningJob(key, mariadb, index, restoreSource)
+	if err != nil {
+		return false, ctrl.Result{}, fmt.Errorf("error building provisioning Job: %v", err)
+	}
+	if err := r.patchProvisioningAnnotation(ctx, pod, true); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, newJob); err != nil {
+		return false, ctrl.Result{}, fmt.Errorf("error creating provisioning Job: %v", err)
+	}
+	logger.Info("Provisioning replica", "job", newJob.Name, "source", provisioning.SourceOrDefault())
+	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicaProvisioning,
+		"Provisioning replica '%d' from %s", index, provisioning.SourceOrDefault())
+
+	return false, ctrl.Result{RequeueAfter: provisioningRequeueInterval}, nil
+}
+
+// finishProvisioning cleans up the provisioning Job of a replica and adds it back to the Endpoints, once replication has been configured.
+func (r *PodReplicationController) finishProvisioning(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, pod *corev1.Pod,
+	index int) error {
+	if err := r.patchProvisioningAnnotation(ctx, pod, false); err != nil {
+		return err
+	}
+	if mariadb.Replication().Replica.Provisioning == nil {
+		return nil
+	}
+	var job batchv1.Job
+	if err := r.Get(ctx, mariadb.ProvisioningJobKey(index), &job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.deleteProvisioningJob(ctx, &job); err != nil {
+		return err
+	}
+	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicaProvisioned,
+		"Replica '%d' provisioned", index)
+	return nil
+}
+
+// needsProvisioning determines whether a replica has an empty data directory and therefore needs to be provisioned:
+// it has never replicated and the primary has executed transactions.
+func (r *PodReplicationController) needsProvisioning(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	client *sqlClient.Client) (bool, error) {
+	hasConnections, err := client.HasReplicationConnections(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting replication connections: %v", err)
+	}
+	if hasConnections {
+		return false, nil
+	}
+	slavePos, err := client.GtidSlavePos(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting gtid_slave_pos: %v", err)
+	}
+	if slavePos != "" {
+		return false, nil
+	}
+
+	primaryClient, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.refResolver, *mariadb.Status.CurrentPrimaryPodIndex,
+		r.sqlOpts...)
+	if err != nil {
+		return false, fmt.Errorf("error connecting to primary: %v", err)
+	}
+	defer primaryClient.Close()
+
+	primaryPos, err := primaryClient.GtidCurrentPos(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting primary gtid_current_pos: %v", err)
+	}
+	return primaryPos != "", nil
+}
+
+// provisioningBackup returns the Backup referenced by the provisioning configuration or, if not provided,
+// the full Backup of the MariaDB that completed most recently.
+func (r *PodReplicationController) provisioningBackup(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	provisioning *mariadbv1alpha1.ReplicaProvisioning) (*mariadbv1alpha1.Backup, error) {
+	if provisioning.BackupRef != nil {
+		backup, err := r.refResolver.Backup(ctx, provisioning.BackupRef, mariadb.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("%w: Backup '%s' not found", errNoProvisioningBackup, provisioning.BackupRef.Name)
+			}
+			return nil, err
+		}
+		if !backup.IsSuccessful() {
+			return nil, fmt.Errorf("%w: Backup '%s' not complete", errNoProvisioningBackup, backup.Name)
+		}
+		return backup, nil
+	}
+
+	var backupList mariadbv1alpha1.BackupList
+	if err := r.List(ctx, &backupList, client.InNamespace(mariadb.Namespace)); err != nil {
+		return nil, fmt.Errorf("error listing Backups: %v", err)
+	}
+	var latest *mariadbv1alpha1.Backup
+	var latestTime time.Time
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if !isProvisioningBackup(backup, mariadb) {
+			continue
+		}
+		if t := lastBackupTime(backup); latest == nil || t.After(latestTime) {
+			latest = backup
+			latestTime = t
+		}
+	}
+	if latest == nil {
+		return nil, errNoProvisioningBackup
+	}
+	return latest, nil
+}
+
+// patchProvisioningAnnotation sets or removes the annotation that keeps the Pod out of the Endpoints while provisioning.
+func (r *PodReplicationController) patchProvisioningAnnotation(ctx context.Context, pod *corev1.Pod, provisioning bool) error {
+	if mdbpod.PodProvisioning(pod) == provisioning {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if provisioning {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[metadata.ProvisioningAnnotation] = "true"
+	} else {
+		delete(pod.Annotations, metadata.ProvisioningAnnotation)
+	}
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("error patching provisioning annotation in Pod '%s': %v", pod.Name, err)
+	}
+	return nil
+}
+
+func (r *PodReplicationController) deleteProvisioningJob(ctx context.Context, job *batchv1.Job) error {
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
+
+// isProvisioningBackup determines whether a Backup of the MariaDB can be used to provision replicas.
+// Per-database Backups are not suitable, as they don't contain all the data.
+func isProvisioningBackup(backup *mariadbv1alpha1.Backup, mariadb *mariadbv1alpha1.MariaDB) bool {
+	ref := backup.Spec.MariaDBRef
+	if ref.Name != mariadb.Name || (ref.Namespace != "" && ref.Namespace != mariadb.Namespace) {
+		return false
+	}
+	return len(backup.Spec.Databases) == 0 && backup.IsSuccessful()
+}
+
+// lastBackupTime returns when the most recent backup was taken, according to the artifacts reported by the Backup,
+// falling back to the time when it was completed.
+func lastBackupTime(backup *mariadbv1alpha1.Backup) time.Time {
+	if len(backup.Status.Artifacts) > 0 {
+		return backup.Status.Artifacts[0].Timestamp.Time
+	}
+	if c := meta.FindStatusCondition(backup.Status.Conditions, mariadbv1alpha1.ConditionTypeComplete); c != nil {
+		return c.LastTransitionTime.Time
+	}
+	return time.Time{}
+}
+
+func isJobComplete(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -4,18 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/batch"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	restoreFinalizerName = "restore.mariadb.mmontes.io/finalizer"
 )
 
 // RestoreReconciler reconciles a restore object
@@ -26,6 +38,7 @@ type RestoreReconciler struct {
 	RefResolver       *refresolver.RefResolver
 	ConditionComplete *condition.Complete
 	BatchReconciler   *batch.BatchReconciler
+	SqlOpts           []sqlClient.Opt
 }
 
 //+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restores,verbs=get;list;watch;create;update;patch;delete
@@ -42,6 +55,10 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if restore.DeletionTimestamp != nil {
+		return ctrl.Result{}, r.finalize(ctx, &restore)
+	}
+
 	mariaDb, err := r.RefResolver.MariaDB(ctx, &restore.Spec.MariaDBRef, restore.Namespace)
 	if err != nil {
 		var mariaDbErr *multierror.Error
@@ -71,6 +88,26 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, fmt.Errorf("error initializing source: %v", sourceErr)
 	}
 
+	// The Restore is reconciled again when the Backup dependencies change.
+	if msg, err := backupDependenciesMessage(ctx, r.RefResolver, restore.Spec.DependsOnBackups, restore.Namespace); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error checking Backup dependencies: %v", err)
+	} else if msg != "" {
+		log.FromContext(ctx).Info(msg)
+		if err := r.patchStatus(ctx, &restore, r.ConditionComplete.PatcherWaiting(msg)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if restore.Spec.WriteFreeze && !restore.IsComplete() {
+		if err := r.addFinalizer(ctx, &restore); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error adding finalizer: %v", err)
+		}
+		if err := r.freezeWrites(ctx, &restore, mariaDb); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error freezing writes: %v", err)
+		}
+	}
+
 	var jobErr *multierror.Error
 	err = r.BatchReconciler.Reconcile(ctx, &restore, mariaDb)
 	jobErr = multierror.Append(jobErr, err)
@@ -86,6 +123,12 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	err = r.patchStatus(ctx, &restore, patcher)
 	jobErr = multierror.Append(jobErr, err)
 
+	if restore.IsComplete() && restore.IsWriteFrozen() {
+		if err := r.unfreezeWrites(ctx, &restore, mariaDb); err != nil {
+			jobErr = multierror.Append(jobErr, fmt.Errorf("error unfreezing writes: %v", err))
+		}
+	}
+
 	if err := jobErr.ErrorOrNil(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error creating Job: %v", err)
 	}
@@ -134,6 +177,19 @@ func (r *RestoreReconciler) setDefaults(ctx context.Context, restore *mariadbv1a
 		return errBundle
 	}
 
+	if targetRecoveryTime := restore.Spec.RestoreSource.TargetRecoveryTime; targetRecoveryTime != nil {
+		if artifact, ok := backup.RestorePointBefore(targetRecoveryTime.Time); ok && artifact == nil {
+			msg := fmt.Sprintf("No backup available before target recovery time '%s'", targetRecoveryTime.Format(time.RFC3339))
+			var errBundle *multierror.Error
+			errBundle = multierror.Append(errBundle, errors.New(msg))
+
+			err := r.patchStatus(ctx, restore, r.ConditionComplete.PatcherFailed(msg))
+			errBundle = multierror.Append(errBundle, err)
+
+			return errBundle
+		}
+	}
+
 	if err := r.patch(ctx, restore, func(r *mariadbv1alpha1.Restore) error {
 		return r.Spec.RestoreSource.SetDefaultsWithBackup(backup)
 	}); err != nil {
@@ -142,6 +198,85 @@ func (r *RestoreReconciler) setDefaults(ctx context.Context, restore *mariadbv1a
 	return nil
 }
 
+func (r *RestoreReconciler) freezeWrites(ctx context.Context, restore *mariadbv1alpha1.Restore,
+	mariadb *mariadbv1alpha1.MariaDB) error {
+	if restore.IsWriteFrozen() {
+		return nil
+	}
+	if err := r.setReadOnly(ctx, mariadb, true); err != nil {
+		return err
+	}
+	return r.patchStatus(ctx, restore, condition.SetWriteFrozen)
+}
+
+func (r *RestoreReconciler) unfreezeWrites(ctx context.Context, restore *mariadbv1alpha1.Restore,
+	mariadb *mariadbv1alpha1.MariaDB) error {
+	if err := r.setReadOnly(ctx, mariadb, false); err != nil {
+		return err
+	}
+	return r.patchStatus(ctx, restore, condition.SetWriteUnfrozen)
+}
+
+func (r *RestoreReconciler) addFinalizer(ctx context.Context, restore *mariadbv1alpha1.Restore) error {
+	if controllerutil.ContainsFinalizer(restore, restoreFinalizerName) {
+		return nil
+	}
+	return r.patch(ctx, restore, func(r *mariadbv1alpha1.Restore) error {
+		controllerutil.AddFinalizer(r, restoreFinalizerName)
+		return nil
+	})
+}
+
+// finalize unfreezes the writes when the Restore is deleted before completing, otherwise the MariaDB would remain read-only.
+func (r *RestoreReconciler) finalize(ctx context.Context, restore *mariadbv1alpha1.Restore) error {
+	if !controllerutil.ContainsFinalizer(restore, restoreFinalizerName) {
+		return nil
+	}
+	if restore.IsWriteFrozen() {
+		mariadb, err := r.RefResolver.MariaDB(ctx, &restore.Spec.MariaDBRef, restore.Namespace)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting MariaDB: %v", err)
+		}
+		if err == nil && mariadb.DeletionTimestamp == nil {
+			if err := r.unfreezeWrites(ctx, restore, mariadb); err != nil {
+				return fmt.Errorf("error unfreezing writes: %v", err)
+			}
+		}
+	}
+	return r.patch(ctx, restore, func(r *mariadbv1alpha1.Restore) error {
+		controllerutil.RemoveFinalizer(r, restoreFinalizerName)
+		return nil
+	})
+}
+
+func (r *RestoreReconciler) setReadOnly(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, readOnly bool) error {
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		// replicas must remain read-only after the Restore, as it is managed by the replication controller.
+		if !readOnly && mariadb.Replication().Enabled &&
+			mariadb.Status.CurrentPrimaryPodIndex != nil && *mariadb.Status.CurrentPrimaryPodIndex != i {
+			continue
+		}
+		if err := r.setPodReadOnly(ctx, mariadb, i, readOnly); err != nil {
+			return fmt.Errorf("error setting read_only in Pod %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *RestoreReconciler) setPodReadOnly(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, podIndex int,
+	readOnly bool) error {
+	client, err := sqlClient.NewInternalClientWithPodIndex(ctx, mariadb, r.RefResolver, podIndex, r.SqlOpts...)
+	if err != nil {
+		return fmt.Errorf("error connecting to MariaDB: %v", err)
+	}
+	defer client.Close()
+
+	if readOnly {
+		return client.EnableReadOnly(ctx)
+	}
+	return client.DisableReadOnly(ctx)
+}
+
 func (r *RestoreReconciler) patchStatus(ctx context.Context, restore *mariadbv1alpha1.Restore,
 	patcher condition.Patcher) error {
 	patch := client.MergeFrom(restore.DeepCopy())
@@ -164,8 +299,21 @@ func (r *RestoreReconciler) patch(ctx context.Context, restore *mariadbv1alpha1.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexBackupDependencies(mgr, &mariadbv1alpha1.Restore{}, func(obj client.Object) []corev1.LocalObjectReference {
+		return obj.(*mariadbv1alpha1.Restore).Spec.DependsOnBackups
+	}); err != nil {
+		return fmt.Errorf("error creating index: %v", err)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&mariadbv1alpha1.Restore{}).
 		Owns(&batchv1.Job{}).
-		Complete(r)
+		Watches(
+			&mariadbv1alpha1.Backup{},
+			handler.EnqueueRequestsFromMapFunc(r.mapBackupToRequests),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
+
+func (r *RestoreReconciler) mapBackupToRequests(ctx context.Context, backup client.Object) []reconcile.Request {
+	return mapBackupToDependents(ctx, r.Client, &mariadbv1alpha1.RestoreList{}, backup)
 }
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"errors"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Restore write freeze", func() {
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-write-freeze",
+			Namespace: testNamespace,
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image: "mariadb:11.0.3",
+			Port:  3306,
+		},
+	}
+
+	Context("When deleting a Restore", func() {
+		newRestore := func(writeFrozen bool) *mariadbv1alpha1.Restore {
+			restore := &mariadbv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "restore-write-freeze",
+					Namespace:         testNamespace,
+					DeletionTimestamp: ptr.To(metav1.Now()),
+					Finalizers: []string{
+						restoreFinalizerName,
+					},
+				},
+				Spec: mariadbv1alpha1.RestoreSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: "mariadb-not-found",
+						},
+					},
+					WriteFreeze: true,
+				},
+			}
+			if writeFrozen {
+				restore.Status.Conditions = []metav1.Condition{
+					{
+						Type:   mariadbv1alpha1.ConditionTypeWriteFrozen,
+						Status: metav1.ConditionTrue,
+						Reason: mariadbv1alpha1.ConditionReasonWriteFreeze,
+					},
+				}
+			}
+			return restore
+		}
+
+		DescribeTable("Should release the finalizer",
+			func(writeFrozen bool) {
+				restore := newRestore(writeFrozen)
+				c := fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(restore).
+					WithStatusSubresource(&mariadbv1alpha1.Restore{}).
+					Build()
+				r := &RestoreReconciler{
+					Client:      c,
+					RefResolver: refresolver.New(c),
+				}
+
+				Expect(r.finalize(testCtx, restore)).To(Succeed())
+
+				err := c.Get(testCtx, client.ObjectKeyFromObject(restore), &mariadbv1alpha1.Restore{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			},
+			Entry("not frozen", false),
+			Entry("frozen with MariaDB not found", true),
+		)
+	})
+
+	Context("When reconciling a SqlJob", func() {
+		newSqlJob := func(schedule *mariadbv1alpha1.Schedule) *mariadbv1alpha1.SqlJob {
+			return &mariadbv1alpha1.SqlJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sqljob-write-freeze",
+					Namespace: testNamespace,
+				},
+				Spec: mariadbv1alpha1.SqlJobSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: mariadb.Name,
+						},
+					},
+					SqlConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "sql-sqljob-write-freeze",
+						},
+						Key: jobConfigMapKey,
+					},
+					Schedule: schedule,
+				},
+			}
+		}
+		newReconciler := func(objs ...client.Object) *SqlJobReconciler {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(objs...).
+				Build()
+			return &SqlJobReconciler{
+				Client:  c,
+				Builder: builder.NewBuilder(scheme.Scheme, &environment.Environment{}),
+			}
+		}
+
+		It("Should not create new Jobs", func() {
+			sqlJob := newSqlJob(nil)
+			key := client.ObjectKeyFromObject(sqlJob)
+			r := newReconciler(sqlJob)
+
+			err := r.reconcileBatch(testCtx, sqlJob, mariadb, key, true)
+			Expect(errors.Is(err, errWriteFrozen)).To(BeTrue())
+
+			err = r.Get(testCtx, key, &batchv1.Job{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("Should reconcile existing Jobs", func() {
+			sqlJob := newSqlJob(nil)
+			key := client.ObjectKeyFromObject(sqlJob)
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+			}
+			r := newReconciler(sqlJob, job)
+
+			Expect(r.reconcileBatch(testCtx, sqlJob, mariadb, key, true)).To(Succeed())
+		})
+
+		It("Should suspend the CronJob", func() {
+			sqlJob := newSqlJob(&mariadbv1alpha1.Schedule{
+				Cron: "*/1 * * * *",
+			})
+			key := client.ObjectKeyFromObject(sqlJob)
+			r := newReconciler(sqlJob)
+
+			Expect(r.reconcileBatch(testCtx, sqlJob, mariadb, key, true)).To(Succeed())
+
+			var cronJob batchv1.CronJob
+			Expect(r.Get(testCtx, key, &cronJob)).To(Succeed())
+			Expect(ptr.Deref(cronJob.Spec.Suspend, false)).To(BeTrue())
+
+			Expect(r.reconcileBatch(testCtx, sqlJob, mariadb, key, false)).To(Succeed())
+
+			Expect(r.Get(testCtx, key, &cronJob)).To(Succeed())
+			Expect(ptr.Deref(cronJob.Spec.Suspend, false)).To(BeFalse())
+		})
+	})
+})
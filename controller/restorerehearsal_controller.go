@@ -0,0 +1,345 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	restoreRehearsalFinalizerName = "restorerehearsal.mariadb.mmontes.io/finalizer"
+)
+
+var rehearsalRequeueInterval = 10 * time.Second
+
+// RestoreRehearsalReconciler reconciles a RestoreRehearsal object
+type RestoreRehearsalReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	Builder     *builder.Builder
+	RefResolver *refresolver.RefResolver
+	SqlOpts     []sqlClient.Opt
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restorerehearsals,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restorerehearsals/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restorerehearsals/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=mariadbs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=restores,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;watch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *RestoreRehearsalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var rehearsal mariadbv1alpha1.RestoreRehearsal
+	if err := r.Get(ctx, req.NamespacedName, &rehearsal); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if rehearsal.DeletionTimestamp != nil {
+		return ctrl.Result{}, r.finalize(ctx, &rehearsal)
+	}
+	if err := r.addFinalizer(ctx, &rehearsal); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error adding finalizer: %v", err)
+	}
+
+	if rehearsal.IsFinished() {
+		return r.reconcileSchedule(ctx, &rehearsal)
+	}
+	if rehearsal.Status.Phase == "" {
+		if err := r.start(ctx, &rehearsal); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error starting rehearsal: %v", err)
+		}
+	}
+	return r.reconcileProvisioning(ctx, &rehearsal)
+}
+
+// reconcileSchedule starts a new rehearsal when the schedule is due.
+func (r *RestoreRehearsalReconciler) reconcileSchedule(ctx context.Context,
+	rehearsal *mariadbv1alpha1.RestoreRehearsal) (ctrl.Result, error) {
+	if rehearsal.Spec.Schedule == nil || rehearsal.Spec.Schedule.Suspend {
+		return ctrl.Result{}, nil
+	}
+	next, err := rehearsal.NextScheduleTime()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if now := time.Now(); now.Before(next) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	log.FromContext(ctx).Info("Starting scheduled rehearsal")
+	if err := r.start(ctx, rehearsal); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error starting rehearsal: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: rehearsalRequeueInterval}, nil
+}
+
+// start cleans up any leftovers of previous rehearsals and resets the status.
+func (r *RestoreRehearsalReconciler) start(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal) error {
+	if err := r.teardown(ctx, rehearsal); err != nil {
+		return fmt.Errorf("error tearing down previous rehearsal: %v", err)
+	}
+	now := metav1.Now()
+	return r.patchStatus(ctx, rehearsal, func(s *mariadbv1alpha1.RestoreRehearsalStatus) {
+		s.Phase = mariadbv1alpha1.RestoreRehearsalPhaseProvisioning
+		s.Message = ""
+		s.StartTime = &now
+		s.RestoreTime = nil
+		s.CompletionTime = nil
+		s.Results = nil
+		condition.SetCompleteWithRehearsalStatus(s, s)
+	})
+}
+
+func (r *RestoreRehearsalReconciler) reconcileProvisioning(ctx context.Context,
+	rehearsal *mariadbv1alpha1.RestoreRehearsal) (ctrl.Result, error) {
+	if start := rehearsal.Status.StartTime; start != nil && time.Since(start.Time) > rehearsal.Timeout() {
+		return ctrl.Result{}, r.finish(ctx, rehearsal, mariadbv1alpha1.RestoreRehearsalPhaseFailed,
+			fmt.Sprintf("Timeout of %s exceeded", rehearsal.Timeout()), nil)
+	}
+
+	var mariadb mariadbv1alpha1.MariaDB
+	if err := r.Get(ctx, rehearsal.MariaDBKey(), &mariadb); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("error getting MariaDB: %v", err)
+		}
+		if err := r.createMariaDB(ctx, rehearsal); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: rehearsalRequeueInterval}, nil
+	}
+	if mariadb.DeletionTimestamp != nil {
+		return ctrl.Result{RequeueAfter: rehearsalRequeueInterval}, nil
+	}
+
+	failed, err := r.isRestoreFailed(ctx, &mariadb)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if failed {
+		return ctrl.Result{}, r.finish(ctx, rehearsal, mariadbv1alpha1.RestoreRehearsalPhaseFailed,
+			"Error restoring backup", nil)
+	}
+	if !mariadb.HasRestoredBackup() || !mariadb.IsReady() {
+		return ctrl.Result{RequeueAfter: rehearsalRequeueInterval}, nil
+	}
+
+	restoreTime := metav1.Now()
+	if err := r.patchStatus(ctx, rehearsal, func(s *mariadbv1alpha1.RestoreRehearsalStatus) {
+		s.RestoreTime = &restoreTime
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	results := r.validate(ctx, rehearsal, &mariadb)
+	var failedValidations []string
+	for _, result := range results {
+		if !result.Passed {
+			failedValidations = append(failedValidations, result.Name)
+		}
+	}
+	if len(failedValidations) > 0 {
+		return ctrl.Result{}, r.finish(ctx, rehearsal, mariadbv1alpha1.RestoreRehearsalPhaseFailed,
+			fmt.Sprintf("Validations failed: %s", strings.Join(failedValidations, ", ")), results)
+	}
+	return ctrl.Result{}, r.finish(ctx, rehearsal, mariadbv1alpha1.RestoreRehearsalPhaseSucceeded,
+		fmt.Sprintf("Backup restored and %d validations passed", len(results)), results)
+}
+
+func (r *RestoreRehearsalReconciler) createMariaDB(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal) error {
+	template, err := r.RefResolver.MariaDB(ctx, &rehearsal.Spec.MariaDBRef, rehearsal.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting MariaDB: %v", err)
+	}
+	mariadb, err := r.Builder.BuildRestoreRehearsalMariaDB(rehearsal, template)
+	if err != nil {
+		return fmt.Errorf("error building MariaDB: %v", err)
+	}
+	log.FromContext(ctx).Info("Provisioning temporary MariaDB", "mariadb", mariadb.Name)
+	if err := r.Create(ctx, mariadb); err != nil {
+		return fmt.Errorf("error creating MariaDB: %v", err)
+	}
+	return nil
+}
+
+// isRestoreFailed checks whether the Restore used to bootstrap the temporary MariaDB has failed.
+func (r *RestoreRehearsalReconciler) isRestoreFailed(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (bool, error) {
+	var restore mariadbv1alpha1.Restore
+	if err := r.Get(ctx, mariadb.RestoreKey(), &restore); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting Restore: %v", err)
+	}
+	c := meta.FindStatusCondition(restore.Status.Conditions, mariadbv1alpha1.ConditionTypeComplete)
+	return c != nil && c.Reason == mariadbv1alpha1.ConditionReasonJobFailed, nil
+}
+
+func (r *RestoreRehearsalReconciler) validate(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal,
+	mariadb *mariadbv1alpha1.MariaDB) []mariadbv1alpha1.RestoreRehearsalValidationResult {
+	results := make([]mariadbv1alpha1.RestoreRehearsalValidationResult, len(rehearsal.Spec.Validations))
+	for i, v := range rehearsal.Spec.Validations {
+		results[i] = r.runValidation(ctx, mariadb, v)
+	}
+	return results
+}
+
+func (r *RestoreRehearsalReconciler) runValidation(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	validation mariadbv1alpha1.RestoreRehearsalValidation) mariadbv1alpha1.RestoreRehearsalValidationResult {
+	result := mariadbv1alpha1.RestoreRehearsalValidationResult{
+		Name: validation.Name,
+	}
+	opts := slices.Clip(r.SqlOpts)
+	if validation.Database != nil {
+		opts = append(opts, sqlClient.WithDatabase(*validation.Database))
+	}
+	client, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver, opts...)
+	if err != nil {
+		result.Message = fmt.Sprintf("Error connecting to MariaDB: %v", err)
+		return result
+	}
+	defer client.Close()
+
+	value, err := client.ReadOnlyQueryValue(ctx, validation.Sql)
+	if err != nil {
+		result.Message = fmt.Sprintf("Error executing query: %v", err)
+		return result
+	}
+	result.Value = value
+
+	if validation.Expected != nil {
+		if value == nil || *value != *validation.Expected {
+			result.Message = fmt.Sprintf("Expected '%s'", *validation.Expected)
+			return result
+		}
+	}
+	result.Passed = true
+	return result
+}
+
+func (r *RestoreRehearsalReconciler) finish(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal,
+	phase mariadbv1alpha1.RestoreRehearsalPhase, message string, results []mariadbv1alpha1.RestoreRehearsalValidationResult) error {
+	logger := log.FromContext(ctx)
+	if phase == mariadbv1alpha1.RestoreRehearsalPhaseFailed && rehearsal.Spec.KeepOnFailure {
+		logger.Info("Keeping temporary MariaDB for inspection", "mariadb", rehearsal.MariaDBKey().Name)
+	} else if err := r.teardown(ctx, rehearsal); err != nil {
+		return fmt.Errorf("error tearing down rehearsal: %v", err)
+	}
+
+	logger.Info("Rehearsal finished", "phase", phase, "message", message)
+	now := metav1.Now()
+	return r.patchStatus(ctx, rehearsal, func(s *mariadbv1alpha1.RestoreRehearsalStatus) {
+		s.Phase = phase
+		s.Message = message
+		s.CompletionTime = &now
+		s.Results = results
+		condition.SetCompleteWithRehearsalStatus(s, s)
+	})
+}
+
+// teardown deletes the temporary MariaDB and its PersistentVolumeClaims.
+func (r *RestoreRehearsalReconciler) teardown(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal) error {
+	key := rehearsal.MariaDBKey()
+	var mariadb mariadbv1alpha1.MariaDB
+	if err := r.Get(ctx, key, &mariadb); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting MariaDB: %v", err)
+		}
+	} else if mariadb.DeletionTimestamp == nil {
+		if err := r.Delete(ctx, &mariadb, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+			!apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting MariaDB: %v", err)
+		}
+	}
+
+	var pvcs corev1.PersistentVolumeClaimList
+	listOpts := []client.ListOption{
+		client.InNamespace(key.Namespace),
+		client.MatchingLabels(
+			labels.NewLabelsBuilder().
+				WithMariaDB(&mariadbv1alpha1.MariaDB{ObjectMeta: metav1.ObjectMeta{Name: key.Name}}).
+				Build(),
+		),
+	}
+	if err := r.List(ctx, &pvcs, listOpts...); err != nil {
+		return fmt.Errorf("error listing PersistentVolumeClaims: %v", err)
+	}
+	for i := range pvcs.Items {
+		if err := r.Delete(ctx, &pvcs.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting PersistentVolumeClaim: %v", err)
+		}
+	}
+	return nil
+}
+
+func (r *RestoreRehearsalReconciler) addFinalizer(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal) error {
+	if controllerutil.ContainsFinalizer(rehearsal, restoreRehearsalFinalizerName) {
+		return nil
+	}
+	return r.patch(ctx, rehearsal, func(rr *mariadbv1alpha1.RestoreRehearsal) {
+		controllerutil.AddFinalizer(rr, restoreRehearsalFinalizerName)
+	})
+}
+
+// finalize tears down the temporary MariaDB, as its PersistentVolumeClaims are not garbage collected.
+func (r *RestoreRehearsalReconciler) finalize(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal) error {
+	if !controllerutil.ContainsFinalizer(rehearsal, restoreRehearsalFinalizerName) {
+		return nil
+	}
+	if err := r.teardown(ctx, rehearsal); err != nil {
+		return fmt.Errorf("error tearing down rehearsal: %v", err)
+	}
+	return r.patch(ctx, rehearsal, func(rr *mariadbv1alpha1.RestoreRehearsal) {
+		controllerutil.RemoveFinalizer(rr, restoreRehearsalFinalizerName)
+	})
+}
+
+func (r *RestoreRehearsalReconciler) patch(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal,
+	patchFn func(*mariadbv1alpha1.RestoreRehearsal)) error {
+	patch := client.MergeFrom(rehearsal.DeepCopy())
+	patchFn(rehearsal)
+
+	if err := r.Client.Patch(ctx, rehearsal, patch); err != nil {
+		return fmt.Errorf("error patching RestoreRehearsal: %v", err)
+	}
+	return nil
+}
+
+func (r *RestoreRehearsalReconciler) patchStatus(ctx context.Context, rehearsal *mariadbv1alpha1.RestoreRehearsal,
+	patcher func(*mariadbv1alpha1.RestoreRehearsalStatus)) error {
+	patch := client.MergeFrom(rehearsal.DeepCopy())
+	patcher(&rehearsal.Status)
+
+	if err := r.Client.Status().Patch(ctx, rehearsal, patch); err != nil {
+		return fmt.Errorf("error patching RestoreRehearsal status: %v", err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RestoreRehearsalReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.RestoreRehearsal{}).
+		Owns(&mariadbv1alpha1.MariaDB{}).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("RestoreRehearsal controller", func() {
+	Context("When creating a RestoreRehearsal", func() {
+		It("Should reconcile", func() {
+			By("Creating RestoreRehearsal")
+			rehearsal := mariadbv1alpha1.RestoreRehearsal{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "restorerehearsal-test",
+					Namespace: testNamespace,
+				},
+				Spec: mariadbv1alpha1.RestoreRehearsalSpec{
+					RestoreSource: mariadbv1alpha1.RestoreSource{
+						BackupRef: &corev1.LocalObjectReference{
+							Name: "backup-test",
+						},
+					},
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbName,
+						},
+					},
+					Validations: []mariadbv1alpha1.RestoreRehearsalValidation{
+						{
+							Name: "ping",
+							Sql:  "SELECT 1",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &rehearsal)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(testCtx, &rehearsal)).To(Succeed())
+			})
+
+			By("Expecting RestoreRehearsal to be provisioning")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(&rehearsal), &rehearsal); err != nil {
+					return false
+				}
+				return rehearsal.Status.Phase == mariadbv1alpha1.RestoreRehearsalPhaseProvisioning &&
+					rehearsal.Status.StartTime != nil && !rehearsal.IsComplete()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting to create a temporary MariaDB")
+			var mdb mariadbv1alpha1.MariaDB
+			Eventually(func() bool {
+				return k8sClient.Get(testCtx, rehearsal.MariaDBKey(), &mdb) == nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(mdb.Labels).To(HaveKeyWithValue(labels.RestoreRehearsalLabel, rehearsal.Name))
+			Expect(metav1.IsControlledBy(&mdb, &rehearsal)).To(BeTrue())
+			Expect(mdb.Spec.Replicas).To(BeEquivalentTo(1))
+			Expect(mdb.Spec.Replication).To(BeNil())
+			Expect(mdb.Spec.Galera).To(BeNil())
+			Expect(mdb.Spec.BootstrapFrom).NotTo(BeNil())
+			Expect(mdb.Spec.BootstrapFrom.BackupRef).NotTo(BeNil())
+			Expect(mdb.Spec.BootstrapFrom.BackupRef.Name).To(Equal("backup-test"))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RoleReconciler reconciles a Role object
+type RoleReconciler struct {
+	client.Client
+	RefResolver     *refresolver.RefResolver
+	ConditionReady  *condition.Ready
+	RequeueInterval time.Duration
+	SqlOpts         []sqlClient.Opt
+}
+
+func NewRoleReconciler(client client.Client, refResolver *refresolver.RefResolver, conditionReady *condition.Ready,
+	requeueInterval time.Duration, sqlOpts ...sqlClient.Opt) *RoleReconciler {
+	return &RoleReconciler{
+		Client:          client,
+		RefResolver:     refResolver,
+		ConditionReady:  conditionReady,
+		RequeueInterval: requeueInterval,
+		SqlOpts:         sqlOpts,
+	}
+}
+
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=roles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mariadb.mmontes.io,resources=roles/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *RoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var role mariadbv1alpha1.Role
+	if err := r.Get(ctx, req.NamespacedName, &role); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	wr := newWrappedRoleReconciler(r.Client, &role)
+	wf := newWrappedRoleFinalizer(r.Client, &role)
+	tf := sql.NewSqlFinalizer(r.Client, wf, r.SqlOpts...)
+	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval, r.SqlOpts...)
+
+	result, err := tr.Reconcile(ctx, &role)
+	if err != nil {
+		return result, fmt.Errorf("error reconciling in TemplateReconciler: %v", err)
+	}
+	return result, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mariadbv1alpha1.Role{}).
+		Complete(priority.NewReconciler(priority.PriorityBulk, r))
+}
+
+type wrappedRoleReconciler struct {
+	client.Client
+	role *mariadbv1alpha1.Role
+}
+
+func newWrappedRoleReconciler(client client.Client, role *mariadbv1alpha1.Role) sql.WrappedReconciler {
+	return &wrappedRoleReconciler{
+		Client: client,
+		role:   role,
+	}
+}
+
+func (wr *wrappedRoleReconciler) Reconcile(ctx context.Context, mdbClient *sqlClient.Client) error {
+	if err := mdbClient.CreateRole(ctx, wr.role.RoleNameOrDefault()); err != nil {
+		return fmt.Errorf("error creating role in MariaDB: %v", err)
+	}
+	return nil
+}
+
+func (wr *wrappedRoleReconciler) PatchStatus(ctx context.Context, patcher condition.Patcher) error {
+	patch := client.MergeFrom(wr.role.DeepCopy())
+	patcher(&wr.role.Status)
+
+	if err := wr.Client.Status().Patch(ctx, wr.role, patch); err != nil {
+		return fmt.Errorf("error patching Role status: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/sql"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	roleFinalizerName = "role.mariadb.mmontes.io/finalizer"
+)
+
+type wrappedRoleFinalizer struct {
+	client.Client
+	role *mariadbv1alpha1.Role
+}
+
+func newWrappedRoleFinalizer(client client.Client, role *mariadbv1alpha1.Role) sql.WrappedFinalizer {
+	return &wrappedRoleFinalizer{
+		Client: client,
+		role:   role,
+	}
+}
+
+func (wf *wrappedRoleFinalizer) AddFinalizer(ctx context.Context) error {
+	if wf.ContainsFinalizer() {
+		return nil
+	}
+	return wf.patch(ctx, wf.role, func(role *mariadbv1alpha1.Role) {
+		controllerutil.AddFinalizer(role, roleFinalizerName)
+	})
+}
+
+func (wf *wrappedRoleFinalizer) RemoveFinalizer(ctx context.Context) error {
+	if !wf.ContainsFinalizer() {
+		return nil
+	}
+	return wf.patch(ctx, wf.role, func(role *mariadbv1alpha1.Role) {
+		controllerutil.RemoveFinalizer(role, roleFinalizerName)
+	})
+}
+
+func (wf *wrappedRoleFinalizer) ContainsFinalizer() bool {
+	return controllerutil.ContainsFinalizer(wf.role, roleFinalizerName)
+}
+
+func (wf *wrappedRoleFinalizer) Reconcile(ctx context.Context, mdbClient *sqlClient.Client) error {
+	if err := mdbClient.DropRole(ctx, wf.role.RoleNameOrDefault()); err != nil {
+		return fmt.Errorf("error dropping role in MariaDB: %v", err)
+	}
+	return nil
+}
+
+func (wf *wrappedRoleFinalizer) patch(ctx context.Context, role *mariadbv1alpha1.Role,
+	patchFn func(*mariadbv1alpha1.Role)) error {
+	patch := client.MergeFrom(role.DeepCopy())
+	patchFn(role)
+
+	if err := wf.Client.Patch(ctx, role, patch); err != nil {
+		return fmt.Errorf("error patching Role finalizer: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("Role controller", func() {
+	Context("When creating a Role", func() {
+		It("Should reconcile", func() {
+			By("Creating a Role")
+			roleKey := types.NamespacedName{
+				Name:      "role-test",
+				Namespace: testNamespace,
+			}
+			role := mariadbv1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      roleKey.Name,
+					Namespace: roleKey.Namespace,
+				},
+				Spec: mariadbv1alpha1.RoleSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbKey.Name,
+						},
+						WaitForIt: true,
+					},
+					Name: "role-test-readonly",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &role)).To(Succeed())
+
+			By("Expecting Role to be ready eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, roleKey, &role); err != nil {
+					return false
+				}
+				return role.IsReady()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Expecting Role to eventually have finalizer")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, roleKey, &role); err != nil {
+					return false
+				}
+				return controllerutil.ContainsFinalizer(&role, roleFinalizerName)
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Creating a Grant for the Role")
+			grantKey := types.NamespacedName{
+				Name:      "role-grant-test",
+				Namespace: testNamespace,
+			}
+			grant := mariadbv1alpha1.Grant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      grantKey.Name,
+					Namespace: grantKey.Namespace,
+				},
+				Spec: mariadbv1alpha1.GrantSpec{
+					SQLTemplate: mariadbv1alpha1.SQLTemplate{
+						RetryInterval: &metav1.Duration{Duration: 1 * time.Second},
+					},
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbKey.Name,
+						},
+						WaitForIt: true,
+					},
+					Privileges: []string{
+						"SELECT",
+					},
+					Database: "*",
+					Table:    "*",
+					Role:     roleKey.Name,
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &grant)).To(Succeed())
+
+			By("Expecting Grant to be ready eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, grantKey, &grant); err != nil {
+					return false
+				}
+				return grant.IsReady()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Creating a User with the Role")
+			userKey := types.NamespacedName{
+				Name:      "role-user-test",
+				Namespace: testNamespace,
+			}
+			user := mariadbv1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      userKey.Name,
+					Namespace: userKey.Namespace,
+				},
+				Spec: mariadbv1alpha1.UserSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbKey.Name,
+						},
+						WaitForIt: true,
+					},
+					PasswordSecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: testPwdKey.Name,
+						},
+						Key: testPwdSecretKey,
+					},
+					Roles: []string{
+						role.RoleNameOrDefault(),
+					},
+					DefaultRole:        role.RoleNameOrDefault(),
+					MaxUserConnections: 20,
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &user)).To(Succeed())
+
+			By("Expecting User to have the Role eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, userKey, &user); err != nil {
+					return false
+				}
+				return user.IsReady() && len(user.Status.Roles) == 1 && user.Status.Roles[0] == role.RoleNameOrDefault()
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Deleting User")
+			Expect(k8sClient.Delete(testCtx, &user)).To(Succeed())
+
+			By("Deleting Grant")
+			Expect(k8sClient.Delete(testCtx, &grant)).To(Succeed())
+
+			By("Deleting Role")
+			Expect(k8sClient.Delete(testCtx, &role)).To(Succeed())
+		})
+	})
+})
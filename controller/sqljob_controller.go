@@ -12,19 +12,29 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/configmap"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var (
 	jobConfigMapKey = "job.sql"
+
+	errWriteFrozen = errors.New("writes frozen")
+)
+
+const (
+	writeFreezeMariaDBField = ".spec.writeFreeze.mariaDbRef"
 )
 
 // SqlJobReconciler reconciles a SqlJob object
@@ -75,12 +85,28 @@ func (r *SqlJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, errors.New("MariaDB not ready")
 	}
 
+	restore, err := r.writeFreezingRestore(ctx, mariadb)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting Restores: %v", err)
+	}
+	var writeFrozenMsg string
+	if restore != nil {
+		writeFrozenMsg = fmt.Sprintf("Writes frozen by Restore '%s'", restore.Name)
+		log.FromContext(ctx).Info(writeFrozenMsg)
+	}
+
 	if err := r.reconcileConfigMap(ctx, &sqlJob, mariadb); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error reconciling ConfigMap: %v", err)
 	}
 
 	var jobErr *multierror.Error
-	err = r.reconcileBatch(ctx, &sqlJob, mariadb, req.NamespacedName)
+	err = r.reconcileBatch(ctx, &sqlJob, mariadb, req.NamespacedName, restore != nil)
+	if errors.Is(err, errWriteFrozen) {
+		if err := r.patchStatus(ctx, &sqlJob, r.ConditionComplete.PatcherFailed(writeFrozenMsg)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error patching SqlJob: %v", err)
+		}
+		return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+	}
 	jobErr = multierror.Append(jobErr, err)
 
 	patcher, err := r.patcher(ctx, &sqlJob, err, req.NamespacedName)
@@ -97,15 +123,31 @@ func (r *SqlJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	if err := jobErr.ErrorOrNil(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error reconciling SqlJob: %v", err)
 	}
+	if restore != nil {
+		return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
 func (r *SqlJobReconciler) waitForDependencies(ctx context.Context, sqlJob *v1alpha1.SqlJob) (bool, ctrl.Result, error) {
-	if sqlJob.Spec.DependsOn == nil {
+	if sqlJob.Spec.DependsOn == nil && sqlJob.Spec.DependsOnBackups == nil {
 		return true, ctrl.Result{}, nil
 	}
 	logger := log.FromContext(ctx)
 
+	msg, err := backupDependenciesMessage(ctx, r.RefResolver, sqlJob.Spec.DependsOnBackups, sqlJob.Namespace)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	// The SqlJob is reconciled again when the Backup dependencies change.
+	if msg != "" {
+		logger.Info(msg)
+		if err := r.patchStatus(ctx, sqlJob, r.ConditionComplete.PatcherWaiting(msg)); err != nil {
+			return false, ctrl.Result{}, err
+		}
+		return false, ctrl.Result{}, nil
+	}
+
 	for _, dep := range sqlJob.Spec.DependsOn {
 		sqlJobDep, err := r.RefResolver.SqlJob(ctx, &dep, sqlJob.Namespace)
 
@@ -119,6 +161,7 @@ func (r *SqlJobReconciler) waitForDependencies(ctx context.Context, sqlJob *v1al
 			if err := r.patchStatus(ctx, sqlJob, r.ConditionComplete.PatcherFailed(msg)); err != nil {
 				return false, ctrl.Result{}, err
 			}
+			return false, ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
 		}
 		if !sqlJobDep.IsComplete() {
 			msg := fmt.Sprintf("Dependency '%s' not ready", dep.Name)
@@ -133,6 +176,22 @@ func (r *SqlJobReconciler) waitForDependencies(ctx context.Context, sqlJob *v1al
 	return true, ctrl.Result{}, nil
 }
 
+func (r *SqlJobReconciler) writeFreezingRestore(ctx context.Context,
+	mariadb *mariadbv1alpha1.MariaDB) (*mariadbv1alpha1.Restore, error) {
+	var restoreList mariadbv1alpha1.RestoreList
+	if err := r.List(ctx, &restoreList, client.MatchingFields{
+		writeFreezeMariaDBField: writeFreezeIndexValue(mariadb.Name, mariadb.Namespace),
+	}); err != nil {
+		return nil, err
+	}
+	for _, restore := range restoreList.Items {
+		if !restore.IsComplete() {
+			return &restore, nil
+		}
+	}
+	return nil, nil
+}
+
 func (r *SqlJobReconciler) reconcileConfigMap(ctx context.Context, sqlJob *mariadbv1alpha1.SqlJob,
 	mariadb *mariadbv1alpha1.MariaDB) error {
 	key := configMapSqlJobKey(sqlJob)
@@ -163,12 +222,14 @@ func (r *SqlJobReconciler) reconcileConfigMap(ctx context.Context, sqlJob *maria
 	})
 }
 
+// reconcileBatch reconciles the Job or CronJob of the SqlJob. When writes are frozen, no new Jobs are created:
+// a Job that does not exist yet is not created and the CronJob is suspended, but the existing ones are still reconciled.
 func (r *SqlJobReconciler) reconcileBatch(ctx context.Context, sqlJob *mariadbv1alpha1.SqlJob,
-	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) error {
+	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName, writeFrozen bool) error {
 	if sqlJob.Spec.Schedule != nil {
-		return r.reconcileCronJob(ctx, sqlJob, mariadb, key)
+		return r.reconcileCronJob(ctx, sqlJob, mariadb, key, writeFrozen)
 	}
-	return r.reconcileJob(ctx, sqlJob, mariadb, key)
+	return r.reconcileJob(ctx, sqlJob, mariadb, key, writeFrozen)
 }
 
 func (r *SqlJobReconciler) patcher(ctx context.Context, sqlJob *mariadbv1alpha1.SqlJob, err error,
@@ -180,7 +241,7 @@ func (r *SqlJobReconciler) patcher(ctx context.Context, sqlJob *mariadbv1alpha1.
 }
 
 func (r *SqlJobReconciler) reconcileJob(ctx context.Context, sqlJob *mariadbv1alpha1.SqlJob,
-	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) error {
+	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName, writeFrozen bool) error {
 	desiredJob, err := r.Builder.BuildSqlJob(key, sqlJob, mariadb)
 	if err != nil {
 		return fmt.Errorf("error building Job: %v", err)
@@ -191,6 +252,13 @@ func (r *SqlJobReconciler) reconcileJob(ctx context.Context, sqlJob *mariadbv1al
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("error getting Job: %v", err)
 		}
+		// the Job has already been garbage collected after finishing, it must not be executed again.
+		if sqlJob.IsComplete() {
+			return nil
+		}
+		if writeFrozen {
+			return errWriteFrozen
+		}
 
 		if err := r.Create(ctx, desiredJob); err != nil {
 			return fmt.Errorf("error creating Job: %v", err)
@@ -200,6 +268,8 @@ func (r *SqlJobReconciler) reconcileJob(ctx context.Context, sqlJob *mariadbv1al
 
 	patch := client.MergeFrom(existingJob.DeepCopy())
 	existingJob.Spec.BackoffLimit = desiredJob.Spec.BackoffLimit
+	existingJob.Spec.ActiveDeadlineSeconds = desiredJob.Spec.ActiveDeadlineSeconds
+	existingJob.Spec.TTLSecondsAfterFinished = desiredJob.Spec.TTLSecondsAfterFinished
 
 	if err := r.Patch(ctx, &existingJob, patch); err != nil {
 		return fmt.Errorf("error patching Job: %v", err)
@@ -208,11 +278,14 @@ func (r *SqlJobReconciler) reconcileJob(ctx context.Context, sqlJob *mariadbv1al
 }
 
 func (r *SqlJobReconciler) reconcileCronJob(ctx context.Context, sqlJob *mariadbv1alpha1.SqlJob,
-	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) error {
+	mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName, writeFrozen bool) error {
 	desiredCronJob, err := r.Builder.BuildSqlCronJob(key, sqlJob, mariadb)
 	if err != nil {
 		return fmt.Errorf("error building CronJob: %v", err)
 	}
+	if writeFrozen {
+		desiredCronJob.Spec.Suspend = ptr.To(true)
+	}
 
 	var existingCronJob batchv1.CronJob
 	if err := r.Get(ctx, key, &existingCronJob); err != nil {
@@ -229,7 +302,12 @@ func (r *SqlJobReconciler) reconcileCronJob(ctx context.Context, sqlJob *mariadb
 	patch := client.MergeFrom(existingCronJob.DeepCopy())
 	existingCronJob.Spec.Schedule = desiredCronJob.Spec.Schedule
 	existingCronJob.Spec.Suspend = desiredCronJob.Spec.Suspend
+	existingCronJob.Spec.SuccessfulJobsHistoryLimit = desiredCronJob.Spec.SuccessfulJobsHistoryLimit
+	existingCronJob.Spec.FailedJobsHistoryLimit = desiredCronJob.Spec.FailedJobsHistoryLimit
 	existingCronJob.Spec.JobTemplate.Spec.BackoffLimit = desiredCronJob.Spec.JobTemplate.Spec.BackoffLimit
+	existingCronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished = desiredCronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished
+	existingCronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds = desiredCronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds
+	existingCronJob.Spec.JobTemplate.Spec.PodFailurePolicy = desiredCronJob.Spec.JobTemplate.Spec.PodFailurePolicy
 
 	if err := r.Patch(ctx, &existingCronJob, patch); err != nil {
 		return fmt.Errorf("error patching CronJob: %v", err)
@@ -268,10 +346,49 @@ func configMapSqlJobKey(sqlJob *mariadbv1alpha1.SqlJob) types.NamespacedName {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SqlJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := r.createIndex(mgr); err != nil {
+		return fmt.Errorf("error creating index: %v", err)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&mariadbv1alpha1.SqlJob{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&batchv1.CronJob{}).
 		Owns(&batchv1.Job{}).
-		Complete(r)
+		Watches(
+			&mariadbv1alpha1.Backup{},
+			handler.EnqueueRequestsFromMapFunc(r.mapBackupToRequests),
+		).
+		Complete(priority.NewReconciler(priority.PriorityNormal, r))
+}
+
+func (r *SqlJobReconciler) mapBackupToRequests(ctx context.Context, backup client.Object) []reconcile.Request {
+	return mapBackupToDependents(ctx, r.Client, &mariadbv1alpha1.SqlJobList{}, backup)
+}
+
+// createIndex indexes the Restores that freeze the writes by the MariaDB they refer to, so the SqlJobs do not need
+// to list all the Restores of the cluster. It also indexes the SqlJobs by the Backups they depend on.
+func (r *SqlJobReconciler) createIndex(mgr ctrl.Manager) error {
+	indexFn := func(rawObj client.Object) []string {
+		restore := rawObj.(*mariadbv1alpha1.Restore)
+		if !restore.Spec.WriteFreeze {
+			return nil
+		}
+		ref := restore.Spec.MariaDBRef
+		namespace := restore.Namespace
+		if ref.Namespace != "" {
+			namespace = ref.Namespace
+		}
+		return []string{writeFreezeIndexValue(ref.Name, namespace)}
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mariadbv1alpha1.Restore{}, writeFreezeMariaDBField,
+		indexFn); err != nil {
+		return fmt.Errorf("error indexing '%s' field in Restore: %v", writeFreezeMariaDBField, err)
+	}
+	return indexBackupDependencies(mgr, &mariadbv1alpha1.SqlJob{}, func(obj client.Object) []corev1.LocalObjectReference {
+		return obj.(*mariadbv1alpha1.SqlJob).Spec.DependsOnBackups
+	})
+}
+
+func writeFreezeIndexValue(name, namespace string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
 }
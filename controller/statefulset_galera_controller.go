@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
 	"github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	"github.com/mariadb-operator/mariadb-operator/pkg/predicate"
@@ -30,6 +31,7 @@ type StatefulSetGaleraReconciler struct {
 	client.Client
 	Recorder    record.EventRecorder
 	RefResolver *refresolver.RefResolver
+	SqlOpts     []sqlClient.Opt
 }
 
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
@@ -50,7 +52,7 @@ func (r *StatefulSetGaleraReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	if !mariadb.Galera().Enabled || !mariadb.Galera().Recovery.Enabled ||
-		!mariadb.HasGaleraConfiguredCondition() || mariadb.HasGaleraNotReadyCondition() {
+		!mariadb.HasGaleraConfiguredCondition() || mariadb.HasGaleraNotReadyCondition() || mariadb.IsHibernated() {
 		return ctrl.Result{}, nil
 	}
 	logger := log.FromContext(ctx).WithName("galera").WithName("health")
@@ -96,7 +98,7 @@ func (r *StatefulSetGaleraReconciler) pollUntilHealthyWithTimeout(ctx context.Co
 func (r *StatefulSetGaleraReconciler) isHealthy(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, sts *appsv1.StatefulSet,
 	logger logr.Logger) (bool, error) {
 	logger.V(1).Info("StatefulSet ready replicas", "replicas", sts.Status.ReadyReplicas)
-	if sts.Status.ReadyReplicas == mariadb.Spec.Replicas {
+	if sts.Status.ReadyReplicas == mariadb.ScaledReplicas() {
 		return true, nil
 	}
 	if sts.Status.ReadyReplicas == 0 {
@@ -106,7 +108,7 @@ func (r *StatefulSetGaleraReconciler) isHealthy(ctx context.Context, mariadb *ma
 	clientCtx, cancelClient := context.WithTimeout(ctx, 5*time.Second)
 	defer cancelClient()
 
-	clientSet := sqlClientSet.NewClientSet(mariadb, r.RefResolver)
+	clientSet := sqlClientSet.NewClientSet(mariadb, r.RefResolver, r.SqlOpts...)
 	defer clientSet.Close()
 	client, err := r.readyClient(clientCtx, mariadb, clientSet)
 	if err != nil {
@@ -127,7 +129,7 @@ func (r *StatefulSetGaleraReconciler) isHealthy(ctx context.Context, mariadb *ma
 		return false, fmt.Errorf("error getting Galera cluster size: %v", err)
 	}
 	logger.V(1).Info("Galera cluster size", "size", size)
-	if size != int(mariadb.Spec.Replicas) {
+	if size != mariadb.GaleraClusterSize() {
 		return false, nil
 	}
 
@@ -136,7 +138,7 @@ func (r *StatefulSetGaleraReconciler) isHealthy(ctx context.Context, mariadb *ma
 
 func (r *StatefulSetGaleraReconciler) readyClient(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
 	clientSet *sqlClientSet.ClientSet) (*sqlClient.Client, error) {
-	for i := 0; i < int(mariadb.Spec.Replicas); i++ {
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
 		key := types.NamespacedName{
 			Name:      statefulset.PodName(mariadb.ObjectMeta, i),
 			Namespace: mariadb.Namespace,
@@ -175,5 +177,5 @@ func (r *StatefulSetGaleraReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				},
 			),
 		).
-		Complete(r)
+		Complete(priority.NewReconciler(priority.PriorityCritical, r))
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/audit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/batch"
@@ -14,6 +15,7 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/deployment"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/endpoints"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/galera"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/rbac"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/replication"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
@@ -104,6 +106,7 @@ var _ = BeforeSuite(func() {
 
 	builder := builder.NewBuilder(scheme, env)
 	refResolver := refresolver.New(client)
+	auditor := audit.NewAuditor(client)
 
 	conditionReady := condition.NewReady()
 	conditionComplete := condition.NewComplete(client)
@@ -116,6 +119,7 @@ var _ = BeforeSuite(func() {
 	rbacReconciler := rbac.NewRBACReconiler(client, builder)
 	deployReconciler := deployment.NewDeploymentReconciler(client)
 	svcMonitorReconciler := servicemonitor.NewServiceMonitorReconciler(client)
+	actionLimiter := ratelimit.NewActionLimiter(client, k8sManager.GetEventRecorderFor("mariadb"))
 
 	replConfig := replication.NewReplicationConfig(client, builder, secretReconciler)
 	replicationReconciler := replication.NewReplicationReconciler(
@@ -135,6 +139,8 @@ var _ = BeforeSuite(func() {
 		galera.WithRefResolver(refResolver),
 		galera.WithConfigMapReconciler(configMapReconciler),
 		galera.WithServiceReconciler(serviceReconciler),
+		galera.WithDeploymentReconciler(deployReconciler),
+		galera.WithActionLimiter(actionLimiter),
 	)
 
 	podReplicationController := NewPodController(
@@ -146,6 +152,7 @@ var _ = BeforeSuite(func() {
 			builder,
 			refResolver,
 			replConfig,
+			actionLimiter,
 		),
 		[]string{
 			metadata.MariadbAnnotation,
@@ -155,7 +162,7 @@ var _ = BeforeSuite(func() {
 	podGaleraController := NewPodController(
 		client,
 		refResolver,
-		NewPodGaleraController(client, galeraRecorder),
+		NewPodGaleraController(client, galeraRecorder, actionLimiter),
 		[]string{
 			metadata.MariadbAnnotation,
 			metadata.GaleraAnnotation,
@@ -172,6 +179,7 @@ var _ = BeforeSuite(func() {
 		RefResolver:     refResolver,
 		ConditionReady:  conditionReady,
 		DiscoveryClient: discoveryClient,
+		Auditor:         auditor,
 
 		ConfigMapReconciler:      configMapReconciler,
 		SecretReconciler:         secretReconciler,
@@ -180,6 +188,7 @@ var _ = BeforeSuite(func() {
 		RBACReconciler:           rbacReconciler,
 		DeploymentReconciler:     deployReconciler,
 		ServiceMonitorReconciler: svcMonitorReconciler,
+		ActionLimiter:            actionLimiter,
 
 		ReplicationReconciler: replicationReconciler,
 		GaleraReconciler:      galeraReconciler,
@@ -193,6 +202,7 @@ var _ = BeforeSuite(func() {
 		RefResolver:       refResolver,
 		ConditionComplete: conditionComplete,
 		BatchReconciler:   batchReconciler,
+		Recorder:          k8sManager.GetEventRecorderFor("backup"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -206,13 +216,18 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = NewUserReconciler(client, refResolver, conditionReady, 5*time.Second).SetupWithManager(k8sManager)
+	err = NewUserReconciler(client, refResolver, conditionReady,
+		k8sManager.GetEventRecorderFor("user"), 5*time.Second).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = NewGrantReconciler(client, refResolver, conditionReady, 5*time.Second).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
-	err = NewDatabaseReconciler(client, refResolver, conditionReady, 5*time.Second).SetupWithManager(k8sManager)
+	err = NewRoleReconciler(client, refResolver, conditionReady, 5*time.Second).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = NewDatabaseReconciler(client, refResolver, conditionReady,
+		k8sManager.GetEventRecorderFor("database"), 5*time.Second).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&ConnectionReconciler{
@@ -236,12 +251,59 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&MaintenanceJobReconciler{
+		Client:            client,
+		Scheme:            scheme,
+		Builder:           builder,
+		RefResolver:       refResolver,
+		ConditionComplete: conditionComplete,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MariaDBFleetReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Builder: builder,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&RestoreRehearsalReconciler{
+		Client:      client,
+		Scheme:      scheme,
+		Builder:     builder,
+		RefResolver: refResolver,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MariaDBTestReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Builder: builder,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MaxScaleReconciler{
+		Client:               client,
+		Scheme:               scheme,
+		Builder:              builder,
+		RefResolver:          refResolver,
+		ConditionReady:       conditionReady,
+		Environment:          env,
+		SecretReconciler:     secretReconciler,
+		ServiceReconciler:    serviceReconciler,
+		DeploymentReconciler: deployReconciler,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = podReplicationController.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = podGaleraController.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = NewPodGaleraSegmentController(client, refResolver).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&StatefulSetGaleraReconciler{
 		Client:      client,
 		RefResolver: refResolver,
@@ -3,13 +3,16 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/priority"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/sql"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
 	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,16 +23,20 @@ type UserReconciler struct {
 	client.Client
 	RefResolver     *refresolver.RefResolver
 	ConditionReady  *condition.Ready
+	Recorder        record.EventRecorder
 	RequeueInterval time.Duration
+	SqlOpts         []sqlClient.Opt
 }
 
 func NewUserReconciler(client client.Client, refResolver *refresolver.RefResolver, conditionReady *condition.Ready,
-	requeueInterval time.Duration) *UserReconciler {
+	recorder record.EventRecorder, requeueInterval time.Duration, sqlOpts ...sqlClient.Opt) *UserReconciler {
 	return &UserReconciler{
 		Client:          client,
 		RefResolver:     refResolver,
 		ConditionReady:  conditionReady,
+		Recorder:        recorder,
 		RequeueInterval: requeueInterval,
+		SqlOpts:         sqlOpts,
 	}
 }
 
@@ -45,10 +52,10 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, ctrlClient.IgnoreNotFound(err)
 	}
 
-	wr := newWrapperUserReconciler(r.Client, r.RefResolver, &user)
+	wr := newWrapperUserReconciler(r.Client, r.RefResolver, r.Recorder, &user)
 	wf := newWrappedUserFinalizer(r.Client, &user)
-	tf := sql.NewSqlFinalizer(r.Client, wf)
-	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval)
+	tf := sql.NewSqlFinalizer(r.Client, wf, r.SqlOpts...)
+	tr := sql.NewSqlReconciler(r.Client, r.ConditionReady, wr, tf, r.RequeueInterval, r.SqlOpts...)
 
 	result, err := tr.Reconcile(ctx, &user)
 	if err != nil {
@@ -61,36 +68,103 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&mariadbv1alpha1.User{}).
-		Complete(r)
+		Complete(priority.NewReconciler(priority.PriorityBulk, r))
 }
 
 type wrappedUserReconciler struct {
 	client.Client
 	refResolver *refresolver.RefResolver
+	recorder    record.EventRecorder
 	user        *mariadbv1alpha1.User
 }
 
-func newWrapperUserReconciler(client client.Client, refResolver *refresolver.RefResolver,
+func newWrapperUserReconciler(client client.Client, refResolver *refresolver.RefResolver, recorder record.EventRecorder,
 	user *mariadbv1alpha1.User) sql.WrappedReconciler {
 	return &wrappedUserReconciler{
 		Client:      client,
 		refResolver: refResolver,
+		recorder:    recorder,
 		user:        user,
 	}
 }
 
 func (wr *wrappedUserReconciler) Reconcile(ctx context.Context, mdbClient *sqlClient.Client) error {
-	password, err := wr.refResolver.SecretKeyRef(ctx, wr.user.Spec.PasswordSecretKeyRef, wr.user.Namespace)
-	if err != nil {
-		return fmt.Errorf("error reading user password secret: %v", err)
+	var password, passwordHash string
+	if ref := wr.user.Spec.PasswordSecretKeyRef; ref != nil {
+		var err error
+		password, err = wr.refResolver.SecretKeyRef(ctx, *ref, wr.user.Namespace)
+		if err != nil {
+			return fmt.Errorf("error reading user password secret: %v", err)
+		}
+	}
+	if ref := wr.user.Spec.PasswordHashSecretKeyRef; ref != nil {
+		var err error
+		passwordHash, err = wr.refResolver.SecretKeyRef(ctx, *ref, wr.user.Namespace)
+		if err != nil {
+			return fmt.Errorf("error reading user password hash secret: %v", err)
+		}
+		if err := wr.user.Spec.AuthenticationPlugin.ValidatePasswordHash(passwordHash); err != nil {
+			return fmt.Errorf("invalid user password hash: %v", err)
+		}
+	}
+	plugin := string(wr.user.Spec.AuthenticationPlugin)
+	if plugin != "" {
+		if err := mdbClient.EnsureAuthPlugin(ctx, plugin); err != nil {
+			return fmt.Errorf("error installing authentication plugin in MariaDB: %v", err)
+		}
 	}
 
 	opts := sqlClient.CreateUserOpts{
-		IdentifiedBy:       password,
-		MaxUserConnections: wr.user.Spec.MaxUserConnections,
+		IdentifiedBy:         password,
+		IdentifiedByPassword: passwordHash,
+		IdentifiedVia:        plugin,
+		MaxUserConnections:   wr.user.Spec.MaxUserConnections,
+	}
+	hosts := wr.user.HostsOrDefault()
+	for _, host := range hosts {
+		if err := mdbClient.CreateUser(ctx, wr.user.AccountNameWithHost(host), opts); err != nil {
+			return fmt.Errorf("error creating user in MariaDB: %v", err)
+		}
+		if err := wr.reconcileAuthPlugin(ctx, mdbClient, host, password, passwordHash); err != nil {
+			return fmt.Errorf("error reconciling user authentication plugin in MariaDB: %v", err)
+		}
+		if err := wr.reconcilePasswordHash(ctx, mdbClient, host, passwordHash); err != nil {
+			return fmt.Errorf("error reconciling user password hash in MariaDB: %v", err)
+		}
+		if err := wr.reconcileMaxStatementTime(ctx, mdbClient, host); err != nil {
+			return fmt.Errorf("error reconciling user max statement time in MariaDB: %v", err)
+		}
+		if err := wr.reconcileRequire(ctx, mdbClient, host); err != nil {
+			return fmt.Errorf("error reconciling user TLS requirement in MariaDB: %v", err)
+		}
+	}
+	// The accounts of the removed hosts are dropped once the accounts of the new hosts are in place.
+	for _, host := range wr.user.AppliedHosts() {
+		if slices.Contains(hosts, host) {
+			continue
+		}
+		if err := mdbClient.DropUser(ctx, wr.user.AccountNameWithHost(host)); err != nil {
+			return fmt.Errorf("error dropping user in MariaDB: %v", err)
+		}
+	}
+
+	if !slices.Equal(hosts, wr.user.Status.Hosts) {
+		patch := client.MergeFrom(wr.user.DeepCopy())
+		wr.user.Status.Hosts = hosts
+		if err := wr.Client.Status().Patch(ctx, wr.user, patch); err != nil {
+			return fmt.Errorf("error patching User hosts: %v", err)
+		}
+	}
+
+	if err := wr.reconcileRoles(ctx, mdbClient, hosts); err != nil {
+		return fmt.Errorf("error reconciling user roles in MariaDB: %v", err)
+	}
+
+	if err := wr.reconcilePasswordRotation(ctx, mdbClient, password); err != nil {
+		return fmt.Errorf("error reconciling password rotation: %v", err)
 	}
-	if err := mdbClient.CreateUser(ctx, wr.user.AccountName(), opts); err != nil {
-		return fmt.Errorf("error creating user in MariaDB: %v", err)
+	if err := wr.reconcilePasswordExpiration(ctx, mdbClient); err != nil {
+		return fmt.Errorf("error reconciling password expiration: %v", err)
 	}
 	return nil
 }
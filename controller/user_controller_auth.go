@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileAuthPlugin identifies the account of a host via the authentication plugin of the User whenever they do not match,
+// i.e. when the plugin is changed in an existing User or the account has been altered directly in MariaDB.
+func (wr *wrappedUserReconciler) reconcileAuthPlugin(ctx context.Context, mdbClient *sqlClient.Client, host, password,
+	passwordHash string) error {
+	desired := string(wr.user.Spec.AuthenticationPlugin)
+	if desired == "" {
+		return nil
+	}
+	current, err := mdbClient.UserAuthPlugin(ctx, wr.user.Username(), host)
+	if err != nil {
+		return fmt.Errorf("error getting authentication plugin: %v", err)
+	}
+	if current == desired {
+		return nil
+	}
+
+	accountName := wr.user.AccountNameWithHost(host)
+	if err := mdbClient.AlterUserAuthPlugin(ctx, accountName, desired, password, passwordHash); err != nil {
+		return fmt.Errorf("error altering authentication plugin: %v", err)
+	}
+	log.FromContext(ctx).Info("Altered authentication plugin", "account", accountName, "from", current, "to", desired)
+	wr.recorder.Eventf(wr.user, corev1.EventTypeNormal, mariadbv1alpha1.ReasonUserAuthPluginDrift,
+		"Authentication plugin of account %s changed from '%s' to '%s'", accountName, current, desired)
+	return nil
+}
+
+// reconcilePasswordHash identifies the account of a host by the password hash of the User whenever they do not match,
+// i.e. when the hash is updated in the Secret or the password has been changed directly in MariaDB.
+func (wr *wrappedUserReconciler) reconcilePasswordHash(ctx context.Context, mdbClient *sqlClient.Client, host, passwordHash string) error {
+	if passwordHash == "" {
+		return nil
+	}
+	current, err := mdbClient.UserAuthenticationString(ctx, wr.user.Username(), host)
+	if err != nil {
+		return fmt.Errorf("error getting password hash: %v", err)
+	}
+	if strings.EqualFold(current, passwordHash) {
+		return nil
+	}
+
+	accountName := wr.user.AccountNameWithHost(host)
+	if err := mdbClient.AlterUserPasswordHash(ctx, accountName, string(wr.user.Spec.AuthenticationPlugin), passwordHash); err != nil {
+		return fmt.Errorf("error altering password hash: %v", err)
+	}
+	log.FromContext(ctx).Info("Altered password hash", "account", accountName)
+	wr.recorder.Eventf(wr.user, corev1.EventTypeNormal, mariadbv1alpha1.ReasonUserPasswordHashDrift,
+		"Password hash of account %s updated", accountName)
+	return nil
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/expiry"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const passwordExpirationWarning = 7 * 24 * time.Hour
+
+// passwordExpirationVersion is the first version that tracks when passwords are changed and supports password_lifetime.
+var passwordExpirationVersion = sqlClient.Version{Major: 10, Minor: 4, Patch: 3}
+
+// reconcilePasswordExpiration reports when the password of the User was last changed and when it expires,
+// according to the password expiration policy of MariaDB. When the User has multiple hosts, the oldest password is reported.
+func (wr *wrappedUserReconciler) reconcilePasswordExpiration(ctx context.Context, mdbClient *sqlClient.Client) error {
+	if err := mdbClient.RequireVersion(ctx, "password expiration", passwordExpirationVersion); err != nil {
+		if sqlClient.IsUnsupported(err) {
+			return wr.patchPasswordExpirationUnsupported(ctx, err)
+		}
+		return err
+	}
+
+	var lastChanged, expiresAt *time.Time
+	for _, host := range wr.user.HostsOrDefault() {
+		policy, err := mdbClient.PasswordPolicy(ctx, wr.user.Username(), host)
+		if err != nil {
+			return fmt.Errorf("error getting password policy: %v", err)
+		}
+		if policy.LastChanged == nil {
+			continue
+		}
+		if lastChanged == nil || policy.LastChanged.Before(*lastChanged) {
+			lastChanged = policy.LastChanged
+		}
+		hostExpiresAt := expiry.PasswordExpiration(*policy.LastChanged, policy.LifetimeDays, policy.DefaultLifetimeDays)
+		if hostExpiresAt != nil && (expiresAt == nil || hostExpiresAt.Before(*expiresAt)) {
+			expiresAt = hostExpiresAt
+		}
+	}
+	if lastChanged == nil {
+		return nil
+	}
+	expiry.SetPasswordExpiration(client.ObjectKeyFromObject(wr.user), *lastChanged, expiresAt)
+
+	now := time.Now()
+	expiring := expiresAt != nil && expiry.IsExpiring(*expiresAt, now, passwordExpirationWarning)
+	expired := expiresAt != nil && expiry.IsExpired(*expiresAt, now)
+	wasExpiring := wr.user.IsPasswordExpiring()
+	wasExpired := wasExpiring && meta.FindStatusCondition(wr.user.Status.Conditions,
+		mariadbv1alpha1.ConditionTypePasswordExpiring).Reason == mariadbv1alpha1.ConditionReasonPasswordExpired
+
+	patch := client.MergeFrom(wr.user.DeepCopy())
+	wr.user.Status.PasswordLastChanged = &metav1.Time{Time: *lastChanged}
+	wr.user.Status.PasswordExpiresAt = nil
+	if expiresAt != nil {
+		wr.user.Status.PasswordExpiresAt = &metav1.Time{Time: *expiresAt}
+	}
+	switch {
+	case expired:
+		condition.SetPasswordExpired(&wr.user.Status, *expiresAt)
+	case expiring:
+		condition.SetPasswordExpiring(&wr.user.Status, *expiresAt)
+	default:
+		condition.SetPasswordNotExpiring(&wr.user.Status, expiresAt)
+	}
+	if err := wr.Client.Status().Patch(ctx, wr.user, patch); err != nil {
+		return fmt.Errorf("error patching User status: %v", err)
+	}
+
+	if expired && !wasExpired {
+		wr.recorder.Eventf(wr.user, corev1.EventTypeWarning, mariadbv1alpha1.ReasonUserPasswordExpired,
+			"Password of user '%s' expired at %s", wr.user.Username(), expiresAt.UTC().Format(time.RFC3339))
+	} else if expiring && !expired && !wasExpiring {
+		wr.recorder.Eventf(wr.user, corev1.EventTypeWarning, mariadbv1alpha1.ReasonUserPasswordExpiring,
+			"Password of user '%s' expires at %s", wr.user.Username(), expiresAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// patchPasswordExpirationUnsupported reports in the PasswordExpiring condition that the server version does not track
+// password changes, as the User is otherwise reconciled successfully.
+func (wr *wrappedUserReconciler) patchPasswordExpirationUnsupported(ctx context.Context, err error) error {
+	message := fmt.Sprintf("Unsupported by MariaDB: %v", err)
+	if c := meta.FindStatusCondition(wr.user.Status.Conditions, mariadbv1alpha1.ConditionTypePasswordExpiring); c != nil &&
+		c.Reason == mariadbv1alpha1.ConditionReasonUnsupported && c.Message == message {
+		return nil
+	}
+	patch := client.MergeFrom(wr.user.DeepCopy())
+	condition.SetPasswordExpirationUnsupported(&wr.user.Status, message)
+	if err := wr.Client.Status().Patch(ctx, wr.user, patch); err != nil {
+		return fmt.Errorf("error patching User status: %v", err)
+	}
+	return nil
+}
@@ -3,9 +3,11 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/expiry"
 	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -50,9 +52,18 @@ func (wf *wrappedUserFinalizer) ContainsFinalizer() bool {
 }
 
 func (wf *wrappedUserFinalizer) Reconcile(ctx context.Context, mdbClient *sqlClient.Client) error {
-	if err := mdbClient.DropUser(ctx, wf.user.AccountName()); err != nil {
-		return fmt.Errorf("error dropping user in MariaDB: %v", err)
+	hosts := slices.Clone(wf.user.HostsOrDefault())
+	for _, host := range wf.user.AppliedHosts() {
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
 	}
+	for _, host := range hosts {
+		if err := mdbClient.DropUser(ctx, wf.user.AccountNameWithHost(host)); err != nil {
+			return fmt.Errorf("error dropping user in MariaDB: %v", err)
+		}
+	}
+	expiry.DeletePasswordExpiration(client.ObjectKeyFromObject(wf.user))
 	return nil
 }
 
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileRequire applies the TLS requirement of the User to the account of a host whenever they do not match,
+// which also reverts the changes performed directly in MariaDB, i.e. via ALTER USER.
+func (wr *wrappedUserReconciler) reconcileRequire(ctx context.Context, mdbClient *sqlClient.Client, host string) error {
+	require := wr.user.Spec.Require
+	if require == nil {
+		return nil
+	}
+	desired := sqlClient.UserRequire{
+		Type:    string(require.Type),
+		Subject: require.Subject,
+		Issuer:  require.Issuer,
+	}
+	current, err := mdbClient.UserRequire(ctx, wr.user.Username(), host)
+	if err != nil {
+		return fmt.Errorf("error getting TLS requirement: %v", err)
+	}
+	if *current == desired {
+		return nil
+	}
+
+	accountName := wr.user.AccountNameWithHost(host)
+	if err := mdbClient.AlterUserRequire(ctx, accountName, desired); err != nil {
+		return fmt.Errorf("error altering TLS requirement: %v", err)
+	}
+	log.FromContext(ctx).Info("Altered TLS requirement", "account", accountName, "from", current.Clause(), "to", desired.Clause())
+	wr.recorder.Eventf(wr.user, corev1.EventTypeNormal, mariadbv1alpha1.ReasonUserRequireDrift,
+		"TLS requirement of account %s changed from '%s' to '%s'", accountName, current.Clause(), desired.Clause())
+	return nil
+}
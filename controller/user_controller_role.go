@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileRoles grants the roles of the User to the accounts of all hosts, and revokes the ones that have been removed
+// from the User since they were granted. The default role is only unset when it is one of the roles managed by the User,
+// so the default roles set directly in MariaDB are left untouched.
+func (wr *wrappedUserReconciler) reconcileRoles(ctx context.Context, mdbClient *sqlClient.Client, hosts []string) error {
+	roles := wr.user.Spec.Roles
+	var revoked []string
+	for _, role := range wr.user.Status.Roles {
+		if !slices.Contains(roles, role) {
+			revoked = append(revoked, role)
+		}
+	}
+	logger := log.FromContext(ctx)
+
+	for _, host := range hosts {
+		accountName := wr.user.AccountNameWithHost(host)
+		current, err := mdbClient.AccountRoles(ctx, wr.user.Username(), host)
+		if err != nil {
+			return fmt.Errorf("error getting roles: %v", err)
+		}
+		for _, role := range roles {
+			if slices.Contains(current, role) {
+				continue
+			}
+			if err := mdbClient.GrantRole(ctx, role, accountName); err != nil {
+				return fmt.Errorf("error granting role '%s': %v", role, err)
+			}
+			logger.Info("Granted role", "account", accountName, "role", role)
+		}
+		for _, role := range revoked {
+			if !slices.Contains(current, role) {
+				continue
+			}
+			if err := mdbClient.RevokeRole(ctx, role, accountName); err != nil {
+				return fmt.Errorf("error revoking role '%s': %v", role, err)
+			}
+			logger.Info("Revoked role", "account", accountName, "role", role)
+		}
+
+		currentDefault, err := mdbClient.AccountDefaultRole(ctx, wr.user.Username(), host)
+		if err != nil {
+			return fmt.Errorf("error getting default role: %v", err)
+		}
+		desiredDefault := wr.user.Spec.DefaultRole
+		if currentDefault == desiredDefault ||
+			(desiredDefault == "" && !slices.Contains(roles, currentDefault) && !slices.Contains(revoked, currentDefault)) {
+			continue
+		}
+		if err := mdbClient.SetDefaultRole(ctx, accountName, desiredDefault); err != nil {
+			return fmt.Errorf("error setting default role: %v", err)
+		}
+		logger.Info("Set default role", "account", accountName, "from", currentDefault, "to", desiredDefault)
+	}
+
+	if !slices.Equal(roles, wr.user.Status.Roles) {
+		patch := client.MergeFrom(wr.user.DeepCopy())
+		wr.user.Status.Roles = roles
+		if err := wr.Client.Status().Patch(ctx, wr.user, patch); err != nil {
+			return fmt.Errorf("error patching User roles: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcilePasswordRotation sets a new random password to the accounts of the User once the rotation interval has elapsed
+// since the last rotation. The new password is persisted under a pending key of the Secret before altering the accounts, so
+// it is not lost if the rotation is interrupted: the next attempt resumes with the pending password, which is moved to the
+// password key once the accounts have been altered. The Connections using the Secret are refreshed afterwards, which is
+// retried in the subsequent reconciliations until all of them have been refreshed.
+func (wr *wrappedUserReconciler) reconcilePasswordRotation(ctx context.Context, mdbClient *sqlClient.Client, password string) error {
+	rotation := wr.user.Spec.PasswordRotation
+	secretKeyRef := wr.user.Spec.PasswordSecretKeyRef
+	if rotation == nil || secretKeyRef == nil {
+		return nil
+	}
+	now := time.Now()
+	lastRotated := wr.user.Status.PasswordLastRotated
+	if lastRotated == nil {
+		return wr.patchPasswordLastRotated(ctx, now)
+	}
+	passwordSecret, err := wr.passwordSecret(ctx, *secretKeyRef)
+	if err != nil {
+		return err
+	}
+	pendingKey := pendingPasswordSecretKey(secretKeyRef.Key)
+	newPassword, pending := passwordSecret.Data[pendingKey]
+	if !pending && !rotation.IsDue(lastRotated.Time, now) {
+		return refreshConnections(ctx, wr.Client, wr.user.Namespace, *secretKeyRef, password, lastRotated.Time)
+	}
+
+	if !pending {
+		generated, err := secret.GeneratePassword()
+		if err != nil {
+			return fmt.Errorf("error generating password: %v", err)
+		}
+		if err := wr.patchPasswordSecret(ctx, passwordSecret, func(data map[string][]byte) {
+			data[pendingKey] = []byte(generated)
+		}); err != nil {
+			return fmt.Errorf("error persisting pending password: %v", err)
+		}
+		newPassword = []byte(generated)
+	}
+	var accountNames []string
+	for _, host := range wr.user.HostsOrDefault() {
+		accountNames = append(accountNames, wr.user.AccountNameWithHost(host))
+	}
+	plugin := string(wr.user.Spec.AuthenticationPlugin)
+
+	// The accounts may already have the pending password if a previous attempt was interrupted.
+	if err := mdbClient.AlterAccountsPassword(ctx, accountNames, plugin, string(newPassword)); err != nil {
+		return fmt.Errorf("error altering password: %v", err)
+	}
+	if err := wr.patchPasswordSecret(ctx, passwordSecret, func(data map[string][]byte) {
+		data[secretKeyRef.Key] = newPassword
+		delete(data, pendingKey)
+	}); err != nil {
+		return fmt.Errorf("error updating password Secret: %v", err)
+	}
+	log.FromContext(ctx).Info("Rotated password", "user", wr.user.Username())
+	wr.recorder.Event(wr.user, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPasswordRotated, "Password rotated")
+
+	if err := wr.patchPasswordLastRotated(ctx, now); err != nil {
+		return err
+	}
+	return refreshConnections(ctx, wr.Client, wr.user.Namespace, *secretKeyRef, string(newPassword), now)
+}
+
+func (wr *wrappedUserReconciler) patchPasswordLastRotated(ctx context.Context, lastRotated time.Time) error {
+	patch := client.MergeFrom(wr.user.DeepCopy())
+	wr.user.Status.PasswordLastRotated = ptr.To(metav1.NewTime(lastRotated))
+	if err := wr.Client.Status().Patch(ctx, wr.user, patch); err != nil {
+		return fmt.Errorf("error patching User password rotation: %v", err)
+	}
+	return nil
+}
+
+func (wr *wrappedUserReconciler) passwordSecret(ctx context.Context, secretKeyRef corev1.SecretKeySelector) (*corev1.Secret, error) {
+	key := types.NamespacedName{
+		Name:      secretKeyRef.Name,
+		Namespace: wr.user.Namespace,
+	}
+	var secret corev1.Secret
+	if err := wr.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("error getting password Secret: %v", err)
+	}
+	return &secret, nil
+}
+
+func (wr *wrappedUserReconciler) patchPasswordSecret(ctx context.Context, secret *corev1.Secret,
+	patchFn func(data map[string][]byte)) error {
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	patchFn(secret.Data)
+	if err := wr.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("error patching password Secret: %v", err)
+	}
+	return nil
+}
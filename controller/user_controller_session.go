@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileMaxStatementTime applies the max statement time of the User to the account of a host whenever they do not match.
+// The limit is removed from the account when the max statement time is removed from the User.
+func (wr *wrappedUserReconciler) reconcileMaxStatementTime(ctx context.Context, mdbClient *sqlClient.Client, host string) error {
+	var desired float64
+	if maxStatementTime := wr.user.Spec.MaxStatementTime; maxStatementTime != nil {
+		desired = maxStatementTime.Seconds()
+	}
+	current, err := mdbClient.UserMaxStatementTime(ctx, wr.user.Username(), host)
+	if err != nil {
+		return fmt.Errorf("error getting max statement time: %v", err)
+	}
+	if current == desired {
+		return nil
+	}
+
+	accountName := wr.user.AccountNameWithHost(host)
+	if err := mdbClient.AlterUserMaxStatementTime(ctx, accountName, desired); err != nil {
+		return fmt.Errorf("error altering max statement time: %v", err)
+	}
+	log.FromContext(ctx).Info("Altered max statement time", "account", accountName, "from", current, "to", desired)
+	return nil
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"time"
+
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,7 +31,7 @@ var _ = Describe("User controller", func() {
 						},
 						WaitForIt: true,
 					},
-					PasswordSecretKeyRef: corev1.SecretKeySelector{
+					PasswordSecretKeyRef: &corev1.SecretKeySelector{
 						LocalObjectReference: corev1.LocalObjectReference{
 							Name: testPwdKey.Name,
 						},
@@ -56,8 +58,82 @@ var _ = Describe("User controller", func() {
 				return controllerutil.ContainsFinalizer(&user, userFinalizerName)
 			}, testTimeout, testInterval).Should(BeTrue())
 
+			By("Expecting User to eventually report password expiration")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, userKey, &user); err != nil {
+					return false
+				}
+				return user.Status.PasswordLastChanged != nil
+			}, testTimeout, testInterval).Should(BeTrue())
+			Expect(user.IsPasswordExpiring()).To(BeFalse())
+
 			By("Deleting User")
 			Expect(k8sClient.Delete(testCtx, &user)).To(Succeed())
 		})
+
+		It("Should resume a pending password rotation", func() {
+			userKey := types.NamespacedName{
+				Name:      "user-rotation-test",
+				Namespace: testNamespace,
+			}
+			secretKey := types.NamespacedName{
+				Name:      "user-rotation-test",
+				Namespace: testNamespace,
+			}
+			pendingKey := pendingPasswordSecretKey(testPwdSecretKey)
+
+			By("Creating password Secret with a pending password")
+			secret := corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretKey.Name,
+					Namespace: secretKey.Namespace,
+				},
+				Data: map[string][]byte{
+					testPwdSecretKey: []byte("MariaDB11!"),
+					pendingKey:       []byte("MariaDB11!pending"),
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &secret)).To(Succeed())
+
+			user := mariadbv1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      userKey.Name,
+					Namespace: userKey.Namespace,
+				},
+				Spec: mariadbv1alpha1.UserSpec{
+					MariaDBRef: mariadbv1alpha1.MariaDBRef{
+						ObjectReference: corev1.ObjectReference{
+							Name: testMariaDbKey.Name,
+						},
+						WaitForIt: true,
+					},
+					PasswordSecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secretKey.Name,
+						},
+						Key: testPwdSecretKey,
+					},
+					PasswordRotation: &mariadbv1alpha1.PasswordRotation{
+						Interval: metav1.Duration{Duration: 24 * time.Hour},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, &user)).To(Succeed())
+
+			By("Expecting the pending password to be moved to the password key eventually")
+			Eventually(func() bool {
+				if err := k8sClient.Get(testCtx, secretKey, &secret); err != nil {
+					return false
+				}
+				_, pending := secret.Data[pendingKey]
+				return !pending && string(secret.Data[testPwdSecretKey]) == "MariaDB11!pending"
+			}, testTimeout, testInterval).Should(BeTrue())
+
+			By("Deleting User")
+			Expect(k8sClient.Delete(testCtx, &user)).To(Succeed())
+
+			By("Deleting password Secret")
+			Expect(k8sClient.Delete(testCtx, &secret)).To(Succeed())
+		})
 	})
 })
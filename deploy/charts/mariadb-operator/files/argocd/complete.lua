@@ -0,0 +1,21 @@
+hs = {}
+if obj.status ~= nil and obj.status.conditions ~= nil then
+  for i, condition in ipairs(obj.status.conditions) do
+    if condition.type == "Complete" then
+      hs.message = condition.message
+      if condition.reason == "Failed" or (condition.message ~= nil and string.sub(condition.message, 1, 6) == "Failed") then
+        hs.status = "Degraded"
+      elseif condition.reason == "JobSuspended" then
+        hs.status = "Suspended"
+      elseif condition.status == "True" or obj.spec.schedule ~= nil then
+        hs.status = "Healthy"
+      else
+        hs.status = "Progressing"
+      end
+      return hs
+    end
+  end
+end
+hs.status = "Progressing"
+hs.message = "Waiting for Job to be created"
+return hs
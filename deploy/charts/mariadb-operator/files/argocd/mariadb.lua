@@ -0,0 +1,39 @@
+hs = {}
+if obj.status == nil or obj.status.conditions == nil then
+  hs.status = "Progressing"
+  hs.message = "Waiting for MariaDB to be reconciled"
+  return hs
+end
+if obj.status.observedGeneration == nil or obj.status.observedGeneration < obj.metadata.generation then
+  hs.status = "Progressing"
+  hs.message = "Waiting for the latest spec to be reconciled"
+  return hs
+end
+local ready = nil
+local reconciling = nil
+for i, condition in ipairs(obj.status.conditions) do
+  if condition.type == "Stalled" and condition.status == "True" then
+    hs.status = "Degraded"
+    hs.message = condition.message
+    return hs
+  end
+  if condition.type == "Reconciling" and condition.status == "True" then
+    reconciling = condition
+  end
+  if condition.type == "Ready" then
+    ready = condition
+  end
+end
+if reconciling ~= nil then
+  hs.status = "Progressing"
+  hs.message = reconciling.message
+  return hs
+end
+if ready ~= nil and ready.status == "True" then
+  hs.status = "Healthy"
+  hs.message = ready.message
+  return hs
+end
+hs.status = "Progressing"
+hs.message = "Waiting for MariaDB to be ready"
+return hs
@@ -0,0 +1,19 @@
+hs = {}
+if obj.status ~= nil and obj.status.conditions ~= nil then
+  for i, condition in ipairs(obj.status.conditions) do
+    if condition.type == "Ready" then
+      hs.message = condition.message
+      if condition.status == "True" then
+        hs.status = "Healthy"
+      elseif condition.reason == "Failed" or condition.reason == "ConnectionFailed" then
+        hs.status = "Degraded"
+      else
+        hs.status = "Progressing"
+      end
+      return hs
+    end
+  end
+end
+hs.status = "Progressing"
+hs.message = "Waiting for resource to be reconciled"
+return hs
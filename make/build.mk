@@ -4,6 +4,10 @@
 build: ## Build binary.
 	go build -o bin/mariadb-operator cmd/controller/*.go
 
+.PHONY: build-plugin
+build-plugin: ## Build the kubectl plugin binary.
+	go build -o bin/kubectl-mariadb cmd/kubectl-mariadb/*.go
+
 .PHONY: docker-build
 docker-build: ## Build docker image.
 	docker build -t $(IMG) .  
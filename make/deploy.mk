@@ -98,6 +98,7 @@ helm-images: ## Update images in Helm chart.
 	$(KUBECTL) create configmap mariadb-operator-images \
 		--from-literal=RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB) \
 		--from-literal=RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) \
+		--from-literal=RELATED_IMAGE_MAXSCALE=$(RELATED_IMAGE_MAXSCALE) \
 		--from-literal=MARIADB_OPERATOR_IMAGE=$(IMG) \
 		--dry-run=client -o yaml \
 		> deploy/charts/mariadb-operator/templates/configmap.yaml
@@ -8,7 +8,7 @@ export MARIADB_OPERATOR_SA_PATH ?= /tmp/mariadb-operator/token
 lint: golangci-lint ## Lint.
 	$(GOLANGCI_LINT) run
 
-TEST_ENV ?= RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB) RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) MARIADB_OPERATOR_IMAGE=$(IMG) \
+TEST_ENV ?= RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB) RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) RELATED_IMAGE_MAXSCALE=$(RELATED_IMAGE_MAXSCALE) MARIADB_OPERATOR_IMAGE=$(IMG) \
 	MARIADB_OPERATOR_NAME=$(MARIADB_OPERATOR_NAME) MARIADB_OPERATOR_NAMESPACE=$(MARIADB_OPERATOR_NAMESPACE) MARIADB_OPERATOR_SA_PATH=$(MARIADB_OPERATOR_SA_PATH) \
 	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) -p path)"
 .PHONY: test
@@ -28,12 +28,12 @@ release: goreleaser ## Test release locally.
 WATCH_NAMESPACE ?= ""
 RUN_FLAGS ?= --log-dev --log-level=debug --log-time-encoder=iso8601
 
-RUN_ENV ?= RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB) RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) MARIADB_OPERATOR_IMAGE=$(IMG) WATCH_NAMESPACE=$(WATCH_NAMESPACE)
+RUN_ENV ?= RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB) RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) RELATED_IMAGE_MAXSCALE=$(RELATED_IMAGE_MAXSCALE) MARIADB_OPERATOR_IMAGE=$(IMG) WATCH_NAMESPACE=$(WATCH_NAMESPACE)
 .PHONY: run
 run: lint ## Run a controller from your host.
 	$(RUN_ENV) go run cmd/controller/*.go $(RUN_FLAGS)
 
-RUN_ENT_ENV ?= RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB_ENT) RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) MARIADB_OPERATOR_IMAGE=$(IMG_ENT) WATCH_NAMESPACE=$(WATCH_NAMESPACE)
+RUN_ENT_ENV ?= RELATED_IMAGE_MARIADB=$(RELATED_IMAGE_MARIADB_ENT) RELATED_IMAGE_EXPORTER=$(RELATED_IMAGE_EXPORTER) RELATED_IMAGE_MAXSCALE=$(RELATED_IMAGE_MAXSCALE) MARIADB_OPERATOR_IMAGE=$(IMG_ENT) WATCH_NAMESPACE=$(WATCH_NAMESPACE)
 .PHONY: run-ent
 run-ent: lint cert ## Run a enterprise from your host.
 	$(RUN_ENT_ENV) go run cmd/enterprise/*.go $(RUN_FLAGS)
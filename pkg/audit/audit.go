@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KeyFields returns the fields of the MariaDB spec that are tracked in the history.
+func KeyFields(mariadb *mariadbv1alpha1.MariaDB) map[string]string {
+	spec := mariadb.Spec
+	fields := map[string]string{
+		"image":                    spec.Image,
+		"replicas":                 strconv.Itoa(int(spec.Replicas)),
+		"port":                     strconv.Itoa(int(spec.Port)),
+		"rootPasswordSecretKeyRef": secretKeyRef(&spec.RootPasswordSecretKeyRef),
+	}
+	if spec.MyCnf != nil {
+		fields["myCnf"] = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(*spec.MyCnf)))[:19]
+	}
+	if spec.MyCnfConfigMapKeyRef != nil {
+		fields["myCnfConfigMapKeyRef"] = fmt.Sprintf("%s/%s", spec.MyCnfConfigMapKeyRef.Name, spec.MyCnfConfigMapKeyRef.Key)
+	}
+	if spec.Resources != nil {
+		if requests := resourceList(spec.Resources.Requests); requests != "" {
+			fields["resources.requests"] = requests
+		}
+		if limits := resourceList(spec.Resources.Limits); limits != "" {
+			fields["resources.limits"] = limits
+		}
+	}
+	if storage, ok := spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage]; ok {
+		fields["volumeClaimTemplate.storage"] = storage.String()
+	}
+	if spec.UpdateStrategy != nil {
+		fields["updateStrategy"] = string(spec.UpdateStrategy.Type)
+	}
+	if spec.MaintenanceWindow != nil {
+		fields["maintenanceWindow"] = fmt.Sprintf("%s (%s)", spec.MaintenanceWindow.Cron, spec.MaintenanceWindow.Duration.Duration)
+	}
+	if spec.Replication != nil {
+		fields["replication.enabled"] = strconv.FormatBool(spec.Replication.Enabled)
+		if spec.Replication.Primary != nil && spec.Replication.Primary.PodIndex != nil {
+			fields["replication.primary.podIndex"] = strconv.Itoa(*spec.Replication.Primary.PodIndex)
+		}
+	}
+	if spec.Galera != nil {
+		fields["galera.enabled"] = strconv.FormatBool(spec.Galera.Enabled)
+		if spec.Galera.Primary != nil && spec.Galera.Primary.PodIndex != nil {
+			fields["galera.primary.podIndex"] = strconv.Itoa(*spec.Galera.Primary.PodIndex)
+		}
+	}
+	return fields
+}
+
+// Diff returns the changes between two sets of key fields, sorted by field.
+func Diff(old, new map[string]string) []mariadbv1alpha1.FieldChange {
+	var changes []mariadbv1alpha1.FieldChange
+	for field, newValue := range new {
+		if oldValue, ok := old[field]; !ok || oldValue != newValue {
+			changes = append(changes, mariadbv1alpha1.FieldChange{
+				Field: field,
+				Old:   old[field],
+				New:   newValue,
+			})
+		}
+	}
+	for field, oldValue := range old {
+		if _, ok := new[field]; !ok {
+			changes = append(changes, mariadbv1alpha1.FieldChange{
+				Field: field,
+				Old:   oldValue,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// SpecManager returns the field manager that most recently updated the MariaDB spec.
+func SpecManager(mariadb *mariadbv1alpha1.MariaDB) string {
+	var (
+		manager string
+		latest  *metav1.Time
+	)
+	for _, m := range mariadb.ManagedFields {
+		if m.Subresource != "" || m.FieldsV1 == nil || !strings.Contains(string(m.FieldsV1.Raw), `"f:spec"`) {
+			continue
+		}
+		if latest == nil || (m.Time != nil && latest.Before(m.Time)) {
+			manager = m.Manager
+			latest = m.Time
+		}
+	}
+	return manager
+}
+
+// ChangesMessage returns a human readable summary of the changes.
+func ChangesMessage(changes []mariadbv1alpha1.FieldChange) string {
+	fields := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = fmt.Sprintf("%s: '%s' -> '%s'", c.Field, c.Old, c.New)
+	}
+	return strings.Join(fields, ", ")
+}
+
+// Auditor records entries in the MariaDB history.
+type Auditor struct {
+	client client.Client
+}
+
+func NewAuditor(client client.Client) *Auditor {
+	return &Auditor{
+		client: client,
+	}
+}
+
+// Record appends an entry to the MariaDB history. The status is patched using optimistic locking,
+// so entries recorded concurrently by different controllers are not lost.
+func (a *Auditor) Record(ctx context.Context, key types.NamespacedName, entry mariadbv1alpha1.HistoryEntry,
+	auditedFields map[string]string) (*mariadbv1alpha1.MariaDB, error) {
+	var mariadb mariadbv1alpha1.MariaDB
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := a.client.Get(ctx, key, &mariadb); err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(mariadb.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		mariadb.Status.AddHistoryEntry(entry, mariadb.HistoryLimit())
+		if auditedFields != nil {
+			mariadb.Status.AuditedFields = auditedFields
+		}
+		return a.client.Status().Patch(ctx, &mariadb, patch)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording history entry: %v", err)
+	}
+	return &mariadb, nil
+}
+
+func secretKeyRef(ref *corev1.SecretKeySelector) string {
+	if ref.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", ref.Name, ref.Key)
+}
+
+func resourceList(resources corev1.ResourceList) string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	values := make([]string, len(names))
+	for i, name := range names {
+		quantity := resources[corev1.ResourceName(name)]
+		values[i] = fmt.Sprintf("%s=%s", name, quantity.String())
+	}
+	return strings.Join(values, ",")
+}
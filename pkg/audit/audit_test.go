@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyFieldsDiff(t *testing.T) {
+	podIndex := 0
+	mariadb := &mariadbv1alpha1.MariaDB{
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image:    "mariadb:11.0.3",
+			Replicas: 3,
+			Port:     3306,
+			ContainerTemplate: mariadbv1alpha1.ContainerTemplate{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+					},
+				},
+			},
+			Replication: &mariadbv1alpha1.Replication{
+				Enabled: true,
+				ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+					Primary: &mariadbv1alpha1.PrimaryReplication{
+						PodIndex: &podIndex,
+					},
+				},
+			},
+		},
+	}
+	old := KeyFields(mariadb)
+	if old["resources.requests"] != "cpu=100m,memory=128Mi" {
+		t.Errorf("unexpected resources.requests: %s", old["resources.requests"])
+	}
+
+	newPodIndex := 1
+	mariadb.Spec.Image = "mariadb:11.2.2"
+	mariadb.Spec.Replication.Primary.PodIndex = &newPodIndex
+	mariadb.Spec.Resources = nil
+
+	changes := Diff(old, KeyFields(mariadb))
+	expected := []mariadbv1alpha1.FieldChange{
+		{
+			Field: "image",
+			Old:   "mariadb:11.0.3",
+			New:   "mariadb:11.2.2",
+		},
+		{
+			Field: "replication.primary.podIndex",
+			Old:   "0",
+			New:   "1",
+		},
+		{
+			Field: "resources.requests",
+			Old:   "cpu=100m,memory=128Mi",
+		},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("unexpected changes, expected: %v got: %v", expected, changes)
+	}
+	if Diff(old, old) != nil {
+		t.Error("expected no changes")
+	}
+}
+
+func TestSpecManager(t *testing.T) {
+	now := time.Now()
+	fieldsSpec := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:image":{}}}`)}
+	fieldsStatus := &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{}}}`)}
+
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		wantManager   string
+	}{
+		{
+			name:        "no managed fields",
+			wantManager: "",
+		},
+		{
+			name: "latest spec manager",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:  "kubectl-client-side-apply",
+					Time:     &metav1.Time{Time: now.Add(-time.Hour)},
+					FieldsV1: fieldsSpec,
+				},
+				{
+					Manager:  "helm",
+					Time:     &metav1.Time{Time: now.Add(-time.Minute)},
+					FieldsV1: fieldsSpec,
+				},
+				{
+					Manager:     "mariadb-operator",
+					Time:        &metav1.Time{Time: now},
+					FieldsV1:    fieldsStatus,
+					Subresource: "status",
+				},
+			},
+			wantManager: "helm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mariadb := &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					ManagedFields: tt.managedFields,
+				},
+			}
+			if manager := SpecManager(mariadb); manager != tt.wantManager {
+				t.Errorf("expected manager '%s', got '%s'", tt.wantManager, manager)
+			}
+		})
+	}
+}
+
+func TestAddHistoryEntry(t *testing.T) {
+	var status mariadbv1alpha1.MariaDBStatus
+	for i := 0; i < 5; i++ {
+		status.AddHistoryEntry(mariadbv1alpha1.HistoryEntry{
+			Message: string(rune('a' + i)),
+		}, 3)
+	}
+	if len(status.History) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(status.History))
+	}
+	if status.History[0].Message != "c" || status.History[2].Message != "e" {
+		t.Errorf("expected the oldest entries to be removed, got %v", status.History)
+	}
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const recordTimeout = 10 * time.Second
+
+var actionReasons = map[string]struct{}{
+	mariadbv1alpha1.ReasonPrimarySwitching:        {},
+	mariadbv1alpha1.ReasonPrimarySwitched:         {},
+	mariadbv1alpha1.ReasonGaleraClusterNotHealthy: {},
+	mariadbv1alpha1.ReasonGaleraClusterBootstrap:  {},
+	mariadbv1alpha1.ReasonMariaDBUpgraded:         {},
+	mariadbv1alpha1.ReasonDriftReverted:           {},
+}
+
+// Recorder is a record.EventRecorder that, besides recording the Kubernetes Events,
+// records the actions performed by the operator in the MariaDB history.
+type Recorder struct {
+	record.EventRecorder
+	auditor   *Auditor
+	component string
+}
+
+func NewRecorder(recorder record.EventRecorder, auditor *Auditor, component string) *Recorder {
+	return &Recorder{
+		EventRecorder: recorder,
+		auditor:       auditor,
+		component:     component,
+	}
+}
+
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, message)
+	r.record(object, reason, message)
+}
+
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	r.record(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason,
+	messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	r.record(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) record(object runtime.Object, reason, message string) {
+	if _, ok := actionReasons[reason]; !ok {
+		return
+	}
+	mariadb, ok := object.(*mariadbv1alpha1.MariaDB)
+	if !ok || mariadb.HistoryLimit() == 0 {
+		return
+	}
+	key := client.ObjectKeyFromObject(mariadb)
+	entry := mariadbv1alpha1.HistoryEntry{
+		Time:    metav1.Now(),
+		Type:    mariadbv1alpha1.HistoryEntryTypeAction,
+		Actor:   r.component,
+		Reason:  reason,
+		Message: message,
+	}
+	// Entries are recorded asynchronously to avoid blocking the reconciliation.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+		defer cancel()
+
+		if _, err := r.auditor.Record(ctx, key, entry, nil); err != nil {
+			ctrl.Log.WithName("audit").Error(err, "Error recording action", "mariadb", key.Name, "namespace", key.Namespace,
+				"reason", reason)
+		}
+	}()
+}
@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// maxArtifacts is the maximum number of artifacts to be reported, starting from the most recent one.
+const maxArtifacts = 30
+
+// ArtifactType is the type of a backup artifact.
+type ArtifactType string
+
+const (
+	// ArtifactTypeLogical is a logical backup taken by mariadb-dump.
+	ArtifactTypeLogical ArtifactType = "Logical"
+)
+
+// Artifact is a backup available in the storage.
+type Artifact struct {
+	// Name is the name of the backup file.
+	Name string `json:"name"`
+	// Database is the database contained in the backup file. It is empty for backups of all databases.
+	Database string `json:"database,omitempty"`
+	// Size is the size of the backup file in bytes. It is zero when it could not be determined.
+	Size int64 `json:"size,omitempty"`
+	// StorageClass is the storage class of the backup file, only available in object storages.
+	StorageClass string `json:"storageClass,omitempty"`
+	// Timestamp is the time when the backup was taken.
+	Timestamp time.Time `json:"timestamp"`
+	// Type is the type of the backup.
+	Type ArtifactType `json:"type"`
+}
+
+// GetArtifacts returns the backups available in the storage, sorted from the most recent to the oldest one.
+// Only the most recent ones are returned, as the artifacts are reported in the Pod termination message.
+func GetArtifacts(ctx context.Context, storage BackupStorage, backupFileNames []string, logger logr.Logger) []Artifact {
+	var artifacts []Artifact
+	for _, file := range backupFileNames {
+		date, err := parseDateInBackupFile(file)
+		if err != nil {
+			continue
+		}
+		artifacts = append(artifacts, Artifact{
+			Name:      file,
+			Database:  DatabaseInBackupFile(file),
+			Timestamp: date,
+			Type:      ArtifactTypeLogical,
+		})
+	}
+	sort.SliceStable(artifacts, func(i, j int) bool {
+		return artifacts[i].Timestamp.After(artifacts[j].Timestamp)
+	})
+	if len(artifacts) > maxArtifacts {
+		artifacts = artifacts[:maxArtifacts]
+	}
+
+	for i := range artifacts {
+		info, err := storage.Stat(ctx, artifacts[i].Name)
+		if err != nil {
+			logger.V(1).Info("error getting backup info", "backup", artifacts[i].Name, "err", err)
+			continue
+		}
+		artifacts[i].Size = info.Size
+		artifacts[i].StorageClass = info.StorageClass
+	}
+	return artifacts
+}
@@ -0,0 +1,69 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetArtifacts(t *testing.T) {
+	basePath := t.TempDir()
+	backupFiles := []string{
+		"backup.2023-12-22T13:00:00Z.sql",
+		"backup.2023-12-22T20:00:00Z.sql.gz",
+		"backup.2023-12-22T15:00:00Z.sql",
+		"backup.2023-12-22T21:00:00Z.db1.sql",
+	}
+	for i, file := range backupFiles {
+		if err := os.WriteFile(filepath.Join(basePath, file), make([]byte, (i+1)*10), 0644); err != nil {
+			t.Fatalf("unexpected error creating backup file: %v", err)
+		}
+	}
+	storage := NewFileSystemBackupStorage(basePath, logger)
+
+	artifacts := GetArtifacts(context.Background(), storage, append(backupFiles, "backup.foo.sql"), logger)
+	wantNames := []string{
+		"backup.2023-12-22T21:00:00Z.db1.sql",
+		"backup.2023-12-22T20:00:00Z.sql.gz",
+		"backup.2023-12-22T15:00:00Z.sql",
+		"backup.2023-12-22T13:00:00Z.sql",
+	}
+	wantDatabases := []string{"db1", "", "", ""}
+	wantSizes := []int64{40, 20, 30, 10}
+	if len(artifacts) != len(wantNames) {
+		t.Fatalf("unexpected number of artifacts, expected: %d got: %d", len(wantNames), len(artifacts))
+	}
+	for i, artifact := range artifacts {
+		if artifact.Name != wantNames[i] {
+			t.Errorf("unexpected artifact name, expected: %s got: %s", wantNames[i], artifact.Name)
+		}
+		if artifact.Database != wantDatabases[i] {
+			t.Errorf("unexpected artifact database, expected: %s got: %s", wantDatabases[i], artifact.Database)
+		}
+		if artifact.Size != wantSizes[i] {
+			t.Errorf("unexpected artifact size, expected: %d got: %d", wantSizes[i], artifact.Size)
+		}
+		if artifact.Type != ArtifactTypeLogical {
+			t.Errorf("unexpected artifact type, expected: %s got: %s", ArtifactTypeLogical, artifact.Type)
+		}
+	}
+}
+
+func TestGetArtifactsLimit(t *testing.T) {
+	basePath := t.TempDir()
+	var backupFiles []string
+	for i := 0; i < maxArtifacts+10; i++ {
+		backupFiles = append(backupFiles, fmt.Sprintf("backup.2023-12-%02dT13:00:00Z.sql", i%28+1))
+	}
+	storage := NewFileSystemBackupStorage(basePath, logger)
+
+	artifacts := GetArtifacts(context.Background(), storage, backupFiles, logger)
+	if len(artifacts) != maxArtifacts {
+		t.Fatalf("unexpected number of artifacts, expected: %d got: %d", maxArtifacts, len(artifacts))
+	}
+	if artifacts[0].Name != "backup.2023-12-28T13:00:00Z.sql" {
+		t.Fatalf("expected most recent artifact first, got: %s", artifacts[0].Name)
+	}
+}
@@ -0,0 +1,212 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/go-logr/logr"
+)
+
+type AzureBlobBackupStorageOpts struct {
+	Prefix     string
+	Endpoint   string
+	AccountKey string
+	Progress   *Progress
+	FileFilter func(fileName string) bool
+}
+
+type AzureBlobBackupStorageOpt func(s *AzureBlobBackupStorageOpts)
+
+func WithAzureBlobPrefix(prefix string) AzureBlobBackupStorageOpt {
+	return func(s *AzureBlobBackupStorageOpts) {
+		s.Prefix = prefix
+	}
+}
+
+// WithAzureBlobEndpoint overrides the Blob service endpoint, https://<storage-account>.blob.core.windows.net by default.
+func WithAzureBlobEndpoint(endpoint string) AzureBlobBackupStorageOpt {
+	return func(s *AzureBlobBackupStorageOpts) {
+		s.Endpoint = endpoint
+	}
+}
+
+// WithAzureBlobAccountKey configures a storage account key. When not provided, the credentials are obtained from
+// the environment, for instance, from the federated token injected by Azure Workload Identity.
+func WithAzureBlobAccountKey(accountKey string) AzureBlobBackupStorageOpt {
+	return func(s *AzureBlobBackupStorageOpts) {
+		s.AccountKey = accountKey
+	}
+}
+
+func WithAzureBlobProgress(progress *Progress) AzureBlobBackupStorageOpt {
+	return func(s *AzureBlobBackupStorageOpts) {
+		s.Progress = progress
+	}
+}
+
+// WithAzureBlobFileFilter overrides the function that determines which files are listed, backup files by default.
+func WithAzureBlobFileFilter(filter func(fileName string) bool) AzureBlobBackupStorageOpt {
+	return func(s *AzureBlobBackupStorageOpts) {
+		s.FileFilter = filter
+	}
+}
+
+type AzureBlobBackupStorage struct {
+	AzureBlobBackupStorageOpts
+	basePath  string
+	container string
+	logger    logr.Logger
+	client    *azblob.Client
+}
+
+func NewAzureBlobBackupStorage(basePath, container, storageAccount string, logger logr.Logger,
+	azureOpts ...AzureBlobBackupStorageOpt) (BackupStorage, error) {
+	opts := AzureBlobBackupStorageOpts{}
+	for _, setOpt := range azureOpts {
+		setOpt(&opts)
+	}
+	if opts.FileFilter == nil {
+		opts.FileFilter = IsValidBackupFile
+	}
+	serviceURL := opts.Endpoint
+	if serviceURL == "" {
+		serviceURL = fmt.Sprintf("https://%s.blob.core.windows.net/", storageAccount)
+	}
+
+	var client *azblob.Client
+	if opts.AccountKey != "" {
+		cred, err := azblob.NewSharedKeyCredential(storageAccount, opts.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Azure shared key credential: %v", err)
+		}
+		client, err = azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Azure Blob client: %v", err)
+		}
+	} else {
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Azure credentials: %v", err)
+		}
+		client, err = azblob.NewClient(serviceURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Azure Blob client: %v", err)
+		}
+	}
+
+	return &AzureBlobBackupStorage{
+		AzureBlobBackupStorageOpts: opts,
+		basePath:                   basePath,
+		container:                  container,
+		client:                     client,
+		logger:                     logger,
+	}, nil
+}
+
+func (a *AzureBlobBackupStorage) List(ctx context.Context) ([]string, error) {
+	var fileNames []string
+	prefix := normalizePrefix(a.Prefix)
+	pager := a.client.NewListBlobsFlatPager(a.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing blobs: %v", err)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name == nil {
+				continue
+			}
+			fileName := unprefixedFileName(a.Prefix, *blob.Name)
+			if shouldProcessFile(fileName, a.FileFilter, a.logger) {
+				fileNames = append(fileNames, fileName)
+			}
+		}
+	}
+	return fileNames, nil
+}
+
+func (a *AzureBlobBackupStorage) Push(ctx context.Context, fileName string) error {
+	file, err := os.Open(filepath.Join(a.basePath, fileName))
+	if err != nil {
+		return fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error getting file info: %v", err)
+	}
+	a.Progress.StartTransfer(fileName, info.Size())
+
+	reader := io.TeeReader(file, a.Progress.Writer(io.Discard))
+	if _, err := a.client.UploadStream(ctx, a.container, prefixedFileName(a.Prefix, fileName), reader, nil); err != nil {
+		return fmt.Errorf("error uploading blob: %v", err)
+	}
+	return nil
+}
+
+func (a *AzureBlobBackupStorage) Pull(ctx context.Context, fileName string) error {
+	resp, err := a.client.DownloadStream(ctx, a.container, prefixedFileName(a.Prefix, fileName), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return fmt.Errorf("error getting blob: %w", ErrFileNotFound)
+		}
+		if bloberror.HasCode(err, bloberror.BlobArchived) {
+			return fmt.Errorf("error getting blob: %w", ErrFileArchived)
+		}
+		return fmt.Errorf("error getting blob: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.ContentLength != nil {
+		a.Progress.StartTransfer(fileName, *resp.ContentLength)
+	}
+
+	if err := downloadFile(filepath.Join(a.basePath, fileName), resp.Body, a.Progress); err != nil {
+		return fmt.Errorf("error downloading blob: %v", err)
+	}
+	return nil
+}
+
+func (a *AzureBlobBackupStorage) Delete(ctx context.Context, fileName string) error {
+	_, err := a.client.DeleteBlob(ctx, a.container, prefixedFileName(a.Prefix, fileName), nil)
+	return err
+}
+
+func (a *AzureBlobBackupStorage) Stat(ctx context.Context, fileName string) (*FileInfo, error) {
+	props, err := a.blobClient(fileName).GetProperties(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blob properties: %v", err)
+	}
+	var info FileInfo
+	if props.ContentLength != nil {
+		info.Size = *props.ContentLength
+	}
+	if props.AccessTier != nil {
+		info.StorageClass = *props.AccessTier
+		info.Archived = isArchivedStorageClass(info.StorageClass)
+	}
+	return &info, nil
+}
+
+// SetStorageClass sets the access tier of the blob.
+// See: https://learn.microsoft.com/en-us/azure/storage/blobs/access-tiers-online-manage.
+func (a *AzureBlobBackupStorage) SetStorageClass(ctx context.Context, fileName, storageClass string) error {
+	if _, err := a.blobClient(fileName).SetTier(ctx, blob.AccessTier(storageClass), nil); err != nil {
+		return fmt.Errorf("error setting blob access tier: %v", err)
+	}
+	return nil
+}
+
+func (a *AzureBlobBackupStorage) blobClient(fileName string) *blob.Client {
+	return a.client.ServiceClient().
+		NewContainerClient(a.container).
+		NewBlobClient(prefixedFileName(a.Prefix, fileName))
+}
@@ -3,6 +3,7 @@ package backup
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -48,6 +49,31 @@ func GetBackupTargetFile(backupFileNames []string, targetRecoveryTime time.Time,
 	return backupDiffs[0].fileName, nil
 }
 
+// GetBackupTargetFileBefore finds the latest backup file taken before or at the target recovery time.
+// It is used when the binary logs are replayed after restoring the backup, as they can only roll the data forward.
+func GetBackupTargetFileBefore(backupFileNames []string, targetRecoveryTime time.Time, logger logr.Logger) (string, error) {
+	var targetFile string
+	var targetDate time.Time
+	for _, file := range backupFileNames {
+		backupDate, err := parseDateInBackupFile(file)
+		if err != nil {
+			logger.Error(err, "error parsing backup date. Skipping", "file", file)
+			continue
+		}
+		if backupDate.After(targetRecoveryTime) {
+			continue
+		}
+		if targetFile == "" || backupDate.After(targetDate) {
+			targetFile = file
+			targetDate = backupDate
+		}
+	}
+	if targetFile == "" {
+		return "", errors.New("no valid backup files were found before the target recovery time")
+	}
+	return targetFile, nil
+}
+
 // GetOldBackupFiles determines which backup files should be deleted according with the retention policy.
 func GetOldBackupFiles(backupFileNames []string, maxRetention time.Duration, logger logr.Logger) []string {
 	var oldBackups []string
@@ -67,7 +93,7 @@ func GetOldBackupFiles(backupFileNames []string, maxRetention time.Duration, log
 
 // IsValidBackupFile determines whether a backup file name is valid.
 func IsValidBackupFile(fileName string) bool {
-	if !strings.HasPrefix(fileName, "backup.") || !strings.HasSuffix(fileName, ".sql") {
+	if !strings.HasPrefix(fileName, "backup.") || backupFileExtension(fileName) == "" {
 		return false
 	}
 	_, err := parseDateInBackupFile(fileName)
@@ -88,10 +114,81 @@ func ParseBackupDate(timeRaw string) (time.Time, error) {
 	return t, nil
 }
 
-func parseDateInBackupFile(fileName string) (time.Time, error) {
-	parts := strings.Split(fileName, ".")
+// DatabaseInBackupFile returns the database of a per-database backup file, or an empty string for full backups.
+func DatabaseInBackupFile(fileName string) string {
+	parts := backupFileParts(fileName)
 	if len(parts) != 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+// FilterBackupFilesByDatabase keeps the full backups and the per-database backups of the given databases.
+// All the backup files are returned when no databases are provided.
+func FilterBackupFilesByDatabase(backupFileNames []string, databases []string) []string {
+	if len(databases) == 0 {
+		return backupFileNames
+	}
+	var files []string
+	for _, file := range backupFileNames {
+		database := DatabaseInBackupFile(file)
+		if database == "" || slices.Contains(databases, database) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// GetBackupTargetFiles returns the files that need to be restored for a given target file: the target file itself
+// for full backups and all the per-database backups taken at the same time as the target for per-database backups.
+func GetBackupTargetFiles(backupFileNames []string, targetFile string) []string {
+	if DatabaseInBackupFile(targetFile) == "" {
+		return []string{targetFile}
+	}
+	targetDate, err := parseDateInBackupFile(targetFile)
+	if err != nil {
+		return []string{targetFile}
+	}
+	var files []string
+	for _, file := range backupFileNames {
+		if DatabaseInBackupFile(file) == "" {
+			continue
+		}
+		date, err := parseDateInBackupFile(file)
+		if err == nil && date.Equal(targetDate) {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ParseTargetFiles parses the backup files listed in a target file, separated by whitespaces.
+func ParseTargetFiles(content string) []string {
+	return strings.Fields(content)
+}
+
+func parseDateInBackupFile(fileName string) (time.Time, error) {
+	parts := backupFileParts(fileName)
+	if len(parts) != 2 && len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid backup file name: %s", fileName)
+	}
+	if len(parts) == 3 && parts[2] == "" {
 		return time.Time{}, fmt.Errorf("invalid backup file name: %s", fileName)
 	}
 	return ParseBackupDate(parts[1])
 }
+
+// backupFileParts splits a backup file name into its prefix, date and, for per-database backups, database.
+func backupFileParts(fileName string) []string {
+	return strings.Split(strings.TrimSuffix(fileName, backupFileExtension(fileName)), ".")
+}
+
+func backupFileExtension(fileName string) string {
+	for _, ext := range []string{".sql.gz", ".sql.zst", ".sql.bz2", ".sql"} {
+		if strings.HasSuffix(fileName, ext) {
+			return ext
+		}
+	}
+	return ""
+}
@@ -41,11 +41,51 @@ func TestIsValidBackupFile(t *testing.T) {
 			backupFile: "backup.2023-12-18 16:14.sql",
 			wantValid:  false,
 		},
+		{
+			name:       "invalid compression extension",
+			backupFile: "backup.2023-12-18T16:14:00Z.sql.zip",
+			wantValid:  false,
+		},
 		{
 			name:       "valid",
 			backupFile: "backup.2023-12-18T16:14:00Z.sql",
 			wantValid:  true,
 		},
+		{
+			name:       "valid compressed",
+			backupFile: "backup.2023-12-18T16:14:00Z.sql.gz",
+			wantValid:  true,
+		},
+		{
+			name:       "valid zstd",
+			backupFile: "backup.2023-12-18T16:14:00Z.sql.zst",
+			wantValid:  true,
+		},
+		{
+			name:       "valid bzip2",
+			backupFile: "backup.2023-12-18T16:14:00Z.sql.bz2",
+			wantValid:  true,
+		},
+		{
+			name:       "valid per-database",
+			backupFile: "backup.2023-12-18T16:14:00Z.db1.sql",
+			wantValid:  true,
+		},
+		{
+			name:       "valid per-database compressed",
+			backupFile: "backup.2023-12-18T16:14:00Z.db_1.sql.gz",
+			wantValid:  true,
+		},
+		{
+			name:       "empty database",
+			backupFile: "backup.2023-12-18T16:14:00Z..sql",
+			wantValid:  false,
+		},
+		{
+			name:       "too many parts",
+			backupFile: "backup.2023-12-18T16:14:00Z.db1.foo.sql",
+			wantValid:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,6 +155,17 @@ func TestGetTargerRecoveryFile(t *testing.T) {
 			wantFile:       "backup.2023-12-18T15:58:01Z.sql",
 			wantErr:        false,
 		},
+		{
+			name: "compressed backups",
+			backupFiles: []string{
+				"backup.2023-12-18T15:58:00Z.sql",
+				"backup.2023-12-18T15:59:00Z.sql.gz",
+				"backup.2023-12-18T16:00:00Z.sql.gz",
+			},
+			targetRecovery: mustParseDate(t, "2023-12-18T15:59:10Z"),
+			wantFile:       "backup.2023-12-18T15:59:00Z.sql.gz",
+			wantErr:        false,
+		},
 		{
 			name: "target before backups",
 			backupFiles: []string{
@@ -205,6 +256,164 @@ func TestGetTargerRecoveryFile(t *testing.T) {
 	}
 }
 
+func TestGetBackupTargetFileBefore(t *testing.T) {
+	tests := []struct {
+		name           string
+		backupFiles    []string
+		targetRecovery time.Time
+		wantFile       string
+		wantErr        bool
+	}{
+		{
+			name:           "no backups",
+			backupFiles:    []string{},
+			targetRecovery: time.Now(),
+			wantFile:       "",
+			wantErr:        true,
+		},
+		{
+			name: "only newer backups",
+			backupFiles: []string{
+				"backup.2023-12-18T15:58:00Z.sql",
+				"backup.2023-12-18T16:58:00Z.sql",
+			},
+			targetRecovery: mustParseDate(t, "2023-12-18T15:00:00Z"),
+			wantFile:       "",
+			wantErr:        true,
+		},
+		{
+			name: "exact match",
+			backupFiles: []string{
+				"backup.2023-12-18T15:58:00Z.sql",
+				"backup.2023-12-18T16:58:00Z.sql",
+			},
+			targetRecovery: mustParseDate(t, "2023-12-18T16:58:00Z"),
+			wantFile:       "backup.2023-12-18T16:58:00Z.sql",
+			wantErr:        false,
+		},
+		{
+			name: "closer newer backup",
+			backupFiles: []string{
+				"backup.2023-12-18T14:00:00Z.sql.gz",
+				"backup.2023-12-18T15:00:00Z.sql.gz",
+				"backup.foo.sql",
+				"backup.2023-12-18T16:00:00Z.sql.gz",
+			},
+			targetRecovery: mustParseDate(t, "2023-12-18T15:55:00Z"),
+			wantFile:       "backup.2023-12-18T15:00:00Z.sql.gz",
+			wantErr:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := GetBackupTargetFileBefore(tt.backupFiles, tt.targetRecovery, logger)
+			if tt.wantErr && err == nil {
+				t.Error("expect error to have occurred, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expect error to not have occurred, got: %v", err)
+			}
+			if tt.wantFile != file {
+				t.Fatalf("unexpected backup target file, expected: %v got: %v", tt.wantFile, file)
+			}
+		})
+	}
+}
+
+func TestFilterBackupFilesByDatabase(t *testing.T) {
+	backupFiles := []string{
+		"backup.2023-12-18T14:00:00Z.sql",
+		"backup.2023-12-18T15:00:00Z.db1.sql",
+		"backup.2023-12-18T15:00:00Z.db2.sql",
+		"backup.2023-12-18T16:00:00Z.db1.sql.gz",
+		"backup.2023-12-18T16:00:00Z.db2.sql.gz",
+	}
+	tests := []struct {
+		name      string
+		databases []string
+		wantFiles []string
+	}{
+		{
+			name:      "no databases",
+			databases: nil,
+			wantFiles: backupFiles,
+		},
+		{
+			name:      "single database",
+			databases: []string{"db1"},
+			wantFiles: []string{
+				"backup.2023-12-18T14:00:00Z.sql",
+				"backup.2023-12-18T15:00:00Z.db1.sql",
+				"backup.2023-12-18T16:00:00Z.db1.sql.gz",
+			},
+		},
+		{
+			name:      "unknown database",
+			databases: []string{"foo"},
+			wantFiles: []string{
+				"backup.2023-12-18T14:00:00Z.sql",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := FilterBackupFilesByDatabase(backupFiles, tt.databases)
+			if !reflect.DeepEqual(tt.wantFiles, files) {
+				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantFiles, files)
+			}
+		})
+	}
+}
+
+func TestGetBackupTargetFiles(t *testing.T) {
+	backupFiles := []string{
+		"backup.2023-12-18T14:00:00Z.sql",
+		"backup.2023-12-18T15:00:00Z.db2.sql",
+		"backup.2023-12-18T15:00:00Z.sql",
+		"backup.2023-12-18T15:00:00Z.db1.sql",
+		"backup.2023-12-18T16:00:00Z.db1.sql",
+	}
+	tests := []struct {
+		name       string
+		targetFile string
+		wantFiles  []string
+	}{
+		{
+			name:       "full backup",
+			targetFile: "backup.2023-12-18T15:00:00Z.sql",
+			wantFiles: []string{
+				"backup.2023-12-18T15:00:00Z.sql",
+			},
+		},
+		{
+			name:       "per-database backup",
+			targetFile: "backup.2023-12-18T15:00:00Z.db2.sql",
+			wantFiles: []string{
+				"backup.2023-12-18T15:00:00Z.db1.sql",
+				"backup.2023-12-18T15:00:00Z.db2.sql",
+			},
+		},
+		{
+			name:       "single per-database backup",
+			targetFile: "backup.2023-12-18T16:00:00Z.db1.sql",
+			wantFiles: []string{
+				"backup.2023-12-18T16:00:00Z.db1.sql",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := GetBackupTargetFiles(backupFiles, tt.targetFile)
+			if !reflect.DeepEqual(tt.wantFiles, files) {
+				t.Fatalf("unexpected backup target files, expected: %v got: %v", tt.wantFiles, files)
+			}
+		})
+	}
+}
+
 func TestGetBackupFilesToDelete(t *testing.T) {
 	previousNowFunc := now
 	tests := []struct {
@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	binlogFileRegex = regexp.MustCompile(`^[\w.-]+-bin\.\d{6,}$`)
+	binlogMagic     = []byte{0xfe, 'b', 'i', 'n'}
+)
+
+// IsValidBinlogFile determines whether a binary log file name is valid.
+func IsValidBinlogFile(fileName string) bool {
+	return binlogFileRegex.MatchString(fileName)
+}
+
+// ReadBinlogIndex reads the binary log index file maintained by MariaDB, returning the binary log file names in order.
+func ReadBinlogIndex(indexPath string) ([]string, error) {
+	file, err := os.Open(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening binlog index: %v", err)
+	}
+	defer file.Close()
+
+	var binlogs []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		binlog := filepath.Base(line)
+		if IsValidBinlogFile(binlog) {
+			binlogs = append(binlogs, binlog)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading binlog index: %v", err)
+	}
+	return binlogs, nil
+}
+
+// GetBinlogsToArchive returns the binary logs of the index that have been closed and are not archived yet.
+// The last binary log of the index is the one currently being written, therefore it is never returned.
+func GetBinlogsToArchive(index []string, archived []string) []string {
+	if len(index) <= 1 {
+		return nil
+	}
+	archivedSet := make(map[string]struct{}, len(archived))
+	for _, a := range archived {
+		archivedSet[a] = struct{}{}
+	}
+	var binlogs []string
+	for _, b := range index[:len(index)-1] {
+		if _, ok := archivedSet[b]; !ok {
+			binlogs = append(binlogs, b)
+		}
+	}
+	return binlogs
+}
+
+// SortBinlogs sorts the binary log file names in the order they were written. The sequence number suffix is compared
+// numerically, as it overflows its six zero padded digits after 999999.
+func SortBinlogs(binlogs []string) []string {
+	sorted := make([]string, len(binlogs))
+	copy(sorted, binlogs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		baseI, seqI := splitBinlogFile(sorted[i])
+		baseJ, seqJ := splitBinlogFile(sorted[j])
+		if baseI != baseJ {
+			return baseI < baseJ
+		}
+		return seqI < seqJ
+	})
+	return sorted
+}
+
+// SortBinlogsForReplay sorts the binary logs located in a path by their creation time, as recorded in their header,
+// so the binary logs written by different servers, i.e. before and after a primary failover, are replayed in order.
+func SortBinlogsForReplay(basePath string, binlogs []string) ([]string, error) {
+	sorted := SortBinlogs(binlogs)
+	createdAt := make(map[string]time.Time, len(sorted))
+	for _, binlog := range sorted {
+		t, err := BinlogCreationTime(filepath.Join(basePath, binlog))
+		if err != nil {
+			return nil, fmt.Errorf("error getting creation time of binlog %s: %v", binlog, err)
+		}
+		createdAt[binlog] = t
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return createdAt[sorted[i]].Before(createdAt[sorted[j]])
+	})
+	return sorted, nil
+}
+
+// BinlogCreationTime returns the creation time of a binary log, which is the timestamp of the format description event
+// that follows the magic number at the beginning of the file.
+func BinlogCreationTime(filePath string) (time.Time, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error opening binlog: %v", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, len(binlogMagic)+4)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return time.Time{}, fmt.Errorf("error reading binlog header: %v", err)
+	}
+	if !bytes.Equal(header[:len(binlogMagic)], binlogMagic) {
+		return time.Time{}, errors.New("invalid binlog magic number")
+	}
+	timestamp := binary.LittleEndian.Uint32(header[len(binlogMagic):])
+	return time.Unix(int64(timestamp), 0).UTC(), nil
+}
+
+// splitBinlogFile splits a binary log file name into its base name and its sequence number.
+func splitBinlogFile(fileName string) (string, int) {
+	idx := strings.LastIndex(fileName, ".")
+	if idx == -1 {
+		return fileName, 0
+	}
+	seq, err := strconv.Atoi(fileName[idx+1:])
+	if err != nil {
+		return fileName, 0
+	}
+	return fileName[:idx], seq
+}
@@ -0,0 +1,208 @@
+package backup
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsValidBinlogFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileName  string
+		wantValid bool
+	}{
+		{
+			name:      "empty",
+			fileName:  "",
+			wantValid: false,
+		},
+		{
+			name:      "index",
+			fileName:  "mariadb-bin.index",
+			wantValid: false,
+		},
+		{
+			name:      "backup",
+			fileName:  "backup.2023-12-18T16:14:00Z.sql",
+			wantValid: false,
+		},
+		{
+			name:      "no sequence",
+			fileName:  "mariadb-bin.",
+			wantValid: false,
+		},
+		{
+			name:      "valid",
+			fileName:  "mariadb-bin.000001",
+			wantValid: true,
+		},
+		{
+			name:      "valid with dashes",
+			fileName:  "mariadb-galera-bin.1000000",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := IsValidBinlogFile(tt.fileName); valid != tt.wantValid {
+				t.Fatalf("unexpected binlog file validity, expected: %v got: %v", tt.wantValid, valid)
+			}
+		})
+	}
+}
+
+func TestReadBinlogIndex(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), "mariadb-bin.index")
+	index := "./mariadb-bin.000001\n./mariadb-bin.000002\n\n/var/lib/mysql/mariadb-bin.000003\n"
+	if err := os.WriteFile(indexPath, []byte(index), 0644); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+
+	binlogs, err := ReadBinlogIndex(indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading index: %v", err)
+	}
+	wantBinlogs := []string{"mariadb-bin.000001", "mariadb-bin.000002", "mariadb-bin.000003"}
+	if !reflect.DeepEqual(binlogs, wantBinlogs) {
+		t.Fatalf("unexpected binlogs, expected: %v got: %v", wantBinlogs, binlogs)
+	}
+}
+
+func TestGetBinlogsToArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       []string
+		archived    []string
+		wantBinlogs []string
+	}{
+		{
+			name:        "empty index",
+			index:       nil,
+			archived:    nil,
+			wantBinlogs: nil,
+		},
+		{
+			name:        "only current binlog",
+			index:       []string{"mariadb-bin.000001"},
+			archived:    nil,
+			wantBinlogs: nil,
+		},
+		{
+			name:        "nothing archived",
+			index:       []string{"mariadb-bin.000001", "mariadb-bin.000002", "mariadb-bin.000003"},
+			archived:    nil,
+			wantBinlogs: []string{"mariadb-bin.000001", "mariadb-bin.000002"},
+		},
+		{
+			name:        "partially archived",
+			index:       []string{"mariadb-bin.000001", "mariadb-bin.000002", "mariadb-bin.000003"},
+			archived:    []string{"mariadb-bin.000001"},
+			wantBinlogs: []string{"mariadb-bin.000002"},
+		},
+		{
+			name:        "all archived",
+			index:       []string{"mariadb-bin.000001", "mariadb-bin.000002", "mariadb-bin.000003"},
+			archived:    []string{"mariadb-bin.000001", "mariadb-bin.000002"},
+			wantBinlogs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binlogs := GetBinlogsToArchive(tt.index, tt.archived)
+			if !reflect.DeepEqual(binlogs, tt.wantBinlogs) {
+				t.Fatalf("unexpected binlogs to archive, expected: %v got: %v", tt.wantBinlogs, binlogs)
+			}
+		})
+	}
+}
+
+func TestSortBinlogs(t *testing.T) {
+	tests := []struct {
+		name        string
+		binlogs     []string
+		wantBinlogs []string
+	}{
+		{
+			name:        "empty",
+			binlogs:     nil,
+			wantBinlogs: []string{},
+		},
+		{
+			name:        "sorted",
+			binlogs:     []string{"mariadb-bin.000001", "mariadb-bin.000002"},
+			wantBinlogs: []string{"mariadb-bin.000001", "mariadb-bin.000002"},
+		},
+		{
+			name:        "unsorted",
+			binlogs:     []string{"mariadb-bin.000003", "mariadb-bin.000001", "mariadb-bin.000002"},
+			wantBinlogs: []string{"mariadb-bin.000001", "mariadb-bin.000002", "mariadb-bin.000003"},
+		},
+		{
+			name:        "sequence overflow",
+			binlogs:     []string{"mariadb-bin.1000000", "mariadb-bin.999999", "mariadb-bin.1000001"},
+			wantBinlogs: []string{"mariadb-bin.999999", "mariadb-bin.1000000", "mariadb-bin.1000001"},
+		},
+		{
+			name:        "multiple Pods",
+			binlogs:     []string{"mariadb-1.mariadb-bin.000002", "mariadb-0.mariadb-bin.000010", "mariadb-0.mariadb-bin.000009"},
+			wantBinlogs: []string{"mariadb-0.mariadb-bin.000009", "mariadb-0.mariadb-bin.000010", "mariadb-1.mariadb-bin.000002"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binlogs := SortBinlogs(tt.binlogs)
+			if !reflect.DeepEqual(binlogs, tt.wantBinlogs) {
+				t.Fatalf("unexpected sorted binlogs, expected: %v got: %v", tt.wantBinlogs, binlogs)
+			}
+		})
+	}
+}
+
+func TestSortBinlogsForReplay(t *testing.T) {
+	basePath := t.TempDir()
+	binlogs := map[string]uint32{
+		"mariadb-0.mariadb-bin.000001": 1000,
+		"mariadb-0.mariadb-bin.000002": 3000,
+		"mariadb-1.mariadb-bin.000001": 1000,
+		"mariadb-1.mariadb-bin.000002": 2000,
+	}
+	for binlog, timestamp := range binlogs {
+		header := append([]byte{0xfe, 'b', 'i', 'n'}, binary.LittleEndian.AppendUint32(nil, timestamp)...)
+		if err := os.WriteFile(filepath.Join(basePath, binlog), header, 0644); err != nil {
+			t.Fatalf("unexpected error writing binlog: %v", err)
+		}
+	}
+
+	sorted, err := SortBinlogsForReplay(basePath, []string{
+		"mariadb-1.mariadb-bin.000002",
+		"mariadb-0.mariadb-bin.000002",
+		"mariadb-1.mariadb-bin.000001",
+		"mariadb-0.mariadb-bin.000001",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error sorting binlogs: %v", err)
+	}
+	wantBinlogs := []string{
+		"mariadb-0.mariadb-bin.000001",
+		"mariadb-1.mariadb-bin.000001",
+		"mariadb-1.mariadb-bin.000002",
+		"mariadb-0.mariadb-bin.000002",
+	}
+	if !reflect.DeepEqual(sorted, wantBinlogs) {
+		t.Fatalf("unexpected sorted binlogs, expected: %v got: %v", wantBinlogs, sorted)
+	}
+
+	invalidBinlog := "mariadb-0.mariadb-bin.000003"
+	if err := os.WriteFile(filepath.Join(basePath, invalidBinlog), []byte("invalid"), 0644); err != nil {
+		t.Fatalf("unexpected error writing binlog: %v", err)
+	}
+	if _, err := SortBinlogsForReplay(basePath, []string{invalidBinlog}); err == nil {
+		t.Fatal("expected error sorting invalid binlog")
+	}
+}
@@ -0,0 +1,59 @@
+package backup
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var compressionExtensions = map[string]string{
+	CompressionGzip:  ".gz",
+	CompressionZstd:  ".zst",
+	CompressionBzip2: ".bz2",
+}
+
+// CompressionExtension returns the file extension of a compression algorithm.
+func CompressionExtension(compression string) string {
+	return compressionExtensions[compression]
+}
+
+// GetCompression determines the compression algorithm of a backup file by its extension.
+func GetCompression(fileName string) string {
+	for compression, ext := range compressionExtensions {
+		if strings.HasSuffix(fileName, ext) {
+			return compression
+		}
+	}
+	return CompressionNone
+}
+
+func isSupportedCompression(compression string) bool {
+	if compression == CompressionNone {
+		return true
+	}
+	_, ok := compressionExtensions[compression]
+	return ok
+}
+
+func newDecompressReader(compression string, reader io.Reader) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(reader)
+	case CompressionZstd:
+		zstdReader, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return zstdReader.IOReadCloser(), nil
+	case CompressionBzip2:
+		return io.NopCloser(bzip2.NewReader(reader)), nil
+	case CompressionNone:
+		return io.NopCloser(reader), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
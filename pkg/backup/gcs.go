@@ -0,0 +1,175 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"cloud.google.com/go/storage"
+	"github.com/go-logr/logr"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+type GCSBackupStorageOpts struct {
+	Prefix          string
+	CredentialsPath string
+	Progress        *Progress
+	FileFilter      func(fileName string) bool
+}
+
+type GCSBackupStorageOpt func(s *GCSBackupStorageOpts)
+
+func WithGCSPrefix(prefix string) GCSBackupStorageOpt {
+	return func(s *GCSBackupStorageOpts) {
+		s.Prefix = prefix
+	}
+}
+
+// WithGCSCredentials configures a service account key in JSON format. When not provided, the credentials are
+// obtained from the environment, for instance, from the GKE metadata server when using Workload Identity.
+func WithGCSCredentials(credentialsPath string) GCSBackupStorageOpt {
+	return func(s *GCSBackupStorageOpts) {
+		s.CredentialsPath = credentialsPath
+	}
+}
+
+func WithGCSProgress(progress *Progress) GCSBackupStorageOpt {
+	return func(s *GCSBackupStorageOpts) {
+		s.Progress = progress
+	}
+}
+
+// WithGCSFileFilter overrides the function that determines which files are listed, backup files by default.
+func WithGCSFileFilter(filter func(fileName string) bool) GCSBackupStorageOpt {
+	return func(s *GCSBackupStorageOpts) {
+		s.FileFilter = filter
+	}
+}
+
+type GCSBackupStorage struct {
+	GCSBackupStorageOpts
+	basePath string
+	bucket   string
+	logger   logr.Logger
+	client   *storage.Client
+}
+
+func NewGCSBackupStorage(ctx context.Context, basePath, bucket string, logger logr.Logger,
+	gcsOpts ...GCSBackupStorageOpt) (BackupStorage, error) {
+	opts := GCSBackupStorageOpts{}
+	for _, setOpt := range gcsOpts {
+		setOpt(&opts)
+	}
+	if opts.FileFilter == nil {
+		opts.FileFilter = IsValidBackupFile
+	}
+
+	var clientOpts []option.ClientOption
+	if opts.CredentialsPath != "" {
+		clientOpts = append(clientOpts, option.WithCredentialsFile(opts.CredentialsPath))
+	}
+	client, err := storage.NewClient(ctx, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GCS client: %v", err)
+	}
+
+	return &GCSBackupStorage{
+		GCSBackupStorageOpts: opts,
+		basePath:             basePath,
+		bucket:               bucket,
+		client:               client,
+		logger:               logger,
+	}, nil
+}
+
+func (g *GCSBackupStorage) List(ctx context.Context) ([]string, error) {
+	var fileNames []string
+	it := g.client.Bucket(g.bucket).Objects(ctx, &storage.Query{
+		Prefix: normalizePrefix(g.Prefix),
+	})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing objects: %v", err)
+		}
+		fileName := unprefixedFileName(g.Prefix, attrs.Name)
+		if shouldProcessFile(fileName, g.FileFilter, g.logger) {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	return fileNames, nil
+}
+
+func (g *GCSBackupStorage) Push(ctx context.Context, fileName string) error {
+	file, err := os.Open(filepath.Join(g.basePath, fileName))
+	if err != nil {
+		return fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error getting file info: %v", err)
+	}
+	g.Progress.StartTransfer(fileName, info.Size())
+
+	writer := g.client.Bucket(g.bucket).Object(prefixedFileName(g.Prefix, fileName)).NewWriter(ctx)
+	if _, err := io.Copy(g.Progress.Writer(writer), file); err != nil {
+		writer.Close()
+		return fmt.Errorf("error uploading object: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error uploading object: %v", err)
+	}
+	return nil
+}
+
+func (g *GCSBackupStorage) Pull(ctx context.Context, fileName string) error {
+	reader, err := g.client.Bucket(g.bucket).Object(prefixedFileName(g.Prefix, fileName)).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("error getting object: %w", ErrFileNotFound)
+		}
+		return fmt.Errorf("error getting object: %v", err)
+	}
+	defer reader.Close()
+	g.Progress.StartTransfer(fileName, reader.Attrs.Size)
+
+	if err := downloadFile(filepath.Join(g.basePath, fileName), reader, g.Progress); err != nil {
+		return fmt.Errorf("error downloading object: %v", err)
+	}
+	return nil
+}
+
+func (g *GCSBackupStorage) Delete(ctx context.Context, fileName string) error {
+	return g.client.Bucket(g.bucket).Object(prefixedFileName(g.Prefix, fileName)).Delete(ctx)
+}
+
+func (g *GCSBackupStorage) Stat(ctx context.Context, fileName string) (*FileInfo, error) {
+	attrs, err := g.client.Bucket(g.bucket).Object(prefixedFileName(g.Prefix, fileName)).Attrs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting object attributes: %v", err)
+	}
+	return &FileInfo{
+		Size:         attrs.Size,
+		StorageClass: attrs.StorageClass,
+	}, nil
+}
+
+// SetStorageClass rewrites the object onto itself with a new storage class.
+// See: https://cloud.google.com/storage/docs/changing-storage-classes.
+func (g *GCSBackupStorage) SetStorageClass(ctx context.Context, fileName, storageClass string) error {
+	object := g.client.Bucket(g.bucket).Object(prefixedFileName(g.Prefix, fileName))
+	copier := object.CopierFrom(object)
+	copier.StorageClass = storageClass
+	if _, err := copier.Run(ctx); err != nil {
+		return fmt.Errorf("error rewriting object: %v", err)
+	}
+	return nil
+}
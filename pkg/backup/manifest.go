@@ -0,0 +1,219 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ManifestVersion is the version of the manifest format written alongside the backup files.
+	ManifestVersion = "v1"
+
+	manifestSuffix = ".manifest.json"
+	// maxHeaderLines is the number of lines scanned in the beginning of the dump to find the header comments.
+	maxHeaderLines = 100
+)
+
+type Topology string
+
+const (
+	TopologyStandalone  Topology = "standalone"
+	TopologyReplication Topology = "replication"
+	TopologyGalera      Topology = "galera"
+)
+
+const (
+	CompressionNone  = "none"
+	CompressionGzip  = "gzip"
+	CompressionZstd  = "zstd"
+	CompressionBzip2 = "bzip2"
+
+	EncryptionNone = "none"
+)
+
+// ErrFileNotFound is returned by the BackupStorage when the requested file does not exist.
+var ErrFileNotFound = errors.New("file not found")
+
+// ErrFileArchived is returned by the BackupStorage when the requested file is archived, and it needs to be restored
+// in the storage before being read.
+var ErrFileArchived = errors.New("file is archived, it must be restored in the storage before being read")
+
+// ManifestTopology describes the MariaDB the backup was taken from.
+type ManifestTopology struct {
+	Type     Topology `json:"type"`
+	Replicas int32    `json:"replicas,omitempty"`
+}
+
+// Manifest describes a backup file, allowing to validate its compatibility before restoring it.
+type Manifest struct {
+	FormatVersion  string           `json:"formatVersion"`
+	BackupFile     string           `json:"backupFile"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	ServerVersion  string           `json:"serverVersion,omitempty"`
+	Tool           string           `json:"tool,omitempty"`
+	ToolVersion    string           `json:"toolVersion,omitempty"`
+	Topology       ManifestTopology `json:"topology"`
+	GtidPosition   string           `json:"gtidPosition,omitempty"`
+	BinlogFile     string           `json:"binlogFile,omitempty"`
+	BinlogPosition int64            `json:"binlogPosition,omitempty"`
+	Compression    string           `json:"compression"`
+	Encryption     string           `json:"encryption"`
+}
+
+// ManifestFileName returns the name of the manifest file of a backup file.
+func ManifestFileName(backupFile string) string {
+	return backupFile + manifestSuffix
+}
+
+var (
+	dumpToolRegex      = regexp.MustCompile(`^-- (\S+) dump [\d.]+\s+Distrib (\S+?),?(\s|$)`)
+	serverVersionRegex = regexp.MustCompile(`^-- Server version\s+(\S+)`)
+	binlogRegex        = regexp.MustCompile(`^-- CHANGE MASTER TO MASTER_LOG_FILE='([^']+)', MASTER_LOG_POS=(\d+)`)
+	gtidRegex          = regexp.MustCompile(`^-- SET GLOBAL gtid_slave_pos='([^']*)'`)
+)
+
+// NewManifest builds the Manifest of a backup file by inspecting the header comments written by mariadb-dump.
+func NewManifest(backupFilePath string, topology ManifestTopology, encryption string) (*Manifest, error) {
+	file, err := os.Open(backupFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening backup file: %v", err)
+	}
+	defer file.Close()
+
+	manifest := &Manifest{
+		FormatVersion: ManifestVersion,
+		BackupFile:    filepath.Base(backupFilePath),
+		CreatedAt:     now().UTC(),
+		Topology:      topology,
+		Compression:   GetCompression(backupFilePath),
+		Encryption:    encryption,
+	}
+	if manifest.Encryption == "" {
+		manifest.Encryption = EncryptionNone
+	}
+	if createdAt, err := parseDateInBackupFile(manifest.BackupFile); err == nil {
+		manifest.CreatedAt = createdAt
+	}
+
+	reader, err := newDecompressReader(manifest.Compression, file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading compressed backup file: %v", err)
+	}
+	defer reader.Close()
+
+	if err := manifest.parseDumpHeader(reader); err != nil {
+		return nil, fmt.Errorf("error parsing dump header: %v", err)
+	}
+	return manifest, nil
+}
+
+func (m *Manifest) parseDumpHeader(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for i := 0; i < maxHeaderLines && scanner.Scan(); i++ {
+		line := scanner.Text()
+		if match := dumpToolRegex.FindStringSubmatch(line); match != nil {
+			m.Tool = fmt.Sprintf("%s-dump", strings.ToLower(match[1]))
+			m.ToolVersion = match[2]
+			continue
+		}
+		if match := serverVersionRegex.FindStringSubmatch(line); match != nil {
+			m.ServerVersion = match[1]
+			continue
+		}
+		if match := binlogRegex.FindStringSubmatch(line); match != nil {
+			pos, err := strconv.ParseInt(match[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("error parsing binlog position: %v", err)
+			}
+			m.BinlogFile = match[1]
+			m.BinlogPosition = pos
+			continue
+		}
+		if match := gtidRegex.FindStringSubmatch(line); match != nil {
+			m.GtidPosition = match[1]
+		}
+	}
+	// Lines longer than the buffer are not part of the header, the data has already been reached.
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return err
+	}
+	return nil
+}
+
+// WriteManifest writes the Manifest as JSON into the given path.
+func WriteManifest(path string, manifest *Manifest) error {
+	bytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling manifest: %v", err)
+	}
+	return os.WriteFile(path, bytes, 0644)
+}
+
+// ReadManifest reads a JSON Manifest from the given path.
+func ReadManifest(path string) (*Manifest, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(bytes, &manifest); err != nil {
+		return nil, fmt.Errorf("error unmarshalling manifest: %v", err)
+	}
+	return &manifest, nil
+}
+
+// CheckCompatibility validates that the backup described by the Manifest can be restored into a server with the given version.
+func (m *Manifest) CheckCompatibility(targetServerVersion string) error {
+	if m.FormatVersion != ManifestVersion {
+		return fmt.Errorf("unsupported manifest format version '%s'", m.FormatVersion)
+	}
+	if !isSupportedCompression(m.Compression) {
+		return fmt.Errorf("unsupported compression '%s'", m.Compression)
+	}
+	if m.ServerVersion == "" || targetServerVersion == "" {
+		return nil
+	}
+	source, err := parseMajorMinor(m.ServerVersion)
+	if err != nil {
+		return fmt.Errorf("error parsing backup server version: %v", err)
+	}
+	target, err := parseMajorMinor(targetServerVersion)
+	if err != nil {
+		return fmt.Errorf("error parsing target server version: %v", err)
+	}
+	if target[0] < source[0] || (target[0] == source[0] && target[1] < source[1]) {
+		return fmt.Errorf(
+			"backup taken from server version '%s' cannot be restored into older server version '%s'",
+			m.ServerVersion,
+			targetServerVersion,
+		)
+	}
+	return nil
+}
+
+func parseMajorMinor(version string) ([2]int, error) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return [2]int{}, fmt.Errorf("invalid version '%s'", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid major version '%s': %v", parts[0], err)
+	}
+	minor, err := strconv.Atoi(strings.TrimFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' }))
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid minor version '%s': %v", parts[1], err)
+	}
+	return [2]int{major, minor}, nil
+}
@@ -0,0 +1,299 @@
+package backup
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const dumpHeader = `-- MariaDB dump 10.19  Distrib 10.11.2-MariaDB, for debian-linux-gnu (x86_64)
+--
+-- Host: mariadb.default.svc.cluster.local    Database:
+-- ------------------------------------------------------
+-- Server version	10.11.2-MariaDB-1:10.11.2+maria~ubu2204-log
+
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+
+--
+-- Position to start replication or point-in-time recovery from
+--
+
+-- CHANGE MASTER TO MASTER_LOG_FILE='mariadb-bin.000002', MASTER_LOG_POS=1234;
+
+--
+-- GTID to start replication from
+--
+
+-- SET GLOBAL gtid_slave_pos='0-10-42';
+
+CREATE DATABASE IF NOT EXISTS mariadb;
+`
+
+func TestNewManifest(t *testing.T) {
+	dir := t.TempDir()
+	plainFile := filepath.Join(dir, "backup.2023-12-18T16:14:00Z.sql")
+	if err := os.WriteFile(plainFile, []byte(dumpHeader), 0644); err != nil {
+		t.Fatalf("unexpected error writing backup file: %v", err)
+	}
+	gzipFile := filepath.Join(dir, "backup.2023-12-18T16:14:00Z.sql.gz")
+	writeGzip(t, gzipFile, dumpHeader)
+	zstdFile := filepath.Join(dir, "backup.2023-12-18T16:14:00Z.sql.zst")
+	writeZstd(t, zstdFile, dumpHeader)
+
+	topology := ManifestTopology{
+		Type:     TopologyReplication,
+		Replicas: 3,
+	}
+	tests := []struct {
+		name            string
+		file            string
+		encryption      string
+		wantCompression string
+		wantEncryption  string
+	}{
+		{
+			name:            "plain",
+			file:            plainFile,
+			wantCompression: CompressionNone,
+			wantEncryption:  EncryptionNone,
+		},
+		{
+			name:            "gzip",
+			file:            gzipFile,
+			encryption:      "KMS",
+			wantCompression: CompressionGzip,
+			wantEncryption:  "KMS",
+		},
+		{
+			name:            "zstd",
+			file:            zstdFile,
+			wantCompression: CompressionZstd,
+			wantEncryption:  EncryptionNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := NewManifest(tt.file, topology, tt.encryption)
+			if err != nil {
+				t.Fatalf("unexpected error building manifest: %v", err)
+			}
+			want := Manifest{
+				FormatVersion:  ManifestVersion,
+				BackupFile:     filepath.Base(tt.file),
+				CreatedAt:      time.Date(2023, 12, 18, 16, 14, 0, 0, time.UTC),
+				ServerVersion:  "10.11.2-MariaDB-1:10.11.2+maria~ubu2204-log",
+				Tool:           "mariadb-dump",
+				ToolVersion:    "10.11.2-MariaDB",
+				Topology:       topology,
+				GtidPosition:   "0-10-42",
+				BinlogFile:     "mariadb-bin.000002",
+				BinlogPosition: 1234,
+				Compression:    tt.wantCompression,
+				Encryption:     tt.wantEncryption,
+			}
+			if *manifest != want {
+				t.Errorf("unexpected manifest:\nexpected: %+v\ngot:      %+v", want, *manifest)
+			}
+
+			manifestPath := filepath.Join(dir, ManifestFileName(manifest.BackupFile))
+			if err := WriteManifest(manifestPath, manifest); err != nil {
+				t.Fatalf("unexpected error writing manifest: %v", err)
+			}
+			info, err := os.Stat(manifestPath)
+			if err != nil {
+				t.Fatalf("unexpected error getting manifest info: %v", err)
+			}
+			if perm := info.Mode().Perm(); perm&0022 != 0 || perm&0111 != 0 {
+				t.Errorf("unexpected manifest permissions: %v", perm)
+			}
+			read, err := ReadManifest(manifestPath)
+			if err != nil {
+				t.Fatalf("unexpected error reading manifest: %v", err)
+			}
+			if *read != want {
+				t.Errorf("unexpected manifest read:\nexpected: %+v\ngot:      %+v", want, *read)
+			}
+			if IsValidBackupFile(ManifestFileName(manifest.BackupFile)) {
+				t.Error("expected manifest file not to be a valid backup file")
+			}
+		})
+	}
+}
+
+func TestManifestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      Manifest
+		targetVersion string
+		wantErr       bool
+	}{
+		{
+			name: "same version",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "10.11.2-MariaDB-log",
+				Compression:   CompressionGzip,
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       false,
+		},
+		{
+			name: "newer target",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "10.6.16-MariaDB",
+				Compression:   CompressionNone,
+			},
+			targetVersion: "11.2.2-MariaDB",
+			wantErr:       false,
+		},
+		{
+			name: "older patch",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "10.11.6-MariaDB",
+				Compression:   CompressionNone,
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       false,
+		},
+		{
+			name: "older minor",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "10.11.2-MariaDB",
+				Compression:   CompressionNone,
+			},
+			targetVersion: "10.6.16-MariaDB",
+			wantErr:       true,
+		},
+		{
+			name: "older major",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "11.2.2-MariaDB",
+				Compression:   CompressionNone,
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       true,
+		},
+		{
+			name: "unknown source version",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				Compression:   CompressionNone,
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       false,
+		},
+		{
+			name: "zstd compression",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "10.11.2-MariaDB",
+				Compression:   CompressionZstd,
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       false,
+		},
+		{
+			name: "unsupported format version",
+			manifest: Manifest{
+				FormatVersion: "v2",
+				ServerVersion: "10.11.2-MariaDB",
+				Compression:   CompressionNone,
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       true,
+		},
+		{
+			name: "unsupported compression",
+			manifest: Manifest{
+				FormatVersion: ManifestVersion,
+				ServerVersion: "10.11.2-MariaDB",
+				Compression:   "lz4",
+			},
+			targetVersion: "10.11.2-MariaDB",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.CheckCompatibility(tt.targetVersion)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func writeGzip(t *testing.T, path, content string) {
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unexpected error creating file: %v", err)
+	}
+	defer file.Close()
+	writer := gzip.NewWriter(file)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatalf("unexpected error writing gzip: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error closing gzip: %v", err)
+	}
+}
+
+func TestGetCompression(t *testing.T) {
+	tests := []struct {
+		fileName        string
+		wantCompression string
+	}{
+		{
+			fileName:        "backup.2023-12-18T16:14:00Z.sql",
+			wantCompression: CompressionNone,
+		},
+		{
+			fileName:        "backup.2023-12-18T16:14:00Z.sql.gz",
+			wantCompression: CompressionGzip,
+		},
+		{
+			fileName:        "backup.2023-12-18T16:14:00Z.sql.zst",
+			wantCompression: CompressionZstd,
+		},
+		{
+			fileName:        "backup.2023-12-18T16:14:00Z.sql.bz2",
+			wantCompression: CompressionBzip2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			if compression := GetCompression(tt.fileName); compression != tt.wantCompression {
+				t.Errorf("unexpected compression, expected: %s got: %s", tt.wantCompression, compression)
+			}
+		})
+	}
+}
+
+func writeZstd(t *testing.T, path, content string) {
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unexpected error creating file: %v", err)
+	}
+	defer file.Close()
+	writer, err := zstd.NewWriter(file)
+	if err != nil {
+		t.Fatalf("unexpected error creating zstd writer: %v", err)
+	}
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatalf("unexpected error writing zstd: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error closing zstd: %v", err)
+	}
+}
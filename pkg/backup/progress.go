@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Phase string
+
+const (
+	PhaseListing     Phase = "Listing"
+	PhaseUploading   Phase = "Uploading"
+	PhaseDownloading Phase = "Downloading"
+	PhaseCleanup     Phase = "Cleanup"
+	PhaseTiering     Phase = "Tiering"
+	PhaseCompleted   Phase = "Completed"
+)
+
+var phases = []Phase{
+	PhaseListing,
+	PhaseUploading,
+	PhaseDownloading,
+	PhaseCleanup,
+	PhaseTiering,
+	PhaseCompleted,
+}
+
+// ProgressSnapshot is a point in time view of the progress of a backup operation.
+type ProgressSnapshot struct {
+	Phase            Phase
+	File             string
+	DumpedBytes      int64
+	TotalBytes       int64
+	TransferredBytes int64
+	Percent          float64
+	ETA              time.Duration
+}
+
+// Progress tracks the phase and the bytes transferred by a backup operation, exposing them as logs and metrics.
+// A nil Progress is valid and does not track anything.
+type Progress struct {
+	operation string
+	logger    logr.Logger
+	now       func() time.Time
+
+	mux              sync.Mutex
+	phase            Phase
+	file             string
+	dumpedBytes      int64
+	totalBytes       int64
+	transferredBytes int64
+	startTime        time.Time
+
+	phaseGauge       *prometheus.GaugeVec
+	dumpedGauge      prometheus.Gauge
+	totalGauge       prometheus.Gauge
+	transferredGauge prometheus.Gauge
+	etaGauge         prometheus.Gauge
+}
+
+func NewProgress(operation string, logger logr.Logger) *Progress {
+	labels := prometheus.Labels{"operation": operation}
+	return &Progress{
+		operation: operation,
+		logger:    logger,
+		now:       time.Now,
+		phaseGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "mariadb_operator_backup_phase",
+			Help:        "Current phase of the backup operation, 1 for the current phase and 0 for the rest.",
+			ConstLabels: labels,
+		}, []string{"phase"}),
+		dumpedGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "mariadb_operator_backup_dumped_bytes",
+			Help:        "Size in bytes of the backup file dumped by the backup operation.",
+			ConstLabels: labels,
+		}),
+		totalGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "mariadb_operator_backup_total_bytes",
+			Help:        "Size in bytes of the backup file being transferred.",
+			ConstLabels: labels,
+		}),
+		transferredGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "mariadb_operator_backup_transferred_bytes",
+			Help:        "Bytes of the backup file transferred so far.",
+			ConstLabels: labels,
+		}),
+		etaGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "mariadb_operator_backup_eta_seconds",
+			Help:        "Estimated time in seconds to complete the current transfer.",
+			ConstLabels: labels,
+		}),
+	}
+}
+
+// Register registers the progress metrics in the given registerer.
+func (p *Progress) Register(registerer prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		p.phaseGauge,
+		p.dumpedGauge,
+		p.totalGauge,
+		p.transferredGauge,
+		p.etaGauge,
+	}
+	for _, c := range collectors {
+		if err := registerer.Register(c); err != nil {
+			return fmt.Errorf("error registering progress metric: %v", err)
+		}
+	}
+	return nil
+}
+
+func (p *Progress) SetPhase(phase Phase) {
+	if p == nil {
+		return
+	}
+	p.mux.Lock()
+	p.phase = phase
+	p.mux.Unlock()
+
+	for _, ph := range phases {
+		value := 0.0
+		if ph == phase {
+			value = 1
+		}
+		p.phaseGauge.WithLabelValues(string(ph)).Set(value)
+	}
+	p.logger.Info("backup phase", "operation", p.operation, "phase", phase)
+}
+
+func (p *Progress) SetDumpedBytes(bytes int64) {
+	if p == nil {
+		return
+	}
+	p.mux.Lock()
+	p.dumpedBytes = bytes
+	p.mux.Unlock()
+
+	p.dumpedGauge.Set(float64(bytes))
+}
+
+// StartTransfer starts tracking the transfer of a file of the given size.
+func (p *Progress) StartTransfer(file string, totalBytes int64) {
+	if p == nil {
+		return
+	}
+	p.mux.Lock()
+	p.file = file
+	p.totalBytes = totalBytes
+	p.transferredBytes = 0
+	p.startTime = p.now()
+	p.mux.Unlock()
+
+	p.totalGauge.Set(float64(totalBytes))
+	p.transferredGauge.Set(0)
+}
+
+// Add adds transferred bytes to the current transfer.
+func (p *Progress) Add(bytes int64) {
+	if p == nil {
+		return
+	}
+	p.mux.Lock()
+	p.transferredBytes += bytes
+	p.mux.Unlock()
+
+	snapshot := p.Snapshot()
+	p.transferredGauge.Set(float64(snapshot.TransferredBytes))
+	p.etaGauge.Set(snapshot.ETA.Seconds())
+}
+
+// Snapshot returns the current progress, estimating the time left based on the average throughput.
+func (p *Progress) Snapshot() ProgressSnapshot {
+	if p == nil {
+		return ProgressSnapshot{}
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	snapshot := ProgressSnapshot{
+		Phase:            p.phase,
+		File:             p.file,
+		DumpedBytes:      p.dumpedBytes,
+		TotalBytes:       p.totalBytes,
+		TransferredBytes: p.transferredBytes,
+	}
+	if p.totalBytes > 0 {
+		snapshot.Percent = float64(p.transferredBytes) * 100 / float64(p.totalBytes)
+	}
+	elapsed := p.now().Sub(p.startTime)
+	if p.transferredBytes > 0 && p.transferredBytes < p.totalBytes && elapsed > 0 {
+		throughput := float64(p.transferredBytes) / elapsed.Seconds()
+		snapshot.ETA = time.Duration(float64(p.totalBytes-p.transferredBytes) / throughput * float64(time.Second))
+	}
+	return snapshot
+}
+
+// LogPeriodically logs the progress at the given interval until the context is cancelled.
+func (p *Progress) LogPeriodically(ctx context.Context, interval time.Duration) {
+	if p == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s := p.Snapshot()
+			// streamed backups have an unknown size, only the transferred bytes are reported.
+			if s.TotalBytes == 0 && s.TransferredBytes == 0 {
+				continue
+			}
+			p.logger.Info(
+				"backup progress",
+				"operation", p.operation,
+				"phase", s.Phase,
+				"file", s.File,
+				"transferred-bytes", s.TransferredBytes,
+				"total-bytes", s.TotalBytes,
+				"percent", fmt.Sprintf("%.2f", s.Percent),
+				"eta", s.ETA.Round(time.Second).String(),
+			)
+		}
+	}
+}
+
+// Hook returns a reader that tracks the bytes read from it without copying them,
+// to be used as a progress hook by the S3 client.
+func (p *Progress) Hook() io.Reader {
+	if p == nil {
+		return nil
+	}
+	return &progressHook{progress: p}
+}
+
+// Writer returns a writer that tracks the bytes written to the given writer.
+func (p *Progress) Writer(w io.Writer) io.Writer {
+	if p == nil {
+		return w
+	}
+	return &progressWriter{
+		writer:   w,
+		progress: p,
+	}
+}
+
+type progressHook struct {
+	progress *Progress
+}
+
+func (h *progressHook) Read(b []byte) (int, error) {
+	h.progress.Add(int64(len(b)))
+	return len(b), nil
+}
+
+type progressWriter struct {
+	writer   io.Writer
+	progress *Progress
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	n, err := w.writer.Write(b)
+	w.progress.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestProgress(t *testing.T) {
+	start := time.Date(2023, 12, 18, 16, 0, 0, 0, time.UTC)
+	current := start
+
+	progress := NewProgress("backup", logger)
+	progress.now = func() time.Time { return current }
+	registry := prometheus.NewRegistry()
+	if err := progress.Register(registry); err != nil {
+		t.Fatalf("unexpected error registering metrics: %v", err)
+	}
+
+	progress.SetPhase(PhaseUploading)
+	progress.SetDumpedBytes(1000)
+	progress.StartTransfer("backup.2023-12-18T16:00:00Z.sql", 1000)
+
+	current = start.Add(10 * time.Second)
+	if _, err := io.Copy(progress.Writer(io.Discard), strings.NewReader(strings.Repeat("a", 250))); err != nil {
+		t.Fatalf("unexpected error copying: %v", err)
+	}
+
+	snapshot := progress.Snapshot()
+	if snapshot.Phase != PhaseUploading {
+		t.Errorf("expected phase '%s', got '%s'", PhaseUploading, snapshot.Phase)
+	}
+	if snapshot.TransferredBytes != 250 {
+		t.Errorf("expected 250 transferred bytes, got %d", snapshot.TransferredBytes)
+	}
+	if snapshot.Percent != 25 {
+		t.Errorf("expected 25 percent, got %f", snapshot.Percent)
+	}
+	if snapshot.ETA != 30*time.Second {
+		t.Errorf("expected 30s ETA, got %s", snapshot.ETA)
+	}
+
+	if _, err := progress.Hook().Read(make([]byte, 750)); err != nil {
+		t.Fatalf("unexpected error reading hook: %v", err)
+	}
+	snapshot = progress.Snapshot()
+	if snapshot.Percent != 100 || snapshot.ETA != 0 {
+		t.Errorf("expected transfer to be completed, got %+v", snapshot)
+	}
+
+	expected := `
+# HELP mariadb_operator_backup_transferred_bytes Bytes of the backup file transferred so far.
+# TYPE mariadb_operator_backup_transferred_bytes gauge
+mariadb_operator_backup_transferred_bytes{operation="backup"} 1000
+`
+	err := testutil.GatherAndCompare(registry, bytes.NewBufferString(expected), "mariadb_operator_backup_transferred_bytes")
+	if err != nil {
+		t.Errorf("unexpected metrics: %v", err)
+	}
+}
+
+func TestNilProgress(t *testing.T) {
+	var progress *Progress
+	progress.SetPhase(PhaseDownloading)
+	progress.StartTransfer("backup.2023-12-18T16:00:00Z.sql", 1000)
+	progress.Add(100)
+
+	if progress.Hook() != nil {
+		t.Error("expected nil hook")
+	}
+	var buf bytes.Buffer
+	if w := progress.Writer(&buf); w != &buf {
+		t.Error("expected the writer to be returned unchanged")
+	}
+	if snapshot := progress.Snapshot(); snapshot != (ProgressSnapshot{}) {
+		t.Errorf("expected empty snapshot, got %+v", snapshot)
+	}
+}
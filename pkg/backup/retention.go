@@ -0,0 +1,138 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// maxPruneResultSize is the maximum size of the Pod termination messages, where the prune results are reported.
+const maxPruneResultSize = 4096
+
+// RetentionPolicy determines which backups are kept in the storage.
+type RetentionPolicy struct {
+	// MaxRetention is the maximum age of the backups.
+	MaxRetention time.Duration
+	// MaxBackups is the maximum number of backups to be kept, starting from the most recent one. It is disabled when zero.
+	MaxBackups int
+}
+
+// GetPrunableBackupFiles determines which backup files should be deleted according with the retention policy,
+// sorted from the oldest to the most recent one.
+func GetPrunableBackupFiles(backupFileNames []string, policy RetentionPolicy, logger logr.Logger) []string {
+	prunable := make(map[string]struct{})
+	for _, file := range GetOldBackupFiles(backupFileNames, policy.MaxRetention, logger) {
+		prunable[file] = struct{}{}
+	}
+
+	var backups []string
+	for _, file := range backupFileNames {
+		if _, err := parseDateInBackupFile(file); err == nil {
+			backups = append(backups, file)
+		}
+	}
+	sortBackupFiles(backups)
+	if policy.MaxBackups > 0 {
+		// per-database backups taken at the same time count as a single backup
+		dates := make(map[time.Time]struct{})
+		for i := len(backups) - 1; i >= 0; i-- {
+			date, _ := parseDateInBackupFile(backups[i])
+			if _, ok := dates[date]; !ok && len(dates) == policy.MaxBackups {
+				for _, file := range backups[:i+1] {
+					prunable[file] = struct{}{}
+				}
+				break
+			}
+			dates[date] = struct{}{}
+		}
+	}
+
+	var prunableFiles []string
+	for _, file := range backups {
+		if _, ok := prunable[file]; ok {
+			prunableFiles = append(prunableFiles, file)
+		}
+	}
+	return prunableFiles
+}
+
+// PruneResult summarizes the backups deleted by the retention policy.
+type PruneResult struct {
+	// Pruned is the number of backups that have been deleted.
+	Pruned int `json:"pruned"`
+	// Retained is the number of backups that remain in the storage.
+	Retained int `json:"retained"`
+	// Files are the names of the deleted backups. It may be truncated to fit in the Pod termination message.
+	Files []string `json:"files,omitempty"`
+	// Artifacts are the backups available in the storage after pruning, from the most recent to the oldest one.
+	// It may be truncated to fit in the Pod termination message.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+}
+
+// Prune deletes the backups, along with their manifests, that are not retained by the retention policy.
+func Prune(ctx context.Context, storage BackupStorage, backupFileNames []string, policy RetentionPolicy,
+	logger logr.Logger) *PruneResult {
+	prunable := GetPrunableBackupFiles(backupFileNames, policy, logger)
+	result := &PruneResult{
+		Retained: len(backupFileNames) - len(prunable),
+	}
+
+	for _, file := range prunable {
+		logger.V(1).Info("deleting old backup", "backup", file)
+		if err := storage.Delete(ctx, file); err != nil {
+			logger.Error(err, "error removing old backup", "backup", file)
+			result.Retained++
+			continue
+		}
+		// Backups taken by previous versions of the operator do not have a manifest.
+		if err := storage.Delete(ctx, ManifestFileName(file)); err != nil {
+			logger.V(1).Info("error removing old backup manifest", "backup", file, "err", err)
+		}
+		result.Pruned++
+		result.Files = append(result.Files, file)
+	}
+	return result
+}
+
+// WritePruneResult writes the prune result in JSON format, dropping file names and then the oldest artifacts
+// until it fits in a Pod termination message.
+func WritePruneResult(path string, result *PruneResult) error {
+	r := *result
+	for {
+		bytes, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("error marshaling prune result: %v", err)
+		}
+		if len(bytes) <= maxPruneResultSize || (len(r.Files) == 0 && len(r.Artifacts) == 0) {
+			return os.WriteFile(path, bytes, 0644)
+		}
+		if len(r.Files) > 0 {
+			r.Files = r.Files[:len(r.Files)-1]
+		} else {
+			r.Artifacts = r.Artifacts[:len(r.Artifacts)-1]
+		}
+	}
+}
+
+// ParsePruneResult parses a prune result previously written by WritePruneResult.
+func ParsePruneResult(raw string) (*PruneResult, error) {
+	var result PruneResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling prune result: %v", err)
+	}
+	return &result, nil
+}
+
+func sortBackupFiles(backupFileNames []string) {
+	sort.SliceStable(backupFileNames, func(i, j int) bool {
+		// errors are not expected, as the backup files have been previously filtered by date
+		iDate, _ := parseDateInBackupFile(backupFileNames[i])
+		jDate, _ := parseDateInBackupFile(backupFileNames[j])
+		return iDate.Before(jDate)
+	})
+}
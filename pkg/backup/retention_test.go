@@ -0,0 +1,244 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPrunableBackupFiles(t *testing.T) {
+	previousNowFunc := now
+	backupFiles := []string{
+		"backup.2023-12-22T20:00:00Z.sql",
+		"backup.2023-12-22T13:00:00Z.sql",
+		"backup.2023-12-22T18:00:00Z.sql",
+		"backup.2023-12-22T15:00:00Z.sql",
+		"backup.foo.sql",
+	}
+	tests := []struct {
+		name        string
+		policy      RetentionPolicy
+		wantBackups []string
+	}{
+		{
+			name: "no prunable backups",
+			policy: RetentionPolicy{
+				MaxRetention: 24 * time.Hour,
+			},
+			wantBackups: nil,
+		},
+		{
+			name: "max retention",
+			policy: RetentionPolicy{
+				MaxRetention: 8 * time.Hour,
+			},
+			wantBackups: []string{
+				"backup.2023-12-22T13:00:00Z.sql",
+			},
+		},
+		{
+			name: "max backups",
+			policy: RetentionPolicy{
+				MaxRetention: 24 * time.Hour,
+				MaxBackups:   2,
+			},
+			wantBackups: []string{
+				"backup.2023-12-22T13:00:00Z.sql",
+				"backup.2023-12-22T15:00:00Z.sql",
+			},
+		},
+		{
+			name: "max backups not exceeded",
+			policy: RetentionPolicy{
+				MaxRetention: 24 * time.Hour,
+				MaxBackups:   10,
+			},
+			wantBackups: nil,
+		},
+		{
+			name: "max retention and max backups",
+			policy: RetentionPolicy{
+				MaxRetention: 3 * time.Hour,
+				MaxBackups:   3,
+			},
+			wantBackups: []string{
+				"backup.2023-12-22T13:00:00Z.sql",
+				"backup.2023-12-22T15:00:00Z.sql",
+				"backup.2023-12-22T18:00:00Z.sql",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = timeFn(mustParseDate(t, "2023-12-22T22:10:00Z"))
+			t.Cleanup(func() {
+				now = previousNowFunc
+			})
+
+			backups := GetPrunableBackupFiles(backupFiles, tt.policy, logger)
+			if !reflect.DeepEqual(tt.wantBackups, backups) {
+				t.Fatalf("unexpected backup files, expected: %v got: %v", tt.wantBackups, backups)
+			}
+		})
+	}
+}
+
+func TestGetPrunableBackupFilesPerDatabase(t *testing.T) {
+	previousNowFunc := now
+	now = timeFn(mustParseDate(t, "2023-12-22T22:10:00Z"))
+	t.Cleanup(func() {
+		now = previousNowFunc
+	})
+	backupFiles := []string{
+		"backup.2023-12-22T13:00:00Z.sql",
+		"backup.2023-12-22T15:00:00Z.db1.sql",
+		"backup.2023-12-22T15:00:00Z.db2.sql",
+		"backup.2023-12-22T18:00:00Z.db1.sql",
+		"backup.2023-12-22T18:00:00Z.db2.sql",
+		"backup.2023-12-22T20:00:00Z.db1.sql",
+		"backup.2023-12-22T20:00:00Z.db2.sql",
+	}
+
+	backups := GetPrunableBackupFiles(backupFiles, RetentionPolicy{
+		MaxRetention: 24 * time.Hour,
+		MaxBackups:   2,
+	}, logger)
+	wantBackups := []string{
+		"backup.2023-12-22T13:00:00Z.sql",
+		"backup.2023-12-22T15:00:00Z.db1.sql",
+		"backup.2023-12-22T15:00:00Z.db2.sql",
+	}
+	if !reflect.DeepEqual(wantBackups, backups) {
+		t.Fatalf("unexpected backup files, expected: %v got: %v", wantBackups, backups)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	previousNowFunc := now
+	now = timeFn(mustParseDate(t, "2023-12-22T22:10:00Z"))
+	t.Cleanup(func() {
+		now = previousNowFunc
+	})
+
+	basePath := t.TempDir()
+	backupFiles := []string{
+		"backup.2023-12-22T13:00:00Z.sql",
+		"backup.2023-12-22T15:00:00Z.sql",
+		"backup.2023-12-22T20:00:00Z.sql",
+	}
+	for _, file := range backupFiles {
+		if err := os.WriteFile(filepath.Join(basePath, file), nil, 0644); err != nil {
+			t.Fatalf("unexpected error creating backup file: %v", err)
+		}
+	}
+	storage := NewFileSystemBackupStorage(basePath, logger)
+
+	result := Prune(context.Background(), storage, backupFiles, RetentionPolicy{
+		MaxRetention: 24 * time.Hour,
+		MaxBackups:   1,
+	}, logger)
+	wantResult := &PruneResult{
+		Pruned:   2,
+		Retained: 1,
+		Files: []string{
+			"backup.2023-12-22T13:00:00Z.sql",
+			"backup.2023-12-22T15:00:00Z.sql",
+		},
+	}
+	if !reflect.DeepEqual(wantResult, result) {
+		t.Fatalf("unexpected prune result, expected: %v got: %v", wantResult, result)
+	}
+
+	files, err := storage.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error listing backup files: %v", err)
+	}
+	wantFiles := []string{"backup.2023-12-22T20:00:00Z.sql"}
+	if !reflect.DeepEqual(wantFiles, files) {
+		t.Fatalf("unexpected backup files, expected: %v got: %v", wantFiles, files)
+	}
+}
+
+func TestWritePruneResult(t *testing.T) {
+	var files []string
+	for i := 0; i < 200; i++ {
+		files = append(files, "backup.2023-12-22T13:00:00Z.sql")
+	}
+	result := &PruneResult{
+		Pruned:   len(files),
+		Retained: 10,
+		Files:    files,
+	}
+	path := filepath.Join(t.TempDir(), "termination-log")
+
+	if err := WritePruneResult(path, result); err != nil {
+		t.Fatalf("unexpected error writing prune result: %v", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading prune result: %v", err)
+	}
+	if len(bytes) > maxPruneResultSize {
+		t.Fatalf("expected prune result to fit in %d bytes, got %d", maxPruneResultSize, len(bytes))
+	}
+
+	parsed, err := ParsePruneResult(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		t.Fatalf("unexpected error parsing prune result: %v", err)
+	}
+	if parsed.Pruned != 200 || parsed.Retained != 10 {
+		t.Fatalf("unexpected prune result counters: %v", parsed)
+	}
+	if len(parsed.Files) == 0 || len(parsed.Files) >= 200 {
+		t.Fatalf("expected prune result files to be truncated, got %d files", len(parsed.Files))
+	}
+	if len(result.Files) != 200 {
+		t.Fatal("expected original prune result not to be modified")
+	}
+}
+
+func TestWritePruneResultArtifacts(t *testing.T) {
+	var files []string
+	var artifacts []Artifact
+	for i := 0; i < 100; i++ {
+		files = append(files, "backup.2023-12-22T13:00:00Z.sql")
+		artifacts = append(artifacts, Artifact{
+			Name: "backup.2023-12-22T13:00:00Z.sql",
+			Size: 1024 * 1024,
+			Type: ArtifactTypeLogical,
+		})
+	}
+	result := &PruneResult{
+		Pruned:    len(files),
+		Files:     files,
+		Artifacts: artifacts,
+	}
+	path := filepath.Join(t.TempDir(), "termination-log")
+
+	if err := WritePruneResult(path, result); err != nil {
+		t.Fatalf("unexpected error writing prune result: %v", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading prune result: %v", err)
+	}
+	if len(bytes) > maxPruneResultSize {
+		t.Fatalf("expected prune result to fit in %d bytes, got %d", maxPruneResultSize, len(bytes))
+	}
+
+	parsed, err := ParsePruneResult(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		t.Fatalf("unexpected error parsing prune result: %v", err)
+	}
+	if len(parsed.Files) != 0 {
+		t.Fatalf("expected prune result files to be dropped before artifacts, got %d files", len(parsed.Files))
+	}
+	if len(parsed.Artifacts) == 0 || len(parsed.Artifacts) >= 100 {
+		t.Fatalf("expected prune result artifacts to be truncated, got %d artifacts", len(parsed.Artifacts))
+	}
+}
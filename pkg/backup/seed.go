@@ -0,0 +1,37 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseSeedFile parses a seed file definition with the '<name>=<sha256>' format.
+func ParseSeedFile(seedFile string) (name string, checksum string, err error) {
+	i := strings.LastIndex(seedFile, "=")
+	if i <= 0 || i == len(seedFile)-1 {
+		return "", "", fmt.Errorf("invalid seed file '%s', expected format is '<name>=<sha256>'", seedFile)
+	}
+	return seedFile[:i], seedFile[i+1:], nil
+}
+
+// VerifyChecksum verifies that the SHA-256 checksum of a file matches the expected hex encoded checksum.
+func VerifyChecksum(filePath string, checksum string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("error reading file: %v", err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != strings.ToLower(checksum) {
+		return fmt.Errorf("checksum mismatch, expected: %s got: %s", checksum, got)
+	}
+	return nil
+}
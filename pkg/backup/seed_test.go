@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSeedFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		seedFile     string
+		wantName     string
+		wantChecksum string
+		wantErr      bool
+	}{
+		{
+			name:     "empty",
+			seedFile: "",
+			wantErr:  true,
+		},
+		{
+			name:     "no checksum",
+			seedFile: "schema.sql=",
+			wantErr:  true,
+		},
+		{
+			name:     "no name",
+			seedFile: "=abc",
+			wantErr:  true,
+		},
+		{
+			name:         "valid",
+			seedFile:     "schema.sql=abc",
+			wantName:     "schema.sql",
+			wantChecksum: "abc",
+		},
+		{
+			name:         "name with equal sign",
+			seedFile:     "a=b.csv=abc",
+			wantName:     "a=b.csv",
+			wantChecksum: "abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, checksum, err := ParseSeedFile(tt.seedFile)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != tt.wantName || checksum != tt.wantChecksum {
+				t.Fatalf("unexpected seed file, expected: %s=%s got: %s=%s", tt.wantName, tt.wantChecksum, name, checksum)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "seed.sql")
+	if err := os.WriteFile(filePath, []byte("CREATE DATABASE demo;\n"), 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	// echo "CREATE DATABASE demo;" | sha256sum
+	checksum := "c8f21c14cf5c13bb6698c667f6f10fe57958bb12933e421323fda212a3243646"
+
+	tests := []struct {
+		name     string
+		checksum string
+		wantErr  bool
+	}{
+		{
+			name:     "mismatch",
+			checksum: "0000000000000000000000000000000000000000000000000000000000000000",
+			wantErr:  true,
+		},
+		{
+			name:     "match",
+			checksum: checksum,
+			wantErr:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChecksum(filePath, tt.checksum)
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
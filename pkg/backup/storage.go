@@ -2,13 +2,17 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-logr/logr"
 	mariadbminio "github.com/mariadb-operator/mariadb-operator/pkg/minio"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
 type BackupStorage interface {
@@ -16,6 +20,23 @@ type BackupStorage interface {
 	Push(ctx context.Context, fileName string) error
 	Pull(ctx context.Context, fileName string) error
 	Delete(ctx context.Context, fileName string) error
+	Stat(ctx context.Context, fileName string) (*FileInfo, error)
+}
+
+// TieredBackupStorage is a BackupStorage that supports moving files across storage classes.
+type TieredBackupStorage interface {
+	BackupStorage
+	SetStorageClass(ctx context.Context, fileName, storageClass string) error
+}
+
+// FileInfo describes a file in the storage.
+type FileInfo struct {
+	// Size is the size of the file in bytes.
+	Size int64
+	// StorageClass is the storage class of the file. It is empty when the storage does not support storage classes.
+	StorageClass string
+	// Archived indicates that the file is in an archive storage class, and it needs to be restored before being read.
+	Archived bool
 }
 
 type FileSystemBackupStorage struct {
@@ -38,7 +59,7 @@ func (f *FileSystemBackupStorage) List(ctx context.Context) ([]string, error) {
 	var fileNames []string
 	for _, e := range entries {
 		fileName := e.Name()
-		if shouldProcessBackupFile(fileName, f.logger) {
+		if shouldProcessFile(fileName, IsValidBackupFile, f.logger) {
 			fileNames = append(fileNames, fileName)
 		}
 	}
@@ -50,17 +71,37 @@ func (f *FileSystemBackupStorage) Push(ctx context.Context, fileName string) err
 }
 
 func (f *FileSystemBackupStorage) Pull(ctx context.Context, fileName string) error {
-	return nil // noop
+	if _, err := os.Stat(filepath.Join(f.basePath, fileName)); errors.Is(err, os.ErrNotExist) {
+		return ErrFileNotFound
+	}
+	return nil
 }
 
 func (f *FileSystemBackupStorage) Delete(ctx context.Context, fileName string) error {
 	return os.Remove(filepath.Join(f.basePath, fileName))
 }
 
+func (f *FileSystemBackupStorage) Stat(ctx context.Context, fileName string) (*FileInfo, error) {
+	info, err := os.Stat(filepath.Join(f.basePath, fileName))
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		Size: info.Size(),
+	}, nil
+}
+
 type S3BackupStorageOpts struct {
-	Region     string
-	TLS        bool
-	CACertPath string
+	Region             string
+	Prefix             string
+	TLS                bool
+	CACertPath         string
+	InsecureSkipVerify bool
+	PathStyle          bool
+	SSE                encrypt.ServerSide
+	Progress           *Progress
+	FileFilter         func(fileName string) bool
+	FileNamePrefix     string
 }
 
 type S3BackupStorageOpt func(s *S3BackupStorageOpts)
@@ -71,6 +112,12 @@ func WithRegion(region string) S3BackupStorageOpt {
 	}
 }
 
+func WithPrefix(prefix string) S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.Prefix = prefix
+	}
+}
+
 func WithTLS(caCertPath string) S3BackupStorageOpt {
 	return func(s *S3BackupStorageOpts) {
 		s.TLS = true
@@ -78,6 +125,45 @@ func WithTLS(caCertPath string) S3BackupStorageOpt {
 	}
 }
 
+func WithInsecureSkipVerify() S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.InsecureSkipVerify = true
+	}
+}
+
+func WithPathStyle() S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.PathStyle = true
+	}
+}
+
+func WithSSE(sse encrypt.ServerSide) S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.SSE = sse
+	}
+}
+
+func WithProgress(progress *Progress) S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.Progress = progress
+	}
+}
+
+// WithFileFilter overrides the function that determines which files are listed, backup files by default.
+func WithFileFilter(filter func(fileName string) bool) S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.FileFilter = filter
+	}
+}
+
+// WithFileNamePrefix prepends a prefix to the name of the objects, so files with the same name pushed by different
+// sources do not collide. Only the objects with the prefix are listed, and the prefix is trimmed from their names.
+func WithFileNamePrefix(prefix string) S3BackupStorageOpt {
+	return func(s *S3BackupStorageOpts) {
+		s.FileNamePrefix = prefix
+	}
+}
+
 type S3BackupStorage struct {
 	S3BackupStorageOpts
 	basePath string
@@ -91,6 +177,9 @@ func NewS3BackupStorage(basePath, bucket, endpoint string, logger logr.Logger, s
 	for _, setOpt := range s3Opts {
 		setOpt(&opts)
 	}
+	if opts.FileFilter == nil {
+		opts.FileFilter = IsValidBackupFile
+	}
 
 	clientOpts := []mariadbminio.MinioOpt{
 		mariadbminio.WithRegion(opts.Region),
@@ -98,24 +187,37 @@ func NewS3BackupStorage(basePath, bucket, endpoint string, logger logr.Logger, s
 	if opts.TLS {
 		clientOpts = append(clientOpts, mariadbminio.WithTLS(opts.CACertPath))
 	}
+	if opts.InsecureSkipVerify {
+		clientOpts = append(clientOpts, mariadbminio.WithInsecureSkipVerify())
+	}
+	if opts.PathStyle {
+		clientOpts = append(clientOpts, mariadbminio.WithPathStyle())
+	}
 	client, err := mariadbminio.NewMinioClient(endpoint, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating S3 client: %v", err)
 	}
 
 	return &S3BackupStorage{
-		basePath: basePath,
-		bucket:   bucket,
-		client:   client,
-		logger:   logger,
+		S3BackupStorageOpts: opts,
+		basePath:            basePath,
+		bucket:              bucket,
+		client:              client,
+		logger:              logger,
 	}, nil
 }
 
 func (s *S3BackupStorage) List(ctx context.Context) ([]string, error) {
 	var fileNames []string
-	for o := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{}) {
-		fileName := o.Key
-		if shouldProcessBackupFile(fileName, s.logger) {
+	opts := minio.ListObjectsOptions{
+		Prefix: s.objectName(""),
+	}
+	for o := range s.client.ListObjects(ctx, s.bucket, opts) {
+		if o.Err != nil {
+			return nil, fmt.Errorf("error listing objects: %v", o.Err)
+		}
+		fileName := strings.TrimPrefix(unprefixedFileName(s.Prefix, o.Key), s.FileNamePrefix)
+		if shouldProcessFile(fileName, s.FileFilter, s.logger) {
 			fileNames = append(fileNames, fileName)
 		}
 	}
@@ -124,22 +226,183 @@ func (s *S3BackupStorage) List(ctx context.Context) ([]string, error) {
 
 func (s *S3BackupStorage) Push(ctx context.Context, fileName string) error {
 	filePath := filepath.Join(s.basePath, fileName)
-	_, err := s.client.FPutObject(ctx, s.bucket, fileName, filePath, minio.PutObjectOptions{})
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("error getting file info: %v", err)
+	}
+	if isNamedPipe(info) {
+		return s.pushStream(ctx, fileName, filePath)
+	}
+	s.Progress.StartTransfer(fileName, info.Size())
+
+	_, err = s.client.FPutObject(ctx, s.bucket, s.objectName(fileName), filePath, minio.PutObjectOptions{
+		ServerSideEncryption: s.SSE,
+		Progress:             s.Progress.Hook(),
+	})
+	return err
+}
+
+// pushStream uploads a backup that is being streamed into a named pipe, using a multipart upload as its size is unknown.
+func (s *S3BackupStorage) pushStream(ctx context.Context, fileName, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening stream: %v", err)
+	}
+	defer file.Close()
+	s.Progress.StartTransfer(fileName, 0)
+
+	_, err = s.client.PutObject(ctx, s.bucket, s.objectName(fileName), file, -1, minio.PutObjectOptions{
+		ServerSideEncryption: s.SSE,
+		Progress:             s.Progress.Hook(),
+		PartSize:             streamPartSize,
+	})
 	return err
 }
 
 func (s *S3BackupStorage) Pull(ctx context.Context, fileName string) error {
-	filePath := filepath.Join(s.basePath, fileName)
-	return s.client.FGetObject(ctx, s.bucket, fileName, filePath, minio.GetObjectOptions{})
+	object, err := s.client.GetObject(ctx, s.bucket, s.objectName(fileName), minio.GetObjectOptions{
+		ServerSideEncryption: s.SSE,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting object: %v", err)
+	}
+	defer object.Close()
+	info, err := object.Stat()
+	if err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchKey":
+			return fmt.Errorf("error getting object info: %w", ErrFileNotFound)
+		case "InvalidObjectState":
+			return fmt.Errorf("error getting object info: %w", ErrFileArchived)
+		}
+		return fmt.Errorf("error getting object info: %v", err)
+	}
+	s.Progress.StartTransfer(fileName, info.Size)
+
+	if err := downloadFile(filepath.Join(s.basePath, fileName), object, s.Progress); err != nil {
+		return fmt.Errorf("error downloading object: %v", err)
+	}
+	return nil
 }
 
 func (s *S3BackupStorage) Delete(ctx context.Context, fileName string) error {
-	return s.client.RemoveObject(ctx, s.bucket, fileName, minio.RemoveObjectOptions{})
+	return s.client.RemoveObject(ctx, s.bucket, s.objectName(fileName), minio.RemoveObjectOptions{})
+}
+
+func (s *S3BackupStorage) Stat(ctx context.Context, fileName string) (*FileInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, s.objectName(fileName), minio.StatObjectOptions{
+		ServerSideEncryption: s.SSE,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting object info: %v", err)
+	}
+	storageClass := info.StorageClass
+	// S3 omits the storage class of the objects in the default one.
+	if storageClass == "" {
+		storageClass = s3StandardStorageClass
+	}
+	return &FileInfo{
+		Size:         info.Size,
+		StorageClass: storageClass,
+		// Archived objects are readable once restored, until the restored copy expires.
+		Archived: isArchivedStorageClass(storageClass) && (info.Restore == nil || info.Restore.OngoingRestore),
+	}, nil
+}
+
+// SetStorageClass copies the object onto itself with a new storage class, keeping its metadata. Objects larger than 5GiB are
+// copied with a multipart upload, as they cannot be copied in a single request.
+// See: https://docs.aws.amazon.com/AmazonS3/latest/userguide/sc-howtoset.html.
+func (s *S3BackupStorage) SetStorageClass(ctx context.Context, fileName, storageClass string) error {
+	info, err := s.client.StatObject(ctx, s.bucket, s.objectName(fileName), minio.StatObjectOptions{
+		ServerSideEncryption: s.SSE,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting object info: %v", err)
+	}
+	userMetadata := make(map[string]string, len(info.UserMetadata)+2)
+	for k, v := range info.UserMetadata {
+		userMetadata[k] = v
+	}
+	if info.ContentType != "" {
+		userMetadata["Content-Type"] = info.ContentType
+	}
+	userMetadata["X-Amz-Storage-Class"] = storageClass
+
+	src := minio.CopySrcOptions{
+		Bucket: s.bucket,
+		Object: s.objectName(fileName),
+	}
+	if s.SSE != nil && s.SSE.Type() == encrypt.SSEC {
+		src.Encryption = s.SSE
+	}
+	dst := minio.CopyDestOptions{
+		Bucket:          s.bucket,
+		Object:          s.objectName(fileName),
+		Encryption:      s.SSE,
+		ReplaceMetadata: true,
+		UserMetadata:    userMetadata,
+	}
+	if _, err := s.client.ComposeObject(ctx, dst, src); err != nil {
+		return fmt.Errorf("error copying object: %v", err)
+	}
+	return nil
+}
+
+// normalizePrefix returns the prefix either empty or ending with a slash, so it can be prepended to file names.
+func normalizePrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// objectName returns the name of the object where a file is stored.
+func (s *S3BackupStorage) objectName(fileName string) string {
+	return prefixedFileName(s.Prefix, s.FileNamePrefix+fileName)
+}
+
+func prefixedFileName(prefix, fileName string) string {
+	return normalizePrefix(prefix) + fileName
+}
+
+func unprefixedFileName(prefix, key string) string {
+	return strings.TrimPrefix(key, normalizePrefix(prefix))
+}
+
+// downloadFile writes the contents of the reader into a temporary file that is renamed to the final path once
+// the download completes, so an interrupted download never leaves a partial file behind that looks complete.
+func downloadFile(filePath string, reader io.Reader, progress *Progress) error {
+	file, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %v", err)
+	}
+	tmpPath := file.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := io.Copy(progress.Writer(file), reader); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing file: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("error syncing file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing file: %v", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("error setting file permissions: %v", err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("error renaming file: %v", err)
+	}
+	return nil
 }
 
-func shouldProcessBackupFile(fileName string, logger logr.Logger) bool {
-	logger.V(1).Info("processing backup file", "file", fileName)
-	if IsValidBackupFile(fileName) {
+func shouldProcessFile(fileName string, isValid func(string) bool, logger logr.Logger) bool {
+	logger.V(1).Info("processing file", "file", fileName)
+	if isValid(fileName) {
 		return true
 	}
 	logger.V(1).Info("ignoring file", "file", fileName)
@@ -0,0 +1,93 @@
+package backup
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrefixedFileName(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		fileName     string
+		wantFileName string
+	}{
+		{
+			name:         "no prefix",
+			prefix:       "",
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name:         "slash prefix",
+			prefix:       "/",
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name:         "prefix",
+			prefix:       "mariadb",
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "mariadb/backup.2023-12-18T16:14:00Z.sql",
+		},
+		{
+			name:         "prefix with slashes",
+			prefix:       "/mariadb/production/",
+			fileName:     "backup.2023-12-18T16:14:00Z.sql",
+			wantFileName: "mariadb/production/backup.2023-12-18T16:14:00Z.sql",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := prefixedFileName(tt.prefix, tt.fileName)
+			if fileName != tt.wantFileName {
+				t.Fatalf("unexpected prefixed file name, expected: %s got: %s", tt.wantFileName, fileName)
+			}
+			if unprefixed := unprefixedFileName(tt.prefix, fileName); unprefixed != tt.fileName {
+				t.Fatalf("unexpected unprefixed file name, expected: %s got: %s", tt.fileName, unprefixed)
+			}
+		})
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "backup.2023-12-18T16:14:00Z.sql")
+
+	if err := downloadFile(filePath, strings.NewReader("CREATE DATABASE foo;"), nil); err != nil {
+		t.Fatalf("unexpected error downloading file: %v", err)
+	}
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+	if string(bytes) != "CREATE DATABASE foo;" {
+		t.Fatalf("unexpected file contents: %s", string(bytes))
+	}
+
+	failingReader := io.MultiReader(strings.NewReader("partial"), &errReader{err: errors.New("connection reset")})
+	failingPath := filepath.Join(dir, "backup.2023-12-19T16:14:00Z.sql")
+	if err := downloadFile(failingPath, failingReader, nil); err == nil {
+		t.Fatal("expected error downloading file")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the completed file to be present, got %d files", len(entries))
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(b []byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,69 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamPartSize is the size of the parts uploaded to S3 when streaming a backup of unknown size.
+// It bounds the memory used by the upload, as well as the maximum size of the backup to 10000 parts.
+const streamPartSize = 64 * 1024 * 1024
+
+// StreamStatusFileName returns the name of the file where the process writing a streamed backup records its exit code.
+func StreamStatusFileName(backupFileName string) string {
+	return backupFileName + ".status"
+}
+
+// StreamAbortFileName returns the name of the file that the uploader creates to stop the process writing a streamed
+// backup when it is unable to upload it.
+func StreamAbortFileName(backupFileName string) string {
+	return backupFileName + ".abort"
+}
+
+// AbortStream signals the process writing a streamed backup to stop, as nobody is going to read the named pipe.
+func AbortStream(abortFilePath string) error {
+	if err := os.WriteFile(abortFilePath, nil, 0644); err != nil {
+		return fmt.Errorf("error writing stream abort file: %v", err)
+	}
+	return nil
+}
+
+// isNamedPipe determines whether a file is a named pipe, where a backup is streamed into while it is being taken.
+func isNamedPipe(info os.FileInfo) bool {
+	return info.Mode()&os.ModeNamedPipe != 0
+}
+
+// WaitForStreamStatus waits until the process writing a streamed backup records its exit code in the status file,
+// returning an error if the process failed, as the backup would be incomplete.
+func WaitForStreamStatus(ctx context.Context, statusFilePath string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		bytes, err := os.ReadFile(statusFilePath)
+		if err == nil && len(strings.TrimSpace(string(bytes))) > 0 {
+			exitCode, err := strconv.Atoi(strings.TrimSpace(string(bytes)))
+			if err != nil {
+				return fmt.Errorf("error parsing stream exit code: %v", err)
+			}
+			if exitCode != 0 {
+				return fmt.Errorf("stream finished with exit code %d", exitCode)
+			}
+			return nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error reading stream status: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for stream status: %v", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
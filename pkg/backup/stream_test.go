@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestWaitForStreamStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  *string
+		delay   time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			status:  ptr.To("0"),
+			wantErr: false,
+		},
+		{
+			name:    "success after delay",
+			status:  ptr.To("0"),
+			delay:   50 * time.Millisecond,
+			wantErr: false,
+		},
+		{
+			name:    "failure",
+			status:  ptr.To("2"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid status",
+			status:  ptr.To("foo"),
+			wantErr: true,
+		},
+		{
+			name:    "missing status",
+			status:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusFile := filepath.Join(t.TempDir(), StreamStatusFileName("backup.2023-12-22T13:00:00Z.sql"))
+			if tt.status != nil {
+				go func() {
+					time.Sleep(tt.delay)
+					if err := os.WriteFile(statusFile, []byte(*tt.status), 0644); err != nil {
+						t.Errorf("unexpected error writing stream status: %v", err)
+					}
+				}()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			err := WaitForStreamStatus(ctx, statusFile, 10*time.Millisecond)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error waiting for stream status, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error waiting for stream status: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// s3StandardStorageClass is the default storage class of S3.
+const s3StandardStorageClass = "STANDARD"
+
+// archivedStorageClasses are the storage classes whose files cannot be read without restoring them first:
+// the S3 Glacier Flexible Retrieval and Deep Archive classes, and the Azure Blob archive tier.
+var archivedStorageClasses = []string{"GLACIER", "DEEP_ARCHIVE", "Archive"}
+
+func isArchivedStorageClass(storageClass string) bool {
+	for _, archived := range archivedStorageClasses {
+		if strings.EqualFold(storageClass, archived) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveArchivedBackupFiles removes the backup files that cannot be read without restoring them first, as they have been
+// moved to an archive storage class. Files that cannot be checked are kept, so the error is surfaced when pulling them.
+func RemoveArchivedBackupFiles(ctx context.Context, storage BackupStorage, backupFileNames []string,
+	logger logr.Logger) []string {
+	if _, ok := storage.(TieredBackupStorage); !ok {
+		return backupFileNames
+	}
+	var files []string
+	for _, file := range backupFileNames {
+		info, err := storage.Stat(ctx, file)
+		if err != nil {
+			logger.Error(err, "error getting backup info", "backup", file)
+			files = append(files, file)
+			continue
+		}
+		if info.Archived {
+			logger.Info("skipping archived backup", "backup", file, "storage-class", info.StorageClass)
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// TieringRule moves the backups older than a given age to a colder storage class.
+type TieringRule struct {
+	// MinAge is the minimum age of the backups to be moved.
+	MinAge time.Duration
+	// StorageClass is the storage class where the backups are moved to.
+	StorageClass string
+}
+
+// ParseTieringRule parses a tiering rule with the '<min-age>=<storage-class>' format, for example '720h=GLACIER'.
+func ParseTieringRule(rule string) (*TieringRule, error) {
+	minAgeRaw, storageClass, ok := strings.Cut(rule, "=")
+	if !ok || storageClass == "" {
+		return nil, fmt.Errorf("invalid tiering rule '%s', expected format '<min-age>=<storage-class>'", rule)
+	}
+	minAge, err := time.ParseDuration(minAgeRaw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tiering rule '%s' min age: %v", rule, err)
+	}
+	return &TieringRule{
+		MinAge:       minAge,
+		StorageClass: storageClass,
+	}, nil
+}
+
+func (r TieringRule) String() string {
+	return fmt.Sprintf("%s=%s", r.MinAge, r.StorageClass)
+}
+
+// GetTierableBackupFiles determines the target storage class of each backup file according with the tiering rules.
+// When multiple rules apply, the one with the greatest min age takes precedence.
+func GetTierableBackupFiles(backupFileNames []string, rules []TieringRule, logger logr.Logger) map[string]string {
+	sortedRules := make([]TieringRule, len(rules))
+	copy(sortedRules, rules)
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return sortedRules[i].MinAge > sortedRules[j].MinAge
+	})
+
+	tierable := make(map[string]string)
+	now := now()
+	for _, file := range backupFileNames {
+		backupDate, err := parseDateInBackupFile(file)
+		if err != nil {
+			logger.Error(err, "error parsing backup date. Skipping", "file", file)
+			continue
+		}
+		for _, rule := range sortedRules {
+			if now.Sub(backupDate) > rule.MinAge {
+				tierable[file] = rule.StorageClass
+				break
+			}
+		}
+	}
+	return tierable
+}
+
+// TierResult summarizes the backups moved to a different storage class.
+type TierResult struct {
+	// Tiered is the number of backups that have been moved to a different storage class.
+	Tiered int
+	// Failed is the number of backups that could not be moved to a different storage class.
+	Failed int
+}
+
+// Tier moves the backups to the storage class determined by the tiering rules. Backups already in the target storage class
+// are skipped. The manifests are kept in the original storage class, so they can be read without restoring the backups.
+func Tier(ctx context.Context, storage BackupStorage, backupFileNames []string, rules []TieringRule,
+	logger logr.Logger) (*TierResult, error) {
+	tieredStorage, ok := storage.(TieredBackupStorage)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support storage classes")
+	}
+	result := &TierResult{}
+
+	tierable := GetTierableBackupFiles(backupFileNames, rules, logger)
+	files := make([]string, 0, len(tierable))
+	for file := range tierable {
+		files = append(files, file)
+	}
+	sortBackupFiles(files)
+
+	for _, file := range files {
+		storageClass := tierable[file]
+		info, err := tieredStorage.Stat(ctx, file)
+		if err != nil {
+			logger.Error(err, "error getting backup info", "backup", file)
+			result.Failed++
+			continue
+		}
+		if strings.EqualFold(info.StorageClass, storageClass) {
+			continue
+		}
+
+		logger.V(1).Info("moving backup to storage class", "backup", file, "storage-class", storageClass)
+		if err := tieredStorage.SetStorageClass(ctx, file, storageClass); err != nil {
+			logger.Error(err, "error moving backup to storage class", "backup", file, "storage-class", storageClass)
+			result.Failed++
+			continue
+		}
+		result.Tiered++
+	}
+	return result, nil
+}
@@ -0,0 +1,232 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTieringRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		wantRule *TieringRule
+		wantErr  bool
+	}{
+		{
+			name: "valid",
+			rule: "720h0m0s=GLACIER",
+			wantRule: &TieringRule{
+				MinAge:       720 * time.Hour,
+				StorageClass: "GLACIER",
+			},
+		},
+		{
+			name:    "missing storage class",
+			rule:    "720h=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid min age",
+			rule:    "30d=GLACIER",
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			rule:    "GLACIER",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseTieringRule(tt.rule)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.wantRule, rule) {
+				t.Fatalf("unexpected rule, expected: %v got: %v", tt.wantRule, rule)
+			}
+		})
+	}
+}
+
+func TestGetTierableBackupFiles(t *testing.T) {
+	previousNowFunc := now
+	now = timeFn(mustParseDate(t, "2023-12-31T00:00:00Z"))
+	t.Cleanup(func() {
+		now = previousNowFunc
+	})
+
+	backupFiles := []string{
+		"backup.2023-12-30T00:00:00Z.sql",
+		"backup.2023-12-20T00:00:00Z.sql",
+		"backup.2023-11-20T00:00:00Z.sql",
+		"backup.foo.sql",
+	}
+	rules := []TieringRule{
+		{
+			MinAge:       7 * 24 * time.Hour,
+			StorageClass: "STANDARD_IA",
+		},
+		{
+			MinAge:       30 * 24 * time.Hour,
+			StorageClass: "GLACIER",
+		},
+	}
+	wantTierable := map[string]string{
+		"backup.2023-12-20T00:00:00Z.sql": "STANDARD_IA",
+		"backup.2023-11-20T00:00:00Z.sql": "GLACIER",
+	}
+
+	tierable := GetTierableBackupFiles(backupFiles, rules, logger)
+	if !reflect.DeepEqual(wantTierable, tierable) {
+		t.Fatalf("unexpected tierable backups, expected: %v got: %v", wantTierable, tierable)
+	}
+}
+
+func TestTier(t *testing.T) {
+	previousNowFunc := now
+	now = timeFn(mustParseDate(t, "2023-12-31T00:00:00Z"))
+	t.Cleanup(func() {
+		now = previousNowFunc
+	})
+
+	storage := &fakeTieredStorage{
+		storageClasses: map[string]string{
+			"backup.2023-12-30T00:00:00Z.sql": "STANDARD",
+			"backup.2023-11-25T00:00:00Z.sql": "STANDARD",
+			"backup.2023-11-20T00:00:00Z.sql": "GLACIER",
+			"backup.2023-11-15T00:00:00Z.sql": "STANDARD",
+		},
+		failing: "backup.2023-11-15T00:00:00Z.sql",
+	}
+	var backupFiles []string
+	for file := range storage.storageClasses {
+		backupFiles = append(backupFiles, file)
+	}
+	rules := []TieringRule{
+		{
+			MinAge:       30 * 24 * time.Hour,
+			StorageClass: "GLACIER",
+		},
+	}
+
+	result, err := Tier(context.Background(), storage, backupFiles, rules, logger)
+	if err != nil {
+		t.Fatalf("unexpected error tiering backups: %v", err)
+	}
+	wantResult := &TierResult{
+		Tiered: 1,
+		Failed: 1,
+	}
+	if !reflect.DeepEqual(wantResult, result) {
+		t.Fatalf("unexpected tier result, expected: %v got: %v", wantResult, result)
+	}
+	wantStorageClasses := map[string]string{
+		"backup.2023-12-30T00:00:00Z.sql": "STANDARD",
+		"backup.2023-11-25T00:00:00Z.sql": "GLACIER",
+		"backup.2023-11-20T00:00:00Z.sql": "GLACIER",
+		"backup.2023-11-15T00:00:00Z.sql": "STANDARD",
+	}
+	if !reflect.DeepEqual(wantStorageClasses, storage.storageClasses) {
+		t.Fatalf("unexpected storage classes, expected: %v got: %v", wantStorageClasses, storage.storageClasses)
+	}
+	if storage.changes != 1 {
+		t.Fatalf("expected backups already in the storage class to be skipped, got %d changes", storage.changes)
+	}
+}
+
+func TestTierUnsupportedStorage(t *testing.T) {
+	storage := NewFileSystemBackupStorage(t.TempDir(), logger)
+	if _, err := Tier(context.Background(), storage, nil, nil, logger); err == nil {
+		t.Fatal("expected error tiering backups in a storage without storage classes")
+	}
+}
+
+func TestRemoveArchivedBackupFiles(t *testing.T) {
+	storage := &fakeTieredStorage{
+		storageClasses: map[string]string{
+			"backup.2023-12-30T00:00:00Z.sql": "STANDARD",
+			"backup.2023-12-20T00:00:00Z.sql": "STANDARD_IA",
+			"backup.2023-11-25T00:00:00Z.sql": "GLACIER",
+			"backup.2023-11-20T00:00:00Z.sql": "DEEP_ARCHIVE",
+			"backup.2023-11-15T00:00:00Z.sql": "Archive",
+		},
+	}
+	backupFiles := []string{
+		"backup.2023-12-30T00:00:00Z.sql",
+		"backup.2023-12-20T00:00:00Z.sql",
+		"backup.2023-11-25T00:00:00Z.sql",
+		"backup.2023-11-20T00:00:00Z.sql",
+		"backup.2023-11-15T00:00:00Z.sql",
+		"backup.2023-11-10T00:00:00Z.sql",
+	}
+	wantFiles := []string{
+		"backup.2023-12-30T00:00:00Z.sql",
+		"backup.2023-12-20T00:00:00Z.sql",
+		"backup.2023-11-10T00:00:00Z.sql",
+	}
+
+	files := RemoveArchivedBackupFiles(context.Background(), storage, backupFiles, logger)
+	if !reflect.DeepEqual(wantFiles, files) {
+		t.Fatalf("unexpected backup files, expected: %v got: %v", wantFiles, files)
+	}
+
+	fsStorage := NewFileSystemBackupStorage(t.TempDir(), logger)
+	if files := RemoveArchivedBackupFiles(context.Background(), fsStorage, backupFiles, logger); !reflect.DeepEqual(backupFiles, files) {
+		t.Fatalf("expected backup files to be kept in a storage without storage classes, got: %v", files)
+	}
+}
+
+type fakeTieredStorage struct {
+	storageClasses map[string]string
+	failing        string
+	changes        int
+}
+
+func (f *fakeTieredStorage) List(ctx context.Context) ([]string, error) {
+	var fileNames []string
+	for fileName := range f.storageClasses {
+		fileNames = append(fileNames, fileName)
+	}
+	return fileNames, nil
+}
+
+func (f *fakeTieredStorage) Push(ctx context.Context, fileName string) error {
+	return nil
+}
+
+func (f *fakeTieredStorage) Pull(ctx context.Context, fileName string) error {
+	return nil
+}
+
+func (f *fakeTieredStorage) Delete(ctx context.Context, fileName string) error {
+	delete(f.storageClasses, fileName)
+	return nil
+}
+
+func (f *fakeTieredStorage) Stat(ctx context.Context, fileName string) (*FileInfo, error) {
+	storageClass, ok := f.storageClasses[fileName]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return &FileInfo{
+		StorageClass: storageClass,
+		Archived:     isArchivedStorageClass(storageClass),
+	}, nil
+}
+
+func (f *fakeTieredStorage) SetStorageClass(ctx context.Context, fileName, storageClass string) error {
+	if fileName == f.failing {
+		return errors.New("access denied")
+	}
+	f.storageClasses[fileName] = storageClass
+	f.changes++
+	return nil
+}
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
@@ -35,3 +36,34 @@ func (b *Builder) BuildRestore(mariadb *mariadbv1alpha1.MariaDB, key types.Names
 	}
 	return restore, nil
 }
+
+func (b *Builder) BuildHibernationBackup(mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) (*mariadbv1alpha1.Backup, error) {
+	if mariadb.Spec.Hibernate == nil || mariadb.Spec.Hibernate.FinalBackup == nil {
+		return nil, errors.New("'spec.hibernate.finalBackup' must be set")
+	}
+	finalBackup := mariadb.Spec.Hibernate.FinalBackup
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+	backup := &mariadbv1alpha1.Backup{
+		ObjectMeta: objMeta,
+		Spec: mariadbv1alpha1.BackupSpec{
+			MariaDBRef: mariadbv1alpha1.MariaDBRef{
+				ObjectReference: corev1.ObjectReference{
+					Name: mariadb.Name,
+				},
+				WaitForIt: true,
+			},
+			Storage:      finalBackup.Storage,
+			Compression:  finalBackup.Compression,
+			Affinity:     mariadb.Spec.Affinity,
+			NodeSelector: mariadb.Spec.NodeSelector,
+			Tolerations:  mariadb.Spec.Tolerations,
+		},
+	}
+	if err := controllerutil.SetControllerReference(mariadb, backup, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to hibernation Backup: %v", err)
+	}
+	return backup, nil
+}
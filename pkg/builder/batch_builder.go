@@ -6,35 +6,56 @@ import (
 	"path/filepath"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
 	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
 	"github.com/mariadb-operator/mariadb-operator/pkg/command"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
-	batchStorageVolume     = "backup"
-	batchStorageMountPath  = "/backup"
-	batchScriptsVolume     = "scripts"
-	batchS3PKI             = "s3-pki"
-	batchS3PKIMountPath    = "/s3/pki"
-	batchScriptsMountPath  = "/opt"
-	batchScriptsSqlFile    = "job.sql"
-	batchUserEnv           = "MARIADB_OPERATOR_USER"
-	batchPasswordEnv       = "MARIADB_OPERATOR_PASSWORD"
-	batchS3AccessKeyId     = "AWS_ACCESS_KEY_ID"
-	batchS3SecretAccessKey = "AWS_SECRET_ACCESS_KEY"
-	batchS3SessionTokenKey = "AWS_SESSION_TOKEN"
+	batchStorageVolume              = "backup"
+	batchStorageMountPath           = "/backup"
+	batchScriptsVolume              = "scripts"
+	batchS3PKI                      = "s3-pki"
+	batchS3BinlogPKI                = "s3-binlog-pki"
+	batchS3PKIMountPath             = "/s3/pki"
+	batchGCSCredentials             = "gcs-credentials"
+	batchGCSCredentialsMountPath    = "/gcs"
+	batchAzureAccountKey            = "MARIADB_OPERATOR_AZURE_STORAGE_ACCOUNT_KEY"
+	batchAzureWorkloadIdentityLabel = "azure.workload.identity/use"
+	batchScriptsMountPath           = "/opt"
+	batchScriptsSqlFile             = "job.sql"
+	batchUserEnv                    = "MARIADB_OPERATOR_USER"
+	batchPasswordEnv                = "MARIADB_OPERATOR_PASSWORD"
+	batchS3AccessKeyId              = "AWS_ACCESS_KEY_ID"
+	batchS3SecretAccessKey          = "AWS_SECRET_ACCESS_KEY"
+	batchS3SessionTokenKey          = "AWS_SESSION_TOKEN"
+	batchS3SSECustomerKey           = "MARIADB_OPERATOR_S3_SSE_CUSTOMER_KEY"
+	batchMetricsPort                = 9090
 )
 
-var batchBackupTargetFilePath = fmt.Sprintf("%s/0-backup-target.txt", batchStorageMountPath)
+var batchMetricsAddr = fmt.Sprintf(":%d", batchMetricsPort)
+
+var (
+	batchBackupTargetFilePath        = fmt.Sprintf("%s/0-backup-target.txt", batchStorageMountPath)
+	batchBinlogStartPositionFilePath = fmt.Sprintf("%s/0-binlog-start-position.txt", batchStorageMountPath)
+)
 
 func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alpha1.Backup,
 	mariadb *mariadbv1alpha1.MariaDB) (*batchv1.Job, error) {
 	objMeta :=
 		metadata.NewMetadataBuilder(key).
 			WithMariaDB(mariadb).
+			WithLabels(azureBlobLabels(backup.Spec.Storage.AzureBlob)).
+			WithLabels(
+				labels.NewLabelsBuilder().
+					WithBackup(backup.Name).
+					Build(),
+			).
 			Build()
 
 	cmdOpts := []command.BackupOpt{
@@ -43,12 +64,34 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 			batchBackupTargetFilePath,
 		),
 		command.WithBackupMaxRetention(backup.Spec.MaxRetention.Duration),
+		command.WithBackupPruneResultPath(corev1.TerminationMessagePathDefault),
 		command.WithBackupUserEnv(batchUserEnv),
 		command.WithBackupPasswordEnv(batchPasswordEnv),
 		command.WithBackupLogLevel(backup.Spec.LogLevel),
 		command.WithBackupDumpOpts(backup.Spec.Args),
+		command.WithBackupDatabases(backup.Spec.Databases),
+		command.WithBackupHooks(backup.Spec.Hooks),
+		command.WithBackupMetricsAddr(batchMetricsAddr),
 	}
 	cmdOpts = append(cmdOpts, s3Opts(backup.Spec.Storage.S3)...)
+	cmdOpts = append(cmdOpts, gcsOpts(backup.Spec.Storage.GCS)...)
+	cmdOpts = append(cmdOpts, azureBlobOpts(backup.Spec.Storage.AzureBlob)...)
+	if backup.Spec.MaxBackups != nil {
+		cmdOpts = append(cmdOpts, command.WithBackupMaxBackups(*backup.Spec.MaxBackups))
+	}
+	if len(backup.Spec.Tiering) > 0 {
+		cmdOpts = append(cmdOpts, command.WithBackupTieringRules(backup.Spec.Tiering))
+	}
+	if backup.Spec.Streaming {
+		cmdOpts = append(cmdOpts, command.WithBackupStreaming())
+	}
+	if backup.Spec.Compression != nil {
+		cmdOpts = append(cmdOpts, command.WithBackupCompression(
+			backup.Spec.Compression.Algorithm,
+			backup.Spec.Compression.Level,
+			backup.Spec.Compression.Threads,
+		))
+	}
 
 	cmd, err := command.NewBackupCommand(cmdOpts...)
 	if err != nil {
@@ -60,30 +103,70 @@ func (b *Builder) BuildBackupJob(key types.NamespacedName, backup *mariadbv1alph
 		return nil, fmt.Errorf("error getting volume from Backup: %v", err)
 	}
 	volumes, volumeSources := jobBatchStorageVolume(volume, backup.Spec.Storage.S3)
+	gcsVolumes, gcsVolumeMounts := jobGCSCredentialsVolume(backup.Spec.Storage.GCS)
+	volumes = append(volumes, gcsVolumes...)
+	volumeSources = append(volumeSources, gcsVolumeMounts...)
 
-	opts := []jobOption{
-		withJobMeta(objMeta),
-		withJobVolumes(volumes...),
-		withJobInitContainers(
+	operatorContainer := withProgressMetricsPort(
+		jobMariadbOperatorContainer(
+			cmd.MariadbOperatorBackup(mariadb),
+			volumeSources,
+			append(jobS3Env(backup.Spec.Storage.S3), jobAzureBlobEnv(backup.Spec.Storage.AzureBlob)...),
+			backup.Spec.Resources,
+			mariadb,
+			b.env,
+		),
+	)
+	var initContainers, containers []corev1.Container
+	if backup.Spec.Streaming {
+		// The dump is streamed into a named pipe read by the operator container, which uploads it while it is being taken.
+		initContainers = []corev1.Container{
+			jobContainer("prepare-stream", cmd.MariadbPrepareStream(), mariadb.Spec.Image, volumeSources, nil, nil, mariadb),
+		}
+		containers = []corev1.Container{
 			jobMariadbContainer(
-				cmd.MariadbDump(backup, mariadb),
+				cmd.MariadbDumpStream(mariadb),
 				volumeSources,
 				jobEnv(mariadb),
 				backup.Spec.Resources,
 				mariadb,
 			),
-		),
-		withJobContainers(
-			jobMariadbOperatorContainer(
-				cmd.MariadbOperatorBackup(),
+			operatorContainer,
+		}
+	} else {
+		initContainers = []corev1.Container{
+			jobMariadbContainer(
+				cmd.MariadbDump(backup, mariadb),
 				volumeSources,
-				jobS3Env(backup.Spec.Storage.S3),
+				jobEnv(mariadb),
 				backup.Spec.Resources,
 				mariadb,
-				b.env,
 			),
-		),
+		}
+		if backup.Spec.Compression != nil {
+			initContainers = append(initContainers,
+				jobCompressionContainer(
+					cmd.MariadbCompress(),
+					volumeSources,
+					backup.Spec.Compression.Resources,
+					mariadb,
+				),
+			)
+		}
+		containers = []corev1.Container{operatorContainer}
+	}
+
+	opts := []jobOption{
+		withJobMeta(objMeta),
+		withJobVolumes(volumes...),
+		withJobInitContainers(initContainers...),
+		withJobContainers(containers...),
 		withJobBackoffLimit(backup.Spec.BackoffLimit),
+		withJobServiceAccountName(jobGCSServiceAccountName(backup.Spec.Storage.GCS)),
+		withJobServiceAccountName(jobAzureBlobServiceAccountName(backup.Spec.Storage.AzureBlob)),
+		withJobActiveDeadlineSeconds(backup.Spec.ActiveDeadlineSeconds),
+		withJobPodFailurePolicy(backup.Spec.PodFailurePolicy),
+		// TTLSecondsAfterFinished is set by the operator once the result of the Job has been recorded in the Backup status.
 		withJobRestartPolicy(backup.Spec.RestartPolicy),
 		withAffinity(backup.Spec.Affinity),
 		withNodeSelector(backup.Spec.NodeSelector),
@@ -120,9 +203,11 @@ func (b *Builder) BuildBackupCronJob(key types.NamespacedName, backup *mariadbv1
 	cronJob := &batchv1.CronJob{
 		ObjectMeta: objMeta,
 		Spec: batchv1.CronJobSpec{
-			Schedule:          backup.Spec.Schedule.Cron,
-			ConcurrencyPolicy: batchv1.ForbidConcurrent,
-			Suspend:           &backup.Spec.Schedule.Suspend,
+			Schedule:                   backup.Spec.Schedule.Cron,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			Suspend:                    &backup.Spec.Schedule.Suspend,
+			SuccessfulJobsHistoryLimit: backup.Spec.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     backup.Spec.FailedJobsHistoryLimit,
 			JobTemplate: batchv1.JobTemplateSpec{
 				ObjectMeta: job.ObjectMeta,
 				Spec:       job.Spec,
@@ -140,6 +225,7 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 	objMeta :=
 		metadata.NewMetadataBuilder(key).
 			WithMariaDB(mariadb).
+			WithLabels(azureBlobLabels(restore.Spec.AzureBlob)).
 			Build()
 	cmdOpts := []command.BackupOpt{
 		command.WithBackup(
@@ -150,28 +236,88 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 		command.WithBackupUserEnv(batchUserEnv),
 		command.WithBackupPasswordEnv(batchPasswordEnv),
 		command.WithBackupLogLevel(restore.Spec.LogLevel),
+		command.WithBackupRestoreMode(restore.Spec.Mode),
+		command.WithBackupRestoreSelection(restore.Spec.Databases, restore.Spec.Tables),
+		command.WithBackupMetricsAddr(batchMetricsAddr),
 	}
 	cmdOpts = append(cmdOpts, s3Opts(restore.Spec.S3)...)
+	cmdOpts = append(cmdOpts, gcsOpts(restore.Spec.GCS)...)
+	cmdOpts = append(cmdOpts, azureBlobOpts(restore.Spec.AzureBlob)...)
+	if restore.Spec.SkipCompatibilityCheck {
+		cmdOpts = append(cmdOpts, command.WithBackupSkipCompatibilityCheck())
+	}
+	if restore.Spec.TargetRecoveryTime != nil {
+		cmdOpts = append(cmdOpts, command.WithBackupBeforeTargetTime())
+	}
+	if restore.Spec.Binlogs != nil {
+		cmdOpts = append(cmdOpts, command.WithBinlogReplay(batchBinlogStartPositionFilePath))
+	}
 
 	cmd, err := command.NewBackupCommand(cmdOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("error building restore command: %v", err)
 	}
 	volumes, volumeSources := jobBatchStorageVolume(restore.Spec.RestoreSource.Volume, restore.Spec.S3)
+	gcsVolumes, gcsVolumeMounts := jobGCSCredentialsVolume(restore.Spec.GCS)
+	volumes = append(volumes, gcsVolumes...)
+	volumeSources = append(volumeSources, gcsVolumeMounts...)
 
-	jobOpts := []jobOption{
-		withJobMeta(objMeta),
-		withJobVolumes(volumes...),
-		withJobInitContainers(
+	initContainers := []corev1.Container{
+		withProgressMetricsPort(
 			jobMariadbOperatorContainer(
-				cmd.MariadbOperatorRestore(),
+				cmd.MariadbOperatorRestore(mariadb),
 				volumeSources,
-				jobS3Env(restore.Spec.S3),
+				append(append(jobEnv(mariadb), jobS3Env(restore.Spec.S3)...), jobAzureBlobEnv(restore.Spec.AzureBlob)...),
 				restore.Spec.Resources,
 				mariadb,
 				b.env,
 			),
 		),
+	}
+	if binlogs := restore.Spec.Binlogs; binlogs != nil {
+		binlogCmdOpts := []command.BackupOpt{
+			command.WithBackup(
+				batchStorageMountPath,
+				batchBackupTargetFilePath,
+			),
+			command.WithBackupUserEnv(batchUserEnv),
+			command.WithBackupPasswordEnv(batchPasswordEnv),
+			command.WithBackupLogLevel(restore.Spec.LogLevel),
+			command.WithBinlogReplay(batchBinlogStartPositionFilePath),
+		}
+		binlogCmdOpts = append(binlogCmdOpts, s3Opts(&binlogs.S3)...)
+
+		binlogCmd, err := command.NewBackupCommand(binlogCmdOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error building binlog command: %v", err)
+		}
+		// The binlog storage PKI replaces the one of the restore source, as both are expected in the same path.
+		pkiVolumes, pkiVolumeMounts := jobS3PKIVolume(batchS3BinlogPKI, &binlogs.S3)
+		volumes = append(volumes, pkiVolumes...)
+		binlogVolumeMounts := append([]corev1.VolumeMount{
+			{
+				Name:      batchStorageVolume,
+				MountPath: batchStorageMountPath,
+			},
+		}, pkiVolumeMounts...)
+
+		initContainers = append(initContainers,
+			jobContainer(
+				"binlogs",
+				binlogCmd.MariadbOperatorBinlogPull(),
+				b.env.MariadbOperatorImage,
+				binlogVolumeMounts,
+				jobS3Env(&binlogs.S3),
+				restore.Spec.Resources,
+				mariadb,
+			),
+		)
+	}
+
+	jobOpts := []jobOption{
+		withJobMeta(objMeta),
+		withJobVolumes(volumes...),
+		withJobInitContainers(initContainers...),
 		withJobContainers(
 			jobMariadbContainer(
 				cmd.MariadbRestore(mariadb),
@@ -182,6 +328,11 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 			),
 		),
 		withJobBackoffLimit(restore.Spec.BackoffLimit),
+		withJobServiceAccountName(jobGCSServiceAccountName(restore.Spec.GCS)),
+		withJobServiceAccountName(jobAzureBlobServiceAccountName(restore.Spec.AzureBlob)),
+		withJobActiveDeadlineSeconds(restore.Spec.ActiveDeadlineSeconds),
+		withJobPodFailurePolicy(restore.Spec.PodFailurePolicy),
+		withJobTTLSecondsAfterFinished(restore.Spec.TTLSecondsAfterFinished),
 		withJobRestartPolicy(restore.Spec.RestartPolicy),
 		withAffinity(restore.Spec.Affinity),
 		withNodeSelector(restore.Spec.NodeSelector),
@@ -200,6 +351,172 @@ func (b *Builder) BuildRestoreJob(key types.NamespacedName, restore *mariadbv1al
 	return job, nil
 }
 
+func (b *Builder) BuildSeedDataJob(key types.NamespacedName, mariadb *mariadbv1alpha1.MariaDB) (*batchv1.Job, error) {
+	seedData := mariadb.Spec.SeedData
+	if seedData == nil {
+		return nil, errors.New("seedData field is mandatory when building a seed data Job")
+	}
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+	cmdOpts := []command.BackupOpt{
+		command.WithBackup(
+			batchStorageMountPath,
+			batchBackupTargetFilePath,
+		),
+		command.WithBackupUserEnv(batchUserEnv),
+		command.WithBackupPasswordEnv(batchPasswordEnv),
+		command.WithBackupLogLevel("info"),
+	}
+	cmdOpts = append(cmdOpts, s3Opts(&seedData.S3)...)
+
+	cmd, err := command.NewBackupCommand(cmdOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building seed data command: %v", err)
+	}
+	volumes, volumeSources := jobBatchStorageVolume(
+		&corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+		&seedData.S3,
+	)
+
+	jobOpts := []jobOption{
+		withJobMeta(objMeta),
+		withJobVolumes(volumes...),
+		withJobInitContainers(
+			jobMariadbOperatorContainer(
+				cmd.MariadbOperatorSeedPull(seedData),
+				volumeSources,
+				append(jobEnv(mariadb), jobS3Env(&seedData.S3)...),
+				seedData.Resources,
+				mariadb,
+				b.env,
+			),
+		),
+		withJobContainers(
+			jobMariadbContainer(
+				cmd.MariadbSeed(seedData, mariadb),
+				volumeSources,
+				jobEnv(mariadb),
+				seedData.Resources,
+				mariadb,
+			),
+		),
+		withJobRestartPolicy(corev1.RestartPolicyOnFailure),
+		withAffinity(mariadb.Spec.Affinity),
+		withNodeSelector(mariadb.Spec.NodeSelector),
+		withTolerations(mariadb.Spec.Tolerations...),
+	}
+
+	builder, err := newJobBuilder(jobOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building seed data Job: %v", err)
+	}
+
+	job := builder.build()
+	if err := controllerutil.SetControllerReference(mariadb, job, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Job: %v", err)
+	}
+	return job, nil
+}
+
+// BuildReplicaProvisioningJob builds a Job that loads the data of a new replica before it starts replicating.
+// The restore source is mandatory when provisioning from a Backup.
+func (b *Builder) BuildReplicaProvisioningJob(key types.NamespacedName, mariadb *mariadbv1alpha1.MariaDB, podIndex int,
+	restoreSource *mariadbv1alpha1.RestoreSource) (*batchv1.Job, error) {
+	provisioning := mariadb.Replication().Replica.Provisioning
+	if provisioning == nil {
+		return nil, errors.New("replica provisioning field is mandatory when building a replica provisioning Job")
+	}
+	source := provisioning.SourceOrDefault()
+	if source == mariadbv1alpha1.ReplicaProvisioningSourceBackup && restoreSource == nil {
+		return nil, errors.New("restore source is mandatory when provisioning a replica from a Backup")
+	}
+	if restoreSource == nil {
+		restoreSource = &mariadbv1alpha1.RestoreSource{
+			Volume: &corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			WithLabels(azureBlobLabels(restoreSource.AzureBlob)).
+			Build()
+	cmdOpts := []command.BackupOpt{
+		command.WithBackup(
+			batchStorageMountPath,
+			batchBackupTargetFilePath,
+		),
+		command.WithBackupUserEnv(batchUserEnv),
+		command.WithBackupPasswordEnv(batchPasswordEnv),
+		command.WithBackupLogLevel("info"),
+		command.WithBackupReplicaHost(
+			statefulset.PodFQDNWithService(mariadb.ObjectMeta, podIndex, mariadb.InternalServiceKey().Name),
+		),
+	}
+	cmdOpts = append(cmdOpts, s3Opts(restoreSource.S3)...)
+	cmdOpts = append(cmdOpts, gcsOpts(restoreSource.GCS)...)
+	cmdOpts = append(cmdOpts, azureBlobOpts(restoreSource.AzureBlob)...)
+
+	cmd, err := command.NewBackupCommand(cmdOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building replica provisioning command: %v", err)
+	}
+	volumes, volumeSources := jobBatchStorageVolume(restoreSource.Volume, restoreSource.S3)
+	gcsVolumes, gcsVolumeMounts := jobGCSCredentialsVolume(restoreSource.GCS)
+	volumes = append(volumes, gcsVolumes...)
+	volumeSources = append(volumeSources, gcsVolumeMounts...)
+
+	jobOpts := []jobOption{
+		withJobMeta(objMeta),
+		withJobVolumes(volumes...),
+		withJobContainers(
+			jobMariadbContainer(
+				cmd.MariadbProvisionReplica(mariadb, source),
+				volumeSources,
+				jobEnv(mariadb),
+				provisioning.Resources,
+				mariadb,
+			),
+		),
+		withJobServiceAccountName(jobGCSServiceAccountName(restoreSource.GCS)),
+		withJobServiceAccountName(jobAzureBlobServiceAccountName(restoreSource.AzureBlob)),
+		withJobRestartPolicy(corev1.RestartPolicyOnFailure),
+		withAffinity(mariadb.Spec.Affinity),
+		withNodeSelector(mariadb.Spec.NodeSelector),
+		withTolerations(mariadb.Spec.Tolerations...),
+	}
+	if source == mariadbv1alpha1.ReplicaProvisioningSourceBackup {
+		jobOpts = append(jobOpts,
+			withJobInitContainers(
+				jobMariadbOperatorContainer(
+					cmd.MariadbOperatorRestore(mariadb),
+					volumeSources,
+					append(append(jobEnv(mariadb), jobS3Env(restoreSource.S3)...), jobAzureBlobEnv(restoreSource.AzureBlob)...),
+					provisioning.Resources,
+					mariadb,
+					b.env,
+				),
+			),
+		)
+	}
+
+	builder, err := newJobBuilder(jobOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building replica provisioning Job: %v", err)
+	}
+
+	job := builder.build()
+	if err := controllerutil.SetControllerReference(mariadb, job, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Job: %v", err)
+	}
+	return job, nil
+}
+
 func (b *Builder) BuildSqlJob(key types.NamespacedName, sqlJob *mariadbv1alpha1.SqlJob,
 	mariadb *mariadbv1alpha1.MariaDB) (*batchv1.Job, error) {
 	objMeta :=
@@ -235,6 +552,9 @@ func (b *Builder) BuildSqlJob(key types.NamespacedName, sqlJob *mariadbv1alpha1.
 			),
 		),
 		withJobBackoffLimit(sqlJob.Spec.BackoffLimit),
+		withJobActiveDeadlineSeconds(sqlJob.Spec.ActiveDeadlineSeconds),
+		withJobPodFailurePolicy(sqlJob.Spec.PodFailurePolicy),
+		withJobTTLSecondsAfterFinished(sqlJob.Spec.TTLSecondsAfterFinished),
 		withJobRestartPolicy(sqlJob.Spec.RestartPolicy),
 		withAffinity(sqlJob.Spec.Affinity),
 		withNodeSelector(sqlJob.Spec.NodeSelector),
@@ -271,9 +591,11 @@ func (b *Builder) BuildSqlCronJob(key types.NamespacedName, sqlJob *mariadbv1alp
 	cronJob := &batchv1.CronJob{
 		ObjectMeta: objMeta,
 		Spec: batchv1.CronJobSpec{
-			Schedule:          sqlJob.Spec.Schedule.Cron,
-			ConcurrencyPolicy: batchv1.ForbidConcurrent,
-			Suspend:           &sqlJob.Spec.Schedule.Suspend,
+			Schedule:                   sqlJob.Spec.Schedule.Cron,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			Suspend:                    &sqlJob.Spec.Schedule.Suspend,
+			SuccessfulJobsHistoryLimit: sqlJob.Spec.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     sqlJob.Spec.FailedJobsHistoryLimit,
 			JobTemplate: batchv1.JobTemplateSpec{
 				ObjectMeta: job.ObjectMeta,
 				Spec:       job.Spec,
@@ -286,6 +608,45 @@ func (b *Builder) BuildSqlCronJob(key types.NamespacedName, sqlJob *mariadbv1alp
 	return cronJob, nil
 }
 
+func gcsOpts(gcs *mariadbv1alpha1.GCS) []command.BackupOpt {
+	if gcs == nil {
+		return nil
+	}
+	cmdOpts := []command.BackupOpt{
+		command.WithGCS(gcs.Bucket, gcs.Prefix),
+	}
+	if gcs.CredentialsSecretKeyRef != nil {
+		cmdOpts = append(cmdOpts, command.WithGCSCredentials(
+			filepath.Join(batchGCSCredentialsMountPath, gcs.CredentialsSecretKeyRef.Key),
+		))
+	}
+	return cmdOpts
+}
+
+func azureBlobOpts(azureBlob *mariadbv1alpha1.AzureBlob) []command.BackupOpt {
+	if azureBlob == nil {
+		return nil
+	}
+	return []command.BackupOpt{
+		command.WithAzureBlob(
+			azureBlob.Container,
+			azureBlob.Prefix,
+			azureBlob.StorageAccount,
+			azureBlob.Endpoint,
+		),
+	}
+}
+
+// azureBlobLabels returns the labels required by Azure Workload Identity to inject the credentials in the Pods.
+func azureBlobLabels(azureBlob *mariadbv1alpha1.AzureBlob) map[string]string {
+	if azureBlob == nil || !azureBlob.IsWorkloadIdentity() {
+		return nil
+	}
+	return map[string]string{
+		batchAzureWorkloadIdentityLabel: "true",
+	}
+}
+
 func s3Opts(s3 *mariadbv1alpha1.S3) []command.BackupOpt {
 	if s3 == nil {
 		return nil
@@ -303,6 +664,114 @@ func s3Opts(s3 *mariadbv1alpha1.S3) []command.BackupOpt {
 			caCertPath = filepath.Join(batchS3PKIMountPath, s3.TLS.CASecretKeyRef.Key)
 		}
 		cmdOpts = append(cmdOpts, command.WithS3TLS(caCertPath))
+		if s3.TLS.InsecureSkipVerify {
+			cmdOpts = append(cmdOpts, command.WithS3InsecureSkipVerify())
+		}
+	}
+	if s3.Prefix != "" {
+		cmdOpts = append(cmdOpts, command.WithS3Prefix(s3.Prefix))
+	}
+	if s3.PathStyle {
+		cmdOpts = append(cmdOpts, command.WithS3PathStyle())
+	}
+	if s3.SSE != nil {
+		kmsKeyID := ""
+		if s3.SSE.KMSKeyID != nil {
+			kmsKeyID = *s3.SSE.KMSKeyID
+		}
+		cmdOpts = append(cmdOpts, command.WithS3SSE(string(s3.SSE.Type), kmsKeyID))
 	}
 	return cmdOpts
 }
+
+func (b *Builder) BuildMaintenanceJob(key types.NamespacedName, maintenanceJob *mariadbv1alpha1.MaintenanceJob,
+	mariadb *mariadbv1alpha1.MariaDB) (*batchv1.Job, error) {
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+
+	cmd, err := command.NewMaintenanceCommand(
+		command.WithMaintenanceOperation(maintenanceJob.Spec.Operation),
+		command.WithMaintenanceDatabases(maintenanceJob.Spec.Databases),
+		command.WithMaintenanceConcurrency(maintenanceJob.Spec.Concurrency),
+		command.WithMaintenanceUserEnv(batchUserEnv),
+		command.WithMaintenancePasswordEnv(batchPasswordEnv),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building maintenance command: %v", err)
+	}
+
+	jobOpts := []jobOption{
+		withJobMeta(objMeta),
+		withJobVolumes([]corev1.Volume{}...),
+		withJobContainers(
+			jobMariadbContainer(
+				cmd.ExecCommand(mariadb),
+				nil,
+				jobEnv(mariadb),
+				maintenanceJob.Spec.Resources,
+				mariadb,
+			),
+		),
+		withJobBackoffLimit(maintenanceJob.Spec.BackoffLimit),
+		withJobActiveDeadline(maintenanceJob.Spec.MaxDuration),
+		withJobRestartPolicy(maintenanceJob.Spec.RestartPolicy),
+		withAffinity(maintenanceJob.Spec.Affinity),
+		withNodeSelector(maintenanceJob.Spec.NodeSelector),
+		withTolerations(maintenanceJob.Spec.Tolerations...),
+	}
+
+	builder, err := newJobBuilder(jobOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building maintenance Job: %v", err)
+	}
+
+	job := builder.build()
+	if err := controllerutil.SetControllerReference(maintenanceJob, job, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Job: %v", err)
+	}
+	return job, nil
+}
+
+func (b *Builder) BuildMaintenanceCronJob(key types.NamespacedName, maintenanceJob *mariadbv1alpha1.MaintenanceJob,
+	mariadb *mariadbv1alpha1.MariaDB) (*batchv1.CronJob, error) {
+	if maintenanceJob.Spec.Schedule == nil {
+		return nil, errors.New("schedule field is mandatory when building a CronJob")
+	}
+
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+	job, err := b.BuildMaintenanceJob(key, maintenanceJob, mariadb)
+	if err != nil {
+		return nil, fmt.Errorf("error building MaintenanceJob: %v", err)
+	}
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: objMeta,
+		Spec: batchv1.CronJobSpec{
+			Schedule:          maintenanceJob.Spec.Schedule.Cron,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			Suspend:           &maintenanceJob.Spec.Schedule.Suspend,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: job.ObjectMeta,
+				Spec:       job.Spec,
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(maintenanceJob, cronJob, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to CronJob: %v", err)
+	}
+	return cronJob, nil
+}
+
+// withProgressMetricsPort exposes the endpoint that serves the progress metrics of the backup and restore operations.
+func withProgressMetricsPort(container corev1.Container) corev1.Container {
+	container.Ports = append(container.Ports, corev1.ContainerPort{
+		Name:          MetricsPortName,
+		ContainerPort: batchMetricsPort,
+	})
+	return container
+}
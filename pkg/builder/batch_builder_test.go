@@ -0,0 +1,222 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuildReplicaProvisioningJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := mariadbv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	builder := NewBuilder(scheme, &environment.Environment{
+		MariadbOperatorImage: "mariadb-operator:test",
+	})
+	newMariaDB := func(provisioning *mariadbv1alpha1.ReplicaProvisioning) *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-repl",
+				Namespace: "default",
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Image: "mariadb:11.0.3",
+				Port:  3306,
+				Replication: &mariadbv1alpha1.Replication{
+					Enabled: true,
+					ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+						Replica: &mariadbv1alpha1.ReplicaReplication{
+							Provisioning: provisioning,
+						},
+					},
+				},
+				Replicas: 3,
+			},
+		}
+	}
+	key := types.NamespacedName{
+		Name:      "mariadb-repl-provisioning-2",
+		Namespace: "default",
+	}
+	restoreSource := &mariadbv1alpha1.RestoreSource{
+		Volume: &corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+		TargetRecoveryTime: &metav1.Time{},
+	}
+	replicaHost := "--host=mariadb-repl-2.mariadb-repl-internal.default.svc.cluster.local"
+
+	tests := []struct {
+		name              string
+		mariadb           *mariadbv1alpha1.MariaDB
+		restoreSource     *mariadbv1alpha1.RestoreSource
+		wantErr           bool
+		wantInitContainer bool
+		wantDump          bool
+	}{
+		{
+			name:    "no provisioning",
+			mariadb: newMariaDB(nil),
+			wantErr: true,
+		},
+		{
+			name: "Backup source without restore source",
+			mariadb: newMariaDB(&mariadbv1alpha1.ReplicaProvisioning{
+				Source: mariadbv1alpha1.ReplicaProvisioningSourceBackup,
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Backup source",
+			mariadb: newMariaDB(&mariadbv1alpha1.ReplicaProvisioning{
+				Source: mariadbv1alpha1.ReplicaProvisioningSourceBackup,
+			}),
+			restoreSource:     restoreSource,
+			wantInitContainer: true,
+			wantDump:          false,
+		},
+		{
+			name: "Primary source",
+			mariadb: newMariaDB(&mariadbv1alpha1.ReplicaProvisioning{
+				Source: mariadbv1alpha1.ReplicaProvisioningSourcePrimary,
+			}),
+			wantInitContainer: false,
+			wantDump:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := builder.BuildReplicaProvisioningJob(key, tt.mariadb, 2, tt.restoreSource)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error building Job, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error building Job: %v", err)
+			}
+
+			if job.Name != key.Name || job.Namespace != key.Namespace {
+				t.Errorf("unexpected Job key, expected: %v got: %s/%s", key, job.Namespace, job.Name)
+			}
+			if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Name != tt.mariadb.Name {
+				t.Errorf("expected Job to be owned by the MariaDB, got: %v", job.OwnerReferences)
+			}
+
+			podSpec := job.Spec.Template.Spec
+			if podSpec.RestartPolicy != corev1.RestartPolicyOnFailure {
+				t.Errorf("unexpected restart policy, expected: %s got: %s", corev1.RestartPolicyOnFailure, podSpec.RestartPolicy)
+			}
+			if hasInitContainer := len(podSpec.InitContainers) > 0; hasInitContainer != tt.wantInitContainer {
+				t.Errorf("unexpected init container, expected: %v got: %v", tt.wantInitContainer, hasInitContainer)
+			}
+			if len(podSpec.Containers) != 1 {
+				t.Fatalf("expected a single container, got: %d", len(podSpec.Containers))
+			}
+
+			script := strings.Join(podSpec.Containers[0].Args, " ")
+			if !strings.Contains(script, replicaHost) {
+				t.Errorf("expected provisioning command to connect to the replica: %s", replicaHost)
+			}
+			if hasDump := strings.Contains(script, "mariadb-dump"); hasDump != tt.wantDump {
+				t.Errorf("unexpected dump in provisioning command, expected: %v got: %v", tt.wantDump, hasDump)
+			}
+		})
+	}
+}
+
+func TestBuildRestoreJobBeforeTargetTime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := mariadbv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	builder := NewBuilder(scheme, &environment.Environment{
+		MariadbOperatorImage: "mariadb-operator:test",
+	})
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "default",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image: "mariadb:11.0.3",
+			Port:  3306,
+		},
+	}
+	newRestore := func(targetRecoveryTime *metav1.Time, binlogs *mariadbv1alpha1.RestoreBinlogs) *mariadbv1alpha1.Restore {
+		return &mariadbv1alpha1.Restore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "restore",
+				Namespace: "default",
+			},
+			Spec: mariadbv1alpha1.RestoreSpec{
+				RestoreSource: mariadbv1alpha1.RestoreSource{
+					Volume: &corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+					TargetRecoveryTime: targetRecoveryTime,
+				},
+				Binlogs: binlogs,
+			},
+		}
+	}
+	targetRecoveryTime := &metav1.Time{Time: time.Date(2023, 12, 19, 9, 0, 0, 0, time.UTC)}
+	key := types.NamespacedName{
+		Name:      "restore",
+		Namespace: "default",
+	}
+
+	tests := []struct {
+		name       string
+		restore    *mariadbv1alpha1.Restore
+		wantBefore bool
+	}{
+		{
+			name:       "no target recovery time",
+			restore:    newRestore(nil, nil),
+			wantBefore: false,
+		},
+		{
+			name:       "target recovery time",
+			restore:    newRestore(targetRecoveryTime, nil),
+			wantBefore: true,
+		},
+		{
+			name: "binlogs",
+			restore: newRestore(targetRecoveryTime, &mariadbv1alpha1.RestoreBinlogs{
+				S3: mariadbv1alpha1.S3{
+					Bucket:   "binlogs",
+					Endpoint: "minio:9000",
+				},
+			}),
+			wantBefore: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := builder.BuildRestoreJob(key, tt.restore, mariadb)
+			if err != nil {
+				t.Fatalf("unexpected error building Job: %v", err)
+			}
+			initContainers := job.Spec.Template.Spec.InitContainers
+			if len(initContainers) == 0 {
+				t.Fatal("expected restore init container")
+			}
+			args := strings.Join(append(initContainers[0].Command, initContainers[0].Args...), " ")
+			if hasBefore := strings.Contains(args, "--before-target-time"); hasBefore != tt.wantBefore {
+				t.Errorf("unexpected before target time flag, expected: %v got: %v", tt.wantBefore, hasBefore)
+			}
+		})
+	}
+}
@@ -44,6 +44,35 @@ func withJobBackoffLimit(backoffLimit int32) jobOption {
 	}
 }
 
+func withJobActiveDeadline(activeDeadline *metav1.Duration) jobOption {
+	return func(b *jobBuilder) {
+		if activeDeadline != nil {
+			seconds := int64(activeDeadline.Seconds())
+			b.activeDeadlineSeconds = &seconds
+		}
+	}
+}
+
+func withJobActiveDeadlineSeconds(activeDeadlineSeconds *int64) jobOption {
+	return func(b *jobBuilder) {
+		if activeDeadlineSeconds != nil {
+			b.activeDeadlineSeconds = activeDeadlineSeconds
+		}
+	}
+}
+
+func withJobPodFailurePolicy(podFailurePolicy *batchv1.PodFailurePolicy) jobOption {
+	return func(b *jobBuilder) {
+		b.podFailurePolicy = podFailurePolicy
+	}
+}
+
+func withJobTTLSecondsAfterFinished(ttlSeconds *int32) jobOption {
+	return func(b *jobBuilder) {
+		b.ttlSecondsAfterFinished = ttlSeconds
+	}
+}
+
 func withJobRestartPolicy(restartPolicy corev1.RestartPolicy) jobOption {
 	return func(b *jobBuilder) {
 		b.restartPolicy = &restartPolicy
@@ -68,16 +97,28 @@ func withTolerations(tolerations ...corev1.Toleration) jobOption {
 	}
 }
 
+func withJobServiceAccountName(serviceAccountName *string) jobOption {
+	return func(b *jobBuilder) {
+		if serviceAccountName != nil {
+			b.serviceAccountName = serviceAccountName
+		}
+	}
+}
+
 type jobBuilder struct {
-	meta           *metav1.ObjectMeta
-	volumes        []corev1.Volume
-	initContainers []corev1.Container
-	containers     []corev1.Container
-	backoffLimit   *int32
-	restartPolicy  *corev1.RestartPolicy
-	affinity       *corev1.Affinity
-	nodeSelector   map[string]string
-	tolerations    []corev1.Toleration
+	meta                    *metav1.ObjectMeta
+	volumes                 []corev1.Volume
+	initContainers          []corev1.Container
+	containers              []corev1.Container
+	backoffLimit            *int32
+	activeDeadlineSeconds   *int64
+	ttlSecondsAfterFinished *int32
+	podFailurePolicy        *batchv1.PodFailurePolicy
+	restartPolicy           *corev1.RestartPolicy
+	affinity                *corev1.Affinity
+	nodeSelector            map[string]string
+	tolerations             []corev1.Toleration
+	serviceAccountName      *string
 }
 
 func newJobBuilder(opts ...jobOption) (*jobBuilder, error) {
@@ -102,7 +143,7 @@ func (b *jobBuilder) build() *batchv1.Job {
 	template := corev1.PodTemplateSpec{
 		ObjectMeta: *b.meta,
 		Spec: corev1.PodSpec{
-			Volumes:      b.volumes,
+			Volumes:      withScratchVolumes(b.volumes, append(b.initContainers, b.containers...)...),
 			Containers:   b.containers,
 			Affinity:     b.affinity,
 			NodeSelector: b.nodeSelector,
@@ -115,6 +156,9 @@ func (b *jobBuilder) build() *batchv1.Job {
 	if b.restartPolicy != nil {
 		template.Spec.RestartPolicy = *b.restartPolicy
 	}
+	if b.serviceAccountName != nil {
+		template.Spec.ServiceAccountName = *b.serviceAccountName
+	}
 
 	job := &batchv1.Job{
 		ObjectMeta: *b.meta,
@@ -125,9 +169,38 @@ func (b *jobBuilder) build() *batchv1.Job {
 	if b.backoffLimit != nil {
 		job.Spec.BackoffLimit = b.backoffLimit
 	}
+	if b.activeDeadlineSeconds != nil {
+		job.Spec.ActiveDeadlineSeconds = b.activeDeadlineSeconds
+	}
+	if b.ttlSecondsAfterFinished != nil {
+		job.Spec.TTLSecondsAfterFinished = b.ttlSecondsAfterFinished
+	}
+	if b.podFailurePolicy != nil {
+		job.Spec.PodFailurePolicy = b.podFailurePolicy
+	} else if template.Spec.RestartPolicy == corev1.RestartPolicyNever {
+		job.Spec.PodFailurePolicy = defaultPodFailurePolicy()
+	}
 	return job
 }
 
+// defaultPodFailurePolicy retries the Pods affected by disruptions, such as node evictions or preemptions,
+// without counting them towards the backoff limit.
+func defaultPodFailurePolicy() *batchv1.PodFailurePolicy {
+	return &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionIgnore,
+				OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{
+					{
+						Type:   corev1.DisruptionTarget,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		},
+	}
+}
+
 func jobContainer(name string, cmd *cmd.Command, image string, volumeMounts []corev1.VolumeMount, env []v1.EnvVar,
 	resources *corev1.ResourceRequirements, mariadb *mariadbv1alpha1.MariaDB) corev1.Container {
 
@@ -143,6 +216,8 @@ func jobContainer(name string, cmd *cmd.Command, image string, volumeMounts []co
 	if resources != nil {
 		container.Resources = *resources
 	}
+	// Job containers honour the read-only root filesystem preference of the MariaDB they belong to.
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(nil, mariadb.Spec.SecurityContext), tmpScratchVolumeMount())
 	return container
 }
 
@@ -156,6 +231,11 @@ func jobMariadbContainer(cmd *cmd.Command, volumeMounts []corev1.VolumeMount, en
 	return jobContainer("mariadb", cmd, mariadb.Spec.Image, volumeMounts, envVar, resources, mariadb)
 }
 
+func jobCompressionContainer(cmd *cmd.Command, volumeMounts []corev1.VolumeMount,
+	resources *corev1.ResourceRequirements, mariadb *mariadbv1alpha1.MariaDB) corev1.Container {
+	return jobContainer("compression", cmd, mariadb.Spec.Image, volumeMounts, nil, resources, mariadb)
+}
+
 func jobBatchStorageVolume(volumeSource *corev1.VolumeSource, s3 *mariadbv1alpha1.S3) ([]corev1.Volume, []corev1.VolumeMount) {
 	volumes :=
 		[]corev1.Volume{
@@ -170,23 +250,84 @@ func jobBatchStorageVolume(volumeSource *corev1.VolumeSource, s3 *mariadbv1alpha
 			MountPath: batchStorageMountPath,
 		},
 	}
-	if s3 != nil && s3.TLS != nil && s3.TLS.Enabled && s3.TLS.CASecretKeyRef != nil {
-		volumes = append(volumes, corev1.Volume{
-			Name: batchS3PKI,
+	pkiVolumes, pkiVolumeMounts := jobS3PKIVolume(batchS3PKI, s3)
+	return append(volumes, pkiVolumes...), append(volumeMounts, pkiVolumeMounts...)
+}
+
+func jobS3PKIVolume(name string, s3 *mariadbv1alpha1.S3) ([]corev1.Volume, []corev1.VolumeMount) {
+	if s3 == nil || s3.TLS == nil || !s3.TLS.Enabled || s3.TLS.CASecretKeyRef == nil {
+		return nil, nil
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: name,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &v1.SecretVolumeSource{
 					SecretName: s3.TLS.CASecretKeyRef.Name,
 				},
 			},
-		})
-		volumeMounts = append(volumeMounts, v1.VolumeMount{
-			Name:      batchS3PKI,
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      name,
 			MountPath: batchS3PKIMountPath,
-		})
+		},
+	}
+	return volumes, volumeMounts
+}
+
+func jobGCSCredentialsVolume(gcs *mariadbv1alpha1.GCS) ([]corev1.Volume, []corev1.VolumeMount) {
+	if gcs == nil || gcs.CredentialsSecretKeyRef == nil {
+		return nil, nil
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: batchGCSCredentials,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: gcs.CredentialsSecretKeyRef.Name,
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      batchGCSCredentials,
+			MountPath: batchGCSCredentialsMountPath,
+		},
 	}
 	return volumes, volumeMounts
 }
 
+func jobGCSServiceAccountName(gcs *mariadbv1alpha1.GCS) *string {
+	if gcs == nil {
+		return nil
+	}
+	return gcs.ServiceAccountName
+}
+
+func jobAzureBlobServiceAccountName(azureBlob *mariadbv1alpha1.AzureBlob) *string {
+	if azureBlob == nil {
+		return nil
+	}
+	return azureBlob.ServiceAccountName
+}
+
+func jobAzureBlobEnv(azureBlob *mariadbv1alpha1.AzureBlob) []v1.EnvVar {
+	if azureBlob == nil || azureBlob.AccountKeySecretKeyRef == nil {
+		return nil
+	}
+	return []v1.EnvVar{
+		{
+			Name: batchAzureAccountKey,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: azureBlob.AccountKeySecretKeyRef,
+			},
+		},
+	}
+}
+
 func jobEnv(mariadb *mariadbv1alpha1.MariaDB) []v1.EnvVar {
 	return []v1.EnvVar{
 		{
@@ -228,6 +369,14 @@ func jobS3Env(s3 *mariadbv1alpha1.S3) []v1.EnvVar {
 			},
 		})
 	}
+	if s3.SSE != nil && s3.SSE.CustomerKeySecretKeyRef != nil {
+		env = append(env, v1.EnvVar{
+			Name: batchS3SSECustomerKey,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: s3.SSE.CustomerKeySecretKeyRef,
+			},
+		})
+	}
 	return env
 }
 
@@ -0,0 +1,124 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// CertificateGVK is the kind of the cert-manager Certificate, whose types are not vendored by the operator.
+var CertificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// BuildServerCertificate builds a cert-manager Certificate for the MariaDB server, valid for the Services and the Pods.
+// cert-manager stores the issued certificate in a Secret with the same name.
+func (b *Builder) BuildServerCertificate(mariadb *mariadbv1alpha1.MariaDB,
+	key types.NamespacedName) (*unstructured.Unstructured, error) {
+	if mariadb.Spec.TLS == nil || !mariadb.Spec.TLS.IsIssuedByCertManager() {
+		return nil, errors.New("MariaDB instance does not specify a cert-manager issuer")
+	}
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+	tls := mariadb.Spec.TLS
+
+	spec := map[string]interface{}{
+		"secretName": key.Name,
+		"commonName": ServerCertificateCommonName(mariadb),
+		"dnsNames":   toInterfaceSlice(ServerCertificateDNSNames(mariadb)),
+		"issuerRef": map[string]interface{}{
+			"name":  tls.IssuerRef.Name,
+			"kind":  tls.IssuerRef.KindOrDefault(),
+			"group": tls.IssuerRef.GroupOrDefault(),
+		},
+		"usages": []interface{}{
+			"server auth",
+			"client auth",
+		},
+		"secretTemplate": map[string]interface{}{
+			"labels": toInterfaceMap(objMeta.Labels),
+		},
+	}
+	if tls.Duration != nil {
+		spec["duration"] = tls.Duration.Duration.String()
+	}
+	if tls.RenewBefore != nil {
+		spec["renewBefore"] = tls.RenewBefore.Duration.String()
+	}
+
+	cert := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	cert.SetGroupVersionKind(CertificateGVK)
+	cert.SetName(objMeta.Name)
+	cert.SetNamespace(objMeta.Namespace)
+	cert.SetLabels(objMeta.Labels)
+	cert.SetAnnotations(objMeta.Annotations)
+
+	if err := controllerutil.SetControllerReference(mariadb, cert, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Certificate: %v", err)
+	}
+	return cert, nil
+}
+
+// ServerCertificateCommonName returns the common name of the server certificate. The name of the Service is used instead of
+// its FQDN, as the common name is limited to 64 characters. The FQDN is part of the DNS names, which are verified by the clients.
+func ServerCertificateCommonName(mariadb *mariadbv1alpha1.MariaDB) string {
+	return mariadb.ServiceKey().Name
+}
+
+// ServerCertificateDNSNames returns the DNS names of the server certificate: the Services of the MariaDB and its Pods.
+func ServerCertificateDNSNames(mariadb *mariadbv1alpha1.MariaDB) []string {
+	services := []string{
+		mariadb.ServiceKey().Name,
+		mariadb.InternalServiceKey().Name,
+	}
+	if mariadb.IsHAEnabled() {
+		services = append(services, mariadb.PrimaryServiceKey().Name, mariadb.SecondaryServiceKey().Name)
+		for _, svc := range mariadb.Spec.SecondaryServices {
+			services = append(services, mariadb.NamedSecondaryServiceKey(svc.Name).Name)
+		}
+	}
+	var dnsNames []string
+	for _, svc := range services {
+		dnsNames = append(dnsNames,
+			svc,
+			fmt.Sprintf("%s.%s", svc, mariadb.Namespace),
+			statefulset.ServiceFQDNWithService(mariadb.ObjectMeta, svc),
+		)
+	}
+	dnsNames = append(dnsNames,
+		fmt.Sprintf("*.%s", statefulset.ServiceFQDNWithService(mariadb.ObjectMeta, mariadb.InternalServiceKey().Name)),
+		"localhost",
+	)
+	return dnsNames
+}
+
+func toInterfaceSlice(s []string) []interface{} {
+	out := make([]interface{}, len(s))
+	for i, v := range s {
+		out[i] = v
+	}
+	return out
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
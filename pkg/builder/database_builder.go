@@ -7,6 +7,7 @@ import (
 	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -16,6 +17,8 @@ type UserOpts struct {
 	PasswordSecretKeyRef v1.SecretKeySelector
 	MaxUserConnections   int32
 	Name                 string
+	// Owner of the User. It defaults to the MariaDB.
+	Owner metav1.Object
 }
 
 func (b *Builder) BuildUser(mariadb *mariadbv1alpha1.MariaDB, opts UserOpts) (*mariadbv1alpha1.User, error) {
@@ -26,18 +29,13 @@ func (b *Builder) BuildUser(mariadb *mariadbv1alpha1.MariaDB, opts UserOpts) (*m
 	user := &mariadbv1alpha1.User{
 		ObjectMeta: objMeta,
 		Spec: mariadbv1alpha1.UserSpec{
-			MariaDBRef: mariadbv1alpha1.MariaDBRef{
-				ObjectReference: corev1.ObjectReference{
-					Name: mariadb.Name,
-				},
-				WaitForIt: true,
-			},
-			PasswordSecretKeyRef: opts.PasswordSecretKeyRef,
+			MariaDBRef:           mariaDBRef(mariadb, opts.Key),
+			PasswordSecretKeyRef: &opts.PasswordSecretKeyRef,
 			MaxUserConnections:   opts.MaxUserConnections,
 			Name:                 opts.Name,
 		},
 	}
-	if err := controllerutil.SetControllerReference(mariadb, user, b.scheme); err != nil {
+	if err := controllerutil.SetControllerReference(ownerOrMariaDB(opts.Owner, mariadb), user, b.scheme); err != nil {
 		return nil, fmt.Errorf("error setting controller reference to User: %v", err)
 	}
 
@@ -51,6 +49,8 @@ type GrantOpts struct {
 	Table       string
 	Username    string
 	GrantOption bool
+	// Owner of the Grant. It defaults to the MariaDB.
+	Owner metav1.Object
 }
 
 func (b *Builder) BuildGrant(mariadb *mariadbv1alpha1.MariaDB, opts GrantOpts) (*mariadbv1alpha1.Grant, error) {
@@ -61,12 +61,7 @@ func (b *Builder) BuildGrant(mariadb *mariadbv1alpha1.MariaDB, opts GrantOpts) (
 	grant := &mariadbv1alpha1.Grant{
 		ObjectMeta: objMeta,
 		Spec: mariadbv1alpha1.GrantSpec{
-			MariaDBRef: mariadbv1alpha1.MariaDBRef{
-				ObjectReference: corev1.ObjectReference{
-					Name: mariadb.Name,
-				},
-				WaitForIt: true,
-			},
+			MariaDBRef:  mariaDBRef(mariadb, opts.Key),
 			Privileges:  opts.Privileges,
 			Database:    opts.Database,
 			Table:       opts.Table,
@@ -74,9 +69,30 @@ func (b *Builder) BuildGrant(mariadb *mariadbv1alpha1.MariaDB, opts GrantOpts) (
 			GrantOption: opts.GrantOption,
 		},
 	}
-	if err := controllerutil.SetControllerReference(mariadb, grant, b.scheme); err != nil {
+	if err := controllerutil.SetControllerReference(ownerOrMariaDB(opts.Owner, mariadb), grant, b.scheme); err != nil {
 		return nil, fmt.Errorf("error setting controller reference to Grant: %v", err)
 	}
 
 	return grant, nil
 }
+
+func ownerOrMariaDB(owner metav1.Object, mariadb *mariadbv1alpha1.MariaDB) metav1.Object {
+	if owner != nil {
+		return owner
+	}
+	return mariadb
+}
+
+// mariaDBRef references the MariaDB from an object with the given key, which may live in a different namespace when owned by another resource.
+func mariaDBRef(mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName) mariadbv1alpha1.MariaDBRef {
+	ref := mariadbv1alpha1.MariaDBRef{
+		ObjectReference: corev1.ObjectReference{
+			Name: mariadb.Name,
+		},
+		WaitForIt: true,
+	}
+	if key.Namespace != mariadb.Namespace {
+		ref.Namespace = mariadb.Namespace
+	}
+	return ref
+}
@@ -3,15 +3,20 @@ package builder
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
 	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	galeraresources "github.com/mariadb-operator/mariadb-operator/pkg/controller/galera/resources"
+	annotation "github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -33,6 +38,11 @@ func (b *Builder) BuildExporterDeployment(mariadb *mariadbv1alpha1.MariaDB, key
 		labels.NewLabelsBuilder().
 			WithMetricsSelectorLabels(mariadb).
 			Build()
+	podObjMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			WithLabels(selectorLabels).
+			Build()
 	container, err := buildExporterContainer(mariadb)
 	if err != nil {
 		return nil, fmt.Errorf("error building exporter container: %v", err)
@@ -45,14 +55,12 @@ func (b *Builder) BuildExporterDeployment(mariadb *mariadbv1alpha1.MariaDB, key
 				MatchLabels: selectorLabels,
 			},
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: selectorLabels,
-				},
+				ObjectMeta: podObjMeta,
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						container,
 					},
-					Volumes: []corev1.Volume{
+					Volumes: withScratchVolumes([]corev1.Volume{
 						{
 							Name: metricsConfigVolume,
 							VolumeSource: corev1.VolumeSource{
@@ -61,7 +69,7 @@ func (b *Builder) BuildExporterDeployment(mariadb *mariadbv1alpha1.MariaDB, key
 								},
 							},
 						},
-					},
+					}, container),
 				},
 			},
 		},
@@ -111,6 +119,8 @@ func buildExporterContainer(mariadb *mariadbv1alpha1.MariaDB) (corev1.Container,
 	}
 	container.LivenessProbe = probe
 	container.ReadinessProbe = probe
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(container.SecurityContext, mariadb.Spec.SecurityContext),
+		tmpScratchVolumeMount())
 
 	return container, nil
 }
@@ -118,3 +128,136 @@ func buildExporterContainer(mariadb *mariadbv1alpha1.MariaDB) (corev1.Container,
 func exporterConfigFile(mariadb *mariadbv1alpha1.MariaDB) string {
 	return fmt.Sprintf("%s/%s", metricsConfigMountPath, mariadb.MetricsConfigSecretKeyRef().Key)
 }
+
+// BuildGaleraArbitratorDeployment builds a Deployment running a Galera arbitrator (garbd) that joins the group with the given name.
+func (b *Builder) BuildGaleraArbitratorDeployment(mariadb *mariadbv1alpha1.MariaDB, key types.NamespacedName,
+	group string) (*appsv1.Deployment, error) {
+	if !mariadb.IsGaleraArbitratorEnabled() {
+		return nil, errors.New("MariaDB instance does not specify a Galera arbitrator")
+	}
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+	selectorLabels :=
+		labels.NewLabelsBuilder().
+			WithGaleraArbitratorSelectorLabels(mariadb).
+			Build()
+	podObjMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			WithLabels(selectorLabels).
+			WithAnnotations(galeraArbitratorAnnotations(mariadb)).
+			Build()
+	arbitrator := mariadb.Galera().Arbitrator
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: objMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: podObjMeta,
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: ptr.To(false),
+					ImagePullSecrets:             mariadb.Spec.ImagePullSecrets,
+					Containers: []corev1.Container{
+						buildGaleraArbitratorContainer(mariadb, group),
+					},
+					Volumes:      buildGaleraArbitratorVolumes(mariadb),
+					NodeSelector: arbitrator.NodeSelector,
+					Affinity:     arbitrator.Affinity,
+					Tolerations:  arbitrator.Tolerations,
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(mariadb, deployment, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Deployment: %v", err)
+	}
+	return deployment, nil
+}
+
+func buildGaleraArbitratorContainer(mariadb *mariadbv1alpha1.MariaDB, group string) corev1.Container {
+	arbitrator := mariadb.Galera().Arbitrator
+	tpl := arbitrator.ContainerTemplate
+
+	image := arbitrator.Image
+	pullPolicy := arbitrator.ImagePullPolicy
+	if image == "" {
+		image = mariadb.Spec.Image
+		pullPolicy = mariadb.Spec.ImagePullPolicy
+	}
+	container := buildContainer(image, pullPolicy, &tpl)
+	container.Name = "garbd"
+	if len(container.Command) == 0 {
+		container.Command = []string{"garbd"}
+	}
+	args := []string{
+		"--address", galeraArbitratorAddress(mariadb),
+		"--group", group,
+	}
+	if mariadb.IsGaleraTLSEnabled() {
+		args = append(args, "--options", formatProviderOptions(galeraTLSProviderOptions()))
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      ServerTLSVolume,
+			MountPath: ServerTLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+	container.Args = append(args, tpl.Args...)
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = galeraArbitratorReadinessProbe()
+	}
+
+	return container
+}
+
+// galeraArbitratorReadinessProbe checks that garbd holds an established connection to the group communication port of a member,
+// which only happens once it has joined the cluster. garbd exposes no other endpoint to report its state.
+func galeraArbitratorReadinessProbe() *corev1.Probe {
+	connectedPattern := fmt.Sprintf(":%04X 01 ", galeraresources.GaleraISTPort)
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					"sh",
+					"-c",
+					fmt.Sprintf("grep -q '%s' /proc/net/tcp /proc/net/tcp6", connectedPattern),
+				},
+			},
+		},
+		InitialDelaySeconds: 10,
+		TimeoutSeconds:      5,
+		PeriodSeconds:       10,
+	}
+}
+
+// galeraArbitratorAnnotations restarts the arbitrator whenever the Pods load a renewed certificate, as garbd is not able to reload it.
+func galeraArbitratorAnnotations(mariadb *mariadbv1alpha1.MariaDB) map[string]string {
+	if !mariadb.IsGaleraTLSEnabled() || mariadb.Status.TLS == nil || mariadb.Status.TLS.CertSerial == "" {
+		return nil
+	}
+	return map[string]string{
+		annotation.TLSCertSerialAnnotation: mariadb.Status.TLS.CertSerial,
+	}
+}
+
+func buildGaleraArbitratorVolumes(mariadb *mariadbv1alpha1.MariaDB) []corev1.Volume {
+	if !mariadb.IsGaleraTLSEnabled() {
+		return nil
+	}
+	return []corev1.Volume{
+		buildServerTLSVolume(mariadb),
+	}
+}
+
+func galeraArbitratorAddress(mariadb *mariadbv1alpha1.MariaDB) string {
+	hosts := make([]string, mariadb.ScaledReplicas())
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		hosts[i] = statefulset.PodFQDNWithService(mariadb.ObjectMeta, i, mariadb.InternalServiceKey().Name)
+	}
+	return fmt.Sprintf("gcomm://%s", strings.Join(hosts, ","))
+}
@@ -0,0 +1,147 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuildGaleraArbitratorDeploymentReadinessProbe(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := mariadbv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	builder := NewBuilder(scheme, &environment.Environment{
+		MariadbOperatorImage: "mariadb-operator:test",
+	})
+	newMariaDB := func(readinessProbe *corev1.Probe) *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-galera",
+				Namespace: "default",
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Image:    "mariadb:11.0.3",
+				Replicas: 2,
+				Galera: &mariadbv1alpha1.Galera{
+					Enabled: true,
+					GaleraSpec: mariadbv1alpha1.GaleraSpec{
+						Arbitrator: &mariadbv1alpha1.GaleraArbitrator{
+							Enabled: true,
+							ContainerTemplate: mariadbv1alpha1.ContainerTemplate{
+								ReadinessProbe: readinessProbe,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	key := types.NamespacedName{
+		Name:      "mariadb-galera-arbitrator",
+		Namespace: "default",
+	}
+	customProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"true"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		mariadb     *mariadbv1alpha1.MariaDB
+		wantCommand string
+	}{
+		{
+			name:        "default probe",
+			mariadb:     newMariaDB(nil),
+			wantCommand: "grep -q ':11D7 01 ' /proc/net/tcp /proc/net/tcp6",
+		},
+		{
+			name:        "custom probe",
+			mariadb:     newMariaDB(customProbe),
+			wantCommand: "true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploy, err := builder.BuildGaleraArbitratorDeployment(tt.mariadb, key, "mariadb-operator")
+			if err != nil {
+				t.Fatalf("unexpected error building Deployment: %v", err)
+			}
+			containers := deploy.Spec.Template.Spec.Containers
+			if len(containers) != 1 {
+				t.Fatalf("expected a single container, got: %d", len(containers))
+			}
+			probe := containers[0].ReadinessProbe
+			if probe == nil || probe.Exec == nil {
+				t.Fatalf("expected exec readiness probe, got: %v", probe)
+			}
+			command := probe.Exec.Command[len(probe.Exec.Command)-1]
+			if !strings.Contains(command, tt.wantCommand) {
+				t.Errorf("unexpected readiness probe command, expected: %s got: %s", tt.wantCommand, command)
+			}
+		})
+	}
+}
+
+func TestBuildExporterDeploymentInheritMetadata(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := mariadbv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	builder := NewBuilder(scheme, &environment.Environment{})
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-metrics",
+			Namespace: "default",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			InheritMetadata: &mariadbv1alpha1.InheritMetadata{
+				Labels: map[string]string{
+					"database.myorg.io": "mariadb",
+				},
+				Annotations: map[string]string{
+					"database.myorg.io": "mariadb",
+				},
+			},
+			Metrics: &mariadbv1alpha1.Metrics{
+				Enabled: true,
+				Exporter: mariadbv1alpha1.Exporter{
+					Image: "prom/mysqld-exporter:v0.15.1",
+					Port:  9104,
+				},
+			},
+		},
+	}
+	key := types.NamespacedName{
+		Name:      "mariadb-metrics-exporter",
+		Namespace: "default",
+	}
+
+	deploy, err := builder.BuildExporterDeployment(mariadb, key)
+	if err != nil {
+		t.Fatalf("unexpected error building Deployment: %v", err)
+	}
+	podMeta := deploy.Spec.Template.ObjectMeta
+	if podMeta.Labels["database.myorg.io"] != "mariadb" {
+		t.Errorf("expected Pod template to inherit labels, got: %v", podMeta.Labels)
+	}
+	if podMeta.Annotations["database.myorg.io"] != "mariadb" {
+		t.Errorf("expected Pod template to inherit annotations, got: %v", podMeta.Annotations)
+	}
+	for k, v := range deploy.Spec.Selector.MatchLabels {
+		if podMeta.Labels[k] != v {
+			t.Errorf("expected Pod template to have selector label '%s=%s', got: %v", k, v, podMeta.Labels)
+		}
+	}
+}
@@ -6,11 +6,19 @@ import (
 )
 
 const (
-	appLabel           = "app.kubernetes.io/name"
-	instanceLabel      = "app.kubernetes.io/instance"
-	statefulSetPodName = "statefulset.kubernetes.io/pod-name"
-	appMariaDb         = "mariadb"
-	appExporter        = "exporter"
+	appLabel            = "app.kubernetes.io/name"
+	instanceLabel       = "app.kubernetes.io/instance"
+	statefulSetPodName  = "statefulset.kubernetes.io/pod-name"
+	appMariaDb          = "mariadb"
+	appExporter         = "exporter"
+	appMaxScale         = "maxscale"
+	appGaleraArbitrator = "galera-arbitrator"
+
+	SecondaryServiceLabel = "mariadb.mmontes.io/secondary-service"
+	FleetLabel            = "mariadb.mmontes.io/fleet"
+	RestoreRehearsalLabel = "mariadb.mmontes.io/restore-rehearsal"
+	MariaDBTestLabel      = "mariadb.mmontes.io/test"
+	BackupLabel           = "mariadb.mmontes.io/backup"
 )
 
 type LabelsBuilder struct {
@@ -43,6 +51,31 @@ func (b *LabelsBuilder) WithStatefulSetPod(mdb *mariadbv1alpha1.MariaDB, podInde
 	return b
 }
 
+func (b *LabelsBuilder) WithSecondaryService(name string) *LabelsBuilder {
+	b.labels[SecondaryServiceLabel] = name
+	return b
+}
+
+func (b *LabelsBuilder) WithFleet(name string) *LabelsBuilder {
+	b.labels[FleetLabel] = name
+	return b
+}
+
+func (b *LabelsBuilder) WithRestoreRehearsal(name string) *LabelsBuilder {
+	b.labels[RestoreRehearsalLabel] = name
+	return b
+}
+
+func (b *LabelsBuilder) WithMariaDBTest(name string) *LabelsBuilder {
+	b.labels[MariaDBTestLabel] = name
+	return b
+}
+
+func (b *LabelsBuilder) WithBackup(name string) *LabelsBuilder {
+	b.labels[BackupLabel] = name
+	return b
+}
+
 func (b *LabelsBuilder) WithLabels(labels map[string]string) *LabelsBuilder {
 	for k, v := range labels {
 		b.labels[k] = v
@@ -63,6 +96,16 @@ func (b *LabelsBuilder) WithMetricsSelectorLabels(mdb *mariadbv1alpha1.MariaDB)
 		WithInstance(mdb.Name)
 }
 
+func (b *LabelsBuilder) WithGaleraArbitratorSelectorLabels(mdb *mariadbv1alpha1.MariaDB) *LabelsBuilder {
+	return b.WithApp(appGaleraArbitrator).
+		WithInstance(mdb.Name)
+}
+
+func (b *LabelsBuilder) WithMaxScaleSelectorLabels(mxs *mariadbv1alpha1.MaxScale) *LabelsBuilder {
+	return b.WithApp(appMaxScale).
+		WithInstance(mxs.Name)
+}
+
 func (b *LabelsBuilder) Build() map[string]string {
 	return b.labels
 }
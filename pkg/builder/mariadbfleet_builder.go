@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	annotation "github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func (b *Builder) BuildFleetMember(fleet *mariadbv1alpha1.MariaDBFleet,
+	member *mariadbv1alpha1.MariaDBFleetMember) (*mariadbv1alpha1.MariaDB, error) {
+	spec := *fleet.Spec.Template.Spec.DeepCopy()
+	if member.Image != nil {
+		spec.Image = *member.Image
+	}
+	hash, err := FleetMemberSpecHash(&spec)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := metadata.NewMetadataBuilder(member.Key())
+	if meta := fleet.Spec.Template.Metadata; meta != nil {
+		builder = builder.
+			WithLabels(meta.Labels).
+			WithAnnotations(meta.Annotations)
+	}
+	objMeta :=
+		builder.
+			WithLabels(
+				labels.NewLabelsBuilder().
+					WithFleet(fleet.Name).
+					Build(),
+			).
+			WithAnnotations(map[string]string{
+				annotation.FleetSpecHashAnnotation: hash,
+			}).
+			Build()
+
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: objMeta,
+		Spec:       spec,
+	}
+	mariadb.Default()
+	if b.env != nil {
+		mariadb.SetDefaults(b.env)
+	}
+	if err := controllerutil.SetControllerReference(fleet, mariadb, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to MariaDB: %v", err)
+	}
+	return mariadb, nil
+}
+
+// FleetMemberSpecHash returns a hash of the MariaDB spec used to detect changes in the template of a MariaDBFleet.
+func FleetMemberSpecHash(spec *mariadbv1alpha1.MariaDBSpec) (string, error) {
+	bytes, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling MariaDB spec: %v", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(bytes)), nil
+}
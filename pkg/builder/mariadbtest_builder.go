@@ -0,0 +1,72 @@
+package builder
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BuildMariaDBTestMariaDB builds the MariaDB of a MariaDBTest: a single standalone replica with ephemeral storage
+// and a readiness probe tuned to report readiness as soon as possible.
+func (b *Builder) BuildMariaDBTestMariaDB(test *mariadbv1alpha1.MariaDBTest) (*mariadbv1alpha1.MariaDB, error) {
+	objMeta :=
+		metadata.NewMetadataBuilder(test.MariaDBKey()).
+			WithLabels(
+				labels.NewLabelsBuilder().
+					WithMariaDBTest(test.Name).
+					Build(),
+			).
+			Build()
+
+	readinessProbe := defaultStsProbe
+	readinessProbe.InitialDelaySeconds = 5
+	readinessProbe.PeriodSeconds = 2
+
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: objMeta,
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image:    test.Spec.Image,
+			Database: ptr.To(test.Database()),
+			Username: ptr.To(test.Username()),
+			MyCnf:    test.Spec.MyCnf,
+			Replicas: 1,
+			VolumeClaimTemplate: mariadbv1alpha1.VolumeClaimTemplate{
+				PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{
+						corev1.ReadWriteOnce,
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: test.Storage(),
+						},
+					},
+				},
+			},
+			Ephemeral: true,
+			Connection: &mariadbv1alpha1.ConnectionTemplate{
+				SecretName: ptr.To(test.SecretKey().Name),
+				SecretTemplate: &mariadbv1alpha1.SecretTemplate{
+					Key:         ptr.To("dsn"),
+					UsernameKey: ptr.To("username"),
+					PasswordKey: ptr.To("password"),
+					HostKey:     ptr.To("host"),
+					PortKey:     ptr.To("port"),
+					DatabaseKey: ptr.To("database"),
+				},
+			},
+		},
+	}
+	mariadb.Spec.ReadinessProbe = &readinessProbe
+	if test.Spec.Resources != nil {
+		mariadb.Spec.Resources = test.Spec.Resources.DeepCopy()
+	}
+	if err := controllerutil.SetControllerReference(test, mariadb, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to MariaDB: %v", err)
+	}
+	return mariadb, nil
+}
@@ -0,0 +1,195 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	"github.com/mariadb-operator/mariadb-operator/pkg/maxscale"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	MaxScaleContainerName   = "maxscale"
+	maxScaleConfigVolume    = "config"
+	maxScaleConfigMountPath = "/etc/config"
+	maxScaleDataVolume      = "data"
+	maxScaleDataMountPath   = "/var/lib/maxscale"
+)
+
+func (b *Builder) BuildMaxScaleDeployment(mxs *mariadbv1alpha1.MaxScale, key types.NamespacedName,
+	podAnnotations map[string]string) (*appsv1.Deployment, error) {
+	if len(mxs.Spec.Services) == 0 {
+		return nil, errors.New("MaxScale should have at least one service")
+	}
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			Build()
+	selectorLabels :=
+		labels.NewLabelsBuilder().
+			WithMaxScaleSelectorLabels(mxs).
+			Build()
+	podObjMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithLabels(selectorLabels).
+			WithAnnotations(podAnnotations).
+			Build()
+	replicas := mxs.Spec.Replicas
+	container := buildMaxScaleContainer(mxs)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: objMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: podObjMeta,
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						container,
+					},
+					Volumes: withScratchVolumes([]corev1.Volume{
+						{
+							Name: maxScaleConfigVolume,
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: mxs.ConfigMapKeyRef().LocalObjectReference,
+								},
+							},
+						},
+						scratchVolume(maxScaleDataVolume),
+					}, container),
+					Affinity:     mxs.Spec.Affinity,
+					NodeSelector: mxs.Spec.NodeSelector,
+					Tolerations:  mxs.Spec.Tolerations,
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(mxs, deployment, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Deployment: %v", err)
+	}
+	return deployment, nil
+}
+
+func buildMaxScaleContainer(mxs *mariadbv1alpha1.MaxScale) corev1.Container {
+	tpl := mxs.Spec.ContainerTemplate
+
+	container := buildContainer(mxs.Spec.Image, mxs.Spec.ImagePullPolicy, &tpl)
+	container.Name = MaxScaleContainerName
+	if len(container.Command) == 0 {
+		container.Command = []string{"maxscale"}
+	}
+	container.Args = []string{
+		"--nodaemon",
+		"--user=maxscale",
+		"--log=stdout",
+		fmt.Sprintf("--config=%s/%s", maxScaleConfigMountPath, mxs.ConfigMapKeyRef().Key),
+		fmt.Sprintf("--datadir=%s", maxScaleDataMountPath),
+		fmt.Sprintf("--cachedir=%s/cache", maxScaleDataMountPath),
+		fmt.Sprintf("--piddir=%s/run", maxScaleDataMountPath),
+		fmt.Sprintf("--logdir=%s/log", maxScaleDataMountPath),
+		fmt.Sprintf("--persistdir=%s/maxscale.cnf.d", maxScaleDataMountPath),
+	}
+	if len(tpl.Args) > 0 {
+		container.Args = append(container.Args, tpl.Args...)
+	}
+	container.Env = append([]corev1.EnvVar{
+		{
+			Name: maxscale.PasswordEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &mxs.Spec.Auth.PasswordSecretKeyRef,
+			},
+		},
+	}, tpl.Env...)
+	for _, svc := range mxs.Spec.Services {
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          svc.Name,
+			ContainerPort: svc.Listener.Port,
+		})
+	}
+	container.VolumeMounts = append(container.VolumeMounts,
+		corev1.VolumeMount{
+			Name:      maxScaleConfigVolume,
+			MountPath: maxScaleConfigMountPath,
+			ReadOnly:  true,
+		},
+		corev1.VolumeMount{
+			Name:      maxScaleDataVolume,
+			MountPath: maxScaleDataMountPath,
+		},
+	)
+
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(int(mxs.Spec.Services[0].Listener.Port)),
+			},
+		},
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       10,
+	}
+	if container.LivenessProbe == nil {
+		container.LivenessProbe = probe
+	}
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = probe
+	}
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(container.SecurityContext, nil), tmpScratchVolumeMount())
+	return container
+}
+
+func (b *Builder) BuildMaxScaleService(mxs *mariadbv1alpha1.MaxScale, key types.NamespacedName) (*corev1.Service, error) {
+	tpl := mariadbv1alpha1.ServiceTemplate{}
+	if mxs.Spec.KubernetesService != nil {
+		tpl = *mxs.Spec.KubernetesService
+	}
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithAnnotations(tpl.Annotations).
+			WithLabels(tpl.Labels).
+			Build()
+	svc := &corev1.Service{
+		ObjectMeta: objMeta,
+		Spec: corev1.ServiceSpec{
+			Type: tpl.Type,
+			Selector: labels.NewLabelsBuilder().
+				WithMaxScaleSelectorLabels(mxs).
+				Build(),
+		},
+	}
+	for _, s := range mxs.Spec.Services {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name: s.Name,
+			Port: s.Listener.Port,
+		})
+	}
+	if tpl.LoadBalancerIP != nil {
+		svc.Spec.LoadBalancerIP = *tpl.LoadBalancerIP
+	}
+	if tpl.LoadBalancerSourceRanges != nil {
+		svc.Spec.LoadBalancerSourceRanges = tpl.LoadBalancerSourceRanges
+	}
+	if tpl.ExternalTrafficPolicy != nil {
+		svc.Spec.ExternalTrafficPolicy = *tpl.ExternalTrafficPolicy
+	}
+	if tpl.SessionAffinity != nil {
+		svc.Spec.SessionAffinity = *tpl.SessionAffinity
+	}
+	if tpl.AllocateLoadBalancerNodePorts != nil {
+		svc.Spec.AllocateLoadBalancerNodePorts = tpl.AllocateLoadBalancerNodePorts
+	}
+	if err := controllerutil.SetControllerReference(mxs, svc, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to Service: %v", err)
+	}
+	return svc, nil
+}
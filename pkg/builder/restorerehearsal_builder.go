@@ -0,0 +1,61 @@
+package builder
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	labels "github.com/mariadb-operator/mariadb-operator/pkg/builder/labels"
+	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BuildRestoreRehearsalMariaDB builds the temporary MariaDB of a RestoreRehearsal, using the referenced MariaDB as template.
+// It runs a single standalone replica without any of the services, connections or integrations of the template.
+func (b *Builder) BuildRestoreRehearsalMariaDB(rehearsal *mariadbv1alpha1.RestoreRehearsal,
+	template *mariadbv1alpha1.MariaDB) (*mariadbv1alpha1.MariaDB, error) {
+	objMeta :=
+		metadata.NewMetadataBuilder(rehearsal.MariaDBKey()).
+			WithLabels(
+				labels.NewLabelsBuilder().
+					WithRestoreRehearsal(rehearsal.Name).
+					Build(),
+			).
+			Build()
+
+	spec := *template.Spec.DeepCopy()
+	restoreSource := *rehearsal.Spec.RestoreSource.DeepCopy()
+	spec.BootstrapFrom = &restoreSource
+	spec.Replicas = 1
+	spec.Replication = nil
+	spec.Galera = nil
+	spec.Database = nil
+	spec.Username = nil
+	spec.PasswordSecretKeyRef = nil
+	spec.Metrics = nil
+	spec.PodDisruptionBudget = nil
+	spec.MaintenanceWindow = nil
+	spec.Notifications = nil
+	spec.Service = nil
+	spec.Connection = nil
+	spec.PrimaryService = nil
+	spec.PrimaryConnection = nil
+	spec.SecondaryService = nil
+	spec.SecondaryServices = nil
+	spec.SecondaryConnection = nil
+	spec.StrictOwnership = false
+	if rehearsal.Spec.VolumeClaimTemplate != nil {
+		spec.VolumeClaimTemplate = *rehearsal.Spec.VolumeClaimTemplate.DeepCopy()
+	}
+	if rehearsal.Spec.Resources != nil {
+		spec.Resources = rehearsal.Spec.Resources.DeepCopy()
+	}
+
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: objMeta,
+		Spec:       spec,
+	}
+	if err := controllerutil.SetControllerReference(rehearsal, mariadb, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to MariaDB: %v", err)
+	}
+	return mariadb, nil
+}
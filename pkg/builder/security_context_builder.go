@@ -0,0 +1,114 @@
+package builder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	TmpScratchVolume    = "tmp-scratch"
+	TmpScratchMountPath = "/tmp"
+	RunScratchVolume    = "run-scratch"
+	RunScratchMountPath = "/run/mysqld"
+)
+
+// buildReadOnlyRootFilesystem makes the container run with a read-only root filesystem unless it is disabled, mounting scratch volumes
+// in the paths where it needs to write.
+func buildReadOnlyRootFilesystem(container *corev1.Container, enabled bool, scratchMounts ...corev1.VolumeMount) {
+	if !enabled {
+		return
+	}
+	securityContext := &corev1.SecurityContext{}
+	if container.SecurityContext != nil {
+		securityContext = container.SecurityContext.DeepCopy()
+	}
+	securityContext.ReadOnlyRootFilesystem = ptr.To(true)
+	container.SecurityContext = securityContext
+
+	volumeMounts := make([]corev1.VolumeMount, len(container.VolumeMounts))
+	copy(volumeMounts, container.VolumeMounts)
+	for _, scratchMount := range scratchMounts {
+		if !hasMountPath(volumeMounts, scratchMount.MountPath) {
+			volumeMounts = append(volumeMounts, scratchMount)
+		}
+	}
+	container.VolumeMounts = volumeMounts
+}
+
+// isReadOnlyRootFilesystem returns whether a container runs with a read-only root filesystem. It is enabled by default, and it can be
+// disabled by setting it to false either in the SecurityContext of the container or, when it is not set there, in the SecurityContext
+// it derives from, such as the one of the MariaDB for the sidecar and Job containers.
+func isReadOnlyRootFilesystem(securityContext *corev1.SecurityContext, defaultSecurityContext *corev1.SecurityContext) bool {
+	if securityContext != nil && securityContext.ReadOnlyRootFilesystem != nil {
+		return *securityContext.ReadOnlyRootFilesystem
+	}
+	return defaultSecurityContext == nil || ptr.Deref(defaultSecurityContext.ReadOnlyRootFilesystem, true)
+}
+
+// withScratchVolumes returns a copy of the volumes including the scratch volumes mounted by the containers.
+func withScratchVolumes(volumes []corev1.Volume, containers ...corev1.Container) []corev1.Volume {
+	result := make([]corev1.Volume, len(volumes), len(volumes)+2)
+	copy(result, volumes)
+	for _, name := range []string{TmpScratchVolume, RunScratchVolume} {
+		if hasVolume(result, name) {
+			continue
+		}
+		for _, container := range containers {
+			if hasVolumeMountName(container.VolumeMounts, name) {
+				result = append(result, scratchVolume(name))
+				break
+			}
+		}
+	}
+	return result
+}
+
+func hasMountPath(volumeMounts []corev1.VolumeMount, mountPath string) bool {
+	for _, m := range volumeMounts {
+		if m.MountPath == mountPath {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMountName(volumeMounts []corev1.VolumeMount, name string) bool {
+	for _, m := range volumeMounts {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func scratchVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func tmpScratchVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      TmpScratchVolume,
+		MountPath: TmpScratchMountPath,
+	}
+}
+
+func runScratchVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      RunScratchVolume,
+		MountPath: RunScratchMountPath,
+	}
+}
@@ -0,0 +1,252 @@
+package builder
+
+import (
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestIsReadOnlyRootFilesystem(t *testing.T) {
+	tests := []struct {
+		name                   string
+		securityContext        *corev1.SecurityContext
+		defaultSecurityContext *corev1.SecurityContext
+		want                   bool
+	}{
+		{
+			name: "no security contexts",
+			want: true,
+		},
+		{
+			name:            "empty security context",
+			securityContext: &corev1.SecurityContext{},
+			want:            true,
+		},
+		{
+			name: "disabled in container",
+			securityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+			},
+			want: false,
+		},
+		{
+			name: "disabled in default",
+			defaultSecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+			},
+			want: false,
+		},
+		{
+			name: "enabled in container and disabled in default",
+			securityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			defaultSecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+			},
+			want: true,
+		},
+		{
+			name: "enabled in container",
+			securityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			want: true,
+		},
+		{
+			name: "enabled in default",
+			defaultSecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			want: true,
+		},
+		{
+			name: "disabled in container and enabled in default",
+			securityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+			},
+			defaultSecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isReadOnlyRootFilesystem(tt.securityContext, tt.defaultSecurityContext); got != tt.want {
+				t.Errorf("unexpected read-only root filesystem, expected: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildReadOnlyRootFilesystem(t *testing.T) {
+	volumeMounts := make([]corev1.VolumeMount, 1, 4)
+	volumeMounts[0] = corev1.VolumeMount{
+		Name:      "storage",
+		MountPath: "/var/lib/mysql",
+	}
+	securityContext := &corev1.SecurityContext{
+		RunAsUser: ptr.To(int64(999)),
+	}
+
+	disabled := corev1.Container{
+		SecurityContext: securityContext,
+		VolumeMounts:    volumeMounts,
+	}
+	buildReadOnlyRootFilesystem(&disabled, false, tmpScratchVolumeMount())
+	if disabled.SecurityContext.ReadOnlyRootFilesystem != nil {
+		t.Error("expected read-only root filesystem not to be set when disabled")
+	}
+	if len(disabled.VolumeMounts) != 1 {
+		t.Errorf("expected no scratch mounts when disabled, got: %v", disabled.VolumeMounts)
+	}
+
+	enabled := corev1.Container{
+		SecurityContext: securityContext,
+		VolumeMounts:    volumeMounts,
+	}
+	buildReadOnlyRootFilesystem(&enabled, true, tmpScratchVolumeMount(), runScratchVolumeMount())
+	if !ptr.Deref(enabled.SecurityContext.ReadOnlyRootFilesystem, false) {
+		t.Error("expected read-only root filesystem to be set when enabled")
+	}
+	if !ptr.Equal(enabled.SecurityContext.RunAsUser, ptr.To(int64(999))) {
+		t.Errorf("expected security context to be preserved, got: %v", enabled.SecurityContext)
+	}
+	if !hasVolumeMount(enabled.VolumeMounts, TmpScratchVolume) || !hasVolumeMount(enabled.VolumeMounts, RunScratchVolume) {
+		t.Errorf("expected scratch mounts when enabled, got: %v", enabled.VolumeMounts)
+	}
+	if securityContext.ReadOnlyRootFilesystem != nil {
+		t.Error("expected original security context not to be modified")
+	}
+	if extended := volumeMounts[:2]; extended[1].Name != "" {
+		t.Errorf("expected original volume mounts not to be modified, got: %v", extended)
+	}
+}
+
+func TestWithScratchVolumes(t *testing.T) {
+	volumes := make([]corev1.Volume, 1, 4)
+	volumes[0] = corev1.Volume{
+		Name: "config",
+	}
+
+	got := withScratchVolumes(volumes, corev1.Container{
+		VolumeMounts: []corev1.VolumeMount{
+			tmpScratchVolumeMount(),
+		},
+	})
+	if len(got) != 2 || findVolume(got, TmpScratchVolume) == nil {
+		t.Errorf("expected tmp scratch volume, got: %v", got)
+	}
+	if findVolume(got, RunScratchVolume) != nil {
+		t.Error("expected no run scratch volume when it is not mounted")
+	}
+	if extended := volumes[:2]; extended[1].Name != "" {
+		t.Errorf("expected original volumes not to be modified, got: %v", extended)
+	}
+
+	if got := withScratchVolumes(volumes, corev1.Container{}); len(got) != 1 {
+		t.Errorf("expected no scratch volumes when they are not mounted, got: %v", got)
+	}
+}
+
+func TestReadOnlyRootFilesystemDefault(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := mariadbv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	builder := NewBuilder(scheme, &environment.Environment{
+		MariadbOperatorImage: "mariadb-operator:test",
+	})
+	newMariaDB := func(securityContext *corev1.SecurityContext) *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb",
+				Namespace: "default",
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				ContainerTemplate: mariadbv1alpha1.ContainerTemplate{
+					SecurityContext: securityContext,
+				},
+				Image:    "mariadb:11.0.3",
+				Port:     3306,
+				Replicas: 1,
+				Metrics: &mariadbv1alpha1.Metrics{
+					Enabled: true,
+					Exporter: mariadbv1alpha1.Exporter{
+						Image: "prom/mysqld-exporter:v0.15.1",
+						Port:  9104,
+					},
+				},
+			},
+		}
+	}
+	key := types.NamespacedName{
+		Name:      "mariadb",
+		Namespace: "default",
+	}
+
+	tests := []struct {
+		name        string
+		mariadb     *mariadbv1alpha1.MariaDB
+		wantScratch bool
+	}{
+		{
+			name:        "default",
+			mariadb:     newMariaDB(nil),
+			wantScratch: true,
+		},
+		{
+			name: "disabled",
+			mariadb: newMariaDB(&corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+			}),
+			wantScratch: false,
+		},
+		{
+			name: "enabled",
+			mariadb: newMariaDB(&corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			}),
+			wantScratch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts, err := builder.BuildStatefulSet(tt.mariadb, key)
+			if err != nil {
+				t.Fatalf("unexpected error building StatefulSet: %v", err)
+			}
+			stsVolumes := sts.Spec.Template.Spec.Volumes
+			if hasScratch := findVolume(stsVolumes, TmpScratchVolume) != nil; hasScratch != tt.wantScratch {
+				t.Errorf("unexpected StatefulSet tmp scratch volume, expected: %v got: %v", tt.wantScratch, hasScratch)
+			}
+			if hasScratch := findVolume(stsVolumes, RunScratchVolume) != nil; hasScratch != tt.wantScratch {
+				t.Errorf("unexpected StatefulSet run scratch volume, expected: %v got: %v", tt.wantScratch, hasScratch)
+			}
+
+			deploy, err := builder.BuildExporterDeployment(tt.mariadb, key)
+			if err != nil {
+				t.Fatalf("unexpected error building exporter Deployment: %v", err)
+			}
+			podSpec := deploy.Spec.Template.Spec
+			if hasScratch := findVolume(podSpec.Volumes, TmpScratchVolume) != nil; hasScratch != tt.wantScratch {
+				t.Errorf("unexpected exporter scratch volume, expected: %v got: %v", tt.wantScratch, hasScratch)
+			}
+			if len(podSpec.Containers) != 1 {
+				t.Fatalf("expected a single exporter container, got: %d", len(podSpec.Containers))
+			}
+			if hasMount := hasVolumeMount(podSpec.Containers[0].VolumeMounts, TmpScratchVolume); hasMount != tt.wantScratch {
+				t.Errorf("unexpected exporter scratch mount, expected: %v got: %v", tt.wantScratch, hasMount)
+			}
+		})
+	}
+}
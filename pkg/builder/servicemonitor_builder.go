@@ -57,8 +57,8 @@ func serviceMonitorObjectMeta(mariadb *mariadbv1alpha1.MariaDB, key types.Namesp
 }
 
 func serviceMonitorEndpoints(mariadb *mariadbv1alpha1.MariaDB) []monitoringv1.Endpoint {
-	endpoints := make([]monitoringv1.Endpoint, mariadb.Spec.Replicas)
-	for i := 0; i < int(mariadb.Spec.Replicas); i++ {
+	endpoints := make([]monitoringv1.Endpoint, mariadb.ScaledReplicas())
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
 		podName := statefulset.PodName(mariadb.ObjectMeta, i)
 		podFQDN := statefulset.PodFQDNWithService(mariadb.ObjectMeta, i, mariadb.InternalServiceKey().Name)
 		endpoints[i] = monitoringv1.Endpoint{
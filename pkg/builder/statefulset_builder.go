@@ -11,8 +11,10 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -24,14 +26,90 @@ const (
 	ConfigMountPath         = "/etc/mysql/conf.d"
 	ServiceAccountVolume    = "serviceaccount"
 	ServiceAccountMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+	ProbeAccountVolume      = "probe-account"
+	ProbeAccountMountPath   = "/etc/mariadb/probe-account"
+	GaleraSegmentVolume     = "galera-segment"
+	GaleraSegmentMountPath  = "/etc/galera-segment"
+	GaleraSegmentFile       = "segment"
 
 	MariaDbContainerName = "mariadb"
 	MariaDbPortName      = "mariadb"
 
-	InitContainerName  = "init"
-	AgentContainerName = "agent"
+	InitContainerName           = "init"
+	GaleraSegmentContainerName  = "galera-segment"
+	AgentContainerName          = "agent"
+	BinlogArchiverContainerName = "binlog-archiver"
+	WsrepNotifyContainerName    = "wsrep-notify"
+
+	podNameEnv       = "POD_NAME"
+	galeraSegmentEnv = "GALERA_SEGMENT"
+
+	// mariadbUser is the UID of the mysql user of the MariaDB image, which owns the data directory.
+	mariadbUser int64 = 999
+)
+
+const (
+	ExternalReplicationPKIVolume    = "external-replication-pki"
+	ExternalReplicationPKIMountPath = "/etc/pki/external-replication"
+	ExternalReplicationCAFile       = "ca.crt"
+
+	ReplicationChannelsPKIVolume    = "replication-channels-pki"
+	ReplicationChannelsPKIMountPath = "/etc/pki/replication-channels"
+
+	ServerTLSVolume    = "server-tls"
+	ServerTLSMountPath = "/etc/pki/mariadb"
+	ServerTLSCertFile  = "tls.crt"
+	ServerTLSKeyFile   = "tls.key"
+	ServerTLSCAFile    = "ca.crt"
 )
 
+// buildServerTLSVolume projects the server certificate and the CA that issued it, which may be stored in a different Secret.
+func buildServerTLSVolume(mariadb *mariadbv1alpha1.MariaDB) corev1.Volume {
+	caRef := mariadb.TLSCASecretKeyRef()
+	return corev1.Volume{
+		Name: ServerTLSVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						Secret: &corev1.SecretProjection{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: mariadb.TLSKey().Name,
+							},
+							Items: []corev1.KeyToPath{
+								{
+									Key:  ServerTLSCertFile,
+									Path: ServerTLSCertFile,
+								},
+								{
+									Key:  ServerTLSKeyFile,
+									Path: ServerTLSKeyFile,
+								},
+							},
+						},
+					},
+					{
+						Secret: &corev1.SecretProjection{
+							LocalObjectReference: caRef.LocalObjectReference,
+							Items: []corev1.KeyToPath{
+								{
+									Key:  caRef.Key,
+									Path: ServerTLSCAFile,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ReplicationChannelCAPath returns the path of the CA bundle of a replication channel in the MariaDB container.
+func ReplicationChannelCAPath(channel string) string {
+	return fmt.Sprintf("%s/%s/%s", ReplicationChannelsPKIMountPath, channel, ExternalReplicationCAFile)
+}
+
 func PVCKey(mariadb *mariadbv1alpha1.MariaDB) types.NamespacedName {
 	podName := statefulset.PodName(mariadb.ObjectMeta, 0)
 	if mariadb.Replication().Enabled {
@@ -58,11 +136,16 @@ func (b *Builder) BuildStatefulSet(mariadb *mariadbv1alpha1.MariaDB, key types.N
 		return nil, fmt.Errorf("error building pod template: %v", err)
 	}
 
+	replicas := mariadb.ScaledReplicas()
+	if mariadb.IsHibernated() {
+		replicas = 0
+	}
+
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: objMeta,
 		Spec: appsv1.StatefulSetSpec{
 			ServiceName:         mariadb.InternalServiceKey().Name,
-			Replicas:            &mariadb.Spec.Replicas,
+			Replicas:            &replicas,
 			PodManagementPolicy: buildStsPodManagementPolicy(mariadb),
 			UpdateStrategy:      buildStsUpdateStrategy(mariadb),
 			Selector: &metav1.LabelSelector{
@@ -83,6 +166,7 @@ func (b *Builder) buildStsPodTemplate(mariadb *mariadbv1alpha1.MariaDB, labels m
 	if err != nil {
 		return nil, fmt.Errorf("error building MariaDB containers: %v", err)
 	}
+	initContainers := buildStsInitContainers(mariadb)
 	objMeta :=
 		metadata.NewMetadataBuilder(client.ObjectKeyFromObject(mariadb)).
 			WithMariaDB(mariadb).
@@ -96,10 +180,10 @@ func (b *Builder) buildStsPodTemplate(mariadb *mariadbv1alpha1.MariaDB, labels m
 		Spec: corev1.PodSpec{
 			AutomountServiceAccountToken: automount,
 			ServiceAccountName:           serviceAccount,
-			InitContainers:               buildStsInitContainers(mariadb),
+			InitContainers:               initContainers,
 			Containers:                   containers,
 			ImagePullSecrets:             mariadb.Spec.ImagePullSecrets,
-			Volumes:                      buildStsVolumes(mariadb),
+			Volumes:                      withScratchVolumes(buildStsVolumes(mariadb), append(initContainers, containers...)...),
 			SecurityContext:              mariadb.Spec.PodSecurityContext,
 			Affinity:                     mariadb.Spec.Affinity,
 			NodeSelector:                 mariadb.Spec.NodeSelector,
@@ -125,31 +209,33 @@ func buildStsUpdateStrategy(mariadb *mariadbv1alpha1.MariaDB) appsv1.StatefulSet
 }
 
 func buildStsVolumeClaimTemplates(mariadb *mariadbv1alpha1.MariaDB) []corev1.PersistentVolumeClaim {
-	vctpl := mariadb.Spec.VolumeClaimTemplate
-	pvcs := []corev1.PersistentVolumeClaim{
-		{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        StorageVolume,
-				Labels:      vctpl.Labels,
-				Annotations: vctpl.Annotations,
-			},
-			Spec: vctpl.PersistentVolumeClaimSpec,
-		},
+	var pvcs []corev1.PersistentVolumeClaim
+	if !mariadb.Spec.Ephemeral {
+		vctpl := mariadb.Spec.VolumeClaimTemplate
+		pvcs = append(pvcs, corev1.PersistentVolumeClaim{
+			ObjectMeta: volumeClaimTemplateObjectMeta(mariadb, StorageVolume, &vctpl),
+			Spec:       vctpl.PersistentVolumeClaimSpec,
+		})
 	}
 	if mariadb.Galera().Enabled {
 		vctpl := *mariadb.Galera().VolumeClaimTemplate
 		pvcs = append(pvcs, corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        galeraresources.GaleraConfigVolume,
-				Labels:      vctpl.Labels,
-				Annotations: vctpl.Annotations,
-			},
-			Spec: vctpl.PersistentVolumeClaimSpec,
+			ObjectMeta: volumeClaimTemplateObjectMeta(mariadb, galeraresources.GaleraConfigVolume, &vctpl),
+			Spec:       vctpl.PersistentVolumeClaimSpec,
 		})
 	}
 	return pvcs
 }
 
+func volumeClaimTemplateObjectMeta(mariadb *mariadbv1alpha1.MariaDB, name string,
+	vctpl *mariadbv1alpha1.VolumeClaimTemplate) metav1.ObjectMeta {
+	return metadata.NewMetadataBuilder(types.NamespacedName{Name: name}).
+		WithMariaDB(mariadb).
+		WithLabels(vctpl.Labels).
+		WithAnnotations(vctpl.Annotations).
+		Build()
+}
+
 func buildStsServiceAccountName(mariadb *mariadbv1alpha1.MariaDB) (autoMount *bool, serviceAccount string) {
 	if mariadb.Galera().Enabled {
 		mount := false
@@ -187,6 +273,19 @@ func buildStsVolumes(mariadb *mariadbv1alpha1.MariaDB) []corev1.Volume {
 	volumes := []corev1.Volume{
 		configVolume,
 	}
+	if mariadb.Spec.Ephemeral {
+		volumes = append(volumes, corev1.Volume{
+			Name: StorageVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: ephemeralStorageSizeLimit(mariadb),
+				},
+			},
+		})
+	}
+	if mariadb.IsProbeAccountReady() {
+		volumes = append(volumes, buildProbeAccountVolume(mariadb))
+	}
 	if mariadb.Galera().Enabled {
 		volumes = append(volumes, corev1.Volume{
 			Name: ServiceAccountVolume,
@@ -227,6 +326,50 @@ func buildStsVolumes(mariadb *mariadbv1alpha1.MariaDB) []corev1.Volume {
 				},
 			},
 		})
+		if mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+			volumes = append(volumes, corev1.Volume{
+				Name: galeraresources.WsrepNotifyVolume,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: mariadb.WsrepNotifyKey().Name,
+						},
+						DefaultMode: &galeraresources.WsrepNotifyScriptMode,
+					},
+				},
+			})
+		}
+	}
+	if mariadb.IsGaleraSegmentEnabled() {
+		volumes = append(volumes, buildGaleraSegmentVolume())
+	}
+	if mariadb.IsTLSEnabled() {
+		volumes = append(volumes, buildServerTLSVolume(mariadb))
+	}
+	if mariadb.IsReplicatingFromExternal() {
+		if tls := mariadb.Replication().External.TLS; tls != nil && tls.Enabled && tls.CASecretKeyRef != nil {
+			volumes = append(volumes, corev1.Volume{
+				Name: ExternalReplicationPKIVolume,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: tls.CASecretKeyRef.Name,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  tls.CASecretKeyRef.Key,
+								Path: ExternalReplicationCAFile,
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+	if volume := buildReplicationChannelsPKIVolume(mariadb); volume != nil {
+		volumes = append(volumes, *volume)
+	}
+	if mariadb.Spec.BinlogArchive != nil {
+		pkiVolumes, _ := jobS3PKIVolume(batchS3PKI, &mariadb.Spec.BinlogArchive.S3)
+		volumes = append(volumes, pkiVolumes...)
 	}
 	if mariadb.Spec.Volumes != nil {
 		volumes = append(volumes, mariadb.Spec.Volumes...)
@@ -234,6 +377,13 @@ func buildStsVolumes(mariadb *mariadbv1alpha1.MariaDB) []corev1.Volume {
 	return volumes
 }
 
+func ephemeralStorageSizeLimit(mariadb *mariadbv1alpha1.MariaDB) *resource.Quantity {
+	if storage, ok := mariadb.Spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage]; ok {
+		return &storage
+	}
+	return nil
+}
+
 func buildHAAnnotations(mariadb *mariadbv1alpha1.MariaDB) map[string]string {
 	var annotations map[string]string
 	if mariadb.IsHAEnabled() {
@@ -249,3 +399,81 @@ func buildHAAnnotations(mariadb *mariadbv1alpha1.MariaDB) map[string]string {
 	}
 	return annotations
 }
+
+// buildReplicationChannelsPKIVolume projects the CA bundles of the replication channels into a single volume,
+// with a directory per channel.
+func buildReplicationChannelsPKIVolume(mariadb *mariadbv1alpha1.MariaDB) *corev1.Volume {
+	var sources []corev1.VolumeProjection
+	for _, channel := range mariadb.Spec.ReplicationChannels {
+		if !channel.IsTLSEnabled() || channel.TLS.CASecretKeyRef == nil {
+			continue
+		}
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: channel.TLS.CASecretKeyRef.LocalObjectReference,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  channel.TLS.CASecretKeyRef.Key,
+						Path: fmt.Sprintf("%s/%s", channel.Name, ExternalReplicationCAFile),
+					},
+				},
+			},
+		})
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return &corev1.Volume{
+		Name: ReplicationChannelsPKIVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	}
+}
+
+// buildProbeAccountVolume mounts the current and the previous password of the probe account, so the probes keep working
+// while a password rotation is propagated to the Pods.
+func buildProbeAccountVolume(mariadb *mariadbv1alpha1.MariaDB) corev1.Volume {
+	account := mariadb.Spec.ProbeAccount
+	return corev1.Volume{
+		Name: ProbeAccountVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: account.PasswordSecretKeyRef.Name,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  account.PasswordSecretKeyRef.Key,
+						Path: probeAccountPasswordFile,
+					},
+					{
+						Key:  account.PreviousPasswordSecretKey(),
+						Path: probeAccountPreviousPasswordFile,
+					},
+				},
+				Optional: ptr.To(true),
+			},
+		},
+	}
+}
+
+// buildGaleraSegmentVolume projects the segment annotation, which is patched by the operator once the Pod is scheduled.
+// Unlike environment variables, the projected file is updated after the Pod has started.
+func buildGaleraSegmentVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: GaleraSegmentVolume,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{
+					{
+						Path: GaleraSegmentFile,
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: fmt.Sprintf("metadata.annotations['%s']", annotation.GaleraSegmentAnnotation),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,250 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	galeraresources "github.com/mariadb-operator/mariadb-operator/pkg/controller/galera/resources"
+	annotation "github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGaleraSegment(t *testing.T) {
+	tests := []struct {
+		name        string
+		mariadb     *mariadbv1alpha1.MariaDB
+		wantSegment bool
+	}{
+		{
+			name: "no Galera",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb",
+				},
+			},
+			wantSegment: false,
+		},
+		{
+			name: "Galera without segments",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-galera",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+					},
+				},
+			},
+			wantSegment: false,
+		},
+		{
+			name: "Galera with segments",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-galera",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Image: "mariadb:11.0.3",
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+						GaleraSpec: mariadbv1alpha1.GaleraSpec{
+							Segment: &mariadbv1alpha1.GaleraSegment{
+								Enabled: true,
+								Segments: map[string]int32{
+									"eu-west-1a": 1,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantSegment: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initContainers := buildStsInitContainers(tt.mariadb)
+			hasInitContainer := len(initContainers) > 0 && initContainers[0].Name == GaleraSegmentContainerName
+			if hasInitContainer != tt.wantSegment {
+				t.Fatalf("unexpected segment init container, expected: %v got: %v", tt.wantSegment, hasInitContainer)
+			}
+			if hasInitContainer {
+				initContainer := initContainers[0]
+				if initContainer.Image != tt.mariadb.Spec.Image {
+					t.Errorf("unexpected segment init container image, expected: %s got: %s", tt.mariadb.Spec.Image, initContainer.Image)
+				}
+				if !hasVolumeMount(initContainer.VolumeMounts, GaleraSegmentVolume) {
+					t.Errorf("expected segment init container to mount the '%s' volume", GaleraSegmentVolume)
+				}
+			}
+
+			volume := findVolume(buildStsVolumes(tt.mariadb), GaleraSegmentVolume)
+			if (volume != nil) != tt.wantSegment {
+				t.Fatalf("unexpected segment volume, expected: %v got: %v", tt.wantSegment, volume != nil)
+			}
+			if volume != nil {
+				wantFieldPath := fmt.Sprintf("metadata.annotations['%s']", annotation.GaleraSegmentAnnotation)
+				items := volume.DownwardAPI.Items
+				if len(items) != 1 || items[0].Path != GaleraSegmentFile || items[0].FieldRef.FieldPath != wantFieldPath {
+					t.Errorf("unexpected segment volume items: %v", items)
+				}
+			}
+
+			hasEnv := findEnv(buildStsEnv(tt.mariadb), galeraSegmentEnv) != nil
+			if hasEnv != tt.wantSegment {
+				t.Errorf("unexpected segment env, expected: %v got: %v", tt.wantSegment, hasEnv)
+			}
+			hasProviderOption := strings.Contains(buildGaleraProviderOptions(tt.mariadb),
+				fmt.Sprintf("gmcast.segment=$(%s)", galeraSegmentEnv))
+			if hasProviderOption != tt.wantSegment {
+				t.Errorf("unexpected segment provider option, expected: %v got: %v", tt.wantSegment, hasProviderOption)
+			}
+		})
+	}
+}
+
+func TestWaitForFileCmds(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	file := filepath.Join(t.TempDir(), GaleraSegmentFile)
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatalf("unexpected error writing empty file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "bash", "-c", strings.Join(waitForFileCmds(file), ";"))
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("unexpected error starting command: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected command to wait for the file to be written, got: %v", err)
+	case <-time.After(1500 * time.Millisecond):
+	}
+
+	if err := os.WriteFile(file, []byte("1"), 0644); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error waiting for file: %v", err)
+	}
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, name string) bool {
+	for _, m := range mounts {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func findVolume(volumes []corev1.Volume, name string) *corev1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == name {
+			return &volumes[i]
+		}
+	}
+	return nil
+}
+
+func findEnv(env []corev1.EnvVar, name string) *corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == name {
+			return &env[i]
+		}
+	}
+	return nil
+}
+
+func TestBuildStsVolumeClaimTemplatesInheritMetadata(t *testing.T) {
+	inheritMetadata := &mariadbv1alpha1.InheritMetadata{
+		Labels: map[string]string{
+			"database.myorg.io": "mariadb",
+		},
+		Annotations: map[string]string{
+			"database.myorg.io": "mariadb",
+		},
+	}
+	volumeClaimTemplate := mariadbv1alpha1.VolumeClaimTemplate{
+		Labels: map[string]string{
+			"storage.myorg.io": "ssd",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		mariadb   *mariadbv1alpha1.MariaDB
+		wantNames []string
+	}{
+		{
+			name: "standalone",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					InheritMetadata:     inheritMetadata,
+					VolumeClaimTemplate: volumeClaimTemplate,
+				},
+			},
+			wantNames: []string{StorageVolume},
+		},
+		{
+			name: "Galera",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mariadb-galera",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					InheritMetadata:     inheritMetadata,
+					VolumeClaimTemplate: volumeClaimTemplate,
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+					},
+				},
+			},
+			wantNames: []string{StorageVolume, galeraresources.GaleraConfigVolume},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvcs := buildStsVolumeClaimTemplates(tt.mariadb)
+			if len(pvcs) != len(tt.wantNames) {
+				t.Fatalf("expected %d volume claim templates, got: %d", len(tt.wantNames), len(pvcs))
+			}
+			for i, pvc := range pvcs {
+				if pvc.Name != tt.wantNames[i] {
+					t.Errorf("unexpected volume claim template name, expected: %s got: %s", tt.wantNames[i], pvc.Name)
+				}
+				if pvc.Labels["database.myorg.io"] != "mariadb" {
+					t.Errorf("expected volume claim template '%s' to inherit labels, got: %v", pvc.Name, pvc.Labels)
+				}
+				if pvc.Annotations["database.myorg.io"] != "mariadb" {
+					t.Errorf("expected volume claim template '%s' to inherit annotations, got: %v", pvc.Name, pvc.Annotations)
+				}
+			}
+			if pvcs[0].Labels["storage.myorg.io"] != "ssd" {
+				t.Errorf("expected storage volume claim template to keep its labels, got: %v", pvcs[0].Labels)
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@ package builder
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/command"
 	galeraresources "github.com/mariadb-operator/mariadb-operator/pkg/controller/galera/resources"
+	annotation "github.com/mariadb-operator/mariadb-operator/pkg/metadata"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -20,12 +25,24 @@ func (b *Builder) buildStsContainers(mariadb *mariadbv1alpha1.MariaDB) ([]corev1
 	mariadbContainer.VolumeMounts = buildStsVolumeMounts(mariadb)
 	mariadbContainer.LivenessProbe = buildStsLivenessProbe(mariadb)
 	mariadbContainer.ReadinessProbe = buildStsReadinessProbe(mariadb)
+	buildReadOnlyRootFilesystem(&mariadbContainer, isReadOnlyRootFilesystem(mariadbContainer.SecurityContext, nil),
+		tmpScratchVolumeMount(), runScratchVolumeMount())
 
 	var containers []corev1.Container
 	containers = append(containers, mariadbContainer)
 
 	if mariadb.Galera().Enabled {
 		containers = append(containers, b.buildGaleraAgentContainer(mariadb))
+		if mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+			containers = append(containers, b.buildWsrepNotifyContainer(mariadb))
+		}
+	}
+	if mariadb.Spec.BinlogArchive != nil {
+		binlogArchiverContainer, err := b.buildBinlogArchiverContainer(mariadb)
+		if err != nil {
+			return nil, fmt.Errorf("error building binlog archiver container: %v", err)
+		}
+		containers = append(containers, *binlogArchiverContainer)
 	}
 	if mariadb.Spec.SidecarContainers != nil {
 		for index, container := range mariadb.Spec.SidecarContainers {
@@ -96,12 +113,110 @@ func (b *Builder) buildGaleraAgentContainer(mariadb *mariadbv1alpha1.MariaDB) co
 			RunAsUser: &runAsUser,
 		}
 	}()
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(container.SecurityContext, mariadb.Spec.SecurityContext),
+		tmpScratchVolumeMount())
 
 	return container
 }
 
+// buildWsrepNotifyContainer builds a sidecar that publishes the Galera node state written by wsrep_notify_cmd
+// in the Pod annotations.
+func (b *Builder) buildWsrepNotifyContainer(mariadb *mariadbv1alpha1.MariaDB) corev1.Container {
+	runAsUser := mariadbUser
+	runAsNonRoot := true
+
+	container := corev1.Container{
+		Name:            WsrepNotifyContainerName,
+		Image:           b.env.MariadbOperatorImage,
+		ImagePullPolicy: mariadb.Spec.ImagePullPolicy,
+		Args: []string{
+			"wsrep-notify",
+			fmt.Sprintf("--state-file=%s", galeraresources.WsrepNotifyStateFile),
+			fmt.Sprintf("--pod-name=$(%s)", podNameEnv),
+			fmt.Sprintf("--pod-namespace=%s", mariadb.Namespace),
+		},
+		Env: []corev1.EnvVar{
+			podNameEnvVar(),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      StorageVolume,
+				MountPath: StorageMountPath,
+			},
+			{
+				Name:      ServiceAccountVolume,
+				MountPath: ServiceAccountMountPath,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:    &runAsUser,
+			RunAsNonRoot: &runAsNonRoot,
+		},
+	}
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(container.SecurityContext, mariadb.Spec.SecurityContext),
+		tmpScratchVolumeMount())
+	return container
+}
+
+func (b *Builder) buildBinlogArchiverContainer(mariadb *mariadbv1alpha1.MariaDB) (*corev1.Container, error) {
+	archive := mariadb.Spec.BinlogArchive
+	cmdOpts := []command.BackupOpt{
+		command.WithBackup(
+			StorageMountPath,
+			fmt.Sprintf("%s/0-binlog-archive-target.txt", StorageMountPath),
+		),
+		command.WithBackupUserEnv(batchUserEnv),
+		command.WithBackupPasswordEnv(batchPasswordEnv),
+		command.WithBackupLogLevel("info"),
+		command.WithBinlogArchive(
+			fmt.Sprintf("%s-bin.index", mariadb.Name),
+			fmt.Sprintf("$(%s)", podNameEnv),
+			archive.IntervalOrDefault(),
+			archive.FlushIntervalOrDefault(),
+		),
+	}
+	cmdOpts = append(cmdOpts, s3Opts(&archive.S3)...)
+
+	backupCmd, err := command.NewBackupCommand(cmdOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error building binlog archive command: %v", err)
+	}
+	cmd := backupCmd.MariadbOperatorBinlogArchive(mariadb)
+	_, pkiVolumeMounts := jobS3PKIVolume(batchS3PKI, &archive.S3)
+	env := append([]corev1.EnvVar{podNameEnvVar()}, jobEnv(mariadb)...)
+	env = append(env, jobS3Env(&archive.S3)...)
+	runAsUser := mariadbUser
+	runAsNonRoot := true
+
+	container := &corev1.Container{
+		Name:            BinlogArchiverContainerName,
+		Image:           b.env.MariadbOperatorImage,
+		ImagePullPolicy: mariadb.Spec.ImagePullPolicy,
+		Command:         cmd.Command,
+		Args:            cmd.Args,
+		Env:             env,
+		VolumeMounts: append([]corev1.VolumeMount{
+			{
+				Name:      StorageVolume,
+				MountPath: StorageMountPath,
+			},
+		}, pkiVolumeMounts...),
+		// The binary logs are owned by the mysql user of the MariaDB image, which is enough to read them.
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:    &runAsUser,
+			RunAsNonRoot: &runAsNonRoot,
+		},
+	}
+	buildReadOnlyRootFilesystem(container, isReadOnlyRootFilesystem(container.SecurityContext, mariadb.Spec.SecurityContext),
+		tmpScratchVolumeMount())
+	return container, nil
+}
+
 func buildStsInitContainers(mariadb *mariadbv1alpha1.MariaDB) []corev1.Container {
 	initContainers := []corev1.Container{}
+	if mariadb.IsGaleraSegmentEnabled() {
+		initContainers = append(initContainers, buildGaleraSegmentInitContainer(mariadb))
+	}
 	if mariadb.Spec.InitContainers != nil {
 		for index, container := range mariadb.Spec.InitContainers {
 			initContainer := buildContainer(container.Image, container.ImagePullPolicy, &container.ContainerTemplate)
@@ -141,18 +256,118 @@ func buildGaleraInitContainer(mariadb *mariadbv1alpha1.MariaDB) corev1.Container
 	}()
 	container.Env = buildStsEnv(mariadb)
 	container.VolumeMounts = buildStsVolumeMounts(mariadb)
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(container.SecurityContext, mariadb.Spec.SecurityContext),
+		tmpScratchVolumeMount())
 
 	return container
 }
 
+// buildGaleraSegmentInitContainer blocks the Pod startup until the segment annotation has been set by the operator.
+// The GALERA_SEGMENT environment variable is resolved when the MariaDB container is created, which happens after the init containers complete.
+func buildGaleraSegmentInitContainer(mariadb *mariadbv1alpha1.MariaDB) corev1.Container {
+	cmd := command.NewBashCommand(waitForFileCmds(fmt.Sprintf("%s/%s", GaleraSegmentMountPath, GaleraSegmentFile)))
+	container := corev1.Container{
+		Name:            GaleraSegmentContainerName,
+		Image:           mariadb.Spec.Image,
+		ImagePullPolicy: mariadb.Spec.ImagePullPolicy,
+		Command:         cmd.Command,
+		Args:            cmd.Args,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      GaleraSegmentVolume,
+				MountPath: GaleraSegmentMountPath,
+			},
+		},
+	}
+	buildReadOnlyRootFilesystem(&container, isReadOnlyRootFilesystem(container.SecurityContext, mariadb.Spec.SecurityContext),
+		tmpScratchVolumeMount())
+	return container
+}
+
+func waitForFileCmds(file string) []string {
+	return []string{
+		fmt.Sprintf("until [ -s %s ]", file),
+		fmt.Sprintf("do echo 'Waiting for %s to be written'", file),
+		"sleep 1",
+		"done",
+	}
+}
+
 func buildStsArgs(mariadb *mariadbv1alpha1.MariaDB) []string {
-	if mariadb.Replication().Enabled {
-		return []string{
+	var args []string
+	if mariadb.Replication().Enabled || mariadb.Spec.BinlogArchive != nil {
+		args = append(args, []string{
 			"--log-bin",
 			fmt.Sprintf("--log-basename=%s", mariadb.Name),
-		}
+		}...)
+	}
+	// The events replicated by the primary from outside of the cluster must be written into its binary log to reach the replicas.
+	if mariadb.Replication().Enabled && (mariadb.IsReplicatingFromExternal() || len(mariadb.Spec.ReplicationChannels) > 0) {
+		args = append(args, "--log-slave-updates")
+	}
+	if mariadb.IsReplicatingFromExternal() {
+		args = append(args, fmt.Sprintf("--gtid-domain-id=%d", mariadb.Replication().External.GtidDomainIdOrDefault()))
+	}
+	if mariadb.IsTLSEnabled() {
+		args = append(args,
+			fmt.Sprintf("--ssl-cert=%s/%s", ServerTLSMountPath, ServerTLSCertFile),
+			fmt.Sprintf("--ssl-key=%s/%s", ServerTLSMountPath, ServerTLSKeyFile),
+			fmt.Sprintf("--ssl-ca=%s/%s", ServerTLSMountPath, ServerTLSCAFile),
+		)
+	}
+	if opts := buildGaleraProviderOptions(mariadb); opts != "" {
+		args = append(args, fmt.Sprintf("--wsrep_provider_options=%s", opts))
+	}
+	if mariadb.Galera().Enabled && mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+		args = append(args,
+			fmt.Sprintf("--wsrep_notify_cmd=%s/%s", galeraresources.WsrepNotifyMountPath, galeraresources.WsrepNotifyScriptKey),
+		)
+	}
+	return args
+}
+
+// galeraTLSProviderOptions encrypts the Galera replication traffic with the server certificate.
+func galeraTLSProviderOptions() map[string]string {
+	return map[string]string{
+		"socket.ssl":      "yes",
+		"socket.ssl_cert": fmt.Sprintf("%s/%s", ServerTLSMountPath, ServerTLSCertFile),
+		"socket.ssl_key":  fmt.Sprintf("%s/%s", ServerTLSMountPath, ServerTLSKeyFile),
+		"socket.ssl_ca":   fmt.Sprintf("%s/%s", ServerTLSMountPath, ServerTLSCAFile),
 	}
-	return nil
+}
+
+// buildGaleraProviderOptions formats the provider options as 'key=value;key=value', sorted by key, to be merged into wsrep_provider_options.
+// The segment is resolved from the Pod annotations when the container starts, as it depends on the Node where the Pod is scheduled.
+func buildGaleraProviderOptions(mariadb *mariadbv1alpha1.MariaDB) string {
+	if !mariadb.Galera().Enabled {
+		return ""
+	}
+	opts := make(map[string]string)
+	maps.Copy(opts, mariadb.Galera().ProviderOptions)
+	if mariadb.Galera().GCache != nil {
+		maps.Copy(opts, mariadb.Galera().GCache.ProviderOptions())
+	}
+	if mariadb.IsGaleraSegmentEnabled() {
+		opts["gmcast.segment"] = fmt.Sprintf("$(%s)", galeraSegmentEnv)
+	}
+	if mariadb.IsGaleraTLSEnabled() {
+		maps.Copy(opts, galeraTLSProviderOptions())
+	}
+	return formatProviderOptions(opts)
+}
+
+func formatProviderOptions(opts map[string]string) string {
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kv := make([]string, 0, len(keys))
+	for _, k := range keys {
+		kv = append(kv, fmt.Sprintf("%s=%s", k, opts[k]))
+	}
+	return strings.Join(kv, ";")
 }
 
 func buildStsEnv(mariadb *mariadbv1alpha1.MariaDB) []corev1.EnvVar {
@@ -183,14 +398,18 @@ func buildStsEnv(mariadb *mariadbv1alpha1.MariaDB) []corev1.EnvVar {
 			Name:  "CLUSTER_NAME",
 			Value: clusterName,
 		},
-		{
-			Name: "POD_NAME",
+		podNameEnvVar(),
+	}
+
+	if mariadb.IsGaleraSegmentEnabled() {
+		env = append(env, corev1.EnvVar{
+			Name: galeraSegmentEnv,
 			ValueFrom: &corev1.EnvVarSource{
 				FieldRef: &corev1.ObjectFieldSelector{
-					FieldPath: "metadata.name",
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", annotation.GaleraSegmentAnnotation),
 				},
 			},
-		},
+		})
 	}
 
 	if !mariadb.Replication().Enabled {
@@ -223,6 +442,17 @@ func buildStsEnv(mariadb *mariadbv1alpha1.MariaDB) []corev1.EnvVar {
 	return env
 }
 
+func podNameEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: podNameEnv,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	}
+}
+
 func buildStsVolumeMounts(mariadb *mariadbv1alpha1.MariaDB) []corev1.VolumeMount {
 	volumeMounts := []corev1.VolumeMount{
 		{
@@ -245,6 +475,42 @@ func buildStsVolumeMounts(mariadb *mariadbv1alpha1.MariaDB) []corev1.VolumeMount
 				MountPath: ServiceAccountMountPath,
 			},
 		}...)
+		if mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      galeraresources.WsrepNotifyVolume,
+				MountPath: galeraresources.WsrepNotifyMountPath,
+			})
+		}
+	}
+	if mariadb.IsProbeAccountReady() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ProbeAccountVolume,
+			MountPath: ProbeAccountMountPath,
+			ReadOnly:  true,
+		})
+	}
+	if mariadb.IsTLSEnabled() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ServerTLSVolume,
+			MountPath: ServerTLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+	if mariadb.IsReplicatingFromExternal() {
+		if tls := mariadb.Replication().External.TLS; tls != nil && tls.Enabled && tls.CASecretKeyRef != nil {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      ExternalReplicationPKIVolume,
+				MountPath: ExternalReplicationPKIMountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+	if buildReplicationChannelsPKIVolume(mariadb) != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ReplicationChannelsPKIVolume,
+			MountPath: ReplicationChannelsPKIMountPath,
+			ReadOnly:  true,
+		})
 	}
 	if mariadb.Spec.VolumeMounts != nil {
 		volumeMounts = append(volumeMounts, mariadb.Spec.VolumeMounts...)
@@ -308,14 +574,41 @@ func buildStsProbe(mariadb *mariadbv1alpha1.MariaDB, probe *corev1.Probe) *corev
 			galerProbe.SuccessThreshold = p.SuccessThreshold
 			galerProbe.FailureThreshold = p.FailureThreshold
 		}
+		if mariadb.IsProbeAccountReady() {
+			galerProbe.ProbeHandler = probeAccountHandler(mariadb, "SHOW STATUS LIKE 'wsrep_ready'", "grep -c ON")
+		}
 		return &galerProbe
 	}
 	if probe != nil {
 		return probe
 	}
+	if mariadb.IsProbeAccountReady() {
+		accountProbe := defaultStsProbe
+		accountProbe.ProbeHandler = probeAccountHandler(mariadb, "SELECT 1;", "")
+		return &accountProbe
+	}
 	return &defaultStsProbe
 }
 
+// probeAccountHandler runs a query with the probe account, optionally piping its output to a filter. Both the current and the previous
+// passwords are tried, as the Secret volume is refreshed asynchronously by the kubelet after a password rotation.
+func probeAccountHandler(mariadb *mariadbv1alpha1.MariaDB, query, filter string) corev1.ProbeHandler {
+	cmd := fmt.Sprintf("mariadb -u '%s' -p\"$(cat \"$f\")\" -e \"%s\"", mariadb.Spec.ProbeAccount.Username, query)
+	if filter != "" {
+		cmd = fmt.Sprintf("%s | %s", cmd, filter)
+	}
+	return corev1.ProbeHandler{
+		Exec: &corev1.ExecAction{
+			Command: []string{
+				"bash",
+				"-c",
+				fmt.Sprintf("for f in %s/%s %s/%s; do [ -f \"$f\" ] && %s && exit 0; done; exit 1",
+					ProbeAccountMountPath, probeAccountPasswordFile, ProbeAccountMountPath, probeAccountPreviousPasswordFile, cmd),
+			},
+		},
+	}
+}
+
 func buildStsLivenessProbe(mariadb *mariadbv1alpha1.MariaDB) *corev1.Probe {
 	return buildStsProbe(mariadb, mariadb.Spec.LivenessProbe)
 }
@@ -324,6 +617,11 @@ func buildStsReadinessProbe(mariadb *mariadbv1alpha1.MariaDB) *corev1.Probe {
 	return buildStsProbe(mariadb, mariadb.Spec.ReadinessProbe)
 }
 
+const (
+	probeAccountPasswordFile         = "password"
+	probeAccountPreviousPasswordFile = "previous-password"
+)
+
 var (
 	defaultStsProbe = corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
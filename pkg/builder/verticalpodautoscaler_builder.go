@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metadata "github.com/mariadb-operator/mariadb-operator/pkg/builder/metadata"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// VerticalPodAutoscalerGVK is the kind of the VerticalPodAutoscaler, whose types are not vendored by the operator.
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{
+	Group:   "autoscaling.k8s.io",
+	Version: "v1",
+	Kind:    "VerticalPodAutoscaler",
+}
+
+// BuildVerticalPodAutoscaler builds a VerticalPodAutoscaler targeting the MariaDB StatefulSet in recommendation-only mode,
+// which computes recommendations without evicting or updating the Pods.
+func (b *Builder) BuildVerticalPodAutoscaler(mariadb *mariadbv1alpha1.MariaDB,
+	key types.NamespacedName) (*unstructured.Unstructured, error) {
+	objMeta :=
+		metadata.NewMetadataBuilder(key).
+			WithMariaDB(mariadb).
+			Build()
+
+	vpa := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"targetRef": map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "StatefulSet",
+					"name":       mariadb.Name,
+				},
+				"updatePolicy": map[string]interface{}{
+					"updateMode": "Off",
+				},
+			},
+		},
+	}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetName(objMeta.Name)
+	vpa.SetNamespace(objMeta.Namespace)
+	vpa.SetLabels(objMeta.Labels)
+	vpa.SetAnnotations(objMeta.Annotations)
+
+	if err := controllerutil.SetControllerReference(mariadb, vpa, b.scheme); err != nil {
+		return nil, fmt.Errorf("error setting controller reference to VerticalPodAutoscaler: %v", err)
+	}
+	return vpa, nil
+}
@@ -3,11 +3,13 @@ package command
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	backuppkg "github.com/mariadb-operator/mariadb-operator/pkg/backup"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 )
 
 type BackupOpts struct {
@@ -15,19 +17,63 @@ type BackupOpts struct {
 	Path                 string
 	TargetFilePath       string
 	MaxRetentionDuration time.Duration
+	MaxBackups           int32
+	PruneResultPath      string
+	TieringRules         []string
 	TargetTime           time.Time
+	BeforeTargetTime     bool
 	S3                   bool
 	S3Bucket             string
+	S3Prefix             string
 	S3Endpoint           string
 	S3Region             string
 	S3TLS                bool
 	S3CACertPath         string
+	S3InsecureSkipVerify bool
+	S3PathStyle          bool
+	S3SSE                string
+	S3SSEKMSKeyID        string
+	GCS                  bool
+	GCSBucket            string
+	GCSPrefix            string
+	GCSCredentialsPath   string
+	AzureBlob            bool
+	AzureContainer       string
+	AzurePrefix          string
+	AzureStorageAccount  string
+	AzureEndpoint        string
 	LogLevel             string
 	DumpOpts             []string
+	Databases            []string
+	BeforeBackupHooks    []mariadbv1alpha1.BackupHook
+	AfterBackupHooks     []mariadbv1alpha1.BackupHook
+	Compression          bool
+	CompressionAlgorithm mariadbv1alpha1.CompressAlgorithm
+	CompressionLevel     int32
+	CompressionThreads   int32
+	Streaming            bool
+	RestoreMode          mariadbv1alpha1.RestoreMode
+	RestoreDatabases     []string
+	RestoreTables        []string
+	MetricsAddr          string
+	SkipCompatibility    bool
+	BinlogIndex          string
+	BinlogInterval       time.Duration
+	BinlogFlushInterval  time.Duration
+	BinlogPodName        string
+	BinlogReplay         bool
+	BinlogPositionPath   string
+	ReplicaHost          string
 }
 
 type BackupOpt func(*BackupOpts)
 
+// provisioningGtidFile is the file where the GTID position of the dump is extracted when provisioning a replica.
+const provisioningGtidFile = "provisioning-gtid.sql"
+
+// backupHostEnv is the environment variable where the FQDN of the Pod resolved when the Job starts is exported.
+const backupHostEnv = "BACKUP_HOST"
+
 func WithBackup(path string, targetFilePath string) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.Path = path
@@ -41,12 +87,41 @@ func WithBackupMaxRetention(d time.Duration) BackupOpt {
 	}
 }
 
+func WithBackupMaxBackups(maxBackups int32) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.MaxBackups = maxBackups
+	}
+}
+
+// WithBackupPruneResultPath configures the file where the backups deleted by the retention policy are reported.
+func WithBackupPruneResultPath(path string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.PruneResultPath = path
+	}
+}
+
+// WithBackupTieringRules configures the rules to move the backups to colder storage classes.
+func WithBackupTieringRules(rules []mariadbv1alpha1.BackupTieringRule) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.TieringRules = nil
+		for _, rule := range rules {
+			bo.TieringRules = append(bo.TieringRules, fmt.Sprintf("%s=%s", rule.MinAge.Duration, rule.StorageClass))
+		}
+	}
+}
+
 func WithBackupTargetTime(t time.Time) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.TargetTime = t
 	}
 }
 
+func WithBackupBeforeTargetTime() BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.BeforeTargetTime = true
+	}
+}
+
 func WithS3(bucket, endpoint, region string) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.S3 = true
@@ -56,6 +131,12 @@ func WithS3(bucket, endpoint, region string) BackupOpt {
 	}
 }
 
+func WithS3Prefix(prefix string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.S3Prefix = prefix
+	}
+}
+
 func WithS3TLS(caCertPath string) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.S3TLS = true
@@ -63,12 +144,101 @@ func WithS3TLS(caCertPath string) BackupOpt {
 	}
 }
 
+func WithS3InsecureSkipVerify() BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.S3InsecureSkipVerify = true
+	}
+}
+
+func WithS3PathStyle() BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.S3PathStyle = true
+	}
+}
+
+func WithS3SSE(sseType, kmsKeyID string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.S3SSE = sseType
+		bo.S3SSEKMSKeyID = kmsKeyID
+	}
+}
+
+func WithGCS(bucket, prefix string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.GCS = true
+		bo.GCSBucket = bucket
+		bo.GCSPrefix = prefix
+	}
+}
+
+func WithGCSCredentials(credentialsPath string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.GCSCredentialsPath = credentialsPath
+	}
+}
+
+func WithAzureBlob(container, prefix, storageAccount, endpoint string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.AzureBlob = true
+		bo.AzureContainer = container
+		bo.AzurePrefix = prefix
+		bo.AzureStorageAccount = storageAccount
+		bo.AzureEndpoint = endpoint
+	}
+}
+
 func WithBackupDumpOpts(opts []string) BackupOpt {
 	return func(o *BackupOpts) {
 		o.DumpOpts = opts
 	}
 }
 
+// WithBackupDatabases configures the databases to be dumped, each of them into its own backup file.
+func WithBackupDatabases(databases []string) BackupOpt {
+	return func(o *BackupOpts) {
+		o.Databases = databases
+	}
+}
+
+// WithBackupHooks configures the hooks executed before and after taking the dump.
+func WithBackupHooks(hooks *mariadbv1alpha1.BackupHooks) BackupOpt {
+	return func(o *BackupOpts) {
+		if hooks == nil {
+			return
+		}
+		o.BeforeBackupHooks = hooks.BeforeBackup
+		o.AfterBackupHooks = hooks.AfterBackup
+	}
+}
+
+func WithBackupCompression(algorithm mariadbv1alpha1.CompressAlgorithm, level, threads int32) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.Compression = true
+		bo.CompressionAlgorithm = algorithm
+		bo.CompressionLevel = level
+		bo.CompressionThreads = threads
+	}
+}
+
+func WithBackupStreaming() BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.Streaming = true
+	}
+}
+
+func WithBackupRestoreMode(mode mariadbv1alpha1.RestoreMode) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.RestoreMode = mode
+	}
+}
+
+func WithBackupRestoreSelection(databases, tables []string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.RestoreDatabases = databases
+		bo.RestoreTables = tables
+	}
+}
+
 func WithBackupUserEnv(u string) BackupOpt {
 	return func(bo *BackupOpts) {
 		bo.UserEnv = u
@@ -93,6 +263,41 @@ func WithBackupLogLevel(l string) BackupOpt {
 	}
 }
 
+func WithBackupMetricsAddr(addr string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.MetricsAddr = addr
+	}
+}
+
+func WithBackupSkipCompatibilityCheck() BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.SkipCompatibility = true
+	}
+}
+
+func WithBinlogArchive(index, podName string, interval, flushInterval time.Duration) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.BinlogIndex = index
+		bo.BinlogPodName = podName
+		bo.BinlogInterval = interval
+		bo.BinlogFlushInterval = flushInterval
+	}
+}
+
+func WithBinlogReplay(startPositionFilePath string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.BinlogReplay = true
+		bo.BinlogPositionPath = startPositionFilePath
+	}
+}
+
+// WithBackupReplicaHost sets the host of the replica Pod to be provisioned.
+func WithBackupReplicaHost(host string) BackupOpt {
+	return func(bo *BackupOpts) {
+		bo.ReplicaHost = host
+	}
+}
+
 type BackupCommand struct {
 	*BackupOpts
 }
@@ -120,14 +325,24 @@ func NewBackupCommand(userOpts ...BackupOpt) (*BackupCommand, error) {
 	if opts.PasswordEnv == "" {
 		return nil, errors.New("password environment variable not provided")
 	}
+	if opts.Compression {
+		if opts.CompressionAlgorithm == "" {
+			opts.CompressionAlgorithm = mariadbv1alpha1.CompressGzip
+		}
+		if opts.CompressionLevel == 0 {
+			opts.CompressionLevel = 6
+		}
+		if opts.CompressionThreads == 0 {
+			opts.CompressionThreads = 1
+		}
+	}
 	return &BackupCommand{opts}, nil
 }
 
 func (b *BackupCommand) MariadbDump(backup *mariadbv1alpha1.Backup,
 	mariadb *mariadbv1alpha1.MariaDB) *Command {
-	dumpOpts := "--single-transaction --events --routines --dump-slave=2 --master-data=2 --gtid --all-databases"
-	if b.BackupOpts.DumpOpts != nil {
-		dumpOpts = strings.Join(b.BackupOpts.DumpOpts, " ")
+	if len(b.Databases) > 0 {
+		return b.mariadbDumpDatabases(mariadb)
 	}
 	cmds := []string{
 		"set -euo pipefail",
@@ -149,21 +364,388 @@ func (b *BackupCommand) MariadbDump(backup *mariadbv1alpha1.Backup,
 			"chmod 777 %s",
 			b.TargetFilePath,
 		),
+	}
+	cmds = append(cmds, b.resolveHostCmds(mariadb, "")...)
+	cmds = append(cmds, b.withHooks(mariadb, []string{
 		fmt.Sprintf(
 			"echo 💾 Taking backup: %s",
 			b.getTargetFilePath(),
 		),
 		fmt.Sprintf(
-			"mariadb-dump %s %s > %s",
+			"%s > %s",
+			b.dumpCmd(mariadb, ""),
+			b.getTargetFilePath(),
+		),
+	})...)
+	return NewBashCommand(cmds)
+}
+
+// mariadbDumpDatabases dumps each database into its own backup file, listing all of them in the target file.
+// All the files share the same date, so they can be restored together.
+func (b *BackupCommand) mariadbDumpDatabases(mariadb *mariadbv1alpha1.MariaDB) *Command {
+	backupFiles := make([]string, len(b.Databases))
+	for i, db := range b.Databases {
+		backupFiles[i] = b.newDatabaseBackupFile(db)
+	}
+	cmds := []string{
+		"set -euo pipefail",
+		"echo 💾 Exporting env",
+		fmt.Sprintf(
+			"export BACKUP_DATE=$(date -u +'%s')",
+			"%Y-%m-%dT%H:%M:%SZ",
+		),
+		fmt.Sprintf(
+			"echo 💾 Writing target file: %s",
+			b.TargetFilePath,
+		),
+		fmt.Sprintf(
+			"printf \"%s\" > %s",
+			strings.Join(backupFiles, "\\n"),
+			b.TargetFilePath,
+		),
+		"echo 💾 Setting target file permissions",
+		fmt.Sprintf(
+			"chmod 777 %s",
+			b.TargetFilePath,
+		),
+	}
+	cmds = append(cmds, b.resolveHostCmds(mariadb, "")...)
+	var dumpCmds []string
+	for i, db := range b.Databases {
+		filePath := fmt.Sprintf("%s/%s", b.Path, backupFiles[i])
+		dumpCmds = append(dumpCmds,
+			fmt.Sprintf(
+				"echo 💾 Taking backup of database '%s': %s",
+				db,
+				filePath,
+			),
+			fmt.Sprintf(
+				"%s > %s",
+				b.dumpCmd(mariadb, db),
+				filePath,
+			),
+		)
+	}
+	cmds = append(cmds, b.withHooks(mariadb, dumpCmds)...)
+	return NewBashCommand(cmds)
+}
+
+// withHooks wraps the dump commands with the backup hooks. The dump commands, preceded by the BeforeBackup hooks,
+// are executed in a subshell that stops at the first failure, and the AfterBackup hooks are executed regardless of its result.
+func (b *BackupCommand) withHooks(mariadb *mariadbv1alpha1.MariaDB, dumpCmds []string) []string {
+	if len(b.BeforeBackupHooks) == 0 && len(b.AfterBackupHooks) == 0 {
+		return dumpCmds
+	}
+	var subshellCmds []string
+	if len(b.BeforeBackupHooks) > 0 {
+		subshellCmds = append(subshellCmds, "echo 💾 Running before backup hooks")
+		subshellCmds = append(subshellCmds, b.hookCmds(mariadb, b.BeforeBackupHooks)...)
+	}
+	subshellCmds = append(subshellCmds, dumpCmds...)
+
+	cmds := []string{
+		"set +e",
+		fmt.Sprintf("(set -e; %s)", strings.Join(subshellCmds, ";")),
+		"EXIT_CODE=$?",
+		"set -e",
+	}
+	if len(b.AfterBackupHooks) > 0 {
+		cmds = append(cmds, "echo 💾 Running after backup hooks")
+		cmds = append(cmds, b.hookCmds(mariadb, b.AfterBackupHooks)...)
+	}
+	return append(cmds, "exit ${EXIT_CODE}")
+}
+
+// hookCmds returns the shell commands that execute the hooks. SQL hooks are executed with the mariadb client,
+// whereas the arguments of the exec hooks are quoted to prevent them from being interpreted by the shell.
+func (b *BackupCommand) hookCmds(mariadb *mariadbv1alpha1.MariaDB, hooks []mariadbv1alpha1.BackupHook) []string {
+	var cmds []string
+	for _, hook := range hooks {
+		if hook.SQL != nil {
+			cmds = append(cmds, fmt.Sprintf(
+				"mariadb %s -e %s",
+				ConnectionFlags(b.dumpCommandOpts(mariadb), mariadb),
+				shellQuote(*hook.SQL),
+			))
+			continue
+		}
+		if hook.Exec != nil {
+			args := make([]string, len(hook.Exec.Command))
+			for i, arg := range hook.Exec.Command {
+				args[i] = shellQuote(arg)
+			}
+			cmds = append(cmds, strings.Join(args, " "))
+		}
+	}
+	return cmds
+}
+
+// resolveHostCmds resolves the Pod that serves the Galera Service when the Job starts, exporting its FQDN in BACKUP_HOST.
+// The hooks and the dump connect to this Pod, as otherwise each connection could be load balanced to a different node.
+// When exitCodeVar is set, the exit code of the resolution is stored in it instead of relying on errexit.
+func (b *BackupCommand) resolveHostCmds(mariadb *mariadbv1alpha1.MariaDB, exitCodeVar string) []string {
+	if !b.pinsHost(mariadb) {
+		return nil
+	}
+	cmds := []string{
+		"echo 💾 Resolving backup host",
+		fmt.Sprintf(
+			"BACKUP_POD=$(mariadb %s -N -s -e 'SELECT @@hostname')",
 			ConnectionFlags(&b.BackupOpts.CommandOpts, mariadb),
-			dumpOpts,
+		),
+	}
+	if exitCodeVar != "" {
+		cmds = append(cmds, fmt.Sprintf("%s=$?", exitCodeVar))
+	}
+	return append(cmds,
+		fmt.Sprintf(
+			"export %s=${BACKUP_POD}.%s",
+			backupHostEnv,
+			statefulset.ServiceFQDNWithService(mariadb.ObjectMeta, mariadb.InternalServiceKey().Name),
+		),
+		fmt.Sprintf("echo 💾 Backup host: ${%s}", backupHostEnv),
+	)
+}
+
+// pinsHost determines whether the hooks and the dump should connect to a single Pod resolved when the Job starts.
+func (b *BackupCommand) pinsHost(mariadb *mariadbv1alpha1.MariaDB) bool {
+	hasHooks := len(b.BeforeBackupHooks) > 0 || len(b.AfterBackupHooks) > 0
+	return hasHooks && mariadb.Galera().Enabled && b.Host == ""
+}
+
+// dumpCommandOpts returns the options used to connect the hooks and the dump to MariaDB.
+func (b *BackupCommand) dumpCommandOpts(mariadb *mariadbv1alpha1.MariaDB) *CommandOpts {
+	opts := b.BackupOpts.CommandOpts
+	if b.pinsHost(mariadb) {
+		opts.Host = fmt.Sprintf("${%s}", backupHostEnv)
+	}
+	return &opts
+}
+
+// MariadbPrepareStream writes the target file and creates the named pipe where the backup is streamed into.
+func (b *BackupCommand) MariadbPrepareStream() *Command {
+	backupFile := b.newBackupFile()
+	if b.Compression {
+		backupFile += backuppkg.CompressionExtension(string(b.CompressionAlgorithm))
+	}
+	cmds := []string{
+		"set -euo pipefail",
+		"echo 💾 Exporting env",
+		fmt.Sprintf(
+			"export BACKUP_FILE=%s",
+			backupFile,
+		),
+		fmt.Sprintf(
+			"echo 💾 Writing target file: %s",
+			b.TargetFilePath,
+		),
+		fmt.Sprintf(
+			"printf \"${BACKUP_FILE}\" > %s",
+			b.TargetFilePath,
+		),
+		"echo 💾 Setting target file permissions",
+		fmt.Sprintf(
+			"chmod 777 %s",
+			b.TargetFilePath,
+		),
+		fmt.Sprintf(
+			"echo 💾 Creating stream: %s",
+			b.getTargetFilePath(),
+		),
+		fmt.Sprintf(
+			"mkfifo -m 666 %s",
 			b.getTargetFilePath(),
 		),
 	}
 	return NewBashCommand(cmds)
 }
 
-func (b *BackupCommand) MariadbOperatorBackup() *Command {
+// MariadbDumpStream streams the backup into the named pipe, compressing it on the fly if needed.
+// The exit code is recorded in a status file, so the uploader can tell apart complete and truncated backups.
+func (b *BackupCommand) MariadbDumpStream(mariadb *mariadbv1alpha1.MariaDB) *Command {
+	dumpCmd := b.dumpCmd(mariadb, "")
+	if b.Compression {
+		dumpCmd = fmt.Sprintf("%s | %s", dumpCmd, b.compressStreamCmd())
+	}
+	streamFilePath := b.getTargetFilePath()
+	statusFilePath := backuppkg.StreamStatusFileName(streamFilePath)
+	streamCmd := fmt.Sprintf("%s > %s", dumpCmd, streamFilePath)
+	cmds := []string{
+		"set -uo pipefail",
+	}
+	guardStream := b.pinsHost(mariadb) || len(b.BeforeBackupHooks) > 0
+	if guardStream {
+		cmds = append(cmds, "HOOKS_EXIT_CODE=0")
+		cmds = append(cmds, b.resolveHostCmds(mariadb, "HOOKS_EXIT_CODE")...)
+	}
+	if len(b.BeforeBackupHooks) > 0 {
+		cmds = append(cmds,
+			"echo 💾 Running before backup hooks",
+			fmt.Sprintf(
+				"if [ ${HOOKS_EXIT_CODE} -eq 0 ]; then (set -e; %s); HOOKS_EXIT_CODE=$?; fi",
+				strings.Join(b.hookCmds(mariadb, b.BeforeBackupHooks), ";"),
+			),
+		)
+	}
+	if guardStream {
+		// the stream is opened and closed when the host resolution or the hooks fail, so the uploader does not wait for the dump
+		streamCmd = fmt.Sprintf(
+			"if [ ${HOOKS_EXIT_CODE} -eq 0 ]; then %s; else : > %s; exit ${HOOKS_EXIT_CODE}; fi",
+			streamCmd,
+			streamFilePath,
+		)
+	}
+	cmds = append(cmds,
+		fmt.Sprintf(
+			"echo 💾 Streaming backup: %s",
+			streamFilePath,
+		),
+	)
+	cmds = append(cmds, streamCmds(streamCmd, backuppkg.StreamAbortFileName(streamFilePath))...)
+	cmds = append(cmds,
+		fmt.Sprintf(
+			"echo 💾 Writing stream status: %s",
+			statusFilePath,
+		),
+		fmt.Sprintf(
+			"printf \"${EXIT_CODE}\" > %s",
+			statusFilePath,
+		),
+	)
+	if len(b.AfterBackupHooks) > 0 {
+		cmds = append(cmds,
+			"echo 💾 Running after backup hooks",
+			fmt.Sprintf("(set -e; %s) || EXIT_CODE=$?", strings.Join(b.hookCmds(mariadb, b.AfterBackupHooks), ";")),
+		)
+	}
+	cmds = append(cmds, "exit ${EXIT_CODE}")
+	return NewBashCommand(cmds)
+}
+
+// streamCmds runs the command writing into the named pipe in its own process group, which is terminated when the
+// uploader aborts the stream or the container is stopped, as writing into a pipe without reader blocks forever.
+// The exit code of the command is stored in EXIT_CODE.
+func streamCmds(streamCmd, abortFilePath string) []string {
+	return []string{
+		"set -m",
+		fmt.Sprintf("(%s) & STREAM_PID=$!", streamCmd),
+		"set +m",
+		"trap 'kill -TERM -- -${STREAM_PID} 2>/dev/null' TERM INT",
+		fmt.Sprintf(
+			"while kill -0 ${STREAM_PID} 2>/dev/null; do if [ -f %s ]; then echo 💾 Stream aborted by the uploader; "+
+				"kill -TERM -- -${STREAM_PID} 2>/dev/null; break; fi; sleep 1; done",
+			abortFilePath,
+		),
+		"wait ${STREAM_PID}",
+		"EXIT_CODE=$?",
+	}
+}
+
+// dumpCmd dumps all the databases, or only the given database when it is not empty.
+func (b *BackupCommand) dumpCmd(mariadb *mariadbv1alpha1.MariaDB, database string) string {
+	dumpOpts := []string{"--single-transaction", "--events", "--routines", "--dump-slave=2", "--master-data=2", "--gtid"}
+	if b.BackupOpts.DumpOpts != nil {
+		dumpOpts = nil
+		for _, opt := range b.BackupOpts.DumpOpts {
+			if database != "" && (opt == "--all-databases" || opt == "-A") {
+				continue
+			}
+			dumpOpts = append(dumpOpts, opt)
+		}
+	} else if database == "" {
+		dumpOpts = append(dumpOpts, "--all-databases")
+	}
+	if database != "" {
+		dumpOpts = append(dumpOpts, fmt.Sprintf("--databases '%s'", database))
+	}
+	return fmt.Sprintf(
+		"mariadb-dump %s %s",
+		ConnectionFlags(b.dumpCommandOpts(mariadb), mariadb),
+		strings.Join(dumpOpts, " "),
+	)
+}
+
+func (b *BackupCommand) MariadbCompress() *Command {
+	cmds := []string{
+		"set -euo pipefail",
+		fmt.Sprintf(
+			"for f in $(cat '%s'); do echo 💾 Compressing backup with %s: %s/${f}; %s; done",
+			b.TargetFilePath,
+			b.CompressionAlgorithm,
+			b.Path,
+			b.compressCmd(fmt.Sprintf("%s/${f}", b.Path)),
+		),
+		fmt.Sprintf(
+			"echo 💾 Writing target file: %s",
+			b.TargetFilePath,
+		),
+		fmt.Sprintf(
+			"sed -i -e 's/$/%s/' %s",
+			backuppkg.CompressionExtension(string(b.CompressionAlgorithm)),
+			b.TargetFilePath,
+		),
+	}
+	return NewBashCommand(cmds)
+}
+
+// compressCmd compresses a backup file in place, replacing it by a file with the extension of the compression algorithm.
+func (b *BackupCommand) compressCmd(filePath string) string {
+	switch b.CompressionAlgorithm {
+	case mariadbv1alpha1.CompressZstd:
+		return fmt.Sprintf(
+			"zstd -q --rm -%d -T%d %s",
+			b.CompressionLevel,
+			b.CompressionThreads,
+			filePath,
+		)
+	case mariadbv1alpha1.CompressBzip2:
+		return fmt.Sprintf(
+			"if command -v pbzip2 > /dev/null; then pbzip2 -%d -p%d %s; else bzip2 -%d %s; fi",
+			b.CompressionLevel,
+			b.CompressionThreads,
+			filePath,
+			b.CompressionLevel,
+			filePath,
+		)
+	default:
+		return fmt.Sprintf(
+			"if command -v pigz > /dev/null; then pigz -%d -p %d %s; else gzip -%d %s; fi",
+			b.CompressionLevel,
+			b.CompressionThreads,
+			filePath,
+			b.CompressionLevel,
+			filePath,
+		)
+	}
+}
+
+// compressStreamCmd compresses the standard input into the standard output.
+func (b *BackupCommand) compressStreamCmd() string {
+	switch b.CompressionAlgorithm {
+	case mariadbv1alpha1.CompressZstd:
+		return fmt.Sprintf(
+			"zstd -q -%d -T%d -c",
+			b.CompressionLevel,
+			b.CompressionThreads,
+		)
+	case mariadbv1alpha1.CompressBzip2:
+		return fmt.Sprintf(
+			"if command -v pbzip2 > /dev/null; then pbzip2 -%d -p%d -c; else bzip2 -%d -c; fi",
+			b.CompressionLevel,
+			b.CompressionThreads,
+			b.CompressionLevel,
+		)
+	default:
+		return fmt.Sprintf(
+			"if command -v pigz > /dev/null; then pigz -%d -p %d -c; else gzip -%d -c; fi",
+			b.CompressionLevel,
+			b.CompressionThreads,
+			b.CompressionLevel,
+		)
+	}
+}
+
+func (b *BackupCommand) MariadbOperatorBackup(mariadb *mariadbv1alpha1.MariaDB) *Command {
 	args := []string{
 		"backup",
 		"--path",
@@ -172,14 +754,25 @@ func (b *BackupCommand) MariadbOperatorBackup() *Command {
 		b.TargetFilePath,
 		"--max-retention",
 		b.MaxRetentionDuration.String(),
+		"--mariadb-topology",
+		string(topology(mariadb)),
+		"--mariadb-replicas",
+		fmt.Sprint(mariadb.ScaledReplicas()),
 		"--log-level",
 		b.LogLevel,
 	}
+	if b.Streaming {
+		args = append(args, "--streaming")
+	}
+	args = append(args, b.retentionArgs()...)
+	args = append(args, b.metricsArgs()...)
 	args = append(args, b.s3Args()...)
+	args = append(args, b.gcsArgs()...)
+	args = append(args, b.azureBlobArgs()...)
 	return NewCommand(nil, args)
 }
 
-func (b *BackupCommand) MariadbOperatorRestore() *Command {
+func (b *BackupCommand) MariadbOperatorRestore(mariadb *mariadbv1alpha1.MariaDB) *Command {
 	args := []string{
 		"backup",
 		"restore",
@@ -189,13 +782,120 @@ func (b *BackupCommand) MariadbOperatorRestore() *Command {
 		backuppkg.FormatBackupDate(b.TargetTime),
 		"--target-file-path",
 		b.TargetFilePath,
+		"--mariadb-host",
+		b.restoreHost(mariadb),
+		"--mariadb-port",
+		fmt.Sprint(mariadb.Spec.Port),
 		"--log-level",
 		b.LogLevel,
 	}
+	if b.SkipCompatibility {
+		args = append(args, "--skip-compatibility-check")
+	}
+	if b.BeforeTargetTime || b.BinlogReplay {
+		args = append(args, "--before-target-time")
+	}
+	for _, db := range b.restoreSelectionDatabases() {
+		args = append(args, "--database", db)
+	}
+	args = append(args, b.metricsArgs()...)
 	args = append(args, b.s3Args()...)
+	args = append(args, b.gcsArgs()...)
+	args = append(args, b.azureBlobArgs()...)
 	return NewCommand(nil, args)
 }
 
+func (b *BackupCommand) MariadbOperatorBinlogArchive(mariadb *mariadbv1alpha1.MariaDB) *Command {
+	args := []string{
+		"backup",
+		"binlog-archive",
+		"--path",
+		b.Path,
+		"--target-file-path",
+		b.TargetFilePath,
+		"--binlog-index",
+		b.BinlogIndex,
+		"--pod-name",
+		b.BinlogPodName,
+		"--interval",
+		b.BinlogInterval.String(),
+		"--flush-interval",
+		b.BinlogFlushInterval.String(),
+		"--mariadb-port",
+		fmt.Sprint(mariadb.Spec.Port),
+		"--log-level",
+		b.LogLevel,
+	}
+	args = append(args, b.s3Args()...)
+	return NewCommand(nil, args)
+}
+
+func (b *BackupCommand) MariadbOperatorBinlogPull() *Command {
+	args := []string{
+		"backup",
+		"binlog-pull",
+		"--path",
+		b.Path,
+		"--target-file-path",
+		b.TargetFilePath,
+		"--start-position-file-path",
+		b.BinlogPositionPath,
+		"--log-level",
+		b.LogLevel,
+	}
+	args = append(args, b.s3Args()...)
+	return NewCommand(nil, args)
+}
+
+func (b *BackupCommand) MariadbOperatorSeedPull(seedData *mariadbv1alpha1.SeedData) *Command {
+	args := []string{
+		"backup",
+		"seed-pull",
+		"--path",
+		b.Path,
+		"--target-file-path",
+		b.TargetFilePath,
+		"--log-level",
+		b.LogLevel,
+	}
+	for _, f := range seedData.Files {
+		args = append(args, "--file", fmt.Sprintf("%s=%s", f.Name, f.SHA256))
+	}
+	args = append(args, b.s3Args()...)
+	return NewCommand(nil, args)
+}
+
+// MariadbSeed loads the seed files in order. SQL files are executed and CSV files are imported with mariadb-import,
+// which infers the table from the file name.
+func (b *BackupCommand) MariadbSeed(seedData *mariadbv1alpha1.SeedData, mariadb *mariadbv1alpha1.MariaDB) *Command {
+	cmds := []string{
+		"set -euo pipefail",
+	}
+	for _, f := range seedData.Files {
+		filePath := fmt.Sprintf("'%s/%s'", b.Path, f.Name)
+		cmds = append(cmds, fmt.Sprintf("echo 🌱 Loading seed file: %s", filePath))
+
+		opts := b.BackupOpts.CommandOpts
+		if f.IsCSV() {
+			opts.Database = nil
+			cmds = append(cmds, fmt.Sprintf(
+				"mariadb-import %s --local --fields-terminated-by=',' --fields-optionally-enclosed-by='\"' --ignore-lines=1 %s %s",
+				ConnectionFlags(&opts, mariadb),
+				*f.Database,
+				filePath,
+			))
+			continue
+		}
+		opts.Database = f.Database
+		cmds = append(cmds, fmt.Sprintf(
+			"mariadb %s < %s",
+			ConnectionFlags(&opts, mariadb),
+			filePath,
+		))
+	}
+	return NewBashCommand(cmds)
+}
+
 func (b *BackupCommand) MariadbRestore(mariadb *mariadbv1alpha1.MariaDB) *Command {
 	cmds := []string{
 		"set -euo pipefail",
@@ -203,15 +903,203 @@ func (b *BackupCommand) MariadbRestore(mariadb *mariadbv1alpha1.MariaDB) *Comman
 			"echo 💾 Restoring backup: %s",
 			b.getTargetFilePath(),
 		),
+		b.restoreCmd(mariadb),
+	}
+	if b.BinlogReplay {
+		cmds = append(cmds,
+			fmt.Sprintf(
+				"echo 💾 Replaying binlogs until: %s",
+				backuppkg.FormatBackupDate(b.TargetTime),
+			),
+			b.binlogReplayCmd(mariadb),
+		)
+	}
+	return NewBashCommand(cmds)
+}
+
+// MariadbProvisionReplica loads the data of a new replica without writing it into its binary log, either from the backup
+// pulled into the backup path or from a dump of the primary streamed straight into the replica.
+// The GTID position recorded in the dump is set as gtid_slave_pos afterwards, so the replica can start replicating from it.
+func (b *BackupCommand) MariadbProvisionReplica(mariadb *mariadbv1alpha1.MariaDB,
+	source mariadbv1alpha1.ReplicaProvisioningSource) *Command {
+	replicaOpts := b.BackupOpts.CommandOpts
+	replicaOpts.Host = b.restoreHost(mariadb)
+	replicaOpts.Database = nil
+
+	sourceCmd := b.decompressCmd()
+	if source == mariadbv1alpha1.ReplicaProvisioningSourcePrimary {
+		sourceCmd = b.dumpCmd(mariadb, "")
+	}
+	gtidFilePath := fmt.Sprintf("%s/%s", b.Path, provisioningGtidFile)
+	cmds := []string{
+		"set -euo pipefail",
 		fmt.Sprintf(
-			"mariadb %s < %s",
-			ConnectionFlags(&b.BackupOpts.CommandOpts, mariadb),
-			b.getTargetFilePath(),
+			"echo 💾 Provisioning replica from %s: %s",
+			source,
+			replicaOpts.Host,
+		),
+		fmt.Sprintf(
+			"%s | awk -v f='%s' '!found && /^-- SET GLOBAL gtid_slave_pos=/ { print > f; found=1 } { print }' | "+
+				"mariadb --init-command='SET SESSION sql_log_bin=0' %s",
+			sourceCmd,
+			gtidFilePath,
+			ConnectionFlags(&replicaOpts, mariadb),
+		),
+		fmt.Sprintf(
+			"if [ ! -s '%s' ]; then echo 💾 GTID position not found in the dump, it must be taken with --gtid; exit 1; fi",
+			gtidFilePath,
+		),
+		fmt.Sprintf(
+			"echo 💾 Setting GTID position: $(cat '%s')",
+			gtidFilePath,
+		),
+		fmt.Sprintf(
+			"sed -e 's/^-- //' '%s' | mariadb %s",
+			gtidFilePath,
+			ConnectionFlags(&replicaOpts, mariadb),
 		),
 	}
 	return NewBashCommand(cmds)
 }
 
+// binlogReplayCmd replays the binary logs pulled into the backup path from the GTID position of the backup until the target time.
+// mariadb-binlog interprets the stop datetime in the local time zone, UTC is enforced to match the target time.
+func (b *BackupCommand) binlogReplayCmd(mariadb *mariadbv1alpha1.MariaDB) string {
+	return fmt.Sprintf(
+		"TZ=UTC mariadb-binlog --start-position=\"$(cat '%s')\" --stop-datetime='%s' %s/*-bin.[0-9]* | mariadb %s",
+		b.BinlogPositionPath,
+		b.TargetTime.UTC().Format(time.DateTime),
+		b.Path,
+		ConnectionFlags(&b.BackupOpts.CommandOpts, mariadb),
+	)
+}
+
+func (b *BackupCommand) restoreCmd(mariadb *mariadbv1alpha1.MariaDB) string {
+	cmds := []string{b.decompressCmd()}
+	if filter := b.selectionFilter(); filter != "" {
+		cmds = append(cmds, filter)
+	}
+	if filter := b.restoreFilter(); filter != "" {
+		cmds = append(cmds, filter)
+	}
+	cmds = append(cmds, fmt.Sprintf("mariadb %s", ConnectionFlags(&b.BackupOpts.CommandOpts, mariadb)))
+	return strings.Join(cmds, " | ")
+}
+
+// decompressCmd writes the backup files into the standard output, decompressing them according to their extension.
+// Per-database backups consist of multiple files, which are restored one after another.
+func (b *BackupCommand) decompressCmd() string {
+	filePath := fmt.Sprintf("%s/${f}", b.Path)
+	return fmt.Sprintf(
+		"for f in $(cat '%s'); do case \"${f}\" in *.gz) gzip -dc %s ;; *.zst) zstd -dc %s ;; *.bz2) bzip2 -dc %s ;; *) cat %s ;; esac; done",
+		b.TargetFilePath,
+		filePath,
+		filePath,
+		filePath,
+		filePath,
+	)
+}
+
+// selectionFilter returns an awk command that only keeps the sections of the dump belonging to the databases and tables to be restored.
+// The sections are delimited by the comments written by mariadb-dump, and the session variables are always kept,
+// as they are restored at the end of the dump. The selection is passed via environment variables, as awk -v interprets
+// the escape sequences of its values.
+func (b *BackupCommand) selectionFilter() string {
+	if len(b.RestoreDatabases) == 0 && len(b.RestoreTables) == 0 {
+		return ""
+	}
+	program := []string{
+		`BEGIN { keep = 1; n = split(ENVIRON["RESTORE_DATABASES"], d, ","); for (i = 1; i <= n; i++) db_sel[d[i]] = 1;`,
+		`n = split(ENVIRON["RESTORE_TABLES"], t, ","); for (i = 1; i <= n; i++) { table_sel[t[i]] = 1; table_db_sel[substr(t[i], 1, index(t[i], ".") - 1)] = 1 } }`,
+		"/^-- Current Database: `/ { db = $0; sub(/^[^`]*`/, \"\", db); sub(/`$/, \"\", db); keep = (db in db_sel) || (db in table_db_sel) }",
+		"/^-- (Table structure for table|Dumping data for table|Temporary table structure for view|Final view structure for view) `/ " +
+			"{ table = $0; sub(/^[^`]*`/, \"\", table); sub(/`$/, \"\", table); keep = (db in db_sel) || ((db \".\" table) in table_sel) }",
+		`/^-- Dumping (routines|events) for database / { keep = (db in db_sel) }`,
+		`/^\/\*![0-9]+ SET / { print; next }`,
+		`keep { print }`,
+	}
+	return fmt.Sprintf(
+		"RESTORE_DATABASES=%s RESTORE_TABLES=%s awk '%s'",
+		shellQuote(strings.Join(b.RestoreDatabases, ",")),
+		shellQuote(strings.Join(b.RestoreTables, ",")),
+		strings.Join(program, " "),
+	)
+}
+
+// restoreFilter returns an awk command that filters the dump according to the restore mode.
+// awk is used instead of grep, as grep exits with non zero status when no lines are selected.
+// Statements spanning multiple lines are skipped until their terminator, so the values they contain are never matched.
+func (b *BackupCommand) restoreFilter() string {
+	var program []string
+	switch b.RestoreMode {
+	case mariadbv1alpha1.RestoreModeSchemaOnly:
+		program = []string{
+			`skip { if ($0 ~ /\);$/) skip = 0; next }`,
+			`/^INSERT INTO / { if ($0 !~ /\);$/) skip = 1; next }`,
+			`{ print }`,
+		}
+	case mariadbv1alpha1.RestoreModeDataOnly:
+		program = []string{
+			// the system tables of the mysql schema are never replaced
+			"/^-- Current Database: `/ { db = $0; sub(/^[^`]*`/, \"\", db); sub(/`$/, \"\", db) }",
+			`db == "mysql" { next }`,
+			// table and view DDL
+			`skip_table { if ($0 ~ /^\) .*;$/) skip_table = 0; next }`,
+			`skip_view { if ($0 ~ /\*\/;$/) skip_view = 0; next }`,
+			`/^DROP TABLE / { next }`,
+			`/^CREATE TABLE / { if ($0 !~ /^CREATE TABLE .*\) .*;$/) skip_table = 1; next }`,
+			`/^\/\*!50001 / { if ($0 !~ /\*\/;$/) skip_view = 1; next }`,
+			`/^\/\*!50013 / { next }`,
+			// triggers and routines
+			`skip_routine { if ($0 ~ /^DELIMITER ;$/) skip_routine = 0; next }`,
+			`/^DELIMITER ;;$/ { skip_routine = 1; next }`,
+			// replace the existing rows of the tables present in the dump
+			"/^LOCK TABLES `[^`]*` WRITE;$/ { print; table = $0; sub(/^LOCK TABLES /, \"\", table); sub(/ WRITE;$/, \"\", table); " +
+				"print \"DELETE FROM \" table \";\"; next }",
+			`{ print }`,
+		}
+	default:
+		return ""
+	}
+	return fmt.Sprintf("awk '%s'", strings.Join(program, " "))
+}
+
+// restoreSelectionDatabases returns the databases involved in the restore selection,
+// so only the per-database backups containing them are pulled.
+func (b *BackupCommand) restoreSelectionDatabases() []string {
+	var databases []string
+	for _, db := range b.RestoreDatabases {
+		if !slices.Contains(databases, db) {
+			databases = append(databases, db)
+		}
+	}
+	for _, table := range b.RestoreTables {
+		db, _, _ := strings.Cut(table, ".")
+		if !slices.Contains(databases, db) {
+			databases = append(databases, db)
+		}
+	}
+	return databases
+}
+
+func topology(mariadb *mariadbv1alpha1.MariaDB) backuppkg.Topology {
+	if mariadb.Galera().Enabled {
+		return backuppkg.TopologyGalera
+	}
+	if mariadb.Replication().Enabled {
+		return backuppkg.TopologyReplication
+	}
+	return backuppkg.TopologyStandalone
+}
+
+// restoreHost returns the host where backups are restored, which is the replica being provisioned if any.
+func (b *BackupCommand) restoreHost(mariadb *mariadbv1alpha1.MariaDB) string {
+	if b.ReplicaHost != "" {
+		return b.ReplicaHost
+	}
+	return b.BackupOpts.CommandOpts.host(mariadb)
+}
+
 func (b *BackupCommand) newBackupFile() string {
 	return fmt.Sprintf(
 		"backup.$(date -u +'%s').sql",
@@ -219,10 +1107,52 @@ func (b *BackupCommand) newBackupFile() string {
 	)
 }
 
+// shellQuote quotes a string to be used as a single shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (b *BackupCommand) newDatabaseBackupFile(database string) string {
+	return fmt.Sprintf("backup.${BACKUP_DATE}.%s.sql", database)
+}
+
 func (b *BackupCommand) getTargetFilePath() string {
 	return fmt.Sprintf("%s/$(cat '%s')", b.Path, b.TargetFilePath)
 }
 
+func (b *BackupCommand) retentionArgs() []string {
+	var args []string
+	if b.MaxBackups > 0 {
+		args = append(args, []string{
+			"--max-backups",
+			fmt.Sprint(b.MaxBackups),
+		}...)
+	}
+	if b.PruneResultPath != "" {
+		args = append(args, []string{
+			"--prune-result-path",
+			b.PruneResultPath,
+		}...)
+	}
+	for _, rule := range b.TieringRules {
+		args = append(args, []string{
+			"--tiering-rule",
+			rule,
+		}...)
+	}
+	return args
+}
+
+func (b *BackupCommand) metricsArgs() []string {
+	if b.MetricsAddr == "" {
+		return nil
+	}
+	return []string{
+		"--metrics-addr",
+		b.MetricsAddr,
+	}
+}
+
 func (b *BackupCommand) s3Args() []string {
 	if !b.S3 {
 		return nil
@@ -234,6 +1164,12 @@ func (b *BackupCommand) s3Args() []string {
 		"--s3-endpoint",
 		b.S3Endpoint,
 	}
+	if b.S3Prefix != "" {
+		args = append(args,
+			"--s3-prefix",
+			b.S3Prefix,
+		)
+	}
 	if b.S3Region != "" {
 		args = append(args,
 			"--s3-region",
@@ -250,6 +1186,78 @@ func (b *BackupCommand) s3Args() []string {
 				b.S3CACertPath,
 			)
 		}
+		if b.S3InsecureSkipVerify {
+			args = append(args,
+				"--s3-tls-insecure-skip-verify",
+			)
+		}
+	}
+	if b.S3PathStyle {
+		args = append(args,
+			"--s3-path-style",
+		)
+	}
+	if b.S3SSE != "" {
+		args = append(args,
+			"--s3-sse",
+			b.S3SSE,
+		)
+		if b.S3SSEKMSKeyID != "" {
+			args = append(args,
+				"--s3-sse-kms-key-id",
+				b.S3SSEKMSKeyID,
+			)
+		}
+	}
+	return args
+}
+
+func (b *BackupCommand) gcsArgs() []string {
+	if !b.GCS {
+		return nil
+	}
+	args := []string{
+		"--gcs",
+		"--gcs-bucket",
+		b.GCSBucket,
+	}
+	if b.GCSPrefix != "" {
+		args = append(args,
+			"--gcs-prefix",
+			b.GCSPrefix,
+		)
+	}
+	if b.GCSCredentialsPath != "" {
+		args = append(args,
+			"--gcs-credentials-path",
+			b.GCSCredentialsPath,
+		)
+	}
+	return args
+}
+
+func (b *BackupCommand) azureBlobArgs() []string {
+	if !b.AzureBlob {
+		return nil
+	}
+	args := []string{
+		"--azure-blob",
+		"--azure-blob-container",
+		b.AzureContainer,
+		"--azure-blob-storage-account",
+		b.AzureStorageAccount,
+	}
+	if b.AzurePrefix != "" {
+		args = append(args,
+			"--azure-blob-prefix",
+			b.AzurePrefix,
+		)
+	}
+	if b.AzureEndpoint != "" {
+		args = append(args,
+			"--azure-blob-endpoint",
+			b.AzureEndpoint,
+		)
 	}
 	return args
 }
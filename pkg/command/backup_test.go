@@ -0,0 +1,536 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	backuppkg "github.com/mariadb-operator/mariadb-operator/pkg/backup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestStreamCmds(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	tests := []struct {
+		name         string
+		readStream   bool
+		abortStream  bool
+		wantExitCode int
+	}{
+		{
+			name:         "uploaded stream",
+			readStream:   true,
+			wantExitCode: 0,
+		},
+		{
+			name:         "failing uploader",
+			abortStream:  true,
+			wantExitCode: 128 + int(syscall.SIGTERM),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamFile := filepath.Join(t.TempDir(), "backup.2023-12-22T13:00:00Z.sql")
+			if err := syscall.Mkfifo(streamFile, 0666); err != nil {
+				t.Fatalf("unexpected error creating named pipe: %v", err)
+			}
+			abortFile := backuppkg.StreamAbortFileName(streamFile)
+
+			cmds := streamCmds(fmt.Sprintf("printf backup > %s", streamFile), abortFile)
+			cmds = append(cmds, "exit ${EXIT_CODE}")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			cmd := exec.CommandContext(ctx, "bash", "-c", strings.Join(cmds, ";"))
+			if err := cmd.Start(); err != nil {
+				t.Fatalf("unexpected error starting stream: %v", err)
+			}
+
+			if tt.readStream {
+				file, err := os.Open(streamFile)
+				if err != nil {
+					t.Fatalf("unexpected error opening stream: %v", err)
+				}
+				defer file.Close()
+				bytes, err := io.ReadAll(file)
+				if err != nil {
+					t.Fatalf("unexpected error reading stream: %v", err)
+				}
+				if string(bytes) != "backup" {
+					t.Errorf("unexpected stream content, expected: backup got: %s", string(bytes))
+				}
+			}
+			if tt.abortStream {
+				time.Sleep(100 * time.Millisecond)
+				if err := backuppkg.AbortStream(abortFile); err != nil {
+					t.Fatalf("unexpected error aborting stream: %v", err)
+				}
+			}
+
+			err := cmd.Wait()
+			if ctx.Err() != nil {
+				t.Fatal("timeout waiting for stream to finish")
+			}
+			exitCode := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("unexpected error waiting for stream: %v", err)
+			}
+			if exitCode != tt.wantExitCode {
+				t.Errorf("unexpected exit code, expected: %d got: %d", tt.wantExitCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestBackupHost(t *testing.T) {
+	hooks := &mariadbv1alpha1.BackupHooks{
+		BeforeBackup: []mariadbv1alpha1.BackupHook{
+			{
+				SQL: ptr.To("SET GLOBAL wsrep_desync=ON"),
+			},
+		},
+		AfterBackup: []mariadbv1alpha1.BackupHook{
+			{
+				SQL: ptr.To("SET GLOBAL wsrep_desync=OFF"),
+			},
+		},
+	}
+	galera := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-galera",
+			Namespace: "default",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port: 3306,
+			Galera: &mariadbv1alpha1.Galera{
+				Enabled: true,
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		mariadb  *mariadbv1alpha1.MariaDB
+		hooks    *mariadbv1alpha1.BackupHooks
+		wantPin  bool
+		wantHost string
+	}{
+		{
+			name: "standalone with hooks",
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb",
+					Namespace: "default",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Port: 3306,
+				},
+			},
+			hooks:    hooks,
+			wantPin:  false,
+			wantHost: "--host=mariadb.default.svc.cluster.local",
+		},
+		{
+			name:     "Galera without hooks",
+			mariadb:  galera,
+			wantPin:  false,
+			wantHost: "--host=mariadb-galera.default.svc.cluster.local",
+		},
+		{
+			name:     "Galera with hooks",
+			mariadb:  galera,
+			hooks:    hooks,
+			wantPin:  true,
+			wantHost: "--host=${BACKUP_HOST}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []BackupOpt{
+				WithBackup("/backup", "/backup/0-backup-target.txt"),
+				WithBackupUserEnv("MARIADB_OPERATOR_USER"),
+				WithBackupPasswordEnv("MARIADB_OPERATOR_PASSWORD"),
+			}
+			if tt.hooks != nil {
+				opts = append(opts, WithBackupHooks(tt.hooks))
+			}
+			cmd, err := NewBackupCommand(opts...)
+			if err != nil {
+				t.Fatalf("unexpected error creating command: %v", err)
+			}
+
+			for name, script := range map[string]string{
+				"dump":   cmd.MariadbDump(&mariadbv1alpha1.Backup{}, tt.mariadb).Args[0],
+				"stream": cmd.MariadbDumpStream(tt.mariadb).Args[0],
+			} {
+				hasResolution := strings.Contains(script, "SELECT @@hostname")
+				if hasResolution != tt.wantPin {
+					t.Errorf("unexpected host resolution in %s, expected: %v got: %v", name, tt.wantPin, hasResolution)
+				}
+				if tt.wantPin {
+					wantExport := "export BACKUP_HOST=${BACKUP_POD}.mariadb-galera-internal.default.svc.cluster.local"
+					if !strings.Contains(script, wantExport) {
+						t.Errorf("expected %s to export the Pod FQDN: %s", name, wantExport)
+					}
+				}
+				for _, c := range strings.Split(script, ";") {
+					isConnection := strings.Contains(c, "mariadb-dump ") || strings.Contains(c, "wsrep_desync")
+					if isConnection && !strings.Contains(c, tt.wantHost) {
+						t.Errorf("expected %s command to connect to %s: %s", name, tt.wantHost, c)
+					}
+				}
+				if _, err := exec.LookPath("bash"); err == nil {
+					if out, err := exec.Command("bash", "-n", "-c", script).CombinedOutput(); err != nil {
+						t.Errorf("unexpected syntax error in %s: %v: %s", name, err, string(out))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMariadbProvisionReplica(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	tests := []struct {
+		name        string
+		dump        string
+		wantErr     bool
+		wantGtid    string
+		wantSetGtid string
+	}{
+		{
+			name: "GTID position",
+			dump: `-- MariaDB dump 10.19
+-- GTID to start replication from
+-- SET GLOBAL gtid_slave_pos='0-10-42';
+CREATE TABLE t (id int);
+-- SET GLOBAL gtid_slave_pos='0-10-43';
+INSERT INTO t VALUES (1);
+`,
+			wantGtid:    "-- SET GLOBAL gtid_slave_pos='0-10-42';",
+			wantSetGtid: "SET GLOBAL gtid_slave_pos='0-10-42';",
+		},
+		{
+			name: "GTID not found",
+			dump: `-- MariaDB dump 10.19
+CREATE TABLE t (id int);
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backupDir := t.TempDir()
+			binDir := t.TempDir()
+			loadedFile := filepath.Join(t.TempDir(), "loaded.sql")
+
+			if err := os.WriteFile(filepath.Join(backupDir, "backup.sql"), []byte(tt.dump), 0644); err != nil {
+				t.Fatalf("unexpected error writing backup: %v", err)
+			}
+			targetFile := filepath.Join(backupDir, "0-backup-target.txt")
+			if err := os.WriteFile(targetFile, []byte("backup.sql"), 0644); err != nil {
+				t.Fatalf("unexpected error writing target file: %v", err)
+			}
+			fakeMariadb := fmt.Sprintf("#!/bin/bash\ncat >> %s\n", loadedFile)
+			if err := os.WriteFile(filepath.Join(binDir, "mariadb"), []byte(fakeMariadb), 0755); err != nil {
+				t.Fatalf("unexpected error writing fake mariadb client: %v", err)
+			}
+
+			backupCmd, err := NewBackupCommand(
+				WithBackup(backupDir, targetFile),
+				WithBackupUserEnv("MARIADB_OPERATOR_USER"),
+				WithBackupPasswordEnv("MARIADB_OPERATOR_PASSWORD"),
+				WithBackupReplicaHost("mariadb-repl-2.mariadb-repl-internal.default.svc.cluster.local"),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error creating command: %v", err)
+			}
+			mariadb := &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mariadb-repl",
+					Namespace: "default",
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Port: 3306,
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+				},
+			}
+			cmd := backupCmd.MariadbProvisionReplica(mariadb, mariadbv1alpha1.ReplicaProvisioningSourceBackup)
+
+			execCmd := exec.Command(cmd.Command[0], append(cmd.Command[1:], cmd.Args...)...)
+			execCmd.Env = append(os.Environ(),
+				"PATH="+binDir+":"+os.Getenv("PATH"),
+				"MARIADB_OPERATOR_USER=mariadb-operator",
+				"MARIADB_OPERATOR_PASSWORD=MariaDB11!",
+			)
+			out, err := execCmd.CombinedOutput()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error provisioning replica, got output: %s", string(out))
+				}
+				if !strings.Contains(string(out), "GTID position not found") {
+					t.Errorf("unexpected error output: %s", string(out))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error provisioning replica: %v: %s", err, string(out))
+			}
+
+			gtid, err := os.ReadFile(filepath.Join(backupDir, provisioningGtidFile))
+			if err != nil {
+				t.Fatalf("unexpected error reading GTID file: %v", err)
+			}
+			if strings.TrimSpace(string(gtid)) != tt.wantGtid {
+				t.Errorf("unexpected GTID file, expected: %s got: %s", tt.wantGtid, string(gtid))
+			}
+
+			loaded, err := os.ReadFile(loadedFile)
+			if err != nil {
+				t.Fatalf("unexpected error reading loaded SQL: %v", err)
+			}
+			if !strings.HasPrefix(string(loaded), tt.dump) {
+				t.Errorf("expected the whole dump to be loaded, got: %s", string(loaded))
+			}
+			setGtid := strings.TrimSpace(strings.TrimPrefix(string(loaded), tt.dump))
+			if setGtid != tt.wantSetGtid {
+				t.Errorf("unexpected GTID statement, expected: %s got: %s", tt.wantSetGtid, setGtid)
+			}
+		})
+	}
+}
+
+func TestRestoreFilter(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	if _, err := exec.LookPath("awk"); err != nil {
+		t.Skip("awk not available")
+	}
+	dump := strings.Join([]string{
+		"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+		"-- Current Database: `mysql`",
+		"USE `mysql`;",
+		"DROP TABLE IF EXISTS `user`;",
+		"CREATE TABLE `user` (",
+		"  `User` char(128) NOT NULL DEFAULT '';",
+		") ENGINE=Aria DEFAULT CHARSET=utf8mb3;",
+		"LOCK TABLES `user` WRITE;",
+		"INSERT INTO `user` VALUES ('root');",
+		"UNLOCK TABLES;",
+		"-- Current Database: `app`",
+		"USE `app`;",
+		"DROP TABLE IF EXISTS `users`;",
+		"CREATE TABLE `users` (",
+		"  `id` int(11) NOT NULL,",
+		"  `name` varchar(255) DEFAULT 'it''s;',",
+		"  `bio` text COMMENT 'ends with a statement;'",
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;",
+		"LOCK TABLES `users` WRITE;",
+		"INSERT INTO `users` VALUES (1,'O''Brien','DROP TABLE x;'),(2,'a\\nb',NULL);",
+		"INSERT INTO `users` VALUES (3,'multi',",
+		"'line');",
+		"UNLOCK TABLES;",
+		"DELIMITER ;;",
+		"/*!50003 CREATE*/ /*!50003 TRIGGER `t` BEFORE INSERT ON `users` FOR EACH ROW SET NEW.name = 'x' */;;",
+		"DELIMITER ;",
+		"/*!50001 DROP VIEW IF EXISTS `v`*/;",
+		"/*!50001 CREATE ALGORITHM=UNDEFINED */",
+		"/*!50013 DEFINER=`root`@`localhost` SQL SECURITY DEFINER */",
+		"/*!50001 VIEW `v` AS select `users`.`id` AS `id` from `users` */;",
+		"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+	}, "\n") + "\n"
+
+	tests := []struct {
+		name string
+		mode mariadbv1alpha1.RestoreMode
+		want []string
+	}{
+		{
+			name: "SchemaOnly",
+			mode: mariadbv1alpha1.RestoreModeSchemaOnly,
+			want: []string{
+				"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+				"-- Current Database: `mysql`",
+				"USE `mysql`;",
+				"DROP TABLE IF EXISTS `user`;",
+				"CREATE TABLE `user` (",
+				"  `User` char(128) NOT NULL DEFAULT '';",
+				") ENGINE=Aria DEFAULT CHARSET=utf8mb3;",
+				"LOCK TABLES `user` WRITE;",
+				"UNLOCK TABLES;",
+				"-- Current Database: `app`",
+				"USE `app`;",
+				"DROP TABLE IF EXISTS `users`;",
+				"CREATE TABLE `users` (",
+				"  `id` int(11) NOT NULL,",
+				"  `name` varchar(255) DEFAULT 'it''s;',",
+				"  `bio` text COMMENT 'ends with a statement;'",
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;",
+				"LOCK TABLES `users` WRITE;",
+				"UNLOCK TABLES;",
+				"DELIMITER ;;",
+				"/*!50003 CREATE*/ /*!50003 TRIGGER `t` BEFORE INSERT ON `users` FOR EACH ROW SET NEW.name = 'x' */;;",
+				"DELIMITER ;",
+				"/*!50001 DROP VIEW IF EXISTS `v`*/;",
+				"/*!50001 CREATE ALGORITHM=UNDEFINED */",
+				"/*!50013 DEFINER=`root`@`localhost` SQL SECURITY DEFINER */",
+				"/*!50001 VIEW `v` AS select `users`.`id` AS `id` from `users` */;",
+				"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+			},
+		},
+		{
+			name: "DataOnly",
+			mode: mariadbv1alpha1.RestoreModeDataOnly,
+			want: []string{
+				"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+				"-- Current Database: `app`",
+				"USE `app`;",
+				"LOCK TABLES `users` WRITE;",
+				"DELETE FROM `users`;",
+				"INSERT INTO `users` VALUES (1,'O''Brien','DROP TABLE x;'),(2,'a\\nb',NULL);",
+				"INSERT INTO `users` VALUES (3,'multi',",
+				"'line');",
+				"UNLOCK TABLES;",
+				"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BackupCommand{
+				BackupOpts: &BackupOpts{
+					RestoreMode: tt.mode,
+				},
+			}
+			cmd := exec.Command("bash", "-c", b.restoreFilter())
+			cmd.Stdin = strings.NewReader(dump)
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("unexpected error running filter: %v", err)
+			}
+			want := strings.Join(tt.want, "\n") + "\n"
+			if string(out) != want {
+				t.Errorf("unexpected filtered dump, expected:\n%s\ngot:\n%s", want, out)
+			}
+		})
+	}
+}
+
+func TestSelectionFilter(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	if _, err := exec.LookPath("awk"); err != nil {
+		t.Skip("awk not available")
+	}
+	dump := strings.Join([]string{
+		"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+		"-- Current Database: `app`",
+		"USE `app`;",
+		"-- Table structure for table `users`",
+		"CREATE TABLE `users` (`id` int);",
+		"-- Dumping data for table `users`",
+		"INSERT INTO `users` VALUES (1);",
+		"-- Table structure for table `orders`",
+		"CREATE TABLE `orders` (`id` int);",
+		"-- Current Database: `we\\ird`",
+		"USE `we\\ird`;",
+		"-- Table structure for table `t`",
+		"CREATE TABLE `t` (`id` int);",
+		"-- Current Database: `it's`",
+		"USE `it's`;",
+		"-- Dumping routines for database 'it''s'",
+		"CREATE PROCEDURE `p`() SELECT 1;",
+		"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+	}, "\n") + "\n"
+
+	tests := []struct {
+		name      string
+		databases []string
+		tables    []string
+		want      []string
+	}{
+		{
+			name:      "database with backslash",
+			databases: []string{"we\\ird"},
+			want: []string{
+				"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+				"-- Current Database: `we\\ird`",
+				"USE `we\\ird`;",
+				"-- Table structure for table `t`",
+				"CREATE TABLE `t` (`id` int);",
+				"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+			},
+		},
+		{
+			name:      "database with quote",
+			databases: []string{"it's"},
+			want: []string{
+				"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+				"-- Current Database: `it's`",
+				"USE `it's`;",
+				"-- Dumping routines for database 'it''s'",
+				"CREATE PROCEDURE `p`() SELECT 1;",
+				"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+			},
+		},
+		{
+			name:   "table",
+			tables: []string{"app.users"},
+			want: []string{
+				"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;",
+				"-- Current Database: `app`",
+				"USE `app`;",
+				"-- Table structure for table `users`",
+				"CREATE TABLE `users` (`id` int);",
+				"-- Dumping data for table `users`",
+				"INSERT INTO `users` VALUES (1);",
+				"/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BackupCommand{
+				BackupOpts: &BackupOpts{
+					RestoreDatabases: tt.databases,
+					RestoreTables:    tt.tables,
+				},
+			}
+			cmd := exec.Command("bash", "-c", b.selectionFilter())
+			cmd.Stdin = strings.NewReader(dump)
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("unexpected error running filter: %v", err)
+			}
+			want := strings.Join(tt.want, "\n") + "\n"
+			if string(out) != want {
+				t.Errorf("unexpected filtered dump, expected:\n%s\ngot:\n%s", want, out)
+			}
+		})
+	}
+}
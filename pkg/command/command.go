@@ -17,6 +17,8 @@ type CommandOpts struct {
 	UserEnv     string
 	PasswordEnv string
 	Database    *string
+	// Host overrides the host of the MariaDB Service, for connecting to a specific Pod.
+	Host string
 }
 
 func NewCommand(cmd, args []string) *Command {
@@ -38,7 +40,7 @@ func ConnectionFlags(co *CommandOpts, mariadb *mariadbv1alpha1.MariaDB) string {
 		"--user=${%s} --password=${%s} --host=%s --port=%d",
 		co.UserEnv,
 		co.PasswordEnv,
-		host(mariadb),
+		co.host(mariadb),
 		mariadb.Spec.Port,
 	)
 	if co.Database != nil {
@@ -47,6 +49,13 @@ func ConnectionFlags(co *CommandOpts, mariadb *mariadbv1alpha1.MariaDB) string {
 	return flags
 }
 
+func (co *CommandOpts) host(mariadb *mariadbv1alpha1.MariaDB) string {
+	if co.Host != "" {
+		return co.Host
+	}
+	return host(mariadb)
+}
+
 func host(mariadb *mariadbv1alpha1.MariaDB) string {
 	if mariadb.Replication().Enabled {
 		return statefulset.ServiceFQDNWithService(
@@ -54,5 +63,5 @@ func host(mariadb *mariadbv1alpha1.MariaDB) string {
 			mariadb.PrimaryServiceKey().Name,
 		)
 	}
-	return statefulset.ServiceFQDN(mariadb.ObjectMeta)
+	return statefulset.ServiceFQDNWithService(mariadb.ObjectMeta, mariadb.ServiceKey().Name)
 }
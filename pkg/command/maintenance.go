@@ -0,0 +1,158 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+)
+
+type MaintenanceOpts struct {
+	CommandOpts
+	Operation   mariadbv1alpha1.MaintenanceOperation
+	Databases   []string
+	Concurrency int32
+}
+
+type MaintenanceOpt func(*MaintenanceOpts)
+
+func WithMaintenanceOperation(o mariadbv1alpha1.MaintenanceOperation) MaintenanceOpt {
+	return func(mo *MaintenanceOpts) {
+		mo.Operation = o
+	}
+}
+
+func WithMaintenanceDatabases(d []string) MaintenanceOpt {
+	return func(mo *MaintenanceOpts) {
+		mo.Databases = d
+	}
+}
+
+func WithMaintenanceConcurrency(c int32) MaintenanceOpt {
+	return func(mo *MaintenanceOpts) {
+		mo.Concurrency = c
+	}
+}
+
+func WithMaintenanceUserEnv(u string) MaintenanceOpt {
+	return func(mo *MaintenanceOpts) {
+		mo.UserEnv = u
+	}
+}
+
+func WithMaintenancePasswordEnv(p string) MaintenanceOpt {
+	return func(mo *MaintenanceOpts) {
+		mo.PasswordEnv = p
+	}
+}
+
+type MaintenanceCommand struct {
+	*MaintenanceOpts
+}
+
+func NewMaintenanceCommand(userOpts ...MaintenanceOpt) (*MaintenanceCommand, error) {
+	opts := &MaintenanceOpts{}
+
+	for _, setOpt := range userOpts {
+		setOpt(opts)
+	}
+	if opts.UserEnv == "" {
+		return nil, errors.New("user environment variable not provided")
+	}
+	if opts.PasswordEnv == "" {
+		return nil, errors.New("password environment variable not provided")
+	}
+	if opts.Operation == "" {
+		opts.Operation = mariadbv1alpha1.MaintenanceOperationAnalyze
+	}
+	if err := opts.Operation.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	return &MaintenanceCommand{opts}, nil
+}
+
+// ExecCommand performs the maintenance host by host, replicas first and primary last.
+// Each database is maintained by a separate mariadb-check process, running up to Concurrency processes in parallel.
+func (m *MaintenanceCommand) ExecCommand(mariadb *mariadbv1alpha1.MariaDB) *Command {
+	checkFlags := []string{
+		m.operationFlag(),
+	}
+	hosts := maintenanceHosts(mariadb)
+	if len(hosts) > 1 {
+		// each host is maintained independently, the changes must not be replicated
+		checkFlags = append(checkFlags, "--skip-write-binlog")
+	}
+	connFlags := fmt.Sprintf(
+		"--user=${%s} --password=${%s} --host=${host} --port=%d",
+		m.UserEnv,
+		m.PasswordEnv,
+		mariadb.Spec.Port,
+	)
+
+	databases := fmt.Sprintf("echo %s", strings.Join(m.Databases, " "))
+	if len(m.Databases) == 0 {
+		databases = fmt.Sprintf(
+			"mariadb %s --skip-column-names -e 'SHOW DATABASES' | sed -e '/^information_schema$/d' -e '/^performance_schema$/d'",
+			connFlags,
+		)
+	}
+
+	cmds := []string{
+		"set -euo pipefail",
+		fmt.Sprintf("echo '🧹 Performing %s maintenance'", m.Operation),
+		fmt.Sprintf("for host in %s; do "+
+			"echo \"⚙️ Maintaining host ${host}\"; "+
+			"%s | xargs -n 1 -P %d mariadb-check %s %s --databases; "+
+			"done",
+			strings.Join(hosts, " "),
+			databases,
+			m.Concurrency,
+			strings.Join(checkFlags, " "),
+			connFlags,
+		),
+	}
+	return NewBashCommand(cmds)
+}
+
+func (m *MaintenanceCommand) operationFlag() string {
+	switch m.Operation {
+	case mariadbv1alpha1.MaintenanceOperationCheck:
+		return "--check"
+	case mariadbv1alpha1.MaintenanceOperationOptimize:
+		return "--optimize"
+	default:
+		return "--analyze"
+	}
+}
+
+func maintenanceHosts(mariadb *mariadbv1alpha1.MariaDB) []string {
+	if !mariadb.Replication().Enabled && !mariadb.Galera().Enabled {
+		return []string{host(mariadb)}
+	}
+	primaryIndex := 0
+	if mariadb.Status.CurrentPrimaryPodIndex != nil {
+		primaryIndex = *mariadb.Status.CurrentPrimaryPodIndex
+	}
+	podHost := func(index int) string {
+		return statefulset.PodFQDNWithService(
+			mariadb.ObjectMeta,
+			index,
+			mariadb.InternalServiceKey().Name,
+		)
+	}
+
+	var hosts []string
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		if i == primaryIndex {
+			continue
+		}
+		hosts = append(hosts, podHost(i))
+	}
+	return append(hosts, podHost(primaryIndex))
+}
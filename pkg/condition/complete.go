@@ -1,6 +1,8 @@
 package conditions
 
 import (
+	"fmt"
+
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -61,7 +63,7 @@ func SetCompleteWithJob(c Conditioner, job *batchv1.Job) {
 			Type:    mariadbv1alpha1.ConditionTypeComplete,
 			Status:  metav1.ConditionTrue,
 			Reason:  mariadbv1alpha1.ConditionReasonJobFailed,
-			Message: "Failed",
+			Message: jobFailedMessage(job),
 		})
 	case batchv1.JobComplete:
 		c.SetCondition(metav1.Condition{
@@ -96,13 +98,38 @@ func SetCompleteFailedWithMessage(c Conditioner, message string) {
 	})
 }
 
+// SetCompleteWaitingWithMessage indicates that the object is waiting for its dependencies before starting.
+func SetCompleteWaitingWithMessage(c Conditioner, message string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeComplete,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonWaitingForDependencies,
+		Message: message,
+	})
+}
+
 func SetCompleteFailed(c Conditioner) {
 	SetCompleteFailedWithMessage(c, "Failed")
 }
 
+// jobFailedMessage describes why a Job has failed, for instance, when a Pod failure policy rule or the active deadline have been hit.
+func jobFailedMessage(job *batchv1.Job) string {
+	for _, c := range job.Status.Conditions {
+		if c.Type != batchv1.JobFailed || c.Reason == "" {
+			continue
+		}
+		if c.Message == "" {
+			return fmt.Sprintf("Failed: %s", c.Reason)
+		}
+		return fmt.Sprintf("Failed: %s: %s", c.Reason, c.Message)
+	}
+	return "Failed"
+}
+
 func getJobConditionType(job *batchv1.Job) batchv1.JobConditionType {
 	for _, c := range job.Status.Conditions {
-		if c.Status == corev1.ConditionFalse {
+		// FailureTarget is added by Pod failure policies before the Failed condition, once the Pods are terminated.
+		if c.Status == corev1.ConditionFalse || c.Type == batchv1.JobFailureTarget {
 			continue
 		}
 		return c.Type
@@ -31,6 +31,12 @@ func (p *Ready) PatcherFailed(msg string) Patcher {
 	}
 }
 
+func (p *Ready) PatcherUnsupported(msg string) Patcher {
+	return func(c Conditioner) {
+		SetReadyUnsupportedWithMessage(c, msg)
+	}
+}
+
 func (p *Ready) PatcherWithError(err error) Patcher {
 	return func(c Conditioner) {
 		if err == nil {
@@ -80,6 +86,12 @@ func (p *Complete) PatcherFailed(msg string) Patcher {
 	}
 }
 
+func (p *Complete) PatcherWaiting(msg string) Patcher {
+	return func(c Conditioner) {
+		SetCompleteWaitingWithMessage(c, msg)
+	}
+}
+
 func (p *Complete) PatcherWithCronJob(ctx context.Context, err error, key types.NamespacedName) (Patcher, error) {
 	if err != nil {
 		return func(c Conditioner) {
@@ -0,0 +1,41 @@
+package conditions
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetSeedingData(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeDataSeeded,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonSeedData,
+		Message: "Seeding data",
+	})
+}
+
+func SetSeededData(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeDataSeeded,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonSeedData,
+		Message: "Seeded data",
+	})
+}
+
+func SetSeedDataWithJob(c Conditioner, job *batchv1.Job) {
+	switch getJobConditionType(job) {
+	case batchv1.JobComplete:
+		SetSeededData(c)
+	case batchv1.JobFailed:
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeDataSeeded,
+			Status:  metav1.ConditionFalse,
+			Reason:  mariadbv1alpha1.ConditionReasonFailed,
+			Message: jobFailedMessage(job),
+		})
+	default:
+		SetSeedingData(c)
+	}
+}
@@ -0,0 +1,30 @@
+package conditions
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetReadyWithFleetStatus(c Conditioner, status *mariadbv1alpha1.MariaDBFleetStatus) {
+	if status.ReadyMembers != status.TotalMembers {
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  mariadbv1alpha1.ConditionReasonFleetMembersNotReady,
+			Message: fmt.Sprintf("%d/%d members ready", status.ReadyMembers, status.TotalMembers),
+		})
+		return
+	}
+	if status.UpdatedMembers != status.TotalMembers {
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  mariadbv1alpha1.ConditionReasonFleetRollingOut,
+			Message: fmt.Sprintf("%d/%d members updated", status.UpdatedMembers, status.TotalMembers),
+		})
+		return
+	}
+	SetReadyHealthty(c)
+}
@@ -0,0 +1,92 @@
+package conditions
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetMariaDBHealth sets the Reconciling and Stalled conditions of a MariaDB according to the rest of its conditions and its StatefulSet,
+// which may be nil. These conditions follow the kstatus conventions, so GitOps tools report the health of the MariaDB accurately.
+func SetMariaDBHealth(s *mariadbv1alpha1.MariaDBStatus, sts *appsv1.StatefulSet) {
+	if stalled := mariadbStalled(s); stalled != nil {
+		s.SetCondition(*stalled)
+		meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeReconciling)
+		return
+	}
+	meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeStalled)
+
+	if reconciling := mariadbReconciling(s, sts); reconciling != nil {
+		s.SetCondition(*reconciling)
+		return
+	}
+	meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeReconciling)
+}
+
+func mariadbStalled(s *mariadbv1alpha1.MariaDBStatus) *metav1.Condition {
+	ready := meta.FindStatusCondition(s.Conditions, mariadbv1alpha1.ConditionTypeReady)
+	if ready != nil && ready.Status == metav1.ConditionFalse && ready.Reason == mariadbv1alpha1.ConditionReasonFailed {
+		return stalledCondition(ready.Reason, ready.Message)
+	}
+	for _, conditionType := range []string{
+		mariadbv1alpha1.ConditionTypePodFailed,
+		mariadbv1alpha1.ConditionTypeActionsRateLimited,
+	} {
+		if c := meta.FindStatusCondition(s.Conditions, conditionType); c != nil && c.Status == metav1.ConditionTrue {
+			return stalledCondition(c.Reason, c.Message)
+		}
+	}
+	return nil
+}
+
+func mariadbReconciling(s *mariadbv1alpha1.MariaDBStatus, sts *appsv1.StatefulSet) *metav1.Condition {
+	ready := meta.FindStatusCondition(s.Conditions, mariadbv1alpha1.ConditionTypeReady)
+	if ready == nil {
+		return reconcilingCondition(mariadbv1alpha1.ConditionReasonProvisioning, "Provisioning")
+	}
+	if ready.Reason == mariadbv1alpha1.ConditionReasonHibernated {
+		return nil
+	}
+	if ready.Status != metav1.ConditionTrue {
+		return reconcilingCondition(ready.Reason, ready.Message)
+	}
+	if sts != nil && isStatefulSetRollingOut(sts) {
+		return reconcilingCondition(mariadbv1alpha1.ConditionReasonStatefulSetRollingOut, "Rolling out Pods")
+	}
+	restartPending := meta.FindStatusCondition(s.Conditions, mariadbv1alpha1.ConditionTypeRestartPending)
+	if restartPending != nil && restartPending.Status == metav1.ConditionTrue {
+		return reconcilingCondition(restartPending.Reason, restartPending.Message)
+	}
+	return nil
+}
+
+// isStatefulSetRollingOut determines whether a StatefulSet with the RollingUpdate strategy is updating its Pods.
+// StatefulSets with the OnDelete strategy are covered by the RestartPending condition.
+func isStatefulSetRollingOut(sts *appsv1.StatefulSet) bool {
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return true
+	}
+	return sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision
+}
+
+func stalledCondition(reason, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeStalled,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func reconcilingCondition(reason, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeReconciling,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+}
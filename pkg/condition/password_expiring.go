@@ -0,0 +1,50 @@
+package conditions
+
+import (
+	"fmt"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetPasswordExpiring(c Conditioner, expiresAt time.Time) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypePasswordExpiring,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonPasswordExpiring,
+		Message: fmt.Sprintf("Password expires at %s", expiresAt.UTC().Format(time.RFC3339)),
+	})
+}
+
+func SetPasswordExpired(c Conditioner, expiresAt time.Time) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypePasswordExpiring,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonPasswordExpired,
+		Message: fmt.Sprintf("Password expired at %s", expiresAt.UTC().Format(time.RFC3339)),
+	})
+}
+
+// SetPasswordExpirationUnsupported reports that the password expiration cannot be tracked by the MariaDB server version.
+func SetPasswordExpirationUnsupported(c Conditioner, message string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypePasswordExpiring,
+		Status:  metav1.ConditionUnknown,
+		Reason:  mariadbv1alpha1.ConditionReasonUnsupported,
+		Message: message,
+	})
+}
+
+func SetPasswordNotExpiring(c Conditioner, expiresAt *time.Time) {
+	message := "Password does not expire"
+	if expiresAt != nil {
+		message = fmt.Sprintf("Password expires at %s", expiresAt.UTC().Format(time.RFC3339))
+	}
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypePasswordExpiring,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonPasswordNotExpiring,
+		Message: message,
+	})
+}
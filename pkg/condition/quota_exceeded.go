@@ -0,0 +1,27 @@
+package conditions
+
+import (
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetQuotaExceeded(c Conditioner, size, quota *resource.Quantity) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeQuotaExceeded,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonQuotaExceeded,
+		Message: fmt.Sprintf("Size %s exceeds quota %s", size.String(), quota.String()),
+	})
+}
+
+func SetQuotaNotExceeded(c Conditioner, size, quota *resource.Quantity) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeQuotaExceeded,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonQuotaNotExceeded,
+		Message: fmt.Sprintf("Size %s within quota %s", size.String(), quota.String()),
+	})
+}
@@ -46,10 +46,28 @@ func SetReadyFailedWithMessage(c Conditioner, message string) {
 	})
 }
 
+func SetReadyUnsupportedWithMessage(c Conditioner, message string) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonUnsupported,
+		Message: message,
+	})
+}
+
 func SetReadyFailed(c Conditioner) {
 	SetReadyFailedWithMessage(c, "Failed")
 }
 
+func SetReadyHibernated(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonHibernated,
+		Message: "Hibernated",
+	})
+}
+
 func SetReadyWithStatefulSet(c Conditioner, sts *appsv1.StatefulSet) {
 	if sts.Status.Replicas == 0 || sts.Status.ReadyReplicas != sts.Status.Replicas {
 		c.SetCondition(metav1.Condition{
@@ -67,3 +85,30 @@ func SetReadyWithStatefulSet(c Conditioner, sts *appsv1.StatefulSet) {
 		Message: "Running",
 	})
 }
+
+func SetReadyWithDeployment(c Conditioner, deploy *appsv1.Deployment) {
+	if deploy.Status.Replicas == 0 || deploy.Status.ReadyReplicas != deploy.Status.Replicas {
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  mariadbv1alpha1.ConditionReasonDeploymentNotReady,
+			Message: "Not ready",
+		})
+		return
+	}
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  mariadbv1alpha1.ConditionReasonDeploymentReady,
+		Message: "Running",
+	})
+}
+
+func SetReadyProvisioning(c Conditioner) {
+	c.SetCondition(metav1.Condition{
+		Type:    mariadbv1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  mariadbv1alpha1.ConditionReasonProvisioning,
+		Message: "Provisioning",
+	})
+}
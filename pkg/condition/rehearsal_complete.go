@@ -0,0 +1,32 @@
+package conditions
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func SetCompleteWithRehearsalStatus(c Conditioner, status *mariadbv1alpha1.RestoreRehearsalStatus) {
+	switch status.Phase {
+	case mariadbv1alpha1.RestoreRehearsalPhaseSucceeded:
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeComplete,
+			Status:  metav1.ConditionTrue,
+			Reason:  mariadbv1alpha1.ConditionReasonRehearsalSucceeded,
+			Message: "Success",
+		})
+	case mariadbv1alpha1.RestoreRehearsalPhaseFailed:
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeComplete,
+			Status:  metav1.ConditionTrue,
+			Reason:  mariadbv1alpha1.ConditionReasonRehearsalFailed,
+			Message: "Failed",
+		})
+	default:
+		c.SetCondition(metav1.Condition{
+			Type:    mariadbv1alpha1.ConditionTypeComplete,
+			Status:  metav1.ConditionFalse,
+			Reason:  mariadbv1alpha1.ConditionReasonRehearsalProvisioning,
+			Message: "Provisioning",
+		})
+	}
+}
@@ -75,7 +75,7 @@ func (r *BatchReconciler) reconcileBatch(ctx context.Context, parentObj client.O
 	}
 
 	if desiredJob, ok := desiredBatch.(*batchv1.Job); ok {
-		return r.reconcileJob(ctx, key, desiredJob)
+		return r.reconcileJob(ctx, key, desiredJob, isComplete(parentObj))
 	}
 	if desiredCronJob, ok := desiredBatch.(*batchv1.CronJob); ok {
 		return r.reconcileCronJob(ctx, key, desiredCronJob)
@@ -102,13 +102,17 @@ func (r *BatchReconciler) buildBatch(ctx context.Context, parentObj client.Objec
 }
 
 func (r *BatchReconciler) reconcileJob(ctx context.Context, key types.NamespacedName,
-	desiredJob *batchv1.Job) error {
+	desiredJob *batchv1.Job, complete bool) error {
 
 	var existingJob batchv1.Job
 	if err := r.Get(ctx, key, &existingJob); err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("error getting Job: %v", err)
 		}
+		// the Job has already been garbage collected after finishing, it must not be executed again.
+		if complete {
+			return nil
+		}
 
 		if err := r.Create(ctx, desiredJob); err != nil {
 			return fmt.Errorf("error creating Job: %v", err)
@@ -118,6 +122,11 @@ func (r *BatchReconciler) reconcileJob(ctx context.Context, key types.Namespaced
 
 	patch := client.MergeFrom(existingJob.DeepCopy())
 	existingJob.Spec.BackoffLimit = desiredJob.Spec.BackoffLimit
+	existingJob.Spec.ActiveDeadlineSeconds = desiredJob.Spec.ActiveDeadlineSeconds
+	// the TTL of finished Jobs may be set by the parent controller once their result has been recorded.
+	if !isJobFinished(&existingJob) {
+		existingJob.Spec.TTLSecondsAfterFinished = desiredJob.Spec.TTLSecondsAfterFinished
+	}
 
 	if err := r.Patch(ctx, &existingJob, patch); err != nil {
 		return fmt.Errorf("error patching Job: %v", err)
@@ -143,10 +152,42 @@ func (r *BatchReconciler) reconcileCronJob(ctx context.Context, key types.Namesp
 	patch := client.MergeFrom(existingCronJob.DeepCopy())
 	existingCronJob.Spec.Schedule = desiredCronJob.Spec.Schedule
 	existingCronJob.Spec.Suspend = desiredCronJob.Spec.Suspend
+	existingCronJob.Spec.SuccessfulJobsHistoryLimit = desiredCronJob.Spec.SuccessfulJobsHistoryLimit
+	existingCronJob.Spec.FailedJobsHistoryLimit = desiredCronJob.Spec.FailedJobsHistoryLimit
+	// the Jobs are labeled with their parent, so they can be listed with a label selector.
+	if existingCronJob.Spec.JobTemplate.Labels == nil {
+		existingCronJob.Spec.JobTemplate.Labels = make(map[string]string)
+	}
+	for k, v := range desiredCronJob.Spec.JobTemplate.Labels {
+		existingCronJob.Spec.JobTemplate.Labels[k] = v
+	}
 	existingCronJob.Spec.JobTemplate.Spec.BackoffLimit = desiredCronJob.Spec.JobTemplate.Spec.BackoffLimit
+	existingCronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished = desiredCronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished
+	existingCronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds = desiredCronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds
+	existingCronJob.Spec.JobTemplate.Spec.PodFailurePolicy = desiredCronJob.Spec.JobTemplate.Spec.PodFailurePolicy
+	// Backup Pods may be moved to dedicated nodes at any time, taking effect from the next scheduled run.
+	existingCronJob.Spec.JobTemplate.Spec.Template.Spec.Affinity = desiredCronJob.Spec.JobTemplate.Spec.Template.Spec.Affinity
+	existingCronJob.Spec.JobTemplate.Spec.Template.Spec.NodeSelector = desiredCronJob.Spec.JobTemplate.Spec.Template.Spec.NodeSelector
+	existingCronJob.Spec.JobTemplate.Spec.Template.Spec.Tolerations = desiredCronJob.Spec.JobTemplate.Spec.Template.Spec.Tolerations
 
 	if err := r.Patch(ctx, &existingCronJob, patch); err != nil {
 		return fmt.Errorf("error patching CronJob: %v", err)
 	}
 	return nil
 }
+
+func isComplete(parentObj client.Object) bool {
+	if completer, ok := parentObj.(interface{ IsComplete() bool }); ok {
+		return completer.IsComplete()
+	}
+	return false
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -2,15 +2,18 @@ package certificate
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"time"
 
+	"github.com/mariadb-operator/mariadb-operator/pkg/expiry"
 	"github.com/mariadb-operator/mariadb-operator/pkg/pki"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var (
@@ -28,8 +31,10 @@ type CertReconcilerOpts struct {
 	certCommonName string
 	certDNSNames   []string
 	certValidity   time.Duration
+	certKeyUsage   []x509.ExtKeyUsage
 
 	lookaheadValidity time.Duration
+	owner             metav1.Object
 }
 
 type CertReconcilerOpt func(opts *CertReconcilerOpts)
@@ -52,6 +57,20 @@ func WithLookaheadValidity(validity time.Duration) CertReconcilerOpt {
 	}
 }
 
+// WithCertExtKeyUsage sets the extended key usages of the certificate, which defaults to server auth.
+func WithCertExtKeyUsage(usage ...x509.ExtKeyUsage) CertReconcilerOpt {
+	return func(opts *CertReconcilerOpts) {
+		opts.certKeyUsage = usage
+	}
+}
+
+// WithOwner sets a controller reference to the owner in the Secrets, so they are garbage collected along with it.
+func WithOwner(owner metav1.Object) CertReconcilerOpt {
+	return func(opts *CertReconcilerOpts) {
+		opts.owner = owner
+	}
+}
+
 type CertReconciler struct {
 	client.Client
 	CertReconcilerOpts
@@ -69,6 +88,7 @@ func NewCertReconciler(client client.Client, caSecretKey types.NamespacedName, c
 		certCommonName: certCommonName,
 		certValidity:   defaultCertValidityDuration,
 		certDNSNames:   certDNSNames,
+		certKeyUsage:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 
 		lookaheadValidity: defaultLookaheadValidity,
 	}
@@ -118,6 +138,9 @@ func (r *CertReconciler) Reconcile(ctx context.Context) (*ReconcileResult, error
 			return nil, fmt.Errorf("Error reconciling certificate KeyPair: %v", err)
 		}
 	}
+
+	expiry.SetCertificateExpiration(r.caSecretKey, "ca", result.CAKeyPair.Cert)
+	expiry.SetCertificateExpiration(r.certSecretKey, "cert", result.CertKeyPair.Cert)
 	return result, nil
 }
 
@@ -170,6 +193,7 @@ func (r *CertReconciler) createCertFn(caKeyPair *pki.KeyPair) func() (*pki.KeyPa
 			caKeyPair,
 			pki.WithCommonName(r.certCommonName),
 			pki.WithDNSNames(r.certDNSNames),
+			pki.WithExtKeyUsage(r.certKeyUsage...),
 			pki.WithNotBefore(time.Now().Add(-1*time.Hour)),
 			pki.WithNotAfter(time.Now().Add(r.certValidity)),
 		)
@@ -183,6 +207,11 @@ func (r *CertReconciler) createSecret(ctx context.Context, key types.NamespacedN
 	}
 	secret.Type = corev1.SecretTypeTLS
 	keyPair.FillTLSSecret(secret)
+	if r.owner != nil {
+		if err := controllerutil.SetControllerReference(r.owner, secret, r.Scheme()); err != nil {
+			return fmt.Errorf("Error setting controller reference to TLS Secret: %v", err)
+		}
+	}
 	if err := r.Create(ctx, secret); err != nil {
 		return fmt.Errorf("Error creating TLS Secret: %v", err)
 	}
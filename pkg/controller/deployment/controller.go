@@ -34,6 +34,9 @@ func (r *DeploymentReconciler) Reconcile(ctx context.Context, desiredDeploy *app
 
 	patch := client.MergeFrom(existingDeploy.DeepCopy())
 	existingDeploy.Spec.Template = desiredDeploy.Spec.Template
+	if desiredDeploy.Spec.Replicas != nil {
+		existingDeploy.Spec.Replicas = desiredDeploy.Spec.Replicas
+	}
 
 	return r.Patch(ctx, &existingDeploy, patch)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
@@ -32,8 +33,51 @@ func NewEndpointsReconciler(client client.Client, builder *builder.Builder) *End
 	}
 }
 
-func (r *EndpointsReconciler) Reconcile(ctx context.Context, key types.NamespacedName, mariadb *mariadbv1alpha1.MariaDB) error {
-	desiredEndpoints, err := r.endpoints(ctx, key, mariadb)
+type EndpointsOpts struct {
+	PodIndexes  []int
+	PodSelector map[string]string
+	WarmUp      bool
+	MaxLag      *time.Duration
+}
+
+type EndpointsOpt func(*EndpointsOpts)
+
+// WithPodIndexes restricts the Endpoints addresses to the Pods with the given StatefulSet indexes.
+func WithPodIndexes(podIndexes []int) EndpointsOpt {
+	return func(eo *EndpointsOpts) {
+		eo.PodIndexes = podIndexes
+	}
+}
+
+// WithPodSelector restricts the Endpoints addresses to the Pods that have the given labels.
+func WithPodSelector(podSelector map[string]string) EndpointsOpt {
+	return func(eo *EndpointsOpts) {
+		eo.PodSelector = podSelector
+	}
+}
+
+// WithWarmUp considers not ready the Pods that have not completed the warm-up.
+func WithWarmUp() EndpointsOpt {
+	return func(eo *EndpointsOpts) {
+		eo.WarmUp = true
+	}
+}
+
+// WithMaxLag considers not ready the replicas that lag more than maxLag behind the primary, according to the MariaDB status.
+func WithMaxLag(maxLag time.Duration) EndpointsOpt {
+	return func(eo *EndpointsOpts) {
+		eo.MaxLag = &maxLag
+	}
+}
+
+func (r *EndpointsReconciler) Reconcile(ctx context.Context, key types.NamespacedName, mariadb *mariadbv1alpha1.MariaDB,
+	endpointsOpts ...EndpointsOpt) error {
+	opts := EndpointsOpts{}
+	for _, setOpt := range endpointsOpts {
+		setOpt(&opts)
+	}
+
+	desiredEndpoints, err := r.endpoints(ctx, key, mariadb, opts)
 	if err != nil {
 		if errors.Is(err, ErrNoAddressesAvailable) {
 			return err
@@ -58,7 +102,7 @@ func (r *EndpointsReconciler) Reconcile(ctx context.Context, key types.Namespace
 }
 
 func (r *EndpointsReconciler) endpoints(ctx context.Context, key types.NamespacedName,
-	mariadb *mariadbv1alpha1.MariaDB) (*corev1.Endpoints, error) {
+	mariadb *mariadbv1alpha1.MariaDB, opts EndpointsOpts) (*corev1.Endpoints, error) {
 	if mariadb.Status.CurrentPrimaryPodIndex == nil {
 		return nil, fmt.Errorf("'status.currentPrimaryPodIndex' must be set")
 	}
@@ -66,6 +110,7 @@ func (r *EndpointsReconciler) endpoints(ctx context.Context, key types.Namespace
 	listOpts := &client.ListOptions{
 		LabelSelector: klabels.SelectorFromSet(
 			labels.NewLabelsBuilder().
+				WithLabels(opts.PodSelector).
 				WithMariaDB(mariadb).
 				Build(),
 		),
@@ -92,8 +137,12 @@ func (r *EndpointsReconciler) endpoints(ctx context.Context, key types.Namespace
 		if *podIndex == *mariadb.Status.CurrentPrimaryPodIndex {
 			continue
 		}
+		if opts.PodIndexes != nil && !containsIndex(opts.PodIndexes, *podIndex) {
+			continue
+		}
 
-		if mdbpod.PodReady(&pod) {
+		if mdbpod.PodReady(&pod) && !mdbpod.PodProvisioning(&pod) && !mdbpod.PodFenced(&pod) && (!opts.WarmUp || mdbpod.PodWarmedUp(&pod, builder.MariaDbContainerName)) &&
+			(opts.MaxLag == nil || !isLagging(mariadb, pod.Name, *opts.MaxLag)) {
 			addresses = append(addresses, *addr)
 		} else {
 			notReadyAddresses = append(notReadyAddresses, *addr)
@@ -138,3 +187,22 @@ func endpointAddress(pod *corev1.Pod) *corev1.EndpointAddress {
 		},
 	}
 }
+
+// isLagging determines whether a replica is lagging according to the last replication status. Replicas that have not been
+// checked yet are not considered lagging.
+func isLagging(mariadb *mariadbv1alpha1.MariaDB, podName string, maxLag time.Duration) bool {
+	if mariadb.Status.Replication == nil {
+		return false
+	}
+	replica := mariadb.Status.Replication.Replica(podName)
+	return replica != nil && replica.IsLagging(maxLag)
+}
+
+func containsIndex(indexes []int, index int) bool {
+	for _, i := range indexes {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
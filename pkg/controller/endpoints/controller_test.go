@@ -0,0 +1,165 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileSecondaryEndpoints(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	if err := mariadbv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding to scheme: %v", err)
+	}
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-repl",
+			Namespace: "default",
+		},
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Port:     3306,
+			Replicas: 4,
+		},
+		Status: mariadbv1alpha1.MariaDBStatus{
+			CurrentPrimaryPodIndex: ptr.To(0),
+		},
+	}
+	newPod := func(name, ip string, labels map[string]string) *corev1.Pod {
+		podLabels := map[string]string{
+			"app.kubernetes.io/name":     "mariadb",
+			"app.kubernetes.io/instance": mariadb.Name,
+		}
+		for k, v := range labels {
+			podLabels[k] = v
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: mariadb.Namespace,
+				Labels:    podLabels,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node",
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	pods := []client.Object{
+		newPod("mariadb-repl-0", "10.0.0.0", nil),
+		newPod("mariadb-repl-1", "10.0.0.1", nil),
+		newPod("mariadb-repl-2", "10.0.0.2", map[string]string{"workload": "reporting"}),
+		newPod("mariadb-repl-3", "10.0.0.3", map[string]string{"workload": "reporting"}),
+	}
+	key := types.NamespacedName{
+		Name:      "mariadb-repl-reporting",
+		Namespace: mariadb.Namespace,
+	}
+
+	tests := []struct {
+		name      string
+		opts      []EndpointsOpt
+		wantIPs   []string
+		wantNoIPs bool
+	}{
+		{
+			name:    "all secondaries",
+			wantIPs: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			name: "pod indexes",
+			opts: []EndpointsOpt{
+				WithPodIndexes([]int{1, 3}),
+			},
+			wantIPs: []string{"10.0.0.1", "10.0.0.3"},
+		},
+		{
+			name: "pod indexes excluding the primary",
+			opts: []EndpointsOpt{
+				WithPodIndexes([]int{0, 2}),
+			},
+			wantIPs: []string{"10.0.0.2"},
+		},
+		{
+			name: "pod selector",
+			opts: []EndpointsOpt{
+				WithPodSelector(map[string]string{"workload": "reporting"}),
+			},
+			wantIPs: []string{"10.0.0.2", "10.0.0.3"},
+		},
+		{
+			name: "pod indexes and pod selector",
+			opts: []EndpointsOpt{
+				WithPodIndexes([]int{1, 2}),
+				WithPodSelector(map[string]string{"workload": "reporting"}),
+			},
+			wantIPs: []string{"10.0.0.2"},
+		},
+		{
+			name: "no matching pods",
+			opts: []EndpointsOpt{
+				WithPodSelector(map[string]string{"workload": "analytics"}),
+			},
+			wantNoIPs: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(pods...).
+				Build()
+			r := NewEndpointsReconciler(c, builder.NewBuilder(scheme, &environment.Environment{}))
+
+			err := r.Reconcile(context.Background(), key, mariadb, tt.opts...)
+			if tt.wantNoIPs {
+				if !errors.Is(err, ErrNoAddressesAvailable) {
+					t.Fatalf("expecting error '%v', got '%v'", ErrNoAddressesAvailable, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error reconciling Endpoints: %v", err)
+			}
+
+			var endpoints corev1.Endpoints
+			if err := c.Get(context.Background(), key, &endpoints); err != nil {
+				t.Fatalf("unexpected error getting Endpoints: %v", err)
+			}
+			if len(endpoints.Subsets) != 1 {
+				t.Fatalf("expecting 1 subset, got %d", len(endpoints.Subsets))
+			}
+			var ips []string
+			for _, addr := range endpoints.Subsets[0].Addresses {
+				ips = append(ips, addr.IP)
+			}
+			if !reflect.DeepEqual(ips, tt.wantIPs) {
+				t.Errorf("expecting addresses to be %v, got %v", tt.wantIPs, ips)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package galera
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClientSet "github.com/mariadb-operator/mariadb-operator/pkg/sqlset"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ReconcileArbitrator deploys the Galera arbitrator (garbd) and reports its state. The arbitrator joins the group
+// of the running cluster, therefore the Deployment is only created or updated when the cluster is healthy.
+// It is scaled down to zero Pods while the MariaDB is hibernated, and deleted when the arbitrator is disabled.
+func (r *GaleraReconciler) ReconcileArbitrator(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if !mariadb.IsGaleraArbitratorEnabled() {
+		return r.deleteArbitrator(ctx, mariadb)
+	}
+	if mariadb.IsHibernated() {
+		return r.scaleDownArbitrator(ctx, mariadb)
+	}
+	if mariadb.IsRestoringBackup() || !mariadb.HasGaleraReadyCondition() {
+		return nil
+	}
+	logger := log.FromContext(ctx).WithName("galera").WithName("arbitrator")
+
+	clientSet := sqlClientSet.NewClientSet(mariadb, r.refResolver, r.sqlOpts...)
+	defer clientSet.Close()
+
+	primaryPodIndex := *mariadb.Galera().Primary.PodIndex
+	if mariadb.Status.CurrentPrimaryPodIndex != nil {
+		primaryPodIndex = *mariadb.Status.CurrentPrimaryPodIndex
+	}
+	sqlClient, err := clientSet.ClientForIndex(ctx, primaryPodIndex)
+	if err != nil {
+		return fmt.Errorf("error getting primary client: %v", err)
+	}
+	group, err := sqlClient.SystemVariable(ctx, "wsrep_cluster_name")
+	if err != nil {
+		return fmt.Errorf("error getting Galera cluster name: %v", err)
+	}
+	if group == "" {
+		return fmt.Errorf("'wsrep_cluster_name' not found in Pod %d", primaryPodIndex)
+	}
+
+	key := mariadb.GaleraArbitratorKey()
+	desiredDeploy, err := r.builder.BuildGaleraArbitratorDeployment(mariadb, key, group)
+	if err != nil {
+		return fmt.Errorf("error building arbitrator Deployment: %v", err)
+	}
+	if err := r.deploymentReconciler.Reconcile(ctx, desiredDeploy); err != nil {
+		return fmt.Errorf("error reconciling arbitrator Deployment: %v", err)
+	}
+
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, key, &deploy); err != nil {
+		return fmt.Errorf("error getting arbitrator Deployment: %v", err)
+	}
+	clusterSize, err := sqlClient.GaleraClusterSize(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Galera cluster size: %v", err)
+	}
+	arbitratorStatus := &mariadbv1alpha1.GaleraArbitratorStatus{
+		Ready:       deploy.Status.ReadyReplicas > 0,
+		ClusterSize: clusterSize,
+	}
+	if reflect.DeepEqual(mariadb.Status.GaleraArbitrator, arbitratorStatus) {
+		return nil
+	}
+
+	wasReady := mariadb.Status.GaleraArbitrator != nil && mariadb.Status.GaleraArbitrator.Ready
+	if arbitratorStatus.Ready && !wasReady {
+		logger.Info("Galera arbitrator ready", "cluster-size", clusterSize)
+		r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraArbitratorReady,
+			"Galera arbitrator ready, cluster size is %d", clusterSize)
+	}
+	if !arbitratorStatus.Ready && wasReady {
+		logger.Info("Galera arbitrator not ready", "cluster-size", clusterSize)
+		r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonGaleraArbitratorNotReady,
+			"Galera arbitrator not ready, cluster size is %d", clusterSize)
+	}
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.GaleraArbitrator = arbitratorStatus
+	})
+}
+
+func (r *GaleraReconciler) scaleDownArbitrator(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, mariadb.GaleraArbitratorKey(), &deploy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if deploy.Spec.Replicas != nil && *deploy.Spec.Replicas == 0 {
+		return nil
+	}
+	patch := client.MergeFrom(deploy.DeepCopy())
+	deploy.Spec.Replicas = ptr.To(int32(0))
+	if err := r.Patch(ctx, &deploy, patch); err != nil {
+		return fmt.Errorf("error scaling down arbitrator Deployment: %v", err)
+	}
+	return nil
+}
+
+func (r *GaleraReconciler) deleteArbitrator(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if mariadb.Status.GaleraArbitrator == nil && mariadb.Galera().Arbitrator == nil {
+		return nil
+	}
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, mariadb.GaleraArbitratorKey(), &deploy); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting arbitrator Deployment: %v", err)
+		}
+	} else if metav1.IsControlledBy(&deploy, mariadb) {
+		if err := r.Delete(ctx, &deploy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("error deleting arbitrator Deployment: %v", err)
+		}
+	}
+	if mariadb.Status.GaleraArbitrator == nil {
+		return nil
+	}
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.GaleraArbitrator = nil
+	})
+}
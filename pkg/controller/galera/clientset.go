@@ -49,10 +49,10 @@ func (a *agentClientSet) clientForIndex(index int) (*client.Client, error) {
 }
 
 func (c *agentClientSet) validateIndex(index int) error {
-	if index >= 0 && index < int(c.mariadb.Spec.Replicas) {
+	if index >= 0 && index < int(c.mariadb.ScaledReplicas()) {
 		return nil
 	}
-	return fmt.Errorf("index '%d' out of MariaDB replicas bounds [0, %d]", index, c.mariadb.Spec.Replicas-1)
+	return fmt.Errorf("index '%d' out of MariaDB replicas bounds [0, %d]", index, c.mariadb.ScaledReplicas()-1)
 }
 
 func baseUrl(mariadb *mariadbv1alpha1.MariaDB, index int) string {
@@ -0,0 +1,141 @@
+package galera
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClientSet "github.com/mariadb-operator/mariadb-operator/pkg/sqlset"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const providerOptionsVariable = "wsrep_provider_options"
+
+// driftVariables are the wsrep settings that must be consistent across the Galera nodes.
+var driftVariables = []string{
+	"wsrep_cluster_name",
+	"wsrep_provider",
+	"wsrep_sst_method",
+	"wsrep_slave_threads",
+	providerOptionsVariable,
+}
+
+// nodeProviderOptions are the provider options that are expected to be different in each node.
+var nodeProviderOptions = map[string]struct{}{
+	"base_dir":           {},
+	"base_host":          {},
+	"base_port":          {},
+	"gcache.dir":         {},
+	"gcache.name":        {},
+	"gmcast.listen_addr": {},
+	"gmcast.segment":     {},
+	"ist.recv_addr":      {},
+	"ist.recv_bind":      {},
+	"pc.weight":          {},
+}
+
+// reconcileConfigDrift compares the wsrep settings of all the Galera nodes and reports the ones that are not consistent.
+func (r *GaleraReconciler) reconcileConfigDrift(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, logger logr.Logger) error {
+	clientSet := sqlClientSet.NewClientSet(mariadb, r.refResolver, r.sqlOpts...)
+	defer clientSet.Close()
+
+	settingsByPod := make(map[string]map[string]string, mariadb.ScaledReplicas())
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		podName := statefulset.PodName(mariadb.ObjectMeta, i)
+		client, err := clientSet.ClientForIndex(ctx, i)
+		if err != nil {
+			return fmt.Errorf("error getting client for Pod '%s': %v", podName, err)
+		}
+		settings := make(map[string]string, len(driftVariables))
+		for _, variable := range driftVariables {
+			value, err := client.SystemVariable(ctx, variable)
+			if err != nil {
+				return fmt.Errorf("error getting variable '%s' in Pod '%s': %v", variable, podName, err)
+			}
+			if variable == providerOptionsVariable {
+				for k, v := range parseProviderOptions(value) {
+					settings[fmt.Sprintf("%s.%s", providerOptionsVariable, k)] = v
+				}
+				continue
+			}
+			settings[variable] = value
+		}
+		settingsByPod[podName] = settings
+	}
+
+	drift := configDrift(settingsByPod)
+	if reflect.DeepEqual(drift, mariadb.Status.GaleraConfigDrift) {
+		return nil
+	}
+	if len(drift) > 0 {
+		variables := make([]string, len(drift))
+		for i, d := range drift {
+			variables[i] = d.Variable
+		}
+		logger.Info("Galera configuration drift detected", "variables", variables)
+		r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonGaleraConfigDrift,
+			"Galera configuration drift detected in variables: %s", strings.Join(variables, ", "))
+	} else {
+		logger.Info("Galera configuration is consistent")
+		r.recorder.Event(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraConfigConsistent,
+			"Galera configuration is consistent across all nodes")
+	}
+
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.GaleraConfigDrift = drift
+	})
+}
+
+// configDrift returns the settings that have different values across the Pods, sorted by variable.
+func configDrift(settingsByPod map[string]map[string]string) []mariadbv1alpha1.GaleraConfigDrift {
+	variables := make(map[string]struct{})
+	for _, settings := range settingsByPod {
+		for variable := range settings {
+			variables[variable] = struct{}{}
+		}
+	}
+
+	var drift []mariadbv1alpha1.GaleraConfigDrift
+	for variable := range variables {
+		values := make(map[string]string, len(settingsByPod))
+		distinct := make(map[string]struct{})
+		for pod, settings := range settingsByPod {
+			value := settings[variable]
+			values[pod] = value
+			distinct[value] = struct{}{}
+		}
+		if len(distinct) > 1 {
+			drift = append(drift, mariadbv1alpha1.GaleraConfigDrift{
+				Variable: variable,
+				Values:   values,
+			})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		return drift[i].Variable < drift[j].Variable
+	})
+	return drift
+}
+
+// parseProviderOptions parses the 'key = value; key = value' format of wsrep_provider_options,
+// excluding the options that are expected to be different in each node.
+func parseProviderOptions(raw string) map[string]string {
+	options := make(map[string]string)
+	for _, option := range strings.Split(raw, ";") {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if _, ok := nodeProviderOptions[key]; ok {
+			continue
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+	return options
+}
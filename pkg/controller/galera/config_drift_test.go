@@ -0,0 +1,101 @@
+package galera
+
+import (
+	"reflect"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+)
+
+func TestParseProviderOptions(t *testing.T) {
+	raw := "base_dir = /var/lib/mysql/; base_host = 10.244.0.12; evs.suspect_timeout = PT5S; gcache.size = 1G; " +
+		"gmcast.segment = 0; ist.recv_addr = 10.244.0.12; pc.weight = 1; repl.causal_read_timeout = PT30S"
+	want := map[string]string{
+		"evs.suspect_timeout":      "PT5S",
+		"gcache.size":              "1G",
+		"repl.causal_read_timeout": "PT30S",
+	}
+	if got := parseProviderOptions(raw); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected provider options, expected: %v got: %v", want, got)
+	}
+}
+
+func TestConfigDrift(t *testing.T) {
+	tests := []struct {
+		name          string
+		settingsByPod map[string]map[string]string
+		wantDrift     []mariadbv1alpha1.GaleraConfigDrift
+	}{
+		{
+			name: "consistent",
+			settingsByPod: map[string]map[string]string{
+				"mariadb-galera-0": {
+					"wsrep_cluster_name":                 "mariadb-operator",
+					"wsrep_provider_options.gcache.size": "1G",
+				},
+				"mariadb-galera-1": {
+					"wsrep_cluster_name":                 "mariadb-operator",
+					"wsrep_provider_options.gcache.size": "1G",
+				},
+			},
+			wantDrift: nil,
+		},
+		{
+			name: "drift",
+			settingsByPod: map[string]map[string]string{
+				"mariadb-galera-0": {
+					"wsrep_cluster_name":                 "mariadb-operator",
+					"wsrep_sst_method":                   "mariabackup",
+					"wsrep_provider_options.gcache.size": "1G",
+				},
+				"mariadb-galera-1": {
+					"wsrep_cluster_name":                 "mariadb-operator",
+					"wsrep_sst_method":                   "rsync",
+					"wsrep_provider_options.gcache.size": "2G",
+				},
+			},
+			wantDrift: []mariadbv1alpha1.GaleraConfigDrift{
+				{
+					Variable: "wsrep_provider_options.gcache.size",
+					Values: map[string]string{
+						"mariadb-galera-0": "1G",
+						"mariadb-galera-1": "2G",
+					},
+				},
+				{
+					Variable: "wsrep_sst_method",
+					Values: map[string]string{
+						"mariadb-galera-0": "mariabackup",
+						"mariadb-galera-1": "rsync",
+					},
+				},
+			},
+		},
+		{
+			name: "missing provider option",
+			settingsByPod: map[string]map[string]string{
+				"mariadb-galera-0": {
+					"wsrep_provider_options.evs.suspect_timeout": "PT5S",
+				},
+				"mariadb-galera-1": {},
+			},
+			wantDrift: []mariadbv1alpha1.GaleraConfigDrift{
+				{
+					Variable: "wsrep_provider_options.evs.suspect_timeout",
+					Values: map[string]string{
+						"mariadb-galera-0": "PT5S",
+						"mariadb-galera-1": "",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if drift := configDrift(tt.settingsByPod); !reflect.DeepEqual(drift, tt.wantDrift) {
+				t.Errorf("unexpected drift, expected: %v got: %v", tt.wantDrift, drift)
+			}
+		})
+	}
+}
@@ -10,9 +10,13 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/configmap"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/deployment"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/ratelimit"
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/service"
 	"github.com/mariadb-operator/mariadb-operator/pkg/environment"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -40,14 +44,37 @@ func WithServiceReconciler(sr *service.ServiceReconciler) Option {
 	}
 }
 
+func WithDeploymentReconciler(dr *deployment.DeploymentReconciler) Option {
+	return func(r *GaleraReconciler) {
+		r.deploymentReconciler = dr
+	}
+}
+
+// WithActionLimiter sets the ActionLimiter shared with the rest of controllers acting on the MariaDB Pods.
+func WithActionLimiter(l *ratelimit.ActionLimiter) Option {
+	return func(r *GaleraReconciler) {
+		r.limiter = l
+	}
+}
+
+// WithSqlOpts sets the options of the SQL clients used to connect to the MariaDB Pods.
+func WithSqlOpts(opts ...sqlClient.Opt) Option {
+	return func(r *GaleraReconciler) {
+		r.sqlOpts = opts
+	}
+}
+
 type GaleraReconciler struct {
 	client.Client
-	recorder            record.EventRecorder
-	env                 *environment.Environment
-	builder             *builder.Builder
-	refResolver         *refresolver.RefResolver
-	configMapReconciler *configmap.ConfigMapReconciler
-	serviceReconciler   *service.ServiceReconciler
+	recorder             record.EventRecorder
+	env                  *environment.Environment
+	builder              *builder.Builder
+	refResolver          *refresolver.RefResolver
+	configMapReconciler  *configmap.ConfigMapReconciler
+	serviceReconciler    *service.ServiceReconciler
+	deploymentReconciler *deployment.DeploymentReconciler
+	limiter              *ratelimit.ActionLimiter
+	sqlOpts              []sqlClient.Opt
 }
 
 func NewGaleraReconciler(client client.Client, recorder record.EventRecorder, env *environment.Environment, builder *builder.Builder,
@@ -70,6 +97,12 @@ func NewGaleraReconciler(client client.Client, recorder record.EventRecorder, en
 	if r.serviceReconciler == nil {
 		r.serviceReconciler = service.NewServiceReconciler(client)
 	}
+	if r.deploymentReconciler == nil {
+		r.deploymentReconciler = deployment.NewDeploymentReconciler(client)
+	}
+	if r.limiter == nil {
+		r.limiter = ratelimit.NewActionLimiter(client, recorder)
+	}
 	return r
 }
 
@@ -89,7 +122,7 @@ func (r *GaleraReconciler) Reconcile(ctx context.Context, mariadb *mariadbv1alph
 		}
 	}
 
-	if !mariadb.HasGaleraReadyCondition() && sts.Status.ReadyReplicas == mariadb.Spec.Replicas {
+	if !mariadb.HasGaleraReadyCondition() && sts.Status.ReadyReplicas == mariadb.ScaledReplicas() {
 		if err := r.disableBootstrap(ctx, mariadb, logger); err != nil {
 			return err
 		}
@@ -104,6 +137,12 @@ func (r *GaleraReconciler) Reconcile(ctx context.Context, mariadb *mariadbv1alph
 		}
 	}
 
+	if mariadb.HasGaleraReadyCondition() && sts.Status.ReadyReplicas == mariadb.ScaledReplicas() {
+		if err := r.reconcileConfigDrift(ctx, mariadb, logger.WithName("config-drift")); err != nil {
+			logger.V(1).Info("Unable to check Galera configuration drift", "err", err)
+		}
+	}
+
 	if mariadb.Status.CurrentPrimaryPodIndex != nil && *mariadb.Status.CurrentPrimaryPodIndex != *mariadb.Galera().Primary.PodIndex {
 		fromIndex := *mariadb.Status.CurrentPrimaryPodIndex
 		toIndex := *mariadb.Galera().Primary.PodIndex
@@ -136,12 +175,13 @@ func (r *GaleraReconciler) disableBootstrap(ctx context.Context, mariadb *mariad
 	if err != nil {
 		return fmt.Errorf("error creating agent client set: %v", err)
 	}
-	for i := 0; i < int(mariadb.Spec.Replicas); i++ {
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
 		agentClient, err := clientSet.clientForIndex(i)
 		if err != nil {
 			return fmt.Errorf("error creating agent client: %v", err)
 		}
 		if err := agentClient.Bootstrap.Disable(ctx); err != nil && !agentclient.IsNotFound(err) {
+			incAgentAPIErrors(mariadb, statefulset.PodName(mariadb.ObjectMeta, i), agentOperationBootstrapDisable)
 			return fmt.Errorf("error disabling bootstrap in Pod %d: %v", i, err)
 		}
 	}
@@ -0,0 +1,87 @@
+package galera
+
+import (
+	agentgalera "github.com/mariadb-operator/agent/pkg/galera"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	bootstrapDecisionBootstrap   = "bootstrap"
+	bootstrapDecisionRateLimited = "rate_limited"
+	bootstrapDecisionTimeout     = "timeout"
+
+	agentOperationState            = "state"
+	agentOperationRecoveryEnable   = "recovery_enable"
+	agentOperationRecoveryStart    = "recovery_start"
+	agentOperationRecoveryDisable  = "recovery_disable"
+	agentOperationBootstrapEnable  = "bootstrap_enable"
+	agentOperationBootstrapDisable = "bootstrap_disable"
+)
+
+var (
+	recoveryAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mariadb_operator_galera_recovery_attempts_total",
+		Help: "Number of attempts to recover the Galera sequence of a Pod.",
+	}, []string{"namespace", "mariadb", "pod"})
+	lastSeqno = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_galera_last_seqno",
+		Help: "Last Galera sequence number of a Pod, as fetched from its state or recovered during the cluster recovery.",
+	}, []string{"namespace", "mariadb", "pod"})
+	bootstrapDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mariadb_operator_galera_bootstrap_decisions_total",
+		Help: "Number of Galera cluster bootstrap decisions, by Pod and decision: bootstrap, rate_limited or timeout.",
+	}, []string{"namespace", "mariadb", "pod", "decision"})
+	agentAPIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mariadb_operator_galera_agent_api_errors_total",
+		Help: "Number of errors returned by the Galera agent API, by Pod and operation.",
+	}, []string{"namespace", "mariadb", "pod", "operation"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		recoveryAttempts,
+		lastSeqno,
+		bootstrapDecisions,
+		agentAPIErrors,
+	)
+}
+
+// DeleteGaleraMetrics stops reporting the Galera recovery metrics of a MariaDB.
+func DeleteGaleraMetrics(mariadbKey types.NamespacedName) {
+	labels := prometheus.Labels{
+		"namespace": mariadbKey.Namespace,
+		"mariadb":   mariadbKey.Name,
+	}
+	recoveryAttempts.DeletePartialMatch(labels)
+	lastSeqno.DeletePartialMatch(labels)
+	bootstrapDecisions.DeletePartialMatch(labels)
+	agentAPIErrors.DeletePartialMatch(labels)
+}
+
+func incRecoveryAttempts(mariadb *mariadbv1alpha1.MariaDB, pod string) {
+	recoveryAttempts.WithLabelValues(mariadb.Namespace, mariadb.Name, pod).Inc()
+}
+
+func setLastSeqno(mariadb *mariadbv1alpha1.MariaDB, pod string, recoverer agentgalera.GaleraRecoverer) {
+	lastSeqno.WithLabelValues(mariadb.Namespace, mariadb.Name, pod).Set(float64(recoverer.GetSeqno()))
+}
+
+func incBootstrapDecisions(mariadb *mariadbv1alpha1.MariaDB, pod, decision string) {
+	bootstrapDecisions.WithLabelValues(mariadb.Namespace, mariadb.Name, pod, decision).Inc()
+}
+
+func incAgentAPIErrors(mariadb *mariadbv1alpha1.MariaDB, pod, operation string) {
+	agentAPIErrors.WithLabelValues(mariadb.Namespace, mariadb.Name, pod, operation).Inc()
+}
+
+// agentAPICall calls fn, recording the errors it returns in the agent API errors metric.
+func agentAPICall(mariadb *mariadbv1alpha1.MariaDB, pod, operation string, fn func() error) error {
+	err := fn()
+	if err != nil {
+		incAgentAPIErrors(mariadb, pod, operation)
+	}
+	return err
+}
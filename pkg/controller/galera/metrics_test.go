@@ -0,0 +1,67 @@
+package galera
+
+import (
+	"errors"
+	"testing"
+
+	agentgalera "github.com/mariadb-operator/agent/pkg/galera"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGaleraMetrics(t *testing.T) {
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-galera-metrics",
+			Namespace: "default",
+		},
+	}
+	pod := "mariadb-galera-metrics-0"
+
+	incRecoveryAttempts(mariadb, pod)
+	incRecoveryAttempts(mariadb, pod)
+	if got := testutil.ToFloat64(recoveryAttempts.WithLabelValues(mariadb.Namespace, mariadb.Name, pod)); got != 2 {
+		t.Errorf("expecting 2 recovery attempts, got %v", got)
+	}
+
+	setLastSeqno(mariadb, pod, &agentgalera.GaleraState{Seqno: 3})
+	setLastSeqno(mariadb, pod, &agentgalera.Bootstrap{Seqno: 7})
+	if got := testutil.ToFloat64(lastSeqno.WithLabelValues(mariadb.Namespace, mariadb.Name, pod)); got != 7 {
+		t.Errorf("expecting last seqno to be 7, got %v", got)
+	}
+
+	incBootstrapDecisions(mariadb, pod, bootstrapDecisionRateLimited)
+	incBootstrapDecisions(mariadb, pod, bootstrapDecisionBootstrap)
+	if got := testutil.ToFloat64(
+		bootstrapDecisions.WithLabelValues(mariadb.Namespace, mariadb.Name, pod, bootstrapDecisionBootstrap),
+	); got != 1 {
+		t.Errorf("expecting 1 bootstrap decision, got %v", got)
+	}
+
+	if err := agentAPICall(mariadb, pod, agentOperationState, func() error { return nil }); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	wantErr := errors.New("connection refused")
+	if err := agentAPICall(mariadb, pod, agentOperationState, func() error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("expecting error '%v', got '%v'", wantErr, err)
+	}
+	if got := testutil.ToFloat64(
+		agentAPIErrors.WithLabelValues(mariadb.Namespace, mariadb.Name, pod, agentOperationState),
+	); got != 1 {
+		t.Errorf("expecting 1 agent API error, got %v", got)
+	}
+
+	DeleteGaleraMetrics(client.ObjectKeyFromObject(mariadb))
+	for name, count := range map[string]int{
+		"recovery attempts":   testutil.CollectAndCount(recoveryAttempts),
+		"last seqno":          testutil.CollectAndCount(lastSeqno),
+		"bootstrap decisions": testutil.CollectAndCount(bootstrapDecisions),
+		"agent API errors":    testutil.CollectAndCount(agentAPIErrors),
+	} {
+		if count != 0 {
+			t.Errorf("expecting %s metrics to be deleted, got %d", name, count)
+		}
+	}
+}
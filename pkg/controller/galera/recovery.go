@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
 	"github.com/mariadb-operator/agent/pkg/client"
+	agentgalera "github.com/mariadb-operator/agent/pkg/galera"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	mariadbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
@@ -31,7 +32,7 @@ func (r *GaleraReconciler) reconcileRecovery(ctx context.Context, mariadb *maria
 	if err != nil {
 		return fmt.Errorf("error getting agent client: %v", err)
 	}
-	sqlClientSet := sqlClientSet.NewClientSet(mariadb, r.refResolver)
+	sqlClientSet := sqlClientSet.NewClientSet(mariadb, r.refResolver, r.sqlOpts...)
 	defer sqlClientSet.Close()
 
 	if sts.Status.ReadyReplicas == 0 {
@@ -49,6 +50,7 @@ func (r *GaleraReconciler) recoverCluster(ctx context.Context, mariadb *mariadbv
 			logger.Info("Galera cluster bootstrap timed out. Resetting recovery status")
 			r.recorder.Event(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonGaleraClusterBootstrapTimeout,
 				"Galera cluster bootstrap timed out")
+			incBootstrapDecisions(mariadb, rs.bootstrappingPod(), bootstrapDecisionTimeout)
 
 			rs.reset()
 			return r.patchRecoveryStatus(ctx, mariadb, rs)
@@ -73,10 +75,7 @@ func (r *GaleraReconciler) recoverCluster(ctx context.Context, mariadb *mariadbv
 		logger.V(1).Info("Error getting bootstrap source", "err", err)
 	}
 	if src != nil {
-		if err := r.bootstrap(ctx, src, rs, mariadb, clientSet, logger); err != nil {
-			return fmt.Errorf("error bootstrapping: %v", err)
-		}
-		return r.patchRecoveryStatus(ctx, mariadb, rs)
+		return r.planAndBootstrap(ctx, src, rs, mariadb, pods, clientSet, logger)
 	}
 
 	logger.V(1).Info("Recovery by Pod")
@@ -95,8 +94,28 @@ func (r *GaleraReconciler) recoverCluster(ctx context.Context, mariadb *mariadbv
 	if err != nil {
 		return fmt.Errorf("error getting bootstrap source: %v", err)
 	}
-	if err := r.bootstrap(ctx, src, rs, mariadb, clientSet, logger); err != nil {
-		return fmt.Errorf("error bootstrapping: %v", err)
+	return r.planAndBootstrap(ctx, src, rs, mariadb, pods, clientSet, logger)
+}
+
+func (r *GaleraReconciler) planAndBootstrap(ctx context.Context, src *bootstrapSource, rs *recoveryStatus,
+	mariadb *mariadbv1alpha1.MariaDB, pods []corev1.Pod, clientSet *agentClientSet, logger logr.Logger) error {
+	if r.reconcileRecoveryPlan(mariadb, pods, rs, src, logger) {
+		allowed, _, err := r.limiter.Allow(ctx, mariadb, mariadbv1alpha1.DisruptiveActionClusterBootstrap, src.pod.Name)
+		if err != nil {
+			return fmt.Errorf("error checking action rate limit: %v", err)
+		}
+		if !allowed {
+			logger.Info("Galera cluster bootstrap rate limited", "pod", src.pod.Name)
+			incBootstrapDecisions(mariadb, src.pod.Name, bootstrapDecisionRateLimited)
+			return r.patchRecoveryStatus(ctx, mariadb, rs)
+		}
+		if err := r.bootstrap(ctx, src, rs, mariadb, clientSet, logger); err != nil {
+			return fmt.Errorf("error bootstrapping: %v", err)
+		}
+		if err := r.patchRecoveryStatus(ctx, mariadb, rs); err != nil {
+			return err
+		}
+		return r.clearRecoveryApproval(ctx, mariadb)
 	}
 	return r.patchRecoveryStatus(ctx, mariadb, rs)
 }
@@ -178,6 +197,15 @@ func (r *GaleraReconciler) recoverPod(ctx context.Context, mariadb *mariadbv1alp
 			return fmt.Errorf("error getting Pod '%s': %v", podKey.Name, err)
 		}
 
+		allowed, _, err := r.limiter.Allow(ctx, mariadb, mariadbv1alpha1.DisruptiveActionPodDeletion, pod.Name)
+		if err != nil {
+			return fmt.Errorf("error checking action rate limit: %v", err)
+		}
+		if !allowed {
+			logger.Info("Pod deletion rate limited", "pod", pod.Name)
+			return nil
+		}
+
 		deleteCtx, cancelDelete := context.WithTimeout(ctx, 30*time.Second)
 		defer cancelDelete()
 		if err := pollUntilSucessWithTimeout(deleteCtx, logger, func(ctx context.Context) error {
@@ -191,7 +219,7 @@ func (r *GaleraReconciler) recoverPod(ctx context.Context, mariadb *mariadbv1alp
 
 func (r *GaleraReconciler) pods(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) ([]corev1.Pod, error) {
 	var pods []corev1.Pod
-	for i := 0; i < int(mariadb.Spec.Replicas); i++ {
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
 		key := types.NamespacedName{
 			Name:      statefulset.PodName(mariadb.ObjectMeta, i),
 			Namespace: mariadb.Namespace,
@@ -217,163 +245,175 @@ func (r *GaleraReconciler) notReadyPods(pods []corev1.Pod) []corev1.Pod {
 	return notReadyPods
 }
 
+// stateByPod fetches the Galera state of all the Pods in parallel, skipping the ones already cached in the recovery status.
+// It waits for all the Pods to report before returning, so a failure in one of them does not discard the state of the rest.
 func (r *GaleraReconciler) stateByPod(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, pods []corev1.Pod, rs *recoveryStatus,
 	clientSet *agentClientSet, logger logr.Logger) error {
-	doneChan := make(chan struct{})
-	errChan := make(chan error)
-
-	var wg sync.WaitGroup
+	var statePods []corev1.Pod
 	for _, pod := range pods {
 		if _, ok := rs.state(pod.Name); ok {
 			logger.V(1).Info("Skipping Pod state", "pod", pod.Name)
 			continue
 		}
+		statePods = append(statePods, pod)
+	}
 
-		i, err := statefulset.PodIndex(pod.Name)
+	return forEachPod(ctx, statePods, func(i int, pod corev1.Pod) error {
+		client, err := clientSet.clientForIndex(i)
 		if err != nil {
-			return fmt.Errorf("error getting index for Pod '%s': %v", pod.Name, err)
+			return fmt.Errorf("error getting client for Pod '%s': %v", pod.Name, err)
 		}
 
-		wg.Add(1)
-		go func(i int, pod corev1.Pod) {
-			defer wg.Done()
-
-			client, err := clientSet.clientForIndex(i)
-			if err != nil {
-				errChan <- fmt.Errorf("error getting client for Pod '%s': %v", pod.Name, err)
-				return
-			}
-
-			stateCtx, cancelState := context.WithTimeout(ctx, 30*time.Second)
-			defer cancelState()
-			if err = pollUntilSucessWithTimeout(stateCtx, logger, func(ctx context.Context) error {
-				galeraState, err := client.GaleraState.Get(ctx)
-				if err != nil {
-					return err
-				}
-
-				logger.Info("Galera state fetched in Pod", "pod", pod.Name)
-				r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraPodStateFetched,
-					"Galera state fetched in Pod '%s'", pod.Name)
-				rs.setState(pod.Name, galeraState)
-				return nil
+		stateCtx, cancelState := context.WithTimeout(ctx, 30*time.Second)
+		defer cancelState()
+		if err = pollUntilSucessWithTimeout(stateCtx, logger, func(ctx context.Context) error {
+			var galeraState *agentgalera.GaleraState
+			if err := agentAPICall(mariadb, pod.Name, agentOperationState, func() (err error) {
+				galeraState, err = client.GaleraState.Get(ctx)
+				return err
 			}); err != nil {
-				errChan <- fmt.Errorf("error getting Galera state for Pod '%s': %v", pod.Name, err)
+				return err
 			}
-		}(*i, pod)
-	}
-	go func() {
-		wg.Wait()
-		close(doneChan)
-	}()
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-doneChan:
+			logger.Info("Galera state fetched in Pod", "pod", pod.Name)
+			r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraPodStateFetched,
+				"Galera state fetched in Pod '%s'", pod.Name)
+			rs.setState(pod.Name, galeraState)
+			setLastSeqno(mariadb, pod.Name, galeraState)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error getting Galera state for Pod '%s': %v", pod.Name, err)
+		}
 		return nil
-	case err := <-errChan:
-		return err
-	}
+	})
 }
 
+// recoveryByPod recovers the sequence of the Pods in parallel. Only the Pods without a valid sequence in their Galera state
+// are recovered, as the recovery requires restarting the Pod, and the Pods already recovered are skipped.
 func (r *GaleraReconciler) recoveryByPod(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, pods []corev1.Pod, rs *recoveryStatus,
 	clientSet *agentClientSet, logger logr.Logger) error {
-	doneChan := make(chan struct{})
-	errChan := make(chan error)
-
-	var wg sync.WaitGroup
+	recoveryPods := rs.pendingRecovery(pods)
 	for _, pod := range pods {
-		if _, ok := rs.recovered(pod.Name); ok {
+		if !containsPod(recoveryPods, pod.Name) {
 			logger.V(1).Info("Skipping Pod recovery", "pod", pod.Name)
-			continue
 		}
+	}
 
-		i, err := statefulset.PodIndex(pod.Name)
+	return forEachPod(ctx, recoveryPods, func(i int, pod corev1.Pod) error {
+		client, err := clientSet.clientForIndex(i)
 		if err != nil {
-			return fmt.Errorf("error getting index for Pod '%s': %v", pod.Name, err)
+			return fmt.Errorf("error getting client for Pod '%s': %v", pod.Name, err)
 		}
 
-		wg.Add(1)
-		go func(i int, pod corev1.Pod) {
-			defer wg.Done()
-
-			client, err := clientSet.clientForIndex(i)
-			if err != nil {
-				errChan <- fmt.Errorf("error getting client for Pod '%s': %v", pod.Name, err)
-				return
-			}
-
-			logger.V(1).Info("Enabling recovery", "pod", pod.Name)
-			enableCtx, cancelEnable := context.WithTimeout(ctx, 30*time.Second)
-			defer cancelEnable()
-			if err = pollUntilSucessWithTimeout(enableCtx, logger, func(ctx context.Context) error {
+		logger.V(1).Info("Enabling recovery", "pod", pod.Name)
+		enableCtx, cancelEnable := context.WithTimeout(ctx, 30*time.Second)
+		defer cancelEnable()
+		if err = pollUntilSucessWithTimeout(enableCtx, logger, func(ctx context.Context) error {
+			return agentAPICall(mariadb, pod.Name, agentOperationRecoveryEnable, func() error {
 				return client.Recovery.Enable(ctx)
-			}); err != nil {
-				errChan <- fmt.Errorf("error enabling recovery in Pod '%s': %v", pod.Name, err)
-				return
-			}
+			})
+		}); err != nil {
+			return fmt.Errorf("error enabling recovery in Pod '%s': %v", pod.Name, err)
+		}
 
-			deleteCtx, cancelDelete := context.WithTimeout(ctx, 3*time.Minute)
-			defer cancelDelete()
-			go func() {
-				ticker := time.NewTicker(30 * time.Second)
-				for {
-					select {
-					case <-deleteCtx.Done():
-						return
-					case <-ticker.C:
-						logger.V(1).Info("Deleting Pod", "pod", pod.Name)
-						if err := r.Delete(ctx, &pod); err != nil {
-							logger.V(1).Info("Error deleting Pod", "pod", pod.Name, "err", err)
-						}
+		deleteCtx, cancelDelete := context.WithTimeout(ctx, 3*time.Minute)
+		defer cancelDelete()
+		go func() {
+			ticker := time.NewTicker(30 * time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-deleteCtx.Done():
+					return
+				case <-ticker.C:
+					logger.V(1).Info("Deleting Pod", "pod", pod.Name)
+					if err := r.Delete(ctx, &pod); err != nil {
+						logger.V(1).Info("Error deleting Pod", "pod", pod.Name, "err", err)
 					}
 				}
-			}()
-
-			logger.V(1).Info("Performing recovery", "pod", pod.Name)
-			recoveryCtx, cancelRecovery := context.WithTimeout(ctx, mariadb.Galera().Recovery.PodRecoveryTimeout.Duration)
-			defer cancelRecovery()
-			if err = pollUntilSucessWithTimeout(recoveryCtx, logger, func(ctx context.Context) error {
-				bootstrap, err := client.Recovery.Start(ctx)
-				if err != nil {
-					return err
-				}
-
-				logger.Info("Recovered Galera sequence in Pod", "pod", pod.Name)
-				r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraPodRecovered,
-					"Recovered Galera sequence in Pod '%s'", pod.Name)
-				rs.setRecovered(pod.Name, bootstrap)
-				return nil
+			}
+		}()
+
+		logger.V(1).Info("Performing recovery", "pod", pod.Name)
+		incRecoveryAttempts(mariadb, pod.Name)
+		recoveryCtx, cancelRecovery := context.WithTimeout(ctx, mariadb.Galera().Recovery.PodRecoveryTimeout.Duration)
+		defer cancelRecovery()
+		if err = pollUntilSucessWithTimeout(recoveryCtx, logger, func(ctx context.Context) error {
+			var bootstrap *agentgalera.Bootstrap
+			if err := agentAPICall(mariadb, pod.Name, agentOperationRecoveryStart, func() (err error) {
+				bootstrap, err = client.Recovery.Start(ctx)
+				return err
 			}); err != nil {
-				errChan <- fmt.Errorf("error performing recovery in Pod '%s': %v", pod.Name, err)
-				return
+				return err
 			}
-			cancelDelete()
 
-			logger.V(1).Info("Disabling recovery", "pod", pod.Name)
-			disableCtx, cancelDisable := context.WithTimeout(ctx, 30*time.Second)
-			defer cancelDisable()
-			if err = pollUntilSucessWithTimeout(disableCtx, logger, func(ctx context.Context) error {
+			logger.Info("Recovered Galera sequence in Pod", "pod", pod.Name)
+			r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraPodRecovered,
+				"Recovered Galera sequence in Pod '%s'", pod.Name)
+			rs.setRecovered(pod.Name, bootstrap)
+			setLastSeqno(mariadb, pod.Name, bootstrap)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error performing recovery in Pod '%s': %v", pod.Name, err)
+		}
+		cancelDelete()
+
+		logger.V(1).Info("Disabling recovery", "pod", pod.Name)
+		disableCtx, cancelDisable := context.WithTimeout(ctx, 30*time.Second)
+		defer cancelDisable()
+		if err = pollUntilSucessWithTimeout(disableCtx, logger, func(ctx context.Context) error {
+			return agentAPICall(mariadb, pod.Name, agentOperationRecoveryDisable, func() error {
 				return client.Recovery.Disable(ctx)
-			}); err != nil {
-				errChan <- fmt.Errorf("error disabling recovery in Pod '%s': %v", pod.Name, err)
+			})
+		}); err != nil {
+			return fmt.Errorf("error disabling recovery in Pod '%s': %v", pod.Name, err)
+		}
+		return nil
+	})
+}
+
+// forEachPod calls fn for each Pod in parallel and waits for all of them to finish, aggregating the errors.
+func forEachPod(ctx context.Context, pods []corev1.Pod, fn func(index int, pod corev1.Pod) error) error {
+	var (
+		wg   sync.WaitGroup
+		mux  sync.Mutex
+		errs *multierror.Error
+	)
+	for _, pod := range pods {
+		i, err := statefulset.PodIndex(pod.Name)
+		if err != nil {
+			mux.Lock()
+			errs = multierror.Append(errs, fmt.Errorf("error getting index for Pod '%s': %v", pod.Name, err))
+			mux.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, pod corev1.Pod) {
+			defer wg.Done()
+
+			if err := fn(i, pod); err != nil {
+				mux.Lock()
+				errs = multierror.Append(errs, err)
+				mux.Unlock()
 			}
 		}(*i, pod)
 	}
-	go func() {
-		wg.Wait()
-		close(doneChan)
-	}()
+	wg.Wait()
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-doneChan:
-		return nil
-	case err := <-errChan:
+	if err := ctx.Err(); err != nil {
 		return err
 	}
+	return errs.ErrorOrNil()
+}
+
+func containsPod(pods []corev1.Pod, name string) bool {
+	for _, p := range pods {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *GaleraReconciler) bootstrap(ctx context.Context, src *bootstrapSource, rs *recoveryStatus, mdb *mariadbv1alpha1.MariaDB,
@@ -394,7 +434,9 @@ func (r *GaleraReconciler) bootstrap(ctx context.Context, src *bootstrapSource,
 	bootstrapCtx, cancelBootstrap := context.WithTimeout(ctx, 30*time.Second)
 	defer cancelBootstrap()
 	if err = pollUntilSucessWithTimeout(bootstrapCtx, logger, func(ctx context.Context) error {
-		return client.Bootstrap.Enable(ctx, src.bootstrap)
+		return agentAPICall(mdb, src.pod.Name, agentOperationBootstrapEnable, func() error {
+			return client.Bootstrap.Enable(ctx, src.bootstrap)
+		})
 	}); err != nil {
 		return fmt.Errorf("error enabling bootstrap in Pod '%s': %v", src.pod.Name, err)
 	}
@@ -408,6 +450,7 @@ func (r *GaleraReconciler) bootstrap(ctx context.Context, src *bootstrapSource,
 	}
 
 	rs.setBootstrapping(src.pod.Name)
+	incBootstrapDecisions(mdb, src.pod.Name, bootstrapDecisionBootstrap)
 	return nil
 }
 
@@ -0,0 +1,124 @@
+package galera
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (rs *recoveryStatus) setPlan(plan *mariadbv1alpha1.GaleraRecoveryPlan) {
+	rs.mux.Lock()
+	defer rs.mux.Unlock()
+
+	rs.inner.Plan = plan
+}
+
+func (rs *recoveryStatus) plan() *mariadbv1alpha1.GaleraRecoveryPlan {
+	rs.mux.RLock()
+	defer rs.mux.RUnlock()
+
+	return rs.inner.Plan
+}
+
+// recoveryPlan computes the plan to bootstrap the cluster from the given source, based on the sequences found in the Pods.
+func (rs *recoveryStatus) recoveryPlan(mariadb *mariadbv1alpha1.MariaDB, pods []corev1.Pod,
+	src *bootstrapSource) *mariadbv1alpha1.GaleraRecoveryPlan {
+	rs.mux.RLock()
+	defer rs.mux.RUnlock()
+
+	plan := &mariadbv1alpha1.GaleraRecoveryPlan{
+		BootstrapPod: src.pod.Name,
+	}
+	var joiners []string
+	for _, p := range pods {
+		planPod := mariadbv1alpha1.GaleraRecoveryPlanPod{
+			Pod:   p.Name,
+			Seqno: -1,
+		}
+		if state := rs.inner.State[p.Name]; state != nil {
+			planPod.UUID = state.UUID
+			planPod.Seqno = state.Seqno
+			planPod.SafeToBootstrap = state.SafeToBootstrap
+		}
+		if recovered := rs.inner.Recovered[p.Name]; recovered != nil && recovered.Seqno > planPod.Seqno {
+			planPod.UUID = recovered.UUID
+			planPod.Seqno = recovered.Seqno
+		}
+		plan.Pods = append(plan.Pods, planPod)
+
+		if p.Name != src.pod.Name {
+			joiners = append(joiners, p.Name)
+		}
+	}
+
+	plan.Actions = []string{
+		fmt.Sprintf("Enable bootstrap in Pod '%s' with UUID '%s' and seqno %d", src.pod.Name, src.bootstrap.UUID, src.bootstrap.Seqno),
+		fmt.Sprintf("Restart Pod '%s' to bootstrap a new cluster", src.pod.Name),
+	}
+	if len(joiners) > 0 {
+		plan.Actions = append(plan.Actions,
+			fmt.Sprintf("Wait for Pods '%s' to join the cluster, restarting them if they are not Synced within %s",
+				strings.Join(joiners, "', '"), mariadb.Galera().Recovery.PodSyncTimeout.Duration))
+	}
+
+	recovery := mariadb.Galera().Recovery
+	plan.DryRun = recovery.DryRun
+	plan.PendingApproval = recovery.RequireApproval && !isRecoveryApproved(mariadb, plan)
+	return plan
+}
+
+// isRecoveryApproved checks whether the plan has been approved by annotating the MariaDB with the Pod chosen to bootstrap the cluster.
+func isRecoveryApproved(mariadb *mariadbv1alpha1.MariaDB, plan *mariadbv1alpha1.GaleraRecoveryPlan) bool {
+	approved, ok := mariadb.Annotations[metadata.GaleraRecoveryApprovedAnnotation]
+	return ok && approved == plan.BootstrapPod
+}
+
+// reconcileRecoveryPlan publishes the recovery plan and returns whether the cluster can be bootstrapped.
+func (r *GaleraReconciler) reconcileRecoveryPlan(mariadb *mariadbv1alpha1.MariaDB, pods []corev1.Pod, rs *recoveryStatus,
+	src *bootstrapSource, logger logr.Logger) bool {
+	plan := rs.recoveryPlan(mariadb, pods, src)
+	if !reflect.DeepEqual(rs.plan(), plan) {
+		logger.Info("Galera recovery plan", "bootstrap-pod", plan.BootstrapPod, "actions", plan.Actions,
+			"dry-run", plan.DryRun, "pending-approval", plan.PendingApproval)
+		r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonGaleraRecoveryPlan,
+			"Galera recovery plan: %s", strings.Join(plan.Actions, ". "))
+
+		if plan.PendingApproval {
+			r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonGaleraRecoveryPendingApproval,
+				"Galera recovery plan pending approval. Set the '%s' annotation to '%s' to approve it",
+				metadata.GaleraRecoveryApprovedAnnotation, plan.BootstrapPod)
+		}
+	}
+	rs.setPlan(plan)
+
+	if plan.DryRun {
+		logger.V(1).Info("Galera recovery in dry run mode. Skipping bootstrap")
+		return false
+	}
+	if plan.PendingApproval {
+		logger.V(1).Info("Galera recovery plan pending approval. Skipping bootstrap")
+		return false
+	}
+	return true
+}
+
+// clearRecoveryApproval removes the approval once the plan has been executed, so it is not reused by subsequent recoveries.
+func (r *GaleraReconciler) clearRecoveryApproval(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if _, ok := mariadb.Annotations[metadata.GaleraRecoveryApprovedAnnotation]; !ok {
+		return nil
+	}
+	patch := ctrlclient.MergeFrom(mariadb.DeepCopy())
+	delete(mariadb.Annotations, metadata.GaleraRecoveryApprovedAnnotation)
+
+	if err := r.Patch(ctx, mariadb, patch); err != nil {
+		return fmt.Errorf("error clearing Galera recovery approval: %v", err)
+	}
+	return nil
+}
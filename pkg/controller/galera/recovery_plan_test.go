@@ -0,0 +1,123 @@
+package galera
+
+import (
+	"reflect"
+	"testing"
+
+	agentgalera "github.com/mariadb-operator/agent/pkg/galera"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecoveryPlan(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "mariadb-galera-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mariadb-galera-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "mariadb-galera-2"}},
+	}
+	uuid := "dfc4e849-1c90-43b0-a2c8-0b777c1ce6e4"
+
+	rs := newRecoveryStatus(&mariadbv1alpha1.MariaDB{})
+	rs.setState("mariadb-galera-0", &agentgalera.GaleraState{UUID: uuid, Seqno: -1})
+	rs.setState("mariadb-galera-1", &agentgalera.GaleraState{UUID: uuid, Seqno: 5})
+	rs.setState("mariadb-galera-2", &agentgalera.GaleraState{UUID: uuid, Seqno: -1})
+	rs.setRecovered("mariadb-galera-0", &agentgalera.Bootstrap{UUID: uuid, Seqno: 7})
+	rs.setRecovered("mariadb-galera-2", &agentgalera.Bootstrap{UUID: uuid, Seqno: 3})
+
+	src, err := rs.bootstrapSource(pods)
+	if err != nil {
+		t.Fatalf("unexpected error getting bootstrap source: %v", err)
+	}
+
+	tests := []struct {
+		name                string
+		recovery            mariadbv1alpha1.GaleraRecovery
+		annotations         map[string]string
+		wantDryRun          bool
+		wantPendingApproval bool
+	}{
+		{
+			name: "no approval required",
+		},
+		{
+			name: "dry run",
+			recovery: mariadbv1alpha1.GaleraRecovery{
+				Enabled: true,
+				DryRun:  true,
+			},
+			wantDryRun: true,
+		},
+		{
+			name: "pending approval",
+			recovery: mariadbv1alpha1.GaleraRecovery{
+				Enabled:         true,
+				RequireApproval: true,
+			},
+			wantPendingApproval: true,
+		},
+		{
+			name: "approval for another Pod",
+			recovery: mariadbv1alpha1.GaleraRecovery{
+				Enabled:         true,
+				RequireApproval: true,
+			},
+			annotations: map[string]string{
+				metadata.GaleraRecoveryApprovedAnnotation: "mariadb-galera-1",
+			},
+			wantPendingApproval: true,
+		},
+		{
+			name: "approved",
+			recovery: mariadbv1alpha1.GaleraRecovery{
+				Enabled:         true,
+				RequireApproval: true,
+			},
+			annotations: map[string]string{
+				metadata.GaleraRecoveryApprovedAnnotation: "mariadb-galera-0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recovery := tt.recovery
+			mariadb := &mariadbv1alpha1.MariaDB{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+						GaleraSpec: mariadbv1alpha1.GaleraSpec{
+							Recovery: &recovery,
+						},
+					},
+				},
+			}
+
+			plan := rs.recoveryPlan(mariadb, pods, src)
+			if plan.BootstrapPod != "mariadb-galera-0" {
+				t.Errorf("unexpected bootstrap Pod: expected: mariadb-galera-0, got: %s", plan.BootstrapPod)
+			}
+			wantPods := []mariadbv1alpha1.GaleraRecoveryPlanPod{
+				{Pod: "mariadb-galera-0", UUID: uuid, Seqno: 7},
+				{Pod: "mariadb-galera-1", UUID: uuid, Seqno: 5},
+				{Pod: "mariadb-galera-2", UUID: uuid, Seqno: 3},
+			}
+			if !reflect.DeepEqual(wantPods, plan.Pods) {
+				t.Errorf("unexpected Pods: expected: %v, got: %v", wantPods, plan.Pods)
+			}
+			if len(plan.Actions) != 3 {
+				t.Errorf("unexpected number of actions: expected: 3, got: %d", len(plan.Actions))
+			}
+			if plan.DryRun != tt.wantDryRun {
+				t.Errorf("unexpected dry run: expected: %v, got: %v", tt.wantDryRun, plan.DryRun)
+			}
+			if plan.PendingApproval != tt.wantPendingApproval {
+				t.Errorf("unexpected pending approval: expected: %v, got: %v", tt.wantPendingApproval, plan.PendingApproval)
+			}
+		})
+	}
+}
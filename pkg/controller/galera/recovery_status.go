@@ -43,6 +43,9 @@ func newRecoveryStatus(mariadb *mariadbv1alpha1.MariaDB) *recoveryStatus {
 		if mariadb.Status.GaleraRecovery.Bootstrap != nil {
 			inner.Bootstrap = mariadb.Status.GaleraRecovery.Bootstrap
 		}
+		if mariadb.Status.GaleraRecovery.Plan != nil {
+			inner.Plan = mariadb.Status.GaleraRecovery.Plan
+		}
 	}
 	return &recoveryStatus{
 		inner: &inner,
@@ -117,6 +120,16 @@ func (rs *recoveryStatus) isBootstrapping() bool {
 	return rs.inner.Bootstrap != nil
 }
 
+func (rs *recoveryStatus) bootstrappingPod() string {
+	rs.mux.RLock()
+	defer rs.mux.RUnlock()
+
+	if rs.inner.Bootstrap == nil || rs.inner.Bootstrap.Pod == nil {
+		return ""
+	}
+	return *rs.inner.Bootstrap.Pod
+}
+
 func (rs *recoveryStatus) bootstrapTimeout(mdb *mariadbv1alpha1.MariaDB) bool {
 	if !rs.isBootstrapping() {
 		return false
@@ -158,18 +171,24 @@ func (rs *recoveryStatus) isComplete(pods []corev1.Pod) bool {
 	if len(pods) == 0 {
 		return false
 	}
+	return len(rs.pendingRecovery(pods)) == 0
+}
+
+// pendingRecovery returns the Pods that neither have a valid sequence in their Galera state nor a recovered one.
+func (rs *recoveryStatus) pendingRecovery(pods []corev1.Pod) []corev1.Pod {
 	rs.mux.RLock()
 	defer rs.mux.RUnlock()
 
+	var pending []corev1.Pod
 	for _, p := range pods {
 		state := rs.inner.State[p.Name]
 		recovered := rs.inner.Recovered[p.Name]
 		if (state != nil && state.Seqno != -1) || (recovered != nil && recovered.Seqno != -1) {
 			continue
 		}
-		return false
+		pending = append(pending, p)
 	}
-	return true
+	return pending
 }
 
 func (rs *recoveryStatus) bootstrapSource(pods []corev1.Pod) (*bootstrapSource, error) {
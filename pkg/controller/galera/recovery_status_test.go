@@ -648,6 +648,148 @@ func TestRecoveryStatusIsComplete(t *testing.T) {
 	}
 }
 
+func TestRecoveryStatusPendingRecovery(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mariadb-galera-0",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mariadb-galera-1",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mariadb-galera-2",
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		mdb      *mariadbv1alpha1.MariaDB
+		wantPods []string
+	}{
+		{
+			name:     "no status",
+			mdb:      &mariadbv1alpha1.MariaDB{},
+			wantPods: []string{"mariadb-galera-0", "mariadb-galera-1", "mariadb-galera-2"},
+		},
+		{
+			name: "valid state",
+			mdb: &mariadbv1alpha1.MariaDB{
+				Status: mariadbv1alpha1.MariaDBStatus{
+					GaleraRecovery: &mariadbv1alpha1.GaleraRecoveryStatus{
+						State: map[string]*agentgalera.GaleraState{
+							"mariadb-galera-0": {
+								Version:         "2.1",
+								UUID:            "dfc4e849-1c90-43b0-a2c8-0b777c1ce6e4",
+								Seqno:           1,
+								SafeToBootstrap: false,
+							},
+							"mariadb-galera-1": {
+								Version:         "2.1",
+								UUID:            "0fc0436e-560f-4951-ae97-16911aae7ecf",
+								Seqno:           -1,
+								SafeToBootstrap: false,
+							},
+							"mariadb-galera-2": {
+								Version:         "2.1",
+								UUID:            "1ef327e6-8579-4d8e-bd3c-6f3f99e40b1d",
+								Seqno:           2,
+								SafeToBootstrap: false,
+							},
+						},
+					},
+				},
+			},
+			wantPods: []string{"mariadb-galera-1"},
+		},
+		{
+			name: "recovered",
+			mdb: &mariadbv1alpha1.MariaDB{
+				Status: mariadbv1alpha1.MariaDBStatus{
+					GaleraRecovery: &mariadbv1alpha1.GaleraRecoveryStatus{
+						State: map[string]*agentgalera.GaleraState{
+							"mariadb-galera-0": {
+								Version:         "2.1",
+								UUID:            "dfc4e849-1c90-43b0-a2c8-0b777c1ce6e4",
+								Seqno:           -1,
+								SafeToBootstrap: false,
+							},
+							"mariadb-galera-1": {
+								Version:         "2.1",
+								UUID:            "0fc0436e-560f-4951-ae97-16911aae7ecf",
+								Seqno:           -1,
+								SafeToBootstrap: false,
+							},
+							"mariadb-galera-2": {
+								Version:         "2.1",
+								UUID:            "1ef327e6-8579-4d8e-bd3c-6f3f99e40b1d",
+								Seqno:           -1,
+								SafeToBootstrap: false,
+							},
+						},
+						Recovered: map[string]*agentgalera.Bootstrap{
+							"mariadb-galera-0": {
+								UUID:  "dfc4e849-1c90-43b0-a2c8-0b777c1ce6e4",
+								Seqno: 1,
+							},
+							"mariadb-galera-1": {
+								UUID:  "0fc0436e-560f-4951-ae97-16911aae7ecf",
+								Seqno: -1,
+							},
+						},
+					},
+				},
+			},
+			wantPods: []string{"mariadb-galera-1", "mariadb-galera-2"},
+		},
+		{
+			name: "complete",
+			mdb: &mariadbv1alpha1.MariaDB{
+				Status: mariadbv1alpha1.MariaDBStatus{
+					GaleraRecovery: &mariadbv1alpha1.GaleraRecoveryStatus{
+						State: map[string]*agentgalera.GaleraState{
+							"mariadb-galera-0": {
+								Version:         "2.1",
+								UUID:            "dfc4e849-1c90-43b0-a2c8-0b777c1ce6e4",
+								Seqno:           1,
+								SafeToBootstrap: false,
+							},
+						},
+						Recovered: map[string]*agentgalera.Bootstrap{
+							"mariadb-galera-1": {
+								UUID:  "0fc0436e-560f-4951-ae97-16911aae7ecf",
+								Seqno: 1,
+							},
+							"mariadb-galera-2": {
+								UUID:  "1ef327e6-8579-4d8e-bd3c-6f3f99e40b1d",
+								Seqno: 1,
+							},
+						},
+					},
+				},
+			},
+			wantPods: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := newRecoveryStatus(tt.mdb)
+			var gotPods []string
+			for _, p := range rs.pendingRecovery(pods) {
+				gotPods = append(gotPods, p.Name)
+			}
+			if !reflect.DeepEqual(tt.wantPods, gotPods) {
+				t.Errorf("unexpected pending Pods: expected: %v, got: %v", tt.wantPods, gotPods)
+			}
+		})
+	}
+}
+
 func TestRecoveryStatusBootstrapSource(t *testing.T) {
 	pod0 := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
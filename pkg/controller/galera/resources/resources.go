@@ -11,4 +11,28 @@ var (
 	GaleraSSTPortName     = "sst"
 	GaleraSSTPort         = int32(4568)
 	AgentPortName         = "agent"
+
+	WsrepNotifyVolume     = "wsrep-notify"
+	WsrepNotifyMountPath  = "/etc/wsrep-notify"
+	WsrepNotifyScriptKey  = "notify.sh"
+	WsrepNotifyStateFile  = "/var/lib/mysql/wsrep-notify.state"
+	WsrepNotifyScriptMode = int32(0755)
 )
+
+// WsrepNotifyScript is invoked by MariaDB via wsrep_notify_cmd on every node state change.
+// It atomically writes the state to a file shared with the wsrep-notify sidecar, which publishes it in the Pod annotations.
+var WsrepNotifyScript = `#!/bin/bash
+while [ $# -gt 0 ]; do
+  case $1 in
+    --status)
+      STATUS=$2
+      shift
+      ;;
+  esac
+  shift
+done
+if [ -n "$STATUS" ]; then
+  echo "$STATUS" > "` + WsrepNotifyStateFile + `.tmp" && mv "` + WsrepNotifyStateFile + `.tmp" "` + WsrepNotifyStateFile + `"
+fi
+exit 0
+`
@@ -0,0 +1,30 @@
+package priority
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	inFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_reconciles_in_flight",
+		Help: "Number of reconciliations currently running, by priority.",
+	}, []string{"priority"})
+	waiting = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_reconciles_waiting",
+		Help: "Number of reconciliations waiting for capacity, by priority.",
+	}, []string{"priority"})
+	waitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mariadb_operator_reconcile_wait_seconds",
+		Help:    "Time that reconciliations waited for capacity before running, by priority.",
+		Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30, 60},
+	}, []string{"priority"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		inFlight,
+		waiting,
+		waitDuration,
+	)
+}
@@ -0,0 +1,73 @@
+package priority
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	defaultScheduler   = NewScheduler(0)
+	defaultWaitTimeout = 30 * time.Second
+)
+
+// SetDefaultCapacity sets the capacity of the Scheduler shared by all the reconcilers created with NewReconciler.
+// It must be called before the controllers are started. A zero value disables the scheduling.
+func SetDefaultCapacity(capacity int) {
+	defaultScheduler = NewScheduler(capacity)
+}
+
+// SetDefaultWaitTimeout sets the maximum time that a reconciliation waits for capacity before being requeued.
+// It must be called before the controllers are started. A zero value waits with no deadline.
+func SetDefaultWaitTimeout(timeout time.Duration) {
+	defaultWaitTimeout = timeout
+}
+
+// Func returns the priority of a reconciliation request.
+type Func func(ctx context.Context, req ctrl.Request) Priority
+
+// Reconciler runs the reconciliations of the wrapped reconciler with a given priority, using the default Scheduler.
+type Reconciler struct {
+	priority   Func
+	reconciler reconcile.Reconciler
+}
+
+// NewReconciler wraps a reconciler so its reconciliations run with the given priority.
+func NewReconciler(p Priority, r reconcile.Reconciler) reconcile.Reconciler {
+	return NewReconcilerWithFunc(func(context.Context, ctrl.Request) Priority {
+		return p
+	}, r)
+}
+
+// NewReconcilerWithFunc wraps a reconciler so each reconciliation runs with the priority returned by fn.
+func NewReconcilerWithFunc(fn Func, r reconcile.Reconciler) reconcile.Reconciler {
+	return &Reconciler{
+		priority:   fn,
+		reconciler: r,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	p := r.priority(ctx, req)
+
+	acquireCtx := ctx
+	if defaultWaitTimeout > 0 {
+		var cancel context.CancelFunc
+		acquireCtx, cancel = context.WithTimeout(ctx, defaultWaitTimeout)
+		defer cancel()
+	}
+	release, err := defaultScheduler.Acquire(acquireCtx, p)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			log.FromContext(ctx).V(1).Info("Requeuing reconciliation waiting for capacity", "priority", p.String())
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	defer release()
+	return r.reconciler.Reconcile(ctx, req)
+}
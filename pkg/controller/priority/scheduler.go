@@ -0,0 +1,156 @@
+package priority
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority of a reconciliation.
+type Priority int
+
+const (
+	// PriorityBulk is the priority of the reconciliations that are executed in large numbers and can be delayed,
+	// such as the drift checks of the Users, Grants and Databases.
+	PriorityBulk Priority = iota
+	// PriorityNormal is the priority of the regular reconciliations.
+	PriorityNormal
+	// PriorityCritical is the priority of the reconciliations that keep MariaDB highly available,
+	// such as failovers and Galera cluster recovery. They are never delayed.
+	PriorityCritical
+)
+
+const numPriorities = int(PriorityCritical) + 1
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityBulk:
+		return "bulk"
+	case PriorityNormal:
+		return "normal"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Scheduler limits the number of reconciliations running concurrently across controllers, admitting the queued ones
+// by priority. Critical reconciliations are always admitted straight away and, while they are running, they take up
+// capacity so queued bulk work is delayed in favour of them.
+type Scheduler struct {
+	capacity int
+
+	mu       sync.Mutex
+	inFlight int
+	waiters  [numPriorities]*list.List
+}
+
+// NewScheduler returns a Scheduler that runs at most capacity reconciliations concurrently.
+// A capacity lower or equal to zero disables the scheduling, admitting all the reconciliations.
+func NewScheduler(capacity int) *Scheduler {
+	s := &Scheduler{
+		capacity: capacity,
+	}
+	for i := range s.waiters {
+		s.waiters[i] = list.New()
+	}
+	return s
+}
+
+// Acquire waits until a reconciliation with the given priority can be run. The returned function must be called when
+// the reconciliation finishes, in order to release its capacity. An error is returned when the context is done while waiting.
+func (s *Scheduler) Acquire(ctx context.Context, p Priority) (func(), error) {
+	if s == nil || s.capacity <= 0 {
+		return func() {}, nil
+	}
+	start := time.Now()
+
+	s.mu.Lock()
+	if s.canAdmit(p) {
+		s.inFlight++
+		s.mu.Unlock()
+		return s.admitted(p, start), nil
+	}
+	admit := make(chan struct{})
+	elem := s.waiters[p].PushBack(admit)
+	waiting.WithLabelValues(p.String()).Inc()
+	s.mu.Unlock()
+
+	select {
+	case <-admit:
+		return s.admitted(p, start), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-admit:
+			// admitted right before the context was done, the capacity is handed over to the next waiter.
+			s.mu.Unlock()
+			s.admitted(p, start)()
+		default:
+			s.waiters[p].Remove(elem)
+			waiting.WithLabelValues(p.String()).Dec()
+			s.mu.Unlock()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// InFlight returns the number of reconciliations currently running.
+func (s *Scheduler) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inFlight
+}
+
+// canAdmit determines whether a reconciliation can be run straight away, which is not the case when it has to wait for
+// capacity or when other reconciliations with the same or higher priority are already waiting.
+func (s *Scheduler) canAdmit(p Priority) bool {
+	if p == PriorityCritical {
+		return true
+	}
+	if s.inFlight >= s.capacity {
+		return false
+	}
+	for i := int(p); i < numPriorities; i++ {
+		if s.waiters[i].Len() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Scheduler) admitted(p Priority, start time.Time) func() {
+	waitDuration.WithLabelValues(p.String()).Observe(time.Since(start).Seconds())
+	inFlight.WithLabelValues(p.String()).Inc()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			inFlight.WithLabelValues(p.String()).Dec()
+			s.release()
+		})
+	}
+}
+
+// release frees the capacity of a finished reconciliation and admits the waiting ones, from the highest to the lowest priority.
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight--
+
+	for i := numPriorities - 1; i >= 0; i-- {
+		p := Priority(i)
+		for s.waiters[i].Len() > 0 {
+			if p != PriorityCritical && s.inFlight >= s.capacity {
+				return
+			}
+			elem := s.waiters[i].Front()
+			s.waiters[i].Remove(elem)
+			waiting.WithLabelValues(p.String()).Dec()
+			s.inFlight++
+			close(elem.Value.(chan struct{}))
+		}
+	}
+}
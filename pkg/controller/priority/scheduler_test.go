@@ -0,0 +1,195 @@
+package priority
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSchedulerDisabled(t *testing.T) {
+	s := NewScheduler(0)
+	for i := 0; i < 10; i++ {
+		if _, err := s.Acquire(context.Background(), PriorityBulk); err != nil {
+			t.Fatalf("unexpected error acquiring: %v", err)
+		}
+	}
+	if inFlight := s.InFlight(); inFlight != 0 {
+		t.Errorf("expecting no reconciliations to be tracked, got %d", inFlight)
+	}
+}
+
+func TestSchedulerCritical(t *testing.T) {
+	s := NewScheduler(1)
+	releaseBulk := mustAcquire(t, s, PriorityBulk)
+
+	releaseCritical, err := acquireWithTimeout(s, PriorityCritical)
+	if err != nil {
+		t.Fatalf("expecting critical reconciliation to be admitted when saturated, got error: %v", err)
+	}
+	if inFlight := s.InFlight(); inFlight != 2 {
+		t.Errorf("expecting 2 reconciliations in flight, got %d", inFlight)
+	}
+	if _, err := acquireWithTimeout(s, PriorityNormal); err == nil {
+		t.Error("expecting normal reconciliation to wait when saturated")
+	}
+
+	releaseBulk()
+	if _, err := acquireWithTimeout(s, PriorityNormal); err == nil {
+		t.Error("expecting normal reconciliation to wait while critical reconciliation takes up the capacity")
+	}
+	releaseCritical()
+	if _, err := acquireWithTimeout(s, PriorityNormal); err != nil {
+		t.Errorf("expecting normal reconciliation to be admitted, got error: %v", err)
+	}
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	s := NewScheduler(1)
+	release := mustAcquire(t, s, PriorityNormal)
+
+	admitted := make(chan Priority, 2)
+	acquire := func(p Priority) {
+		release, err := s.Acquire(context.Background(), p)
+		if err != nil {
+			t.Errorf("unexpected error acquiring: %v", err)
+			return
+		}
+		admitted <- p
+		release()
+	}
+	go acquire(PriorityBulk)
+	waitForWaiters(t, s, PriorityBulk, 1)
+	go acquire(PriorityNormal)
+	waitForWaiters(t, s, PriorityNormal, 1)
+
+	if _, err := acquireWithTimeout(s, PriorityBulk); err == nil {
+		t.Error("expecting bulk reconciliation to wait behind the queued ones")
+	}
+
+	release()
+	for _, want := range []Priority{PriorityNormal, PriorityBulk} {
+		select {
+		case p := <-admitted:
+			if p != want {
+				t.Errorf("expecting %s reconciliation to be admitted, got %s", want, p)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %s reconciliation", want)
+		}
+	}
+	if inFlight := s.InFlight(); inFlight != 0 {
+		t.Errorf("expecting no reconciliations in flight, got %d", inFlight)
+	}
+}
+
+func TestSchedulerContextDone(t *testing.T) {
+	s := NewScheduler(1)
+	release := mustAcquire(t, s, PriorityBulk)
+
+	if _, err := acquireWithTimeout(s, PriorityBulk); err == nil {
+		t.Fatal("expecting error when the context is done while waiting")
+	}
+	if waiters := waitersLen(s, PriorityBulk); waiters != 0 {
+		t.Errorf("expecting waiter to be removed, got %d waiters", waiters)
+	}
+
+	release()
+	release()
+	if inFlight := s.InFlight(); inFlight != 0 {
+		t.Errorf("expecting releasing twice to have no effect, got %d reconciliations in flight", inFlight)
+	}
+}
+
+func mustAcquire(t *testing.T, s *Scheduler, p Priority) func() {
+	release, err := s.Acquire(context.Background(), p)
+	if err != nil {
+		t.Fatalf("unexpected error acquiring: %v", err)
+	}
+	return release
+}
+
+func acquireWithTimeout(s *Scheduler, p Priority) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	return s.Acquire(ctx, p)
+}
+
+func waitersLen(s *Scheduler, p Priority) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiters[p].Len()
+}
+
+func waitForWaiters(t *testing.T, s *Scheduler, p Priority, n int) {
+	deadline := time.Now().Add(time.Second)
+	for waitersLen(s, p) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %d %s waiters", n, p)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReconcilerWaitTimeout(t *testing.T) {
+	prevScheduler, prevWaitTimeout := defaultScheduler, defaultWaitTimeout
+	defer func() {
+		defaultScheduler, defaultWaitTimeout = prevScheduler, prevWaitTimeout
+	}()
+	defaultScheduler = NewScheduler(1)
+	defaultWaitTimeout = 50 * time.Millisecond
+
+	release := mustAcquire(t, defaultScheduler, PriorityNormal)
+	defer release()
+
+	var reconciled int
+	r := NewReconciler(PriorityBulk, reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		reconciled++
+		return ctrl.Result{}, nil
+	}))
+	result, err := r.Reconcile(context.Background(), ctrl.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error reconciling: %v", err)
+	}
+	if !result.Requeue {
+		t.Error("expecting reconciliation to be requeued after waiting for capacity")
+	}
+	if reconciled != 0 {
+		t.Errorf("expecting reconciliation not to run, got %d runs", reconciled)
+	}
+	if n := waitersLen(defaultScheduler, PriorityBulk); n != 0 {
+		t.Errorf("expecting no bulk waiters after the timeout, got %d", n)
+	}
+}
+
+func TestReconcilerWithFunc(t *testing.T) {
+	prevScheduler := defaultScheduler
+	defer func() {
+		defaultScheduler = prevScheduler
+	}()
+	defaultScheduler = NewScheduler(1)
+
+	release := mustAcquire(t, defaultScheduler, PriorityNormal)
+	defer release()
+
+	var reconciled int
+	r := NewReconcilerWithFunc(func(ctx context.Context, req ctrl.Request) Priority {
+		if req.Name == "critical" {
+			return PriorityCritical
+		}
+		return PriorityNormal
+	}, reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		reconciled++
+		return ctrl.Result{}, nil
+	}))
+	req := ctrl.Request{}
+	req.Name = "critical"
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error reconciling: %v", err)
+	}
+	if reconciled != 1 {
+		t.Errorf("expecting critical reconciliation to run when saturated, got %d runs", reconciled)
+	}
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ActionLimiter limits the disruptive actions performed by the operator according to 'spec.actionRateLimit',
+// keeping track of them in the MariaDB status. It is safe for concurrent use.
+type ActionLimiter struct {
+	client.Client
+	recorder record.EventRecorder
+	mux      sync.Mutex
+}
+
+func NewActionLimiter(client client.Client, recorder record.EventRecorder) *ActionLimiter {
+	return &ActionLimiter{
+		Client:   client,
+		recorder: recorder,
+	}
+}
+
+// Allow determines whether a disruptive action can be performed, recording it in the MariaDB status when allowed.
+// When the rate limit has been hit, it sets the ActionsRateLimited condition and returns how long to wait until the action is allowed.
+func (a *ActionLimiter) Allow(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, actionType mariadbv1alpha1.DisruptiveActionType,
+	pod string) (bool, time.Duration, error) {
+	limit := mariadb.Spec.ActionRateLimit
+	if limit == nil {
+		return true, 0, nil
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	now := time.Now()
+	logger := log.FromContext(ctx).WithName("rate-limit")
+
+	if retryAfter := limit.RetryAfter(mariadb.Status.DisruptiveActions, now); retryAfter > 0 {
+		logger.Info("Disruptive action rate limited", "action", actionType, "pod", pod, "retry-after", retryAfter)
+		if mariadb.IsActionsRateLimited() {
+			return false, retryAfter, nil
+		}
+		msg := fmt.Sprintf("Rate limit of %d disruptive actions per %s exceeded, '%s' blocked until %s",
+			limit.MaxActions, limit.Window.Duration, actionType, now.Add(retryAfter).UTC().Format(time.RFC3339))
+		if err := a.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) {
+			condition.SetActionsRateLimited(s, msg)
+		}); err != nil {
+			return false, 0, err
+		}
+		a.recorder.Event(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonActionsRateLimited, msg)
+		return false, retryAfter, nil
+	}
+
+	if err := a.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) {
+		s.DisruptiveActions = append(limit.RecentActions(s.DisruptiveActions, now), mariadbv1alpha1.DisruptiveAction{
+			Time: metav1.NewTime(now),
+			Type: actionType,
+			Pod:  pod,
+		})
+		meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeActionsRateLimited)
+	}); err != nil {
+		return false, 0, err
+	}
+	return true, 0, nil
+}
+
+// Reconcile removes the actions that are no longer within the window, along with the ActionsRateLimited condition
+// once new actions are allowed.
+func (a *ActionLimiter) Reconcile(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	limit := mariadb.Spec.ActionRateLimit
+	if limit == nil {
+		if mariadb.Status.DisruptiveActions == nil && !mariadb.IsActionsRateLimited() {
+			return nil
+		}
+		return a.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) {
+			s.DisruptiveActions = nil
+			meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeActionsRateLimited)
+		})
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	now := time.Now()
+	recent := limit.RecentActions(mariadb.Status.DisruptiveActions, now)
+	lifted := mariadb.IsActionsRateLimited() && limit.RetryAfter(mariadb.Status.DisruptiveActions, now) == 0
+	if len(recent) == len(mariadb.Status.DisruptiveActions) && !lifted {
+		return nil
+	}
+
+	if lifted {
+		log.FromContext(ctx).WithName("rate-limit").Info("Disruptive actions allowed again")
+	}
+	return a.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) {
+		s.DisruptiveActions = recent
+		if lifted {
+			meta.RemoveStatusCondition(&s.Conditions, mariadbv1alpha1.ConditionTypeActionsRateLimited)
+		}
+	})
+}
+
+func (a *ActionLimiter) patchStatus(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	patcher func(*mariadbv1alpha1.MariaDBStatus)) error {
+	patch := client.MergeFromWithOptions(mariadb.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	patcher(&mariadb.Status)
+
+	if err := a.Client.Status().Patch(ctx, mariadb, patch); err != nil {
+		return fmt.Errorf("error patching MariaDB status: %v", err)
+	}
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -89,15 +91,36 @@ func (r *RBACReconciler) reconcileServiceAccount(ctx context.Context, key types.
 
 func (r *RBACReconciler) reconcileRole(ctx context.Context, key types.NamespacedName,
 	mariadb *mariadbv1alpha1.MariaDB) (*rbacv1.Role, error) {
+	rules := roleRules(mariadb)
+
 	var existingRole rbacv1.Role
 	err := r.Get(ctx, key, &existingRole)
 	if err == nil {
+		if equality.Semantic.DeepEqual(existingRole.Rules, rules) {
+			return &existingRole, nil
+		}
+		patch := client.MergeFrom(existingRole.DeepCopy())
+		existingRole.Rules = rules
+		if err := r.Patch(ctx, &existingRole, patch); err != nil {
+			return nil, fmt.Errorf("error patching Role: %v", err)
+		}
 		return &existingRole, nil
 	}
 	if err != nil && !apierrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error getting Role: %v", err)
 	}
 
+	role, err := r.builder.BuildRole(key, mariadb, rules)
+	if err != nil {
+		return nil, fmt.Errorf("error building Role: %v", err)
+	}
+	if err := r.Create(ctx, role); err != nil {
+		return nil, fmt.Errorf("error creating Role: %v", err)
+	}
+	return role, nil
+}
+
+func roleRules(mariadb *mariadbv1alpha1.MariaDB) []rbacv1.PolicyRule {
 	rules := []rbacv1.PolicyRule{
 		{
 			APIGroups: []string{
@@ -122,14 +145,31 @@ func (r *RBACReconciler) reconcileRole(ctx context.Context, key types.Namespaced
 			},
 		},
 	}
-	role, err := r.builder.BuildRole(key, mariadb, rules)
-	if err != nil {
-		return nil, fmt.Errorf("error building Role: %v", err)
+	if mariadb.Galera().Agent.IsWsrepNotifyEnabled() {
+		// The wsrep-notify sidecar is only allowed to patch the Pods of the StatefulSet,
+		// which are updated when scaling, as the Role is reconciled before the StatefulSet.
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{
+				corev1.GroupName,
+			},
+			Resources: []string{
+				"pods",
+			},
+			ResourceNames: podNames(mariadb),
+			Verbs: []string{
+				"patch",
+			},
+		})
 	}
-	if err := r.Create(ctx, role); err != nil {
-		return nil, fmt.Errorf("error creating Role: %v", err)
+	return rules
+}
+
+func podNames(mariadb *mariadbv1alpha1.MariaDB) []string {
+	names := make([]string, mariadb.ScaledReplicas())
+	for i := range names {
+		names[i] = statefulset.PodName(mariadb.ObjectMeta, i)
 	}
-	return role, nil
+	return names
 }
 
 func (r *RBACReconciler) reconcileRoleBinding(ctx context.Context, key types.NamespacedName, mariadb *mariadbv1alpha1.MariaDB,
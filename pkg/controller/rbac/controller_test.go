@@ -0,0 +1,77 @@
+package rbac
+
+import (
+	"reflect"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoleRules(t *testing.T) {
+	newMariaDB := func(replicas int32, wsrepNotify bool) *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-galera",
+				Namespace: "default",
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Replicas: replicas,
+				Galera: &mariadbv1alpha1.Galera{
+					Enabled: true,
+					GaleraSpec: mariadbv1alpha1.GaleraSpec{
+						Agent: &mariadbv1alpha1.GaleraAgent{
+							WsrepNotify: &mariadbv1alpha1.WsrepNotify{
+								Enabled: wsrepNotify,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		mariadb           *mariadbv1alpha1.MariaDB
+		wantPatchPodNames []string
+	}{
+		{
+			name:    "wsrep-notify disabled",
+			mariadb: newMariaDB(3, false),
+		},
+		{
+			name:              "wsrep-notify enabled",
+			mariadb:           newMariaDB(3, true),
+			wantPatchPodNames: []string{"mariadb-galera-0", "mariadb-galera-1", "mariadb-galera-2"},
+		},
+		{
+			name:              "scaled",
+			mariadb:           newMariaDB(5, true),
+			wantPatchPodNames: []string{"mariadb-galera-0", "mariadb-galera-1", "mariadb-galera-2", "mariadb-galera-3", "mariadb-galera-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patchPodNames []string
+			for _, rule := range roleRules(tt.mariadb) {
+				for _, verb := range rule.Verbs {
+					if verb != "patch" {
+						continue
+					}
+					if !reflect.DeepEqual(rule.Resources, []string{"pods"}) {
+						t.Fatalf("unexpected patch rule for resources %v", rule.Resources)
+					}
+					if len(rule.ResourceNames) == 0 {
+						t.Fatal("expecting patch rule to be restricted to resource names")
+					}
+					patchPodNames = append(patchPodNames, rule.ResourceNames...)
+				}
+			}
+			if !reflect.DeepEqual(patchPodNames, tt.wantPatchPodNames) {
+				t.Errorf("expecting Pods allowed to be patched to be %v, got %v", tt.wantPatchPodNames, patchPodNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package replication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
+	mdbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	sqlClientSet "github.com/mariadb-operator/mariadb-operator/pkg/sqlset"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ReconcileChannels configures the replication channels in the primary Pod and reports their status. The channels no longer
+// declared in the spec are removed, as well as the ones configured in the rest of the Pods, which may have been the primary before a switchover.
+// Only the primary Pod needs to be reachable, the rest of the Pods are skipped while they are down, for instance after a failover.
+func (r *ReplicationReconciler) ReconcileChannels(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if len(mariadb.Spec.ReplicationChannels) == 0 && mariadb.Status.ReplicationChannels == nil {
+		return nil
+	}
+	if mariadb.IsRestoringBackup() {
+		return nil
+	}
+	if mariadb.Replication().Enabled && (!mariadb.HasConfiguredReplication() || mariadb.IsSwitchingPrimary()) {
+		return nil
+	}
+	logger := log.FromContext(ctx).WithName("replication-channels")
+	key := client.ObjectKeyFromObject(mariadb)
+
+	desired := make(map[string]mariadbv1alpha1.ReplicationChannel, len(mariadb.Spec.ReplicationChannels))
+	for _, channel := range mariadb.Spec.ReplicationChannels {
+		desired[channel.Name] = channel
+	}
+	managed := make(map[string]struct{})
+	for name := range desired {
+		managed[name] = struct{}{}
+	}
+	if mariadb.Status.ReplicationChannels != nil {
+		for _, channel := range mariadb.Status.ReplicationChannels.Channels {
+			managed[channel.Name] = struct{}{}
+		}
+	}
+
+	clientSet := sqlClientSet.NewClientSet(mariadb, r.refResolver, r.sqlOpts...)
+	defer clientSet.Close()
+
+	podIndex := mariadb.ReplicationChannelsPodIndex()
+	client, err := clientSet.ClientForIndex(ctx, podIndex)
+	if err != nil {
+		return fmt.Errorf("error getting client for Pod '%d': %v", podIndex, err)
+	}
+
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		podName := statefulset.PodName(mariadb.ObjectMeta, i)
+		podClient := client
+		if i != podIndex {
+			ready, err := r.isPodReady(ctx, mariadb, podName)
+			if err != nil {
+				return err
+			}
+			if !ready {
+				logger.V(1).Info("Skipping Pod not ready", "pod", podName)
+				continue
+			}
+			if podClient, err = clientSet.ClientForIndex(ctx, i); err != nil {
+				logger.V(1).Info("Skipping Pod not reachable", "pod", podName, "err", err)
+				continue
+			}
+		}
+		conns, err := podClient.ReplicationConnections(ctx)
+		if err != nil {
+			if i != podIndex {
+				logger.V(1).Info("Skipping Pod not reachable", "pod", podName, "err", err)
+				continue
+			}
+			return fmt.Errorf("error getting replication connections in Pod '%s': %v", podName, err)
+		}
+		for _, conn := range conns {
+			if _, ok := managed[conn.Name]; !ok {
+				continue
+			}
+			if _, ok := desired[conn.Name]; ok && i == podIndex {
+				continue
+			}
+			logger.Info("Removing replication channel", "channel", conn.Name, "pod", podName)
+			if err := removeChannel(ctx, podClient, conn.Name); err != nil {
+				return fmt.Errorf("error removing replication channel '%s' in Pod '%s': %v", conn.Name, podName, err)
+			}
+		}
+	}
+
+	conns, err := client.ReplicationConnections(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting replication connections: %v", err)
+	}
+	connsByName := make(map[string]sqlClient.ReplicationConnection, len(conns))
+	for _, conn := range conns {
+		connsByName[conn.Name] = conn
+	}
+	configHashes := make(map[string]string, len(mariadb.Spec.ReplicationChannels))
+	if mariadb.Status.ReplicationChannels != nil {
+		for _, channel := range mariadb.Status.ReplicationChannels.Channels {
+			configHashes[channel.Name] = channel.ConfigHash
+		}
+	}
+	for _, channel := range mariadb.Spec.ReplicationChannels {
+		opts, err := r.changeMasterOpts(ctx, mariadb, &channel)
+		if err != nil {
+			return fmt.Errorf("error getting replication channel '%s' options: %v", channel.Name, err)
+		}
+		configHash, err := changeMasterOptsHash(opts)
+		if err != nil {
+			return fmt.Errorf("error hashing replication channel '%s' options: %v", channel.Name, err)
+		}
+		if _, ok := connsByName[channel.Name]; ok && configHashes[channel.Name] == configHash {
+			continue
+		}
+		address := net.JoinHostPort(channel.Host, strconv.Itoa(int(channel.PortOrDefault())))
+		logger.Info("Configuring replication channel", "channel", channel.Name, "address", address)
+		r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationChannelConfigure,
+			"Configuring replication channel '%s' from '%s'", channel.Name, address)
+		if err := configureChannel(ctx, client, opts); err != nil {
+			return fmt.Errorf("error configuring replication channel '%s': %v", channel.Name, err)
+		}
+		configHashes[channel.Name] = configHash
+	}
+
+	status, err := channelsStatus(ctx, mariadb, client, configHashes)
+	if err != nil {
+		return fmt.Errorf("error getting replication channels status: %v", err)
+	}
+	DeleteReplicationChannelMetrics(key)
+	for _, channel := range status.Channels {
+		setReplicationChannelMetrics(key.Namespace, key.Name, &channel)
+	}
+	if len(mariadb.Spec.ReplicationChannels) == 0 {
+		status = nil
+	} else if !shouldUpdateChannelsStatus(mariadb.Status.ReplicationChannels, status) {
+		return nil
+	}
+	return r.patchStatus(ctx, mariadb, func(s *mariadbv1alpha1.MariaDBStatus) {
+		s.ReplicationChannels = status
+	})
+}
+
+// changeMasterOpts returns the options used to configure the channel, resolving its password.
+func (r *ReplicationReconciler) changeMasterOpts(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	channel *mariadbv1alpha1.ReplicationChannel) (*sqlClient.ChangeMasterOpts, error) {
+	password, err := r.refResolver.SecretKeyRef(ctx, channel.PasswordSecretKeyRef, mariadb.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error getting password: %v", err)
+	}
+	gtidString, err := channel.GtidOrDefault().MariaDBFormat()
+	if err != nil {
+		return nil, fmt.Errorf("error getting GTID: %v", err)
+	}
+
+	changeMasterOpts := &sqlClient.ChangeMasterOpts{
+		Connection: channel.Name,
+		Host:       channel.Host,
+		Port:       channel.PortOrDefault(),
+		User:       channel.Username,
+		Password:   password,
+		Gtid:       gtidString,
+		Retries:    *mariadb.Replication().Replica.ConnectionRetries,
+	}
+	if channel.IsTLSEnabled() {
+		changeMasterOpts.SSL = true
+		changeMasterOpts.SSLVerifyServerCert = channel.TLS.VerifyServerCert
+		if channel.TLS.CASecretKeyRef != nil {
+			changeMasterOpts.SSLCA = builder.ReplicationChannelCAPath(channel.Name)
+		}
+	}
+	return changeMasterOpts, nil
+}
+
+// changeMasterOptsHash returns a hash of all the options applied to the channel, so any change in the spec or the password
+// triggers a reconfiguration. The password is hashed along with the rest of options, so it is not exposed in the status.
+func changeMasterOptsHash(opts *sqlClient.ChangeMasterOpts) (string, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(bytes)), nil
+}
+
+func configureChannel(ctx context.Context, client *sqlClient.Client, opts *sqlClient.ChangeMasterOpts) error {
+	if err := client.StopSlave(ctx, opts.Connection); err != nil {
+		return fmt.Errorf("error stopping slave: %v", err)
+	}
+	if err := client.ChangeMaster(ctx, opts); err != nil {
+		return fmt.Errorf("error changing master: %v", err)
+	}
+	if err := client.StartSlave(ctx, opts.Connection); err != nil {
+		return fmt.Errorf("error starting slave: %v", err)
+	}
+	return nil
+}
+
+func removeChannel(ctx context.Context, client *sqlClient.Client, name string) error {
+	if err := client.StopSlave(ctx, name); err != nil {
+		return fmt.Errorf("error stopping slave: %v", err)
+	}
+	if err := client.ResetSlave(ctx, name); err != nil {
+		return fmt.Errorf("error resetting slave: %v", err)
+	}
+	return nil
+}
+
+// isPodReady determines whether the Pod exists and it is ready.
+func (r *ReplicationReconciler) isPodReady(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, podName string) (bool, error) {
+	var pod corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Name: podName, Namespace: mariadb.Namespace}, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting Pod '%s': %v", podName, err)
+	}
+	return mdbpod.PodReady(&pod), nil
+}
+
+func channelsStatus(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
+	configHashes map[string]string) (*mariadbv1alpha1.ReplicationChannelsStatus, error) {
+	conns, err := client.ReplicationConnections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	connsByName := make(map[string]sqlClient.ReplicationConnection, len(conns))
+	for _, conn := range conns {
+		connsByName[conn.Name] = conn
+	}
+	status := &mariadbv1alpha1.ReplicationChannelsStatus{
+		LastCheckTime: &metav1.Time{Time: time.Now()},
+	}
+	for _, channel := range mariadb.Spec.ReplicationChannels {
+		channelStatus := mariadbv1alpha1.ReplicationChannelStatus{
+			Name:       channel.Name,
+			ConfigHash: configHashes[channel.Name],
+		}
+		if conn, ok := connsByName[channel.Name]; ok {
+			channelStatus.Host = conn.Host
+			channelStatus.IORunning = conn.IORunning
+			channelStatus.SQLRunning = conn.SQLRunning
+			channelStatus.SecondsBehindSource = conn.SecondsBehindMaster
+			channelStatus.GtidIOPos = conn.GtidIOPos
+			channelStatus.LastError = conn.LastError
+		}
+		status.Channels = append(status.Channels, channelStatus)
+	}
+	return status, nil
+}
+
+// shouldUpdateChannelsStatus determines whether the replication channels status has to be updated, either because the state
+// of the replication threads has changed or because the refresh interval has elapsed.
+func shouldUpdateChannelsStatus(previous, current *mariadbv1alpha1.ReplicationChannelsStatus) bool {
+	if previous == nil || previous.LastCheckTime == nil || len(previous.Channels) != len(current.Channels) {
+		return true
+	}
+	for i, channel := range current.Channels {
+		prevChannel := previous.Channels[i]
+		if prevChannel.Name != channel.Name || prevChannel.ConfigHash != channel.ConfigHash ||
+			prevChannel.Host != channel.Host || prevChannel.IORunning != channel.IORunning ||
+			prevChannel.SQLRunning != channel.SQLRunning || prevChannel.LastError != channel.LastError ||
+			(prevChannel.SecondsBehindSource == nil) != (channel.SecondsBehindSource == nil) {
+			return true
+		}
+	}
+	return current.LastCheckTime.Sub(previous.LastCheckTime.Time) >= replicationStatusRefreshInterval
+}
@@ -15,12 +15,13 @@ type replicationClientSet struct {
 	*sqlClientSet.ClientSet
 }
 
-func newReplicationClientSet(mariadb *mariadbv1alpha1.MariaDB, refResolver *refresolver.RefResolver) (*replicationClientSet, error) {
+func newReplicationClientSet(mariadb *mariadbv1alpha1.MariaDB, refResolver *refresolver.RefResolver,
+	clientOpts ...sqlClient.Opt) (*replicationClientSet, error) {
 	if !mariadb.Replication().Enabled {
 		return nil, errors.New("'mariadb.spec.replication' is required to create a replicationClientSet")
 	}
 	return &replicationClientSet{
-		ClientSet: sqlClientSet.NewClientSet(mariadb, refResolver),
+		ClientSet: sqlClientSet.NewClientSet(mariadb, refResolver, clientOpts...),
 	}, nil
 }
 
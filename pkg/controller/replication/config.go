@@ -3,6 +3,9 @@ package replication
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
@@ -13,11 +16,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var (
 	replUser       = "repl"
-	connectionName = "mariadb-operator"
+	connectionName = mariadbv1alpha1.ReservedReplicationChannel
+	// semiSyncVersion is the version where semi-synchronous replication was built into the server.
+	semiSyncVersion = sqlClient.Version{Major: 10, Minor: 3, Patch: 3}
+	// gtidCurrentPosDeprecatedVersion is the version where MASTER_USE_GTID=current_pos was deprecated.
+	gtidCurrentPosDeprecatedVersion = sqlClient.Version{Major: 10, Minor: 10, Patch: 1}
 )
 
 type ReplicationConfig struct {
@@ -38,6 +46,9 @@ func NewReplicationConfig(client client.Client, builder *builder.Builder, secret
 
 func (r *ReplicationConfig) ConfigurePrimary(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
 	podIndex int) error {
+	if mariadb.IsReplicatingFromExternal() {
+		return r.configureExternalReplica(ctx, mariadb, client, podIndex)
+	}
 	if err := client.StopAllSlaves(ctx); err != nil {
 		return fmt.Errorf("error stopping slaves: %v", err)
 	}
@@ -54,7 +65,7 @@ func (r *ReplicationConfig) ConfigurePrimary(ctx context.Context, mariadb *maria
 		return fmt.Errorf("error reconciling primary SQL: %v", err)
 	}
 	if err := r.configurePrimaryVars(ctx, mariadb, client, podIndex); err != nil {
-		return fmt.Errorf("error configuring replication variables: %v", err)
+		return fmt.Errorf("error configuring replication variables: %w", err)
 	}
 	return nil
 }
@@ -76,7 +87,7 @@ func (r *ReplicationConfig) ConfigureReplica(ctx context.Context, mariadb *maria
 		return fmt.Errorf("error enabling read_only: %v", err)
 	}
 	if err := r.configureReplicaVars(ctx, mariadb, client, replicaPodIndex); err != nil {
-		return fmt.Errorf("error configuring replication variables: %v", err)
+		return fmt.Errorf("error configuring replication variables: %w", err)
 	}
 	if err := r.changeMaster(ctx, mariadb, client, primaryPodIndex); err != nil {
 		return fmt.Errorf("error changing master: %v", err)
@@ -87,38 +98,235 @@ func (r *ReplicationConfig) ConfigureReplica(ctx context.Context, mariadb *maria
 	return nil
 }
 
+// configureExternalReplica configures the primary Pod as a replica of the external primary. The replication position
+// is kept, so a new primary resumes replicating from where the previous one stopped.
+func (r *ReplicationConfig) configureExternalReplica(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	client *sqlClient.Client, podIndex int) error {
+	if err := client.StopAllSlaves(ctx); err != nil {
+		return fmt.Errorf("error stopping slaves: %v", err)
+	}
+	if err := r.reconcilePrimarySql(ctx, mariadb, client); err != nil {
+		return fmt.Errorf("error reconciling primary SQL: %v", err)
+	}
+	if err := client.EnableReadOnly(ctx); err != nil {
+		return fmt.Errorf("error enabling read_only: %v", err)
+	}
+	if err := r.configurePrimaryVars(ctx, mariadb, client, podIndex); err != nil {
+		return fmt.Errorf("error configuring replication variables: %w", err)
+	}
+	if err := r.changeMasterToExternal(ctx, mariadb, client); err != nil {
+		return fmt.Errorf("error changing master to external primary: %v", err)
+	}
+	if err := client.StartSlave(ctx, connectionName); err != nil {
+		return fmt.Errorf("error starting slave: %v", err)
+	}
+	return nil
+}
+
 func (r *ReplicationConfig) configurePrimaryVars(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
 	primaryPodIndex int) error {
+	if err := client.RequireVersion(ctx, "semi-synchronous replication", semiSyncVersion); err != nil {
+		return err
+	}
+	kv, err := semiSyncPrimaryVars(mariadb)
+	if err != nil {
+		return err
+	}
+	kv["sync_binlog"] = binaryFromBool(mariadb.Replication().SyncBinlog)
+	kv["rpl_semi_sync_slave_enabled"] = "OFF"
+	kv["server_id"] = serverId(primaryPodIndex)
+
+	gtidKv, err := gtidVars(mariadb)
+	if err != nil {
+		return err
+	}
+	maps.Copy(kv, gtidKv)
+
+	if err := client.SetSystemVariables(ctx, kv); err != nil {
+		return fmt.Errorf("error setting replication vars: %v", err)
+	}
+	return nil
+}
+
+// ReconcileSemiSync applies the semi-synchronous replication settings to the primary, as they can be changed dynamically,
+// and returns the effective ones.
+func (r *ReplicationConfig) ReconcileSemiSync(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	client *sqlClient.Client) (*sqlClient.SemiSyncStatus, error) {
+	if err := client.RequireVersion(ctx, "semi-synchronous replication", semiSyncVersion); err != nil {
+		return nil, err
+	}
+	kv, err := semiSyncPrimaryVars(mariadb)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SetSystemVariables(ctx, kv); err != nil {
+		return nil, fmt.Errorf("error setting semi-synchronous replication vars: %v", err)
+	}
+	status, err := client.SemiSyncPrimaryStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting semi-synchronous replication status: %v", err)
+	}
+	return status, nil
+}
+
+func semiSyncPrimaryVars(mariadb *mariadbv1alpha1.MariaDB) (map[string]string, error) {
 	kv := map[string]string{
-		"sync_binlog":                  binaryFromBool(mariadb.Replication().SyncBinlog),
 		"rpl_semi_sync_master_enabled": "ON",
-		"rpl_semi_sync_master_timeout": func() string {
-			return fmt.Sprint(mariadb.Replication().Replica.ConnectionTimeout.Milliseconds())
-		}(),
-		"rpl_semi_sync_slave_enabled": "OFF",
-		"server_id":                   serverId(primaryPodIndex),
+		"rpl_semi_sync_master_timeout": fmt.Sprint(mariadb.Replication().SemiSyncTimeout().Milliseconds()),
 	}
 	if mariadb.Replication().Replica.WaitPoint != nil {
 		waitPoint, err := mariadb.Replication().Replica.WaitPoint.MariaDBFormat()
 		if err != nil {
-			return fmt.Errorf("error getting wait point: %v", err)
+			return nil, fmt.Errorf("error getting wait point: %v", err)
 		}
 		kv["rpl_semi_sync_master_wait_point"] = waitPoint
 	}
-	if err := client.SetSystemVariables(ctx, kv); err != nil {
-		return fmt.Errorf("error setting replication vars: %v", err)
+	if semiSync := mariadb.Replication().SemiSync; semiSync != nil && semiSync.WaitNoSlave != nil {
+		kv["rpl_semi_sync_master_wait_no_slave"] = binaryFromBool(semiSync.WaitNoSlave)
+	}
+	return kv, nil
+}
+
+// ReconcileGtidSettings applies the GTID and binary log settings that differ from the spec. The replication connection to the
+// primary is restarted around the change when the Pod is replicating, so the replication threads pick up the new settings.
+// It returns whether any setting has been changed.
+func (r *ReplicationConfig) ReconcileGtidSettings(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
+	replicating bool) (bool, error) {
+	kv, err := gtidVars(mariadb)
+	if err != nil {
+		return false, err
+	}
+	pending := make(map[string]string)
+	for k, v := range kv {
+		current, err := client.SystemVariable(ctx, k)
+		if err != nil {
+			return false, fmt.Errorf("error getting '%s': %v", k, err)
+		}
+		if !strings.EqualFold(current, strings.Trim(v, "'")) {
+			pending[k] = v
+		}
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	if replicating {
+		if err := client.StopSlave(ctx, connectionName); err != nil {
+			return false, fmt.Errorf("error stopping slave: %v", err)
+		}
+	}
+	if err := client.SetSystemVariables(ctx, pending); err != nil {
+		return false, fmt.Errorf("error setting GTID vars: %v", err)
+	}
+	if replicating {
+		if err := client.StartSlave(ctx, connectionName); err != nil {
+			return false, fmt.Errorf("error starting slave: %v", err)
+		}
+	}
+	return true, nil
+}
+
+// ReconcileReplicaTLS points the replica to the primary again when its replication connection does not match the TLS
+// settings. The connection is reset, as CHANGE MASTER keeps the SSL options that are not specified, whereas the
+// replication position is preserved in gtid_slave_pos. It returns whether the connection has been changed.
+func (r *ReplicationConfig) ReconcileReplicaTLS(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
+	primaryPodIndex int) (bool, error) {
+	conns, err := client.ReplicationConnections(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting replication connections: %v", err)
+	}
+	var conn *sqlClient.ReplicationConnection
+	for i := range conns {
+		if conns[i].Name == connectionName {
+			conn = &conns[i]
+			break
+		}
+	}
+	if conn == nil || conn.SSL == mariadb.IsReplicationTLSEnabled() {
+		return false, nil
+	}
+
+	if err := client.StopSlave(ctx, connectionName); err != nil {
+		return false, fmt.Errorf("error stopping slave: %v", err)
+	}
+	if err := client.ResetSlave(ctx, connectionName); err != nil {
+		return false, fmt.Errorf("error resetting slave: %v", err)
+	}
+	if err := r.changeMaster(ctx, mariadb, client, primaryPodIndex); err != nil {
+		return false, fmt.Errorf("error changing master: %v", err)
+	}
+	if err := client.StartSlave(ctx, connectionName); err != nil {
+		return false, fmt.Errorf("error starting slave: %v", err)
+	}
+	return true, nil
+}
+
+// UpdateReplicaPassword points the replica to the primary again with a new password of the replication user.
+// The replication position is preserved in gtid_slave_pos.
+func (r *ReplicationConfig) UpdateReplicaPassword(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
+	primaryPodIndex int, password string) error {
+	if err := client.StopSlave(ctx, connectionName); err != nil {
+		return fmt.Errorf("error stopping slave: %v", err)
+	}
+	if err := r.changeMasterWithPassword(ctx, mariadb, client, primaryPodIndex, password); err != nil {
+		return fmt.Errorf("error changing master: %v", err)
+	}
+	if err := client.StartSlave(ctx, connectionName); err != nil {
+		return fmt.Errorf("error starting slave: %v", err)
 	}
 	return nil
 }
 
+func gtidVars(mariadb *mariadbv1alpha1.MariaDB) (map[string]string, error) {
+	replication := mariadb.Replication()
+	kv := make(map[string]string)
+	if replication.GtidStrictMode != nil {
+		kv["gtid_strict_mode"] = binaryFromBool(replication.GtidStrictMode)
+	}
+	if domainId := replication.EffectiveGtidDomainId(); domainId != nil {
+		kv["gtid_domain_id"] = fmt.Sprint(*domainId)
+	}
+	if replication.BinlogFormat != nil {
+		binlogFormat, err := replication.BinlogFormat.MariaDBFormat()
+		if err != nil {
+			return nil, fmt.Errorf("error getting binlog format: %v", err)
+		}
+		kv["binlog_format"] = fmt.Sprintf("'%s'", binlogFormat)
+	}
+	return kv, nil
+}
+
 func (r *ReplicationConfig) configureReplicaVars(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
 	client *sqlClient.Client, ordinal int) error {
+	if err := client.RequireVersion(ctx, "semi-synchronous replication", semiSyncVersion); err != nil {
+		return err
+	}
 	kv := map[string]string{
 		"sync_binlog":                  binaryFromBool(mariadb.Replication().SyncBinlog),
 		"rpl_semi_sync_master_enabled": "OFF",
 		"rpl_semi_sync_slave_enabled":  "ON",
 		"server_id":                    serverId(ordinal),
 	}
+	replica := mariadb.Replication().Replica
+	if replica.ParallelThreads != nil {
+		kv["slave_parallel_threads"] = fmt.Sprint(*replica.ParallelThreads)
+	}
+	if replica.ParallelMode != nil {
+		parallelMode, err := replica.ParallelMode.MariaDBFormat()
+		if err != nil {
+			return fmt.Errorf("error getting parallel mode: %v", err)
+		}
+		kv["slave_parallel_mode"] = fmt.Sprintf("'%s'", parallelMode)
+	}
+	if replica.ParallelMaxQueued != nil {
+		kv["slave_parallel_max_queued"] = fmt.Sprint(*replica.ParallelMaxQueued)
+	}
+	gtidKv, err := gtidVars(mariadb)
+	if err != nil {
+		return err
+	}
+	maps.Copy(kv, gtidKv)
+
 	if err := client.SetSystemVariables(ctx, kv); err != nil {
 		return fmt.Errorf("error setting replication vars: %v", err)
 	}
@@ -132,15 +340,26 @@ func (r *ReplicationConfig) changeMaster(ctx context.Context, mariadb *mariadbv1
 	if err := r.Get(ctx, replPasswordRef.NamespacedName, &replSecret); err != nil {
 		return fmt.Errorf("error getting replication password Secret: %v", err)
 	}
+	return r.changeMasterWithPassword(ctx, mariadb, client, primaryPodIndex, string(replSecret.Data[replPasswordRef.secretKey]))
+}
 
-	gtid := mariadbv1alpha1.GtidCurrentPos
-	if mariadb.Replication().Replica.Gtid != nil {
-		gtid = *mariadb.Replication().Replica.Gtid
-	}
+func (r *ReplicationConfig) changeMasterWithPassword(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
+	primaryPodIndex int, password string) error {
+	gtid := replicaGtid(mariadb)
 	gtidString, err := gtid.MariaDBFormat()
 	if err != nil {
 		return fmt.Errorf("error getting GTID: %v", err)
 	}
+	if gtid == mariadbv1alpha1.GtidCurrentPos {
+		version, err := client.ServerVersion(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting server version: %v", err)
+		}
+		if version.AtLeast(gtidCurrentPosDeprecatedVersion) {
+			log.FromContext(ctx).Info("GTID CurrentPos is deprecated in this MariaDB version, consider using SlavePos",
+				"version", version.String())
+		}
+	}
 
 	changeMasterOpts := &sqlClient.ChangeMasterOpts{
 		Connection: connectionName,
@@ -150,16 +369,66 @@ func (r *ReplicationConfig) changeMaster(ctx context.Context, mariadb *mariadbv1
 			mariadb.InternalServiceKey().Name,
 		),
 		User:     replUser,
-		Password: string(replSecret.Data[replPasswordRef.secretKey]),
+		Password: password,
 		Gtid:     gtidString,
 		Retries:  *mariadb.Replication().Replica.ConnectionRetries,
 	}
+	if mariadb.IsReplicationTLSEnabled() {
+		changeMasterOpts.SSL = true
+		changeMasterOpts.SSLCA = filepath.Join(builder.ServerTLSMountPath, builder.ServerTLSCAFile)
+		changeMasterOpts.SSLCert = filepath.Join(builder.ServerTLSMountPath, builder.ServerTLSCertFile)
+		changeMasterOpts.SSLKey = filepath.Join(builder.ServerTLSMountPath, builder.ServerTLSKeyFile)
+		changeMasterOpts.SSLVerifyServerCert = true
+	}
+	if err := client.ChangeMaster(ctx, changeMasterOpts); err != nil {
+		return fmt.Errorf("error changing master: %v", err)
+	}
+	return nil
+}
+
+func (r *ReplicationConfig) changeMasterToExternal(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	client *sqlClient.Client) error {
+	external := mariadb.Replication().External
+	password, err := r.refResolver.SecretKeyRef(ctx, external.PasswordSecretKeyRef, mariadb.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting external primary password: %v", err)
+	}
+	gtidString, err := replicaGtid(mariadb).MariaDBFormat()
+	if err != nil {
+		return fmt.Errorf("error getting GTID: %v", err)
+	}
+
+	changeMasterOpts := &sqlClient.ChangeMasterOpts{
+		Connection: connectionName,
+		Host:       external.Host,
+		Port:       external.PortOrDefault(),
+		User:       external.Username,
+		Password:   password,
+		Gtid:       gtidString,
+		Retries:    *mariadb.Replication().Replica.ConnectionRetries,
+		// Transactions executed in the cluster are not part of the external primary binary log.
+		IgnoreDomainIds: []uint32{external.GtidDomainIdOrDefault()},
+	}
+	if external.IsTLSEnabled() {
+		changeMasterOpts.SSL = true
+		changeMasterOpts.SSLVerifyServerCert = external.TLS.VerifyServerCert
+		if external.TLS.CASecretKeyRef != nil {
+			changeMasterOpts.SSLCA = filepath.Join(builder.ExternalReplicationPKIMountPath, builder.ExternalReplicationCAFile)
+		}
+	}
 	if err := client.ChangeMaster(ctx, changeMasterOpts); err != nil {
 		return fmt.Errorf("error changing master: %v", err)
 	}
 	return nil
 }
 
+func replicaGtid(mariadb *mariadbv1alpha1.MariaDB) mariadbv1alpha1.Gtid {
+	if mariadb.Replication().Replica.Gtid != nil {
+		return *mariadb.Replication().Replica.Gtid
+	}
+	return mariadbv1alpha1.GtidCurrentPos
+}
+
 func (r *ReplicationConfig) reconcilePrimarySql(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client) error {
 	if mariadb.Spec.Username != nil && mariadb.Spec.PasswordSecretKeyRef != nil {
 		password, err := r.refResolver.SecretKeyRef(ctx, *mariadb.Spec.PasswordSecretKeyRef, mariadb.Namespace)
@@ -2,6 +2,7 @@ package replication
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -12,10 +13,12 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/controller/service"
 	"github.com/mariadb-operator/mariadb-operator/pkg/health"
 	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -40,6 +43,13 @@ func WithServiceReconciler(sr *service.ServiceReconciler) Option {
 	}
 }
 
+// WithSqlOpts sets the options of the SQL clients used to connect to the MariaDB Pods.
+func WithSqlOpts(opts ...sqlClient.Opt) Option {
+	return func(rr *ReplicationReconciler) {
+		rr.sqlOpts = opts
+	}
+}
+
 type ReplicationReconciler struct {
 	client.Client
 	recorder          record.EventRecorder
@@ -48,6 +58,7 @@ type ReplicationReconciler struct {
 	refResolver       *refresolver.RefResolver
 	secretReconciler  *secret.SecretReconciler
 	serviceReconciler *service.ServiceReconciler
+	sqlOpts           []sqlClient.Opt
 }
 
 func NewReplicationReconciler(client client.Client, recorder record.EventRecorder, builder *builder.Builder, replConfig *ReplicationConfig,
@@ -85,14 +96,24 @@ type replicationPhase struct {
 	reconcile func(context.Context, *reconcileRequest, logr.Logger) error
 }
 
-func (r *ReplicationReconciler) Reconcile(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+// Reconcile configures replication in the MariaDB Pods and performs the primary switchovers.
+// The switchover is requeued while the connections of the primary are being drained.
+func (r *ReplicationReconciler) Reconcile(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) (ctrl.Result, error) {
+	err := r.reconcile(ctx, mariadb)
+	if errors.Is(err, errPrimaryDraining) {
+		return ctrl.Result{RequeueAfter: drainRequeueInterval}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *ReplicationReconciler) reconcile(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
 	if !mariadb.Replication().Enabled || mariadb.IsRestoringBackup() {
 		return nil
 	}
 	logger := log.FromContext(ctx).WithName("replication")
 
 	if mariadb.IsSwitchingPrimary() {
-		clientSet, err := newReplicationClientSet(mariadb, r.refResolver)
+		clientSet, err := newReplicationClientSet(mariadb, r.refResolver, r.sqlOpts...)
 		if err != nil {
 			return fmt.Errorf("error creating mariadb clientset: %v", err)
 		}
@@ -104,6 +125,9 @@ func (r *ReplicationReconciler) Reconcile(ctx context.Context, mariadb *mariadbv
 			clientSet: clientSet,
 		}
 		if err := r.reconcileSwitchover(ctx, &req, logger.WithName("switchover")); err != nil {
+			if errors.Is(err, errPrimaryDraining) {
+				return err
+			}
 			return fmt.Errorf("error recovering primary switchover: %v", err)
 		}
 		return nil
@@ -116,7 +140,7 @@ func (r *ReplicationReconciler) Reconcile(ctx context.Context, mariadb *mariadbv
 		return nil
 	}
 
-	clientSet, err := newReplicationClientSet(mariadb, r.refResolver)
+	clientSet, err := newReplicationClientSet(mariadb, r.refResolver, r.sqlOpts...)
 	if err != nil {
 		return fmt.Errorf("error creating mariadb clientset: %v", err)
 	}
@@ -149,6 +173,36 @@ func (r *ReplicationReconciler) Reconcile(ctx context.Context, mariadb *mariadbv
 			key:       mariaDbKey,
 			reconcile: r.reconcileSwitchover,
 		},
+		{
+			name:      "reconcile external primary",
+			key:       mariaDbKey,
+			reconcile: r.reconcileExternal,
+		},
+		{
+			name:      "reconcile GTID settings",
+			key:       mariaDbKey,
+			reconcile: r.reconcileGtidSettings,
+		},
+		{
+			name:      "reconcile replica TLS",
+			key:       mariaDbKey,
+			reconcile: r.reconcileReplicaTLS,
+		},
+		{
+			name:      "reconcile GTID",
+			key:       mariaDbKey,
+			reconcile: r.reconcileGtid,
+		},
+		{
+			name:      "reconcile replication status",
+			key:       mariaDbKey,
+			reconcile: r.reconcileReplicationStatus,
+		},
+		{
+			name:      "reconcile semi-sync",
+			key:       mariaDbKey,
+			reconcile: r.reconcileSemiSync,
+		},
 	}
 
 	for _, p := range phases {
@@ -158,10 +212,10 @@ func (r *ReplicationReconciler) Reconcile(ctx context.Context, mariadb *mariadbv
 			clientSet: clientSet,
 		}
 		if err := p.reconcile(ctx, &req, logger); err != nil {
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) || errors.Is(err, errPrimaryDraining) {
 				return err
 			}
-			return fmt.Errorf("error reconciling '%s' phase: %v", p.name, err)
+			return fmt.Errorf("error reconciling '%s' phase: %w", p.name, err)
 		}
 	}
 	return nil
@@ -198,7 +252,7 @@ func (r *ReplicationReconciler) reconcileReplicas(ctx context.Context, req *reco
 		return nil
 	}
 	logger.V(1).Info("Configuring replicas")
-	for i := 0; i < int(req.mariadb.Spec.Replicas); i++ {
+	for i := 0; i < int(req.mariadb.ScaledReplicas()); i++ {
 		if i == *req.mariadb.Replication().Primary.PodIndex {
 			continue
 		}
@@ -209,7 +263,7 @@ func (r *ReplicationReconciler) reconcileReplicas(ctx context.Context, req *reco
 
 		logger.V(1).Info("Configuring replica", "pod-index", i)
 		if err := r.replConfig.ConfigureReplica(ctx, req.mariadb, client, i, *req.mariadb.Replication().Primary.PodIndex, false); err != nil {
-			return fmt.Errorf("error configuring replica '%d': %v", i, err)
+			return fmt.Errorf("error configuring replica '%d': %w", i, err)
 		}
 	}
 	return nil
@@ -0,0 +1,49 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcileExternal connects the primary to the external primary when 'spec.replication.external' is added or updated
+// after replication has been configured, and promotes it when it is removed, completing the migration from the external primary.
+func (r *ReplicationReconciler) reconcileExternal(ctx context.Context, req *reconcileRequest, logger logr.Logger) error {
+	if !req.mariadb.HasConfiguredReplication() || req.mariadb.IsSwitchingPrimary() {
+		return nil
+	}
+	client, err := req.clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting current primary client: %v", err)
+	}
+	address, err := client.ReplicationConnectionAddress(ctx, connectionName)
+	if err != nil {
+		return fmt.Errorf("error getting replication connection address: %v", err)
+	}
+	podIndex := *req.mariadb.Status.CurrentPrimaryPodIndex
+	external := req.mariadb.Replication().External
+
+	if external == nil {
+		if address == "" {
+			return nil
+		}
+		logger.Info("Promoting primary", "pod-index", podIndex, "external-primary", address)
+		r.recorder.Eventf(req.mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationExternalPromote,
+			"Stopping replication from external primary '%s'", address)
+		return r.replConfig.ConfigurePrimary(ctx, req.mariadb, client, podIndex)
+	}
+
+	externalAddress := net.JoinHostPort(external.Host, strconv.Itoa(int(external.PortOrDefault())))
+	if address == externalAddress {
+		return nil
+	}
+	logger.Info("Connecting primary to external primary", "pod-index", podIndex, "external-primary", externalAddress)
+	r.recorder.Eventf(req.mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationExternalConnect,
+		"Connecting to external primary '%s'", externalAddress)
+	return r.replConfig.ConfigurePrimary(ctx, req.mariadb, client, podIndex)
+}
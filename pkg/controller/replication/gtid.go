@@ -0,0 +1,135 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/gtid"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// gtidStatusRefreshInterval is the minimum interval between status updates when only the GTID positions have changed,
+// as they change with every transaction and updating them on every reconciliation would trigger new reconciliations.
+const gtidStatusRefreshInterval = time.Minute
+
+// reconcileGtid compares the GTID positions of the replicas with the primary to detect errant transactions and gaps.
+// Replicas with errant transactions are not eligible for automatic promotion.
+func (r *ReplicationReconciler) reconcileGtid(ctx context.Context, req *reconcileRequest, logger logr.Logger) error {
+	if !req.mariadb.HasConfiguredReplication() || req.mariadb.IsSwitchingPrimary() {
+		return nil
+	}
+	// The replicas are read before the primary, so the primary position is never behind the transactions that the
+	// replicas have already applied from it.
+	replicaPositions := make(map[string]gtid.Position)
+	for i := 0; i < int(req.mariadb.ScaledReplicas()); i++ {
+		if i == *req.mariadb.Status.CurrentPrimaryPodIndex {
+			continue
+		}
+		podName := statefulset.PodName(req.mariadb.ObjectMeta, i)
+		pos, err := replicaGtidPosition(ctx, req, i)
+		if err != nil {
+			logger.V(1).Info("Unable to get replica GTID position", "pod", podName, "err", err)
+			continue
+		}
+		replicaPositions[podName] = pos
+	}
+
+	primaryClient, err := req.clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting current primary client: %v", err)
+	}
+	rawPrimaryPos, err := primaryClient.GtidCurrentPos(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting primary GTID position: %v", err)
+	}
+	primaryPos, err := gtid.ParsePosition(rawPrimaryPos)
+	if err != nil {
+		return fmt.Errorf("error parsing primary GTID position: %v", err)
+	}
+
+	gtidStatus := mariadbv1alpha1.GtidStatus{
+		PrimaryCurrentPos: primaryPos.String(),
+		Replicas:          make(map[string]mariadbv1alpha1.ReplicaGtidStatus),
+		LastCheckTime:     &metav1.Time{Time: time.Now()},
+	}
+	for podName, pos := range replicaPositions {
+		replicaStatus := replicaGtidStatus(pos, primaryPos)
+		r.recordGtidEvents(req.mariadb, podName, &replicaStatus, logger)
+		gtidStatus.Replicas[podName] = replicaStatus
+	}
+
+	if !shouldUpdateGtidStatus(req.mariadb.Status.Gtid, &gtidStatus) {
+		return nil
+	}
+	return r.patchStatus(ctx, req.mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.Gtid = &gtidStatus
+	})
+}
+
+func replicaGtidPosition(ctx context.Context, req *reconcileRequest, index int) (gtid.Position, error) {
+	client, err := req.clientSet.clientForIndex(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client for replica '%d': %v", index, err)
+	}
+	rawPos, err := client.GtidCurrentPos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GTID position: %v", err)
+	}
+	pos, err := gtid.ParsePosition(rawPos)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing GTID position: %v", err)
+	}
+	return pos, nil
+}
+
+func replicaGtidStatus(pos, primaryPos gtid.Position) mariadbv1alpha1.ReplicaGtidStatus {
+	return mariadbv1alpha1.ReplicaGtidStatus{
+		CurrentPos:     pos.String(),
+		ErrantGtids:    gtid.Errant(pos, primaryPos).String(),
+		MissingDomains: gtid.MissingDomains(pos, primaryPos),
+	}
+}
+
+// recordGtidEvents emits Events when errant transactions or gaps are detected for the first time in a replica.
+func (r *ReplicationReconciler) recordGtidEvents(mariadb *mariadbv1alpha1.MariaDB, podName string,
+	replicaStatus *mariadbv1alpha1.ReplicaGtidStatus, logger logr.Logger) {
+	var previous mariadbv1alpha1.ReplicaGtidStatus
+	if mariadb.Status.Gtid != nil {
+		previous = mariadb.Status.Gtid.Replicas[podName]
+	}
+
+	if replicaStatus.HasErrantGtids() && replicaStatus.ErrantGtids != previous.ErrantGtids {
+		logger.Info("Errant GTIDs detected in replica", "pod", podName, "gtids", replicaStatus.ErrantGtids)
+		r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonErrantGtidDetected,
+			"Errant GTIDs '%s' detected in replica '%s'. The replica will not be promoted automatically", replicaStatus.ErrantGtids, podName)
+	}
+	if replicaStatus.HasGaps() && !previous.HasGaps() {
+		domains := make([]string, len(replicaStatus.MissingDomains))
+		for i, d := range replicaStatus.MissingDomains {
+			domains[i] = fmt.Sprint(d)
+		}
+		logger.Info("GTID gap detected in replica", "pod", podName, "missing-domains", replicaStatus.MissingDomains)
+		r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonGtidGapDetected,
+			"Replica '%s' is missing the replication domains '%s' of the primary", podName, strings.Join(domains, ","))
+	}
+}
+
+func shouldUpdateGtidStatus(previous, current *mariadbv1alpha1.GtidStatus) bool {
+	if previous == nil || previous.LastCheckTime == nil || len(previous.Replicas) != len(current.Replicas) {
+		return true
+	}
+	for pod, replica := range current.Replicas {
+		prevReplica, ok := previous.Replicas[pod]
+		if !ok || prevReplica.ErrantGtids != replica.ErrantGtids || !reflect.DeepEqual(prevReplica.MissingDomains, replica.MissingDomains) {
+			return true
+		}
+	}
+	return current.LastCheckTime.Sub(previous.LastCheckTime.Time) >= gtidStatusRefreshInterval
+}
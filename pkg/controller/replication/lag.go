@@ -0,0 +1,103 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// replicationStatusRefreshInterval is the minimum interval between status updates when only the replication lag or
+// the GTID positions have changed, as they change continuously and updating them on every reconciliation would trigger new reconciliations.
+const replicationStatusRefreshInterval = time.Minute
+
+// reconcileReplicationStatus polls the replication threads of the replicas, reporting their replication lag
+// in the status and as metrics.
+func (r *ReplicationReconciler) reconcileReplicationStatus(ctx context.Context, req *reconcileRequest, logger logr.Logger) error {
+	if !req.mariadb.HasConfiguredReplication() || req.mariadb.IsSwitchingPrimary() {
+		return nil
+	}
+	DeleteReplicationMetrics(req.key)
+
+	replicationStatus := mariadbv1alpha1.ReplicationStatus{
+		LastCheckTime: &metav1.Time{Time: time.Now()},
+	}
+	for i := 0; i < int(req.mariadb.ScaledReplicas()); i++ {
+		if i == *req.mariadb.Status.CurrentPrimaryPodIndex {
+			continue
+		}
+		podName := statefulset.PodName(req.mariadb.ObjectMeta, i)
+		replicaStatus, err := r.replicaStatus(ctx, req, i, podName)
+		if err != nil {
+			logger.V(1).Info("Unable to get replica status", "pod", podName, "err", err)
+			continue
+		}
+		setReplicationMetrics(req, replicaStatus)
+		replicationStatus.Replicas = append(replicationStatus.Replicas, *replicaStatus)
+	}
+
+	if !shouldUpdateReplicationStatus(req.mariadb.Status.Replication, &replicationStatus, req.mariadb.SecondaryMaxLag()) {
+		return nil
+	}
+	return r.patchStatus(ctx, req.mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.Replication = &replicationStatus
+	})
+}
+
+func (r *ReplicationReconciler) replicaStatus(ctx context.Context, req *reconcileRequest, index int,
+	podName string) (*mariadbv1alpha1.ReplicaStatus, error) {
+	client, err := req.clientSet.clientForIndex(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client for replica '%d': %v", index, err)
+	}
+	status, err := client.ReplicaStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting replica status: %v", err)
+	}
+	replicaStatus := &mariadbv1alpha1.ReplicaStatus{
+		Name: podName,
+	}
+	if status != nil {
+		replicaStatus.IORunning = status.IORunning
+		replicaStatus.SQLRunning = status.SQLRunning
+		replicaStatus.SecondsBehindPrimary = status.SecondsBehindMaster
+		replicaStatus.GtidIOPos = status.GtidIOPos
+		replicaStatus.LastError = status.LastError
+	}
+	return replicaStatus, nil
+}
+
+func setReplicationMetrics(req *reconcileRequest, replicaStatus *mariadbv1alpha1.ReplicaStatus) {
+	running := 0.0
+	if replicaStatus.IORunning && replicaStatus.SQLRunning {
+		running = 1
+	}
+	replicationRunning.WithLabelValues(req.key.Namespace, req.key.Name, replicaStatus.Name).Set(running)
+	if replicaStatus.SecondsBehindPrimary != nil {
+		replicationLag.WithLabelValues(req.key.Namespace, req.key.Name, replicaStatus.Name).Set(float64(*replicaStatus.SecondsBehindPrimary))
+	}
+}
+
+// shouldUpdateReplicationStatus determines whether the replication status has to be updated, either because the state
+// of the replication threads has changed, a replica has crossed the maximum lag or because the refresh interval has elapsed.
+func shouldUpdateReplicationStatus(previous, current *mariadbv1alpha1.ReplicationStatus, maxLag *time.Duration) bool {
+	if previous == nil || previous.LastCheckTime == nil || len(previous.Replicas) != len(current.Replicas) {
+		return true
+	}
+	for i, replica := range current.Replicas {
+		prevReplica := previous.Replicas[i]
+		if prevReplica.Name != replica.Name || prevReplica.IORunning != replica.IORunning ||
+			prevReplica.SQLRunning != replica.SQLRunning || prevReplica.LastError != replica.LastError ||
+			(prevReplica.SecondsBehindPrimary == nil) != (replica.SecondsBehindPrimary == nil) {
+			return true
+		}
+		if maxLag != nil && prevReplica.IsLagging(*maxLag) != replica.IsLagging(*maxLag) {
+			return true
+		}
+	}
+	return current.LastCheckTime.Sub(previous.LastCheckTime.Time) >= replicationStatusRefreshInterval
+}
@@ -0,0 +1,67 @@
+package replication
+
+import (
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	replicationLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_replication_lag_seconds",
+		Help: "Replication lag of a replica in seconds, as reported by Seconds_Behind_Master. Not reported when the replication threads are not running.",
+	}, []string{"namespace", "mariadb", "pod"})
+	replicationRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_replication_running",
+		Help: "Whether the IO and SQL replication threads of a replica are running (1) or not (0).",
+	}, []string{"namespace", "mariadb", "pod"})
+	replicationChannelLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_replication_channel_lag_seconds",
+		Help: "Replication lag of a replication channel in seconds, as reported by Seconds_Behind_Master. Not reported when the replication threads are not running.",
+	}, []string{"namespace", "mariadb", "channel"})
+	replicationChannelRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_replication_channel_running",
+		Help: "Whether the IO and SQL replication threads of a replication channel are running (1) or not (0).",
+	}, []string{"namespace", "mariadb", "channel"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		replicationLag,
+		replicationRunning,
+		replicationChannelLag,
+		replicationChannelRunning,
+	)
+}
+
+// DeleteReplicationMetrics stops reporting the replication metrics of the replicas of a MariaDB.
+func DeleteReplicationMetrics(mariadbKey types.NamespacedName) {
+	labels := prometheus.Labels{
+		"namespace": mariadbKey.Namespace,
+		"mariadb":   mariadbKey.Name,
+	}
+	replicationLag.DeletePartialMatch(labels)
+	replicationRunning.DeletePartialMatch(labels)
+}
+
+// DeleteReplicationChannelMetrics stops reporting the replication channel metrics of a MariaDB.
+func DeleteReplicationChannelMetrics(mariadbKey types.NamespacedName) {
+	labels := prometheus.Labels{
+		"namespace": mariadbKey.Namespace,
+		"mariadb":   mariadbKey.Name,
+	}
+	replicationChannelLag.DeletePartialMatch(labels)
+	replicationChannelRunning.DeletePartialMatch(labels)
+}
+
+func setReplicationChannelMetrics(namespace, mariadb string, channel *mariadbv1alpha1.ReplicationChannelStatus) {
+	running := 0.0
+	if channel.IORunning && channel.SQLRunning {
+		running = 1
+	}
+	replicationChannelRunning.WithLabelValues(namespace, mariadb, channel.Name).Set(running)
+	if channel.SecondsBehindSource != nil {
+		replicationChannelLag.WithLabelValues(namespace, mariadb, channel.Name).Set(float64(*channel.SecondsBehindSource))
+	}
+}
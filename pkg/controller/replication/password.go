@@ -0,0 +1,72 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/controller/secret"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RotatePassword sets a new random password to the replication user in the primary, from where it is replicated to the replicas.
+// The replication user gets the current password back if the Secret cannot be updated. Then, the replicas are pointed to the
+// primary again with the new password one at a time, waiting for each of them to be replicating before moving on to the next one.
+func (r *ReplicationReconciler) RotatePassword(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) error {
+	if !mariadb.HasConfiguredReplication() || mariadb.IsSwitchingPrimary() || mariadb.Status.CurrentPrimaryPodIndex == nil {
+		return errors.New("replication must be configured to rotate the replication password")
+	}
+	logger := log.FromContext(ctx).WithName("replication")
+	primaryPodIndex := *mariadb.Status.CurrentPrimaryPodIndex
+
+	clientSet, err := newReplicationClientSet(mariadb, r.refResolver, r.sqlOpts...)
+	if err != nil {
+		return fmt.Errorf("error creating mariadb clientset: %v", err)
+	}
+	defer clientSet.close()
+
+	replPasswordRef := newReplPasswordRef(mariadb)
+	var replSecret corev1.Secret
+	if err := r.Get(ctx, replPasswordRef.NamespacedName, &replSecret); err != nil {
+		return fmt.Errorf("error getting replication password Secret: %v", err)
+	}
+	password := string(replSecret.Data[replPasswordRef.secretKey])
+	newPassword, err := secret.GeneratePassword()
+	if err != nil {
+		return fmt.Errorf("error generating replication password: %v", err)
+	}
+
+	primaryClient, err := clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := primaryClient.AlterUser(ctx, replUser, newPassword); err != nil {
+		return fmt.Errorf("error altering replication user: %v", err)
+	}
+	if err := r.secretReconciler.UpdatePassword(ctx, replPasswordRef.NamespacedName, replPasswordRef.secretKey, newPassword); err != nil {
+		if revertErr := primaryClient.AlterUser(ctx, replUser, password); revertErr != nil {
+			return fmt.Errorf("error reverting replication user after failing to update Secret (%v): %v", err, revertErr)
+		}
+		return fmt.Errorf("error updating replication password: %v", err)
+	}
+
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		if i == primaryPodIndex {
+			continue
+		}
+		client, err := clientSet.clientForIndex(ctx, i)
+		if err != nil {
+			return fmt.Errorf("error getting client for replica '%d': %v", i, err)
+		}
+		if err := r.replConfig.UpdateReplicaPassword(ctx, mariadb, client, primaryPodIndex, newPassword); err != nil {
+			return fmt.Errorf("error updating replication password in replica '%d': %v", i, err)
+		}
+		if err := waitForReplication(ctx, client, mariadb.Replication().Replica.ConnectionTimeout.Duration); err != nil {
+			return fmt.Errorf("error waiting for replica '%d': %v", i, err)
+		}
+		logger.V(1).Info("Updated replication password", "pod-index", i)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcileSemiSync keeps the semi-synchronous replication settings of the primary up to date with the spec,
+// reporting the effective ones in the status.
+func (r *ReplicationReconciler) reconcileSemiSync(ctx context.Context, req *reconcileRequest, logger logr.Logger) error {
+	if !req.mariadb.HasConfiguredReplication() || req.mariadb.IsSwitchingPrimary() {
+		return nil
+	}
+	primaryClient, err := req.clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting current primary client: %v", err)
+	}
+	status, err := r.replConfig.ReconcileSemiSync(ctx, req.mariadb, primaryClient)
+	if err != nil {
+		return fmt.Errorf("error reconciling semi-synchronous replication: %w", err)
+	}
+
+	semiSyncStatus := &mariadbv1alpha1.SemiSyncStatus{
+		Enabled:     status.Enabled,
+		Active:      status.Active,
+		Timeout:     &metav1.Duration{Duration: status.Timeout},
+		WaitPoint:   status.WaitPoint,
+		WaitNoSlave: status.WaitNoSlave,
+		Clients:     status.Clients,
+	}
+	if reflect.DeepEqual(req.mariadb.Status.SemiSync, semiSyncStatus) {
+		return nil
+	}
+	if previous := req.mariadb.Status.SemiSync; previous != nil && previous.Active && !semiSyncStatus.Active {
+		logger.Info("Primary fell back to asynchronous replication", "clients", semiSyncStatus.Clients)
+	}
+	return r.patchStatus(ctx, req.mariadb, func(s *mariadbv1alpha1.MariaDBStatus) {
+		s.SemiSync = semiSyncStatus
+	})
+}
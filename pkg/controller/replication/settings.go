@@ -0,0 +1,103 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// reconcileGtidSettings rolls out the GTID and binary log settings one Pod at a time, starting with the replicas and
+// finishing with the primary. The rollout is stopped if a replica is not replicating after applying them, so the
+// primary is only changed once all its replicas have picked up the new settings.
+func (r *ReplicationReconciler) reconcileGtidSettings(ctx context.Context, req *reconcileRequest, logger logr.Logger) error {
+	mariadb := req.mariadb
+	if !mariadb.HasConfiguredReplication() || mariadb.IsSwitchingPrimary() || mariadb.Status.CurrentPrimaryPodIndex == nil {
+		return nil
+	}
+	kv, err := gtidVars(mariadb)
+	if err != nil {
+		return err
+	}
+	if len(kv) == 0 {
+		return nil
+	}
+	primaryPodIndex := *mariadb.Status.CurrentPrimaryPodIndex
+
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		if i == primaryPodIndex {
+			continue
+		}
+		client, err := req.clientSet.clientForIndex(ctx, i)
+		if err != nil {
+			return fmt.Errorf("error getting client for replica '%d': %v", i, err)
+		}
+		if err := r.applyGtidSettings(ctx, mariadb, client, i, true, logger); err != nil {
+			return fmt.Errorf("error applying GTID settings to replica '%d': %v", i, err)
+		}
+	}
+
+	client, err := req.clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting current primary client: %v", err)
+	}
+	if err := r.applyGtidSettings(ctx, mariadb, client, primaryPodIndex, mariadb.IsReplicatingFromExternal(), logger); err != nil {
+		return fmt.Errorf("error applying GTID settings to primary: %v", err)
+	}
+	return nil
+}
+
+func (r *ReplicationReconciler) applyGtidSettings(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, client *sqlClient.Client,
+	podIndex int, replicating bool, logger logr.Logger) error {
+	changed, err := r.replConfig.ReconcileGtidSettings(ctx, mariadb, client, replicating)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	logger.Info("Applied GTID settings", "pod-index", podIndex)
+	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationGtidSettings,
+		"Applied GTID settings to Pod %d", podIndex)
+
+	if !replicating {
+		return nil
+	}
+	return waitForReplication(ctx, client, mariadb.Replication().Replica.ConnectionTimeout.Duration)
+}
+
+// waitForReplication waits until the replication threads of the Pod are running after restarting the replication connection.
+func waitForReplication(ctx context.Context, client *sqlClient.Client, timeout time.Duration) error {
+	var lastErr error
+	if err := wait.PollUntilContextTimeout(ctx, 1*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		conns, err := client.ReplicationConnections(ctx)
+		if err != nil {
+			lastErr = fmt.Errorf("error getting replication connections: %v", err)
+			return false, nil
+		}
+		for _, conn := range conns {
+			if conn.Name != connectionName {
+				continue
+			}
+			if conn.LastError != "" {
+				return false, fmt.Errorf("replication error: %s", conn.LastError)
+			}
+			lastErr = errors.New("replication threads are not running")
+			return conn.IORunning && conn.SQLRunning, nil
+		}
+		lastErr = errors.New("replication connection not found")
+		return false, nil
+	}); err != nil {
+		if wait.Interrupted(err) && lastErr != nil {
+			return fmt.Errorf("timeout waiting for replication: %v", lastErr)
+		}
+		return err
+	}
+	return nil
+}
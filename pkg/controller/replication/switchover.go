@@ -5,17 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	condition "github.com/mariadb-operator/mariadb-operator/pkg/condition"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
 	mariadbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
 	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
 	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type switchoverPhase struct {
@@ -38,13 +43,34 @@ func (r *ReplicationReconciler) reconcileSwitchover(ctx context.Context, req *re
 	toIndex := *req.mariadb.Replication().Primary.PodIndex
 	logger := switchoverLogger.WithValues("mariadb", req.mariadb.Name, "from-index", fromIndex, "to-index", toIndex)
 
+	starting := !req.mariadb.IsSwitchingPrimary()
 	if err := r.patchStatus(ctx, req.mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
 		condition.SetPrimarySwitching(&req.mariadb.Status, req.mariadb)
+		if status.Switchover == nil || status.Switchover.ToIndex != toIndex {
+			status.Switchover = &mariadbv1alpha1.SwitchoverStatus{
+				FromIndex: *fromIndex,
+				ToIndex:   toIndex,
+				StartTime: metav1.Now(),
+			}
+		}
 	}); err != nil {
 		return fmt.Errorf("error patching MariaDB status: %v", err)
 	}
+	if starting {
+		logger.Info("Switching primary")
+		r.recorder.Eventf(req.mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonPrimarySwitching,
+			"Switching primary from index '%d' to index '%d'", *fromIndex, toIndex)
+	}
 
 	phases := []switchoverPhase{
+		{
+			name:      "Fence primary",
+			reconcile: r.fencePrimary,
+		},
+		{
+			name:      "Drain connections in primary",
+			reconcile: r.drainPrimary,
+		},
 		{
 			name:      "Lock primary with read lock",
 			reconcile: r.lockPrimaryWithReadLock,
@@ -72,8 +98,11 @@ func (r *ReplicationReconciler) reconcileSwitchover(ctx context.Context, req *re
 	}
 
 	for _, p := range phases {
+		if err := r.setSwitchoverPhase(ctx, req.mariadb, p.name); err != nil {
+			return fmt.Errorf("error patching MariaDB status: %v", err)
+		}
 		if err := p.reconcile(ctx, req.mariadb, req.clientSet, logger); err != nil {
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) || errors.Is(err, errPrimaryDraining) {
 				return err
 			}
 			return fmt.Errorf("error in '%s' switchover reconcile phase: %v", p.name, err)
@@ -83,6 +112,7 @@ func (r *ReplicationReconciler) reconcileSwitchover(ctx context.Context, req *re
 	if err := r.patchStatus(ctx, req.mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
 		status.UpdateCurrentPrimary(req.mariadb, toIndex)
 		condition.SetPrimarySwitched(&req.mariadb.Status)
+		status.Switchover = nil
 	}); err != nil {
 		return fmt.Errorf("error patching MariaDB status: %v", err)
 	}
@@ -93,6 +123,209 @@ func (r *ReplicationReconciler) reconcileSwitchover(ctx context.Context, req *re
 	return nil
 }
 
+// setSwitchoverPhase records the switchover phase being performed in the MariaDB status.
+func (r *ReplicationReconciler) setSwitchoverPhase(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, phase string) error {
+	if mariadb.Status.Switchover == nil || mariadb.Status.Switchover.Phase == phase {
+		return nil
+	}
+	return r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+		status.Switchover.Phase = phase
+	})
+}
+
+// fencePrimary prevents writes in the old primary when it is not ready, which is the case of an automatic failover, before
+// promoting a replica. The old primary is set to read_only and its connections are killed, which is not possible when it is
+// unreachable, so its Pod is optionally deleted as well. Planned switchovers lock the old primary in the next phases instead.
+// As read_only does not survive a restart, the Pod is annotated to keep it out of the Endpoints until it is reconfigured as a replica.
+func (r *ReplicationReconciler) fencePrimary(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	clientSet *replicationClientSet, logger logr.Logger) error {
+	fencing := mariadb.Replication().Primary.Fencing
+	if fencing == nil {
+		return nil
+	}
+	podKey := types.NamespacedName{
+		Name:      statefulset.PodName(mariadb.ObjectMeta, *mariadb.Status.CurrentPrimaryPodIndex),
+		Namespace: mariadb.Namespace,
+	}
+	var pod corev1.Pod
+	if err := r.Get(ctx, podKey, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error getting current primary Pod: %v", err)
+	}
+	if mariadbpod.PodReady(&pod) || isFencedPod(mariadb, &pod) {
+		return nil
+	}
+
+	logger.Info("Fencing primary", "pod", pod.Name)
+	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationPrimaryFence,
+		"Fencing primary '%s'", pod.Name)
+	if err := PatchFencedAnnotation(ctx, r.Client, &pod, true); err != nil {
+		return err
+	}
+
+	fenceCtx, cancel := context.WithTimeout(ctx, fencing.TimeoutOrDefault())
+	defer cancel()
+	if err := r.fencePrimaryConnections(fenceCtx, clientSet, logger); err != nil {
+		logger.Info("Unable to fence primary via SQL", "pod", pod.Name, "err", err)
+		r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonReplicationPrimaryFenceErr,
+			"Unable to set primary '%s' to read_only and kill its connections: %v", pod.Name, err)
+	}
+
+	if !fencing.DeletePod {
+		return nil
+	}
+	logger.Info("Deleting primary Pod", "pod", pod.Name)
+	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationPrimaryFence,
+		"Deleting primary Pod '%s'", pod.Name)
+	if err := r.Delete(ctx, &pod); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting primary Pod: %v", err)
+	}
+	return nil
+}
+
+func (r *ReplicationReconciler) fencePrimaryConnections(ctx context.Context, clientSet *replicationClientSet,
+	logger logr.Logger) error {
+	client, err := clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := client.EnableReadOnly(ctx); err != nil {
+		return fmt.Errorf("error enabling read_only: %v", err)
+	}
+	connections, err := client.Connections(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting connections: %v", err)
+	}
+	for _, c := range connections {
+		// the connection may have been closed in the meantime
+		if err := client.KillConnection(ctx, c.ID); err != nil {
+			logger.V(1).Info("Error killing connection", "id", c.ID, "user", c.User, "err", err)
+		}
+	}
+	return nil
+}
+
+// PatchFencedAnnotation sets or removes the annotation that keeps a fenced Pod out of the Endpoints.
+func PatchFencedAnnotation(ctx context.Context, c client.Client, pod *corev1.Pod, fenced bool) error {
+	if mariadbpod.PodFenced(pod) == fenced {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if fenced {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[metadata.FencedAnnotation] = "true"
+	} else {
+		delete(pod.Annotations, metadata.FencedAnnotation)
+	}
+	if err := c.Patch(ctx, pod, patch); err != nil {
+		return fmt.Errorf("error patching fenced annotation in Pod '%s': %v", pod.Name, err)
+	}
+	return nil
+}
+
+// drainRemaining returns the time left until the draining grace period elapses.
+func drainRemaining(switchover *mariadbv1alpha1.SwitchoverStatus, gracePeriod time.Duration, now time.Time) time.Duration {
+	if switchover == nil || switchover.DrainStartTime == nil {
+		return gracePeriod
+	}
+	return switchover.DrainStartTime.Add(gracePeriod).Sub(now)
+}
+
+// isFencedPod determines whether a Pod has been recreated after being fenced in the current switchover.
+func isFencedPod(mariadb *mariadbv1alpha1.MariaDB, pod *corev1.Pod) bool {
+	switchover := mariadb.Status.Switchover
+	return switchover != nil && !pod.CreationTimestamp.Before(&switchover.StartTime)
+}
+
+// errPrimaryDraining indicates that the switchover is waiting for the queries running in the primary to finish.
+var errPrimaryDraining = errors.New("draining connections in primary")
+
+// drainRequeueInterval is the interval in which the queries running in the primary are checked while draining.
+const drainRequeueInterval = 1 * time.Second
+
+// drainPrimary enables read_only in the primary, so no new writes are accepted, and waits for the running queries to finish
+// by requeueing until the grace period has elapsed. Then, the queries still running are killed according to the kill policy.
+func (r *ReplicationReconciler) drainPrimary(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
+	clientSet *replicationClientSet, logger logr.Logger) error {
+	draining := mariadb.Replication().Primary.ConnectionDraining
+	if draining == nil {
+		return nil
+	}
+	ready, err := r.currentPrimaryReady(ctx, mariadb)
+	if err != nil {
+		return fmt.Errorf("error getting current primary readiness: %v", err)
+	}
+	if !ready {
+		return nil
+	}
+	client, err := clientSet.currentPrimaryClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting current primary client: %v", err)
+	}
+
+	gracePeriod := draining.GracePeriodOrDefault()
+	if mariadb.Status.Switchover != nil && mariadb.Status.Switchover.DrainStartTime == nil {
+		logger.Info("Draining connections in primary", "grace-period", gracePeriod)
+		r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationPrimaryDrain,
+			"Draining connections in primary with a grace period of %s", gracePeriod)
+		if err := r.patchStatus(ctx, mariadb, func(status *mariadbv1alpha1.MariaDBStatus) {
+			status.Switchover.DrainStartTime = ptr.To(metav1.Now())
+		}); err != nil {
+			return fmt.Errorf("error patching MariaDB status: %v", err)
+		}
+	}
+	if err := client.EnableReadOnly(ctx); err != nil {
+		return fmt.Errorf("error enabling read_only: %v", err)
+	}
+
+	processes, err := client.ActiveProcesses(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting active processes in primary: %v", err)
+	}
+	if len(processes) == 0 {
+		return nil
+	}
+	if remaining := drainRemaining(mariadb.Status.Switchover, gracePeriod, time.Now()); remaining > 0 {
+		logger.V(1).Info("Waiting for the running queries in primary", "queries", len(processes), "remaining", remaining)
+		return errPrimaryDraining
+	}
+
+	killPolicy := draining.KillPolicyOrDefault()
+	if killPolicy == mariadbv1alpha1.KillPolicyNone {
+		logger.Info("Grace period elapsed, waiting for the running queries", "queries", len(processes))
+		return nil
+	}
+	killed := 0
+	for _, p := range processes {
+		if draining.IsExempt(p.User) {
+			continue
+		}
+		logger.V(1).Info("Killing query", "id", p.ID, "user", p.User, "time", p.Time, "policy", killPolicy)
+		var killErr error
+		if killPolicy == mariadbv1alpha1.KillPolicyConnection {
+			killErr = client.KillConnection(ctx, p.ID)
+		} else {
+			killErr = client.KillQuery(ctx, p.ID)
+		}
+		// the query may have finished in the meantime
+		if killErr != nil {
+			logger.V(1).Info("Error killing query", "id", p.ID, "err", killErr)
+			continue
+		}
+		killed++
+	}
+	if killed > 0 {
+		logger.Info("Killed running queries in primary", "queries", killed, "policy", killPolicy)
+		r.recorder.Eventf(mariadb, corev1.EventTypeWarning, mariadbv1alpha1.ReasonReplicationPrimaryKill,
+			"Killed %d running queries in primary after a grace period of %s", killed, gracePeriod)
+	}
+	return nil
+}
+
 func (r *ReplicationReconciler) lockPrimaryWithReadLock(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
 	clientSet *replicationClientSet, logger logr.Logger) error {
 	ready, err := r.currentPrimaryReady(ctx, mariadb)
@@ -161,7 +394,7 @@ func (r *ReplicationReconciler) waitForReplicaSync(ctx context.Context, mariadb
 	logger.Info("Waiting for replicas to be synced with primary")
 	r.recorder.Event(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationReplicaSync,
 		"Waiting for replicas to be synced with primary")
-	for i := 0; i < int(mariadb.Spec.Replicas); i++ {
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
 		if i == *mariadb.Status.CurrentPrimaryPodIndex {
 			continue
 		}
@@ -251,7 +484,7 @@ func (r *ReplicationReconciler) connectReplicasToNewPrimary(ctx context.Context,
 	logger.Info("Connecting replicas to new primary")
 	r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationReplicaConn, "Connecting replicas to new primary")
 
-	for i := 0; i < int(mariadb.Spec.Replicas); i++ {
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
 		if i == *mariadb.Status.CurrentPrimaryPodIndex || i == *mariadb.Replication().Primary.PodIndex {
 			continue
 		}
@@ -333,14 +566,26 @@ func (r *ReplicationReconciler) changePrimaryToReplica(ctx context.Context, mari
 		return fmt.Errorf("error unlocking primary: %v", err)
 	}
 
-	return r.replConfig.ConfigureReplica(
+	if err := r.replConfig.ConfigureReplica(
 		ctx,
 		mariadb,
 		currentPrimaryClient,
 		currentPrimary,
 		newPrimary,
 		true,
-	)
+	); err != nil {
+		return err
+	}
+
+	var pod corev1.Pod
+	key := types.NamespacedName{
+		Name:      statefulset.PodName(mariadb.ObjectMeta, currentPrimary),
+		Namespace: mariadb.Namespace,
+	}
+	if err := r.Get(ctx, key, &pod); err != nil {
+		return fmt.Errorf("error getting current primary Pod: %v", err)
+	}
+	return PatchFencedAnnotation(ctx, r.Client, &pod, false)
 }
 
 func (r *ReplicationReconciler) resetSlave(ctx context.Context, client *sqlClient.Client) error {
@@ -0,0 +1,251 @@
+package replication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	mariadbpod "github.com/mariadb-operator/mariadb-operator/pkg/pod"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDrainRemaining(t *testing.T) {
+	now := time.Now()
+	gracePeriod := 30 * time.Second
+
+	tests := []struct {
+		name          string
+		switchover    *mariadbv1alpha1.SwitchoverStatus
+		wantRemaining time.Duration
+	}{
+		{
+			name:          "no switchover",
+			switchover:    nil,
+			wantRemaining: gracePeriod,
+		},
+		{
+			name:          "not started",
+			switchover:    &mariadbv1alpha1.SwitchoverStatus{},
+			wantRemaining: gracePeriod,
+		},
+		{
+			name: "in progress",
+			switchover: &mariadbv1alpha1.SwitchoverStatus{
+				DrainStartTime: ptr.To(metav1.NewTime(now.Add(-10 * time.Second))),
+			},
+			wantRemaining: 20 * time.Second,
+		},
+		{
+			name: "elapsed",
+			switchover: &mariadbv1alpha1.SwitchoverStatus{
+				DrainStartTime: ptr.To(metav1.NewTime(now.Add(-time.Minute))),
+			},
+			wantRemaining: -30 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining := drainRemaining(tt.switchover, gracePeriod, now)
+			if remaining != tt.wantRemaining {
+				t.Errorf("unexpected remaining time, expected: %v got: %v", tt.wantRemaining, remaining)
+			}
+		})
+	}
+}
+
+func TestFencePrimary(t *testing.T) {
+	newMariaDB := func(fencing *mariadbv1alpha1.Fencing) *mariadbv1alpha1.MariaDB {
+		return &mariadbv1alpha1.MariaDB{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-repl",
+				Namespace: "default",
+			},
+			Spec: mariadbv1alpha1.MariaDBSpec{
+				Replication: &mariadbv1alpha1.Replication{
+					Enabled: true,
+					ReplicationSpec: mariadbv1alpha1.ReplicationSpec{
+						Primary: &mariadbv1alpha1.PrimaryReplication{
+							PodIndex: ptr.To(1),
+							Fencing:  fencing,
+						},
+					},
+				},
+				Replicas: 3,
+			},
+			Status: mariadbv1alpha1.MariaDBStatus{
+				CurrentPrimaryPodIndex: ptr.To(0),
+				Switchover: &mariadbv1alpha1.SwitchoverStatus{
+					FromIndex: 0,
+					ToIndex:   1,
+					StartTime: metav1.NewTime(time.Now()),
+				},
+			},
+		}
+	}
+	newPod := func(ready bool, created time.Time) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "mariadb-repl-0",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: status,
+					},
+				},
+			},
+		}
+	}
+	fencing := &mariadbv1alpha1.Fencing{
+		Timeout: &metav1.Duration{Duration: time.Second},
+	}
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		mariadb     *mariadbv1alpha1.MariaDB
+		pod         *corev1.Pod
+		wantFenced  bool
+		wantDeleted bool
+		wantEvents  int
+	}{
+		{
+			name:    "fencing disabled",
+			mariadb: newMariaDB(nil),
+			pod:     newPod(false, past),
+		},
+		{
+			name:    "ready primary",
+			mariadb: newMariaDB(fencing),
+			pod:     newPod(true, past),
+		},
+		{
+			name:    "primary recreated after fencing",
+			mariadb: newMariaDB(fencing),
+			pod:     newPod(false, time.Now().Add(time.Hour)),
+		},
+		{
+			name:       "not ready primary",
+			mariadb:    newMariaDB(fencing),
+			pod:        newPod(false, past),
+			wantFenced: true,
+			wantEvents: 2,
+		},
+		{
+			name: "not ready primary with Pod deletion",
+			mariadb: newMariaDB(&mariadbv1alpha1.Fencing{
+				Timeout:   &metav1.Duration{Duration: time.Second},
+				DeletePod: true,
+			}),
+			pod:         newPod(false, past),
+			wantDeleted: true,
+			wantEvents:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tt.pod).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &ReplicationReconciler{
+				Client:   c,
+				recorder: recorder,
+			}
+			clientSet, err := newReplicationClientSet(tt.mariadb, refresolver.New(c))
+			if err != nil {
+				t.Fatalf("unexpected error creating client set: %v", err)
+			}
+			defer clientSet.close()
+
+			if err := r.fencePrimary(ctx, tt.mariadb, clientSet, logr.Discard()); err != nil {
+				t.Fatalf("unexpected error fencing primary: %v", err)
+			}
+
+			var pod corev1.Pod
+			err = c.Get(ctx, client.ObjectKeyFromObject(tt.pod), &pod)
+			if deleted := apierrors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Fatalf("unexpected Pod deletion, expected: %v got: %v (err: %v)", tt.wantDeleted, deleted, err)
+			}
+			if !tt.wantDeleted {
+				if fenced := mariadbpod.PodFenced(&pod); fenced != tt.wantFenced {
+					t.Errorf("unexpected fenced annotation, expected: %v got: %v", tt.wantFenced, fenced)
+				}
+			}
+			if events := len(recorder.Events); events != tt.wantEvents {
+				t.Errorf("unexpected number of events, expected: %d got: %d", tt.wantEvents, events)
+			}
+		})
+	}
+}
+
+func TestIsFencedPod(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name       string
+		switchover *mariadbv1alpha1.SwitchoverStatus
+		created    time.Time
+		wantFenced bool
+	}{
+		{
+			name:       "no switchover",
+			created:    start,
+			wantFenced: false,
+		},
+		{
+			name: "created before switchover",
+			switchover: &mariadbv1alpha1.SwitchoverStatus{
+				StartTime: metav1.NewTime(start),
+			},
+			created:    start.Add(-time.Minute),
+			wantFenced: false,
+		},
+		{
+			name: "created after switchover",
+			switchover: &mariadbv1alpha1.SwitchoverStatus{
+				StartTime: metav1.NewTime(start),
+			},
+			created:    start.Add(time.Minute),
+			wantFenced: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mariadb := &mariadbv1alpha1.MariaDB{
+				Status: mariadbv1alpha1.MariaDBStatus{
+					Switchover: tt.switchover,
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(tt.created),
+				},
+			}
+			if fenced := isFencedPod(mariadb, pod); fenced != tt.wantFenced {
+				t.Errorf("unexpected fenced Pod, expected: %v got: %v", tt.wantFenced, fenced)
+			}
+		})
+	}
+}
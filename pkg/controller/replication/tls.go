@@ -0,0 +1,52 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reconcileReplicaTLS enables or disables TLS in the replication connections that were configured before the
+// replication TLS settings changed, one replica at a time. Replicas configured afterwards already use the current settings.
+func (r *ReplicationReconciler) reconcileReplicaTLS(ctx context.Context, req *reconcileRequest, logger logr.Logger) error {
+	mariadb := req.mariadb
+	if !mariadb.HasConfiguredReplication() || mariadb.IsSwitchingPrimary() || mariadb.Status.CurrentPrimaryPodIndex == nil {
+		return nil
+	}
+	primaryPodIndex := *mariadb.Status.CurrentPrimaryPodIndex
+
+	for i := 0; i < int(mariadb.ScaledReplicas()); i++ {
+		if i == primaryPodIndex {
+			continue
+		}
+		client, err := req.clientSet.clientForIndex(ctx, i)
+		if err != nil {
+			return fmt.Errorf("error getting client for replica '%d': %v", i, err)
+		}
+		changed, err := r.replConfig.ReconcileReplicaTLS(ctx, mariadb, client, primaryPodIndex)
+		if err != nil {
+			return fmt.Errorf("error reconciling TLS in replica '%d': %v", i, err)
+		}
+		if !changed {
+			continue
+		}
+		logger.Info("Reconciled replication TLS", "pod-index", i, "tls", mariadb.IsReplicationTLSEnabled())
+		r.recorder.Eventf(mariadb, corev1.EventTypeNormal, mariadbv1alpha1.ReasonReplicationTLS,
+			"Replica %d reconnected to the primary with TLS %s", i, enabledString(mariadb.IsReplicationTLSEnabled()))
+
+		if err := waitForReplication(ctx, client, mariadb.Replication().Replica.ConnectionTimeout.Duration); err != nil {
+			return fmt.Errorf("error waiting for replica '%d': %v", i, err)
+		}
+	}
+	return nil
+}
+
+func enabledString(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/mariadb-operator/mariadb-operator/pkg/builder"
 	"github.com/sethvargo/go-password/password"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,11 +27,17 @@ func NewSecretReconciler(client client.Client, builder *builder.Builder) *Secret
 
 func (r *SecretReconciler) ReconcileRandomPassword(ctx context.Context, key types.NamespacedName, secretKey string,
 	mariadb *mariadbv1alpha1.MariaDB) (string, error) {
+	return r.ReconcileRandomPasswordWithOwner(ctx, key, secretKey, mariadb, mariadb)
+}
+
+// ReconcileRandomPasswordWithOwner reconciles a random password Secret controlled by the given owner.
+func (r *SecretReconciler) ReconcileRandomPasswordWithOwner(ctx context.Context, key types.NamespacedName, secretKey string,
+	mariadb *mariadbv1alpha1.MariaDB, owner metav1.Object) (string, error) {
 	var existingSecret corev1.Secret
 	if err := r.Get(ctx, key, &existingSecret); err == nil {
 		return string(existingSecret.Data[secretKey]), nil
 	}
-	password, err := password.Generate(16, 4, 2, false, false)
+	password, err := GeneratePassword()
 	if err != nil {
 		return "", fmt.Errorf("error generating replication password: %v", err)
 	}
@@ -42,7 +49,7 @@ func (r *SecretReconciler) ReconcileRandomPassword(ctx context.Context, key type
 			secretKey: []byte(password),
 		},
 	}
-	secret, err := r.Builder.BuildSecret(opts, mariadb)
+	secret, err := r.Builder.BuildSecret(opts, owner)
 	if err != nil {
 		return "", fmt.Errorf("error building replication password Secret: %v", err)
 	}
@@ -52,3 +59,69 @@ func (r *SecretReconciler) ReconcileRandomPassword(ctx context.Context, key type
 
 	return password, nil
 }
+
+// RotateRandomPassword replaces the password of an existing Secret with a new random one. When previousSecretKey is not empty,
+// the replaced password is kept under that key, so the consumers are able to authenticate until the new password is in use.
+func (r *SecretReconciler) RotateRandomPassword(ctx context.Context, key types.NamespacedName, secretKey,
+	previousSecretKey string) (string, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return "", fmt.Errorf("error getting password Secret: %v", err)
+	}
+	password, err := GeneratePassword()
+	if err != nil {
+		return "", fmt.Errorf("error generating password: %v", err)
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if previousSecretKey != "" {
+		secret.Data[previousSecretKey] = secret.Data[secretKey]
+	}
+	secret.Data[secretKey] = []byte(password)
+	if err := r.Patch(ctx, &secret, patch); err != nil {
+		return "", fmt.Errorf("error patching password Secret: %v", err)
+	}
+	return password, nil
+}
+
+// UpdatePassword sets the password of an existing Secret.
+func (r *SecretReconciler) UpdatePassword(ctx context.Context, key types.NamespacedName, secretKey, password string) error {
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return fmt.Errorf("error getting password Secret: %v", err)
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[secretKey] = []byte(password)
+	if err := r.Patch(ctx, &secret, patch); err != nil {
+		return fmt.Errorf("error patching password Secret: %v", err)
+	}
+	return nil
+}
+
+// DeleteKey removes a key from an existing Secret.
+func (r *SecretReconciler) DeleteKey(ctx context.Context, key types.NamespacedName, secretKey string) error {
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return fmt.Errorf("error getting Secret: %v", err)
+	}
+	if _, ok := secret.Data[secretKey]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	delete(secret.Data, secretKey)
+	if err := r.Patch(ctx, &secret, patch); err != nil {
+		return fmt.Errorf("error patching Secret: %v", err)
+	}
+	return nil
+}
+
+// GeneratePassword generates a new random password.
+func GeneratePassword() (string, error) {
+	return password.Generate(16, 4, 2, false, false)
+}
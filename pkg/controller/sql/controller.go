@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -25,10 +26,11 @@ type SqlReconciler struct {
 	WrappedReconciler WrappedReconciler
 	Finalizer         Finalizer
 	RequeueInterval   time.Duration
+	ClientOpts        []sqlClient.Opt
 }
 
 func NewSqlReconciler(client client.Client, cr *condition.Ready, wr WrappedReconciler, f Finalizer,
-	requeueInterval time.Duration) Reconciler {
+	requeueInterval time.Duration, clientOpts ...sqlClient.Opt) Reconciler {
 	return &SqlReconciler{
 		Client:            client,
 		RefResolver:       refresolver.New(client),
@@ -36,6 +38,7 @@ func NewSqlReconciler(client client.Client, cr *condition.Ready, wr WrappedRecon
 		WrappedReconciler: wr,
 		Finalizer:         f,
 		RequeueInterval:   requeueInterval,
+		ClientOpts:        clientOpts,
 	}
 }
 
@@ -69,7 +72,7 @@ func (r *SqlReconciler) Reconcile(ctx context.Context, resource Resource) (ctrl.
 	}
 
 	// TODO: connection pooling. See https://github.com/mariadb-operator/mariadb-operator/issues/7.
-	mdbClient, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, r.RefResolver)
+	mdbClient, err := sqlClient.NewOperatorClientWithMariaDB(ctx, mariadb, r.RefResolver, sqlClientOpts(r.ClientOpts, resource)...)
 	if err != nil {
 		var errBundle *multierror.Error
 		errBundle = multierror.Append(errBundle, err)
@@ -86,6 +89,15 @@ func (r *SqlReconciler) Reconcile(ctx context.Context, resource Resource) (ctrl.
 	var errBundle *multierror.Error
 	errBundle = multierror.Append(errBundle, err)
 
+	if err := errBundle.ErrorOrNil(); err != nil && sqlClient.IsUnsupported(err) {
+		// Retrying will not help until the resource is updated or the server is upgraded, hence the regular requeue.
+		log.FromContext(ctx).Info("Unsupported by MariaDB server version", "resource", resource.GetName(), "err", err)
+		msg := fmt.Sprintf("Unsupported by MariaDB: %v", err)
+		if err := r.WrappedReconciler.PatchStatus(ctx, r.ConditionReady.PatcherUnsupported(msg)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.requeueResult(ctx, resource)
+	}
 	if err := errBundle.ErrorOrNil(); err != nil {
 		msg := fmt.Sprintf("Error creating %s: %v", resource.GetName(), err)
 		err = r.WrappedReconciler.PatchStatus(ctx, r.ConditionReady.PatcherFailed(msg))
@@ -142,3 +154,11 @@ func waitForMariaDB(ctx context.Context, client client.Client, resource Resource
 	}
 	return mariadbErr.ErrorOrNil()
 }
+
+// sqlClientOpts returns the client options, where the timeout of the resource takes precedence over the default one.
+func sqlClientOpts(opts []sqlClient.Opt, resource Resource) []sqlClient.Opt {
+	if timeout := resource.SqlTimeout(); timeout != nil {
+		return append(slices.Clip(opts), sqlClient.WithQueryTimeout(timeout.Duration))
+	}
+	return opts
+}
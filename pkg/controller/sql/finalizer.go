@@ -15,13 +15,15 @@ type SqlFinalizer struct {
 	RefResolver *refresolver.RefResolver
 
 	WrappedFinalizer WrappedFinalizer
+	ClientOpts       []sqlClient.Opt
 }
 
-func NewSqlFinalizer(client client.Client, wf WrappedFinalizer) Finalizer {
+func NewSqlFinalizer(client client.Client, wf WrappedFinalizer, clientOpts ...sqlClient.Opt) Finalizer {
 	return &SqlFinalizer{
 		Client:           client,
 		RefResolver:      refresolver.New(client),
 		WrappedFinalizer: wf,
+		ClientOpts:       clientOpts,
 	}
 }
 
@@ -56,7 +58,7 @@ func (tf *SqlFinalizer) Finalize(ctx context.Context, resource Resource) error {
 	}
 
 	// TODO: connection pooling. See https://github.com/mariadb-operator/mariadb-operator/issues/7.
-	mdbClient, err := sqlClient.NewClientWithMariaDB(ctx, mariadb, tf.RefResolver)
+	mdbClient, err := sqlClient.NewOperatorClientWithMariaDB(ctx, mariadb, tf.RefResolver, sqlClientOpts(tf.ClientOpts, resource)...)
 	if err != nil {
 		return fmt.Errorf("error connecting to MariaDB: %v", err)
 	}
@@ -17,6 +17,7 @@ type Resource interface {
 	IsBeingDeleted() bool
 	RequeueInterval() *metav1.Duration
 	RetryInterval() *metav1.Duration
+	SqlTimeout() *metav1.Duration
 }
 
 type Reconciler interface {
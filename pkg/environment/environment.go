@@ -15,6 +15,7 @@ type Environment struct {
 	MariadbOperatorImage     string `env:"MARIADB_OPERATOR_IMAGE,required"`
 	RelatedMariadbImage      string `env:"RELATED_IMAGE_MARIADB,required"`
 	RelatedExporterImage     string `env:"RELATED_IMAGE_EXPORTER,required"`
+	RelatedMaxscaleImage     string `env:"RELATED_IMAGE_MAXSCALE,default=mariadb/maxscale:23.08.5"`
 	WatchNamespace           string `env:"WATCH_NAMESPACE"`
 }
 
@@ -0,0 +1,28 @@
+package expiry
+
+import "time"
+
+// PasswordExpiration returns when a password expires according to the MariaDB password expiration policy.
+// The lifetime of the account is nil or negative when it uses the global default_password_lifetime, and a lifetime of 0 days
+// means that the password never expires, in which case nil is returned.
+func PasswordExpiration(lastChanged time.Time, lifetimeDays *int64, defaultLifetimeDays int64) *time.Time {
+	days := defaultLifetimeDays
+	if lifetimeDays != nil && *lifetimeDays >= 0 {
+		days = *lifetimeDays
+	}
+	if days <= 0 {
+		return nil
+	}
+	expiresAt := lastChanged.Add(time.Duration(days) * 24 * time.Hour)
+	return &expiresAt
+}
+
+// IsExpiring determines whether an expiration time falls within the warning window.
+func IsExpiring(expiresAt, now time.Time, window time.Duration) bool {
+	return !now.Add(window).Before(expiresAt)
+}
+
+// IsExpired determines whether an expiration time has already passed.
+func IsExpired(expiresAt, now time.Time) bool {
+	return !now.Before(expiresAt)
+}
@@ -0,0 +1,114 @@
+package expiry
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestPasswordExpiration(t *testing.T) {
+	lastChanged := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                string
+		lifetimeDays        *int64
+		defaultLifetimeDays int64
+		wantExpiresAt       *time.Time
+	}{
+		{
+			name:                "no policy",
+			lifetimeDays:        nil,
+			defaultLifetimeDays: 0,
+			wantExpiresAt:       nil,
+		},
+		{
+			name:                "default policy",
+			lifetimeDays:        nil,
+			defaultLifetimeDays: 30,
+			wantExpiresAt:       ptr.To(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:                "account using default policy",
+			lifetimeDays:        ptr.To(int64(-1)),
+			defaultLifetimeDays: 30,
+			wantExpiresAt:       ptr.To(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:                "account policy",
+			lifetimeDays:        ptr.To(int64(10)),
+			defaultLifetimeDays: 30,
+			wantExpiresAt:       ptr.To(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:                "account never expires",
+			lifetimeDays:        ptr.To(int64(0)),
+			defaultLifetimeDays: 30,
+			wantExpiresAt:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiresAt := PasswordExpiration(lastChanged, tt.lifetimeDays, tt.defaultLifetimeDays)
+			if tt.wantExpiresAt == nil {
+				if expiresAt != nil {
+					t.Fatalf("expecting no expiration, got %v", expiresAt)
+				}
+				return
+			}
+			if expiresAt == nil {
+				t.Fatalf("expecting expiration %v, got none", tt.wantExpiresAt)
+			}
+			if !expiresAt.Equal(*tt.wantExpiresAt) {
+				t.Errorf("expecting expiration %v, got %v", tt.wantExpiresAt, expiresAt)
+			}
+		})
+	}
+}
+
+func TestIsExpiring(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := 7 * 24 * time.Hour
+	tests := []struct {
+		name         string
+		expiresAt    time.Time
+		wantExpiring bool
+		wantExpired  bool
+	}{
+		{
+			name:         "far from expiration",
+			expiresAt:    now.Add(30 * 24 * time.Hour),
+			wantExpiring: false,
+			wantExpired:  false,
+		},
+		{
+			name:         "within window",
+			expiresAt:    now.Add(3 * 24 * time.Hour),
+			wantExpiring: true,
+			wantExpired:  false,
+		},
+		{
+			name:         "window boundary",
+			expiresAt:    now.Add(window),
+			wantExpiring: true,
+			wantExpired:  false,
+		},
+		{
+			name:         "expired",
+			expiresAt:    now.Add(-time.Hour),
+			wantExpiring: true,
+			wantExpired:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if expiring := IsExpiring(tt.expiresAt, now, window); expiring != tt.wantExpiring {
+				t.Errorf("expecting expiring to be %v, got %v", tt.wantExpiring, expiring)
+			}
+			if expired := IsExpired(tt.expiresAt, now); expired != tt.wantExpired {
+				t.Errorf("expecting expired to be %v, got %v", tt.wantExpired, expired)
+			}
+		})
+	}
+}
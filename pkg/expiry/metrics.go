@@ -0,0 +1,57 @@
+package expiry
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	certificateExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_certificate_expiration_timestamp_seconds",
+		Help: "Unix timestamp in seconds when a certificate managed by the operator expires.",
+	}, []string{"namespace", "secret", "certificate"})
+	passwordLastChanged = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_user_password_last_changed_timestamp_seconds",
+		Help: "Unix timestamp in seconds when the password of a User was last changed.",
+	}, []string{"namespace", "user"})
+	passwordExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mariadb_operator_user_password_expiration_timestamp_seconds",
+		Help: "Unix timestamp in seconds when the password of a User expires, only reported when an expiration policy applies.",
+	}, []string{"namespace", "user"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		certificateExpiration,
+		passwordLastChanged,
+		passwordExpiration,
+	)
+}
+
+// SetCertificateExpiration records the expiration of a certificate stored in the Secret with the given key.
+func SetCertificateExpiration(secretKey types.NamespacedName, certificate string, cert *x509.Certificate) {
+	if cert == nil {
+		return
+	}
+	certificateExpiration.WithLabelValues(secretKey.Namespace, secretKey.Name, certificate).Set(float64(cert.NotAfter.Unix()))
+}
+
+// SetPasswordExpiration records when the password of a User was last changed and when it expires, if it does.
+func SetPasswordExpiration(userKey types.NamespacedName, lastChanged time.Time, expiresAt *time.Time) {
+	passwordLastChanged.WithLabelValues(userKey.Namespace, userKey.Name).Set(float64(lastChanged.Unix()))
+	if expiresAt == nil {
+		passwordExpiration.DeleteLabelValues(userKey.Namespace, userKey.Name)
+		return
+	}
+	passwordExpiration.WithLabelValues(userKey.Namespace, userKey.Name).Set(float64(expiresAt.Unix()))
+}
+
+// DeletePasswordExpiration stops reporting the password metrics of a User.
+func DeletePasswordExpiration(userKey types.NamespacedName) {
+	passwordLastChanged.DeleteLabelValues(userKey.Namespace, userKey.Name)
+	passwordExpiration.DeleteLabelValues(userKey.Namespace, userKey.Name)
+}
@@ -0,0 +1,117 @@
+package gtid
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GTID is a MariaDB global transaction ID in the domain-server-sequence format.
+type GTID struct {
+	DomainID       uint32
+	ServerID       uint32
+	SequenceNumber uint64
+}
+
+func (g GTID) String() string {
+	return fmt.Sprintf("%d-%d-%d", g.DomainID, g.ServerID, g.SequenceNumber)
+}
+
+// Position is a GTID position, i.e. 'gtid_current_pos', containing the last GTID of each replication domain.
+type Position map[uint32]GTID
+
+// ParseGTID parses a GTID in the domain-server-sequence format.
+func ParseGTID(s string) (*GTID, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid GTID '%s', expected format is 'domain-server-sequence'", s)
+	}
+	domain, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain in GTID '%s': %v", s, err)
+	}
+	server, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server in GTID '%s': %v", s, err)
+	}
+	sequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sequence number in GTID '%s': %v", s, err)
+	}
+	return &GTID{
+		DomainID:       uint32(domain),
+		ServerID:       uint32(server),
+		SequenceNumber: sequence,
+	}, nil
+}
+
+// ParsePosition parses a comma separated list of GTIDs, one per replication domain.
+func ParsePosition(s string) (Position, error) {
+	pos := Position{}
+	if strings.TrimSpace(s) == "" {
+		return pos, nil
+	}
+	for _, raw := range strings.Split(s, ",") {
+		gtid, err := ParseGTID(raw)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := pos[gtid.DomainID]; ok {
+			return nil, fmt.Errorf("duplicated domain %d in position '%s'", gtid.DomainID, s)
+		}
+		pos[gtid.DomainID] = *gtid
+	}
+	return pos, nil
+}
+
+func (p Position) String() string {
+	gtids := p.sorted()
+	values := make([]string, len(gtids))
+	for i, g := range gtids {
+		values[i] = g.String()
+	}
+	return strings.Join(values, ",")
+}
+
+// Errant returns the GTIDs of the replica position that are not present in the primary position,
+// which means that the replica has executed transactions that have not been executed by the primary.
+// A domain is errant when the replica is ahead of the primary with a transaction originated in a different server,
+// or when it has the same sequence number with a different server, as both histories have diverged. Being ahead with
+// a transaction originated in the primary is not errant, as the primary position may have been read before it.
+func Errant(replica, primary Position) Position {
+	errant := Position{}
+	for domain, r := range replica {
+		p, ok := primary[domain]
+		if !ok || (r.SequenceNumber >= p.SequenceNumber && r.ServerID != p.ServerID) {
+			errant[domain] = r
+		}
+	}
+	return errant
+}
+
+// MissingDomains returns the domains of the primary position that are not present in the replica position,
+// which means that the replica has a gap in its history.
+func MissingDomains(replica, primary Position) []uint32 {
+	var domains []uint32
+	for domain := range primary {
+		if _, ok := replica[domain]; !ok {
+			domains = append(domains, domain)
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i] < domains[j]
+	})
+	return domains
+}
+
+func (p Position) sorted() []GTID {
+	gtids := make([]GTID, 0, len(p))
+	for _, g := range p {
+		gtids = append(gtids, g)
+	}
+	sort.Slice(gtids, func(i, j int) bool {
+		return gtids[i].DomainID < gtids[j].DomainID
+	})
+	return gtids
+}
@@ -0,0 +1,134 @@
+package gtid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		pos     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			pos:  "",
+			want: "",
+		},
+		{
+			name: "single domain",
+			pos:  "0-10-42",
+			want: "0-10-42",
+		},
+		{
+			name: "multiple domains",
+			pos:  "1-11-5, 0-10-42",
+			want: "0-10-42,1-11-5",
+		},
+		{
+			name:    "invalid format",
+			pos:     "0-10",
+			wantErr: true,
+		},
+		{
+			name:    "invalid sequence",
+			pos:     "0-10-foo",
+			wantErr: true,
+		},
+		{
+			name:    "duplicated domain",
+			pos:     "0-10-42,0-11-43",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, err := ParsePosition(tt.pos)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pos.String() != tt.want {
+				t.Errorf("expected position '%s', got '%s'", tt.want, pos.String())
+			}
+		})
+	}
+}
+
+func TestErrant(t *testing.T) {
+	tests := []struct {
+		name               string
+		replica            string
+		primary            string
+		wantErrant         string
+		wantMissingDomains []uint32
+	}{
+		{
+			name:    "in sync",
+			replica: "0-10-42",
+			primary: "0-10-42",
+		},
+		{
+			name:    "replica behind",
+			replica: "0-10-40",
+			primary: "0-10-42",
+		},
+		{
+			name:       "replica ahead",
+			replica:    "0-11-43",
+			primary:    "0-10-42",
+			wantErrant: "0-11-43",
+		},
+		{
+			name:    "replica ahead with transactions from the primary",
+			replica: "0-10-43",
+			primary: "0-10-42",
+		},
+		{
+			name:       "diverged history",
+			replica:    "0-11-42",
+			primary:    "0-10-42",
+			wantErrant: "0-11-42",
+		},
+		{
+			name:       "unknown domain",
+			replica:    "0-10-42,1-11-3",
+			primary:    "0-10-42",
+			wantErrant: "1-11-3",
+		},
+		{
+			name:               "missing domain",
+			replica:            "0-10-42",
+			primary:            "0-10-42,1-10-7,2-10-1",
+			wantMissingDomains: []uint32{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replica, err := ParsePosition(tt.replica)
+			if err != nil {
+				t.Fatalf("unexpected error parsing replica position: %v", err)
+			}
+			primary, err := ParsePosition(tt.primary)
+			if err != nil {
+				t.Fatalf("unexpected error parsing primary position: %v", err)
+			}
+
+			if errant := Errant(replica, primary); errant.String() != tt.wantErrant {
+				t.Errorf("expected errant GTIDs '%s', got '%s'", tt.wantErrant, errant.String())
+			}
+			if missing := MissingDomains(replica, primary); !reflect.DeepEqual(missing, tt.wantMissingDomains) {
+				t.Errorf("expected missing domains %v, got %v", tt.wantMissingDomains, missing)
+			}
+		})
+	}
+}
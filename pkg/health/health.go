@@ -32,7 +32,7 @@ func IsMariaDBHealthy(ctx context.Context, client ctrlclient.Client, mariadb *ma
 	if err := client.Get(ctx, key, &statefulSet); err != nil {
 		return false, ctrlclient.IgnoreNotFound(err)
 	}
-	if statefulSet.Status.ReadyReplicas != mariadb.Spec.Replicas {
+	if statefulSet.Status.ReadyReplicas != mariadb.ScaledReplicas() {
 		return false, nil
 	}
 	var endpoints corev1.Endpoints
@@ -44,7 +44,7 @@ func IsMariaDBHealthy(ctx context.Context, client ctrlclient.Client, mariadb *ma
 			if port.Port == mariadb.Spec.Port {
 				switch endpointPolicy {
 				case EndpointPolicyAll:
-					return len(subset.Addresses) == int(mariadb.Spec.Replicas), nil
+					return len(subset.Addresses) == int(mariadb.ScaledReplicas()), nil
 				case EndpointPolicyAtLeastOne:
 					return len(subset.Addresses) > 0, nil
 				default:
@@ -56,6 +56,9 @@ func IsMariaDBHealthy(ctx context.Context, client ctrlclient.Client, mariadb *ma
 	return false, nil
 }
 
+// HealthyReplica returns the index of a ready replica that can be promoted to primary.
+// Replicas with errant GTIDs are excluded, as promoting them would propagate transactions not executed by the primary.
+// Replicas still being provisioned from a Backup and fenced old primaries are excluded too, as they may be missing data.
 func HealthyReplica(ctx context.Context, client client.Client, mariadb *mariadbv1alpha1.MariaDB) (*int, error) {
 	if mariadb.Status.CurrentPrimaryPodIndex == nil {
 		return nil, errors.New("'status.currentPrimaryPodIndex' must be set")
@@ -80,6 +83,9 @@ func HealthyReplica(ctx context.Context, client client.Client, mariadb *mariadbv
 		if *index == *mariadb.Status.CurrentPrimaryPodIndex {
 			continue
 		}
+		if mariadb.HasErrantGtids(p.Name) || pod.PodProvisioning(&p) || pod.PodFenced(&p) {
+			continue
+		}
 		if pod.PodReady(&p) {
 			return index, nil
 		}
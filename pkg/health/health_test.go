@@ -0,0 +1,128 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHealthyReplica(t *testing.T) {
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb-repl",
+			Namespace: "default",
+		},
+		Status: mariadbv1alpha1.MariaDBStatus{
+			CurrentPrimaryPodIndex: ptr.To(0),
+		},
+	}
+	newPod := func(index string, ready bool, annotations map[string]string) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mariadb-repl-" + index,
+				Namespace: mariadb.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":     "mariadb",
+					"app.kubernetes.io/instance": mariadb.Name,
+				},
+				Annotations: annotations,
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: status,
+					},
+				},
+			},
+		}
+	}
+	provisioning := map[string]string{
+		metadata.ProvisioningAnnotation: "true",
+	}
+	fenced := map[string]string{
+		metadata.FencedAnnotation: "true",
+	}
+
+	tests := []struct {
+		name      string
+		pods      []client.Object
+		wantIndex *int
+		wantErr   bool
+	}{
+		{
+			name: "ready replica",
+			pods: []client.Object{
+				newPod("0", true, nil),
+				newPod("1", true, nil),
+			},
+			wantIndex: ptr.To(1),
+		},
+		{
+			name: "not ready replica",
+			pods: []client.Object{
+				newPod("0", true, nil),
+				newPod("1", false, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "provisioning replica",
+			pods: []client.Object{
+				newPod("0", true, nil),
+				newPod("1", true, provisioning),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fenced replica",
+			pods: []client.Object{
+				newPod("0", true, nil),
+				newPod("1", true, fenced),
+			},
+			wantErr: true,
+		},
+		{
+			name: "skip provisioning and fenced replicas",
+			pods: []client.Object{
+				newPod("0", false, nil),
+				newPod("1", true, provisioning),
+				newPod("2", true, fenced),
+				newPod("3", true, nil),
+			},
+			wantIndex: ptr.To(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tt.pods...).
+				Build()
+
+			index, err := HealthyReplica(context.Background(), c, mariadb)
+			if tt.wantErr && err == nil {
+				t.Fatal("expecting error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (index == nil) != (tt.wantIndex == nil) || (index != nil && *index != *tt.wantIndex) {
+				t.Errorf("expecting index to be %v, got %v", ptr.Deref(tt.wantIndex, -1), ptr.Deref(index, -1))
+			}
+		})
+	}
+}
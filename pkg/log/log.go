@@ -4,7 +4,10 @@ import (
 	"os"
 
 	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
@@ -34,3 +37,20 @@ func SetupLogger(level, timeEncoder string, development bool) {
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 }
+
+// SetupKubeAPIWarningLogger logs the warnings returned by the Kubernetes API, such as the admission warnings of the webhooks,
+// with V(1) verbosity. They are addressed to the users applying the resources, but they are also returned every time the operator
+// writes them. It returns the client options that make the manager client honour the rest config handler.
+func SetupKubeAPIWarningLogger(config *rest.Config) client.Options {
+	config.WarningHandler = ctrllog.NewKubeAPIWarningLogger(
+		ctrl.Log.WithName("KubeAPIWarningLogger").V(1),
+		ctrllog.KubeAPIWarningLoggerOptions{
+			Deduplicate: true,
+		},
+	)
+	return client.Options{
+		WarningHandler: client.WarningHandlerOptions{
+			SuppressWarnings: true,
+		},
+	}
+}
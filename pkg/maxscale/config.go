@@ -0,0 +1,162 @@
+package maxscale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/statefulset"
+)
+
+const (
+	// PasswordEnv is the environment variable holding the password of the MaxScale user, substituted in the config.
+	PasswordEnv = "MAXSCALE_PASSWORD"
+	// AdminPort is the port of the MaxScale REST API, only reachable from within the Pod.
+	AdminPort = 8989
+
+	monitorName            = "monitor"
+	defaultMonitorInterval = 2 * time.Second
+)
+
+const defaultCausalReadsTimeout = 10 * time.Second
+
+type section struct {
+	name   string
+	params map[string]string
+}
+
+// Config renders the maxscale.cnf of a MaxScale routing connections to the servers of a MariaDB.
+// The password of the MaxScale user is not rendered in the config, it is substituted from the PasswordEnv environment variable.
+func Config(maxscale *mariadbv1alpha1.MaxScale, mariadb *mariadbv1alpha1.MariaDB) string {
+	sections := []section{
+		{
+			name: "maxscale",
+			params: map[string]string{
+				"threads":                 "auto",
+				"admin_host":              "127.0.0.1",
+				"admin_port":              fmt.Sprint(AdminPort),
+				"admin_secure_gui":        "false",
+				"persist_runtime_changes": "false",
+				"load_persisted_configs":  "false",
+				"substitute_variables":    "true",
+			},
+		},
+	}
+
+	servers := make([]string, mariadb.ScaledReplicas())
+	for i := range servers {
+		servers[i] = statefulset.PodName(mariadb.ObjectMeta, i)
+		sections = append(sections, section{
+			name: servers[i],
+			params: map[string]string{
+				"type":     "server",
+				"address":  statefulset.PodFQDNWithService(mariadb.ObjectMeta, i, mariadb.InternalServiceKey().Name),
+				"port":     fmt.Sprint(mariadb.Spec.Port),
+				"protocol": "MariaDBBackend",
+			},
+		})
+	}
+
+	sections = append(sections, monitorSection(maxscale, mariadb, servers))
+
+	for _, svc := range maxscale.Spec.Services {
+		sections = append(sections,
+			section{
+				name:   svc.Name,
+				params: serviceParams(maxscale, &svc),
+			},
+			section{
+				name: fmt.Sprintf("%s-listener", svc.Name),
+				params: withParams(map[string]string{
+					"type":     "listener",
+					"service":  svc.Name,
+					"protocol": "MariaDBClient",
+					"port":     fmt.Sprint(svc.Listener.Port),
+				}, svc.Listener.Params),
+			},
+		)
+	}
+
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeSection(&b, s)
+	}
+	return b.String()
+}
+
+func serviceParams(maxscale *mariadbv1alpha1.MaxScale, svc *mariadbv1alpha1.MaxScaleService) map[string]string {
+	params := map[string]string{
+		"type":     "service",
+		"router":   string(svc.Router),
+		"cluster":  monitorName,
+		"user":     maxscale.Spec.Auth.Username,
+		"password": "$" + PasswordEnv,
+	}
+	if svc.CausalReads != nil {
+		timeout := defaultCausalReadsTimeout
+		if svc.CausalReads.Timeout != nil {
+			timeout = svc.CausalReads.Timeout.Duration
+		}
+		params["causal_reads"] = string(svc.CausalReads.ModeOrDefault())
+		params["causal_reads_timeout"] = fmt.Sprintf("%dms", timeout.Milliseconds())
+	}
+	return withParams(params, svc.Params)
+}
+
+func monitorSection(maxscale *mariadbv1alpha1.MaxScale, mariadb *mariadbv1alpha1.MariaDB, servers []string) section {
+	interval := defaultMonitorInterval
+	if maxscale.Spec.Monitor.Interval != nil {
+		interval = maxscale.Spec.Monitor.Interval.Duration
+	}
+	module := maxscale.MonitorModule(mariadb)
+	params := map[string]string{
+		"type":             "monitor",
+		"module":           string(module),
+		"servers":          strings.Join(servers, ","),
+		"user":             maxscale.Spec.Auth.Username,
+		"password":         "$" + PasswordEnv,
+		"monitor_interval": fmt.Sprintf("%dms", interval.Milliseconds()),
+	}
+	if module == mariadbv1alpha1.MaxScaleMonitorModuleMariadb {
+		// The operator is in charge of the failover and the topology, MaxScale only tracks the primary.
+		params["auto_failover"] = "false"
+		params["auto_rejoin"] = "false"
+		if maxscale.Spec.Replicas > 1 {
+			params["cooperative_monitoring_locks"] = "majority_of_running"
+		}
+	}
+	return section{
+		name:   monitorName,
+		params: withParams(params, maxscale.Spec.Monitor.Params),
+	}
+}
+
+func withParams(params, extraParams map[string]string) map[string]string {
+	for k, v := range extraParams {
+		params[k] = v
+	}
+	return params
+}
+
+// writeSection writes a config section with the 'type' param first, followed by the rest of the params sorted by key.
+func writeSection(b *strings.Builder, s section) {
+	fmt.Fprintf(b, "[%s]\n", s.name)
+	if t, ok := s.params["type"]; ok {
+		fmt.Fprintf(b, "type=%s\n", t)
+	}
+	keys := make([]string, 0, len(s.params))
+	for k := range s.params {
+		if k != "type" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s=%s\n", k, s.params[k])
+	}
+}
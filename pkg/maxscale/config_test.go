@@ -0,0 +1,285 @@
+package maxscale
+
+import (
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfig(t *testing.T) {
+	objMeta := metav1.ObjectMeta{
+		Name:      "mariadb",
+		Namespace: "default",
+	}
+	tests := []struct {
+		name     string
+		maxscale *mariadbv1alpha1.MaxScale
+		mariadb  *mariadbv1alpha1.MariaDB
+		want     string
+	}{
+		{
+			name: "replication",
+			maxscale: &mariadbv1alpha1.MaxScale{
+				Spec: mariadbv1alpha1.MaxScaleSpec{
+					Replicas: 2,
+					Services: []mariadbv1alpha1.MaxScaleService{
+						{
+							Name:   "rw-router",
+							Router: mariadbv1alpha1.MaxScaleRouterReadWriteSplit,
+							Listener: mariadbv1alpha1.MaxScaleListener{
+								Port: 3306,
+							},
+							Params: map[string]string{
+								"transaction_replay": "true",
+							},
+						},
+					},
+					Auth: mariadbv1alpha1.MaxScaleAuth{
+						Username: "maxscale",
+					},
+				},
+			},
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+					Replicas: 2,
+					Port:     3306,
+				},
+			},
+			want: `[maxscale]
+admin_host=127.0.0.1
+admin_port=8989
+admin_secure_gui=false
+load_persisted_configs=false
+persist_runtime_changes=false
+substitute_variables=true
+threads=auto
+
+[mariadb-0]
+type=server
+address=mariadb-0.mariadb-internal.default.svc.cluster.local
+port=3306
+protocol=MariaDBBackend
+
+[mariadb-1]
+type=server
+address=mariadb-1.mariadb-internal.default.svc.cluster.local
+port=3306
+protocol=MariaDBBackend
+
+[monitor]
+type=monitor
+auto_failover=false
+auto_rejoin=false
+cooperative_monitoring_locks=majority_of_running
+module=mariadbmon
+monitor_interval=2000ms
+password=$MAXSCALE_PASSWORD
+servers=mariadb-0,mariadb-1
+user=maxscale
+
+[rw-router]
+type=service
+cluster=monitor
+password=$MAXSCALE_PASSWORD
+router=readwritesplit
+transaction_replay=true
+user=maxscale
+
+[rw-router-listener]
+type=listener
+port=3306
+protocol=MariaDBClient
+service=rw-router
+`,
+		},
+		{
+			name: "galera",
+			maxscale: &mariadbv1alpha1.MaxScale{
+				Spec: mariadbv1alpha1.MaxScaleSpec{
+					Replicas: 1,
+					Services: []mariadbv1alpha1.MaxScaleService{
+						{
+							Name:   "rconn-router",
+							Router: mariadbv1alpha1.MaxScaleRouterReadConnRoute,
+							Listener: mariadbv1alpha1.MaxScaleListener{
+								Port: 3307,
+							},
+							Params: map[string]string{
+								"router_options": "slave",
+							},
+						},
+					},
+					Monitor: mariadbv1alpha1.MaxScaleMonitor{
+						Interval: &metav1.Duration{Duration: 1 * time.Second},
+						Params: map[string]string{
+							"available_when_donor": "true",
+						},
+					},
+					Auth: mariadbv1alpha1.MaxScaleAuth{
+						Username: "maxscale",
+					},
+				},
+			},
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Galera: &mariadbv1alpha1.Galera{
+						Enabled: true,
+					},
+					Replicas: 1,
+					Port:     3306,
+				},
+			},
+			want: `[maxscale]
+admin_host=127.0.0.1
+admin_port=8989
+admin_secure_gui=false
+load_persisted_configs=false
+persist_runtime_changes=false
+substitute_variables=true
+threads=auto
+
+[mariadb-0]
+type=server
+address=mariadb-0.mariadb-internal.default.svc.cluster.local
+port=3306
+protocol=MariaDBBackend
+
+[monitor]
+type=monitor
+available_when_donor=true
+module=galeramon
+monitor_interval=1000ms
+password=$MAXSCALE_PASSWORD
+servers=mariadb-0
+user=maxscale
+
+[rconn-router]
+type=service
+cluster=monitor
+password=$MAXSCALE_PASSWORD
+router=readconnroute
+router_options=slave
+user=maxscale
+
+[rconn-router-listener]
+type=listener
+port=3307
+protocol=MariaDBClient
+service=rconn-router
+`,
+		},
+		{
+			name: "causal reads",
+			maxscale: &mariadbv1alpha1.MaxScale{
+				Spec: mariadbv1alpha1.MaxScaleSpec{
+					Replicas: 1,
+					Services: []mariadbv1alpha1.MaxScaleService{
+						{
+							Name:   "rw-router",
+							Router: mariadbv1alpha1.MaxScaleRouterReadWriteSplit,
+							Listener: mariadbv1alpha1.MaxScaleListener{
+								Port: 3306,
+							},
+							CausalReads: &mariadbv1alpha1.MaxScaleCausalReads{
+								Mode:    mariadbv1alpha1.MaxScaleCausalReadsFastGlobal,
+								Timeout: &metav1.Duration{Duration: 5 * time.Second},
+							},
+						},
+						{
+							Name:   "rw-router-local",
+							Router: mariadbv1alpha1.MaxScaleRouterReadWriteSplit,
+							Listener: mariadbv1alpha1.MaxScaleListener{
+								Port: 3308,
+							},
+							CausalReads: &mariadbv1alpha1.MaxScaleCausalReads{},
+						},
+					},
+					Auth: mariadbv1alpha1.MaxScaleAuth{
+						Username: "maxscale",
+					},
+				},
+			},
+			mariadb: &mariadbv1alpha1.MariaDB{
+				ObjectMeta: objMeta,
+				Spec: mariadbv1alpha1.MariaDBSpec{
+					Replication: &mariadbv1alpha1.Replication{
+						Enabled: true,
+					},
+					Replicas: 1,
+					Port:     3306,
+				},
+			},
+			want: `[maxscale]
+admin_host=127.0.0.1
+admin_port=8989
+admin_secure_gui=false
+load_persisted_configs=false
+persist_runtime_changes=false
+substitute_variables=true
+threads=auto
+
+[mariadb-0]
+type=server
+address=mariadb-0.mariadb-internal.default.svc.cluster.local
+port=3306
+protocol=MariaDBBackend
+
+[monitor]
+type=monitor
+auto_failover=false
+auto_rejoin=false
+module=mariadbmon
+monitor_interval=2000ms
+password=$MAXSCALE_PASSWORD
+servers=mariadb-0
+user=maxscale
+
+[rw-router]
+type=service
+causal_reads=fast_global
+causal_reads_timeout=5000ms
+cluster=monitor
+password=$MAXSCALE_PASSWORD
+router=readwritesplit
+user=maxscale
+
+[rw-router-listener]
+type=listener
+port=3306
+protocol=MariaDBClient
+service=rw-router
+
+[rw-router-local]
+type=service
+causal_reads=local
+causal_reads_timeout=10000ms
+cluster=monitor
+password=$MAXSCALE_PASSWORD
+router=readwritesplit
+user=maxscale
+
+[rw-router-local-listener]
+type=listener
+port=3308
+protocol=MariaDBClient
+service=rw-router-local
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config(tt.maxscale, tt.mariadb)
+			if config != tt.want {
+				t.Fatalf("unexpected config, expected:\n%s\ngot:\n%s", tt.want, config)
+			}
+		})
+	}
+}
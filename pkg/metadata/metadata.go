@@ -1,8 +1,24 @@
 package metadata
 
 var (
-	ReplicationAnnotation   = "mariadb.mmontes.io/replication"
-	GaleraAnnotation        = "mariadb.mmontes.io/galera"
-	MariadbAnnotation       = "mariadb.mmontes.io/mariadb"
-	WebhookConfigAnnotation = "mariadb.mmontes.io/webhook"
+	ReplicationAnnotation    = "mariadb.mmontes.io/replication"
+	GaleraAnnotation         = "mariadb.mmontes.io/galera"
+	MariadbAnnotation        = "mariadb.mmontes.io/mariadb"
+	WebhookConfigAnnotation  = "mariadb.mmontes.io/webhook"
+	GenerationAnnotation     = "mariadb.mmontes.io/generation"
+	GaleraStateAnnotation    = "mariadb.mmontes.io/galera-state"
+	GaleraSegmentAnnotation  = "mariadb.mmontes.io/galera-segment"
+	ConfigChecksumAnnotation = "mariadb.mmontes.io/config-checksum"
+	SkipRolloutAnnotation    = "mariadb.mmontes.io/skip-rollout"
+	UpgradeFromAnnotation    = "mariadb.mmontes.io/upgrade-from"
+	FleetSpecHashAnnotation  = "mariadb.mmontes.io/fleet-spec-hash"
+	WarmUpStartedAnnotation  = "mariadb.mmontes.io/warm-up-started"
+	WarmedUpAnnotation       = "mariadb.mmontes.io/warmed-up"
+	TLSCertSerialAnnotation  = "mariadb.mmontes.io/tls-cert-serial"
+	ProvisioningAnnotation   = "mariadb.mmontes.io/provisioning"
+	FencedAnnotation         = "mariadb.mmontes.io/fenced"
+
+	GaleraRecoveryApprovedAnnotation = "mariadb.mmontes.io/galera-recovery-approved"
+	GaleraStateTransferAnnotation    = "mariadb.mmontes.io/galera-state-transfer"
+	PasswordRotatedAtAnnotation      = "mariadb.mmontes.io/password-rotated-at"
 )
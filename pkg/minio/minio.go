@@ -11,9 +11,11 @@ import (
 )
 
 type MinioOpts struct {
-	Region     string
-	TLS        bool
-	CACertPath string
+	Region             string
+	TLS                bool
+	CACertPath         string
+	InsecureSkipVerify bool
+	PathStyle          bool
 }
 
 type MinioOpt func(m *MinioOpts)
@@ -31,6 +33,18 @@ func WithTLS(caCertPath string) MinioOpt {
 	}
 }
 
+func WithInsecureSkipVerify() MinioOpt {
+	return func(m *MinioOpts) {
+		m.InsecureSkipVerify = true
+	}
+}
+
+func WithPathStyle() MinioOpt {
+	return func(m *MinioOpts) {
+		m.PathStyle = true
+	}
+}
+
 func NewMinioClient(endpoint string, mOpts ...MinioOpt) (*minio.Client, error) {
 	opts := MinioOpts{}
 	for _, setOpt := range mOpts {
@@ -60,6 +74,9 @@ func getMinioOptions(opts MinioOpts) (*minio.Options, error) {
 		Secure:    opts.TLS,
 		Transport: transport,
 	}
+	if opts.PathStyle {
+		minioOpts.BucketLookup = minio.BucketLookupPath
+	}
 	return minioOpts, nil
 }
 
@@ -71,6 +88,12 @@ func getTransport(opts *MinioOpts) (*http.Transport, error) {
 	if !opts.TLS {
 		return transport, nil
 	}
+	if opts.InsecureSkipVerify {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if opts.CACertPath == "" {
+		return transport, nil
+	}
 
 	if transport.TLSClientConfig.RootCAs == nil {
 		pool, err := x509.SystemCertPool()
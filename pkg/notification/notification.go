@@ -0,0 +1,300 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/mariadb-operator/mariadb-operator/pkg/refresolver"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultWorkers        = 4
+	defaultQueueSize      = 100
+	pagerDutyEventsURL    = "https://events.pagerduty.com/v2/enqueue"
+	pagerDutySource       = "mariadb-operator"
+	pagerDutySeverity     = "error"
+	pagerDutyInfoSeverity = "info"
+)
+
+// Target is a notification target with its credentials already resolved.
+type Target struct {
+	Name       string                                 `json:"name"`
+	Type       mariadbv1alpha1.NotificationTargetType `json:"type"`
+	URL        string                                 `json:"url,omitempty"`
+	RoutingKey string                                 `json:"routingKey,omitempty"`
+	Events     []mariadbv1alpha1.NotificationEvent    `json:"events,omitempty"`
+}
+
+func (t *Target) subscribed(event mariadbv1alpha1.NotificationEvent) bool {
+	if len(t.Events) == 0 {
+		return true
+	}
+	for _, e := range t.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Target) validate() error {
+	if err := t.Type.Validate(); err != nil {
+		return err
+	}
+	for _, e := range t.Events {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	switch t.Type {
+	case mariadbv1alpha1.NotificationTargetTypeWebhook, mariadbv1alpha1.NotificationTargetTypeSlack:
+		if t.URL == "" {
+			return fmt.Errorf("url must be set in %s targets", t.Type)
+		}
+	case mariadbv1alpha1.NotificationTargetTypePagerDuty:
+		if t.RoutingKey == "" {
+			return errors.New("routingKey must be set in PagerDuty targets")
+		}
+	}
+	return nil
+}
+
+// Config is the global notification configuration, applied to all the MariaDBs.
+type Config struct {
+	Targets []Target `json:"targets,omitempty"`
+}
+
+// ParseConfigFile reads a global notification configuration from a YAML file.
+func ParseConfigFile(path string) (*Config, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading notification config: %v", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(bytes, &config); err != nil {
+		return nil, fmt.Errorf("error parsing notification config: %v", err)
+	}
+	for i, t := range config.Targets {
+		if err := config.Targets[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid target '%s': %v", t.Name, err)
+		}
+	}
+	return &config, nil
+}
+
+// Notification is the payload sent to the targets.
+type Notification struct {
+	Event     mariadbv1alpha1.NotificationEvent `json:"event"`
+	MariaDB   string                            `json:"mariadb"`
+	Namespace string                            `json:"namespace"`
+	Message   string                            `json:"message"`
+	Timestamp time.Time                         `json:"timestamp"`
+}
+
+func (n *Notification) summary() string {
+	return fmt.Sprintf("[%s] MariaDB '%s/%s': %s", n.Event, n.Namespace, n.MariaDB, n.Message)
+}
+
+type Option func(*Notifier)
+
+func WithGlobalTargets(targets []Target) Option {
+	return func(n *Notifier) {
+		n.globalTargets = targets
+	}
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(n *Notifier) {
+		n.httpClient = client
+	}
+}
+
+func WithPagerDutyURL(url string) Option {
+	return func(n *Notifier) {
+		n.pagerDutyURL = url
+	}
+}
+
+func WithWorkers(workers int) Option {
+	return func(n *Notifier) {
+		n.workers = workers
+	}
+}
+
+// Notifier sends notifications to the global targets and to the targets defined in each MariaDB.
+type Notifier struct {
+	refResolver   *refresolver.RefResolver
+	globalTargets []Target
+	httpClient    *http.Client
+	pagerDutyURL  string
+	workers       int
+
+	queue     chan func()
+	startOnce sync.Once
+}
+
+func NewNotifier(refResolver *refresolver.RefResolver, opts ...Option) *Notifier {
+	notifier := &Notifier{
+		refResolver: refResolver,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		pagerDutyURL: pagerDutyEventsURL,
+		workers:      defaultWorkers,
+		queue:        make(chan func(), defaultQueueSize),
+	}
+	for _, setOpt := range opts {
+		setOpt(notifier)
+	}
+	return notifier
+}
+
+// enqueue runs the task in the worker pool, which is started on first use. It never blocks: false is returned when
+// the queue is full and the task has been dropped.
+func (n *Notifier) enqueue(task func()) bool {
+	n.startOnce.Do(func() {
+		for i := 0; i < n.workers; i++ {
+			go func() {
+				for task := range n.queue {
+					task()
+				}
+			}()
+		}
+	})
+	select {
+	case n.queue <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// Notify sends a notification to all the targets subscribed to the event.
+func (n *Notifier) Notify(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, event mariadbv1alpha1.NotificationEvent,
+	message string) error {
+	targets, err := n.targets(ctx, mariadb)
+	if err != nil {
+		return err
+	}
+	notification := &Notification{
+		Event:     event,
+		MariaDB:   mariadb.Name,
+		Namespace: mariadb.Namespace,
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+	}
+
+	var notifyErr *multierror.Error
+	for _, t := range targets {
+		if !t.subscribed(event) {
+			continue
+		}
+		if err := n.send(ctx, &t, notification); err != nil {
+			notifyErr = multierror.Append(notifyErr, fmt.Errorf("error notifying target '%s': %v", t.Name, err))
+		}
+	}
+	return notifyErr.ErrorOrNil()
+}
+
+func (n *Notifier) targets(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB) ([]Target, error) {
+	targets := make([]Target, len(n.globalTargets))
+	copy(targets, n.globalTargets)
+	if mariadb.Spec.Notifications == nil {
+		return targets, nil
+	}
+
+	for _, t := range mariadb.Spec.Notifications.Targets {
+		target := Target{
+			Name:   t.Name,
+			Type:   t.Type,
+			Events: t.Events,
+		}
+		if t.URLSecretKeyRef != nil {
+			url, err := n.refResolver.SecretKeyRef(ctx, *t.URLSecretKeyRef, mariadb.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error getting URL of target '%s': %v", t.Name, err)
+			}
+			target.URL = url
+		}
+		if t.RoutingKeySecretKeyRef != nil {
+			routingKey, err := n.refResolver.SecretKeyRef(ctx, *t.RoutingKeySecretKeyRef, mariadb.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error getting routing key of target '%s': %v", t.Name, err)
+			}
+			target.RoutingKey = routingKey
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func (n *Notifier) send(ctx context.Context, target *Target, notification *Notification) error {
+	switch target.Type {
+	case mariadbv1alpha1.NotificationTargetTypeWebhook:
+		return n.post(ctx, target.URL, notification)
+	case mariadbv1alpha1.NotificationTargetTypeSlack:
+		return n.post(ctx, target.URL, map[string]string{
+			"text": notification.summary(),
+		})
+	case mariadbv1alpha1.NotificationTargetTypePagerDuty:
+		return n.post(ctx, n.pagerDutyURL, pagerDutyEvent(target.RoutingKey, notification))
+	default:
+		return fmt.Errorf("unsupported target type '%s'", target.Type)
+	}
+}
+
+func pagerDutyEvent(routingKey string, notification *Notification) map[string]interface{} {
+	severity := pagerDutySeverity
+	if notification.Event == mariadbv1alpha1.NotificationEventUpgradeCompleted {
+		severity = pagerDutyInfoSeverity
+	}
+	return map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"payload": map[string]interface{}{
+			"summary":        notification.summary(),
+			"source":         pagerDutySource,
+			"severity":       severity,
+			"timestamp":      notification.Timestamp.Format(time.RFC3339),
+			"component":      fmt.Sprintf("%s/%s", notification.Namespace, notification.MariaDB),
+			"class":          string(notification.Event),
+			"custom_details": notification,
+		},
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, url string, body interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error encoding body: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, string(resBody))
+	}
+	return nil
+}
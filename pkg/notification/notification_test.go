@@ -0,0 +1,297 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type request struct {
+	path string
+	body map[string]interface{}
+}
+
+func newServer(t *testing.T) (*httptest.Server, func() []request) {
+	var (
+		mux      sync.Mutex
+		requests []request
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding body: %v", err)
+		}
+		mux.Lock()
+		requests = append(requests, request{path: r.URL.Path, body: body})
+		mux.Unlock()
+	}))
+	return server, func() []request {
+		mux.Lock()
+		defer mux.Unlock()
+		return requests
+	}
+}
+
+func TestNotify(t *testing.T) {
+	server, requests := newServer(t)
+	defer server.Close()
+
+	notifier := NewNotifier(
+		nil,
+		WithGlobalTargets([]Target{
+			{
+				Name: "webhook",
+				Type: mariadbv1alpha1.NotificationTargetTypeWebhook,
+				URL:  server.URL + "/webhook",
+			},
+			{
+				Name: "slack",
+				Type: mariadbv1alpha1.NotificationTargetTypeSlack,
+				URL:  server.URL + "/slack",
+				Events: []mariadbv1alpha1.NotificationEvent{
+					mariadbv1alpha1.NotificationEventFailover,
+				},
+			},
+			{
+				Name:       "pagerduty",
+				Type:       mariadbv1alpha1.NotificationTargetTypePagerDuty,
+				RoutingKey: "routing-key",
+				Events: []mariadbv1alpha1.NotificationEvent{
+					mariadbv1alpha1.NotificationEventBackupFailed,
+				},
+			},
+		}),
+		WithPagerDutyURL(server.URL+"/pagerduty"),
+	)
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		event     mariadbv1alpha1.NotificationEvent
+		wantPaths []string
+	}{
+		{
+			name:      "failover",
+			event:     mariadbv1alpha1.NotificationEventFailover,
+			wantPaths: []string{"/webhook", "/slack"},
+		},
+		{
+			name:      "backup failed",
+			event:     mariadbv1alpha1.NotificationEventBackupFailed,
+			wantPaths: []string{"/webhook", "/pagerduty"},
+		},
+		{
+			name:      "upgrade completed",
+			event:     mariadbv1alpha1.NotificationEventUpgradeCompleted,
+			wantPaths: []string{"/webhook"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := len(requests())
+			if err := notifier.Notify(context.Background(), mariadb, tt.event, "test"); err != nil {
+				t.Fatalf("unexpected error notifying: %v", err)
+			}
+			reqs := requests()[sent:]
+			if len(reqs) != len(tt.wantPaths) {
+				t.Fatalf("expected %d requests, got %d", len(tt.wantPaths), len(reqs))
+			}
+			for i, path := range tt.wantPaths {
+				if reqs[i].path != path {
+					t.Errorf("expected request %d to be sent to '%s', got '%s'", i, path, reqs[i].path)
+				}
+			}
+		})
+	}
+
+	reqs := requests()
+	if reqs[0].body["event"] != string(mariadbv1alpha1.NotificationEventFailover) || reqs[0].body["mariadb"] != "mariadb" {
+		t.Errorf("unexpected webhook body: %v", reqs[0].body)
+	}
+	if reqs[1].body["text"] != "[Failover] MariaDB 'default/mariadb': test" {
+		t.Errorf("unexpected Slack body: %v", reqs[1].body)
+	}
+	if reqs[3].body["routing_key"] != "routing-key" || reqs[3].body["event_action"] != "trigger" {
+		t.Errorf("unexpected PagerDuty body: %v", reqs[3].body)
+	}
+}
+
+func TestNotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(
+		nil,
+		WithGlobalTargets([]Target{
+			{
+				Name: "webhook",
+				Type: mariadbv1alpha1.NotificationTargetTypeWebhook,
+				URL:  server.URL,
+			},
+		}),
+	)
+	mariadb := &mariadbv1alpha1.MariaDB{}
+	if err := notifier.Notify(context.Background(), mariadb, mariadbv1alpha1.NotificationEventFailover, "test"); err == nil {
+		t.Error("expected error notifying")
+	}
+}
+
+func TestParseConfigFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		wantTargets int
+		wantErr     bool
+	}{
+		{
+			name: "valid",
+			config: `
+targets:
+  - name: slack
+    type: Slack
+    url: https://hooks.slack.com/services/foo
+    events:
+      - Failover
+      - BackupFailed
+  - name: pagerduty
+    type: PagerDuty
+    routingKey: foo
+`,
+			wantTargets: 2,
+			wantErr:     false,
+		},
+		{
+			name: "missing url",
+			config: `
+targets:
+  - name: webhook
+    type: Webhook
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid event",
+			config: `
+targets:
+  - name: webhook
+    type: Webhook
+    url: http://example.com
+    events:
+      - foo
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			config: `
+targets:
+  - name: webhook
+    type: Webhook
+    url: http://example.com
+    foo: bar
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "notifications.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0600); err != nil {
+				t.Fatalf("unexpected error writing config: %v", err)
+			}
+			config, err := ParseConfigFile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error parsing config")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error parsing config: %v", err)
+			}
+			if len(config.Targets) != tt.wantTargets {
+				t.Errorf("expected %d targets, got %d", tt.wantTargets, len(config.Targets))
+			}
+		})
+	}
+}
+
+func TestRecorderNotify(t *testing.T) {
+	server, requests := newServer(t)
+	defer server.Close()
+
+	notifier := NewNotifier(
+		nil,
+		WithGlobalTargets([]Target{
+			{
+				Name: "webhook",
+				Type: mariadbv1alpha1.NotificationTargetTypeWebhook,
+				URL:  server.URL + "/webhook",
+			},
+		}),
+		WithWorkers(1),
+	)
+	recorder := NewRecorder(record.NewFakeRecorder(10), notifier)
+	mariadb := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mariadb",
+			Namespace: "default",
+		},
+	}
+
+	recorder.Eventf(mariadb, "Normal", mariadbv1alpha1.ReasonPrimarySwitching, "Switching primary to '%s'", "mariadb-1")
+	recorder.Event(mariadb, "Normal", "Unknown", "not notified")
+	// mutating the object after recording the Event must not affect the notification.
+	mariadb.Name = "mutated"
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(requests()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for notification")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	reqs := requests()
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(reqs))
+	}
+	if reqs[0].body["mariadb"] != "mariadb" || reqs[0].body["message"] != "Switching primary to 'mariadb-1'" {
+		t.Errorf("unexpected webhook body: %v", reqs[0].body)
+	}
+}
+
+func TestEnqueueFull(t *testing.T) {
+	notifier := NewNotifier(nil, WithWorkers(1))
+	block := make(chan struct{})
+	defer close(block)
+
+	var dropped bool
+	for i := 0; i < defaultQueueSize+2; i++ {
+		if !notifier.enqueue(func() { <-block }) {
+			dropped = true
+			break
+		}
+	}
+	if !dropped {
+		t.Error("expected tasks to be dropped when the queue is full")
+	}
+}
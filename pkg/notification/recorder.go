@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var reasonEvents = map[string]mariadbv1alpha1.NotificationEvent{
+	mariadbv1alpha1.ReasonPrimarySwitching:        mariadbv1alpha1.NotificationEventFailover,
+	mariadbv1alpha1.ReasonGaleraClusterNotHealthy: mariadbv1alpha1.NotificationEventRecoveryStarted,
+	mariadbv1alpha1.ReasonBackupFailed:            mariadbv1alpha1.NotificationEventBackupFailed,
+	mariadbv1alpha1.ReasonMariaDBUpgraded:         mariadbv1alpha1.NotificationEventUpgradeCompleted,
+	mariadbv1alpha1.ReasonUserPasswordExpiring:    mariadbv1alpha1.NotificationEventPasswordExpiring,
+	mariadbv1alpha1.ReasonUserPasswordExpired:     mariadbv1alpha1.NotificationEventPasswordExpiring,
+}
+
+// Recorder is a record.EventRecorder that, besides recording the Kubernetes Events,
+// notifies the Events that correspond to a NotificationEvent.
+type Recorder struct {
+	record.EventRecorder
+	notifier *Notifier
+}
+
+func NewRecorder(recorder record.EventRecorder, notifier *Notifier) *Recorder {
+	return &Recorder{
+		EventRecorder: recorder,
+		notifier:      notifier,
+	}
+}
+
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, message)
+	r.notify(object, reason, message)
+}
+
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	r.notify(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason,
+	messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	r.notify(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) notify(object runtime.Object, reason, message string) {
+	event, ok := reasonEvents[reason]
+	if !ok {
+		return
+	}
+	logger := ctrl.Log.WithName("notification").WithValues("event", event)
+	// The object is copied, as the reconciler keeps mutating it while the notification is being sent.
+	object = object.DeepCopyObject()
+
+	// Notifications are sent asynchronously by a bounded pool of workers to avoid blocking the reconciliation on slow targets.
+	queued := r.notifier.enqueue(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+
+		mariadb, err := r.mariadb(ctx, object)
+		if err != nil {
+			logger.Error(err, "Error getting MariaDB")
+			return
+		}
+		if mariadb == nil {
+			return
+		}
+		if err := r.notifier.Notify(ctx, mariadb, event, message); err != nil {
+			logger.Error(err, "Error sending notification", "mariadb", mariadb.Name, "namespace", mariadb.Namespace)
+		}
+	})
+	if !queued {
+		logger.Info("Dropping notification, queue is full", "reason", reason)
+	}
+}
+
+func (r *Recorder) mariadb(ctx context.Context, object runtime.Object) (*mariadbv1alpha1.MariaDB, error) {
+	switch o := object.(type) {
+	case *mariadbv1alpha1.MariaDB:
+		return o, nil
+	case *mariadbv1alpha1.Backup:
+		return r.notifier.refResolver.MariaDB(ctx, &o.Spec.MariaDBRef, o.Namespace)
+	case *mariadbv1alpha1.User:
+		return r.notifier.refResolver.MariaDB(ctx, &o.Spec.MariaDBRef, o.Namespace)
+	default:
+		return nil, nil
+	}
+}
@@ -87,6 +87,7 @@ type X509Opts struct {
 	Organization string
 	NotBefore    time.Time
 	NotAfter     time.Time
+	ExtKeyUsage  []x509.ExtKeyUsage
 }
 
 type X509Opt func(*X509Opts)
@@ -109,6 +110,12 @@ func WithOrganization(org string) X509Opt {
 	}
 }
 
+func WithExtKeyUsage(extKeyUsage ...x509.ExtKeyUsage) X509Opt {
+	return func(x *X509Opts) {
+		x.ExtKeyUsage = extKeyUsage
+	}
+}
+
 func WithNotBefore(notBefore time.Time) X509Opt {
 	return func(x *X509Opts) {
 		x.NotBefore = notBefore
@@ -151,8 +158,9 @@ func CreateCA(x509Opts ...X509Opt) (*KeyPair, error) {
 
 func CreateCert(caKeyPair *KeyPair, x509Opts ...X509Opt) (*KeyPair, error) {
 	opts := X509Opts{
-		NotBefore: time.Now().Add(-1 * time.Hour),
-		NotAfter:  time.Now().Add(defaultCertValidityDuration),
+		NotBefore:   time.Now().Add(-1 * time.Hour),
+		NotAfter:    time.Now().Add(defaultCertValidityDuration),
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 	}
 	for _, setOpt := range x509Opts {
 		setOpt(&opts)
@@ -170,7 +178,7 @@ func CreateCert(caKeyPair *KeyPair, x509Opts ...X509Opt) (*KeyPair, error) {
 		NotBefore:             opts.NotBefore,
 		NotAfter:              opts.NotAfter,
 		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtKeyUsage:           opts.ExtKeyUsage,
 		BasicConstraintsValid: true,
 	}
 	return createKeyPair(tpl, caKeyPair)
@@ -1,6 +1,8 @@
 package pki
 
 import (
+	"crypto/x509"
+	"reflect"
 	"testing"
 	"time"
 
@@ -279,6 +281,39 @@ func TestCert(t *testing.T) {
 	}
 }
 
+func TestCertExtKeyUsage(t *testing.T) {
+	caKeyPair, err := CreateCA()
+	if err != nil {
+		t.Fatalf("CA cert creation should succeed. Got error: %v", err)
+	}
+	commonName := "mariadb.default.svc"
+
+	keyPair, err := CreateCert(caKeyPair, WithCommonName(commonName), WithDNSNames([]string{commonName}))
+	if err != nil {
+		t.Fatalf("Certificate creation should succeed. Got error: %v", err)
+	}
+	if !reflect.DeepEqual(keyPair.Cert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}) {
+		t.Fatalf("Expected server auth usage by default. Got: %v", keyPair.Cert.ExtKeyUsage)
+	}
+
+	extKeyUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	keyPair, err = CreateCert(caKeyPair, WithCommonName(commonName), WithDNSNames([]string{commonName}),
+		WithExtKeyUsage(extKeyUsage...))
+	if err != nil {
+		t.Fatalf("Certificate creation should succeed. Got error: %v", err)
+	}
+	if !reflect.DeepEqual(keyPair.Cert.ExtKeyUsage, extKeyUsage) {
+		t.Fatalf("Expected server and client auth usages. Got: %v", keyPair.Cert.ExtKeyUsage)
+	}
+	valid, err := ValidCert(caKeyPair.Cert, keyPair, commonName, time.Now())
+	if err != nil {
+		t.Fatalf("Cert validation should succeed. Got error: %v", err)
+	}
+	if !valid {
+		t.Fatal("Expected cert to be valid")
+	}
+}
+
 func TestParseCert(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,47 @@
+package pod
+
+import (
+	"strings"
+)
+
+const errorLogTag = "[ERROR]"
+
+// genericErrors are error log messages that are consequences of a previous error, not the root cause.
+var genericErrors = []string{
+	"Aborting",
+	"Failed to initialize plugins",
+	"Unknown/unsupported storage engine",
+}
+
+// ErrorLogRootCause returns the first error logged by MariaDB that is not a consequence of a previous error.
+// The timestamp and thread id prefix is removed. It returns an empty string if no errors are found.
+func ErrorLogRootCause(logs string) string {
+	var fallback string
+	for _, line := range strings.Split(logs, "\n") {
+		idx := strings.Index(line, errorLogTag)
+		if idx == -1 {
+			continue
+		}
+		msg := strings.TrimSpace(line[idx+len(errorLogTag):])
+		if msg == "" {
+			continue
+		}
+		if isGenericError(msg) {
+			if fallback == "" {
+				fallback = msg
+			}
+			continue
+		}
+		return msg
+	}
+	return fallback
+}
+
+func isGenericError(msg string) bool {
+	for _, e := range genericErrors {
+		if strings.HasPrefix(msg, e) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package pod
+
+import "testing"
+
+func TestErrorLogRootCause(t *testing.T) {
+	tests := []struct {
+		name string
+		logs string
+		want string
+	}{
+		{
+			name: "empty",
+			logs: "",
+			want: "",
+		},
+		{
+			name: "no errors",
+			logs: `2023-10-16 10:00:00 0 [Note] Starting MariaDB 11.0.3-MariaDB-1:11.0.3+maria~ubu2204 source revision 70905bcb9059dcc40db3b73bc46a36c7d40f1e10 as process 1
+2023-10-16 10:00:00 0 [Note] mariadbd: ready for connections.`,
+			want: "",
+		},
+		{
+			name: "unknown variable",
+			logs: `2023-10-16 10:00:00 0 [Note] Starting MariaDB 11.0.3-MariaDB-1:11.0.3+maria~ubu2204 source revision 70905bcb9059dcc40db3b73bc46a36c7d40f1e10 as process 1
+2023-10-16 10:00:00 0 [ERROR] mariadbd: unknown variable 'innodb_foo=1'
+2023-10-16 10:00:00 0 [ERROR] Aborting`,
+			want: "mariadbd: unknown variable 'innodb_foo=1'",
+		},
+		{
+			name: "plugin initialization",
+			logs: `2023-10-16 10:00:00 0 [ERROR] InnoDB: Invalid flags 0x4800 in ./ibdata1
+2023-10-16 10:00:00 0 [ERROR] Plugin 'InnoDB' registration as a STORAGE ENGINE failed.
+2023-10-16 10:00:00 0 [ERROR] Unknown/unsupported storage engine: InnoDB
+2023-10-16 10:00:00 0 [ERROR] Aborting`,
+			want: "InnoDB: Invalid flags 0x4800 in ./ibdata1",
+		},
+		{
+			name: "only generic errors",
+			logs: `2023-10-16 10:00:00 0 [ERROR] Failed to initialize plugins.
+2023-10-16 10:00:00 0 [ERROR] Aborting`,
+			want: "Failed to initialize plugins.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorLogRootCause(tt.logs); got != tt.want {
+				t.Errorf("unexpected root cause, got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package pod
 
 import (
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func PodReadyCondition(pod *corev1.Pod) *corev1.PodCondition {
@@ -19,3 +23,38 @@ func PodReady(pod *corev1.Pod) bool {
 	}
 	return false
 }
+
+// ContainerStartedAt returns the time when the given container started running, or nil if it is not running.
+func ContainerStartedAt(pod *corev1.Pod, containerName string) *metav1.Time {
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == containerName && s.State.Running != nil {
+			return &s.State.Running.StartedAt
+		}
+	}
+	return nil
+}
+
+// WarmUpID identifies the current run of the given container, so the warm-up is performed again after a restart.
+func WarmUpID(pod *corev1.Pod, containerName string) string {
+	startedAt := ContainerStartedAt(pod, containerName)
+	if startedAt == nil {
+		return ""
+	}
+	return startedAt.UTC().Format(time.RFC3339)
+}
+
+// PodProvisioning returns whether the Pod is a replica whose data is being loaded by a provisioning Job.
+func PodProvisioning(pod *corev1.Pod) bool {
+	return pod.Annotations[metadata.ProvisioningAnnotation] == "true"
+}
+
+// PodFenced returns whether the Pod is an old primary that has been fenced and not yet reconfigured as a replica.
+func PodFenced(pod *corev1.Pod) bool {
+	return pod.Annotations[metadata.FencedAnnotation] == "true"
+}
+
+// PodWarmedUp returns whether the current run of the given container has completed the warm-up.
+func PodWarmedUp(pod *corev1.Pod, containerName string) bool {
+	id := WarmUpID(pod, containerName)
+	return id != "" && pod.Annotations[metadata.WarmedUpAnnotation] == id
+}
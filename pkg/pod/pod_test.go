@@ -0,0 +1,168 @@
+package pod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodWarmedUp(t *testing.T) {
+	startedAt := metav1.NewTime(time.Date(2023, 12, 18, 16, 14, 0, 0, time.UTC))
+	runningStatus := []corev1.ContainerStatus{
+		{
+			Name: "mariadb",
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{
+					StartedAt: startedAt,
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		pod          *corev1.Pod
+		wantWarmUpID string
+		wantWarmedUp bool
+	}{
+		{
+			name:         "not running",
+			pod:          &corev1.Pod{},
+			wantWarmUpID: "",
+			wantWarmedUp: false,
+		},
+		{
+			name: "not warmed up",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					ContainerStatuses: runningStatus,
+				},
+			},
+			wantWarmUpID: "2023-12-18T16:14:00Z",
+			wantWarmedUp: false,
+		},
+		{
+			name: "warmed up",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						metadata.WarmedUpAnnotation: "2023-12-18T16:14:00Z",
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: runningStatus,
+				},
+			},
+			wantWarmUpID: "2023-12-18T16:14:00Z",
+			wantWarmedUp: true,
+		},
+		{
+			name: "warmed up before restart",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						metadata.WarmedUpAnnotation: "2023-12-18T15:00:00Z",
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: runningStatus,
+				},
+			},
+			wantWarmUpID: "2023-12-18T16:14:00Z",
+			wantWarmedUp: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if id := WarmUpID(tt.pod, "mariadb"); id != tt.wantWarmUpID {
+				t.Fatalf("unexpected warm-up id, expected: %s got: %s", tt.wantWarmUpID, id)
+			}
+			if warmedUp := PodWarmedUp(tt.pod, "mariadb"); warmedUp != tt.wantWarmedUp {
+				t.Fatalf("unexpected warmed up, expected: %v got: %v", tt.wantWarmedUp, warmedUp)
+			}
+		})
+	}
+}
+
+func TestPodProvisioning(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		wantProvisioning bool
+	}{
+		{
+			name:             "no annotations",
+			wantProvisioning: false,
+		},
+		{
+			name: "provisioning",
+			annotations: map[string]string{
+				metadata.ProvisioningAnnotation: "true",
+			},
+			wantProvisioning: true,
+		},
+		{
+			name: "other annotations",
+			annotations: map[string]string{
+				metadata.WarmedUpAnnotation: "true",
+			},
+			wantProvisioning: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			if provisioning := PodProvisioning(pod); provisioning != tt.wantProvisioning {
+				t.Errorf("unexpected provisioning, expected: %v got: %v", tt.wantProvisioning, provisioning)
+			}
+		})
+	}
+}
+
+func TestPodFenced(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantFenced  bool
+	}{
+		{
+			name:       "no annotations",
+			wantFenced: false,
+		},
+		{
+			name: "fenced",
+			annotations: map[string]string{
+				metadata.FencedAnnotation: "true",
+			},
+			wantFenced: true,
+		},
+		{
+			name: "other annotations",
+			annotations: map[string]string{
+				metadata.ProvisioningAnnotation: "true",
+			},
+			wantFenced: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			if fenced := PodFenced(pod); fenced != tt.wantFenced {
+				t.Errorf("unexpected fenced, expected: %v got: %v", tt.wantFenced, fenced)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package pod
+
+import (
+	"strings"
+)
+
+// StateTransfer is the mechanism used by a Galera node to receive the state when joining the cluster.
+type StateTransfer string
+
+const (
+	// StateTransferIST is an Incremental State Transfer, where only the missing write sets are sent from the gcache of the donor.
+	StateTransferIST StateTransfer = "IST"
+	// StateTransferSST is a Snapshot State Transfer, where a full copy of the data is sent by the donor.
+	StateTransferSST StateTransfer = "SST"
+)
+
+// stateTransferLogs are the log messages written by the joiner once the state has been received.
+var stateTransferLogs = map[string]StateTransfer{
+	"WSREP: IST received": StateTransferIST,
+	"WSREP: SST received": StateTransferSST,
+}
+
+// GaleraStateTransfer returns the state transfer used in the last join logged by MariaDB.
+// It returns an empty string if no state transfers are found.
+func GaleraStateTransfer(logs string) StateTransfer {
+	var stateTransfer StateTransfer
+	for _, line := range strings.Split(logs, "\n") {
+		for msg, st := range stateTransferLogs {
+			if strings.Contains(line, msg) {
+				stateTransfer = st
+			}
+		}
+	}
+	return stateTransfer
+}
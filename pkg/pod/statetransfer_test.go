@@ -0,0 +1,52 @@
+package pod
+
+import "testing"
+
+func TestGaleraStateTransfer(t *testing.T) {
+	tests := []struct {
+		name string
+		logs string
+		want StateTransfer
+	}{
+		{
+			name: "empty",
+			logs: "",
+			want: "",
+		},
+		{
+			name: "no state transfer",
+			logs: `2023-10-16 10:00:00 0 [Note] WSREP: Shifting CLOSED -> OPEN (TO: 0)
+2023-10-16 10:00:00 0 [Note] mariadbd: ready for connections.`,
+			want: "",
+		},
+		{
+			name: "IST",
+			logs: `2023-10-16 10:00:00 0 [Note] WSREP: State transfer required:
+2023-10-16 10:00:01 0 [Note] WSREP: Prepared IST receiver for 16-17, listening at: tcp://10.244.0.12:4568
+2023-10-16 10:00:02 0 [Note] WSREP: IST received: 6ea235ec-3232-11ee-8152-4af03d2c43a9:17
+2023-10-16 10:00:02 0 [Note] WSREP: Shifting JOINER -> JOINED (TO: 17)`,
+			want: StateTransferIST,
+		},
+		{
+			name: "SST",
+			logs: `2023-10-16 10:00:00 0 [Note] WSREP: State transfer required:
+2023-10-16 10:00:30 0 [Note] WSREP: SST received: 6ea235ec-3232-11ee-8152-4af03d2c43a9:17
+2023-10-16 10:00:30 0 [Note] WSREP: Shifting JOINER -> JOINED (TO: 17)`,
+			want: StateTransferSST,
+		},
+		{
+			name: "last state transfer",
+			logs: `2023-10-16 10:00:30 0 [Note] WSREP: SST received: 6ea235ec-3232-11ee-8152-4af03d2c43a9:17
+2023-10-16 11:00:02 0 [Note] WSREP: IST received: 6ea235ec-3232-11ee-8152-4af03d2c43a9:42`,
+			want: StateTransferIST,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GaleraStateTransfer(tt.logs); got != tt.want {
+				t.Errorf("unexpected state transfer: expected: %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForwarder forwards a random local port to a Pod port.
+type PortForwarder struct {
+	restConfig *rest.Config
+	namespace  string
+	pod        string
+	port       int32
+	errOut     io.Writer
+}
+
+func NewPortForwarder(restConfig *rest.Config, namespace, pod string, port int32, errOut io.Writer) *PortForwarder {
+	return &PortForwarder{
+		restConfig: restConfig,
+		namespace:  namespace,
+		pod:        pod,
+		port:       port,
+		errOut:     errOut,
+	}
+}
+
+// Start starts forwarding the port in the background until the returned stop function is called.
+// It returns the local port the Pod port is forwarded to.
+func (p *PortForwarder) Start(ctx context.Context) (uint16, func(), error) {
+	kubeClient, err := kubernetes.NewForConfig(p.restConfig)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting Kubernetes client: %v", err)
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(p.restConfig)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting round tripper: %v", err)
+	}
+	req := kubeClient.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(p.namespace).
+		Name(p.pod).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(
+		dialer,
+		[]string{"127.0.0.1"},
+		[]string{fmt.Sprintf("0:%d", p.port)},
+		stopChan,
+		readyChan,
+		io.Discard,
+		p.errOut,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error creating port forwarder: %v", err)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- forwarder.ForwardPorts()
+	}()
+	stop := func() {
+		close(stopChan)
+	}
+
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		if err == nil {
+			err = errors.New("port forwarding finished unexpectedly")
+		}
+		return 0, nil, fmt.Errorf("error forwarding port: %v", err)
+	case <-ctx.Done():
+		stop()
+		return 0, nil, ctx.Err()
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		stop()
+		return 0, nil, fmt.Errorf("error getting forwarded ports: %v", err)
+	}
+	if len(ports) != 1 {
+		stop()
+		return 0, nil, fmt.Errorf("expected 1 forwarded port, got %d", len(ports))
+	}
+	return ports[0].Local, stop, nil
+}
@@ -114,3 +114,22 @@ func (r *RefResolver) SecretKeyRef(ctx context.Context, selector corev1.SecretKe
 
 	return string(data), nil
 }
+
+func (r *RefResolver) ConfigMapKeyRef(ctx context.Context, selector *corev1.ConfigMapKeySelector,
+	namespace string) (string, error) {
+	nn := types.NamespacedName{
+		Name:      selector.Name,
+		Namespace: namespace,
+	}
+	var configMap v1.ConfigMap
+	if err := r.client.Get(ctx, nn, &configMap); err != nil {
+		return "", fmt.Errorf("error getting ConfigMap: %v", err)
+	}
+
+	data, ok := configMap.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("ConfigMap key \"%s\" not found", selector.Key)
+	}
+
+	return data, nil
+}
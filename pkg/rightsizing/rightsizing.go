@@ -0,0 +1,120 @@
+package rightsizing
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodMetricsGVK is the kind served by the metrics API with the resource usage of the Pods.
+var PodMetricsGVK = schema.GroupVersionKind{
+	Group:   "metrics.k8s.io",
+	Version: "v1beta1",
+	Kind:    "PodMetrics",
+}
+
+var resourceNames = []corev1.ResourceName{
+	corev1.ResourceCPU,
+	corev1.ResourceMemory,
+}
+
+// PodUsage returns the CPU and memory usage of each container of a Pod, indexed by container name, as reported by the metrics API.
+func PodUsage(ctx context.Context, c client.Reader, key types.NamespacedName) (map[string]corev1.ResourceList, error) {
+	podMetrics := &unstructured.Unstructured{}
+	podMetrics.SetGroupVersionKind(PodMetricsGVK)
+	if err := c.Get(ctx, key, podMetrics); err != nil {
+		return nil, err
+	}
+	return containersUsage(podMetrics)
+}
+
+func containersUsage(podMetrics *unstructured.Unstructured) (map[string]corev1.ResourceList, error) {
+	containers, _, err := unstructured.NestedSlice(podMetrics.Object, "containers")
+	if err != nil {
+		return nil, fmt.Errorf("error getting containers: %v", err)
+	}
+
+	usage := make(map[string]corev1.ResourceList, len(containers))
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, err := unstructured.NestedString(container, "name")
+		if err != nil || name == "" {
+			continue
+		}
+		containerUsage, _, err := unstructured.NestedStringMap(container, "usage")
+		if err != nil {
+			return nil, fmt.Errorf("error getting usage of container '%s': %v", name, err)
+		}
+		resources := corev1.ResourceList{}
+		for _, resourceName := range resourceNames {
+			value, ok := containerUsage[string(resourceName)]
+			if !ok {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s usage of container '%s': %v", resourceName, name, err)
+			}
+			resources[resourceName] = quantity
+		}
+		usage[name] = resources
+	}
+	return usage, nil
+}
+
+// IsMetricsUnavailable determines whether an error indicates that the metrics API is not installed or that the metrics
+// of a Pod have not been collected yet.
+func IsMetricsUnavailable(err error) bool {
+	return meta.IsNoMatchError(err) || apierrors.IsNotFound(err)
+}
+
+// Peak returns the highest CPU and memory of the given resource lists.
+func Peak(lists ...corev1.ResourceList) corev1.ResourceList {
+	peak := corev1.ResourceList{}
+	for _, list := range lists {
+		for _, resourceName := range resourceNames {
+			quantity, ok := list[resourceName]
+			if !ok {
+				continue
+			}
+			if current, ok := peak[resourceName]; !ok || quantity.Cmp(current) > 0 {
+				peak[resourceName] = quantity.DeepCopy()
+			}
+		}
+	}
+	return peak
+}
+
+// Recommend returns the resource requests recommended for a peak usage, adding a percentage of headroom.
+// CPU is rounded up to millicores and memory to mebibytes.
+func Recommend(peak corev1.ResourceList, headroomPercent int32) corev1.ResourceList {
+	recommendation := corev1.ResourceList{}
+	if cpu, ok := peak[corev1.ResourceCPU]; ok {
+		milli := withHeadroom(cpu.MilliValue(), headroomPercent)
+		recommendation[corev1.ResourceCPU] = *resource.NewMilliQuantity(max(milli, 1), resource.DecimalSI)
+	}
+	if memory, ok := peak[corev1.ResourceMemory]; ok {
+		mebibytes := ceilDiv(withHeadroom(memory.Value(), headroomPercent), 1024*1024)
+		recommendation[corev1.ResourceMemory] = *resource.NewQuantity(max(mebibytes, 1)*1024*1024, resource.BinarySI)
+	}
+	return recommendation
+}
+
+func withHeadroom(value int64, headroomPercent int32) int64 {
+	return ceilDiv(value*(100+int64(headroomPercent)), 100)
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,151 @@
+package rightsizing
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestContainersUsage(t *testing.T) {
+	podMetrics := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      "mariadb-0",
+				"namespace": "default",
+			},
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name": "mariadb",
+					"usage": map[string]interface{}{
+						"cpu":    "250m",
+						"memory": "512Mi",
+					},
+				},
+				map[string]interface{}{
+					"name": "metrics",
+					"usage": map[string]interface{}{
+						"cpu": "10m",
+					},
+				},
+			},
+		},
+	}
+
+	usage, err := containersUsage(podMetrics)
+	if err != nil {
+		t.Fatalf("unexpected error getting Pod usage: %v", err)
+	}
+	assertResources(t, "mariadb", usage["mariadb"], corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("250m"),
+		corev1.ResourceMemory: resource.MustParse("512Mi"),
+	})
+	assertResources(t, "metrics", usage["metrics"], corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("10m"),
+	})
+}
+
+func TestPeak(t *testing.T) {
+	peak := Peak(
+		corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		nil,
+		corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("300m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	)
+	assertResources(t, "peak", peak, corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("300m"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	})
+}
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		name            string
+		peak            corev1.ResourceList
+		headroomPercent int32
+		want            corev1.ResourceList
+	}{
+		{
+			name:            "empty",
+			peak:            corev1.ResourceList{},
+			headroomPercent: 20,
+			want:            corev1.ResourceList{},
+		},
+		{
+			name: "no headroom",
+			peak: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			headroomPercent: 0,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
+		{
+			name: "headroom",
+			peak: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("500Mi"),
+			},
+			headroomPercent: 20,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("300m"),
+				corev1.ResourceMemory: resource.MustParse("600Mi"),
+			},
+		},
+		{
+			name: "round up",
+			peak: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1001u"),
+				corev1.ResourceMemory: resource.MustParse("1000000"),
+			},
+			headroomPercent: 10,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3m"),
+				corev1.ResourceMemory: resource.MustParse("2Mi"),
+			},
+		},
+		{
+			name: "minimum",
+			peak: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("0"),
+				corev1.ResourceMemory: resource.MustParse("0"),
+			},
+			headroomPercent: 20,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1m"),
+				corev1.ResourceMemory: resource.MustParse("1Mi"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertResources(t, tt.name, Recommend(tt.peak, tt.headroomPercent), tt.want)
+		})
+	}
+}
+
+func assertResources(t *testing.T, name string, got, want corev1.ResourceList) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: expecting %d resources, got %d: %v", name, len(want), len(got), got)
+	}
+	for resourceName, wantQuantity := range want {
+		gotQuantity, ok := got[resourceName]
+		if !ok {
+			t.Errorf("%s: expecting resource '%s'", name, resourceName)
+			continue
+		}
+		if gotQuantity.Cmp(wantQuantity) != 0 {
+			t.Errorf("%s: expecting %s '%s', got '%s'", name, resourceName, wantQuantity.String(), gotQuantity.String())
+		}
+	}
+}
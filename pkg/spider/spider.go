@@ -0,0 +1,35 @@
+package spider
+
+import (
+	"sort"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/sql"
+)
+
+// Soname is the shared library of the Spider storage engine.
+const Soname = "ha_spider"
+
+// PluginName is the name of the Spider storage engine plugin.
+const PluginName = "SPIDER"
+
+// Diff compares the servers defined in the Spider MariaDB with the desired ones. It returns the servers that need to be created
+// or replaced because they are missing or outdated, and the previously managed servers that are no longer desired and need to be dropped.
+// Servers not managed by the operator are never dropped.
+func Diff(current, desired map[string]sql.ForeignServerOpts, managed []string) (upsert []string, drop []string) {
+	for name, opts := range desired {
+		if currentOpts, ok := current[name]; !ok || currentOpts != opts {
+			upsert = append(upsert, name)
+		}
+	}
+	for _, name := range managed {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		if _, ok := current[name]; ok {
+			drop = append(drop, name)
+		}
+	}
+	sort.Strings(upsert)
+	sort.Strings(drop)
+	return upsert, drop
+}
@@ -0,0 +1,118 @@
+package spider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mariadb-operator/mariadb-operator/pkg/sql"
+)
+
+func TestDiff(t *testing.T) {
+	shard1 := sql.ForeignServerOpts{
+		Host:     "shard-1.default.svc.cluster.local",
+		Port:     3306,
+		Username: "spider",
+		Password: "secret",
+	}
+	shard2 := sql.ForeignServerOpts{
+		Host:     "shard-2.default.svc.cluster.local",
+		Port:     3306,
+		Username: "spider",
+		Password: "secret",
+	}
+	tests := []struct {
+		name       string
+		current    map[string]sql.ForeignServerOpts
+		desired    map[string]sql.ForeignServerOpts
+		managed    []string
+		wantUpsert []string
+		wantDrop   []string
+	}{
+		{
+			name:    "no servers",
+			current: map[string]sql.ForeignServerOpts{},
+			desired: map[string]sql.ForeignServerOpts{},
+		},
+		{
+			name:    "new servers",
+			current: map[string]sql.ForeignServerOpts{},
+			desired: map[string]sql.ForeignServerOpts{
+				"shard-2": shard2,
+				"shard-1": shard1,
+			},
+			wantUpsert: []string{"shard-1", "shard-2"},
+		},
+		{
+			name: "in sync",
+			current: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+				"shard-2": shard2,
+			},
+			desired: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+				"shard-2": shard2,
+			},
+			managed: []string{"shard-1", "shard-2"},
+		},
+		{
+			name: "outdated server",
+			current: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+			},
+			desired: map[string]sql.ForeignServerOpts{
+				"shard-1": func() sql.ForeignServerOpts {
+					opts := shard1
+					opts.Port = 3307
+					return opts
+				}(),
+			},
+			managed:    []string{"shard-1"},
+			wantUpsert: []string{"shard-1"},
+		},
+		{
+			name: "removed server",
+			current: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+				"shard-2": shard2,
+			},
+			desired: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+			},
+			managed:  []string{"shard-1", "shard-2"},
+			wantDrop: []string{"shard-2"},
+		},
+		{
+			name: "removed server already dropped",
+			current: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+			},
+			desired: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+			},
+			managed: []string{"shard-1", "shard-2"},
+		},
+		{
+			name: "unmanaged server",
+			current: map[string]sql.ForeignServerOpts{
+				"shard-1":  shard1,
+				"external": shard2,
+			},
+			desired: map[string]sql.ForeignServerOpts{
+				"shard-1": shard1,
+			},
+			managed: []string{"shard-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upsert, drop := Diff(tt.current, tt.desired, tt.managed)
+			if !reflect.DeepEqual(upsert, tt.wantUpsert) {
+				t.Errorf("unexpected servers to upsert, expected: %v got: %v", tt.wantUpsert, upsert)
+			}
+			if !reflect.DeepEqual(drop, tt.wantDrop) {
+				t.Errorf("unexpected servers to drop, expected: %v got: %v", tt.wantDrop, drop)
+			}
+		})
+	}
+}
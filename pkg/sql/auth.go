@@ -0,0 +1,115 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+)
+
+// authPluginSonames are the libraries of the authentication plugins that are not built into the server.
+var authPluginSonames = map[string]string{
+	"ed25519":     "auth_ed25519",
+	"unix_socket": "auth_socket",
+}
+
+// identifiedViaClause returns the IDENTIFIED VIA clause of an authentication plugin, hashing the password, if any, via the plugin.
+func identifiedViaClause(plugin, password string) string {
+	if password == "" {
+		return fmt.Sprintf("IDENTIFIED VIA %s", plugin)
+	}
+	return fmt.Sprintf("IDENTIFIED VIA %s USING PASSWORD('%s')", plugin, password)
+}
+
+// identificationClause returns the clause that identifies an account by a password or by its hash, either via an authentication
+// plugin or via the default plugin of the server. The hash takes precedence over the password. It is empty when none of them are set.
+func identificationClause(plugin, password, passwordHash string) string {
+	switch {
+	case plugin != "" && passwordHash != "":
+		return fmt.Sprintf("IDENTIFIED VIA %s USING '%s'", plugin, passwordHash)
+	case plugin != "":
+		return identifiedViaClause(plugin, password)
+	case passwordHash != "":
+		return fmt.Sprintf("IDENTIFIED BY PASSWORD '%s'", passwordHash)
+	case password != "":
+		return fmt.Sprintf("IDENTIFIED BY '%s'", password)
+	}
+	return ""
+}
+
+// authPluginOrDefault returns the authentication plugin reported by the mysql.user table,
+// where it is empty for the accounts created with the default plugin in older versions.
+func authPluginOrDefault(plugin string) string {
+	if plugin == "" {
+		return "mysql_native_password"
+	}
+	return plugin
+}
+
+// EnsureAuthPlugin installs the library of an authentication plugin unless it is already active.
+func (c *Client) EnsureAuthPlugin(ctx context.Context, plugin string) error {
+	soname, ok := authPluginSonames[plugin]
+	if !ok {
+		return nil
+	}
+	active, err := c.isPluginActive(ctx, plugin)
+	if err != nil {
+		return fmt.Errorf("error checking plugin '%s': %v", plugin, err)
+	}
+	if active {
+		return nil
+	}
+	return c.Exec(ctx, fmt.Sprintf("INSTALL SONAME '%s';", soname))
+}
+
+func (c *Client) isPluginActive(ctx context.Context, plugin string) (bool, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.plugins WHERE plugin_name=? AND plugin_status='ACTIVE';", plugin)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UserAuthPlugin returns the authentication plugin of an account.
+func (c *Client) UserAuthPlugin(ctx context.Context, username, host string) (string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx, "SELECT plugin FROM mysql.user WHERE User=? AND Host=?;", username, host)
+	var plugin string
+	if err := row.Scan(&plugin); err != nil {
+		return "", err
+	}
+	return authPluginOrDefault(plugin), nil
+}
+
+// AlterUserAuthPlugin changes the authentication plugin of an account, hashing the password, if any, via the new plugin.
+// When the password hash is set, it is passed to the plugin as is.
+func (c *Client) AlterUserAuthPlugin(ctx context.Context, accountName, plugin, password, passwordHash string) error {
+	query := fmt.Sprintf("ALTER USER %s %s;", accountName, identificationClause(plugin, password, passwordHash))
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// UserAuthenticationString returns the authentication string of an account, which holds the hash of its password.
+func (c *Client) UserAuthenticationString(ctx context.Context, username, host string) (string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx, "SELECT authentication_string FROM mysql.user WHERE User=? AND Host=?;", username, host)
+	var authString string
+	if err := row.Scan(&authString); err != nil {
+		return "", err
+	}
+	return authString, nil
+}
+
+// AlterUserPasswordHash sets the password hash of an account, either via an authentication plugin or via the default plugin of the server.
+func (c *Client) AlterUserPasswordHash(ctx context.Context, accountName, plugin, passwordHash string) error {
+	query := fmt.Sprintf("ALTER USER %s %s;", accountName, identificationClause(plugin, "", passwordHash))
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
@@ -0,0 +1,98 @@
+package sql
+
+import "testing"
+
+func TestIdentifiedViaClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		plugin   string
+		password string
+		want     string
+	}{
+		{
+			name:     "native password",
+			plugin:   "mysql_native_password",
+			password: "MariaDB11!",
+			want:     "IDENTIFIED VIA mysql_native_password USING PASSWORD('MariaDB11!')",
+		},
+		{
+			name:     "ed25519",
+			plugin:   "ed25519",
+			password: "MariaDB11!",
+			want:     "IDENTIFIED VIA ed25519 USING PASSWORD('MariaDB11!')",
+		},
+		{
+			name:   "unix socket",
+			plugin: "unix_socket",
+			want:   "IDENTIFIED VIA unix_socket",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identifiedViaClause(tt.plugin, tt.password); got != tt.want {
+				t.Fatalf("unexpected clause, expected: %s got: %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIdentificationClause(t *testing.T) {
+	tests := []struct {
+		name         string
+		plugin       string
+		password     string
+		passwordHash string
+		want         string
+	}{
+		{
+			name: "none",
+			want: "",
+		},
+		{
+			name:     "password",
+			password: "MariaDB11!",
+			want:     "IDENTIFIED BY 'MariaDB11!'",
+		},
+		{
+			name:         "password hash",
+			passwordHash: "*57685B4F0FF9D049082E296E2C39354B7A98774E",
+			want:         "IDENTIFIED BY PASSWORD '*57685B4F0FF9D049082E296E2C39354B7A98774E'",
+		},
+		{
+			name:         "password hash takes precedence",
+			password:     "MariaDB11!",
+			passwordHash: "*57685B4F0FF9D049082E296E2C39354B7A98774E",
+			want:         "IDENTIFIED BY PASSWORD '*57685B4F0FF9D049082E296E2C39354B7A98774E'",
+		},
+		{
+			name:     "plugin with password",
+			plugin:   "ed25519",
+			password: "MariaDB11!",
+			want:     "IDENTIFIED VIA ed25519 USING PASSWORD('MariaDB11!')",
+		},
+		{
+			name:         "plugin with password hash",
+			plugin:       "ed25519",
+			passwordHash: "ZIgUREUg5PVgQ6LskhXmO+eZLS0nC8be6HPjYWR4YJY",
+			want:         "IDENTIFIED VIA ed25519 USING 'ZIgUREUg5PVgQ6LskhXmO+eZLS0nC8be6HPjYWR4YJY'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identificationClause(tt.plugin, tt.password, tt.passwordHash); got != tt.want {
+				t.Fatalf("unexpected clause, expected: %s got: %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthPluginOrDefault(t *testing.T) {
+	if got := authPluginOrDefault(""); got != "mysql_native_password" {
+		t.Fatalf("unexpected plugin, expected: mysql_native_password got: %s", got)
+	}
+	if got := authPluginOrDefault("ed25519"); got != "ed25519" {
+		t.Fatalf("unexpected plugin, expected: ed25519 got: %s", got)
+	}
+}
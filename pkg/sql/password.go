@@ -0,0 +1,56 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// alterAccountsPasswordQuery returns a single ALTER USER statement that sets the same password to several accounts.
+// When plugin is not empty, the password is hashed via the given authentication plugin.
+func alterAccountsPasswordQuery(accountNames []string, plugin, password string) string {
+	specs := make([]string, len(accountNames))
+	for i, accountName := range accountNames {
+		specs[i] = fmt.Sprintf("%s %s", accountName, identificationClause(plugin, password, ""))
+	}
+	return fmt.Sprintf("ALTER USER %s;", strings.Join(specs, ", "))
+}
+
+// AlterAccountsPassword sets the password of several accounts, in the 'user'@'host' format. They are altered in a single
+// statement, so either all of them or none are changed.
+func (c *Client) AlterAccountsPassword(ctx context.Context, accountNames []string, plugin, password string) error {
+	if len(accountNames) == 0 {
+		return nil
+	}
+	return c.ExecFlushingPrivileges(ctx, alterAccountsPasswordQuery(accountNames, plugin, password))
+}
+
+// UserHosts returns the hosts of the accounts of a user.
+func (c *Client) UserHosts(ctx context.Context, username string) ([]string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SELECT Host FROM mysql.user WHERE User=? ORDER BY Host;", username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hosts []string
+	for rows.Next() {
+		var host string
+		if err := rows.Scan(&host); err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, rows.Err()
+}
+
+// SetPassword sets the password of an account, in the 'user'@'host' format. Unlike ALTER USER ... IDENTIFIED BY, it keeps
+// the authentication plugins of the account, such as the unix_socket authentication of 'root'@'localhost'.
+func (c *Client) SetPassword(ctx context.Context, accountName, password string) error {
+	query := fmt.Sprintf("SET PASSWORD FOR %s = PASSWORD('%s');", accountName, password)
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
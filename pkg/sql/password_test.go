@@ -0,0 +1,42 @@
+package sql
+
+import "testing"
+
+func TestAlterAccountsPasswordQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		accountNames []string
+		plugin       string
+		password     string
+		want         string
+	}{
+		{
+			name:         "single account",
+			accountNames: []string{"'app'@'%'"},
+			password:     "MariaDB11!",
+			want:         "ALTER USER 'app'@'%' IDENTIFIED BY 'MariaDB11!';",
+		},
+		{
+			name:         "multiple accounts",
+			accountNames: []string{"'root'@'%'", "'root'@'localhost'"},
+			password:     "MariaDB11!",
+			want:         "ALTER USER 'root'@'%' IDENTIFIED BY 'MariaDB11!', 'root'@'localhost' IDENTIFIED BY 'MariaDB11!';",
+		},
+		{
+			name:         "plugin",
+			accountNames: []string{"'app'@'%'", "'app'@'10.0.%'"},
+			plugin:       "ed25519",
+			password:     "MariaDB11!",
+			want: "ALTER USER 'app'@'%' IDENTIFIED VIA ed25519 USING PASSWORD('MariaDB11!'), " +
+				"'app'@'10.0.%' IDENTIFIED VIA ed25519 USING PASSWORD('MariaDB11!');",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alterAccountsPasswordQuery(tt.accountNames, tt.plugin, tt.password); got != tt.want {
+				t.Fatalf("unexpected query, expected: %s got: %s", tt.want, got)
+			}
+		})
+	}
+}
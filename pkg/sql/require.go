@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UserRequire is the TLS requirement of an account: NONE, SSL or X509, optionally restricting the subject and the issuer
+// of the client certificate.
+type UserRequire struct {
+	Type    string
+	Subject string
+	Issuer  string
+}
+
+// Clause returns the REQUIRE clause of the requirement. Restricting the subject or the issuer implies X509.
+func (r UserRequire) Clause() string {
+	var conditions []string
+	if r.Subject != "" {
+		conditions = append(conditions, fmt.Sprintf("SUBJECT '%s'", escapeQuotes(r.Subject)))
+	}
+	if r.Issuer != "" {
+		conditions = append(conditions, fmt.Sprintf("ISSUER '%s'", escapeQuotes(r.Issuer)))
+	}
+	if len(conditions) > 0 {
+		return "REQUIRE " + strings.Join(conditions, " AND ")
+	}
+	if r.Type == "" {
+		return "REQUIRE NONE"
+	}
+	return "REQUIRE " + r.Type
+}
+
+// userRequireFromSSLType translates the columns of the mysql.user table into a requirement.
+func userRequireFromSSLType(sslType, subject, issuer string) UserRequire {
+	switch strings.ToUpper(sslType) {
+	case "ANY":
+		return UserRequire{Type: "SSL"}
+	case "X509":
+		return UserRequire{Type: "X509"}
+	case "SPECIFIED":
+		return UserRequire{Type: "X509", Subject: subject, Issuer: issuer}
+	default:
+		return UserRequire{Type: "NONE"}
+	}
+}
+
+// UserRequire returns the TLS requirement of an account.
+func (c *Client) UserRequire(ctx context.Context, username, host string) (*UserRequire, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(
+		ctx,
+		"SELECT ssl_type, x509_subject, x509_issuer FROM mysql.user WHERE User=? AND Host=?;",
+		username,
+		host,
+	)
+	var sslType, subject, issuer string
+	if err := row.Scan(&sslType, &subject, &issuer); err != nil {
+		return nil, err
+	}
+	require := userRequireFromSSLType(sslType, subject, issuer)
+	return &require, nil
+}
+
+// AlterUserRequire sets the TLS requirement of an account.
+func (c *Client) AlterUserRequire(ctx context.Context, accountName string, require UserRequire) error {
+	query := fmt.Sprintf("ALTER USER %s %s;", accountName, require.Clause())
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// escapeQuotes escapes a value to be enclosed in single quotes. Backslashes are escaped as well, as they are escape
+// characters in MariaDB string literals unless the NO_BACKSLASH_ESCAPES SQL mode is enabled.
+func escapeQuotes(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s)
+}
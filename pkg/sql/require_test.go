@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUserRequireClause(t *testing.T) {
+	tests := []struct {
+		name    string
+		require UserRequire
+		want    string
+	}{
+		{
+			name:    "empty",
+			require: UserRequire{},
+			want:    "REQUIRE NONE",
+		},
+		{
+			name:    "none",
+			require: UserRequire{Type: "NONE"},
+			want:    "REQUIRE NONE",
+		},
+		{
+			name:    "ssl",
+			require: UserRequire{Type: "SSL"},
+			want:    "REQUIRE SSL",
+		},
+		{
+			name:    "x509",
+			require: UserRequire{Type: "X509"},
+			want:    "REQUIRE X509",
+		},
+		{
+			name:    "subject",
+			require: UserRequire{Type: "X509", Subject: "/CN=user"},
+			want:    "REQUIRE SUBJECT '/CN=user'",
+		},
+		{
+			name:    "subject and issuer",
+			require: UserRequire{Type: "X509", Subject: "/CN=user", Issuer: "/O=O'Reilly/CN=ca"},
+			want:    "REQUIRE SUBJECT '/CN=user' AND ISSUER '/O=O''Reilly/CN=ca'",
+		},
+		{
+			name:    "backslashes",
+			require: UserRequire{Type: "X509", Subject: `/CN=user\'`, Issuer: `/O=Acme\, Inc/CN=ca`},
+			want:    `REQUIRE SUBJECT '/CN=user\\''' AND ISSUER '/O=Acme\\, Inc/CN=ca'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.require.Clause(); got != tt.want {
+				t.Fatalf("unexpected clause, expected: %s got: %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUserRequireFromSSLType(t *testing.T) {
+	tests := []struct {
+		name    string
+		sslType string
+		subject string
+		issuer  string
+		want    UserRequire
+	}{
+		{
+			name: "none",
+			want: UserRequire{Type: "NONE"},
+		},
+		{
+			name:    "any",
+			sslType: "ANY",
+			want:    UserRequire{Type: "SSL"},
+		},
+		{
+			name:    "x509",
+			sslType: "X509",
+			want:    UserRequire{Type: "X509"},
+		},
+		{
+			name:    "specified",
+			sslType: "SPECIFIED",
+			subject: "/CN=user",
+			issuer:  "/CN=ca",
+			want:    UserRequire{Type: "X509", Subject: "/CN=user", Issuer: "/CN=ca"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userRequireFromSSLType(tt.sslType, tt.subject, tt.issuer)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("unexpected require, expected: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
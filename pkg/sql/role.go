@@ -0,0 +1,81 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateRole creates a role, which is granted to the current user with admin option, so it can be granted to other accounts.
+func (c *Client) CreateRole(ctx context.Context, role string) error {
+	query := fmt.Sprintf("CREATE ROLE IF NOT EXISTS '%s';", role)
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// DropRole drops a role, revoking it from all the accounts it was granted to.
+func (c *Client) DropRole(ctx context.Context, role string) error {
+	query := fmt.Sprintf("DROP ROLE IF EXISTS '%s';", role)
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// GrantRole grants a role to an account, in the 'user'@'host' format.
+func (c *Client) GrantRole(ctx context.Context, role, accountName string) error {
+	query := fmt.Sprintf("GRANT '%s' TO %s;", role, accountName)
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// RevokeRole revokes a role from an account, in the 'user'@'host' format.
+func (c *Client) RevokeRole(ctx context.Context, role, accountName string) error {
+	query := fmt.Sprintf("REVOKE '%s' FROM %s;", role, accountName)
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// AccountRoles returns the roles granted to an account.
+func (c *Client) AccountRoles(ctx context.Context, username, host string) ([]string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SELECT Role FROM mysql.roles_mapping WHERE User=? AND Host=?;", username, host)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// AccountDefaultRole returns the role enabled by default when an account connects, which is empty when there is none.
+func (c *Client) AccountDefaultRole(ctx context.Context, username, host string) (string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx, "SELECT default_role FROM mysql.user WHERE User=? AND Host=?;", username, host)
+	var role string
+	if err := row.Scan(&role); err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+// SetDefaultRole sets the role enabled by default when an account connects. An empty role unsets it.
+func (c *Client) SetDefaultRole(ctx context.Context, accountName, role string) error {
+	return c.ExecFlushingPrivileges(ctx, setDefaultRoleQuery(accountName, role))
+}
+
+func setDefaultRoleQuery(accountName, role string) string {
+	if role == "" {
+		return fmt.Sprintf("SET DEFAULT ROLE NONE FOR %s;", accountName)
+	}
+	return fmt.Sprintf("SET DEFAULT ROLE '%s' FOR %s;", role, accountName)
+}
@@ -0,0 +1,33 @@
+package sql
+
+import "testing"
+
+func TestSetDefaultRoleQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountName string
+		role        string
+		want        string
+	}{
+		{
+			name:        "role",
+			accountName: "'user'@'%'",
+			role:        "readonly",
+			want:        "SET DEFAULT ROLE 'readonly' FOR 'user'@'%';",
+		},
+		{
+			name:        "none",
+			accountName: "'user'@'%'",
+			role:        "",
+			want:        "SET DEFAULT ROLE NONE FOR 'user'@'%';",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setDefaultRoleQuery(tt.accountName, tt.role); got != tt.want {
+				t.Fatalf("unexpected query, expected: %s got: %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -29,6 +32,8 @@ type Opts struct {
 	Database string
 	Params   map[string]string
 	Timeout  *time.Duration
+	// QueryTimeout is the timeout applied to every SQL statement executed by the client. It is disabled when not specified.
+	QueryTimeout *time.Duration
 }
 
 type Opt func(*Opts)
@@ -75,8 +80,18 @@ func WithTimeout(d time.Duration) Opt {
 	}
 }
 
+func WithQueryTimeout(d time.Duration) Opt {
+	return func(o *Opts) {
+		o.QueryTimeout = &d
+	}
+}
+
 type Client struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
+
+	version    *Version
+	versionMux sync.Mutex
 }
 
 func NewClient(clientOpts ...Opt) (*Client, error) {
@@ -84,6 +99,10 @@ func NewClient(clientOpts ...Opt) (*Client, error) {
 	for _, setOpt := range clientOpts {
 		setOpt(&opts)
 	}
+	return NewClientWithOpts(opts)
+}
+
+func NewClientWithOpts(opts Opts) (*Client, error) {
 	dsn, err := BuildDSN(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error building DNS: %v", err)
@@ -92,8 +111,13 @@ func NewClient(clientOpts ...Opt) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	var queryTimeout time.Duration
+	if opts.QueryTimeout != nil {
+		queryTimeout = *opts.QueryTimeout
+	}
 	return &Client{
-		db: db,
+		db:           db,
+		queryTimeout: queryTimeout,
 	}, nil
 }
 
@@ -103,24 +127,46 @@ func NewClientWithMariaDB(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB,
 	if err != nil {
 		return nil, fmt.Errorf("error reading root password secret: %v", err)
 	}
+	return newClientWithCredentials(mariadb, "root", password, clientOpts...)
+}
+
+// NewOperatorClientWithMariaDB connects to MariaDB using the operator account once it has been provisioned.
+// The root account is used when the operator account is not enabled or it is still being provisioned.
+func NewOperatorClientWithMariaDB(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, refResolver *refresolver.RefResolver,
+	clientOpts ...Opt) (*Client, error) {
+	if !mariadb.IsOperatorAccountReady() {
+		return NewClientWithMariaDB(ctx, mariadb, refResolver, clientOpts...)
+	}
+	account := mariadb.Spec.OperatorAccount
+	password, err := refResolver.SecretKeyRef(ctx, account.PasswordSecretKeyRef, mariadb.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error reading operator account password secret: %v", err)
+	}
+	return newClientWithCredentials(mariadb, account.Username, password, clientOpts...)
+}
+
+func newClientWithCredentials(mariadb *mariadbv1alpha1.MariaDB, username, password string, clientOpts ...Opt) (*Client, error) {
 	opts := []Opt{
-		WithUsername("root"),
+		WithUsername(username),
 		WithPassword(password),
-		WitHost(func() string {
-			if mariadb.Replication().Enabled {
-				return statefulset.ServiceFQDNWithService(
-					mariadb.ObjectMeta,
-					mariadb.PrimaryServiceKey().Name,
-				)
-			}
-			return statefulset.ServiceFQDN(mariadb.ObjectMeta)
-		}()),
+		WitHost(PrimaryHost(mariadb)),
 		WithPort(mariadb.Spec.Port),
 	}
 	opts = append(opts, clientOpts...)
 	return NewClient(opts...)
 }
 
+// PrimaryHost returns the host where the writes of a MariaDB are accepted.
+func PrimaryHost(mariadb *mariadbv1alpha1.MariaDB) string {
+	if mariadb.Replication().Enabled {
+		return statefulset.ServiceFQDNWithService(
+			mariadb.ObjectMeta,
+			mariadb.PrimaryServiceKey().Name,
+		)
+	}
+	return statefulset.ServiceFQDNWithService(mariadb.ObjectMeta, mariadb.ServiceKey().Name)
+}
+
 func NewInternalClientWithPodIndex(ctx context.Context, mariadb *mariadbv1alpha1.MariaDB, refResolver *refresolver.RefResolver,
 	podIndex int, clientOpts ...Opt) (*Client, error) {
 	opts := []Opt{
@@ -187,10 +233,22 @@ func (c *Client) Close() error {
 }
 
 func (c *Client) Exec(ctx context.Context, sql string, args ...any) error {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
 	_, err := c.db.ExecContext(ctx, sql, args...)
 	return err
 }
 
+// withQueryTimeout returns a context that is cancelled once the query timeout, plus an extra duration, is exceeded.
+// This prevents hung servers from blocking the caller indefinitely.
+func (c *Client) withQueryTimeout(ctx context.Context, extra time.Duration) (context.Context, context.CancelFunc) {
+	if c.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.queryTimeout+extra)
+}
+
 func (c *Client) ExecFlushingPrivileges(ctx context.Context, sql string, args ...any) error {
 	var errBundle *multierror.Error
 	if err := c.Exec(ctx, sql, args...); err != nil {
@@ -203,14 +261,18 @@ func (c *Client) ExecFlushingPrivileges(ctx context.Context, sql string, args ..
 }
 
 type CreateUserOpts struct {
-	IdentifiedBy       string
+	IdentifiedBy string
+	// IdentifiedByPassword is the hash of the password, it takes precedence over IdentifiedBy.
+	IdentifiedByPassword string
+	// IdentifiedVia is the authentication plugin, the password or its hash is passed to it when set.
+	IdentifiedVia      string
 	MaxUserConnections int32
 }
 
 func (c *Client) CreateUser(ctx context.Context, accountName string, opts CreateUserOpts) error {
 	query := fmt.Sprintf("CREATE USER IF NOT EXISTS %s ", accountName)
-	if opts.IdentifiedBy != "" {
-		query += fmt.Sprintf("IDENTIFIED BY '%s' ", opts.IdentifiedBy)
+	if clause := identificationClause(opts.IdentifiedVia, opts.IdentifiedBy, opts.IdentifiedByPassword); clause != "" {
+		query += clause + " "
 	}
 	if opts.MaxUserConnections != 0 {
 		query += fmt.Sprintf("WITH MAX_USER_CONNECTIONS %d ", opts.MaxUserConnections)
@@ -220,6 +282,32 @@ func (c *Client) CreateUser(ctx context.Context, accountName string, opts Create
 	return c.ExecFlushingPrivileges(ctx, query)
 }
 
+// UserMaxStatementTime returns the maximum execution time in seconds of the statements of an account, as stored in the mysql.global_priv table.
+// 0 means that the account has no limit.
+func (c *Client) UserMaxStatementTime(ctx context.Context, username, host string) (float64, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(
+		ctx,
+		"SELECT JSON_VALUE(Priv, '$.max_statement_time') FROM mysql.global_priv WHERE User=? AND Host=?;",
+		username,
+		host,
+	)
+	var seconds sql.NullFloat64
+	if err := row.Scan(&seconds); err != nil {
+		return 0, err
+	}
+	return seconds.Float64, nil
+}
+
+// AlterUserMaxStatementTime sets the maximum execution time in seconds of the statements of an account. 0 removes the limit.
+func (c *Client) AlterUserMaxStatementTime(ctx context.Context, accountName string, seconds float64) error {
+	query := fmt.Sprintf("ALTER USER %s WITH MAX_STATEMENT_TIME %s;", accountName, strconv.FormatFloat(seconds, 'f', -1, 64))
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
 func (c *Client) DropUser(ctx context.Context, accountName string) error {
 	query := fmt.Sprintf("DROP USER IF EXISTS %s;", accountName)
 
@@ -232,7 +320,31 @@ func (c *Client) AlterUser(ctx context.Context, username, password string) error
 	return c.ExecFlushingPrivileges(ctx, query)
 }
 
+// AlterAccountPassword sets the password of an account, in the 'user'@'host' format.
+func (c *Client) AlterAccountPassword(ctx context.Context, accountName, password string) error {
+	query := fmt.Sprintf("ALTER USER %s IDENTIFIED BY '%s';", accountName, password)
+
+	return c.ExecFlushingPrivileges(ctx, query)
+}
+
+// AccountPasswordMatches returns whether an account exists and it is identified by the given password.
+func (c *Client) AccountPasswordMatches(ctx context.Context, username, host, password string) (bool, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM mysql.user WHERE user=? AND host=? AND authentication_string=PASSWORD(?)", username, host, password)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (c *Client) UserExists(ctx context.Context, username string) (bool, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
 	row := c.db.QueryRowContext(ctx, "SELECT COUNT(user) FROM mysql.user WHERE user=?", username)
 	var count int
 	if err := row.Scan(&count); err != nil {
@@ -241,6 +353,55 @@ func (c *Client) UserExists(ctx context.Context, username string) (bool, error)
 	return count > 0, nil
 }
 
+// AccountExists returns whether an account, in the 'user'@'host' format, exists.
+func (c *Client) AccountExists(ctx context.Context, accountName string) (bool, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM mysql.user WHERE CONCAT("'", user, "'@'", host, "'")=?`, accountName)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// PasswordPolicy is the password expiration policy of an account.
+type PasswordPolicy struct {
+	// LastChanged is nil when the server does not track when the password was changed.
+	LastChanged *time.Time
+	// LifetimeDays is nil when the account uses the global default_password_lifetime.
+	LifetimeDays        *int64
+	DefaultLifetimeDays int64
+}
+
+// PasswordPolicy returns the password expiration policy of an account, as stored in the mysql.global_priv table.
+func (c *Client) PasswordPolicy(ctx context.Context, username, host string) (*PasswordPolicy, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(
+		ctx,
+		`SELECT JSON_VALUE(Priv, '$.password_last_changed'), JSON_VALUE(Priv, '$.password_lifetime'),
+		@@global.default_password_lifetime FROM mysql.global_priv WHERE User=? AND Host=?;`,
+		username,
+		host,
+	)
+	var lastChanged, lifetime sql.NullInt64
+	var policy PasswordPolicy
+	if err := row.Scan(&lastChanged, &lifetime, &policy.DefaultLifetimeDays); err != nil {
+		return nil, err
+	}
+	if lastChanged.Valid {
+		t := time.Unix(lastChanged.Int64, 0)
+		policy.LastChanged = &t
+	}
+	if lifetime.Valid {
+		policy.LifetimeDays = &lifetime.Int64
+	}
+	return &policy, nil
+}
+
 type grantOpts struct {
 	grantOption bool
 }
@@ -265,6 +426,10 @@ func (c *Client) Grant(
 	for _, setOpt := range opts {
 		setOpt(&grantOpts)
 	}
+	privileges, err := c.adaptPrivileges(ctx, privileges, false)
+	if err != nil {
+		return err
+	}
 
 	query := fmt.Sprintf("GRANT %s ON %s.%s TO %s ",
 		strings.Join(privileges, ","),
@@ -292,6 +457,13 @@ func (c *Client) Revoke(
 	for _, setOpt := range opts {
 		setOpt(&grantOpts)
 	}
+	privileges, err := c.adaptPrivileges(ctx, privileges, true)
+	if err != nil {
+		return err
+	}
+	if len(privileges) == 0 && !grantOpts.grantOption {
+		return nil
+	}
 
 	if grantOpts.grantOption {
 		privileges = append(privileges, "GRANT OPTION")
@@ -306,10 +478,23 @@ func (c *Client) Revoke(
 	return c.ExecFlushingPrivileges(ctx, query)
 }
 
+func (c *Client) adaptPrivileges(ctx context.Context, privileges []string, dropUnsupported bool) ([]string, error) {
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return AdaptPrivileges(privileges, *version, dropUnsupported)
+}
+
 func (c *Client) FlushPrivileges(ctx context.Context) error {
 	return c.Exec(ctx, "FLUSH PRIVILEGES;")
 }
 
+// FlushBinaryLogs closes the binary log currently being written and opens a new one.
+func (c *Client) FlushBinaryLogs(ctx context.Context) error {
+	return c.Exec(ctx, "FLUSH BINARY LOGS;")
+}
+
 func escapeWildcard(s string) string {
 	if s == "*" {
 		return s
@@ -323,6 +508,10 @@ type DatabaseOpts struct {
 }
 
 func (c *Client) CreateDatabase(ctx context.Context, database string, opts DatabaseOpts) error {
+	if err := c.requireCollation(ctx, opts); err != nil {
+		return err
+	}
+
 	query := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s` ", database)
 	if opts.CharacterSet != "" {
 		query += fmt.Sprintf("CHARACTER SET = '%s' ", opts.CharacterSet)
@@ -335,11 +524,228 @@ func (c *Client) CreateDatabase(ctx context.Context, database string, opts Datab
 	return c.Exec(ctx, query)
 }
 
+// requireCollation returns an UnsupportedError if the character set or collation are not available in the server,
+// as they vary across versions. For instance, the UCA 14.0.0 collations were introduced in MariaDB 10.10.
+func (c *Client) requireCollation(ctx context.Context, opts DatabaseOpts) error {
+	checks := []struct {
+		feature string
+		value   string
+		query   string
+	}{
+		{
+			feature: "character set",
+			value:   opts.CharacterSet,
+			query:   "SELECT COUNT(*) FROM information_schema.CHARACTER_SETS WHERE CHARACTER_SET_NAME = ?;",
+		},
+		{
+			feature: "collation",
+			value:   opts.Collate,
+			query:   "SELECT COUNT(*) FROM information_schema.COLLATIONS WHERE COLLATION_NAME = ?;",
+		},
+	}
+	for _, check := range checks {
+		if check.value == "" {
+			continue
+		}
+		version, err := c.ServerVersion(ctx)
+		if err != nil {
+			return err
+		}
+
+		queryCtx, cancel := c.withQueryTimeout(ctx, 0)
+		row := c.db.QueryRowContext(queryCtx, check.query, check.value)
+		var count int
+		err = row.Scan(&count)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("error checking %s '%s': %v", check.feature, check.value, err)
+		}
+		if count == 0 {
+			return &UnsupportedError{
+				Feature: fmt.Sprintf("%s '%s'", check.feature, check.value),
+				Version: *version,
+			}
+		}
+	}
+	return nil
+}
+
 func (c *Client) DropDatabase(ctx context.Context, database string) error {
 	return c.Exec(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS `%s`;", database))
 }
 
+// DatabaseSize returns the size in bytes of the data and indexes of a database.
+func (c *Client) DatabaseSize(ctx context.Context, database string) (int64, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(
+		ctx,
+		"SELECT COALESCE(SUM(data_length + index_length), 0) FROM information_schema.tables WHERE table_schema = ?;",
+		database,
+	)
+	var size int64
+	if err := row.Scan(&size); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// DataSize returns the size in bytes of all the tables, including their indexes.
+func (c *Client) DataSize(ctx context.Context) (int64, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(
+		ctx,
+		"SELECT COALESCE(SUM(data_length + index_length), 0) FROM information_schema.tables;",
+	)
+	var size int64
+	if err := row.Scan(&size); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// ActivePlugins returns the names of the plugins that are active in the server.
+func (c *Client) ActivePlugins(ctx context.Context) ([]string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SELECT PLUGIN_NAME FROM information_schema.PLUGINS WHERE PLUGIN_STATUS = 'ACTIVE';")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plugins []string
+	for rows.Next() {
+		var plugin string
+		if err := rows.Scan(&plugin); err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, plugin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// InstallSoname installs all the plugins of a shared library. The plugins are registered in the mysql.plugin table,
+// so they are loaded again after restarting the server.
+func (c *Client) InstallSoname(ctx context.Context, soname string) error {
+	return c.Exec(ctx, fmt.Sprintf("INSTALL SONAME '%s';", soname))
+}
+
+type ForeignServerOpts struct {
+	Host     string
+	Port     int32
+	Database string
+	Username string
+	Password string
+}
+
+// ForeignServers returns the servers defined with the mysql foreign data wrapper, indexed by name.
+func (c *Client) ForeignServers(ctx context.Context) (map[string]ForeignServerOpts, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(
+		ctx,
+		"SELECT Server_name, Host, Port, Db, Username, Password FROM mysql.servers WHERE Wrapper = 'mysql';",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	servers := make(map[string]ForeignServerOpts)
+	for rows.Next() {
+		var name string
+		var opts ForeignServerOpts
+		if err := rows.Scan(&name, &opts.Host, &opts.Port, &opts.Database, &opts.Username, &opts.Password); err != nil {
+			return nil, err
+		}
+		servers[name] = opts
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+func (c *Client) CreateOrReplaceForeignServer(ctx context.Context, name string, opts ForeignServerOpts) error {
+	tpl := createTpl("create-server.sql", `CREATE OR REPLACE SERVER `+"`{{ .Name }}`"+` FOREIGN DATA WRAPPER mysql OPTIONS (
+HOST '{{ .Host }}',
+PORT {{ .Port }},
+DATABASE '{{ .Database }}',
+USER '{{ .Username }}',
+PASSWORD '{{ .Password }}'
+);
+`)
+	buf := new(bytes.Buffer)
+	err := tpl.Execute(buf, struct {
+		Name string
+		ForeignServerOpts
+	}{
+		Name:              name,
+		ForeignServerOpts: opts,
+	})
+	if err != nil {
+		return fmt.Errorf("error generating create server query: %v", err)
+	}
+	return c.Exec(ctx, buf.String())
+}
+
+func (c *Client) DropForeignServer(ctx context.Context, name string) error {
+	return c.Exec(ctx, fmt.Sprintf("DROP SERVER IF EXISTS `%s`;", name))
+}
+
+// DatabaseInsertAccounts returns the accounts that have the INSERT privilege on a database.
+func (c *Client) DatabaseInsertAccounts(ctx context.Context, database string) ([]string, error) {
+	return c.accounts(ctx, "SELECT User, Host FROM mysql.db WHERE Db = ? AND Insert_priv = 'Y';", database)
+}
+
+// GlobalOrTableInsertAccounts returns the accounts that have the INSERT privilege on a database via a global grant
+// or via a table-level grant on any of its tables.
+func (c *Client) GlobalOrTableInsertAccounts(ctx context.Context, database string) ([]string, error) {
+	return c.accounts(
+		ctx,
+		`SELECT User, Host FROM mysql.user WHERE Insert_priv = 'Y'
+		UNION SELECT User, Host FROM mysql.tables_priv WHERE Db = ? AND FIND_IN_SET('Insert', Table_priv) > 0;`,
+		database,
+	)
+}
+
+func (c *Client) accounts(ctx context.Context, query string, args ...any) ([]string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []string
+	for rows.Next() {
+		var user, host string
+		if err := rows.Scan(&user, &host); err != nil {
+			return nil, fmt.Errorf("error scanning account: %v", err)
+		}
+		accounts = append(accounts, fmt.Sprintf("'%s'@'%s'", user, host))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
 func (c *Client) SystemVariable(ctx context.Context, variable string) (string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
 	sql := fmt.Sprintf("SELECT @@global.%s;", variable)
 	row := c.db.QueryRowContext(ctx, sql)
 
@@ -372,6 +778,84 @@ func (c *Client) UnlockTables(ctx context.Context) error {
 	return c.Exec(ctx, "UNLOCK TABLES;")
 }
 
+// Process is a client connection running a query in the server.
+type Process struct {
+	ID      int64
+	User    string
+	Time    time.Duration
+	Command string
+}
+
+// ActiveProcesses returns the client connections running queries, excluding the current connection and the internal threads.
+func (c *Client) ActiveProcesses(ctx context.Context) ([]Process, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(
+		ctx,
+		`SELECT ID, USER, TIME, COMMAND FROM information_schema.PROCESSLIST
+		WHERE ID != CONNECTION_ID() AND COMMAND IN ('Query', 'Execute') AND USER NOT IN ('system user', 'event_scheduler');`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var processes []Process
+	for rows.Next() {
+		var p Process
+		var seconds int64
+		if err := rows.Scan(&p.ID, &p.User, &seconds, &p.Command); err != nil {
+			return nil, fmt.Errorf("error scanning process: %v", err)
+		}
+		p.Time = time.Duration(seconds) * time.Second
+		processes = append(processes, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+// Connections returns the client connections, excluding the current one and the ones opened by the server.
+func (c *Client) Connections(ctx context.Context) ([]Process, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(
+		ctx,
+		`SELECT ID, USER, TIME, COMMAND FROM information_schema.PROCESSLIST
+		WHERE ID != CONNECTION_ID() AND USER NOT IN ('system user', 'event_scheduler');`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var processes []Process
+	for rows.Next() {
+		var p Process
+		var seconds int64
+		if err := rows.Scan(&p.ID, &p.User, &seconds, &p.Command); err != nil {
+			return nil, fmt.Errorf("error scanning process: %v", err)
+		}
+		p.Time = time.Duration(seconds) * time.Second
+		processes = append(processes, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+func (c *Client) KillQuery(ctx context.Context, id int64) error {
+	return c.Exec(ctx, fmt.Sprintf("KILL QUERY %d;", id))
+}
+
+func (c *Client) KillConnection(ctx context.Context, id int64) error {
+	return c.Exec(ctx, fmt.Sprintf("KILL CONNECTION %d;", id))
+}
+
 func (c *Client) EnableReadOnly(ctx context.Context) error {
 	return c.SetSystemVariable(ctx, "read_only", "1")
 }
@@ -398,6 +882,9 @@ func (c *Client) ResetAllSlaves(ctx context.Context) error {
 }
 
 func (c *Client) WaitForReplicaGtid(ctx context.Context, gtid string, timeout time.Duration) error {
+	ctx, cancel := c.withQueryTimeout(ctx, timeout)
+	defer cancel()
+
 	sql := fmt.Sprintf("SELECT MASTER_GTID_WAIT('%s', %d);", gtid, int(timeout.Seconds()))
 	row := c.db.QueryRowContext(ctx, sql)
 
@@ -417,17 +904,43 @@ func (c *Client) WaitForReplicaGtid(ctx context.Context, gtid string, timeout ti
 }
 
 type ChangeMasterOpts struct {
-	Connection string
-	Host       string
-	User       string
-	Password   string
-	Gtid       string
-	Retries    int
+	Connection          string
+	Host                string
+	Port                int32
+	User                string
+	Password            string
+	Gtid                string
+	Retries             int
+	SSL                 bool
+	SSLCA               string
+	SSLCert             string
+	SSLKey              string
+	SSLVerifyServerCert bool
+	IgnoreDomainIds     []uint32
 }
 
 func (c *Client) ChangeMaster(ctx context.Context, opts *ChangeMasterOpts) error {
 	tpl := createTpl("change-master.sql", `CHANGE MASTER '{{ .Connection }}' TO
 MASTER_HOST='{{ .Host }}',
+{{- if .Port }}
+MASTER_PORT={{ .Port }},
+{{- end }}
+{{- if .SSL }}
+MASTER_SSL=1,
+{{- if .SSLCA }}
+MASTER_SSL_CA='{{ .SSLCA }}',
+{{- end }}
+{{- if .SSLCert }}
+MASTER_SSL_CERT='{{ .SSLCert }}',
+{{- end }}
+{{- if .SSLKey }}
+MASTER_SSL_KEY='{{ .SSLKey }}',
+{{- end }}
+MASTER_SSL_VERIFY_SERVER_CERT={{ if .SSLVerifyServerCert }}1{{ else }}0{{ end }},
+{{- end }}
+{{- if .IgnoreDomainIds }}
+IGNORE_DOMAIN_IDS=({{ range $i, $id := .IgnoreDomainIds }}{{ if $i }},{{ end }}{{ $id }}{{ end }}),
+{{- end }}
 MASTER_USER='{{ .User }}',
 MASTER_PASSWORD='{{ .Password }}',
 MASTER_USE_GTID={{ .Gtid }},
@@ -441,6 +954,140 @@ MASTER_CONNECT_RETRY={{ .Retries }};
 	return c.Exec(ctx, buf.String())
 }
 
+func (c *Client) GtidCurrentPos(ctx context.Context) (string, error) {
+	return c.SystemVariable(ctx, "gtid_current_pos")
+}
+
+// SemiSyncStatus is the semi-synchronous replication configuration and state of a primary.
+type SemiSyncStatus struct {
+	Enabled     bool
+	Active      bool
+	Timeout     time.Duration
+	WaitPoint   string
+	WaitNoSlave bool
+	Clients     int
+}
+
+// SemiSyncPrimaryStatus returns the effective semi-synchronous replication configuration and state of a primary.
+func (c *Client) SemiSyncPrimaryStatus(ctx context.Context) (*SemiSyncStatus, error) {
+	queryCtx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(queryCtx, "SELECT @@global.rpl_semi_sync_master_enabled, @@global.rpl_semi_sync_master_timeout, "+
+		"@@global.rpl_semi_sync_master_wait_point, @@global.rpl_semi_sync_master_wait_no_slave;")
+	var status SemiSyncStatus
+	var timeoutMillis int64
+	if err := row.Scan(&status.Enabled, &timeoutMillis, &status.WaitPoint, &status.WaitNoSlave); err != nil {
+		return nil, fmt.Errorf("error scanning semi-synchronous replication variables: %v", err)
+	}
+	status.Timeout = time.Duration(timeoutMillis) * time.Millisecond
+
+	active, err := c.StatusVariable(ctx, "Rpl_semi_sync_master_status")
+	if err != nil {
+		return nil, fmt.Errorf("error getting semi-synchronous replication state: %v", err)
+	}
+	status.Active = active == "ON"
+
+	clients, err := c.StatusVariableInt(ctx, "Rpl_semi_sync_master_clients")
+	if err != nil {
+		return nil, fmt.Errorf("error getting semi-synchronous replication clients: %v", err)
+	}
+	status.Clients = clients
+	return &status, nil
+}
+
+func (c *Client) GtidSlavePos(ctx context.Context) (string, error) {
+	return c.SystemVariable(ctx, "gtid_slave_pos")
+}
+
+// HasReplicationConnections determines whether any replication connection has been configured in the server.
+func (c *Client) HasReplicationConnections(ctx context.Context) (bool, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SHOW ALL SLAVES STATUS;")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	hasConnections := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	return hasConnections, nil
+}
+
+// ReplicationConnectionAddress returns the address of the primary of a replication connection in 'host:port' format.
+// It returns an empty string if the connection has not been configured.
+func (c *Client) ReplicationConnectionAddress(ctx context.Context, connName string) (string, error) {
+	conns, err := c.ReplicationConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, conn := range conns {
+		if conn.Name == connName {
+			return net.JoinHostPort(conn.Host, conn.Port), nil
+		}
+	}
+	return "", nil
+}
+
+// ReplicationConnection is a replication connection configured in the server.
+type ReplicationConnection struct {
+	Name string
+	Host string
+	Port string
+	SSL  bool
+	ReplicaStatus
+}
+
+// ReplicationConnections returns the replication connections configured in the server, including their replication status.
+func (c *Client) ReplicationConnections(ctx context.Context) ([]ReplicationConnection, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SHOW ALL SLAVES STATUS;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error getting columns: %v", err)
+	}
+	var conns []ReplicationConnection
+	for rows.Next() {
+		fields, err := scanFields(rows, columns)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning replication connection: %v", err)
+		}
+		status, err := replicaStatusFromFields(fields)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, ReplicationConnection{
+			Name:          fields["Connection_name"].String,
+			Host:          fields["Master_Host"].String,
+			Port:          fields["Master_Port"].String,
+			SSL:           fields["Master_SSL_Allowed"].String == "Yes",
+			ReplicaStatus: *status,
+		})
+	}
+	return conns, rows.Err()
+}
+
+func (c *Client) StopSlave(ctx context.Context, connName string) error {
+	sql := fmt.Sprintf("STOP SLAVE '%s';", connName)
+	return c.Exec(ctx, sql)
+}
+
+func (c *Client) ResetSlave(ctx context.Context, connName string) error {
+	sql := fmt.Sprintf("RESET SLAVE '%s' ALL;", connName)
+	return c.Exec(ctx, sql)
+}
+
 func (c *Client) ResetSlavePos(ctx context.Context) error {
 	sql := fmt.Sprintf("SET @@global.%s='';", "gtid_slave_pos")
 	return c.Exec(ctx, sql)
@@ -449,6 +1096,9 @@ func (c *Client) ResetSlavePos(ctx context.Context) error {
 const statusVariableSql = "SELECT variable_value FROM information_schema.global_status WHERE variable_name=?;"
 
 func (c *Client) StatusVariable(ctx context.Context, variable string) (string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
 	row := c.db.QueryRowContext(ctx, statusVariableSql, variable)
 	var val string
 	if err := row.Scan(&val); err != nil {
@@ -458,6 +1108,9 @@ func (c *Client) StatusVariable(ctx context.Context, variable string) (string, e
 }
 
 func (c *Client) StatusVariableInt(ctx context.Context, variable string) (int, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
 	row := c.db.QueryRowContext(ctx, statusVariableSql, variable)
 	var val int
 	if err := row.Scan(&val); err != nil {
@@ -481,3 +1134,205 @@ func (c *Client) GaleraLocalState(ctx context.Context) (string, error) {
 func createTpl(name, t string) *template.Template {
 	return template.Must(template.New(name).Parse(t))
 }
+
+// Ping executes a trivial statement to check that the server is able to execute queries.
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	var result int
+	if err := c.db.QueryRowContext(ctx, "SELECT 1;").Scan(&result); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReadOnlyQuery executes a query within a read-only transaction, consuming all the returned rows.
+func (c *Client) ReadOnlyQuery(ctx context.Context, query string) error {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("error starting read-only transaction: %v", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		// rows are consumed to surface the errors raised while streaming them
+	}
+	return rows.Err()
+}
+
+// ReadOnlyQueryValue executes a query within a read-only transaction, returning the first column of the first row.
+// It returns nil if the query does not return any rows or the value is NULL.
+func (c *Client) ReadOnlyQueryValue(ctx context.Context, query string) (*string, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	tx, err := c.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("error starting read-only transaction: %v", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error getting columns: %v", err)
+	}
+	if len(columns) == 0 || !rows.Next() {
+		return nil, rows.Err()
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, fmt.Errorf("error scanning row: %v", err)
+	}
+	if !values[0].Valid {
+		return nil, nil
+	}
+	return &values[0].String, nil
+}
+
+// ReplicationLag returns the maximum replication lag of all the replication connections of the server.
+// It returns nil if the server is not replicating from a primary.
+func (c *Client) ReplicationLag(ctx context.Context) (*time.Duration, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SHOW ALL SLAVES STATUS;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error getting columns: %v", err)
+	}
+	lagIndex := -1
+	for i, c := range columns {
+		if c == "Seconds_Behind_Master" {
+			lagIndex = i
+		}
+	}
+	if lagIndex == -1 {
+		return nil, errors.New("'Seconds_Behind_Master' column not found")
+	}
+
+	var lag *time.Duration
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("error scanning replica status: %v", err)
+		}
+		if !values[lagIndex].Valid {
+			return nil, errors.New("replication is not running")
+		}
+		seconds, err := strconv.Atoi(values[lagIndex].String)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing replication lag: %v", err)
+		}
+		connLag := time.Duration(seconds) * time.Second
+		if lag == nil || connLag > *lag {
+			lag = &connLag
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lag, nil
+}
+
+// ReplicaStatus is the state of the replication threads of a replica.
+type ReplicaStatus struct {
+	IORunning           bool
+	SQLRunning          bool
+	SecondsBehindMaster *int64
+	GtidIOPos           string
+	LastError           string
+}
+
+// ReplicaStatus returns the state of the default replication connection of the server.
+// It returns nil if the server is not replicating from a primary.
+func (c *Client) ReplicaStatus(ctx context.Context) (*ReplicaStatus, error) {
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, "SHOW SLAVE STATUS;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error getting columns: %v", err)
+	}
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	fields, err := scanFields(rows, columns)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning replica status: %v", err)
+	}
+	status, err := replicaStatusFromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	return status, rows.Err()
+}
+
+func scanFields(rows *sql.Rows, columns []string) (map[string]sql.NullString, error) {
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]sql.NullString, len(columns))
+	for i, c := range columns {
+		fields[c] = values[i]
+	}
+	return fields, nil
+}
+
+func replicaStatusFromFields(fields map[string]sql.NullString) (*ReplicaStatus, error) {
+	status := ReplicaStatus{
+		IORunning:  fields["Slave_IO_Running"].String == "Yes",
+		SQLRunning: fields["Slave_SQL_Running"].String == "Yes",
+		GtidIOPos:  fields["Gtid_IO_Pos"].String,
+		LastError:  fields["Last_SQL_Error"].String,
+	}
+	if status.LastError == "" {
+		status.LastError = fields["Last_IO_Error"].String
+	}
+	if lag := fields["Seconds_Behind_Master"]; lag.Valid {
+		seconds, err := strconv.ParseInt(lag.String, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing replication lag: %v", err)
+		}
+		status.SecondsBehindMaster = &seconds
+	}
+	return &status, nil
+}
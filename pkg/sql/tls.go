@@ -0,0 +1,51 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// flushSSLVersion is the version where FLUSH SSL was introduced.
+var flushSSLVersion = Version{Major: 10, Minor: 4, Patch: 0}
+
+// sslTimeLayout is the format of the certificate times reported by the server, as printed by OpenSSL.
+const sslTimeLayout = "Jan _2 15:04:05 2006 MST"
+
+// FlushSSL reloads the certificate, key and CA configured in ssl_cert, ssl_key and ssl_ca without restarting the server.
+// An UnsupportedError is returned if the server version does not support it.
+func (c *Client) FlushSSL(ctx context.Context) error {
+	if err := c.RequireVersion(ctx, "FLUSH SSL", flushSSLVersion); err != nil {
+		return err
+	}
+	return c.Exec(ctx, "FLUSH SSL;")
+}
+
+// ReloadGaleraSSL reloads the certificate, key and CA configured in the socket.ssl provider options.
+func (c *Client) ReloadGaleraSSL(ctx context.Context) error {
+	return c.SetSystemVariable(ctx, "wsrep_provider_options", "'socket.ssl_reload=1'")
+}
+
+// SSLServerNotAfter returns the expiration time of the certificate loaded by the server.
+// A zero time is returned when no certificate is loaded.
+func (c *Client) SSLServerNotAfter(ctx context.Context) (time.Time, error) {
+	notAfter, err := c.StatusVariable(ctx, "Ssl_server_not_after")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ParseSSLTime(notAfter)
+}
+
+// ParseSSLTime parses a certificate time reported by the server, for example 'Apr  4 10:13:06 2025 GMT'.
+func ParseSSLTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(sslTimeLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid certificate time '%s': %v", value, err)
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,51 @@
+package sql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSSLTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  time.Time{},
+		},
+		{
+			name:  "single digit day",
+			value: "Apr  4 10:13:06 2025 GMT",
+			want:  time.Date(2025, time.April, 4, 10, 13, 6, 0, time.UTC),
+		},
+		{
+			name:  "double digit day",
+			value: "Dec 24 23:59:59 2030 GMT",
+			want:  time.Date(2030, time.December, 24, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:    "invalid",
+			value:   "2025-04-04T10:13:06Z",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSSLTime(tt.value)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("unexpected time, expected: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+// Version is the version of a MariaDB server.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses the version reported by the server, ignoring the suffixes added by the distributions,
+// for example '10.11.6-MariaDB-1:10.11.6+maria~ubu2204-log'.
+func ParseVersion(version string) (*Version, error) {
+	match := versionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return nil, fmt.Errorf("invalid version '%s'", version)
+	}
+	var parts [3]int
+	for i := range parts {
+		part, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version '%s': %v", version, err)
+		}
+		parts[i] = part
+	}
+	return &Version{
+		Major: parts[0],
+		Minor: parts[1],
+		Patch: parts[2],
+	}, nil
+}
+
+// AtLeast indicates whether the version is equal or greater than another version.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// UnsupportedError indicates that a feature is not supported by the version of the server.
+type UnsupportedError struct {
+	Feature    string
+	MinVersion *Version
+	Version    Version
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.MinVersion != nil {
+		return fmt.Sprintf("%s requires MariaDB %s or later, server version is %s", e.Feature, e.MinVersion, e.Version)
+	}
+	return fmt.Sprintf("%s is not supported by MariaDB %s", e.Feature, e.Version)
+}
+
+// IsUnsupported indicates whether an error, or any of the errors it wraps, is an UnsupportedError.
+func IsUnsupported(err error) bool {
+	var unsupportedErr *UnsupportedError
+	return errors.As(err, &unsupportedErr)
+}
+
+// ServerVersion returns the version of the server. It is only queried once per Client.
+func (c *Client) ServerVersion(ctx context.Context) (*Version, error) {
+	c.versionMux.Lock()
+	defer c.versionMux.Unlock()
+	if c.version != nil {
+		return c.version, nil
+	}
+
+	ctx, cancel := c.withQueryTimeout(ctx, 0)
+	defer cancel()
+
+	row := c.db.QueryRowContext(ctx, "SELECT VERSION();")
+	var rawVersion string
+	if err := row.Scan(&rawVersion); err != nil {
+		return nil, fmt.Errorf("error getting server version: %v", err)
+	}
+	version, err := ParseVersion(rawVersion)
+	if err != nil {
+		return nil, err
+	}
+	c.version = version
+	return version, nil
+}
+
+// RequireVersion returns an UnsupportedError if the server version is older than the minimum version required by a feature.
+func (c *Client) RequireVersion(ctx context.Context, feature string, minVersion Version) error {
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if !version.AtLeast(minVersion) {
+		return &UnsupportedError{
+			Feature:    feature,
+			MinVersion: &minVersion,
+			Version:    *version,
+		}
+	}
+	return nil
+}
+
+type privilegeVersion struct {
+	minVersion Version
+	// legacy is the equivalent privilege in the versions older than minVersion, if any.
+	legacy string
+}
+
+// privilegeVersions are the privileges that have been introduced by later versions of MariaDB.
+// Privileges split out from SUPER have no legacy equivalent, as granting SUPER would be broader than requested.
+// See: https://mariadb.com/kb/en/grant/#global-privileges.
+var privilegeVersions = map[string]privilegeVersion{
+	"BINLOG ADMIN":              {minVersion: Version{10, 5, 2}},
+	"BINLOG MONITOR":            {minVersion: Version{10, 5, 2}, legacy: "REPLICATION CLIENT"},
+	"BINLOG REPLAY":             {minVersion: Version{10, 5, 2}},
+	"CONNECTION ADMIN":          {minVersion: Version{10, 5, 2}},
+	"FEDERATED ADMIN":           {minVersion: Version{10, 5, 2}},
+	"READ_ONLY ADMIN":           {minVersion: Version{10, 5, 2}},
+	"REPLICATION MASTER ADMIN":  {minVersion: Version{10, 5, 2}},
+	"REPLICATION REPLICA":       {minVersion: Version{10, 5, 1}, legacy: "REPLICATION SLAVE"},
+	"REPLICATION REPLICA ADMIN": {minVersion: Version{10, 5, 2}},
+	"REPLICATION SLAVE ADMIN":   {minVersion: Version{10, 5, 2}},
+	"REPLICA MONITOR":           {minVersion: Version{10, 5, 9}, legacy: "REPLICATION CLIENT"},
+	"SLAVE MONITOR":             {minVersion: Version{10, 5, 9}, legacy: "REPLICATION CLIENT"},
+	"SET USER":                  {minVersion: Version{10, 5, 2}},
+	"SHOW CREATE ROUTINE":       {minVersion: Version{11, 3, 1}},
+}
+
+// normalizePrivilege uppercases the privilege type and collapses its whitespace, leaving the column list as written,
+// since column names are case sensitive when quoted. For example, 'select (Name)' becomes 'SELECT (Name)'.
+func normalizePrivilege(privilege string) string {
+	name, columns, hasColumns := strings.Cut(privilege, "(")
+	name = strings.Join(strings.Fields(strings.ToUpper(name)), " ")
+	if !hasColumns {
+		return name
+	}
+	return fmt.Sprintf("%s (%s", name, columns)
+}
+
+// AdaptPrivileges translates the privileges not supported by the server version into their legacy equivalents.
+// The privileges without equivalent result in an UnsupportedError, unless dropUnsupported is set,
+// in which case they are omitted. This is useful when revoking, as they could have never been granted.
+func AdaptPrivileges(privileges []string, version Version, dropUnsupported bool) ([]string, error) {
+	var adapted []string
+	seen := make(map[string]struct{})
+	for _, p := range privileges {
+		privilege := normalizePrivilege(p)
+		if pv, ok := privilegeVersions[privilege]; ok && !version.AtLeast(pv.minVersion) {
+			if pv.legacy == "" {
+				if dropUnsupported {
+					continue
+				}
+				return nil, &UnsupportedError{
+					Feature:    fmt.Sprintf("'%s' privilege", privilege),
+					MinVersion: &pv.minVersion,
+					Version:    version,
+				}
+			}
+			privilege = pv.legacy
+		}
+		if _, ok := seen[privilege]; ok {
+			continue
+		}
+		seen[privilege] = struct{}{}
+		adapted = append(adapted, privilege)
+	}
+	return adapted, nil
+}
@@ -0,0 +1,147 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		wantVersion *Version
+		wantErr     bool
+	}{
+		{
+			name:        "plain",
+			version:     "10.6.16",
+			wantVersion: &Version{Major: 10, Minor: 6, Patch: 16},
+		},
+		{
+			name:        "suffix",
+			version:     "10.11.6-MariaDB-1:10.11.6+maria~ubu2204-log",
+			wantVersion: &Version{Major: 10, Minor: 11, Patch: 6},
+		},
+		{
+			name:    "invalid",
+			version: "MariaDB",
+			wantErr: true,
+		},
+		{
+			name:    "incomplete",
+			version: "10.11",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ParseVersion(tt.version)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.wantVersion, version) {
+				t.Fatalf("unexpected version, expected: %v got: %v", tt.wantVersion, version)
+			}
+		})
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	version := Version{Major: 10, Minor: 5, Patch: 9}
+	tests := []struct {
+		other Version
+		want  bool
+	}{
+		{other: Version{Major: 10, Minor: 5, Patch: 9}, want: true},
+		{other: Version{Major: 10, Minor: 5, Patch: 2}, want: true},
+		{other: Version{Major: 10, Minor: 4, Patch: 30}, want: true},
+		{other: Version{Major: 10, Minor: 5, Patch: 10}, want: false},
+		{other: Version{Major: 10, Minor: 10, Patch: 1}, want: false},
+		{other: Version{Major: 11, Minor: 0, Patch: 0}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.other.String(), func(t *testing.T) {
+			if got := version.AtLeast(tt.other); got != tt.want {
+				t.Fatalf("unexpected result comparing %s with %s, expected: %v got: %v", version, tt.other, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAdaptPrivileges(t *testing.T) {
+	tests := []struct {
+		name            string
+		privileges      []string
+		version         Version
+		dropUnsupported bool
+		wantPrivileges  []string
+		wantUnsupported bool
+	}{
+		{
+			name:           "supported",
+			privileges:     []string{"SELECT", "BINLOG MONITOR", "SLAVE MONITOR"},
+			version:        Version{Major: 10, Minor: 11, Patch: 6},
+			wantPrivileges: []string{"SELECT", "BINLOG MONITOR", "SLAVE MONITOR"},
+		},
+		{
+			name:           "legacy",
+			privileges:     []string{"SELECT", "binlog  monitor", "SLAVE MONITOR", "REPLICATION REPLICA"},
+			version:        Version{Major: 10, Minor: 4, Patch: 32},
+			wantPrivileges: []string{"SELECT", "REPLICATION CLIENT", "REPLICATION SLAVE"},
+		},
+		{
+			name:           "monitor privileges",
+			privileges:     []string{"REPLICATION CLIENT", "REPLICA MONITOR", "SLAVE MONITOR"},
+			version:        Version{Major: 10, Minor: 5, Patch: 9},
+			wantPrivileges: []string{"REPLICATION CLIENT", "REPLICA MONITOR", "SLAVE MONITOR"},
+		},
+		{
+			name:           "monitor privileges before 10.5.9",
+			privileges:     []string{"REPLICATION CLIENT", "REPLICA MONITOR", "SLAVE MONITOR"},
+			version:        Version{Major: 10, Minor: 5, Patch: 8},
+			wantPrivileges: []string{"REPLICATION CLIENT"},
+		},
+		{
+			name:           "column privileges",
+			privileges:     []string{"select (Name, `Last Name`)", "UPDATE(Email)", "insert"},
+			version:        Version{Major: 10, Minor: 11, Patch: 6},
+			wantPrivileges: []string{"SELECT (Name, `Last Name`)", "UPDATE (Email)", "INSERT"},
+		},
+		{
+			name:            "unsupported",
+			privileges:      []string{"SELECT", "CONNECTION ADMIN"},
+			version:         Version{Major: 10, Minor: 4, Patch: 32},
+			wantUnsupported: true,
+		},
+		{
+			name:            "drop unsupported",
+			privileges:      []string{"SELECT", "SHOW CREATE ROUTINE"},
+			version:         Version{Major: 11, Minor: 2, Patch: 2},
+			dropUnsupported: true,
+			wantPrivileges:  []string{"SELECT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privileges, err := AdaptPrivileges(tt.privileges, tt.version, tt.dropUnsupported)
+			if tt.wantUnsupported {
+				if !IsUnsupported(err) {
+					t.Fatalf("expected unsupported error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.wantPrivileges, privileges) {
+				t.Fatalf("unexpected privileges, expected: %v got: %v", tt.wantPrivileges, privileges)
+			}
+		})
+	}
+}
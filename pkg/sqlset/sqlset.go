@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
@@ -13,14 +14,17 @@ import (
 type ClientSet struct {
 	Mariadb       *mariadbv1alpha1.MariaDB
 	refResolver   *refresolver.RefResolver
+	clientOpts    []sql.Opt
 	clientByIndex map[int]*sql.Client
 	mux           *sync.Mutex
 }
 
-func NewClientSet(mariadb *mariadbv1alpha1.MariaDB, refResolver *refresolver.RefResolver) *ClientSet {
+// NewClientSet creates a ClientSet whose clients are created with the given options.
+func NewClientSet(mariadb *mariadbv1alpha1.MariaDB, refResolver *refresolver.RefResolver, clientOpts ...sql.Opt) *ClientSet {
 	return &ClientSet{
 		Mariadb:       mariadb,
 		refResolver:   refResolver,
+		clientOpts:    clientOpts,
 		clientByIndex: make(map[int]*sql.Client),
 		mux:           &sync.Mutex{},
 	}
@@ -42,7 +46,8 @@ func (c *ClientSet) ClientForIndex(ctx context.Context, index int, clientOpts ..
 	if c, ok := c.clientByIndex[index]; ok {
 		return c, nil
 	}
-	client, err := sql.NewInternalClientWithPodIndex(ctx, c.Mariadb, c.refResolver, index, clientOpts...)
+	opts := append(slices.Clip(c.clientOpts), clientOpts...)
+	client, err := sql.NewInternalClientWithPodIndex(ctx, c.Mariadb, c.refResolver, index, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating replica '%d' client: %v", index, err)
 	}
@@ -53,8 +58,8 @@ func (c *ClientSet) ClientForIndex(ctx context.Context, index int, clientOpts ..
 }
 
 func (c *ClientSet) validateIndex(index int) error {
-	if index >= 0 && index < int(c.Mariadb.Spec.Replicas) {
+	if index >= 0 && index < int(c.Mariadb.ScaledReplicas()) {
 		return nil
 	}
-	return fmt.Errorf("index '%d' out of MariaDB replicas bounds [0, %d]", index, c.Mariadb.Spec.Replicas-1)
+	return fmt.Errorf("index '%d' out of MariaDB replicas bounds [0, %d]", index, c.Mariadb.ScaledReplicas()-1)
 }
@@ -0,0 +1,285 @@
+package upgrade
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var imageVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// serverVariable is a server system variable that has been deprecated or removed.
+// The server refuses to start when a removed variable is set in the configuration file, unless the 'loose' prefix is used.
+type serverVariable struct {
+	name       string
+	deprecated *sqlClient.Version
+	removed    *sqlClient.Version
+}
+
+var serverVariables = []serverVariable{
+	{name: "innodb_file_format", removed: version(10, 3, 1)},
+	{name: "innodb_large_prefix", removed: version(10, 3, 1)},
+	{name: "innodb_undo_logs", deprecated: version(10, 5, 0), removed: version(10, 6, 0)},
+	{name: "innodb_buffer_pool_instances", deprecated: version(10, 5, 1), removed: version(10, 6, 0)},
+	{name: "innodb_page_cleaners", deprecated: version(10, 5, 1), removed: version(10, 6, 0)},
+	{name: "innodb_log_files_in_group", deprecated: version(10, 5, 2), removed: version(10, 6, 0)},
+	{name: "innodb_thread_concurrency", deprecated: version(10, 5, 5), removed: version(10, 6, 0)},
+	{name: "innodb_commit_concurrency", deprecated: version(10, 5, 5), removed: version(10, 6, 0)},
+	{name: "innodb_concurrency_tickets", deprecated: version(10, 5, 5), removed: version(10, 6, 0)},
+	{name: "innodb_replication_delay", deprecated: version(10, 5, 5), removed: version(10, 6, 0)},
+	{name: "innodb_thread_sleep_delay", deprecated: version(10, 5, 5), removed: version(10, 6, 0)},
+	{name: "innodb_adaptive_max_sleep_delay", deprecated: version(10, 5, 5), removed: version(10, 6, 0)},
+	{name: "innodb_locks_unsafe_for_binlog", removed: version(10, 6, 0)},
+	{name: "innodb_change_buffering", deprecated: version(10, 9, 0), removed: version(11, 0, 0)},
+	{name: "innodb_defragment", deprecated: version(11, 0, 1), removed: version(11, 1, 0)},
+}
+
+// serverPlugin is a plugin that has been removed from the server.
+type serverPlugin struct {
+	name    string
+	removed *sqlClient.Version
+}
+
+var serverPlugins = []serverPlugin{
+	{name: "tokudb", removed: version(10, 6, 0)},
+	{name: "cassandra", removed: version(10, 6, 0)},
+}
+
+// ImageVersion parses the MariaDB version from the tag of an image, i.e. 'mariadb:11.2.2' or 'mariadb:10.11-jammy'.
+func ImageVersion(image string) (*sqlClient.Version, error) {
+	name := image
+	if i := strings.Index(name, "@"); i != -1 {
+		name = name[:i]
+	}
+	i := strings.LastIndex(name, ":")
+	if i == -1 || strings.Contains(name[i:], "/") {
+		return nil, fmt.Errorf("image '%s' does not have a tag", image)
+	}
+	match := imageVersionRegex.FindStringSubmatch(name[i+1:])
+	if match == nil {
+		return nil, fmt.Errorf("unable to parse version from image '%s'", image)
+	}
+	var parts [3]int
+	for j := range parts {
+		if match[j+1] == "" {
+			continue
+		}
+		part, err := strconv.Atoi(match[j+1])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse version from image '%s': %v", image, err)
+		}
+		parts[j] = part
+	}
+	return version(parts[0], parts[1], parts[2]), nil
+}
+
+// CheckVersion checks that the server is reachable and that the target version is not a downgrade.
+// Downgrades between patch versions are allowed, as they keep the same on-disk format.
+func CheckVersion(current *sqlClient.Version, currentErr error, target *sqlClient.Version,
+	targetErr error) mariadbv1alpha1.UpgradePreflightCheck {
+	if currentErr != nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVersion, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Unable to get server version: %v", currentErr))
+	}
+	if targetErr != nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVersion, mariadbv1alpha1.UpgradePreflightSeverityWarning,
+			fmt.Sprintf("Unable to get target version, version dependent checks are skipped: %v", targetErr))
+	}
+	if !version(target.Major, target.Minor, 0).AtLeast(*version(current.Major, current.Minor, 0)) {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVersion, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Downgrading from %s to %s is not supported", current, target))
+	}
+	return passed(mariadbv1alpha1.UpgradePreflightCheckVersion, fmt.Sprintf("Upgrading from %s to %s", current, target))
+}
+
+// CheckVariables checks that the server sections of a my.cnf file do not contain variables removed or deprecated in the target version.
+func CheckVariables(myCnf string, myCnfErr error, target *sqlClient.Version) mariadbv1alpha1.UpgradePreflightCheck {
+	if myCnfErr != nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVariables, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Unable to get my.cnf: %v", myCnfErr))
+	}
+	if target == nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVariables, mariadbv1alpha1.UpgradePreflightSeverityWarning,
+			"Unable to check variables without the target version")
+	}
+	var removed, deprecated []string
+	for _, name := range serverVariableNames(myCnf) {
+		for _, v := range serverVariables {
+			if v.name != name {
+				continue
+			}
+			if v.removed != nil && target.AtLeast(*v.removed) {
+				removed = append(removed, fmt.Sprintf("'%s' was removed in %s", name, v.removed))
+			} else if v.deprecated != nil && target.AtLeast(*v.deprecated) {
+				deprecated = append(deprecated, fmt.Sprintf("'%s' was deprecated in %s", name, v.deprecated))
+			}
+		}
+	}
+	if len(removed) > 0 {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVariables, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Removed variables: %s", strings.Join(removed, ", ")))
+	}
+	if len(deprecated) > 0 {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckVariables, mariadbv1alpha1.UpgradePreflightSeverityWarning,
+			fmt.Sprintf("Deprecated variables: %s", strings.Join(deprecated, ", ")))
+	}
+	return passed(mariadbv1alpha1.UpgradePreflightCheckVariables, "No removed or deprecated variables found")
+}
+
+// CheckPlugins checks that none of the active plugins have been removed in the target version.
+func CheckPlugins(plugins []string, pluginsErr error, target *sqlClient.Version) mariadbv1alpha1.UpgradePreflightCheck {
+	if pluginsErr != nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckPlugins, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Unable to get active plugins: %v", pluginsErr))
+	}
+	if target == nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckPlugins, mariadbv1alpha1.UpgradePreflightSeverityWarning,
+			"Unable to check plugins without the target version")
+	}
+	var removed []string
+	for _, plugin := range plugins {
+		for _, p := range serverPlugins {
+			if strings.EqualFold(plugin, p.name) && target.AtLeast(*p.removed) {
+				removed = append(removed, fmt.Sprintf("'%s' was removed in %s", plugin, p.removed))
+			}
+		}
+	}
+	if len(removed) > 0 {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckPlugins, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Removed plugins: %s", strings.Join(removed, ", ")))
+	}
+	return passed(mariadbv1alpha1.UpgradePreflightCheckPlugins, "No removed plugins are active")
+}
+
+// CheckStorage checks that the free storage, estimated from the size of the tables, is above the minimum headroom.
+func CheckStorage(dataSize int64, dataSizeErr error, storageSize int64, minHeadroomPercent int32) mariadbv1alpha1.UpgradePreflightCheck {
+	if dataSizeErr != nil {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckStorage, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			fmt.Sprintf("Unable to get data size: %v", dataSizeErr))
+	}
+	if storageSize <= 0 {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckStorage, mariadbv1alpha1.UpgradePreflightSeverityWarning,
+			"Unable to check storage headroom without the storage size")
+	}
+	free := storageSize - dataSize
+	if free < 0 {
+		free = 0
+	}
+	freePercent := free * 100 / storageSize
+	msg := fmt.Sprintf(
+		"%s free out of %s (%d%%), at least %d%% is required",
+		resource.NewQuantity(free, resource.BinarySI),
+		resource.NewQuantity(storageSize, resource.BinarySI),
+		freePercent,
+		minHeadroomPercent,
+	)
+	if freePercent < int64(minHeadroomPercent) {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckStorage, mariadbv1alpha1.UpgradePreflightSeverityCritical, msg)
+	}
+	return passed(mariadbv1alpha1.UpgradePreflightCheckStorage, msg)
+}
+
+// CheckReplication checks that no replication or Galera issues have been found.
+func CheckReplication(issues []string) mariadbv1alpha1.UpgradePreflightCheck {
+	if len(issues) > 0 {
+		return failed(mariadbv1alpha1.UpgradePreflightCheckReplication, mariadbv1alpha1.UpgradePreflightSeverityCritical,
+			strings.Join(issues, "; "))
+	}
+	return passed(mariadbv1alpha1.UpgradePreflightCheckReplication, "All the replicas are healthy")
+}
+
+// IsBlocked indicates whether any of the checks blocks the upgrade.
+func IsBlocked(checks []mariadbv1alpha1.UpgradePreflightCheck) bool {
+	for _, c := range checks {
+		if c.IsBlocking() {
+			return true
+		}
+	}
+	return false
+}
+
+// BlockingChecks returns the names of the checks that block the upgrade.
+func BlockingChecks(checks []mariadbv1alpha1.UpgradePreflightCheck) []string {
+	var names []string
+	for _, c := range checks {
+		if c.IsBlocking() {
+			names = append(names, string(c.Name))
+		}
+	}
+	return names
+}
+
+// serverVariableNames returns the normalized names of the variables set in the server sections of a my.cnf file.
+// Variables with the 'loose' prefix are ignored, as the server starts even if they are unknown.
+func serverVariableNames(myCnf string) []string {
+	var names []string
+	isServerSection := false
+	scanner := bufio.NewScanner(strings.NewReader(myCnf))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			isServerSection = isServerCnfSection(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+		if !isServerSection {
+			continue
+		}
+		name, _, _ := strings.Cut(line, "=")
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+		if strings.HasPrefix(name, "loose_") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func isServerCnfSection(section string) bool {
+	section = strings.ToLower(section)
+	switch section {
+	case "mysqld", "mariadb", "mariadbd", "server", "galera":
+		return true
+	}
+	for _, prefix := range []string{"mysqld-", "mariadb-", "mariadbd-"} {
+		if strings.HasPrefix(section, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func passed(name mariadbv1alpha1.UpgradePreflightCheckName, msg string) mariadbv1alpha1.UpgradePreflightCheck {
+	return mariadbv1alpha1.UpgradePreflightCheck{
+		Name:     name,
+		Severity: mariadbv1alpha1.UpgradePreflightSeverityCritical,
+		Passed:   true,
+		Message:  msg,
+	}
+}
+
+func failed(name mariadbv1alpha1.UpgradePreflightCheckName, severity mariadbv1alpha1.UpgradePreflightSeverity,
+	msg string) mariadbv1alpha1.UpgradePreflightCheck {
+	return mariadbv1alpha1.UpgradePreflightCheck{
+		Name:     name,
+		Severity: severity,
+		Passed:   false,
+		Message:  msg,
+	}
+}
+
+func version(major, minor, patch int) *sqlClient.Version {
+	return &sqlClient.Version{
+		Major: major,
+		Minor: minor,
+		Patch: patch,
+	}
+}
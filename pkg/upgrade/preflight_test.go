@@ -0,0 +1,342 @@
+package upgrade
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	sqlClient "github.com/mariadb-operator/mariadb-operator/pkg/sql"
+)
+
+func TestImageVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       string
+		wantVersion *sqlClient.Version
+		wantErr     bool
+	}{
+		{
+			name:        "full version",
+			image:       "mariadb:11.2.2",
+			wantVersion: &sqlClient.Version{Major: 11, Minor: 2, Patch: 2},
+		},
+		{
+			name:        "minor version with suffix",
+			image:       "mariadb:10.11-jammy",
+			wantVersion: &sqlClient.Version{Major: 10, Minor: 11},
+		},
+		{
+			name:        "registry with port and digest",
+			image:       "registry.local:5000/library/mariadb:10.6.16@sha256:abcdef",
+			wantVersion: &sqlClient.Version{Major: 10, Minor: 6, Patch: 16},
+		},
+		{
+			name:    "registry with port without tag",
+			image:   "registry.local:5000/library/mariadb",
+			wantErr: true,
+		},
+		{
+			name:    "no tag",
+			image:   "mariadb",
+			wantErr: true,
+		},
+		{
+			name:    "non version tag",
+			image:   "mariadb:latest",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ImageVersion(tt.image)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.wantVersion, version) {
+				t.Fatalf("unexpected version, expected: %v got: %v", tt.wantVersion, version)
+			}
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      *sqlClient.Version
+		currentErr   error
+		target       *sqlClient.Version
+		targetErr    error
+		wantPassed   bool
+		wantBlocking bool
+	}{
+		{
+			name:       "upgrade",
+			current:    version(10, 6, 16),
+			target:     version(10, 11, 6),
+			wantPassed: true,
+		},
+		{
+			name:       "patch downgrade",
+			current:    version(10, 11, 6),
+			target:     version(10, 11, 5),
+			wantPassed: true,
+		},
+		{
+			name:         "minor downgrade",
+			current:      version(11, 2, 2),
+			target:       version(10, 11, 6),
+			wantBlocking: true,
+		},
+		{
+			name:         "server unreachable",
+			currentErr:   errors.New("connection refused"),
+			target:       version(10, 11, 6),
+			wantBlocking: true,
+		},
+		{
+			name:      "unknown target",
+			current:   version(10, 6, 16),
+			targetErr: errors.New("unable to parse version"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckVersion(tt.current, tt.currentErr, tt.target, tt.targetErr)
+			assertCheck(t, check, tt.wantPassed, tt.wantBlocking)
+		})
+	}
+}
+
+func TestCheckVariables(t *testing.T) {
+	tests := []struct {
+		name         string
+		myCnf        string
+		myCnfErr     error
+		target       *sqlClient.Version
+		wantPassed   bool
+		wantBlocking bool
+	}{
+		{
+			name: "no deprecated variables",
+			myCnf: `[mariadb]
+bind-address=*
+innodb_buffer_pool_size=1G`,
+			target:     version(11, 2, 2),
+			wantPassed: true,
+		},
+		{
+			name: "removed variable",
+			myCnf: `[mariadb]
+innodb-buffer-pool-instances = 4`,
+			target:       version(10, 11, 6),
+			wantBlocking: true,
+		},
+		{
+			name: "deprecated variable",
+			myCnf: `[mysqld]
+innodb_change_buffering=none`,
+			target: version(10, 11, 6),
+		},
+		{
+			name: "removed variable in older target",
+			myCnf: `[mysqld]
+innodb_change_buffering=none`,
+			target:     version(10, 6, 16),
+			wantPassed: true,
+		},
+		{
+			name: "loose prefix",
+			myCnf: `[mariadb]
+loose-innodb_file_format=Barracuda`,
+			target:     version(10, 11, 6),
+			wantPassed: true,
+		},
+		{
+			name: "client section",
+			myCnf: `[client]
+innodb_file_format=Barracuda
+
+[mariadb-10.11]
+bind-address=*`,
+			target:     version(10, 11, 6),
+			wantPassed: true,
+		},
+		{
+			name: "versioned server section",
+			myCnf: `[mariadb-10.11]
+innodb_thread_concurrency=8`,
+			target:       version(10, 11, 6),
+			wantBlocking: true,
+		},
+		{
+			name: "unknown target",
+			myCnf: `[mariadb]
+innodb_file_format=Barracuda`,
+		},
+		{
+			name:         "error",
+			myCnfErr:     errors.New("ConfigMap not found"),
+			target:       version(10, 11, 6),
+			wantBlocking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckVariables(tt.myCnf, tt.myCnfErr, tt.target)
+			assertCheck(t, check, tt.wantPassed, tt.wantBlocking)
+		})
+	}
+}
+
+func TestCheckPlugins(t *testing.T) {
+	tests := []struct {
+		name         string
+		plugins      []string
+		pluginsErr   error
+		target       *sqlClient.Version
+		wantPassed   bool
+		wantBlocking bool
+	}{
+		{
+			name:       "builtin plugins",
+			plugins:    []string{"InnoDB", "Aria", "mysql_native_password"},
+			target:     version(11, 2, 2),
+			wantPassed: true,
+		},
+		{
+			name:         "removed plugin",
+			plugins:      []string{"InnoDB", "TokuDB"},
+			target:       version(10, 6, 16),
+			wantBlocking: true,
+		},
+		{
+			name:       "removed plugin in older target",
+			plugins:    []string{"InnoDB", "TokuDB"},
+			target:     version(10, 5, 23),
+			wantPassed: true,
+		},
+		{
+			name:         "error",
+			pluginsErr:   errors.New("connection refused"),
+			target:       version(10, 6, 16),
+			wantBlocking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckPlugins(tt.plugins, tt.pluginsErr, tt.target)
+			assertCheck(t, check, tt.wantPassed, tt.wantBlocking)
+		})
+	}
+}
+
+func TestCheckStorage(t *testing.T) {
+	gib := int64(1024 * 1024 * 1024)
+	tests := []struct {
+		name         string
+		dataSize     int64
+		dataSizeErr  error
+		storageSize  int64
+		minPercent   int32
+		wantPassed   bool
+		wantBlocking bool
+	}{
+		{
+			name:        "enough headroom",
+			dataSize:    5 * gib,
+			storageSize: 10 * gib,
+			minPercent:  20,
+			wantPassed:  true,
+		},
+		{
+			name:         "not enough headroom",
+			dataSize:     9 * gib,
+			storageSize:  10 * gib,
+			minPercent:   20,
+			wantBlocking: true,
+		},
+		{
+			name:        "data bigger than storage",
+			dataSize:    11 * gib,
+			storageSize: 10 * gib,
+			minPercent:  0,
+			wantPassed:  true,
+		},
+		{
+			name:     "unknown storage size",
+			dataSize: 5 * gib,
+		},
+		{
+			name:         "error",
+			dataSizeErr:  errors.New("connection refused"),
+			storageSize:  10 * gib,
+			minPercent:   20,
+			wantBlocking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckStorage(tt.dataSize, tt.dataSizeErr, tt.storageSize, tt.minPercent)
+			assertCheck(t, check, tt.wantPassed, tt.wantBlocking)
+		})
+	}
+}
+
+func TestIsBlocked(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []mariadbv1alpha1.UpgradePreflightCheck
+		want   bool
+	}{
+		{
+			name: "passed",
+			checks: []mariadbv1alpha1.UpgradePreflightCheck{
+				CheckReplication(nil),
+			},
+			want: false,
+		},
+		{
+			name: "warning",
+			checks: []mariadbv1alpha1.UpgradePreflightCheck{
+				CheckReplication(nil),
+				CheckVariables("", nil, nil),
+			},
+			want: false,
+		},
+		{
+			name: "critical",
+			checks: []mariadbv1alpha1.UpgradePreflightCheck{
+				CheckReplication([]string{"Pod 'mariadb-1' replication is not running"}),
+				CheckVariables("", nil, nil),
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBlocked(tt.checks); got != tt.want {
+				t.Fatalf("unexpected blocked, expected: %v got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func assertCheck(t *testing.T, check mariadbv1alpha1.UpgradePreflightCheck, wantPassed, wantBlocking bool) {
+	t.Helper()
+	if check.Passed != wantPassed {
+		t.Fatalf("unexpected passed, expected: %v got: %v (%s)", wantPassed, check.Passed, check.Message)
+	}
+	if check.IsBlocking() != wantBlocking {
+		t.Fatalf("unexpected blocking, expected: %v got: %v (%s)", wantBlocking, check.IsBlocking(), check.Message)
+	}
+}
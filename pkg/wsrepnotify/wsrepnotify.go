@@ -0,0 +1,91 @@
+package wsrepnotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StateWriter publishes the Galera node state, written into a file by wsrep_notify_cmd, in the annotations of the Pod.
+type StateWriter struct {
+	client    client.Client
+	stateFile string
+	podKey    types.NamespacedName
+	logger    logr.Logger
+	lastState string
+}
+
+func NewStateWriter(client client.Client, stateFile string, podKey types.NamespacedName, logger logr.Logger) *StateWriter {
+	return &StateWriter{
+		client:    client,
+		stateFile: stateFile,
+		podKey:    podKey,
+		logger:    logger,
+	}
+}
+
+// Run syncs the state periodically until the context is cancelled.
+func (w *StateWriter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Sync(ctx); err != nil {
+			w.logger.Error(err, "error syncing Galera state")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync reads the state file and patches the Pod annotation whenever the state has changed since the last sync.
+func (w *StateWriter) Sync(ctx context.Context) error {
+	state, err := ReadState(w.stateFile)
+	if err != nil {
+		return err
+	}
+	if state == "" || state == w.lastState {
+		return nil
+	}
+
+	var pod corev1.Pod
+	if err := w.client.Get(ctx, w.podKey, &pod); err != nil {
+		return fmt.Errorf("error getting Pod: %v", err)
+	}
+	if pod.Annotations[metadata.GaleraStateAnnotation] != state {
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[metadata.GaleraStateAnnotation] = state
+		if err := w.client.Patch(ctx, &pod, patch); err != nil {
+			return fmt.Errorf("error patching Pod: %v", err)
+		}
+		w.logger.Info("Galera state changed", "state", state)
+	}
+	w.lastState = state
+	return nil
+}
+
+// ReadState reads the state written by wsrep_notify_cmd. An empty state is returned if the file has not been written yet.
+func ReadState(stateFile string) (string, error) {
+	bytes, err := os.ReadFile(stateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error reading state file: %v", err)
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
@@ -0,0 +1,83 @@
+package wsrepnotify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/mariadb-operator/mariadb-operator/pkg/metadata"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "wsrep-notify.state")
+
+	state, err := ReadState(stateFile)
+	if err != nil {
+		t.Fatalf("unexpected error reading missing state file: %v", err)
+	}
+	if state != "" {
+		t.Fatalf("expected empty state, got: %s", state)
+	}
+
+	if err := os.WriteFile(stateFile, []byte("Synced\n"), 0644); err != nil {
+		t.Fatalf("unexpected error writing state file: %v", err)
+	}
+	state, err = ReadState(stateFile)
+	if err != nil {
+		t.Fatalf("unexpected error reading state file: %v", err)
+	}
+	if state != "Synced" {
+		t.Fatalf("unexpected state, expected: Synced got: %s", state)
+	}
+}
+
+func TestStateWriterSync(t *testing.T) {
+	ctx := context.Background()
+	stateFile := filepath.Join(t.TempDir(), "wsrep-notify.state")
+	podKey := types.NamespacedName{
+		Name:      "mariadb-galera-0",
+		Namespace: "default",
+	}
+	client := fake.NewClientBuilder().
+		WithObjects(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podKey.Name,
+				Namespace: podKey.Namespace,
+			},
+		}).
+		Build()
+	writer := NewStateWriter(client, stateFile, podKey, logr.Discard())
+
+	podState := func() string {
+		var pod corev1.Pod
+		if err := client.Get(ctx, podKey, &pod); err != nil {
+			t.Fatalf("unexpected error getting Pod: %v", err)
+		}
+		return pod.Annotations[metadata.GaleraStateAnnotation]
+	}
+
+	if err := writer.Sync(ctx); err != nil {
+		t.Fatalf("unexpected error syncing missing state: %v", err)
+	}
+	if state := podState(); state != "" {
+		t.Fatalf("expected no state annotation, got: %s", state)
+	}
+
+	for _, state := range []string{"Joined", "Synced", "Donor"} {
+		if err := os.WriteFile(stateFile, []byte(state+"\n"), 0644); err != nil {
+			t.Fatalf("unexpected error writing state file: %v", err)
+		}
+		if err := writer.Sync(ctx); err != nil {
+			t.Fatalf("unexpected error syncing state: %v", err)
+		}
+		if got := podState(); got != state {
+			t.Fatalf("unexpected state annotation, expected: %s got: %s", state, got)
+		}
+	}
+}